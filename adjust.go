@@ -13,8 +13,13 @@ package excelize
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -25,16 +30,49 @@ const (
 	rows    adjustDirection = true
 )
 
+var (
+	// refPartRegexp matches the part of the cell or range reference, which
+	// could be a cell reference, a column name or a row number.
+	refPartRegexp = regexp.MustCompile(`^(\$?)([A-Za-z]{0,3})(\$?)(\d*)$`)
+	// chartFormulaRegexp matches the formula element in the chart part.
+	chartFormulaRegexp = regexp.MustCompile(`(<(?:\w+:)?f>)([^<]*)(</(?:\w+:)?f>)`)
+	// chartDataRefRegexp matches the series name, categories and values
+	// elements, and the title text element in the chart part which refer to
+	// the cells by the formula.
+	chartDataRefRegexp = regexp.MustCompile(`(?s)(<(?:\w+:)?title>\s*)?<((?:\w+:)?)(tx|cat|val|xVal|yVal|bubbleSize)>\s*<(?:\w+:)?(num|str)Ref>\s*<(?:\w+:)?f>([^<]*)</(?:\w+:)?f>(.*?)</(?:\w+:)?(?:num|str)Ref>\s*</(?:\w+:)?(?:tx|cat|val|xVal|yVal|bubbleSize)>`)
+	// chartDataCacheRegexp matches the cached data element in the numeric or
+	// string reference element of the chart part.
+	chartDataCacheRegexp = regexp.MustCompile(`(?s)<(?:\w+:)?(?:num|str)Cache>(.*?)</(?:\w+:)?(?:num|str)Cache>`)
+	// chartDataValueRegexp matches the value element of the cached data point
+	// in the chart part.
+	chartDataValueRegexp = regexp.MustCompile(`<(?:\w+:)?v>([^<]*)</(?:\w+:)?v>`)
+	// dataValidationFormulaRegexp matches the formula elements in the inner
+	// XML of the data validation.
+	dataValidationFormulaRegexp = regexp.MustCompile(`(<formula[12]>)([^<]*)(</formula[12]>)`)
+	// vmlAnchorRegexp matches the anchor element in the inner XML of the VML
+	// shape client data.
+	vmlAnchorRegexp = regexp.MustCompile(`(<x:Anchor>)([^<]*)(</x:Anchor>)`)
+	// vmlRowRegexp matches the row element in the inner XML of the VML shape
+	// client data.
+	vmlRowRegexp = regexp.MustCompile(`(<x:Row>)\s*(\d+)\s*(</x:Row>)`)
+	// vmlColumnRegexp matches the column element in the inner XML of the VML
+	// shape client data.
+	vmlColumnRegexp = regexp.MustCompile(`(<x:Column>)\s*(\d+)\s*(</x:Column>)`)
+	// xmlUnescaper unescapes the predefined entities in XML character data.
+	xmlUnescaper = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&quot;", "\"", "&apos;", "'", "&#34;", "\"", "&#39;", "'", "&amp;", "&")
+)
+
 // adjustHelper provides a function to adjust rows and columns dimensions,
-// hyperlinks, merged cells and auto filter when inserting or deleting rows or
-// columns.
+// hyperlinks, merged cells, auto filter, page breaks, and the references in
+// formulas, defined names, data validations and chart series when inserting
+// or deleting rows or columns.
 //
 // sheet: Worksheet name that we're editing
 // column: Index number of the column we're inserting/deleting before
 // row: Index number of the row we're inserting/deleting before
 // offset: Number of rows/column to insert/delete negative values indicate deletion
 //
-// TODO: adjustPageBreaks, adjustComments, adjustDataValidations, adjustProtectedCells
+// TODO: adjustProtectedCells
 func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -51,6 +89,7 @@ func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int)
 	}
 	f.adjustHyperlinks(ws, sheet, dir, num, offset)
 	f.adjustTable(ws, sheet, dir, num, offset)
+	ws.adjustPageBreaks(dir, num, offset)
 	if err = f.adjustMergeCells(ws, dir, num, offset); err != nil {
 		return err
 	}
@@ -60,6 +99,12 @@ func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int)
 	if err = f.adjustCalcChain(dir, num, offset, sheetID); err != nil {
 		return err
 	}
+	if err = f.adjustFormulaReferences(ws, sheet, dir, num, offset); err != nil {
+		return err
+	}
+	if err = f.adjustComments(ws, sheet, dir, num, offset); err != nil {
+		return err
+	}
 	ws.checkSheet()
 	_ = ws.checkRow()
 
@@ -70,6 +115,591 @@ func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int)
 	return nil
 }
 
+// adjustComments provides a function to update the cell references of the
+// comments and the anchors of the comment shapes in the VML drawing when
+// inserting or deleting rows or columns. The comments on the deleted cells
+// will be removed.
+func (f *File) adjustComments(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset int) error {
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return nil
+	}
+	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
+	if commentsXML == "" {
+		return nil
+	}
+	if !strings.HasPrefix(commentsXML, "/") {
+		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
+	}
+	commentsXML = strings.TrimPrefix(commentsXML, "/")
+	cmts, err := f.commentsReader(commentsXML)
+	if err != nil || cmts == nil {
+		return err
+	}
+	deleted := func(idx int) bool { return offset < 0 && idx >= num && idx < num-offset }
+	comments := cmts.CommentList.Comment[:0]
+	for _, cmt := range cmts.CommentList.Comment {
+		col, row, err := CellNameToCoordinates(cmt.Ref)
+		if err != nil {
+			return err
+		}
+		idx := &col
+		if dir == rows {
+			idx = &row
+		}
+		if deleted(*idx) {
+			continue
+		}
+		if *idx >= num {
+			*idx += offset
+		}
+		if cmt.Ref, err = CoordinatesToCellName(col, row); err != nil {
+			return err
+		}
+		comments = append(comments, cmt)
+	}
+	cmts.CommentList.Comment = comments
+	f.Comments[commentsXML] = cmts
+	if ws.LegacyDrawing == nil {
+		return err
+	}
+	drawingVML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID), "..", "xl")
+	vml, err := f.getVMLDrawing(drawingVML, 0)
+	if err != nil {
+		return err
+	}
+	shapes := vml.Shape[:0]
+	for _, sp := range vml.Shape {
+		var shapeVal decodeShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err != nil ||
+			shapeVal.ClientData.ObjectType != "Note" || shapeVal.ClientData.Row == nil || shapeVal.ClientData.Column == nil {
+			shapes = append(shapes, sp)
+			continue
+		}
+		idx := *shapeVal.ClientData.Column + 1
+		if dir == rows {
+			idx = *shapeVal.ClientData.Row + 1
+		}
+		if deleted(idx) {
+			continue
+		}
+		sp.Val = adjustVMLShapeVal(sp.Val, dir, num, offset)
+		shapes = append(shapes, sp)
+	}
+	vml.Shape = shapes
+	f.VMLDrawing[drawingVML] = vml
+	return nil
+}
+
+// adjustVMLShapeVal provides a function to update the row or column of the
+// anchor cell and the top-left and bottom-right position of the anchor in the
+// inner XML of the VML shape by given adjust direction, row or column number
+// and offset. The zero-based indexes are used in the VML shape.
+func adjustVMLShapeVal(val string, dir adjustDirection, num, offset int) string {
+	adjustIdx := func(idx int) int {
+		if idx+1 >= num {
+			if idx += offset; idx < num-1 {
+				idx = num - 1
+			}
+		}
+		return idx
+	}
+	elemRegexp, anchorPos := vmlColumnRegexp, []int{0, 4}
+	if dir == rows {
+		elemRegexp, anchorPos = vmlRowRegexp, []int{2, 6}
+	}
+	val = elemRegexp.ReplaceAllStringFunc(val, func(match string) string {
+		sub := elemRegexp.FindStringSubmatch(match)
+		idx, _ := strconv.Atoi(sub[2])
+		return sub[1] + strconv.Itoa(adjustIdx(idx)) + sub[3]
+	})
+	return vmlAnchorRegexp.ReplaceAllStringFunc(val, func(match string) string {
+		sub := vmlAnchorRegexp.FindStringSubmatch(match)
+		pos := strings.Split(sub[2], ",")
+		if len(pos) != 8 {
+			return match
+		}
+		for _, i := range anchorPos {
+			if idx, err := strconv.Atoi(strings.TrimSpace(pos[i])); err == nil {
+				pos[i] = strconv.Itoa(adjustIdx(idx))
+			}
+		}
+		for i := range pos {
+			pos[i] = strings.TrimSpace(pos[i])
+		}
+		return sub[1] + strings.Join(pos, ", ") + sub[3]
+	})
+}
+
+// adjustPageBreaks provides a function to update the row or column page breaks
+// when inserting or deleting rows or columns. The page break moves with the
+// first row or column of the next printed page, and will be removed if the
+// page break is out of the worksheet or duplicated.
+func (ws *xlsxWorksheet) adjustPageBreaks(dir adjustDirection, num, offset int) {
+	brks, limit := (*xlsxBreaks)(nil), TotalRows
+	if dir == rows && ws.RowBreaks != nil {
+		brks = &ws.RowBreaks.xlsxBreaks
+	}
+	if dir == columns && ws.ColBreaks != nil {
+		brks, limit = &ws.ColBreaks.xlsxBreaks, MaxColumns
+	}
+	if brks == nil {
+		return
+	}
+	var (
+		result []*xlsxBrk
+		exists = map[int]bool{}
+	)
+	brks.ManualBreakCount = 0
+	for _, brk := range brks.Brk {
+		if brk.ID+1 > num || (offset > 0 && brk.ID+1 == num) {
+			brk.ID += offset
+		}
+		if brk.ID <= 0 || brk.ID >= limit || exists[brk.ID] {
+			continue
+		}
+		exists[brk.ID] = true
+		if brk.Man {
+			brks.ManualBreakCount++
+		}
+		result = append(result, brk)
+	}
+	brks.Brk, brks.Count = result, len(result)
+}
+
+// adjustFormulaReferences provides a function to update the references in the
+// formulas of all worksheets, defined names, data validations and chart
+// series when inserting or deleting rows or columns.
+func (f *File) adjustFormulaReferences(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset int) error {
+	for _, name := range f.GetSheetList() {
+		sheetXML := ws
+		if name != sheet {
+			if !f.hasFormulaContent(name) {
+				continue
+			}
+			var err error
+			if sheetXML, err = f.workSheetReader(name); err != nil {
+				if errors.As(err, &ErrNotWorksheet{}) {
+					continue
+				}
+				return err
+			}
+		}
+		for rowIdx := range sheetXML.SheetData.Row {
+			for colIdx := range sheetXML.SheetData.Row[rowIdx].C {
+				if c := &sheetXML.SheetData.Row[rowIdx].C[colIdx]; c.F != nil && c.F.Content != "" {
+					c.F.Content = adjustFormulaRef(name, sheet, c.F.Content, dir, num, offset)
+				}
+			}
+		}
+		if sheetXML.DataValidations != nil {
+			for _, dv := range sheetXML.DataValidations.DataValidation {
+				dv.Formula1 = adjustDataValidationFormula(name, sheet, dv.Formula1, dir, num, offset)
+				dv.Formula2 = adjustDataValidationFormula(name, sheet, dv.Formula2, dir, num, offset)
+			}
+		}
+		for _, cf := range sheetXML.ConditionalFormatting {
+			for _, rule := range cf.CfRule {
+				for i := range rule.Formula {
+					rule.Formula[i] = adjustFormulaRef(name, sheet, rule.Formula[i], dir, num, offset)
+				}
+			}
+		}
+	}
+	f.adjustDataValidations(ws, dir, num, offset)
+	f.adjustConditionalFormats(ws, dir, num, offset)
+	if err := f.adjustDefinedNames(sheet, dir, num, offset); err != nil {
+		return err
+	}
+	f.adjustChartSeries(sheet, dir, num, offset)
+	return nil
+}
+
+// hasFormulaContent provides a function to check if the worksheet which has
+// not been parsed may contain formulas by given worksheet name.
+func (f *File) hasFormulaContent(sheet string) bool {
+	name, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return false
+	}
+	if _, ok = f.Sheet.Load(name); ok {
+		return true
+	}
+	content := f.readBytes(name)
+	return bytes.Contains(content, []byte("<f")) || bytes.Contains(content, []byte("<dataValidation"))
+}
+
+// adjustDataValidations provides a function to update the range references of
+// the data validations when inserting or deleting rows or columns, the data
+// validation will be deleted if all of the cells it applies to are deleted.
+func (f *File) adjustDataValidations(ws *xlsxWorksheet, dir adjustDirection, num, offset int) {
+	if ws.DataValidations == nil {
+		return
+	}
+	var dataValidations []*DataValidation
+	for _, dv := range ws.DataValidations.DataValidation {
+		if dv.Sqref = adjustSqref(dv.Sqref, dir, num, offset); dv.Sqref != "" {
+			dataValidations = append(dataValidations, dv)
+		}
+	}
+	if ws.DataValidations.DataValidation = dataValidations; len(dataValidations) == 0 {
+		ws.DataValidations = nil
+		return
+	}
+	ws.DataValidations.Count = len(dataValidations)
+}
+
+// adjustConditionalFormats provides a function to update the range
+// references of the conditional formats when inserting or deleting rows or
+// columns, the conditional format will be deleted if all of the cells it
+// applies to are deleted.
+func (f *File) adjustConditionalFormats(ws *xlsxWorksheet, dir adjustDirection, num, offset int) {
+	var conditionalFormats []*xlsxConditionalFormatting
+	for _, cf := range ws.ConditionalFormatting {
+		if cf.SQRef = adjustSqref(cf.SQRef, dir, num, offset); cf.SQRef != "" {
+			conditionalFormats = append(conditionalFormats, cf)
+		}
+	}
+	ws.ConditionalFormatting = conditionalFormats
+}
+
+// adjustDefinedNames provides a function to update the references of the
+// defined names which refer to the worksheet when inserting or deleting rows
+// or columns.
+func (f *File) adjustDefinedNames(sheet string, dir adjustDirection, num, offset int) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames != nil {
+		for i := range wb.DefinedNames.DefinedName {
+			definedName := &wb.DefinedNames.DefinedName[i]
+			definedName.Data = adjustFormulaRef("", sheet, definedName.Data, dir, num, offset)
+		}
+	}
+	return nil
+}
+
+// adjustChartSeries provides a function to update the references of the chart
+// series which refer to the worksheet when inserting or deleting rows or
+// columns.
+func (f *File) adjustChartSeries(sheet string, dir adjustDirection, num, offset int) {
+	f.Pkg.Range(func(k, v interface{}) bool {
+		name := k.(string)
+		if !strings.HasPrefix(name, "xl/charts/chart") || !strings.HasSuffix(name, ".xml") {
+			return true
+		}
+		var changed bool
+		content := chartFormulaRegexp.ReplaceAllFunc(v.([]byte), func(match []byte) []byte {
+			sub := chartFormulaRegexp.FindSubmatch(match)
+			formula := xmlUnescaper.Replace(string(sub[2]))
+			ref := adjustFormulaRef("", sheet, formula, dir, num, offset)
+			if ref == formula {
+				return match
+			}
+			changed = true
+			var buf bytes.Buffer
+			_ = xml.EscapeText(&buf, []byte(ref))
+			return []byte(string(sub[1]) + buf.String() + string(sub[3]))
+		})
+		if changed {
+			f.Pkg.Store(name, content)
+		}
+		return true
+	})
+}
+
+// adjustDataValidationFormula provides a function to update the references in
+// the inner XML of the data validation formulas when inserting or deleting
+// rows or columns.
+func adjustDataValidationFormula(formulaSheet, sheet, innerXML string, dir adjustDirection, num, offset int) string {
+	return dataValidationFormulaRegexp.ReplaceAllStringFunc(innerXML, func(match string) string {
+		sub := dataValidationFormulaRegexp.FindStringSubmatch(match)
+		formula := xmlUnescaper.Replace(sub[2])
+		ref := adjustFormulaRef(formulaSheet, sheet, formula, dir, num, offset)
+		if ref == formula {
+			return match
+		}
+		var buf bytes.Buffer
+		_ = xml.EscapeText(&buf, []byte(ref))
+		return sub[1] + buf.String() + sub[3]
+	})
+}
+
+// adjustSqref provides a function to update the space-separated list of range
+// references when inserting or deleting rows or columns, the deleted ranges
+// will be removed from the list.
+func adjustSqref(sqref string, dir adjustDirection, num, offset int) string {
+	var refs []string
+	for _, ref := range strings.Fields(sqref) {
+		if ref = adjustRef(ref, dir, num, offset); ref != formulaErrorREF {
+			refs = append(refs, ref)
+		}
+	}
+	return strings.Join(refs, " ")
+}
+
+// adjustFormulaRef provides a function to update the references in the formula
+// by given worksheet name where the formula is located, the worksheet name of
+// inserting or deleting rows or columns. The references without worksheet
+// name will be updated only if the formula is located in the worksheet.
+func adjustFormulaRef(formulaSheet, sheet, formula string, dir adjustDirection, num, offset int) string {
+	return traverseFormulaRefs(formula, func(refSheet, ref string) string {
+		if refSheet == "" && !strings.EqualFold(formulaSheet, sheet) ||
+			refSheet != "" && !strings.EqualFold(refSheet, sheet) {
+			return ref
+		}
+		return adjustRef(ref, dir, num, offset)
+	})
+}
+
+// translateFormula provides a function to translate the relative cell and
+// range references in the formula by given column and row offset, which used
+// on copying the formula to another cell. The absolute references with the
+// dollar sign ($) will be kept, and the references which out of the worksheet
+// will be replaced with the #REF! error.
+func translateFormula(formula string, colOffset, rowOffset int) string {
+	return traverseFormulaRefs(formula, func(_, ref string) string {
+		refParts, ok := parseRef(ref)
+		if !ok {
+			return ref
+		}
+		for i := range refParts {
+			p := &refParts[i]
+			if p.colNum > 0 && p.colAbs == "" {
+				if p.colNum += colOffset; p.colNum < 1 || p.colNum > MaxColumns {
+					return formulaErrorREF
+				}
+			}
+			if p.rowNum > 0 && p.rowAbs == "" {
+				if p.rowNum += rowOffset; p.rowNum < 1 || p.rowNum > TotalRows {
+					return formulaErrorREF
+				}
+			}
+		}
+		return joinRefParts(refParts)
+	})
+}
+
+// transposeFormula provides a function to translate the references in the
+// formula which copied from the source range to the transposed destination
+// range by given source worksheet name, sorted coordinates of the source
+// range, the top-left cell coordinates of the destination range, and the
+// column and row offset of the formula cell. The relative references to the
+// cells inside the source range will be transposed, and the other references
+// will be translated by the offset.
+func transposeFormula(formula, sheet string, rect []int, dstCol, dstRow, colOffset, rowOffset int) string {
+	return traverseFormulaRefs(formula, func(refSheet, ref string) string {
+		refParts, ok := parseRef(ref)
+		if !ok {
+			return ref
+		}
+		inside := refSheet == "" || strings.EqualFold(refSheet, sheet)
+		for _, p := range refParts {
+			inside = inside && p.colAbs == "" && p.rowAbs == "" && p.colNum > 0 && p.rowNum > 0 &&
+				cellInRange([]int{p.colNum, p.rowNum}, rect)
+		}
+		if !inside {
+			return translateFormula(ref, colOffset, rowOffset)
+		}
+		for i := range refParts {
+			p := &refParts[i]
+			p.colNum, p.rowNum = dstCol+p.rowNum-rect[1], dstRow+p.colNum-rect[0]
+		}
+		return joinRefParts(refParts)
+	})
+}
+
+// joinRefParts returns the cell or range reference by given parts of the
+// reference.
+func joinRefParts(refParts []refPart) string {
+	if len(refParts) == 1 {
+		return refParts[0].String()
+	}
+	return refParts[0].String() + ":" + refParts[1].String()
+}
+
+// traverseFormulaRefs provides a function to traverse the cell and range
+// references in the formula by given callback function, which receives the
+// unquoted worksheet name and the reference without the worksheet name, and
+// returns the new reference. String literals, function names, error values,
+// external and structured references will be kept as is.
+func traverseFormulaRefs(formula string, fn func(sheet, ref string) string) string {
+	var (
+		buf                strings.Builder
+		last, start, depth int
+		valid              = true
+	)
+	replace := func(end int) {
+		if !valid || start >= end || (end < len(formula) && formula[end] == '(') {
+			return
+		}
+		word, sheet := formula[start:end], ""
+		if idx := strings.LastIndex(word, "!"); idx != -1 {
+			if sheet, word = word[:idx], word[idx+1:]; len(sheet) > 1 && sheet[0] == '\'' && sheet[len(sheet)-1] == '\'' {
+				sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+			}
+			if sheet == "" || strings.ContainsAny(sheet, "[]!") {
+				return
+			}
+		}
+		if ref := fn(sheet, word); ref != word {
+			buf.WriteString(formula[last : end-len(word)])
+			buf.WriteString(ref)
+			last = end
+		}
+	}
+	closeQuote := func(start int, quote byte) int {
+		for i := start; i < len(formula); i++ {
+			if formula[i] == quote {
+				if i+1 < len(formula) && formula[i+1] == quote {
+					i++
+					continue
+				}
+				return i
+			}
+		}
+		return len(formula)
+	}
+	for i := 0; i < len(formula); i++ {
+		switch c := formula[i]; {
+		case depth > 0:
+			if c == '[' {
+				depth++
+			} else if c == ']' {
+				depth--
+			}
+		case c == '"':
+			i = closeQuote(i+1, '"')
+			start, valid = i+1, true
+		case c == '\'':
+			i = closeQuote(i+1, '\'')
+		case c == '[':
+			depth, valid = depth+1, false
+		case c == '#':
+			valid = false
+		case strings.IndexByte(" ,;()+-*/^&=<>{}%\r\n\t", c) != -1:
+			replace(i)
+			start, valid = i+1, true
+		}
+	}
+	replace(len(formula))
+	buf.WriteString(formula[last:])
+	return buf.String()
+}
+
+// refPart directly maps the part of the cell or range reference, which could
+// be a cell reference, a column name or a row number.
+type refPart struct {
+	colAbs, rowAbs string
+	colNum, rowNum int
+}
+
+// String returns the reference by the part of the reference.
+func (p refPart) String() string {
+	var col, row string
+	if p.colNum > 0 {
+		col, _ = ColumnNumberToName(p.colNum)
+	}
+	if p.rowNum > 0 {
+		row = strconv.Itoa(p.rowNum)
+	}
+	return p.colAbs + col + p.rowAbs + row
+}
+
+// num returns the row number or column number of the reference part by given
+// adjusting direction.
+func (p *refPart) num(dir adjustDirection) *int {
+	if dir == rows {
+		return &p.rowNum
+	}
+	return &p.colNum
+}
+
+// parseRefPart provides a function to parse the part of the cell or range
+// reference, it returns false if the reference is invalid.
+func parseRefPart(ref string) (refPart, bool) {
+	var p refPart
+	sub := refPartRegexp.FindStringSubmatch(ref)
+	if sub == nil || sub[2] == "" && sub[4] == "" {
+		return p, false
+	}
+	p.colAbs, p.rowAbs = sub[1], sub[3]
+	if sub[2] != "" {
+		var err error
+		if p.colNum, err = ColumnNameToNumber(sub[2]); err != nil {
+			return p, false
+		}
+	} else if p.colAbs != "" && p.rowAbs == "" {
+		p.colAbs, p.rowAbs = "", p.colAbs
+	}
+	if sub[4] != "" {
+		p.rowNum, _ = strconv.Atoi(sub[4])
+		if p.rowNum < 1 || p.rowNum > TotalRows {
+			return p, false
+		}
+	}
+	return p, true
+}
+
+// parseRef provides a function to parse the cell reference, range reference,
+// whole columns or whole rows reference into parts, it returns false if the
+// reference is invalid.
+func parseRef(ref string) ([]refPart, bool) {
+	parts := strings.Split(ref, ":")
+	if len(parts) > 2 {
+		return nil, false
+	}
+	var refParts []refPart
+	for _, part := range parts {
+		p, ok := parseRefPart(part)
+		if !ok {
+			return nil, false
+		}
+		refParts = append(refParts, p)
+	}
+	if len(refParts) == 1 && (refParts[0].colNum == 0 || refParts[0].rowNum == 0) ||
+		len(refParts) == 2 && ((refParts[0].colNum == 0) != (refParts[1].colNum == 0) ||
+			(refParts[0].rowNum == 0) != (refParts[1].rowNum == 0)) {
+		return nil, false
+	}
+	return refParts, true
+}
+
+// adjustRef provides a function to update the cell or range reference when
+// inserting or deleting rows or columns, it returns the #REF! error if all of
+// the cells in the reference are deleted.
+func adjustRef(ref string, dir adjustDirection, num, offset int) string {
+	refParts, ok := parseRef(ref)
+	if !ok || *refParts[0].num(dir) == 0 {
+		return ref
+	}
+	maxNum := TotalRows
+	if dir == columns {
+		maxNum = MaxColumns
+	}
+	if len(refParts) == 2 && *refParts[0].num(dir) > *refParts[1].num(dir) {
+		refParts[0], refParts[1] = refParts[1], refParts[0]
+	}
+	lo, hi, end := refParts[0].num(dir), refParts[len(refParts)-1].num(dir), num-offset-1
+	if offset < 0 && *lo >= num && *hi <= end {
+		return formulaErrorREF
+	}
+	for i, n := range []*int{lo, hi}[:len(refParts)] {
+		if offset > 0 && *n >= num {
+			if *n += offset; *n > maxNum {
+				return formulaErrorREF
+			}
+		}
+		if offset < 0 && *n > end {
+			*n += offset
+		} else if offset < 0 && *n >= num {
+			*n = num - i
+		}
+	}
+	return joinRefParts(refParts)
+}
+
 // adjustCols provides a function to update column style when inserting or
 // deleting columns.
 func (f *File) adjustCols(ws *xlsxWorksheet, col, offset int) error {
@@ -202,37 +832,16 @@ func (f *File) adjustHyperlinks(ws *xlsxWorksheet, sheet string, dir adjustDirec
 	}
 
 	// order is important
-	if offset < 0 {
-		for i := len(ws.Hyperlinks.Hyperlink) - 1; i >= 0; i-- {
-			linkData := ws.Hyperlinks.Hyperlink[i]
-			colNum, rowNum, _ := CellNameToCoordinates(linkData.Ref)
-
-			if (dir == rows && num == rowNum) || (dir == columns && num == colNum) {
-				f.deleteSheetRelationships(sheet, linkData.RID)
-				if len(ws.Hyperlinks.Hyperlink) > 1 {
-					ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink[:i],
-						ws.Hyperlinks.Hyperlink[i+1:]...)
-				} else {
-					ws.Hyperlinks = nil
-				}
-			}
-		}
-	}
-	if ws.Hyperlinks == nil {
-		return
-	}
-	for i := range ws.Hyperlinks.Hyperlink {
+	for i := len(ws.Hyperlinks.Hyperlink) - 1; i >= 0; i-- {
 		link := &ws.Hyperlinks.Hyperlink[i] // get reference
-		colNum, rowNum, _ := CellNameToCoordinates(link.Ref)
-		if dir == rows {
-			if rowNum >= num {
-				link.Ref, _ = CoordinatesToCellName(colNum, rowNum+offset)
-			}
-		} else {
-			if colNum >= num {
-				link.Ref, _ = CoordinatesToCellName(colNum+offset, rowNum)
-			}
+		if link.Ref = adjustRef(link.Ref, dir, num, offset); link.Ref != formulaErrorREF {
+			continue
 		}
+		f.deleteSheetRelationships(sheet, link.RID)
+		ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink[:i], ws.Hyperlinks.Hyperlink[i+1:]...)
+	}
+	if len(ws.Hyperlinks.Hyperlink) == 0 {
+		ws.Hyperlinks = nil
 	}
 }
 
@@ -278,12 +887,32 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 		if t.AutoFilter != nil {
 			t.AutoFilter.Ref = t.Ref
 		}
-		_, _ = f.setTableHeader(sheet, true, x1, y1, x2)
+		tableColumns, _ := f.setTableHeader(sheet, true, x1, y1, x2)
+		if t.TableColumns != nil && len(t.TableColumns.TableColumn) != len(tableColumns) {
+			t.TableColumns = adjustTableColumns(t.TableColumns, tableColumns)
+		}
 		table, _ := xml.Marshal(t)
 		f.saveFileList(tableXML, table)
 	}
 }
 
+// adjustTableColumns provides a function to update the table columns by
+// given header columns when inserting or deleting columns inside the table,
+// the existing table columns with the same name will be kept.
+func adjustTableColumns(columns *xlsxTableColumns, headers []*xlsxTableColumn) *xlsxTableColumns {
+	existing := make(map[string]*xlsxTableColumn, len(columns.TableColumn))
+	for _, column := range columns.TableColumn {
+		existing[column.Name] = column
+	}
+	for idx, header := range headers {
+		if column, ok := existing[header.Name]; ok {
+			column.ID = header.ID
+			headers[idx] = column
+		}
+	}
+	return &xlsxTableColumns{Count: len(headers), TableColumn: headers}
+}
+
 // adjustAutoFilter provides a function to update the auto filter when
 // inserting or deleting rows or columns.
 func (f *File) adjustAutoFilter(ws *xlsxWorksheet, dir adjustDirection, num, offset int) error {
@@ -320,7 +949,7 @@ func (f *File) adjustAutoFilter(ws *xlsxWorksheet, dir adjustDirection, num, off
 // reference and offset.
 func (f *File) adjustAutoFilterHelper(dir adjustDirection, coordinates []int, num, offset int) []int {
 	if dir == rows {
-		if coordinates[1] >= num {
+		if coordinates[1] > num || (coordinates[1] == num && offset > 0) {
 			coordinates[1] += offset
 		}
 		if coordinates[3] >= num {
@@ -328,7 +957,7 @@ func (f *File) adjustAutoFilterHelper(dir adjustDirection, coordinates []int, nu
 		}
 		return coordinates
 	}
-	if coordinates[0] >= num {
+	if coordinates[0] > num || (coordinates[0] == num && offset > 0) {
 		coordinates[0] += offset
 	}
 	if coordinates[2] >= num {
@@ -343,7 +972,7 @@ func (f *File) adjustMergeCells(ws *xlsxWorksheet, dir adjustDirection, num, off
 	if ws.MergeCells == nil {
 		return nil
 	}
-
+	defer ws.resetMergeCellsIndex()
 	for i := 0; i < len(ws.MergeCells.Cells); i++ {
 		mergedCells := ws.MergeCells.Cells[i]
 		mergedCellsRef := mergedCells.Ref
@@ -419,6 +1048,7 @@ func (f *File) deleteMergeCell(ws *xlsxWorksheet, idx int) {
 	if len(ws.MergeCells.Cells) > idx {
 		ws.MergeCells.Cells = append(ws.MergeCells.Cells[:idx], ws.MergeCells.Cells[idx+1:]...)
 		ws.MergeCells.Count = len(ws.MergeCells.Cells)
+		ws.resetMergeCellsIndex()
 	}
 }
 
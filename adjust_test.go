@@ -2,6 +2,7 @@ package excelize
 
 import (
 	"fmt"
+	"github.com/xuri/excelize/v2/xencoding/xml"
 	"path/filepath"
 	"testing"
 
@@ -337,6 +338,61 @@ func TestAdjustTable(t *testing.T) {
 	assert.NoError(t, f.RemoveRow(sheetName, 1))
 }
 
+func TestAdjustPageBreaks(t *testing.T) {
+	f := NewFile()
+	for _, cell := range []string{"A3", "A5", "A8", "C1", "E1"} {
+		assert.NoError(t, f.InsertPageBreak("Sheet1", cell))
+	}
+	getBreaks := func() ([]int, []int) {
+		breaks, err := f.GetPageBreaks("Sheet1")
+		assert.NoError(t, err)
+		var rows, cols []int
+		for _, brk := range breaks.Rows {
+			rows = append(rows, brk.Number)
+		}
+		for _, brk := range breaks.Columns {
+			cols = append(cols, brk.Number)
+		}
+		return rows, cols
+	}
+	// Test insert rows before the first row of the page
+	assert.NoError(t, f.InsertRows("Sheet1", 5, 2))
+	rows, _ := getBreaks()
+	assert.Equal(t, []int{3, 7, 10}, rows)
+	// Test remove the row before the first row of the page
+	assert.NoError(t, f.RemoveRow("Sheet1", 6))
+	rows, _ = getBreaks()
+	assert.Equal(t, []int{3, 6, 9}, rows)
+	// Test remove the first row of the page
+	assert.NoError(t, f.RemoveRow("Sheet1", 6))
+	rows, _ = getBreaks()
+	assert.Equal(t, []int{3, 6, 8}, rows)
+	// Test remove rows makes page breaks duplicated or out of the worksheet
+	assert.NoError(t, f.RemoveRow("Sheet1", 3))
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	rows, _ = getBreaks()
+	assert.Equal(t, []int{2, 3, 5}, rows)
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	rows, _ = getBreaks()
+	assert.Equal(t, []int{2, 4}, rows)
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	rows, _ = getBreaks()
+	assert.Equal(t, []int{3}, rows)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 1, ws.(*xlsxWorksheet).RowBreaks.Count)
+	assert.Equal(t, 1, ws.(*xlsxWorksheet).RowBreaks.ManualBreakCount)
+	// Test insert and remove columns
+	assert.NoError(t, f.InsertCols("Sheet1", "D", 1))
+	_, cols := getBreaks()
+	assert.Equal(t, []int{3, 6}, cols)
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	_, cols = getBreaks()
+	assert.Equal(t, []int{2, 4}, cols)
+}
+
 func TestAdjustHelper(t *testing.T) {
 	f := NewFile()
 	_, err := f.NewSheet("Sheet2")
@@ -453,7 +509,7 @@ func TestAdjustFormula(t *testing.T) {
 	assert.NoError(t, f.DuplicateRowTo("Sheet1", 1, 10))
 	assert.NoError(t, f.InsertCols("Sheet1", "B", 1))
 	assert.NoError(t, f.InsertRows("Sheet1", 1, 1))
-	for cell, expected := range map[string]string{"D2": "=A1+B1", "D3": "=A2+B2", "D11": "=A1+B1"} {
+	for cell, expected := range map[string]string{"D2": "=A2+C2", "D3": "=A3+C3", "D11": "=A2+C2"} {
 		formula, err := f.GetCellFormula("Sheet1", cell)
 		assert.NoError(t, err)
 		assert.Equal(t, expected, formula)
@@ -465,3 +521,279 @@ func TestAdjustFormula(t *testing.T) {
 	assert.Equal(t, f.adjustFormula(&xlsxF{Ref: "-"}, rows, 0, false), ErrParameterInvalid)
 	assert.Equal(t, f.adjustFormula(&xlsxF{Ref: "XFD1:XFD1"}, columns, 1, false), ErrColumnNumber)
 }
+
+func TestAdjustFormulaReferences(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	for i := 1; i <= 10; i++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", i), i))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "SUM(A3:A6)+A5*$A$8"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "A4+\"A4\"&LOG10(A2)"))
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "A1", "Sheet1!A5+'Sheet1'!$A$9:$A$10+A5"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$4:$A$8"}))
+	dv := NewDataValidation(true)
+	dv.Sqref = "C4:C5 C8"
+	dv.SetSqrefDropList("$A$6:$A$10")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(true)
+	dv.Sqref = "D4"
+	dv.SetSqrefDropList("$A$1:$A$2")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.NoError(t, f.AddChart("Sheet 2", "C1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$A$4:$A$8"}},
+	}))
+
+	// Test insert rows
+	assert.NoError(t, f.InsertRows("Sheet1", 5, 2))
+	for sheet, cells := range map[string]map[string]string{
+		"Sheet1":  {"B1": "SUM(A3:A8)+A7*$A$10", "B2": "A4+\"A4\"&LOG10(A2)"},
+		"Sheet 2": {"A1": "Sheet1!A7+'Sheet1'!$A$11:$A$12+A5"},
+	} {
+		for cell, expected := range cells {
+			formula, err := f.GetCellFormula(sheet, cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, formula, cell)
+		}
+	}
+	assert.Equal(t, "Sheet1!$A$4:$A$10", f.GetDefinedName()[0].RefersTo)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C4:C7 C10", dvs[0].Sqref)
+	assert.Equal(t, "<formula1>$A$8:$A$12</formula1>", dvs[0].Formula1)
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(chart.([]byte)), "<f>Sheet1!$A$4:$A$10</f>")
+	assert.Contains(t, string(chart.([]byte)), "<f>Sheet1!$A$1</f>")
+	result, err := f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "58", result)
+
+	// Test remove rows
+	assert.NoError(t, f.RemoveRow("Sheet1", 4))
+	assert.NoError(t, f.RemoveRow("Sheet1", 3))
+	for sheet, cells := range map[string]map[string]string{
+		"Sheet1":  {"B1": "SUM(A3:A6)+A5*$A$8", "B2": "#REF!+\"A4\"&LOG10(A2)"},
+		"Sheet 2": {"A1": "Sheet1!A5+'Sheet1'!$A$9:$A$10+A5"},
+	} {
+		for cell, expected := range cells {
+			formula, err := f.GetCellFormula(sheet, cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, formula, cell)
+		}
+	}
+	assert.Equal(t, "Sheet1!$A$3:$A$8", f.GetDefinedName()[0].RefersTo)
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "C3:C5 C8", dvs[0].Sqref)
+	chart, ok = f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(chart.([]byte)), "<f>Sheet1!$A$3:$A$8</f>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustFormulaReferences.xlsx")))
+
+	// Test adjust defined names with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.adjustDefinedNames("Sheet1", rows, 1, 1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAdjustRef(t *testing.T) {
+	for _, c := range []struct {
+		ref      string
+		dir      adjustDirection
+		num      int
+		offset   int
+		expected string
+	}{
+		{"A1", rows, 1, 1, "A2"},
+		{"$B$2", columns, 2, 2, "$D$2"},
+		{"A1:B2", rows, 2, 1, "A1:B3"},
+		{"B2:A1", rows, 1, 1, "A2:B3"},
+		{"A:A", rows, 1, 1, "A:A"},
+		{"A:B", columns, 2, 1, "A:C"},
+		{"$1:$3", rows, 1, 1, "$2:$4"},
+		{"1:3", columns, 1, 1, "1:3"},
+		{"A1", rows, 1, -1, "#REF!"},
+		{"A2:A3", rows, 2, -2, "#REF!"},
+		{"A1:A3", rows, 1, -1, "A1:A2"},
+		{"A1:A3", rows, 3, -1, "A1:A2"},
+		{"A1:A3", rows, 2, -1, "A1:A2"},
+		{"A5", rows, 2, -1, "A4"},
+		{"A1048576", rows, 1, 1, "#REF!"},
+		{"XFD1", columns, 1, 1, "#REF!"},
+		{"A", rows, 1, 1, "A"},
+		{"1", rows, 1, 1, "1"},
+		{"A1:B", rows, 1, 1, "A1:B"},
+		{"A1:B2:C3", rows, 1, 1, "A1:B2:C3"},
+		{"XFE1", columns, 1, 1, "XFE1"},
+		{"A0", rows, 1, 1, "A0"},
+		{"Name", rows, 1, 1, "Name"},
+	} {
+		assert.Equal(t, c.expected, adjustRef(c.ref, c.dir, c.num, c.offset), c.ref)
+	}
+	for formula, expected := range map[string]string{
+		"A1+B2":                       "A2+B3",
+		"SUM(A1:A2)":                  "SUM(A2:A3)",
+		"\"A1\"&A1":                   "\"A1\"&A2",
+		"LOG10(A1)":                   "LOG10(A2)",
+		"Table1[[#This Row],[A1]]":    "Table1[[#This Row],[A1]]",
+		"[1]Sheet1!A1+'[1]Sheet1'!A1": "[1]Sheet1!A1+'[1]Sheet1'!A1",
+		"'Sheet1'!A1+Sheet2!A1":       "'Sheet1'!A2+Sheet2!A1",
+		"#N/A+A1":                     "#N/A+A2",
+		"Sheet1!#REF!":                "Sheet1!#REF!",
+	} {
+		assert.Equal(t, expected, adjustFormulaRef("Sheet1", "Sheet1", formula, rows, 1, 1), formula)
+	}
+}
+
+func TestAdjustColumnReferences(t *testing.T) {
+	f := NewFile()
+	for col := 1; col <= 6; col++ {
+		cell, err := CoordinatesToCellName(col, 5)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, col))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "H1", "SUM(C5:E5)+$C$5"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C1", "D1"))
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C2:E2",
+		[]ConditionalFormatOptions{{Type: "formula", Criteria: "$C$5>0", Format: format}}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "C7:E9"}))
+	assert.NoError(t, f.AddChart("Sheet1", "J1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$5", Values: "Sheet1!$C$5:$E$5"}},
+	}))
+	check := func(cell, formula, mergeCell, cfRef, cfCriteria, tableRef, seriesRef string) {
+		result, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, formula, result)
+		mergeCells, err := f.GetMergeCells("Sheet1")
+		assert.NoError(t, err)
+		if mergeCell == "" {
+			assert.Empty(t, mergeCells)
+		} else {
+			assert.Len(t, mergeCells, 1)
+			assert.Equal(t, mergeCell, mergeCells[0][0])
+		}
+		cfs, err := f.GetConditionalFormats("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, cfs, 1)
+		assert.Equal(t, cfCriteria, cfs[cfRef][0].Criteria)
+		table, ok := f.Pkg.Load("xl/tables/table1.xml")
+		assert.True(t, ok)
+		assert.Contains(t, string(table.([]byte)), fmt.Sprintf("ref=\"%s\"", tableRef))
+		chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+		assert.True(t, ok)
+		assert.Contains(t, string(chart.([]byte)), fmt.Sprintf("<f>%s</f>", seriesRef))
+	}
+	// Test insert column before the column A
+	assert.NoError(t, f.InsertCols("Sheet1", "A", 1))
+	check("I1", "SUM(D5:F5)+$D$5", "D1:E1", "D2:F2", "$D$5>0", "D7:F9", "Sheet1!$D$5:$F$5")
+	// Test insert column after the last used column
+	assert.NoError(t, f.InsertCols("Sheet1", "Z", 2))
+	check("I1", "SUM(D5:F5)+$D$5", "D1:E1", "D2:F2", "$D$5>0", "D7:F9", "Sheet1!$D$5:$F$5")
+	// Test remove column which referenced by absolute reference
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	check("H1", "SUM(D5:E5)+#REF!", "", "D2:E2", "#REF!>0", "D7:E9", "Sheet1!$D$5:$E$5")
+	table, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(table.([]byte)), "<tableColumns count=\"2\">")
+	// Test remove all columns the conditional format applies to
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	cfs, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, cfs)
+}
+
+func TestAdjustComments(t *testing.T) {
+	f := NewFile()
+	for _, cell := range []string{"A1", "B3", "C5"} {
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: cell, Author: "Excelize", Text: cell}))
+	}
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "D7", Author: "Excelize", Text: "D7", ShowComment: true}))
+	getShapeVal := func(cell string) decodeShapeVal {
+		var shapeVal decodeShapeVal
+		col, row, err := CellNameToCoordinates(cell)
+		assert.NoError(t, err)
+		for _, sp := range f.VMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape {
+			assert.NoError(t, xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal))
+			if *shapeVal.ClientData.Column == col-1 && *shapeVal.ClientData.Row == row-1 {
+				return shapeVal
+			}
+		}
+		t.Fatalf("comment shape on %s not found", cell)
+		return shapeVal
+	}
+	// Test insert rows before the comments
+	assert.NoError(t, f.InsertRows("Sheet1", 2, 2))
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "B5", "C7", "D9"}, []string{comments[0].Cell, comments[1].Cell, comments[2].Cell, comments[3].Cell})
+	assert.Equal(t, []bool{false, false, false, true}, []bool{comments[0].ShowComment, comments[1].ShowComment, comments[2].ShowComment, comments[3].ShowComment})
+	assert.Equal(t, "1, 23, 3, 0, 4, 8, 6, 5", getShapeVal("A1").ClientData.Anchor)
+	assert.Equal(t, "2, 23, 5, 0, 5, 9, 8, 5", getShapeVal("B5").ClientData.Anchor)
+	// Test insert columns before the comments
+	assert.NoError(t, f.InsertCols("Sheet1", "B", 1))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "C5", "D7", "E9"}, []string{comments[0].Cell, comments[1].Cell, comments[2].Cell, comments[3].Cell})
+	assert.Equal(t, "3, 23, 5, 0, 6, 9, 8, 5", getShapeVal("C5").ClientData.Anchor)
+	// Test remove the row and the column of the comments
+	assert.NoError(t, f.RemoveRow("Sheet1", 5))
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C6", "D8"}, []string{comments[0].Cell, comments[1].Cell})
+	assert.Len(t, f.VMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape, 2)
+	assert.Equal(t, "4, 23, 8, 0, 7, 11, 11, 5", getShapeVal("D8").ClientData.Anchor)
+	assert.NotNil(t, getShapeVal("D8").ClientData.Visible)
+	// Test duplicate row before the comments
+	assert.NoError(t, f.DuplicateRow("Sheet1", 1))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C7", "D9"}, []string{comments[0].Cell, comments[1].Cell})
+	// Test duplicate row of the comments will copy the comments
+	assert.NoError(t, f.SetCellValue("Sheet1", "D9", "D9"))
+	assert.NoError(t, f.DuplicateRow("Sheet1", 9))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C7", "D9", "D10"}, []string{comments[0].Cell, comments[1].Cell, comments[2].Cell})
+	assert.Equal(t, []string{"D7", "D7"}, []string{comments[1].Text, comments[2].Text})
+	assert.Equal(t, []bool{true, true}, []bool{comments[1].ShowComment, comments[2].ShowComment})
+	assert.NotNil(t, getShapeVal("D10").ClientData.Visible)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustComments.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test get the visible comments from the VML drawing of the workbook
+	f, err = OpenFile(filepath.Join("test", "TestAdjustComments.xlsx"))
+	assert.NoError(t, err)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false, true, true}, []bool{comments[0].ShowComment, comments[1].ShowComment, comments[2].ShowComment})
+	assert.Nil(t, f.VMLDrawing["xl/drawings/vmlDrawing1.vml"])
+	assert.NoError(t, f.Close())
+
+	// Test adjust comments with invalid cell reference
+	f = NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment"}))
+	f.Comments["xl/comments1.xml"].CommentList.Comment[0].Ref = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.InsertRows("Sheet1", 1, 1))
+	// Test adjust comments with unsupported charset
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.InsertRows("Sheet1", 1, 1), "XML syntax error on line 1: invalid UTF-8")
+	// Test adjust comments with unsupported charset VML drawing
+	f = NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment"}))
+	f.VMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.InsertRows("Sheet1", 1, 1), "XML syntax error on line 1: invalid UTF-8")
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
@@ -217,9 +217,14 @@ var (
 type calcContext struct {
 	mu                sync.Mutex
 	entry             string
+	iterate           bool
+	refFunc           bool
 	maxCalcIterations uint
 	iterations        map[string]uint
 	iterationsCache   map[string]formulaArg
+	calcStack         []string
+	externalCells     map[string]formulaArg
+	externalSheets    map[string]bool
 }
 
 // cellRef defines the structure of a cell reference.
@@ -239,6 +244,7 @@ type cellRange struct {
 type formulaCriteria struct {
 	Type      byte
 	Condition string
+	re        *regexp.Regexp
 }
 
 // ArgType is the type of formula argument type.
@@ -348,7 +354,12 @@ type formulaFuncs struct {
 // CalcCellValue provides a function to get calculated cell value. This feature
 // is currently in working processing. Iterative calculation, implicit
 // intersection, explicit intersection, array formula, table formula and some
-// other formulas are not supported currently.
+// other formulas are not supported currently. If the formula refers to its
+// own cell indirectly through other formula cells, the ErrCircularReference
+// error will be returned unless the iterative calculation was enabled in the
+// workbook calculation properties or by the MaxCalcIterations option, the
+// maximum iterations specified by the MaxCalcIterations option or the
+// IterateCount of the workbook calculation properties will be used.
 //
 // Supported formula functions:
 //
@@ -791,34 +802,318 @@ type formulaFuncs struct {
 func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string, err error) {
 	var (
 		rawCellValue = getOptions(opts...).RawCellValue
-		styleIdx     int
 		token        formulaArg
 	)
+	entry := fmt.Sprintf("%s!%s", sheet, cell)
+	maxCalcIterations := f.getCalcIterations(getOptions(opts...).MaxCalcIterations)
 	if token, err = f.calcCellValue(&calcContext{
-		entry:             fmt.Sprintf("%s!%s", sheet, cell),
-		maxCalcIterations: getOptions(opts...).MaxCalcIterations,
+		entry:             entry,
+		iterate:           maxCalcIterations > 0,
+		maxCalcIterations: maxCalcIterations,
 		iterations:        make(map[string]uint),
 		iterationsCache:   make(map[string]formulaArg),
+		calcStack:         []string{entry},
 	}, sheet, cell); err != nil {
 		result = token.String
 		return
 	}
+	var styleIdx int
 	if !rawCellValue {
 		styleIdx, _ = f.GetCellStyle(sheet, cell)
 	}
-	result = token.Value()
+	return f.formatCalcResult(styleIdx, token.Value(), rawCellValue)
+}
+
+// formatCalcResult provides a function to apply the number format for the
+// numeric calculated result by given style index and the calculated result.
+func (f *File) formatCalcResult(styleIdx int, result string, rawCellValue bool) (string, error) {
 	if isNum, precision, decimal := isNumeric(result); isNum {
 		if precision > 15 {
-			result, err = f.formattedValue(&xlsxC{S: styleIdx, V: strings.ToUpper(strconv.FormatFloat(decimal, 'G', 15, 64))}, rawCellValue, CellTypeNumber)
-			return
+			return f.formattedValue(&xlsxC{S: styleIdx, V: strings.ToUpper(strconv.FormatFloat(decimal, 'G', 15, 64))}, rawCellValue, CellTypeNumber)
 		}
 		if !strings.HasPrefix(result, "0") {
-			result, err = f.formattedValue(&xlsxC{S: styleIdx, V: strings.ToUpper(strconv.FormatFloat(decimal, 'f', -1, 64))}, rawCellValue, CellTypeNumber)
+			return f.formattedValue(&xlsxC{S: styleIdx, V: strings.ToUpper(strconv.FormatFloat(decimal, 'f', -1, 64))}, rawCellValue, CellTypeNumber)
+		}
+	}
+	return result, nil
+}
+
+// ConvertFormulasToValues provides a function to replace the formulas with
+// the calculated values by given worksheet names, all worksheets in the
+// workbook will be converted if no worksheet name was specified. The formulas
+// will be calculated by the formula engine and removed from the cells, the
+// cell keeps the last cached value if the formula engine can't evaluate the
+// formula, such as the formula uses unsupported functions or contains
+// circular references. For example, replace formulas with values on Sheet1:
+//
+//	err := f.ConvertFormulasToValues("Sheet1")
+func (f *File) ConvertFormulasToValues(sheets ...string) error {
+	if err := f.checkReadOnly("ConvertFormulasToValues"); err != nil {
+		return err
+	}
+	ignoreNotWorksheet := len(sheets) == 0
+	if ignoreNotWorksheet {
+		sheets = f.GetSheetList()
+	}
+	type calcResult struct {
+		cell   *xlsxC
+		result formulaArg
+		ok     bool
+	}
+	results := make(map[string][]calcResult)
+	for _, sheet := range sheets {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if ignoreNotWorksheet && errors.As(err, &ErrNotWorksheet{}) {
+				continue
+			}
+			return err
+		}
+		for rowIdx := range ws.SheetData.Row {
+			for colIdx := range ws.SheetData.Row[rowIdx].C {
+				c := &ws.SheetData.Row[rowIdx].C[colIdx]
+				if c.F == nil {
+					continue
+				}
+				result, ok := f.calcFormulaValue(sheet, c.R)
+				results[sheet] = append(results[sheet], calcResult{cell: c, result: result, ok: ok})
+			}
+		}
+	}
+	for _, sheet := range sheets {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			continue
+		}
+		for _, r := range results[sheet] {
+			if r.ok {
+				if err = f.setFormulaResult(r.cell, r.result); err != nil {
+					return err
+				}
+			}
+			if err = f.removeFormula(r.cell, ws, sheet); err != nil {
+				return err
+			}
+			r.cell.F = nil
+		}
+	}
+	return nil
+}
+
+// calcFormulaValue provides a function to calculate the formula of the cell by
+// given worksheet name and cell reference, it returns false if the formula
+// engine can't evaluate the formula.
+func (f *File) calcFormulaValue(sheet, cell string) (formulaArg, bool) {
+	entry := fmt.Sprintf("%s!%s", sheet, cell)
+	maxCalcIterations := f.getCalcIterations(f.options.MaxCalcIterations)
+	result, err := f.calcCellValue(&calcContext{
+		entry:             entry,
+		iterate:           maxCalcIterations > 0,
+		maxCalcIterations: maxCalcIterations,
+		iterations:        make(map[string]uint),
+		iterationsCache:   make(map[string]formulaArg),
+		calcStack:         []string{entry},
+	}, sheet, cell)
+	if err != nil {
+		if result.Type != ArgError && inStrSlice([]string{
+			formulaErrorDIV, formulaErrorNAME, formulaErrorNA, formulaErrorNUM,
+			formulaErrorVALUE, formulaErrorREF, formulaErrorNULL,
+		}, err.Error(), true) != -1 {
+			return newErrorFormulaArg(err.Error(), err.Error()), true
+		}
+		if result.Type != ArgError || errors.Is(err, ErrCircularReference) ||
+			strings.HasPrefix(result.Error, "not support ") {
+			return result, false
+		}
+	}
+	if result.Type == ArgMatrix {
+		if len(result.Matrix) == 0 || len(result.Matrix[0]) == 0 {
+			return newEmptyFormulaArg(), true
+		}
+		result = result.Matrix[0][0]
+	}
+	return result, true
+}
+
+// GetCellDependencies provides a function to get the precedent cells which are
+// directly referenced by the formula of the cell by given worksheet name and
+// cell reference. The cells on other worksheets will be returned with the
+// sheet name prefix, the defined names will be resolved to the references,
+// and the whole column or row references will be limited to the used range of
+// the worksheet. It returns an empty list if the cell doesn't contain a
+// formula. For example, get the precedent cells of the cell A3 on Sheet1 with
+// formula "=SUM(A1:A2)+Sheet2!B1":
+//
+//	cells, err := f.GetCellDependencies("Sheet1", "A3")
+//
+// The cells will be [A1 A2 Sheet2!B1].
+func (f *File) GetCellDependencies(sheet, cell string) ([]string, error) {
+	formula, err := f.GetCellFormula(sheet, cell)
+	if err != nil || formula == "" {
+		return nil, err
+	}
+	var (
+		cells  []string
+		exists = make(map[string]bool)
+	)
+	appendCell := func(cr cellRef) error {
+		name, err := CoordinatesToCellName(cr.Col, cr.Row)
+		if err != nil {
+			return err
+		}
+		if cr.Sheet = strings.Trim(cr.Sheet, "'"); !strings.EqualFold(cr.Sheet, sheet) {
+			name = fmt.Sprintf("%s!%s", quoteSheetName(strings.ReplaceAll(cr.Sheet, "''", "'")), name)
+		}
+		if !exists[name] {
+			exists[name] = true
+			cells = append(cells, name)
+		}
+		return nil
+	}
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(formula) {
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange {
+			continue
+		}
+		if refTo := f.getDefinedNameRefTo(token.TValue, sheet); refTo != "" {
+			token.TValue = refTo
+		}
+		cellRefs, cellRanges, err := f.prepareReference(sheet, token.TValue)
+		if err != nil {
+			continue
+		}
+		for e := cellRefs.Front(); e != nil; e = e.Next() {
+			if err = appendCell(e.Value.(cellRef)); err != nil {
+				return cells, err
+			}
+		}
+		for e := cellRanges.Front(); e != nil; e = e.Next() {
+			cr := e.Value.(cellRange)
+			if cr.To.Col == MaxColumns || cr.To.Row == TotalRows {
+				maxCol, maxRow := f.getUsedRangeBounds(cr.From.Sheet)
+				if cr.To.Col > maxCol {
+					cr.To.Col = maxCol
+				}
+				if cr.To.Row > maxRow {
+					cr.To.Row = maxRow
+				}
+			}
+			for row := cr.From.Row; row <= cr.To.Row; row++ {
+				for col := cr.From.Col; col <= cr.To.Col; col++ {
+					if err = appendCell(cellRef{Col: col, Row: row, Sheet: cr.From.Sheet}); err != nil {
+						return cells, err
+					}
+				}
+			}
+		}
+	}
+	return cells, err
+}
+
+// getUsedRangeBounds returns the maximum column and row number of the cells in
+// the worksheet by given worksheet name.
+func (f *File) getUsedRangeBounds(sheet string) (maxCol, maxRow int) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(strings.Trim(sheet, "'"))
+	f.mu.Unlock()
+	if err != nil {
+		return
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, row := range ws.SheetData.Row {
+		if row.R > maxRow {
+			maxRow = row.R
+		}
+		for _, c := range row.C {
+			if col, _, err := CellNameToCoordinates(c.R); err == nil && col > maxCol {
+				maxCol = col
+			}
 		}
 	}
 	return
 }
 
+// SetCalcContext provides a function to set the formula calculation context
+// of the workbook, which makes the volatile formula functions NOW, TODAY, RAND
+// and RANDBETWEEN produce deterministic results. The NOW and TODAY functions
+// will use the given fixed time in its location as the current time, and the
+// RAND and RANDBETWEEN functions will generate the random numbers by the
+// given seed. The current wall-clock time and a random seed will be used if
+// the option was not specified. For example, set the current time and the
+// random seed for calculation:
+//
+//	now, seed := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), int64(1)
+//	f.SetCalcContext(&excelize.CalcContextOptions{Now: &now, RandSeed: &seed})
+func (f *File) SetCalcContext(opts *CalcContextOptions) {
+	f.calcMu.Lock()
+	defer f.calcMu.Unlock()
+	f.calcNow, f.calcRand = nil, nil
+	if opts == nil {
+		return
+	}
+	if opts.Now != nil {
+		now := *opts.Now
+		f.calcNow = &now
+	}
+	if opts.RandSeed != nil {
+		f.calcRand = rand.New(rand.NewSource(*opts.RandSeed))
+	}
+}
+
+// now returns the current time for the formula functions by the calculation
+// context of the workbook.
+func (f *File) now() time.Time {
+	f.calcMu.Lock()
+	defer f.calcMu.Unlock()
+	if f.calcNow != nil {
+		return *f.calcNow
+	}
+	return time.Now()
+}
+
+// randFloat64 returns a pseudo-random number in [0.0,1.0) for the formula
+// functions by the calculation context of the workbook.
+func (f *File) randFloat64() float64 {
+	f.calcMu.Lock()
+	defer f.calcMu.Unlock()
+	if f.calcRand != nil {
+		return f.calcRand.Float64()
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano())).Float64()
+}
+
+// randInt63n returns a non-negative pseudo-random number in [0,n) for the
+// formula functions by the calculation context of the workbook.
+func (f *File) randInt63n(n int64) int64 {
+	f.calcMu.Lock()
+	defer f.calcMu.Unlock()
+	if f.calcRand != nil {
+		return f.calcRand.Int63n(n)
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano())).Int63n(n)
+}
+
+// getCalcIterations returns the maximum iterations for the iterative
+// calculation by given maximum iterations option, the workbook calculation
+// properties will be used if the option was not specified. It returns 0 if
+// the iterative calculation was disabled.
+func (f *File) getCalcIterations(maxCalcIterations uint) uint {
+	if maxCalcIterations > 0 {
+		return maxCalcIterations
+	}
+	if f.options.MaxCalcIterations > 0 {
+		return f.options.MaxCalcIterations
+	}
+	if wb, _ := f.workbookReader(); wb != nil && wb.CalcPr != nil && wb.CalcPr.Iterate {
+		if wb.CalcPr.IterateCount > 0 {
+			return uint(wb.CalcPr.IterateCount)
+		}
+		return 100
+	}
+	return 0
+}
+
 // calcCellValue calculate cell value by given context, worksheet name and cell
 // reference.
 func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formulaArg, err error) {
@@ -831,7 +1126,11 @@ func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formu
 	if tokens == nil {
 		return
 	}
-	result, err = f.evalInfixExp(ctx, sheet, cell, tokens)
+	refFunc := ctx.setRefFunc(false)
+	if result, err = f.evalInfixExp(ctx, sheet, cell, tokens); errors.Is(err, ErrCircularReference) {
+		result = newErrorFormulaArg(formulaErrorREF, err.Error())
+	}
+	ctx.setRefFunc(refFunc)
 	return
 }
 
@@ -908,6 +1207,7 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 	var inArray, inArrayRow bool
 	for i := 0; i < len(tokens); i++ {
 		token := tokens[i]
+		ctx.setRefFunc(opfStack.Len() > 0 && isRefFunc(opfStack.Peek().(efp.Token)))
 
 		// out of function stack
 		if opfStack.Len() == 0 {
@@ -1363,7 +1663,7 @@ func isOperatorPrefixToken(token efp.Token) bool {
 
 // isOperand determine if the token is parse operand.
 func isOperand(token efp.Token) bool {
-	return token.TType == efp.TokenTypeOperand && (token.TSubType == efp.TokenSubTypeNumber || token.TSubType == efp.TokenSubTypeText || token.TSubType == efp.TokenSubTypeLogical)
+	return token.TType == efp.TokenTypeOperand && (token.TSubType == efp.TokenSubTypeNumber || token.TSubType == efp.TokenSubTypeText || token.TSubType == efp.TokenSubTypeLogical || token.TSubType == efp.TokenSubTypeError)
 }
 
 // tokenToFormulaArg create a formula argument by given token.
@@ -1374,6 +1674,8 @@ func tokenToFormulaArg(token efp.Token) formulaArg {
 	case efp.TokenSubTypeNumber:
 		num, _ := strconv.ParseFloat(token.TValue, 64)
 		return newNumberFormulaArg(num)
+	case efp.TokenSubTypeError:
+		return newErrorFormulaArg(token.TValue, token.TValue)
 	default:
 		return newStringFormulaArg(token.TValue)
 	}
@@ -1387,6 +1689,8 @@ func formulaArgToToken(arg formulaArg) efp.Token {
 			return efp.Token{TValue: arg.Value(), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeLogical}
 		}
 		return efp.Token{TValue: arg.Value(), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber}
+	case ArgError:
+		return efp.Token{TValue: arg.Value(), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeError}
 	default:
 		return efp.Token{TValue: arg.Value(), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeText}
 	}
@@ -1402,6 +1706,9 @@ func (f *File) parseToken(ctx *calcContext, sheet string, token efp.Token, opdSt
 			token.TValue = refTo
 		}
 		result, err := f.parseReference(ctx, sheet, token.TValue)
+		if errors.Is(err, ErrCircularReference) {
+			return err
+		}
 		if err != nil {
 			return errors.New(formulaErrorNAME)
 		}
@@ -1491,6 +1798,16 @@ func (cr *cellRange) prepareCellRange(col, row bool, cellRef cellRef) error {
 // parseReference parse reference and extract values by given reference
 // characters and default sheet name.
 func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formulaArg, error) {
+	cellRefs, cellRanges, err := f.prepareReference(sheet, reference)
+	if err != nil {
+		return newErrorFormulaArg(formulaErrorNAME, err.Error()), err
+	}
+	return f.rangeResolver(ctx, cellRefs, cellRanges)
+}
+
+// prepareReference parse reference to the cell references and cell ranges
+// list by given reference characters and default sheet name.
+func (f *File) prepareReference(sheet, reference string) (*list.List, *list.List, error) {
 	reference = strings.ReplaceAll(reference, "$", "")
 	ranges, cellRanges, cellRefs := strings.Split(reference, ":"), list.New(), list.New()
 	if len(ranges) > 1 {
@@ -1498,7 +1815,7 @@ func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formul
 		for i, ref := range ranges {
 			cellRef, col, row, err := f.parseRef(ref)
 			if err != nil {
-				return newErrorFormulaArg(formulaErrorNAME, "invalid reference"), errors.New("invalid reference")
+				return cellRefs, cellRanges, errors.New("invalid reference")
 			}
 			if i == 0 {
 				if col {
@@ -1514,21 +1831,21 @@ func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formul
 				continue
 			}
 			if err := cr.prepareCellRange(col, row, cellRef); err != nil {
-				return newErrorFormulaArg(formulaErrorNAME, err.Error()), err
+				return cellRefs, cellRanges, err
 			}
 		}
 		cellRanges.PushBack(cr)
-		return f.rangeResolver(ctx, cellRefs, cellRanges)
+		return cellRefs, cellRanges, nil
 	}
 	cellRef, _, _, err := f.parseRef(reference)
 	if err != nil {
-		return newErrorFormulaArg(formulaErrorNAME, "invalid reference"), errors.New("invalid reference")
+		return cellRefs, cellRanges, errors.New("invalid reference")
 	}
 	if cellRef.Sheet == "" {
 		cellRef.Sheet = sheet
 	}
 	cellRefs.PushBack(cellRef)
-	return f.rangeResolver(ctx, cellRefs, cellRanges)
+	return cellRefs, cellRanges, nil
 }
 
 // prepareValueRange prepare value range.
@@ -1563,6 +1880,114 @@ func prepareValueRef(cr cellRef, valueRange []int) {
 	}
 }
 
+// isCircularRef returns if the given cell reference is being calculated in the
+// formula execution context. The reference to the cell itself which formula is
+// being evaluating will not be considered as the circular reference if it's
+// the calculation entry, and the cached value of the cell will be used. The
+// reference to the cell itself of the precedent cells will be considered as
+// the circular reference, except it was used as the argument of the reference
+// functions such as ROW and COLUMNS, which only use the position of the cell.
+func (ctx *calcContext) isCircularRef(ref string) bool {
+	for i, calcRef := range ctx.calcStack {
+		if calcRef == ref {
+			return i < len(ctx.calcStack)-1 || (i > 0 && !ctx.refFunc)
+		}
+	}
+	return false
+}
+
+// setRefFunc provides a function to set if the cell references being parsed
+// are the arguments of the reference functions, and returns the previous
+// value.
+func (ctx *calcContext) setRefFunc(refFunc bool) bool {
+	if ctx == nil {
+		return refFunc
+	}
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	prev := ctx.refFunc
+	ctx.refFunc = refFunc
+	return prev
+}
+
+// isRefFunc returns if the given formula function only use the position of
+// the cell references in the arguments, instead of the cell values.
+func isRefFunc(token efp.Token) bool {
+	return inStrSlice([]string{"COLUMN", "COLUMNS", "FORMULATEXT", "ISFORMULA", "ISREF", "ROW", "ROWS", "SHEET", "SHEETS"},
+		strings.TrimPrefix(strings.ToUpper(token.TValue), "_XLFN."), true) != -1
+}
+
+// getExternalCellValues provides a function to get the cached cell values of
+// the external workbooks, the keys of the returned cell values map are the
+// upper case cell references with external workbook index, such as
+// [1]SHEET1!A1, and the keys of the returned worksheets map are the upper case
+// worksheet names with external workbook index which has cached values.
+func (f *File) getExternalCellValues() (map[string]formulaArg, map[string]bool, error) {
+	cells, sheets := map[string]formulaArg{}, map[string]bool{}
+	wb, err := f.workbookReader()
+	if err != nil || wb.ExternalReferences == nil {
+		return cells, sheets, err
+	}
+	for idx, ref := range wb.ExternalReferences.ExternalReference {
+		link, err := f.externalLinkReader(f.getExternalLinkPath(ref.RID))
+		if err != nil {
+			return cells, sheets, err
+		}
+		if link.ExternalBook == nil || link.ExternalBook.SheetNames == nil || link.ExternalBook.SheetDataSet == nil {
+			continue
+		}
+		sheetNames := link.ExternalBook.SheetNames.SheetName
+		for _, sheetData := range link.ExternalBook.SheetDataSet.SheetData {
+			if sheetData.SheetID < 0 || sheetData.SheetID >= len(sheetNames) || sheetNames[sheetData.SheetID].Val == nil {
+				continue
+			}
+			sheet := strings.ToUpper(fmt.Sprintf("[%d]%s", idx+1, *sheetNames[sheetData.SheetID].Val))
+			sheets[sheet] = true
+			for _, row := range sheetData.Row {
+				for _, c := range row.Cell {
+					arg := newStringFormulaArg(c.V)
+					switch c.T {
+					case "b":
+						arg = arg.ToBool()
+					case "e":
+						arg = newErrorFormulaArg(c.V, c.V)
+					case "", "n":
+						if arg = arg.ToNumber(); arg.Type != ArgNumber {
+							arg = newEmptyFormulaArg()
+						}
+					}
+					cells[sheet+"!"+strings.ToUpper(c.R)] = arg
+				}
+			}
+		}
+	}
+	return cells, sheets, err
+}
+
+// externalCellResolver provides a function to get the cached cell value of
+// the external workbook by given worksheet name with external workbook index,
+// such as [1]Sheet1, and cell reference. The cell which value was not cached
+// will be considered as an empty cell, and the #REF! error will be returned if
+// the worksheet of the external workbook has no cached values.
+func (f *File) externalCellResolver(ctx *calcContext, sheet, cell string) (formulaArg, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.externalSheets == nil {
+		var err error
+		if ctx.externalCells, ctx.externalSheets, err = f.getExternalCellValues(); err != nil {
+			return newErrorFormulaArg(formulaErrorREF, err.Error()), err
+		}
+	}
+	sheet = strings.ToUpper(sheet)
+	if !ctx.externalSheets[sheet] {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF), nil
+	}
+	if arg, ok := ctx.externalCells[sheet+"!"+cell]; ok {
+		return arg, nil
+	}
+	return newEmptyFormulaArg(), nil
+}
+
 // cellResolver calc cell value by given worksheet name, cell reference and context.
 func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, error) {
 	var (
@@ -1570,19 +1995,42 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 		value string
 		err   error
 	)
+	if strings.HasPrefix(sheet, "[") {
+		return f.externalCellResolver(ctx, sheet, cell)
+	}
 	ref := fmt.Sprintf("%s!%s", sheet, cell)
 	if formula, _ := f.GetCellFormula(sheet, cell); len(formula) != 0 {
 		ctx.mu.Lock()
-		if ctx.entry != ref {
-			if ctx.iterations[ref] <= f.options.MaxCalcIterations {
+		if !ctx.iterate && ctx.isCircularRef(ref) {
+			ctx.mu.Unlock()
+			return newErrorFormulaArg(formulaErrorREF, ErrCircularReference.Error()), ErrCircularReference
+		}
+		if ref != ctx.calcStack[len(ctx.calcStack)-1] {
+			if ctx.iterations[ref] <= ctx.maxCalcIterations {
+				refFunc := ctx.refFunc
 				ctx.iterations[ref]++
+				ctx.calcStack = append(ctx.calcStack, ref)
 				ctx.mu.Unlock()
-				arg, _ = f.calcCellValue(ctx, sheet, cell)
+				arg, err = f.calcCellValue(ctx, sheet, cell)
+				ctx.mu.Lock()
+				ctx.calcStack = ctx.calcStack[:len(ctx.calcStack)-1]
 				ctx.iterationsCache[ref] = arg
+				ctx.mu.Unlock()
+				// The reference functions only use the position of the cell,
+				// so the circular reference in the referenced cell is ignored
+				if errors.Is(err, ErrCircularReference) && !refFunc {
+					return arg, err
+				}
 				return arg, nil
 			}
+			// The cell which is still being evaluated in the current iteration
+			// will be considered as an empty cell
+			arg, ok := ctx.iterationsCache[ref]
 			ctx.mu.Unlock()
-			return ctx.iterationsCache[ref], nil
+			if !ok {
+				arg = newEmptyFormulaArg()
+			}
+			return arg, nil
 		}
 		ctx.mu.Unlock()
 	}
@@ -1599,8 +2047,15 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 			return newEmptyFormulaArg(), err
 		}
 		return arg.ToNumber(), err
-	case CellTypeInlineString, CellTypeSharedString:
+	case CellTypeDate:
+		if num := arg.ToNumber(); num.Type == ArgNumber {
+			return num, err
+		}
+		return newEmptyFormulaArg(), err
+	case CellTypeInlineString, CellTypeSharedString, CellTypeFormulaString:
 		return arg, err
+	case CellTypeError, CellTypeFormulaError:
+		return newErrorFormulaArg(value, value), err
 	default:
 		return newEmptyFormulaArg(), err
 	}
@@ -1686,6 +2141,7 @@ func callFuncByName(receiver interface{}, name string, params []reflect.Value) (
 func formulaCriteriaParser(exp string) (fc *formulaCriteria) {
 	fc = &formulaCriteria{}
 	if exp == "" {
+		fc.Type = criteriaEq
 		return
 	}
 	for i, re := range formularFormats {
@@ -1694,14 +2150,64 @@ func formulaCriteriaParser(exp string) (fc *formulaCriteria) {
 			return
 		}
 	}
-	if strings.Contains(exp, "?") {
-		exp = strings.ReplaceAll(exp, "?", ".")
+	fc.Type, fc.Condition = criteriaRegexp, criteriaWildcardRegexp(exp)
+	return
+}
+
+// criteriaWildcardRegexp converts the criteria with wildcard characters to
+// the case-insensitive regular expression. The question mark matches any
+// single character, the asterisk matches any sequence of characters, and the
+// tilde escapes the next question mark, asterisk or tilde character.
+func criteriaWildcardRegexp(cond string) string {
+	var buf strings.Builder
+	buf.WriteString("(?is)^")
+	runes := []rune(cond)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '~' && i+1 < len(runes) && strings.ContainsRune("*?~", runes[i+1]):
+			i++
+			buf.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case r == '*':
+			buf.WriteString(".*")
+		case r == '?':
+			buf.WriteString(".")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	buf.WriteString("$")
+	return buf.String()
+}
+
+// compile returns the regular expression of the criteria condition, the
+// regular expression will be compiled only once for each criteria, and
+// reused on matching the values of the cells.
+func (fc *formulaCriteria) compile() (*regexp.Regexp, error) {
+	var err error
+	if fc.re == nil {
+		expr := fc.Condition
+		if fc.Type != criteriaRegexp {
+			expr = criteriaWildcardRegexp(fc.Condition)
+		}
+		fc.re, err = regexp.Compile(expr)
+	}
+	return fc.re, err
+}
+
+// criteriaEqual returns if the value equals to the criteria condition. The
+// numeric values will be compared by numbers, and the text values will be
+// compared case-insensitively with wildcard characters support.
+func criteriaEqual(val string, criteria *formulaCriteria) (bool, error) {
+	if value, err := strconv.ParseFloat(val, 64); err == nil {
+		if expected, err := strconv.ParseFloat(criteria.Condition, 64); err == nil {
+			return value == expected, nil
+		}
 	}
-	if strings.Contains(exp, "*") {
-		exp = strings.ReplaceAll(exp, "*", ".*")
+	re, err := criteria.compile()
+	if err != nil {
+		return false, err
 	}
-	fc.Type, fc.Condition = criteriaRegexp, exp
-	return
+	return re.MatchString(val), err
 }
 
 // formulaCriteriaEval evaluate formula criteria expression.
@@ -1725,7 +2231,7 @@ func formulaCriteriaEval(val string, criteria *formulaCriteria) (result bool, er
 	}
 	switch criteria.Type {
 	case criteriaEq:
-		return val == criteria.Condition, err
+		return criteriaEqual(val, criteria)
 	case criteriaLe:
 		value, expected, e = prepareValue(val, criteria.Condition)
 		return value <= expected && e == nil, err
@@ -1733,7 +2239,8 @@ func formulaCriteriaEval(val string, criteria *formulaCriteria) (result bool, er
 		value, expected, e = prepareValue(val, criteria.Condition)
 		return value >= expected && e == nil, err
 	case criteriaNe:
-		return val != criteria.Condition, err
+		result, err = criteriaEqual(val, criteria)
+		return !result, err
 	case criteriaL:
 		value, expected, e = prepareValue(val, criteria.Condition)
 		return value < expected && e == nil, err
@@ -1741,7 +2248,11 @@ func formulaCriteriaEval(val string, criteria *formulaCriteria) (result bool, er
 		value, expected, e = prepareValue(val, criteria.Condition)
 		return value > expected && e == nil, err
 	case criteriaRegexp:
-		return regexp.MatchString(criteria.Condition, val)
+		re, err := criteria.compile()
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(val), err
 	}
 	return
 }
@@ -5074,7 +5585,7 @@ func (fn *formulaFuncs) RAND(argsList *list.List) formulaArg {
 	if argsList.Len() != 0 {
 		return newErrorFormulaArg(formulaErrorVALUE, "RAND accepts no arguments")
 	}
-	return newNumberFormulaArg(rand.New(rand.NewSource(time.Now().UnixNano())).Float64())
+	return newNumberFormulaArg(fn.f.randFloat64())
 }
 
 // RANDBETWEEN function generates a random integer between two supplied
@@ -5096,7 +5607,7 @@ func (fn *formulaFuncs) RANDBETWEEN(argsList *list.List) formulaArg {
 	if top.Number < bottom.Number {
 		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 	}
-	num := rand.New(rand.NewSource(time.Now().UnixNano())).Int63n(int64(top.Number - bottom.Number + 1))
+	num := fn.f.randInt63n(int64(top.Number - bottom.Number + 1))
 	return newNumberFormulaArg(float64(num + int64(bottom.Number)))
 }
 
@@ -5731,7 +6242,7 @@ func (fn *formulaFuncs) SUMIF(argsList *list.List) formulaArg {
 	if argsList.Len() < 2 {
 		return newErrorFormulaArg(formulaErrorVALUE, "SUMIF requires at least 2 arguments")
 	}
-	criteria := formulaCriteriaParser(argsList.Front().Next().Value.(formulaArg).String)
+	criteria := formulaCriteriaParser(argsList.Front().Next().Value.(formulaArg).Value())
 	rangeMtx := argsList.Front().Value.(formulaArg).Matrix
 	var sumRange [][]formulaArg
 	if argsList.Len() == 3 {
@@ -5773,14 +6284,14 @@ func (fn *formulaFuncs) SUMIFS(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 	}
 	var args []formulaArg
-	sum, sumRange := 0.0, argsList.Front().Value.(formulaArg).Matrix
+	sum, sumRange := 0.0, formulaIfsRange(argsList.Front().Value.(formulaArg))
 	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
 	}
+	if !checkFormulaIfsRanges(args, argsList.Front().Value.(formulaArg)) {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
 	for _, ref := range formulaIfsMatch(args) {
-		if ref.Row >= len(sumRange) || ref.Col >= len(sumRange[ref.Row]) {
-			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
-		}
 		if num := sumRange[ref.Row][ref.Col].ToNumber(); num.Type == ArgNumber {
 			sum += num.Number
 		}
@@ -6131,10 +6642,13 @@ func (fn *formulaFuncs) AVERAGEIFS(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 	}
 	var args []formulaArg
-	sum, sumRange := 0.0, argsList.Front().Value.(formulaArg).Matrix
+	sum, sumRange := 0.0, formulaIfsRange(argsList.Front().Value.(formulaArg))
 	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
 	}
+	if !checkFormulaIfsRanges(args, argsList.Front().Value.(formulaArg)) {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
 	count := 0.0
 	for _, ref := range formulaIfsMatch(args) {
 		if num := sumRange[ref.Row][ref.Col].ToNumber(); num.Type == ArgNumber {
@@ -7787,7 +8301,7 @@ func (fn *formulaFuncs) COUNTIF(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorVALUE, "COUNTIF requires 2 arguments")
 	}
 	var (
-		criteria = formulaCriteriaParser(argsList.Front().Next().Value.(formulaArg).String)
+		criteria = formulaCriteriaParser(argsList.Front().Next().Value.(formulaArg).Value())
 		count    float64
 	)
 	for _, cell := range argsList.Front().Value.(formulaArg).ToList() {
@@ -7798,11 +8312,50 @@ func (fn *formulaFuncs) COUNTIF(argsList *list.List) formulaArg {
 	return newNumberFormulaArg(count)
 }
 
+// formulaIfsRange returns the cell values matrix of the range argument for
+// the multiple criteria functions, and the single value will be treated as a
+// range with one cell.
+func formulaIfsRange(arg formulaArg) [][]formulaArg {
+	if arg.Type == ArgMatrix {
+		return arg.Matrix
+	}
+	return [][]formulaArg{{arg}}
+}
+
+// checkFormulaIfsRanges returns if the given ranges and all the criteria
+// ranges in the criteria pairs arguments of the multiple criteria functions
+// have the same shape.
+func checkFormulaIfsRanges(args []formulaArg, ranges ...formulaArg) bool {
+	for i := 0; i < len(args); i += 2 {
+		ranges = append(ranges, args[i])
+	}
+	rows, cols := -1, -1
+	for _, arg := range ranges {
+		matrix := formulaIfsRange(arg)
+		for _, row := range matrix {
+			if len(row) != len(matrix[0]) {
+				return false
+			}
+		}
+		if len(matrix) == 0 {
+			return false
+		}
+		if rows == -1 {
+			rows, cols = len(matrix), len(matrix[0])
+			continue
+		}
+		if len(matrix) != rows || len(matrix[0]) != cols {
+			return false
+		}
+	}
+	return true
+}
+
 // formulaIfsMatch function returns cells reference array which match criteria.
 func formulaIfsMatch(args []formulaArg) (cellRefs []cellRef) {
 	for i := 0; i < len(args)-1; i += 2 {
 		var match []cellRef
-		matrix, criteria := args[i].Matrix, formulaCriteriaParser(args[i+1].Value())
+		matrix, criteria := formulaIfsRange(args[i]), formulaCriteriaParser(args[i+1].Value())
 		if i == 0 {
 			for rowIdx, row := range matrix {
 				for colIdx, col := range row {
@@ -7840,6 +8393,9 @@ func (fn *formulaFuncs) COUNTIFS(argsList *list.List) formulaArg {
 	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
 	}
+	if !checkFormulaIfsRanges(args) {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
 	return newNumberFormulaArg(float64(len(formulaIfsMatch(args))))
 }
 
@@ -9807,10 +10363,13 @@ func (fn *formulaFuncs) MAXIFS(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 	}
 	var args []formulaArg
-	max, maxRange := -math.MaxFloat64, argsList.Front().Value.(formulaArg).Matrix
+	max, maxRange := -math.MaxFloat64, formulaIfsRange(argsList.Front().Value.(formulaArg))
 	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
 	}
+	if !checkFormulaIfsRanges(args, argsList.Front().Value.(formulaArg)) {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
 	for _, ref := range formulaIfsMatch(args) {
 		if num := maxRange[ref.Row][ref.Col].ToNumber(); num.Type == ArgNumber && max < num.Number {
 			max = num.Number
@@ -9950,10 +10509,13 @@ func (fn *formulaFuncs) MINIFS(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 	}
 	var args []formulaArg
-	min, minRange := math.MaxFloat64, argsList.Front().Value.(formulaArg).Matrix
+	min, minRange := math.MaxFloat64, formulaIfsRange(argsList.Front().Value.(formulaArg))
 	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
 	}
+	if !checkFormulaIfsRanges(args, argsList.Front().Value.(formulaArg)) {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
 	for _, ref := range formulaIfsMatch(args) {
 		if num := minRange[ref.Row][ref.Col].ToNumber(); num.Type == ArgNumber && min > num.Number {
 			min = num.Number
@@ -11870,6 +12432,9 @@ func (fn *formulaFuncs) DATEDIF(argsList *list.List) formulaArg {
 	if startArg.Number == endArg.Number {
 		return newNumberFormulaArg(0)
 	}
+	if fn.date1904() {
+		startArg.Number, endArg.Number = startArg.Number+date1904Offset, endArg.Number+date1904Offset
+	}
 	unit := strings.ToLower(argsList.Back().Value.(formulaArg).Value())
 	startDate, endDate := timeFromExcelTime(startArg.Number, false), timeFromExcelTime(endArg.Number, false)
 	sy, smm, sd := startDate.Date()
@@ -12213,11 +12778,11 @@ func (fn *formulaFuncs) DAYS360(argsList *list.List) formulaArg {
 	if argsList.Len() > 3 {
 		return newErrorFormulaArg(formulaErrorVALUE, "DAYS360 requires at most 3 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg), fn.date1904())
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
-	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
+	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg), fn.date1904())
 	if endDate.Type != ArgNumber {
 		return endDate
 	}
@@ -12532,9 +13097,17 @@ func prepareWorkday(weekend formulaArg) ([]byte, int) {
 	return weekendMask, workdaysPerWeek
 }
 
+// date1904 returns if the workbook uses the 1904 date system.
+func (fn *formulaFuncs) date1904() bool {
+	wb, err := fn.f.workbookReader()
+	return err == nil && wb != nil && wb.WorkbookPr != nil && wb.WorkbookPr.Date1904
+}
+
 // toExcelDateArg function converts a text representation of a time, into an
-// Excel date time number formula argument.
-func toExcelDateArg(arg formulaArg) formulaArg {
+// Excel date time number formula argument. The returned serial number is
+// always in the 1900 date system, the numeric argument will be converted
+// from the 1904 date system when the date1904 is true.
+func toExcelDateArg(arg formulaArg, date1904 bool) formulaArg {
 	num := arg.ToNumber()
 	if num.Type != ArgNumber {
 		dateString := strings.ToLower(arg.Value())
@@ -12553,15 +13126,18 @@ func toExcelDateArg(arg formulaArg) formulaArg {
 	if arg.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 	}
+	if date1904 {
+		num.Number += date1904Offset
+	}
 	return num
 }
 
 // prepareHolidays function converts array type formula arguments to into an
 // Excel date time number formula arguments list.
-func prepareHolidays(args formulaArg) []int {
+func prepareHolidays(args formulaArg, date1904 bool) []int {
 	var holidays []int
 	for _, arg := range args.ToList() {
-		num := toExcelDateArg(arg)
+		num := toExcelDateArg(arg, date1904)
 		if num.Type != ArgNumber {
 			continue
 		}
@@ -12642,11 +13218,11 @@ func (fn *formulaFuncs) NETWORKDAYSdotINTL(argsList *list.List) formulaArg {
 	if argsList.Len() > 4 {
 		return newErrorFormulaArg(formulaErrorVALUE, "NETWORKDAYS.INTL requires at most 4 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg), fn.date1904())
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
-	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
+	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg), fn.date1904())
 	if endDate.Type != ArgNumber {
 		return endDate
 	}
@@ -12656,7 +13232,7 @@ func (fn *formulaFuncs) NETWORKDAYSdotINTL(argsList *list.List) formulaArg {
 	}
 	var holidays []int
 	if argsList.Len() == 4 {
-		holidays = prepareHolidays(argsList.Back().Value.(formulaArg))
+		holidays = prepareHolidays(argsList.Back().Value.(formulaArg), fn.date1904())
 		sort.Ints(holidays)
 	}
 	weekendMask, workdaysPerWeek := prepareWorkday(weekend)
@@ -12723,7 +13299,7 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 	if argsList.Len() > 4 {
 		return newErrorFormulaArg(formulaErrorVALUE, "WORKDAY.INTL requires at most 4 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg), fn.date1904())
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
@@ -12737,11 +13313,15 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 	}
 	var holidays []int
 	if argsList.Len() == 4 {
-		holidays = prepareHolidays(argsList.Back().Value.(formulaArg))
+		holidays = prepareHolidays(argsList.Back().Value.(formulaArg), fn.date1904())
 		sort.Ints(holidays)
 	}
+	var offset1904 float64
+	if fn.date1904() {
+		offset1904 = date1904Offset
+	}
 	if days.Number == 0 {
-		return newNumberFormulaArg(math.Ceil(startDate.Number))
+		return newNumberFormulaArg(math.Ceil(startDate.Number) - offset1904)
 	}
 	weekendMask, workdaysPerWeek := prepareWorkday(weekend)
 	if workdaysPerWeek == 0 {
@@ -12770,7 +13350,7 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 			}
 		}
 	}
-	return newNumberFormulaArg(float64(workdayIntl(endDate, sign, holidays, weekendMask, startDate.Number)))
+	return newNumberFormulaArg(float64(workdayIntl(endDate, sign, holidays, weekendMask, startDate.Number)) - offset1904)
 }
 
 // YEAR function returns an integer representing the year of a supplied date.
@@ -12950,7 +13530,7 @@ func (fn *formulaFuncs) NOW(argsList *list.List) formulaArg {
 	if argsList.Len() != 0 {
 		return newErrorFormulaArg(formulaErrorVALUE, "NOW accepts no arguments")
 	}
-	now := time.Now()
+	now := fn.f.now()
 	_, offset := now.Zone()
 	return newNumberFormulaArg(25569.0 + float64(now.Unix()+int64(offset))/86400)
 }
@@ -13046,7 +13626,7 @@ func (fn *formulaFuncs) TODAY(argsList *list.List) formulaArg {
 	if argsList.Len() != 0 {
 		return newErrorFormulaArg(formulaErrorVALUE, "TODAY accepts no arguments")
 	}
-	now := time.Now()
+	now := fn.f.now()
 	_, offset := now.Zone()
 	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), now.Unix()+int64(offset)) + 1)
 }
@@ -14356,6 +14936,68 @@ func calcMatch(matchType int, criteria *formulaCriteria, lookupArray []formulaAr
 	return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 }
 
+// calcApproximateMatch returns the position of the largest value which is
+// less than or equal to the lookup value in an ascending sorted lookup array
+// when the match type is 1, or the position of the smallest value which is
+// greater than or equal to the lookup value in a descending sorted lookup
+// array when the match type is -1 by binary search for the formula function
+// MATCH. The values in the lookup array which data type are different from
+// the lookup value will be ignored. When the match type is -1, the error will
+// be returned if the first comparable value, which is the largest value in the
+// descending sorted lookup array, is less than the lookup value.
+func calcApproximateMatch(matchType int, lookupValue formulaArg, lookupArray []formulaArg) formulaArg {
+	expected := map[int]byte{1: criteriaL, -1: criteriaG}[matchType]
+	compare := func(idx int) (bool, bool) {
+		arg := lookupArray[idx]
+		if arg.Type == ArgEmpty || arg.Boolean != lookupValue.Boolean {
+			return false, false
+		}
+		result := compareFormulaArg(arg, lookupValue, newNumberFormulaArg(matchModeExact), false)
+		return result != criteriaNe && result != criteriaErr, result == criteriaEq || result == expected
+	}
+	// probe returns the index of the nearest comparable value to the middle
+	// index in the range, or -1 if there are no comparable values.
+	probe := func(low, mid, high int) int {
+		for idx := mid; idx >= low; idx-- {
+			if ok, _ := compare(idx); ok {
+				return idx
+			}
+		}
+		for idx := mid + 1; idx <= high; idx++ {
+			if ok, _ := compare(idx); ok {
+				return idx
+			}
+		}
+		return -1
+	}
+	low, high, matchIdx := 0, len(lookupArray)-1, -1
+	if matchType == -1 {
+		if first := probe(low, low, high); first != -1 {
+			if _, ok := compare(first); !ok {
+				return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+			}
+		}
+	}
+	for low <= high {
+		mid := low + (high-low)/2
+		idx := probe(low, mid, high)
+		if idx == -1 {
+			break
+		}
+		if _, ok := compare(idx); ok {
+			if matchIdx, low = idx, idx+1; idx < mid {
+				low = mid + 1
+			}
+			continue
+		}
+		high = idx - 1
+	}
+	if matchIdx == -1 {
+		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	}
+	return newNumberFormulaArg(float64(matchIdx + 1))
+}
+
 // MATCH function looks up a value in an array, and returns the position of
 // the value within the array. The user can specify that the function should
 // only return a result if an exact match is found, or that the function
@@ -14391,7 +15033,11 @@ func (fn *formulaFuncs) MATCH(argsList *list.List) formulaArg {
 	default:
 		return newErrorFormulaArg(formulaErrorNA, lookupArrayErr)
 	}
-	return calcMatch(matchType, formulaCriteriaParser(argsList.Front().Value.(formulaArg).Value()), lookupArray)
+	lookupValue := argsList.Front().Value.(formulaArg)
+	if matchType == 0 {
+		return calcMatch(matchType, formulaCriteriaParser(lookupValue.Value()), lookupArray)
+	}
+	return calcApproximateMatch(matchType, lookupValue, lookupArray)
 }
 
 // TRANSPOSE function 'transposes' an array of cells (i.e. the function copies
@@ -14782,6 +15428,9 @@ func (fn *formulaFuncs) INDEX(argsList *list.List) formulaArg {
 		}
 		colIdx = int(colArg.Number) - 1
 	}
+	if argsList.Len() == 2 && array.Type == ArgMatrix && len(array.Matrix) == 1 && len(array.Matrix[0]) > 1 && rowIdx != -1 {
+		rowIdx, colIdx = 0, rowIdx
+	}
 	if rowIdx == -1 && colIdx == -1 {
 		if len(array.ToList()) != 1 {
 			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/xuri/efp"
@@ -1504,6 +1505,12 @@ func TestCalcCellValue(t *testing.T) {
 		"=DATEDIF(42171,44242,\"m\")":  "67",
 		"=DATEDIF(42156,44454,\"MD\")": "14",
 		"=DATEDIF(42171,44242,\"md\")": "30",
+		"=DATEDIF(42035,42064,\"md\")": "-2",
+		"=DATEDIF(42400,42430,\"md\")": "-1",
+		"=DATEDIF(44956,44986,\"md\")": "-1",
+		"=DATEDIF(45016,45047,\"md\")": "0",
+		"=DATEDIF(44926,44956,\"md\")": "30",
+		"=DATEDIF(44941,44967,\"md\")": "26",
 		"=DATEDIF(43101,43891,\"YM\")": "2",
 		"=DATEDIF(42171,44242,\"ym\")": "7",
 		"=DATEDIF(43101,43891,\"YD\")": "59",
@@ -1897,7 +1904,8 @@ func TestCalcCellValue(t *testing.T) {
 		"=INDEX(0,0,0)":          "0",
 		"=INDEX(A1,0,0)":         "1",
 		"=INDEX(A1:A1,0,0)":      "1",
-		"=SUM(INDEX(A1:B1,1))":   "5",
+		"=SUM(INDEX(A1:B1,1))":   "1",
+		"=SUM(INDEX(A1:B1,2))":   "4",
 		"=SUM(INDEX(A1:B1,1,0))": "5",
 		"=SUM(INDEX(A1:B2,2,0))": "7",
 		"=SUM(INDEX(A1:B4,0,2))": "9",
@@ -5176,6 +5184,75 @@ func TestCalcSUMIFSAndAVERAGEIFS(t *testing.T) {
 	}
 }
 
+func TestCalcIFSCriteria(t *testing.T) {
+	cellData := [][]interface{}{
+		{"Fruit", "Store", "Qty", "Price", "apple", ">10"},
+		{"Apple", "North", 12, 1.5},
+		{"Pineapple", "South", 8, 3},
+		{"apple pie", "North", 20, 4.5},
+		{"Banana", "North", 5, 0.5},
+		{"Ap*ple", "South", 15, 2},
+		{"Cherry", "", 30, 6},
+	}
+	f := prepareCalcData(cellData)
+	formulaList := map[string]string{
+		"=SUMIFS(C2:C7,A2:A7,\"apple\")":                       "12",
+		"=SUMIFS(C2:C7,A2:A7,\"APPLE*\")":                      "32",
+		"=SUMIFS(C2:C7,A2:A7,\"*apple\")":                      "20",
+		"=SUMIFS(C2:C7,A2:A7,\"?pple\")":                       "12",
+		"=SUMIFS(C2:C7,A2:A7,\"ap~*ple\")":                     "15",
+		"=SUMIFS(C2:C7,A2:A7,\"<>apple\",B2:B7,\"North\")":     "25",
+		"=SUMIFS(C2:C7,A2:A7,E1)":                              "12",
+		"=SUMIFS(C2:C7,C2:C7,F1)":                              "77",
+		"=SUMIFS(C2:C7,C2:C7,\">\"&C3,D2:D7,\"<=4.5\")":        "47",
+		"=SUMIFS(C2:C7,B2:B7,\"\")":                            "30",
+		"=SUMIFS(C2:C7,B2:B7,\"<>\")":                          "60",
+		"=SUMIFS(C2:C7,D2:D7,\"1.5\")":                         "12",
+		"=SUMIFS(C2:C7,D2:D7,\"=1.50\")":                       "12",
+		"=COUNTIFS(A2:A7,\"*apple*\",C2:C7,\">10\")":           "2",
+		"=COUNTIFS(A2:A7,\"<>*a*\")":                           "1",
+		"=AVERAGEIFS(D2:D7,A2:A7,\"*apple*\",B2:B7,\"North\")": "3",
+		"=MAXIFS(D2:D7,B2:B7,\"south\")":                       "3",
+		"=MINIFS(D2:D7,A2:A7,\"?*\",C2:C7,\">=12\")":           "1.5",
+		"=SUMIFS(C2,A2,\"apple\")":                             "12",
+		"=SUMIF(C2:C7,C2)":                                     "12",
+		"=COUNTIF(C2:C7,30)":                                   "1",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "H1", formula))
+		result, err := f.CalcCellValue("Sheet1", "H1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string][]string{
+		"=SUMIFS(C2:C7,A2:A6,\"apple\")":              {"#VALUE!", "#VALUE!"},
+		"=SUMIFS(C2:C7,A2:A7,\"apple\",B2:C7,\"*\")":  {"#VALUE!", "#VALUE!"},
+		"=COUNTIFS(A2:A7,\"apple\",B2:B6,\"North\")":  {"#VALUE!", "#VALUE!"},
+		"=AVERAGEIFS(D2:D6,A2:A7,\"apple\")":          {"#VALUE!", "#VALUE!"},
+		"=MAXIFS(D2:D7,A2:B7,\"apple\")":              {"#VALUE!", "#VALUE!"},
+		"=MINIFS(D2:D7,A2:A7,\"apple\",C1:C7,\">1\")": {"#VALUE!", "#VALUE!"},
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "H1", formula))
+		result, err := f.CalcCellValue("Sheet1", "H1")
+		assert.Equal(t, expected[0], result, formula)
+		assert.EqualError(t, err, expected[1], formula)
+	}
+	// Test the regular expression of the criteria was compiled only once
+	criteria := formulaCriteriaParser("ap*")
+	ok, err := formulaCriteriaEval("Apple", criteria)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	re := criteria.re
+	ok, err = formulaCriteriaEval("Banana", criteria)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Same(t, re, criteria.re)
+	// Test evaluate criteria with invalid regular expression
+	_, err = formulaCriteriaEval("Apple", &formulaCriteria{Type: criteriaRegexp, Condition: "("})
+	assert.EqualError(t, err, "error parsing regexp: missing closing ): `(`")
+}
+
 func TestCalcXIRR(t *testing.T) {
 	cellData := [][]interface{}{
 		{-100.00, "01/01/2016"},
@@ -5367,12 +5444,18 @@ func TestCalcXNPV(t *testing.T) {
 func TestCalcMATCH(t *testing.T) {
 	f := NewFile()
 	for cell, row := range map[string][]interface{}{
-		"A1": {"cccc", 7, 4, 16},
-		"A2": {"dddd", 2, 6, 11},
-		"A3": {"aaaa", 4, 7, 10},
-		"A4": {"bbbb", 1, 10, 7},
-		"A5": {"eeee", 8, 11, 6},
-		"A6": {nil, 11, 16, 4},
+		"A1":  {"cccc", 7, 4, 16},
+		"A2":  {"dddd", 2, 6, 11},
+		"A3":  {"aaaa", 4, 7, 10},
+		"A4":  {"bbbb", 1, 10, 7},
+		"A5":  {"eeee", 8, 11, 6},
+		"A6":  {nil, 11, 16, 4},
+		"A7":  {"a", 1, nil, 1, false},
+		"A8":  {"b", 3, nil, 2, false},
+		"A9":  {"c", 5, nil, 3, true},
+		"A10": {nil, 5, nil, 3, true},
+		"A11": {"X", "text", nil, 3, true},
+		"A12": {1, 9, 2, 7, 8},
 	} {
 		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
 	}
@@ -5386,6 +5469,25 @@ func TestCalcMATCH(t *testing.T) {
 		"=MATCH(8,C1:C6,1)":        "3",
 		"=MATCH(6,B1:B6,-1)":       "1",
 		"=MATCH(10,D1:D6,-1)":      "3",
+		// Approximate match with binary search on sorted data
+		"=MATCH(12,C1:C6,1)":      "5",
+		"=MATCH(100,C1:C6)":       "6",
+		"=MATCH(16,C1:C6,1)":      "6",
+		"=MATCH(12,D1:D6,-1)":     "1",
+		"=MATCH(3,D1:D6,-1)":      "6",
+		"=MATCH(\"B\",A7:A12)":    "2",
+		"=MATCH(\"zz\",A7:A12,1)": "5",
+		"=MATCH(3,D7:D12,1)":      "5",
+		"=MATCH(TRUE,E7:E12,1)":   "5",
+		"=MATCH(FALSE,E7:E12,1)":  "2",
+		"=MATCH(5,B7:B12,1)":      "4",
+		"=MATCH(5,B7:B12,0)":      "3",
+		"=INDEX(A7:E12,MATCH(5,B7:B12,1),MATCH(7,A12:E12,0))": "3",
+		// INDEX with both row and column arguments on 2D ranges
+		"=INDEX(B1:D6,4,3)":                       "7",
+		"=INDEX(A1:D6,MATCH(\"eeee\",A1:A6,0),4)": "6",
+		"=INDEX(A1:D1,3)":                         "4",
+		"=INDEX(A1:A6,2)":                         "dddd",
 	}
 	for formula, expected := range formulaList {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
@@ -5393,19 +5495,161 @@ func TestCalcMATCH(t *testing.T) {
 		assert.NoError(t, err, formula)
 		assert.Equal(t, expected, result, formula)
 	}
-	calcError := map[string]string{
-		"=MATCH(3,C1:C6,1)":  "#N/A",
-		"=MATCH(5,C1:C6,-1)": "#N/A",
+	calcError := map[string][]string{
+		"=MATCH(3,C1:C6,1)":    {"#N/A", "#N/A"},
+		"=MATCH(5,C1:C6,-1)":   {"#N/A", "#N/A"},
+		"=MATCH(17,D1:D6,-1)":  {"#N/A", "#N/A"},
+		"=MATCH(0,B7:B12)":     {"#N/A", "#N/A"},
+		"=MATCH(\"a\",B7:B12)": {"#N/A", "#N/A"},
+		"=MATCH(0,A7:A12)":     {"#N/A", "#N/A"},
+		"=INDEX(A1:D6,7,1)":    {"#REF!", "INDEX row_num out of range"},
+		"=INDEX(A1:D6,1,5)":    {"#REF!", "INDEX col_num out of range"},
+		"=INDEX(A1:D1,5)":      {"#REF!", "INDEX col_num out of range"},
 	}
 	for formula, expected := range calcError {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
 		result, err := f.CalcCellValue("Sheet1", "E1")
-		assert.EqualError(t, err, expected, formula)
-		assert.Equal(t, expected, result, formula)
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
 	}
 	assert.Equal(t, newErrorFormulaArg(formulaErrorNA, formulaErrorNA), calcMatch(2, nil, []formulaArg{}))
 }
 
+func TestCalcSelfReference(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=SUM(A1,1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=SUM(B1:B2)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=ROW(C1)+A1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=B1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "=ISFORMULA(B1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "=ROW(F1)+COLUMN(F1)"))
+	// Test the calculation entry cell refers to itself by the cached value
+	for cell, expected := range map[string]string{"A1": "1", "B1": "0", "E1": "TRUE", "F1": "7"} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, result, cell)
+	}
+	// Test the precedent cell refers to itself is the circular reference
+	for _, cell := range []string{"C1", "D1"} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.Equal(t, ErrCircularReference, err, cell)
+		assert.Equal(t, formulaErrorREF, result, cell)
+	}
+}
+
+func TestCalcCircularReference(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=B1+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=C1+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=SUM(A1:A2)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=B1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "=ROWS(E1:E2)+COLUMN(E1)"))
+	for _, cell := range []string{"A1", "B1", "C1", "D1"} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.Equal(t, ErrCircularReference, err, cell)
+		assert.Equal(t, formulaErrorREF, result, cell)
+	}
+	// Test reference to the cell itself by the reference functions
+	result, err := f.CalcCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "7", result)
+	// Test calculate the circular reference with iterative calculation enabled
+	f.WorkBook.CalcPr = &xlsxCalcPr{Iterate: true}
+	_, err = f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	f.WorkBook.CalcPr = nil
+	_, err = f.CalcCellValue("Sheet1", "A1", Options{MaxCalcIterations: 10})
+	assert.NoError(t, err)
+	// Test calculate the circular reference with the maximum iterations of
+	// the workbook calculation properties
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G1", "=H1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "H1", "=SUM(I1,1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "I1", "=H1"))
+	for iterateCount, expected := range map[uint]string{1: "2", 5: "6", 10: "11"} {
+		assert.NoError(t, f.SetCalcProps(&CalcPropsOptions{Iterate: boolPtr(true), IterateCount: uintPtr(iterateCount)}))
+		result, err = f.CalcCellValue("Sheet1", "G1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	}
+	// Test the maximum iterations option takes precedence
+	result, err = f.CalcCellValue("Sheet1", "G1", Options{MaxCalcIterations: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "4", result)
+	assert.NoError(t, f.SetCalcProps(&CalcPropsOptions{Iterate: boolPtr(false)}))
+	result, err = f.CalcCellValue("Sheet1", "G1")
+	assert.Equal(t, ErrCircularReference, err)
+	assert.Equal(t, formulaErrorREF, result)
+	// Test calculate the formula with the same cell referenced multiple times
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "=A1+B1+D1"))
+	result, err = f.CalcCellValue("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, "7", result)
+}
+
+func TestCalcContext(t *testing.T) {
+	now, seed := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC), int64(1)
+	calc := func(f *File) []string {
+		var results []string
+		for cell, formula := range map[string]string{"A1": "=NOW()", "A2": "=TODAY()", "A3": "=RAND()", "A4": "=RANDBETWEEN(1,100)"} {
+			assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+		}
+		for _, cell := range []string{"A1", "A2", "A3", "A4", "A3"} {
+			result, err := f.CalcCellValue("Sheet1", cell, Options{RawCellValue: true})
+			assert.NoError(t, err)
+			results = append(results, result)
+		}
+		return results
+	}
+	f1, f2 := NewFile(), NewFile()
+	f1.SetCalcContext(&CalcContextOptions{Now: &now, RandSeed: &seed})
+	f2.SetCalcContext(&CalcContextOptions{Now: &now, RandSeed: &seed})
+	results := calc(f1)
+	assert.Equal(t, results, calc(f2))
+	assert.Equal(t, []string{"44928.5", "44928"}, results[:2])
+	assert.NotEqual(t, results[2], results[4])
+	// Test reset the calculation context to use the wall-clock time
+	f1.SetCalcContext(nil)
+	result, err := f1.CalcCellValue("Sheet1", "A2", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.NotEqual(t, "44928", result)
+	// Test set the calculation context with fixed time only
+	f2.SetCalcContext(&CalcContextOptions{Now: &now})
+	result, err = f2.CalcCellValue("Sheet1", "A1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "44928.5", result)
+	_, err = f2.CalcCellValue("Sheet1", "A4")
+	assert.NoError(t, err)
+}
+
+func TestGetCellDependencies(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{4, 5, 6}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "Sheet1!$C$2"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=SUM(A1:B2)*Rate+'Sheet 2'!A1+A1+\"B1\""))
+	cells, err := f.GetCellDependencies("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "B1", "A2", "B2", "C2", "'Sheet 2'!A1"}, cells)
+	// Test get dependencies of the whole column and row references
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "=SUM(C:C)+SUM(2:2)+UNKNOWN"))
+	cells, err = f.GetCellDependencies("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C1", "C2", "A2", "B2", "D2"}, cells)
+	// Test get dependencies of the cell without formula
+	cells, err = f.GetCellDependencies("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, cells)
+	// Test get dependencies with invalid cell reference
+	_, err = f.GetCellDependencies("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get dependencies on not exists worksheet
+	_, err = f.GetCellDependencies("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestCalcISFORMULA(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=ISFORMULA(A1)"))
@@ -5793,6 +6037,42 @@ func TestCalcNETWORKDAYSandWORKDAY(t *testing.T) {
 	}
 }
 
+func TestCalcDateFunctionsWithDate1904(t *testing.T) {
+	cellData := [][]interface{}{
+		{40785, 40815},
+		{41054},
+	}
+	f := prepareCalcData(cellData)
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(true)}))
+	formulaList := map[string]string{
+		// The serial number 40785 and 40815 in the 1904 date system are
+		// 2015-08-31 and 2015-09-30
+		"=DATEDIF(A1,B1,\"d\")":                       "30",
+		"=DATEDIF(A1,B1,\"m\")":                       "0",
+		"=DATEDIF(A1,B1,\"md\")":                      "30",
+		"=DATEDIF(A1,A2,\"y\")":                       "0",
+		"=DATEDIF(A1,A2,\"ym\")":                      "8",
+		"=DATEDIF(A1,A2,\"yd\")":                      "269",
+		"=NETWORKDAYS(A1,B1)":                         "23",
+		"=NETWORKDAYS(A1,B1,A2)":                      "23",
+		"=NETWORKDAYS.INTL(A1,B1,11)":                 "27",
+		"=NETWORKDAYS.INTL(A1,B1,1,40815)":            "22",
+		"=WORKDAY(A1,5)":                              "40792",
+		"=WORKDAY(A1,0)":                              "40785",
+		"=WORKDAY(A1,-5)":                             "40778",
+		"=WORKDAY(A1,5,40787)":                        "40793",
+		"=WORKDAY.INTL(A1,5,\"0000011\")":             "40792",
+		"=WORKDAY.INTL(A1,5,11)":                      "40790",
+		"=WORKDAY.INTL(\"08/31/2015\",5,\"0000011\")": "40792",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C1", formula))
+		result, err := f.CalcCellValue("Sheet1", "C1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+}
+
 func TestCalcZTEST(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]int{4, 5, 2, 5, 8, 9, 3, 2, 3, 8, 9, 5}))
@@ -5957,3 +6237,79 @@ func TestParseToken(t *testing.T) {
 		efp.Token{TSubType: efp.TokenSubTypeRange, TValue: "1A"}, nil, nil,
 	).Error())
 }
+
+func TestConvertFormulasToValues(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, "text"}))
+	for cell, formula := range map[string]string{
+		"D1": "SUM(A1:B1)",
+		"E1": "CONCATENATE(C1,\"s\")",
+		"F1": "A1>B1",
+		"G1": "1/0",
+		"H1": "D1*2",
+		"I1": "UNSUPPORTED(A1)",
+		"J1": "J1+K1",
+		"K1": "J1",
+		"L1": "\"\"",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	// Test keep the cached value for the cells can't be evaluated
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[8].setStr("cached")
+	// Test convert shared formulas
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{3, 4}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{5, 6}))
+	formulaType, ref := STCellFormulaTypeShared, "D2:D3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "A2+B2", FormulaOpts{Ref: &ref, Type: &formulaType}))
+
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!D1+1"))
+	assert.NoError(t, f.ConvertFormulasToValues("Sheet1"))
+
+	for cell, expected := range map[string]struct {
+		value    string
+		cellType CellType
+	}{
+		"D1": {"3", CellTypeNumber},
+		"E1": {"texts", CellTypeSharedString},
+		"F1": {"FALSE", CellTypeBool},
+		"G1": {"#DIV/0!", CellTypeError},
+		"H1": {"6", CellTypeNumber},
+		"I1": {"cached", CellTypeFormulaString},
+		"D2": {"7", CellTypeNumber},
+		"D3": {"11", CellTypeNumber},
+		"L1": {"", CellTypeUnset},
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.value, value, cell)
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.cellType, cellType, cell)
+	}
+	// Test formulas on other worksheets are not converted
+	formula, err := f.GetCellFormula("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!D1+1", formula)
+	// Test convert formulas on all worksheets
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$2"}}}))
+	assert.NoError(t, f.ConvertFormulasToValues())
+	value, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "4", value)
+	// Test convert formulas with not exist worksheet
+	assert.EqualError(t, f.ConvertFormulasToValues("SheetN"), "sheet SheetN does not exist")
+	// Test convert formulas on the chart sheet
+	assert.EqualError(t, f.ConvertFormulasToValues("Chart1"), "sheet Chart1 is not a worksheet")
+	// Test convert formulas with unsupported charset shared string table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A5", "\"text\""))
+	assert.EqualError(t, f.ConvertFormulasToValues("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+}
@@ -13,14 +13,21 @@ package excelize
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
+	"io"
+	"net/url"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
+
+	"github.com/mohae/deepcopy"
 )
 
 // CellType is the type of cell value type.
@@ -36,6 +43,23 @@ const (
 	CellTypeInlineString
 	CellTypeNumber
 	CellTypeSharedString
+	CellTypeFormulaError
+	CellTypeFormulaString
+)
+
+// Cell error values enumeration, which are supported by the SetCellError
+// function.
+var (
+	CellErrorCALC        = formulaErrorCALC
+	CellErrorDIV         = formulaErrorDIV
+	CellErrorGETTINGDATA = formulaErrorGETTINGDATA
+	CellErrorNA          = formulaErrorNA
+	CellErrorNAME        = formulaErrorNAME
+	CellErrorNULL        = formulaErrorNULL
+	CellErrorNUM         = formulaErrorNUM
+	CellErrorREF         = formulaErrorREF
+	CellErrorSPILL       = formulaErrorSPILL
+	CellErrorVALUE       = formulaErrorVALUE
 )
 
 const (
@@ -56,7 +80,7 @@ var cellTypes = map[string]CellType{
 	"n":         CellTypeNumber,
 	"e":         CellTypeError,
 	"s":         CellTypeSharedString,
-	"str":       CellTypeFormula,
+	"str":       CellTypeFormulaString,
 	"inlineStr": CellTypeInlineString,
 }
 
@@ -72,26 +96,166 @@ func (f *File) GetCellValue(sheet, cell string, opts ...Options) (string, error)
 		if err != nil {
 			return "", true, err
 		}
-		val, err := c.getValueFrom(f, sst, getOptions(opts...).RawCellValue)
+		style := c.S
+		if col, row, err := CellNameToCoordinates(c.R); err == nil {
+			style = x.prepareCellStyle(col, row, c.S)
+		}
+		val, err := c.getValueFrom(f, sst, getOptions(opts...).RawCellValue, style)
 		return val, true, err
 	})
 }
 
-// GetCellType provides a function to get the cell's data type by given
-// worksheet name and cell reference in spreadsheet file.
-func (f *File) GetCellType(sheet, cell string) (CellType, error) {
+// recalculateCellValue provides a function to calculate the formula cell by
+// the formula engine, the given cached value will be returned if the formula
+// can't be evaluated. The second returned value reports whether the value is
+// an error value.
+func (f *File) recalculateCellValue(sheet, cell, cached string, fail bool, style int, raw bool) (string, bool, error) {
+	result, ok := f.calcFormulaValue(sheet, cell)
+	if !ok {
+		return cached, fail, nil
+	}
+	if result.Type == ArgError {
+		return result.Value(), true, nil
+	}
+	value, err := f.formatCalcResult(style, result.Value(), raw)
+	return value, false, err
+}
+
+// GetCellValueWithMeta provides a function to get the value of the cell and
+// the metadata of the cell value by given worksheet name, cell reference and
+// the options, including the data type, formula, error value and hyperlink of
+// the cell. For example, get the calculated result of the cell A1 on Sheet1,
+// and return "N/A" for the error values:
+//
+//	value, meta, err := f.GetCellValueWithMeta("Sheet1", "A1", excelize.CellValueOptions{
+//	    Recalculate: true,
+//	    ErrorValue:  "N/A",
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if meta.Error != "" {
+//	    fmt.Println("the cell contains an error value", meta.Error)
+//	}
+//	if meta.HyperlinkTarget != "" {
+//	    fmt.Println(value, "links to", meta.HyperlinkTarget)
+//	}
+func (f *File) GetCellValueWithMeta(sheet, cell string, opts ...CellValueOptions) (string, CellValueMeta, error) {
 	var (
-		err         error
-		cellTypeStr string
-		cellType    CellType
+		options CellValueOptions
+		meta    CellValueMeta
+		value   string
+		style   int
+		link    *xlsxHyperlink
 	)
-	if cellTypeStr, err = f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
-		return c.T, true, nil
+	for _, opt := range opts {
+		options = opt
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return value, meta, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return value, meta, err
+	}
+	ref, err := ws.mergeCellsParser(cell)
+	if err != nil {
+		return value, meta, err
+	}
+	col, row, err := CellNameToCoordinates(ref)
+	if err != nil {
+		return value, meta, err
+	}
+	ws.mu.Lock()
+	if c := ws.getCell(ref, row); c != nil {
+		style = ws.prepareCellStyle(col, row, c.S)
+		if value, err = c.getValueFrom(f, sst, options.RawCellValue, style); err != nil {
+			ws.mu.Unlock()
+			return value, meta, err
+		}
+		meta.Type, meta.Formula = f.getCellType(c), ws.getCellFormula(c)
+	}
+	if link, err = ws.getCellHyperLink(cell); err != nil {
+		ws.mu.Unlock()
+		return value, meta, err
+	}
+	ws.mu.Unlock()
+	fail := meta.Type == CellTypeError || meta.Type == CellTypeFormulaError
+	if meta.Formula != "" && options.Recalculate {
+		if value, fail, err = f.recalculateCellValue(sheet, ref, value, fail, style, options.RawCellValue); err != nil {
+			return value, meta, err
+		}
+	}
+	if fail {
+		meta.Error = value
+		if options.ErrorValue != "" {
+			value = options.ErrorValue
+		}
+	}
+	if meta.HyperlinkType = link.linkType(); meta.HyperlinkType != "" {
+		meta.HyperlinkTarget = link.Location
+		if link.RID != "" {
+			meta.HyperlinkTarget = f.getSheetRelationshipsTargetByID(sheet, link.RID)
+		}
+	}
+	return value, meta, err
+}
+
+// GetCellType provides a function to get the cell's data type by given
+// worksheet name and cell reference in spreadsheet file. The cell type of the
+// shared string, inline string and error value cells are CellTypeSharedString,
+// CellTypeInlineString and CellTypeError, and the cell type of the formula
+// cells with a cached string or error value are CellTypeFormulaString and
+// CellTypeFormulaError. The formula cells without cached value are
+// CellTypeFormula. Since the dates are stored as numbers, the numeric cells
+// (including the formula cells with a cached numeric value) with a date or
+// time number format are CellTypeDate, and others are CellTypeNumber. For
+// example, distinguish the error value #DIV/0! from the literal text "#DIV/0!"
+// in the cell A1 on Sheet1:
+//
+//	cellType, err := f.GetCellType("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if cellType == excelize.CellTypeError || cellType == excelize.CellTypeFormulaError {
+//	    fmt.Println("the cell contains an error value")
+//	}
+func (f *File) GetCellType(sheet, cell string) (CellType, error) {
+	var cellType CellType
+	if _, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		cellType = f.getCellType(c)
+		return "", true, nil
 	}); err != nil {
 		return CellTypeUnset, err
 	}
-	cellType = cellTypes[cellTypeStr]
-	return cellType, err
+	return cellType, nil
+}
+
+// getCellType provides a function to get the data type of the cell value.
+func (f *File) getCellType(c *xlsxC) CellType {
+	if c.T == "e" && c.F != nil {
+		return CellTypeFormulaError
+	}
+	if c.T == "" || c.T == "n" {
+		if c.V != "" {
+			if f.isDateTimeStyle(c.S) {
+				return CellTypeDate
+			}
+			return CellTypeNumber
+		}
+		if c.F != nil {
+			return CellTypeFormula
+		}
+	}
+	if c.T == "str" && c.V == "" {
+		return CellTypeFormula
+	}
+	return cellTypes[c.T]
 }
 
 // SetCellValue provides a function to set the value of a cell. This function
@@ -125,35 +289,16 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 // the cell value as number 0 or 60, then create and bind the date-time number
 // format style for the cell.
 func (f *File) SetCellValue(sheet, cell string, value interface{}) error {
-	var err error
-	switch v := value.(type) {
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		err = f.setCellIntFunc(sheet, cell, v)
-	case float32:
-		err = f.SetCellFloat(sheet, cell, float64(v), -1, 32)
-	case float64:
-		err = f.SetCellFloat(sheet, cell, v, -1, 64)
-	case string:
-		err = f.SetCellStr(sheet, cell, v)
-	case []byte:
-		err = f.SetCellStr(sheet, cell, string(v))
-	case time.Duration:
-		_, d := setCellDuration(v)
-		err = f.SetCellDefault(sheet, cell, d)
-		if err != nil {
-			return err
-		}
-		err = f.setDefaultTimeStyle(sheet, cell, 21)
-	case time.Time:
-		err = f.setCellTimeFunc(sheet, cell, v)
-	case bool:
-		err = f.SetCellBool(sheet, cell, v)
-	case nil:
-		err = f.SetCellDefault(sheet, cell, "")
-	default:
-		err = f.SetCellStr(sheet, cell, fmt.Sprint(value))
+	if err := f.checkReadOnly("SetCellValue"); err != nil {
+		return err
 	}
-	return err
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	return f.setCellValues(sheet, nil, func(fn func(col, row int, value interface{}) error) error {
+		return fn(col, row, value)
+	})
 }
 
 // String extracts characters from a string item.
@@ -178,6 +323,14 @@ func (c *xlsxC) hasValue() bool {
 	return c.S != 0 || c.V != "" || c.F != nil || c.T != ""
 }
 
+// removeValueFormula delete formula for the cell on replacing the value of
+// the cell, and unbind the cell from the rich value (such as the linked data
+// types) by removing the value metadata index.
+func (f *File) removeValueFormula(c *xlsxC, ws *xlsxWorksheet, sheet string) error {
+	c.Vm = nil
+	return f.removeFormula(c, ws, sheet)
+}
+
 // removeFormula delete formula for the cell.
 func (f *File) removeFormula(c *xlsxC, ws *xlsxWorksheet, sheet string) error {
 	if c.F != nil && c.Vm == nil {
@@ -190,76 +343,17 @@ func (f *File) removeFormula(c *xlsxC, ws *xlsxWorksheet, sheet string) error {
 			for r, row := range ws.SheetData.Row {
 				for col, cell := range row.C {
 					if cell.F != nil && cell.F.Si != nil && *cell.F.Si == *si {
-						ws.SheetData.Row[r].C[col].F = nil
+						ws.SheetData.Row[r].C[col].F, ws.SheetData.Row[r].C[col].Cm = nil, nil
 						_ = f.deleteCalcChain(sheetID, cell.R)
 					}
 				}
 			}
 		}
-		c.F = nil
+		c.F, c.Cm = nil, nil
 	}
 	return nil
 }
 
-// setCellIntFunc is a wrapper of SetCellInt.
-func (f *File) setCellIntFunc(sheet, cell string, value interface{}) error {
-	var err error
-	switch v := value.(type) {
-	case int:
-		err = f.SetCellInt(sheet, cell, v)
-	case int8:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int16:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int32:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int64:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint8:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint16:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint32:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint64:
-		err = f.SetCellInt(sheet, cell, int(v))
-	}
-	return err
-}
-
-// setCellTimeFunc provides a method to process time type of value for
-// SetCellValue.
-func (f *File) setCellTimeFunc(sheet, cell string, value time.Time) error {
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
-		return err
-	}
-	c, col, row, err := ws.prepareCell(cell)
-	if err != nil {
-		return err
-	}
-	ws.mu.Lock()
-	c.S = ws.prepareCellStyle(col, row, c.S)
-	ws.mu.Unlock()
-	var date1904, isNum bool
-	wb, err := f.workbookReader()
-	if err != nil {
-		return err
-	}
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
-	if isNum, err = c.setCellTime(value, date1904); err != nil {
-		return err
-	}
-	if isNum {
-		_ = f.setDefaultTimeStyle(sheet, cell, 22)
-	}
-	return err
-}
-
 // setCellTime prepares cell type and Excel time by given Go time.Time type
 // timestamp.
 func (c *xlsxC) setCellTime(value time.Time, date1904 bool) (isNum bool, err error) {
@@ -288,6 +382,9 @@ func setCellDuration(value time.Duration) (t string, v string) {
 // SetCellInt provides a function to set int type value of a cell by given
 // worksheet name, cell reference and cell value.
 func (f *File) SetCellInt(sheet, cell string, value int) error {
+	if err := f.checkReadOnly("SetCellInt"); err != nil {
+		return err
+	}
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -304,7 +401,7 @@ func (f *File) SetCellInt(sheet, cell string, value int) error {
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	c.T, c.V = setCellInt(value)
 	c.IS = nil
-	return f.removeFormula(c, ws, sheet)
+	return f.removeValueFormula(c, ws, sheet)
 }
 
 // setCellInt prepares cell type and string type cell value by a given
@@ -317,6 +414,9 @@ func setCellInt(value int) (t string, v string) {
 // SetCellBool provides a function to set bool type value of a cell by given
 // worksheet name, cell reference and cell value.
 func (f *File) SetCellBool(sheet, cell string, value bool) error {
+	if err := f.checkReadOnly("SetCellBool"); err != nil {
+		return err
+	}
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -333,7 +433,7 @@ func (f *File) SetCellBool(sheet, cell string, value bool) error {
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	c.T, c.V = setCellBool(value)
 	c.IS = nil
-	return f.removeFormula(c, ws, sheet)
+	return f.removeValueFormula(c, ws, sheet)
 }
 
 // setCellBool prepares cell type and string type cell value by a given
@@ -348,6 +448,64 @@ func setCellBool(value bool) (t string, v string) {
 	return
 }
 
+// SetCellError provides a function to set the error value of a cell by given
+// worksheet name, cell reference and error value, the cell will be stored as
+// the error data type instead of the text, and the GetCellValue function will
+// return the error value. The supported error values are:
+//
+//	 Error value   | Variable
+//	---------------+----------------------
+//	 #CALC!        | CellErrorCALC
+//	 #DIV/0!       | CellErrorDIV
+//	 #GETTING_DATA | CellErrorGETTINGDATA
+//	 #N/A          | CellErrorNA
+//	 #NAME?        | CellErrorNAME
+//	 #NULL!        | CellErrorNULL
+//	 #NUM!         | CellErrorNUM
+//	 #REF!         | CellErrorREF
+//	 #SPILL!       | CellErrorSPILL
+//	 #VALUE!       | CellErrorVALUE
+//
+// For example, set the error value #N/A in the cell A1 on Sheet1:
+//
+//	err := f.SetCellError("Sheet1", "A1", excelize.CellErrorNA)
+//
+// Write the error result of the formula calculation back to the
+// cell A2 on Sheet1:
+//
+//	if _, err := f.CalcCellValue("Sheet1", "A1"); err != nil {
+//	    if err := f.SetCellError("Sheet1", "A2", err.Error()); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) SetCellError(sheet, cell, value string) error {
+	if err := f.checkReadOnly("SetCellError"); err != nil {
+		return err
+	}
+	if value = strings.ToUpper(value); inStrSlice([]string{
+		CellErrorCALC, CellErrorDIV, CellErrorGETTINGDATA, CellErrorNA, CellErrorNAME,
+		CellErrorNULL, CellErrorNUM, CellErrorREF, CellErrorSPILL, CellErrorVALUE,
+	}, value, true) == -1 {
+		return newInvalidCellErrorValueError(value)
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	c.S = ws.prepareCellStyle(col, row, c.S)
+	c.T, c.V, c.IS, c.XMLSpace = "e", value, nil, xml.Attr{}
+	return f.removeValueFormula(c, ws, sheet)
+}
+
 // SetCellFloat sets a floating point value into a cell. The precision
 // parameter specifies how many places after the decimal will be shown
 // while -1 is a special value that will use as many decimal places as
@@ -357,6 +515,9 @@ func setCellBool(value bool) (t string, v string) {
 //	var x float32 = 1.325
 //	f.SetCellFloat("Sheet1", "A1", float64(x), 2, 32)
 func (f *File) SetCellFloat(sheet, cell string, value float64, precision, bitSize int) error {
+	if err := f.checkReadOnly("SetCellFloat"); err != nil {
+		return err
+	}
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -373,7 +534,7 @@ func (f *File) SetCellFloat(sheet, cell string, value float64, precision, bitSiz
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	c.T, c.V = setCellFloat(value, precision, bitSize)
 	c.IS = nil
-	return f.removeFormula(c, ws, sheet)
+	return f.removeValueFormula(c, ws, sheet)
 }
 
 // setCellFloat prepares cell type and string type cell value by a given
@@ -385,7 +546,13 @@ func setCellFloat(value float64, precision, bitSize int) (t string, v string) {
 
 // SetCellStr provides a function to set string type value of a cell. Total
 // number of characters that a cell can contain 32767 characters.
+// The string will be written as an inline string instead of into the shared
+// string table if the string mode of the workbook was set to the
+// StringModeInline by the SetStringMode function.
 func (f *File) SetCellStr(sheet, cell, value string) error {
+	if err := f.checkReadOnly("SetCellStr"); err != nil {
+		return err
+	}
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -400,11 +567,165 @@ func (f *File) SetCellStr(sheet, cell, value string) error {
 		return err
 	}
 	c.S = ws.prepareCellStyle(col, row, c.S)
-	if c.T, c.V, err = f.setCellString(value); err != nil {
+	if err = f.setCellStrValue(c, value); err != nil {
+		return err
+	}
+	return f.removeValueFormula(c, ws, sheet)
+}
+
+// setCellStrValue provides a function to set string type value of a cell by
+// the string mode of the workbook.
+func (f *File) setCellStrValue(c *xlsxC, value string) (err error) {
+	// keep the phonetic hints of the cell on rewriting the same string value
+	if si, err := f.getCellStringItem(c); err != nil || (si != nil && si.hasPhonetic() && si.String() == value) {
 		return err
 	}
+	if f.stringMode == StringModeInline {
+		c.T, c.V, c.IS = "inlineStr", "", &xlsxSI{T: &xlsxT{}}
+		c.IS.T.Val, c.IS.T.Space = trimCellValue(value, false)
+		return
+	}
 	c.IS = nil
-	return f.removeFormula(c, ws, sheet)
+	c.T, c.V, err = f.setCellString(value)
+	return
+}
+
+// StringMode is the type of the modes of writing the string cell values.
+type StringMode byte
+
+// This section defines the modes of writing the string cell values.
+const (
+	StringModeShared StringMode = iota
+	StringModeInline
+)
+
+// SetStringMode provides a function to set the mode of writing the string cell
+// values by the SetCellStr and SetCellValue functions. The string cell values
+// will be stored in the shared string table by default (StringModeShared),
+// each unique string is stored only once and the cells refer to it by index,
+// so the file size is small for the workbook with many duplicate strings, but
+// the shared string table resides in memory and grows with every unique
+// string. With the StringModeInline mode, the strings are written inline in
+// the cells (t="inlineStr"), which avoids the unbounded growth of the shared
+// string table for the workbook with many unique strings, at the cost of a
+// bigger file size when the strings are duplicated. The mode only affects the
+// string cell values set after calling this function. For example, write
+// string cell values as inline strings:
+//
+//	f.SetStringMode(excelize.StringModeInline)
+func (f *File) SetStringMode(mode StringMode) {
+	f.stringMode = mode
+}
+
+// SharedStringsCount provides a function to get the number of unique strings
+// in the shared string table of the workbook.
+func (f *File) SharedStringsCount() (int, error) {
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return 0, err
+	}
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+	return len(sst.SI), err
+}
+
+// key returns the string which identifies the string item for deduplication.
+func (x *xlsxSI) key() string {
+	if x.T != nil && len(x.R) == 0 && !x.hasPhonetic() {
+		return "t:" + x.T.Space.Value + ":" + x.T.Val
+	}
+	key, _ := xml.Marshal(x)
+	return string(key)
+}
+
+// OptimizeSharedStrings provides a function to rebuild the shared string
+// table of the workbook. It converts the inline string cell values into shared
+// strings, deduplicates the string items, removes the string items which are
+// not referenced by any cell, and rewrites the string item indexes of the
+// cells. This reduces the file size of the workbook built with the
+// StringModeInline string mode, or which contains many duplicate inline
+// strings. Note that the worksheets written by the stream writer will not be
+// affected, and the existing string items will be kept if there are any
+// stream writers. For example:
+//
+//	f.SetStringMode(excelize.StringModeInline)
+//	// write a lot of string cell values...
+//	if err := f.OptimizeSharedStrings(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) OptimizeSharedStrings() error {
+	if err := f.checkReadOnly("OptimizeSharedStrings"); err != nil {
+		return err
+	}
+	if err := f.sharedStringsLoader(); err != nil {
+		return err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	sst.mu.Lock()
+	sharedItems := sst.SI
+	sst.mu.Unlock()
+	var (
+		count int
+		items []xlsxSI
+		index = map[string]int{}
+	)
+	getItemIndex := func(si xlsxSI) string {
+		key := si.key()
+		idx, ok := index[key]
+		if !ok {
+			items = append(items, si)
+			idx = len(items) - 1
+			index[key] = idx
+		}
+		count++
+		return strconv.Itoa(idx)
+	}
+	// keep all existing string items, which may be referenced by the stream
+	// writers
+	if len(f.streams) > 0 {
+		items = append(items, sharedItems...)
+		for idx := len(items) - 1; idx >= 0; idx-- {
+			index[items[idx].key()] = idx
+		}
+	}
+	for _, name := range f.GetSheetList() {
+		if sheetXMLPath, _ := f.getSheetXMLPath(name); f.streams[sheetXMLPath] != nil {
+			continue
+		}
+		f.mu.Lock()
+		ws, err := f.workSheetReader(name)
+		f.mu.Unlock()
+		if err != nil {
+			if errors.As(err, &ErrNotWorksheet{}) {
+				continue
+			}
+			return err
+		}
+		ws.mu.Lock()
+		for rowIdx := range ws.SheetData.Row {
+			for colIdx := range ws.SheetData.Row[rowIdx].C {
+				c := &ws.SheetData.Row[rowIdx].C[colIdx]
+				if c.T == "inlineStr" && c.IS != nil && c.F == nil {
+					c.T, c.V, c.IS = "s", getItemIndex(*c.IS), nil
+					continue
+				}
+				if siIdx, err := strconv.Atoi(c.V); err == nil && c.T == "s" && siIdx >= 0 && siIdx < len(sharedItems) {
+					c.V = getItemIndex(sharedItems[siIdx])
+				}
+			}
+		}
+		ws.mu.Unlock()
+	}
+	sst.mu.Lock()
+	sst.SI, sst.Count, sst.UniqueCount = items, count, len(items)
+	sst.mu.Unlock()
+	f.mu.Lock()
+	f.sharedStringsMap.build(sst)
+	f.mu.Unlock()
+	return err
 }
 
 // setCellString provides a function to set string type to shared string
@@ -434,6 +755,7 @@ func (f *File) sharedStringsLoader() (err error) {
 			return
 		}
 		f.SharedStrings = nil
+		f.sharedStringsMap.sst.Store((*xlsxSST)(nil))
 	}
 	if f.sharedStringTemp != nil {
 		if err := f.sharedStringTemp.Close(); err != nil {
@@ -446,28 +768,136 @@ func (f *File) sharedStringsLoader() (err error) {
 	return
 }
 
+// sharedStringsIndexShards defined the number of shards of the shared
+// strings index.
+const sharedStringsIndexShards = 64
+
+// sharedStringsIndex directly maps the index of the plain text items in the
+// shared string table. The index was split into shards by the hash of the
+// text, so that the concurrent lookups and inserts of the distinct strings
+// don't contend for a single lock. The sst field atomically stores the shared
+// string table which the index was built for, so that the index can be used
+// without locking the workbook once the shared string table was loaded.
+type sharedStringsIndex struct {
+	sst    atomic.Value
+	shards [sharedStringsIndexShards]sharedStringsShard
+}
+
+// sharedStringsShard directly maps a shard of the shared strings index.
+type sharedStringsShard struct {
+	mu    sync.RWMutex
+	items map[string]int
+}
+
+// newSharedStringsIndex provides a function to create an empty shared strings
+// index.
+func newSharedStringsIndex() *sharedStringsIndex {
+	idx := &sharedStringsIndex{}
+	idx.reset()
+	return idx
+}
+
+// shard provides a function to get the shard of the shared strings index by
+// given text, the shard was selected by the FNV-1a hash of the text.
+func (idx *sharedStringsIndex) shard(val string) *sharedStringsShard {
+	hash := uint32(2166136261)
+	for i := 0; i < len(val); i++ {
+		hash ^= uint32(val[i])
+		hash *= 16777619
+	}
+	return &idx.shards[hash%sharedStringsIndexShards]
+}
+
+// load provides a function to get the index of the shared string item by
+// given text.
+func (idx *sharedStringsIndex) load(val string) (int, bool) {
+	shard := idx.shard(val)
+	shard.mu.RLock()
+	i, ok := shard.items[val]
+	shard.mu.RUnlock()
+	return i, ok
+}
+
+// store provides a function to set the index of the shared string item by
+// given text.
+func (idx *sharedStringsIndex) store(val string, i int) {
+	shard := idx.shard(val)
+	shard.mu.Lock()
+	shard.items[val] = i
+	shard.mu.Unlock()
+}
+
+// reset provides a function to remove all items in the shared strings index.
+func (idx *sharedStringsIndex) reset() {
+	for i := range idx.shards {
+		idx.shards[i].mu.Lock()
+		idx.shards[i].items = make(map[string]int)
+		idx.shards[i].mu.Unlock()
+	}
+}
+
+// table provides a function to get the shared string table which the index
+// was built for, it returns nil if the index has not been built.
+func (idx *sharedStringsIndex) table() *xlsxSST {
+	sst, _ := idx.sst.Load().(*xlsxSST)
+	return sst
+}
+
+// build provides a function to rebuild the shared strings index by given
+// shared string table, it should be called with the workbook lock held.
+func (idx *sharedStringsIndex) build(sst *xlsxSST) {
+	idx.sst.Store((*xlsxSST)(nil))
+	idx.reset()
+	sst.mu.Lock()
+	items := sst.SI
+	sst.mu.Unlock()
+	for i := range items {
+		if items[i].T != nil {
+			idx.store(items[i].T.Val, i)
+		}
+	}
+	idx.sst.Store(sst)
+}
+
 // setSharedString provides a function to add string to the share string table.
+// The shared string table will be loaded and the index will be built at the
+// first time, after that the existing string will be found in the index and
+// the new string will be appended without locking the workbook. The index
+// and the new string item share the same string value without copying it.
 func (f *File) setSharedString(val string) (int, error) {
-	if err := f.sharedStringsLoader(); err != nil {
-		return 0, err
+	sst := f.sharedStringsMap.table()
+	if sst == nil || sst != f.SharedStrings {
+		if err := f.sharedStringsLoader(); err != nil {
+			return 0, err
+		}
+		var err error
+		if sst, err = f.sharedStringsReader(); err != nil {
+			return 0, err
+		}
 	}
-	sst, err := f.sharedStringsReader()
-	if err != nil {
-		return 0, err
+	if i, ok := f.sharedStringsMap.load(val); ok {
+		return i, nil
 	}
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	if i, ok := f.sharedStringsMap[val]; ok {
+	shard := f.sharedStringsMap.shard(val)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if i, ok := shard.items[val]; ok {
 		return i, nil
 	}
 	sst.mu.Lock()
 	defer sst.mu.Unlock()
+	// Grow the string items in batches to reduce the reallocation
+	if len(sst.SI) == cap(sst.SI) {
+		items := make([]xlsxSI, len(sst.SI), 2*len(sst.SI)+1024)
+		copy(items, sst.SI)
+		sst.SI = items
+	}
 	sst.Count++
 	sst.UniqueCount++
 	t := xlsxT{Val: val}
-	val, t.Space = trimCellValue(val, false)
+	_, t.Space = trimCellValue(val, false)
 	sst.SI = append(sst.SI, xlsxSI{T: &t})
-	f.sharedStringsMap[val] = sst.UniqueCount - 1
+	shard.items[val] = sst.UniqueCount - 1
 	return sst.UniqueCount - 1, nil
 }
 
@@ -520,9 +950,34 @@ func (c *xlsxC) setStr(val string) {
 	c.V, c.XMLSpace = trimCellValue(val, false)
 }
 
-// getCellDate parse cell value which containing a boolean.
-func (c *xlsxC) getCellBool(f *File, raw bool) (string, error) {
-	if !raw {
+// setFormulaResult provides a function to set the cell value and data type by
+// given calculated result of the formula.
+func (f *File) setFormulaResult(c *xlsxC, result formulaArg) error {
+	c.IS, c.Vm, c.XMLSpace = nil, nil, xml.Attr{}
+	switch result.Type {
+	case ArgNumber:
+		if result.Boolean {
+			c.T, c.V = setCellBool(result.Number != 0)
+			return nil
+		}
+		c.T, c.V = "", strings.ToUpper(strconv.FormatFloat(result.Number, 'G', 15, 64))
+	case ArgString:
+		if result.String == "" {
+			c.T, c.V = "", ""
+			return nil
+		}
+		return f.setCellStrValue(c, result.String)
+	case ArgError:
+		c.T, c.V = "e", result.String
+	default:
+		c.T, c.V = "", "0"
+	}
+	return nil
+}
+
+// getCellDate parse cell value which containing a boolean.
+func (c *xlsxC) getCellBool(f *File, raw bool) (string, error) {
+	if !raw {
 		if c.V == "1" {
 			return "TRUE", nil
 		}
@@ -570,8 +1025,14 @@ func (c *xlsxC) getCellDate(f *File, raw bool) (string, error) {
 
 // getValueFrom return a value from a column/row cell, this function is
 // intended to be used with for range on rows an argument with the spreadsheet
-// opened file.
-func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
+// opened file. The given style index inherited from the row or column will be
+// applied for the cell without style.
+func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool, style int) (string, error) {
+	if c.S == 0 && style != 0 {
+		inherit := *c
+		inherit.S = style
+		c = &inherit
+	}
 	switch c.T {
 	case "b":
 		return c.getCellBool(f, raw)
@@ -610,6 +1071,9 @@ func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
 // SetCellDefault provides a function to set string type value of a cell as
 // default format without escaping the cell.
 func (f *File) SetCellDefault(sheet, cell, value string) error {
+	if err := f.checkReadOnly("SetCellDefault"); err != nil {
+		return err
+	}
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -625,27 +1089,440 @@ func (f *File) SetCellDefault(sheet, cell, value string) error {
 	}
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	c.setCellDefault(value)
-	return f.removeFormula(c, ws, sheet)
+	return f.removeValueFormula(c, ws, sheet)
+}
+
+// RemoveCell provides a function to remove the cell by given worksheet name and
+// cell reference, the value, formula and style of the cell will be removed,
+// and the cell element will not be written into the worksheet. For example,
+// remove the cell A1 on Sheet1:
+//
+//	err := f.RemoveCell("Sheet1", "A1")
+func (f *File) RemoveCell(sheet, cell string) error {
+	if err := f.checkReadOnly("RemoveCell"); err != nil {
+		return err
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if row > len(ws.SheetData.Row) || col > len(ws.SheetData.Row[row-1].C) {
+		return err
+	}
+	c := &ws.SheetData.Row[row-1].C[col-1]
+	if err = f.removeFormula(c, ws, sheet); err != nil {
+		return err
+	}
+	*c = xlsxC{R: c.R}
+	return err
+}
+
+// ClearOptions directly maps the settings of clearing the cells in a range by
+// the ClearRange function.
+//
+// All specifies if clear everything in the range, which is the same as the
+// "Clear All" in Excel, all the other options will be ignored if it was set.
+//
+// Values specifies if clear the values of the cells.
+//
+// Formulas specifies if clear the formulas of the cells.
+//
+// Styles specifies if clear the styles of the cells.
+//
+// Hyperlinks specifies if remove the hyperlinks overlapping the range.
+//
+// Comments specifies if remove the comments of the cells.
+//
+// DataValidations specifies if remove the range from the data validations.
+//
+// UnmergeCells specifies if unmerge the merged cells overlapping the range,
+// the ClearRange function will return an error if the range overlaps part of
+// the merged cells and this option was not set.
+type ClearOptions struct {
+	All             bool
+	Values          bool
+	Formulas        bool
+	Styles          bool
+	Hyperlinks      bool
+	Comments        bool
+	DataValidations bool
+	UnmergeCells    bool
+}
+
+// ClearRange provides a function to clear the cells in a range by given
+// worksheet name, range reference and clear options. The range reference
+// could be a cell reference, range reference, whole columns or whole rows
+// reference. The cleared cells will be kept as empty cells, and the dimension
+// of the worksheet will be recalculated after clearing the values, formulas
+// or styles. For example, clear the values and formulas of the cells in the
+// range A1:C10 on Sheet1, and unmerge the merged cells in the range:
+//
+//	err := f.ClearRange("Sheet1", "A1:C10", excelize.ClearOptions{
+//	    Values:       true,
+//	    Formulas:     true,
+//	    UnmergeCells: true,
+//	})
+//
+// Clear everything in the range A1:C10 on Sheet1:
+//
+//	err := f.ClearRange("Sheet1", "A1:C10", excelize.ClearOptions{All: true})
+func (f *File) ClearRange(sheet, rangeRef string, opts ClearOptions) error {
+	if err := f.checkReadOnly("ClearRange"); err != nil {
+		return err
+	}
+	_, rect, err := parseRangeRef(rangeRef)
+	if err != nil {
+		return err
+	}
+	if opts.All {
+		opts = ClearOptions{
+			Values: true, Formulas: true, Styles: true, Hyperlinks: true,
+			Comments: true, DataValidations: true, UnmergeCells: true,
+		}
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	if err = f.clearRangeMergeCells(ws, rect, opts.UnmergeCells); err != nil {
+		return err
+	}
+	if opts.Comments {
+		comments, err := f.GetComments(sheet)
+		if err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			if col, row, err := CellNameToCoordinates(comment.Cell); err == nil && cellInRange([]int{col, row}, rect) {
+				if err = f.DeleteComment(sheet, comment.Cell); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if opts.DataValidations {
+		if err = f.DeleteDataValidation(sheet, rectToRangeRef(rect)); err != nil {
+			return err
+		}
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if opts.Hyperlinks {
+		f.clearRangeHyperlinks(ws, sheet, rect)
+	}
+	for row := rect[1]; row <= rect[3] && row <= len(ws.SheetData.Row); row++ {
+		for col := rect[0]; col <= rect[2] && col <= len(ws.SheetData.Row[row-1].C); col++ {
+			c := &ws.SheetData.Row[row-1].C[col-1]
+			if opts.Formulas {
+				if err = f.removeFormula(c, ws, sheet); err != nil {
+					return err
+				}
+			}
+			if opts.Values {
+				c.T, c.V, c.IS, c.Vm, c.Ph, c.XMLSpace = "", "", nil, nil, nil, xml.Attr{}
+			}
+			if opts.Styles {
+				c.S = 0
+			}
+		}
+	}
+	if ws.Dimension != nil && (opts.Values || opts.Formulas || opts.Styles) {
+		ws.updateDimension()
+	}
+	return err
+}
+
+// CopyRange provides a function to copy a rectangular range of cells by given
+// source worksheet name, source range reference, destination worksheet name,
+// the top-left cell reference of the destination range and copy options. The
+// values, formulas, styles and the merged cells inside the source range will
+// be copied by default, and the relative cell and range references in the
+// formulas will be translated by the offset between the source and
+// destination range, the absolute references with the dollar sign ($) will
+// be kept. The destination cells which are empty in the source range will be
+// cleared. For example, copy the range A1:C10 on Sheet1 to the range starts
+// from E1 on Sheet2:
+//
+//	err := f.CopyRange("Sheet1", "A1:C10", "Sheet2", "E1", excelize.CopyOptions{})
+//
+// Set the Transpose option to write the range rotated, the rows of the source
+// range become the columns of the destination range. Note that only the
+// values, styles and merged cells will be transposed, the formula cells will
+// be copied as their cached values, unless the TransposeFormulas option was
+// set. For example, transpose the range A1:C10 on Sheet1 to the range
+// A1:J3 on Sheet2:
+//
+//	err := f.CopyRange("Sheet1", "A1:C10", "Sheet2", "A1", excelize.CopyOptions{
+//	    Transpose: true,
+//	})
+func (f *File) CopyRange(srcSheet, srcRange, dstSheet, dstTopLeft string, opts CopyOptions) error {
+	if err := f.checkReadOnly("CopyRange"); err != nil {
+		return err
+	}
+	_, rect, err := parseRangeRef(srcRange)
+	if err != nil {
+		return err
+	}
+	dstCol, dstRow, err := CellNameToCoordinates(dstTopLeft)
+	if err != nil {
+		return err
+	}
+	width, height := rect[2]-rect[0], rect[3]-rect[1]
+	if opts.Transpose {
+		width, height = height, width
+	}
+	if dstCol+width > MaxColumns {
+		return ErrColumnNumber
+	}
+	if dstRow+height > TotalRows {
+		return ErrMaxRows
+	}
+	f.mu.Lock()
+	srcWs, err := f.workSheetReader(srcSheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	dstWs, err := f.workSheetReader(dstSheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	cells, mergeCells := srcWs.copyRangeCells(rect, opts)
+	dstWs.mu.Lock()
+	// Clear the existing cells in the destination range
+	for row := dstRow; row <= dstRow+height && row <= len(dstWs.SheetData.Row); row++ {
+		for col := dstCol; col <= dstCol+width && col <= len(dstWs.SheetData.Row[row-1].C); col++ {
+			c := &dstWs.SheetData.Row[row-1].C[col-1]
+			if err = f.removeFormula(c, dstWs, dstSheet); err != nil {
+				dstWs.mu.Unlock()
+				return err
+			}
+			c.T, c.V, c.IS, c.Cm, c.Vm, c.Ph, c.XMLSpace = "", "", nil, nil, nil, nil, xml.Attr{}
+			if !opts.ValuesOnly {
+				c.S = 0
+			}
+		}
+	}
+	colOffset, rowOffset := dstCol-rect[0], dstRow-rect[1]
+	for rowIdx, row := range cells {
+		for colIdx, src := range row {
+			if src == nil {
+				continue
+			}
+			col, row := dstCol+colIdx, dstRow+rowIdx
+			if opts.Transpose {
+				col, row = dstCol+rowIdx, dstRow+colIdx
+				colOffset, rowOffset = col-rect[0]-colIdx, row-rect[1]-rowIdx
+			}
+			dstWs.prepareSheetXML(col, row)
+			c := &dstWs.SheetData.Row[row-1].C[col-1]
+			c.T, c.V, c.IS, c.Ph, c.XMLSpace = src.T, src.V, src.IS, src.Ph, src.XMLSpace
+			if opts.ValuesOnly {
+				continue
+			}
+			c.S, c.Cm, c.Vm = src.S, src.Cm, src.Vm
+			if src.F == nil || opts.Transpose && !opts.TransposeFormulas {
+				continue
+			}
+			translate := func(formula string) string {
+				if opts.Transpose {
+					return transposeFormula(formula, srcSheet, rect, dstCol, dstRow, colOffset, rowOffset)
+				}
+				return translateFormula(formula, colOffset, rowOffset)
+			}
+			c.F = src.F
+			c.F.Content = translate(c.F.Content)
+			if c.F.Ref != "" {
+				c.F.Ref = translate(c.F.Ref)
+			}
+		}
+	}
+	dstWs.mu.Unlock()
+	for _, mergeRect := range mergeCells {
+		topLeft, _ := CoordinatesToCellName(mergeRect[0]+dstCol-rect[0], mergeRect[1]+dstRow-rect[1])
+		bottomRight, _ := CoordinatesToCellName(mergeRect[2]+dstCol-rect[0], mergeRect[3]+dstRow-rect[1])
+		if opts.Transpose {
+			topLeft, _ = CoordinatesToCellName(dstCol+mergeRect[1]-rect[1], dstRow+mergeRect[0]-rect[0])
+			bottomRight, _ = CoordinatesToCellName(dstCol+mergeRect[3]-rect[1], dstRow+mergeRect[2]-rect[0])
+		}
+		if err = f.MergeCell(dstSheet, topLeft, bottomRight); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// copyRangeCells provides a function to get the copies of the cells and the
+// coordinates of the merged cells inside the range by given sorted
+// coordinates of the range and copy options, the range will be limited to
+// the used area of the worksheet. The shared formulas will be copied as the
+// normal formulas of each cell.
+func (ws *xlsxWorksheet) copyRangeCells(rect []int, opts CopyOptions) ([][]*xlsxC, [][]int) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var (
+		cells      [][]*xlsxC
+		mergeCells [][]int
+		maxCol     int
+	)
+	for row := rect[1]; row <= rect[3] && row <= len(ws.SheetData.Row); row++ {
+		if n := len(ws.SheetData.Row[row-1].C); n > maxCol {
+			maxCol = n
+		}
+	}
+	if maxCol > rect[2] {
+		maxCol = rect[2]
+	}
+	for row := rect[1]; row <= rect[3] && row <= len(ws.SheetData.Row); row++ {
+		rowCells := make([]*xlsxC, 0, maxCol-rect[0]+1)
+		for col := rect[0]; col <= maxCol; col++ {
+			if col > len(ws.SheetData.Row[row-1].C) {
+				rowCells = append(rowCells, nil)
+				continue
+			}
+			c := deepcopy.Copy(ws.SheetData.Row[row-1].C[col-1]).(xlsxC)
+			if c.F != nil && !opts.ValuesOnly {
+				if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+					c.F = &xlsxF{Content: getSharedFormula(ws, *c.F.Si, c.R)}
+				}
+				c.F.Si = nil
+			}
+			rowCells = append(rowCells, &c)
+		}
+		cells = append(cells, rowCells)
+	}
+	if ws.MergeCells == nil || opts.ValuesOnly {
+		return cells, mergeCells
+	}
+	for _, mergeCell := range ws.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		if mergeRect, err := mergeCell.Rect(); err == nil && cellInRange(mergeRect[:2], rect) && cellInRange(mergeRect[2:], rect) {
+			mergeCells = append(mergeCells, append([]int{}, mergeRect...))
+		}
+	}
+	return cells, mergeCells
+}
+
+// clearRangeMergeCells provides a function to unmerge the merged cells which
+// overlapping the range by given sorted coordinates if unmerge is true,
+// otherwise returns an error if the range overlaps part of the merged cells.
+func (f *File) clearRangeMergeCells(ws *xlsxWorksheet, rect []int, unmerge bool) error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.MergeCells == nil {
+		return nil
+	}
+	if err := f.mergeOverlapCells(ws); err != nil {
+		return err
+	}
+	var mergeCells []*xlsxMergeCell
+	for _, mergeCell := range ws.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		mergeRect, err := mergeCell.Rect()
+		if err != nil {
+			return err
+		}
+		if isOverlap(rect, mergeRect) {
+			if unmerge {
+				continue
+			}
+			if !cellInRange(mergeRect[:2], rect) || !cellInRange(mergeRect[2:], rect) {
+				return ErrClearMergedCells
+			}
+		}
+		mergeCells = append(mergeCells, mergeCell)
+	}
+	ws.resetMergeCellsIndex()
+	ws.MergeCells.Cells, ws.MergeCells.Count = mergeCells, len(mergeCells)
+	if ws.MergeCells.Count == 0 {
+		ws.MergeCells = nil
+	}
+	return nil
+}
+
+// clearRangeHyperlinks provides a function to remove the hyperlinks which
+// overlapping the range by given worksheet and sorted coordinates.
+func (f *File) clearRangeHyperlinks(ws *xlsxWorksheet, sheet string, rect []int) {
+	if ws.Hyperlinks == nil {
+		return
+	}
+	var hyperlinks []xlsxHyperlink
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		if _, linkRect, err := parseRangeRef(link.Ref); err == nil && isOverlap(rect, linkRect) {
+			if link.RID != "" {
+				f.deleteSheetRelationships(sheet, link.RID)
+			}
+			continue
+		}
+		hyperlinks = append(hyperlinks, link)
+	}
+	if ws.Hyperlinks.Hyperlink = hyperlinks; len(hyperlinks) == 0 {
+		ws.Hyperlinks = nil
+	}
 }
 
 // GetCellFormula provides a function to get formula from cell by given
-// worksheet name and cell reference in spreadsheet.
-func (f *File) GetCellFormula(sheet, cell string) (string, error) {
-	return f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+// worksheet name and cell reference in spreadsheet. The formula will be
+// returned in the R1C1 reference style if the R1C1 field of the options was
+// set. For example, get the formula "=A1+B1" of the cell "C1" as
+// "=RC[-2]+RC[-1]":
+//
+//	formula, err := f.GetCellFormula("Sheet1", "C1", excelize.FormulaOpts{R1C1: true})
+func (f *File) GetCellFormula(sheet, cell string, opts ...FormulaOpts) (string, error) {
+	formula, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
 		if c.F == nil {
 			return "", false, nil
 		}
-		if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
-			return getSharedFormula(x, *c.F.Si, c.R), true, nil
-		}
-		return c.F.Content, true, nil
+		return x.getCellFormula(c), true, nil
 	})
+	if err != nil || formula == "" {
+		return formula, err
+	}
+	for _, opt := range opts {
+		if opt.R1C1 {
+			return FormulaA1ToR1C1(formula, cell)
+		}
+	}
+	return formula, err
 }
 
-// FormulaOpts can be passed to SetCellFormula to use other formula types.
+// getCellFormula provides a function to get the formula of the cell, the
+// formula of the shared formula cell will be translated from the master cell.
+func (ws *xlsxWorksheet) getCellFormula(c *xlsxC) string {
+	if c.F == nil {
+		return ""
+	}
+	if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+		return getSharedFormula(ws, *c.F.Si, c.R)
+	}
+	return c.F.Content
+}
+
+// FormulaOpts can be passed to SetCellFormula to use other formula types, and
+// can be passed to SetCellFormula and GetCellFormula to use the R1C1 reference
+// style.
 type FormulaOpts struct {
 	Type *string // Formula type
 	Ref  *string // Shared formula ref
+	R1C1 bool    // Formula in the R1C1 reference style
 }
 
 // SetCellFormula provides a function to set formula on the cell is taken
@@ -655,6 +1532,9 @@ type FormulaOpts struct {
 // can get the calculated cell value. If the Excel application doesn't
 // calculate the formula automatically when the workbook has been opened,
 // please call "UpdateLinkedValue" after setting the cell formula functions.
+// The array formula will be marked as a dynamic array formula by the cell
+// metadata, so that the result of the formula can spill in Excel 365, and the
+// cell metadata of the existing cells will be kept on updating the formula.
 //
 // Example 1, set normal formula "=SUM(A1,B1)" for the cell "A3" on "Sheet1":
 //
@@ -690,7 +1570,13 @@ type FormulaOpts struct {
 //	err := f.SetCellFormula("Sheet1", "C1", "=A1+B1",
 //	    excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
 //
-// Example 7, set table formula "=SUM(Table1[[A]:[B]])" for the cell "C2"
+// Example 7, set formula "=SUM(R[-2]C:R[-1]C)" in the R1C1 reference style for
+// the cell "A3" on "Sheet1", which will be stored as "=SUM(A1:A2)":
+//
+//	err := f.SetCellFormula("Sheet1", "A3", "=SUM(R[-2]C:R[-1]C)",
+//	    excelize.FormulaOpts{R1C1: true})
+//
+// Example 8, set table formula "=SUM(Table1[[A]:[B]])" for the cell "C2"
 // on "Sheet1":
 //
 //	package main
@@ -731,6 +1617,9 @@ type FormulaOpts struct {
 //	    }
 //	}
 func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts) error {
+	if err := f.checkReadOnly("SetCellFormula"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -740,9 +1629,16 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 		return err
 	}
 	if formula == "" {
-		c.F = nil
+		c.F, c.Cm = nil, nil
 		return f.deleteCalcChain(f.getSheetID(sheet), cell)
 	}
+	for _, opt := range opts {
+		if opt.R1C1 {
+			if formula, err = FormulaR1C1ToA1(formula, cell); err != nil {
+				return err
+			}
+		}
+	}
 
 	if c.F != nil {
 		c.F.Content = formula
@@ -766,10 +1662,186 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 			c.F.Ref = *opt.Ref
 		}
 	}
+	if c.F.T == STCellFormulaTypeArray && c.Cm == nil {
+		if c.F.Ref == "" {
+			c.F.Ref = cell
+		}
+		var cm uint
+		if cm, err = f.setDynamicArrayMetadata(); err != nil {
+			return err
+		}
+		c.Cm = &cm
+	}
 	c.T, c.IS = "str", nil
 	return err
 }
 
+// getMetadataPath provides a function to get the path of the metadata part
+// of the workbook, and returns false if the workbook relationship of the
+// metadata part does not exist.
+func (f *File) getMetadataPath() (string, bool, error) {
+	metadataPath, exist := defaultXMLPathMetadata, false
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil {
+		return metadataPath, exist, err
+	}
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipSheetMetadata {
+				metadataPath, exist = f.getWorksheetPath(rel.Target), true
+			}
+		}
+		rels.mu.Unlock()
+	}
+	return metadataPath, exist, err
+}
+
+// metadataReader provides a function to get the pointer to the structure
+// after deserialization of the metadata part of the workbook.
+func (f *File) metadataReader() (*xlsxMetadata, error) {
+	if f.Metadata != nil {
+		return f.Metadata, nil
+	}
+	metadataPath, _, err := f.getMetadataPath()
+	if err != nil {
+		return nil, err
+	}
+	metadata := new(xlsxMetadata)
+	content := namespaceStrictToTransitional(f.readXML(metadataPath))
+	if _, ok := f.xmlAttr[metadataPath]; !ok && len(content) > 0 {
+		f.xmlAttr[metadataPath] = getRootElement(f.xmlNewDecoder(bytes.NewReader(content)))
+	}
+	if err = f.xmlNewDecoder(bytes.NewReader(content)).
+		Decode(metadata); err != nil && err != io.EOF {
+		return metadata, err
+	}
+	f.Metadata = metadata
+	return f.Metadata, nil
+}
+
+// metadataWriter provides a function to save the metadata part after
+// serialize structure.
+func (f *File) metadataWriter() {
+	if f.Metadata != nil {
+		metadataPath, _, _ := f.getMetadataPath()
+		output, _ := xml.Marshal(f.Metadata)
+		f.saveFileList(metadataPath, f.replaceNameSpaceBytes(metadataPath, output))
+	}
+}
+
+// isDynamicArrayRecord provides a function to check if the future metadata
+// record contains the dynamic array properties of the not collapsed dynamic
+// array formula.
+func isDynamicArrayRecord(bk xlsxInnerXML) bool {
+	var record decodeFutureMetadataBlock
+	if err := xml.Unmarshal([]byte("<bk>"+bk.Content+"</bk>"), &record); err != nil {
+		return false
+	}
+	for _, ext := range record.Ext {
+		if ext.URI == ExtURIDynamicArrayProperties && ext.DynamicArrayProperties != nil {
+			return ext.DynamicArrayProperties.FDynamic && !ext.DynamicArrayProperties.FCollapsed
+		}
+	}
+	return false
+}
+
+// setDynamicArrayMetadata provides a function to get the 1-based index of the
+// cell metadata which marks the array formula as a dynamic array formula. The
+// dynamic array properties metadata type, future metadata and cell metadata
+// records will be created in the metadata part if not exist.
+func (f *File) setDynamicArrayMetadata() (uint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	metadata, err := f.metadataReader()
+	if err != nil {
+		return 0, err
+	}
+	metadataPath, exist, err := f.getMetadataPath()
+	if err != nil {
+		return 0, err
+	}
+	if metadata.MetadataTypes == nil {
+		metadata.MetadataTypes = new(xlsxMetadataTypes)
+	}
+	typeIdx := -1
+	for i, metadataType := range metadata.MetadataTypes.MetadataType {
+		if metadataType.Name == "XLDAPR" {
+			typeIdx = i + 1
+		}
+	}
+	if typeIdx == -1 {
+		metadata.MetadataTypes.MetadataType = append(metadata.MetadataTypes.MetadataType, xlsxMetadataType{
+			Name: "XLDAPR", MinSupportedVersion: 120000, Copy: true, PasteAll: true,
+			PasteValues: true, Merge: true, SplitFirst: true, RowColShift: true,
+			ClearFormats: true, ClearComments: true, Assign: true, Coerce: true, CellMeta: true,
+		})
+		typeIdx = len(metadata.MetadataTypes.MetadataType)
+	}
+	metadata.MetadataTypes.Count = len(metadata.MetadataTypes.MetadataType)
+	var future *xlsxFutureMetadata
+	for i := range metadata.FutureMetadata {
+		if metadata.FutureMetadata[i].Name == "XLDAPR" {
+			future = &metadata.FutureMetadata[i]
+		}
+	}
+	if future == nil {
+		metadata.FutureMetadata = append(metadata.FutureMetadata, xlsxFutureMetadata{Name: "XLDAPR"})
+		future = &metadata.FutureMetadata[len(metadata.FutureMetadata)-1]
+	}
+	recordIdx := -1
+	for i, bk := range future.Bk {
+		if isDynamicArrayRecord(bk) {
+			recordIdx = i
+			break
+		}
+	}
+	if recordIdx == -1 {
+		future.Bk = append(future.Bk, xlsxInnerXML{Content: fmt.Sprintf(
+			`<extLst><ext uri="%s"><xda:dynamicArrayProperties fDynamic="1" fCollapsed="0"/></ext></extLst>`,
+			ExtURIDynamicArrayProperties)})
+		recordIdx = len(future.Bk) - 1
+	}
+	future.Count = len(future.Bk)
+	if metadata.CellMetadata == nil {
+		metadata.CellMetadata = new(xlsxMetadataBlocks)
+	}
+	cellIdx := -1
+	for i, bk := range metadata.CellMetadata.Bk {
+		if len(bk.Rc) == 1 && bk.Rc[0].T == typeIdx && bk.Rc[0].V == recordIdx {
+			cellIdx = i
+			break
+		}
+	}
+	if cellIdx == -1 {
+		metadata.CellMetadata.Bk = append(metadata.CellMetadata.Bk, xlsxMetadataBlock{
+			Rc: []xlsxMetadataRecord{{T: typeIdx, V: recordIdx}},
+		})
+		cellIdx = len(metadata.CellMetadata.Bk) - 1
+	}
+	metadata.CellMetadata.Count = len(metadata.CellMetadata.Bk)
+	// Declare the dynamic array namespace in the root element
+	if _, ok := f.xmlAttr[metadataPath]; !ok {
+		f.xmlAttr[metadataPath] = []xml.Attr{NameSpaceSpreadSheet}
+	}
+	var declared bool
+	for _, attr := range f.xmlAttr[metadataPath] {
+		if attr.Name.Space == NameSpaceDynamicArray.Name.Space && attr.Name.Local == NameSpaceDynamicArray.Name.Local {
+			declared = true
+		}
+	}
+	if !declared {
+		f.xmlAttr[metadataPath] = append(f.xmlAttr[metadataPath], NameSpaceDynamicArray)
+	}
+	if !exist {
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSheetMetadata, strings.TrimPrefix(metadataPath, "xl/"), "")
+		if err = f.setContentTypes("/"+metadataPath, ContentTypeSheetMetadata); err != nil {
+			return 0, err
+		}
+	}
+	return uint(cellIdx + 1), err
+}
+
 // setSharedFormula set shared formula for the cells.
 func (ws *xlsxWorksheet) setSharedFormula(ref string) error {
 	coordinates, err := rangeRefToCoordinates(ref)
@@ -813,29 +1885,254 @@ func (ws *xlsxWorksheet) countSharedFormula() (count int) {
 //
 //	link, target, err := f.GetCellHyperLink("Sheet1", "H6")
 func (f *File) GetCellHyperLink(sheet, cell string) (bool, string, error) {
+	link, err := f.getCellHyperLink(sheet, cell)
+	if err != nil || link == nil {
+		return false, "", err
+	}
+	if link.RID != "" {
+		return true, f.getSheetRelationshipsTargetByID(sheet, link.RID), err
+	}
+	return true, link.Location, err
+}
+
+// GetCellHyperLinkOpts provides a function to get the optional attributes of
+// the cell hyperlink by given worksheet name and cell reference, including
+// the display text and the tooltip. The link address of the hyperlink could
+// be get by the GetCellHyperLink function. For example, get the tooltip of the
+// hyperlink of the cell H6 on Sheet1:
+//
+//	opts, err := f.GetCellHyperLinkOpts("Sheet1", "H6")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if opts.Tooltip != nil {
+//	    fmt.Println(*opts.Tooltip)
+//	}
+func (f *File) GetCellHyperLinkOpts(sheet, cell string) (HyperlinkOpts, error) {
+	var opts HyperlinkOpts
+	link, err := f.getCellHyperLink(sheet, cell)
+	if err != nil || link == nil {
+		return opts, err
+	}
+	if link.Display != "" {
+		opts.Display = stringPtr(link.Display)
+	}
+	if link.Tooltip != "" {
+		opts.Tooltip = stringPtr(link.Tooltip)
+	}
+	return opts, err
+}
+
+// GetCellHyperLinkType provides a function to get the type of the cell
+// hyperlink by given worksheet name and cell reference. It returns "External"
+// for the hyperlink to the website or the external file, "Location" for the
+// internal hyperlink to a cell, range or defined name in this workbook, and
+// an empty string if the cell doesn't have a hyperlink. For example, get the
+// type of the hyperlink of the cell H6 on Sheet1:
+//
+//	linkType, err := f.GetCellHyperLinkType("Sheet1", "H6")
+func (f *File) GetCellHyperLinkType(sheet, cell string) (string, error) {
+	link, err := f.getCellHyperLink(sheet, cell)
+	return link.linkType(), err
+}
+
+// linkType returns the type of the hyperlink, "External" for the hyperlink
+// with the relationship ID, "Location" for the internal hyperlink, and an
+// empty string for the nil hyperlink.
+func (link *xlsxHyperlink) linkType() string {
+	if link == nil {
+		return ""
+	}
+	if link.RID != "" {
+		return "External"
+	}
+	return "Location"
+}
+
+// getCellHyperLink provides a function to get the hyperlink of the cell by
+// given worksheet name and cell reference, it returns nil if the cell doesn't
+// have a hyperlink.
+func (f *File) getCellHyperLink(sheet, cell string) (*xlsxHyperlink, error) {
 	// Check for correct cell name
 	if _, _, err := SplitCellName(cell); err != nil {
-		return false, "", err
+		return nil, err
 	}
+	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
 	if err != nil {
-		return false, "", err
+		return nil, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.getCellHyperLink(cell)
+}
+
+// getCellHyperLink provides a function to get the copy of the hyperlink of the
+// cell by given cell reference, it returns nil if the cell doesn't have a
+// hyperlink.
+func (ws *xlsxWorksheet) getCellHyperLink(cell string) (*xlsxHyperlink, error) {
+	if ws.Hyperlinks == nil {
+		return nil, nil
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		if link.Ref == cell {
+			return &link, err
+		}
+		if !strings.Contains(link.Ref, ":") {
+			continue
+		}
+		rect, err := rangeRefToCoordinates(link.Ref)
+		if err != nil {
+			return nil, err
+		}
+		if cellInRange([]int{col, row}, rect) {
+			return &link, err
+		}
+	}
+	return nil, err
+}
+
+// DeleteCellHyperLink provides a function to delete the hyperlink of the cell
+// by given worksheet name and cell reference. This function only deletes the
+// hyperlink of the cell and doesn't affect the value and style of the cell.
+// For example, delete the hyperlink of the cell H6 on Sheet1:
+//
+//	err := f.DeleteCellHyperLink("Sheet1", "H6")
+func (f *File) DeleteCellHyperLink(sheet, cell string) error {
+	if err := f.checkReadOnly("DeleteCellHyperLink"); err != nil {
+		return err
+	}
+	return f.DeleteCellHyperLinks(sheet, cell+":"+cell)
+}
+
+// DeleteCellHyperLinks provides a function to delete the hyperlinks of the
+// cells in the range by given worksheet name and range reference. The
+// hyperlink which reference intersects with the range will be deleted, and
+// the relationship of the external hyperlink will be also deleted when no
+// other hyperlinks in the worksheet refer to it. For example, delete the
+// hyperlinks in the range A1:C10 on Sheet1:
+//
+//	err := f.DeleteCellHyperLinks("Sheet1", "A1:C10")
+func (f *File) DeleteCellHyperLinks(sheet, rangeRef string) error {
+	if err := f.checkReadOnly("DeleteCellHyperLinks"); err != nil {
+		return err
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.Hyperlinks == nil {
+		return err
 	}
+	var (
+		links   []xlsxHyperlink
+		deleted []string
+	)
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		ref := link.Ref
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		rect, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(rect)
+		if !isOverlap(coordinates, rect) {
+			links = append(links, link)
+			continue
+		}
+		if link.RID != "" {
+			deleted = append(deleted, link.RID)
+		}
+	}
+	if ws.Hyperlinks.Hyperlink = links; len(links) == 0 {
+		ws.Hyperlinks = nil
+	}
+	for _, rID := range deleted {
+		f.deleteUnusedHyperLinkRels(ws, sheet, rID)
+	}
+	return err
+}
+
+// deleteUnusedHyperLinkRels provides a function to delete the relationship of
+// the external hyperlink by given worksheet, worksheet name and relationship
+// ID if no hyperlinks in the worksheet refer to it.
+func (f *File) deleteUnusedHyperLinkRels(ws *xlsxWorksheet, sheet, rID string) {
 	if ws.Hyperlinks != nil {
 		for _, link := range ws.Hyperlinks.Hyperlink {
-			ok, err := f.checkCellInRangeRef(cell, link.Ref)
-			if err != nil {
-				return false, "", err
-			}
-			if link.Ref == cell || ok {
-				if link.RID != "" {
-					return true, f.getSheetRelationshipsTargetByID(sheet, link.RID), err
-				}
-				return true, link.Location, err
+			if link.RID == rID {
+				return
 			}
 		}
 	}
-	return false, "", err
+	f.deleteSheetRelationships(sheet, rID)
+}
+
+// prepareHyperLinkTarget provides a function to convert the target of the
+// external hyperlink to the form that the spreadsheet application accepts.
+// The UNC path will be converted to the file URI, and the addresses and the
+// header fields of the mailto link will be percent-encoded.
+func prepareHyperLinkTarget(link string) string {
+	if strings.HasPrefix(link, `\\`) {
+		return "file:///" + link
+	}
+	if len(link) > 7 && strings.EqualFold(link[:7], "mailto:") {
+		return prepareMailtoLink(link)
+	}
+	return link
+}
+
+// prepareMailtoLink provides a function to percent-encode the addresses and
+// the header fields of the mailto link. The characters which have been
+// percent-encoded will be kept, and the spaces will be encoded as %20 instead
+// of the plus sign.
+func prepareMailtoLink(link string) string {
+	unescape := func(s string) string {
+		if v, err := url.PathUnescape(s); err == nil {
+			return v
+		}
+		return s
+	}
+	addrs, query := link[7:], ""
+	if idx := strings.Index(addrs, "?"); idx != -1 {
+		addrs, query = addrs[:idx], addrs[idx+1:]
+	}
+	var buf strings.Builder
+	buf.WriteString(link[:7])
+	for i, addr := range strings.Split(addrs, ",") {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(url.PathEscape(unescape(addr)))
+	}
+	if query == "" {
+		return buf.String()
+	}
+	buf.WriteString("?")
+	for i, field := range strings.Split(query, "&") {
+		if i > 0 {
+			buf.WriteString("&")
+		}
+		name, value := field, ""
+		if idx := strings.Index(field, "="); idx != -1 {
+			name, value = field[:idx], field[idx+1:]
+			value = "=" + strings.ReplaceAll(url.QueryEscape(unescape(value)), "+", "%20")
+		}
+		buf.WriteString(strings.ReplaceAll(url.QueryEscape(unescape(name)), "+", "%20") + value)
+	}
+	return buf.String()
 }
 
 // HyperlinkOpts can be passed to SetCellHyperlink to set optional hyperlink
@@ -871,10 +2168,21 @@ type HyperlinkOpts struct {
 //	}
 //	err = f.SetCellStyle("Sheet1", "A3", "A3", style)
 //
-// This is another example for "Location":
+// This is another example for "Location", the internal hyperlink without the
+// relationship, the link could be a cell or range reference with the sheet
+// name, or a defined name, and the leading "#" of the link will be removed:
+//
+//	tooltip := "Go to Sheet1!A40"
+//	err := f.SetCellHyperLink("Sheet1", "A3", "Sheet1!A40", "Location",
+//	    excelize.HyperlinkOpts{Tooltip: &tooltip})
 //
-//	err := f.SetCellHyperLink("Sheet1", "A3", "Sheet1!A40", "Location")
+// Link to the defined name "Totals":
+//
+//	err := f.SetCellHyperLink("Sheet1", "A4", "Totals", "Location")
 func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...HyperlinkOpts) error {
+	if err := f.checkReadOnly("SetCellHyperLink"); err != nil {
+		return err
+	}
 	// Check for correct cell name
 	if _, _, err := SplitCellName(cell); err != nil {
 		return err
@@ -909,16 +2217,21 @@ func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...Hype
 	case "External":
 		sheetPath, _ := f.getSheetXMLPath(sheet)
 		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
-		rID := f.setRels(linkData.RID, sheetRels, SourceRelationshipHyperLink, link, linkType)
+		rID := f.setRels(linkData.RID, sheetRels, SourceRelationshipHyperLink, prepareHyperLinkTarget(link), linkType)
 		linkData = xlsxHyperlink{
 			Ref: cell,
 		}
 		linkData.RID = "rId" + strconv.Itoa(rID)
 		f.addSheetNameSpace(sheet, SourceRelationship)
 	case "Location":
+		rID := linkData.RID
 		linkData = xlsxHyperlink{
 			Ref:      cell,
-			Location: link,
+			Location: strings.TrimPrefix(link, "#"),
+		}
+		if rID != "" {
+			ws.Hyperlinks.Hyperlink[idx].RID = ""
+			f.deleteUnusedHyperLinkRels(ws, sheet, rID)
 		}
 	default:
 		return fmt.Errorf("invalid link type %q", linkType)
@@ -943,8 +2256,9 @@ func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...Hype
 // getCellRichText returns rich text of cell by given string item.
 func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
 	for _, v := range si.R {
-		run := RichTextRun{
-			Text: v.T.Val,
+		var run RichTextRun
+		if v.T != nil {
+			run.Text = bstrUnmarshal(v.T.Val)
 		}
 		if v.RPr != nil {
 			run.Font = newFont(v.RPr)
@@ -955,7 +2269,8 @@ func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
 }
 
 // GetCellRichText provides a function to get rich text of cell by given
-// worksheet.
+// worksheet. Both of the shared string and inline string rich text are
+// supported.
 func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err error) {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -965,6 +2280,12 @@ func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err erro
 	if err != nil {
 		return
 	}
+	if c.T == "inlineStr" {
+		if c.IS != nil {
+			runs = getCellRichText(c.IS)
+		}
+		return
+	}
 	siIdx, err := strconv.Atoi(c.V)
 	if err != nil || c.T != "s" {
 		return
@@ -1184,6 +2505,9 @@ func setRichText(runs []RichTextRun) ([]xlsxR, error) {
 //	    }
 //	}
 func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
+	if err := f.checkReadOnly("SetCellRichText"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -1217,57 +2541,498 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 	return err
 }
 
+// hasPhonetic determine if the string item contains phonetic hints.
+func (x *xlsxSI) hasPhonetic() bool {
+	return len(x.RPh) > 0 || x.PhoneticPr != nil
+}
+
+// setPhonetic provides a function to set the phonetic hint runs and phonetic
+// properties of the string item.
+func (x *xlsxSI) setPhonetic(runs []PhoneticRun, props PhoneticProperties) error {
+	if props.Type != "" && inStrSlice(supportedPhoneticTypes, props.Type, true) == -1 {
+		return newInvalidOptionalValue("Type", props.Type, supportedPhoneticTypes)
+	}
+	if props.Alignment != "" && inStrSlice(supportedPhoneticAlignments, props.Alignment, true) == -1 {
+		return newInvalidOptionalValue("Alignment", props.Alignment, supportedPhoneticAlignments)
+	}
+	if props.FontID < 0 {
+		return ErrParameterInvalid
+	}
+	x.RPh, x.PhoneticPr = nil, nil
+	if len(runs) == 0 && props == (PhoneticProperties{Visible: props.Visible}) {
+		return nil
+	}
+	length, end := utf8.RuneCountInString(x.String()), 0
+	for _, run := range runs {
+		if run.Text == "" || run.StartIndex < end || run.StartIndex >= run.EndIndex || run.EndIndex > length {
+			return ErrParameterInvalid
+		}
+		end = run.EndIndex
+		x.RPh = append(x.RPh, &xlsxPhoneticRun{Sb: uint32(run.StartIndex), Eb: uint32(run.EndIndex), T: run.Text})
+	}
+	x.PhoneticPr = &xlsxPhoneticPr{FontID: intPtr(props.FontID), Type: props.Type, Alignment: props.Alignment}
+	return nil
+}
+
+// getCellStringItem provides a function to get the shared string item or
+// inline string item of the cell, it returns nil if the cell doesn't contain
+// a string value.
+func (f *File) getCellStringItem(c *xlsxC) (*xlsxSI, error) {
+	if c.T == "inlineStr" {
+		return c.IS, nil
+	}
+	siIdx, err := strconv.Atoi(c.V)
+	if err != nil || c.T != "s" {
+		return nil, nil
+	}
+	if err = f.sharedStringsLoader(); err != nil {
+		return nil, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return nil, err
+	}
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+	if siIdx < 0 || siIdx >= len(sst.SI) {
+		return nil, err
+	}
+	return &sst.SI[siIdx], err
+}
+
+// GetCellPhonetic provides a function to get the phonetic hint runs
+// (furigana) and phonetic properties of the cell by given worksheet name and
+// cell reference. Both of the shared string and inline string cell values are
+// supported, and the zero values will be returned if the cell doesn't exist.
+func (f *File) GetCellPhonetic(sheet, cell string) ([]PhoneticRun, PhoneticProperties, error) {
+	var (
+		runs  []PhoneticRun
+		props PhoneticProperties
+	)
+	_, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		si, err := f.getCellStringItem(c)
+		if err != nil || si == nil {
+			return "", true, err
+		}
+		for _, run := range si.RPh {
+			runs = append(runs, PhoneticRun{StartIndex: int(run.Sb), EndIndex: int(run.Eb), Text: run.T})
+		}
+		if si.PhoneticPr != nil {
+			if si.PhoneticPr.FontID != nil {
+				props.FontID = *si.PhoneticPr.FontID
+			}
+			props.Type, props.Alignment = si.PhoneticPr.Type, si.PhoneticPr.Alignment
+		}
+		props.Visible = c.Ph != nil && *c.Ph
+		return "", true, nil
+	})
+	return runs, props, err
+}
+
+// SetCellPhonetic provides a function to set the phonetic hint runs
+// (furigana) and phonetic properties for the cell with string value by given
+// worksheet name and cell reference. The existing phonetic hints of the cell
+// will be replaced, and set runs to nil with empty properties to remove the
+// phonetic hints. For example, set the phonetic hints for the cell A1 with
+// value "東京都" in the worksheet named Sheet1 and show them above the value:
+//
+//	err := f.SetCellValue("Sheet1", "A1", "東京都")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetCellPhonetic("Sheet1", "A1", []excelize.PhoneticRun{
+//	    {StartIndex: 0, EndIndex: 2, Text: "トウキョウ"},
+//	    {StartIndex: 2, EndIndex: 3, Text: "ト"},
+//	}, excelize.PhoneticProperties{Type: "Hiragana", Visible: true})
+//
+// The phonetic hints will be kept on rewriting the same string value of the
+// cell by the SetCellValue or SetCellStr functions.
+func (f *File) SetCellPhonetic(sheet, cell string, runs []PhoneticRun, props PhoneticProperties) error {
+	if err := f.checkReadOnly("SetCellPhonetic"); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	c, _, _, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	si, err := f.getCellStringItem(c)
+	if err != nil {
+		return err
+	}
+	if si == nil {
+		return ErrCellPhonetic
+	}
+	item := *si
+	if err = item.setPhonetic(runs, props); err != nil {
+		return err
+	}
+	c.Ph = nil
+	if props.Visible {
+		c.Ph = boolPtr(true)
+	}
+	if c.T == "inlineStr" {
+		c.IS = &item
+		return err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+	for idx, strItem := range sst.SI {
+		if reflect.DeepEqual(strItem, item) {
+			c.V = strconv.Itoa(idx)
+			return err
+		}
+	}
+	sst.SI = append(sst.SI, item)
+	sst.Count++
+	sst.UniqueCount++
+	c.V = strconv.Itoa(len(sst.SI) - 1)
+	return err
+}
+
+// SheetCellsOptions directly maps the settings of writing the cell values by
+// the SetSheetRow, SetSheetCol and SetSheetRows functions.
+//
+// SkipNil specifies if skip the nil values and keep the existing cells
+// unchanged. The values of the existing cells will be cleared for the nil
+// values by default, and the cell styles will be kept.
+type SheetCellsOptions struct {
+	SkipNil bool
+}
+
 // SetSheetRow writes an array to row by given worksheet name, starting
 // cell reference and a pointer to array type 'slice'. This function is
-// concurrency safe. For example, writes an array to row 6 start with the cell
-// B6 on Sheet1:
+// concurrency safe. The pointers to []interface{}, []string, []float64, []int
+// and []time.Time will be written without reflection, and the worksheet will
+// be locked only once. For example, writes an array to row 6 start with the
+// cell B6 on Sheet1:
 //
 //	err := f.SetSheetRow("Sheet1", "B6", &[]interface{}{"1", nil, 2})
-func (f *File) SetSheetRow(sheet, cell string, slice interface{}) error {
-	return f.setSheetCells(sheet, cell, slice, rows)
+//
+// The values of the existing cells will be cleared for the nil values by
+// default, set the SkipNil field of the options to keep the cells unchanged:
+//
+//	err := f.SetSheetRow("Sheet1", "B6", &[]interface{}{"1", nil, 2},
+//	    excelize.SheetCellsOptions{SkipNil: true})
+func (f *File) SetSheetRow(sheet, cell string, slice interface{}, opts ...SheetCellsOptions) error {
+	if err := f.checkReadOnly("SetSheetRow"); err != nil {
+		return err
+	}
+	return f.setSheetCells(sheet, cell, slice, rows, opts...)
 }
 
 // SetSheetCol writes an array to column by given worksheet name, starting
-// cell reference and a pointer to array type 'slice'. For example, writes an
-// array to column B start with the cell B6 on Sheet1:
+// cell reference and a pointer to array type 'slice'. The supported slice
+// types and the options are the same as the SetSheetRow function. For
+// example, writes an array to column B start with the cell B6 on Sheet1:
 //
 //	err := f.SetSheetCol("Sheet1", "B6", &[]interface{}{"1", nil, 2})
-func (f *File) SetSheetCol(sheet, cell string, slice interface{}) error {
-	return f.setSheetCells(sheet, cell, slice, columns)
+func (f *File) SetSheetCol(sheet, cell string, slice interface{}, opts ...SheetCellsOptions) error {
+	if err := f.checkReadOnly("SetSheetCol"); err != nil {
+		return err
+	}
+	return f.setSheetCells(sheet, cell, slice, columns, opts...)
 }
 
-// setSheetCells provides a function to set worksheet cells value.
-func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDirection) error {
+// SetSheetRows writes a two-dimensional array to the worksheet by given
+// worksheet name, top-left cell reference and the rows of the values, the
+// worksheet will be locked only once. The options are the same as the
+// SetSheetRow function. For example, writes 2 rows start with the cell B6 on
+// Sheet1:
+//
+//	err := f.SetSheetRows("Sheet1", "B6", [][]interface{}{
+//	    {"Name", "Score"},
+//	    {"Excelize", 100},
+//	})
+func (f *File) SetSheetRows(sheet, topLeftCell string, values [][]interface{}, opts ...SheetCellsOptions) error {
+	if err := f.checkReadOnly("SetSheetRows"); err != nil {
+		return err
+	}
+	col, row, err := CellNameToCoordinates(topLeftCell)
+	if err != nil {
+		return err
+	}
+	return f.setCellValues(sheet, opts, func(fn func(col, row int, value interface{}) error) error {
+		for r := range values {
+			for c := range values[r] {
+				if err := fn(col+c, row+r, values[r][c]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// SetSheetRowStruct writes the fields of a struct to a row by given worksheet
+// name, starting cell reference and a struct or pointer to struct, or writes a
+// header row and the rows of the fields by given slice or pointer to slice of
+// structs. The "excel" struct field tags specify the header name and the
+// optional number format of the fields, the field name will be used as the
+// header name if it was omitted, the fields tagged with "-" and the unexported
+// fields will be skipped, and the fields of the embedded structs will be
+// flattened. The supported field types are string, bool, the numeric types,
+// time.Time and the pointers to them, the cells of the nil pointer fields
+// will be skipped. For example, write the header row and the rows of orders
+// start with the cell A1 on Sheet1:
+//
+//	type Order struct {
+//	    ID      int       `excel:"Order ID"`
+//	    Amount  float64   `excel:"Amount,format=#,##0.00"`
+//	    Date    time.Time `excel:"Date,format=yyyy-mm-dd"`
+//	    Comment string    `excel:"-"`
+//	}
+//	err := f.SetSheetRowStruct("Sheet1", "A1", []Order{
+//	    {ID: 1, Amount: 1234.5, Date: time.Now()},
+//	    {ID: 2, Amount: 99, Date: time.Now()},
+//	})
+//
+// Note that the number format after the "format=" is the rest of the tag,
+// so it may contain commas.
+func (f *File) SetSheetRowStruct(sheet, cell string, v interface{}) error {
+	if err := f.checkReadOnly("SetSheetRowStruct"); err != nil {
+		return err
+	}
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
 	}
-	// Make sure 'slice' is a Ptr to Slice
-	v := reflect.ValueOf(slice)
-	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if !val.IsValid() {
 		return ErrParameterInvalid
 	}
-	v = v.Elem()
-	for i := 0; i < v.Len(); i++ {
-		var cell string
-		var err error
-		if dir == rows {
-			cell, err = CoordinatesToCellName(col+i, row)
-		} else {
-			cell, err = CoordinatesToCellName(col, row+i)
+	typ := val.Type()
+	if val.Kind() == reflect.Slice {
+		typ = typ.Elem()
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
 		}
-		// Error should never happen here. But keep checking to early detect regressions
-		// if it will be introduced in the future.
+	}
+	if typ.Kind() != reflect.Struct {
+		return ErrParameterInvalid
+	}
+	fields, styles := getStructFields(typ, nil), make(map[string]int)
+	if val.Kind() == reflect.Struct {
+		return f.setStructRow(sheet, col, row, val, fields, styles)
+	}
+	for i, field := range fields {
+		cell, err := CoordinatesToCellName(col+i, row)
 		if err != nil {
 			return err
 		}
-		if err := f.SetCellValue(sheet, cell, v.Index(i).Interface()); err != nil {
+		if err = f.SetCellStr(sheet, cell, field.name); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < val.Len(); i++ {
+		item := val.Index(i)
+		for item.Kind() == reflect.Ptr && !item.IsNil() {
+			item = item.Elem()
+		}
+		if item.Kind() != reflect.Struct {
+			continue
+		}
+		if err = f.setStructRow(sheet, col, row+i+1, item, fields, styles); err != nil {
 			return err
 		}
 	}
 	return err
 }
 
+// setStructRow provides a function to set the cell values of a row by given
+// worksheet name, coordinates, struct value and fields of the struct.
+func (f *File) setStructRow(sheet string, col, row int, val reflect.Value, fields []structField, styles map[string]int) error {
+	for i, field := range fields {
+		cell, err := CoordinatesToCellName(col+i, row)
+		if err != nil {
+			return err
+		}
+		value, ok := getStructFieldValue(val, field.index)
+		for ok && value.Kind() == reflect.Ptr {
+			if ok = !value.IsNil(); ok {
+				value = value.Elem()
+			}
+		}
+		if !ok {
+			continue
+		}
+		if !isStructFieldTypeSupported(value.Type()) {
+			return newUnsupportedFieldTypeError(field.name, value.Type().String())
+		}
+		if err = f.SetCellValue(sheet, cell, getStructFieldCellValue(value)); err != nil {
+			return err
+		}
+		if field.format == "" {
+			continue
+		}
+		styleID, ok := styles[field.format]
+		if !ok {
+			if styleID, err = f.NewStyle(&Style{CustomNumFmt: &field.format}); err != nil {
+				return err
+			}
+			styles[field.format] = styleID
+		}
+		if err = f.SetCellStyle(sheet, cell, cell, styleID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setSheetCells provides a function to set worksheet cells value.
+func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDirection, opts ...SheetCellsOptions) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	var (
+		length int
+		value  func(i int) interface{}
+	)
+	switch s := slice.(type) {
+	case *[]interface{}:
+		length, value = len(*s), func(i int) interface{} { return (*s)[i] }
+	case *[]string:
+		length, value = len(*s), func(i int) interface{} { return (*s)[i] }
+	case *[]float64:
+		length, value = len(*s), func(i int) interface{} { return (*s)[i] }
+	case *[]int:
+		length, value = len(*s), func(i int) interface{} { return (*s)[i] }
+	case *[]time.Time:
+		length, value = len(*s), func(i int) interface{} { return (*s)[i] }
+	default:
+		// Make sure 'slice' is a Ptr to Slice
+		v := reflect.ValueOf(slice)
+		if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+			return ErrParameterInvalid
+		}
+		v = v.Elem()
+		length, value = v.Len(), func(i int) interface{} { return v.Index(i).Interface() }
+	}
+	return f.setCellValues(sheet, opts, func(fn func(col, row int, value interface{}) error) error {
+		for i := 0; i < length; i++ {
+			c, r := col+i, row
+			if dir == columns {
+				c, r = col, row+i
+			}
+			if err := fn(c, r, value(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// setCellValues provides a function to set the values of the cells by given
+// worksheet name, options and the function which iterates the coordinates and
+// values of the cells, the worksheet will be locked only once.
+func (f *File) setCellValues(sheet string, opts []SheetCellsOptions, iterate func(fn func(col, row int, value interface{}) error) error) error {
+	var options SheetCellsOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	date1904 := wb.WorkbookPr != nil && wb.WorkbookPr.Date1904
+	timeStyles := make(map[int]int)
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return iterate(func(col, row int, value interface{}) error {
+		if value == nil && options.SkipNil {
+			return nil
+		}
+		cell, err := CoordinatesToCellName(col, row)
+		if err != nil {
+			return err
+		}
+		c, col, row, err := ws.prepareCell(cell)
+		if err != nil {
+			return err
+		}
+		c.S = ws.prepareCellStyle(col, row, c.S)
+		numFmt, err := f.setPreparedCellValue(c, value, date1904)
+		if err != nil {
+			return err
+		}
+		if numFmt != 0 && c.S == 0 {
+			if _, ok := timeStyles[numFmt]; !ok {
+				if timeStyles[numFmt], err = f.NewStyle(&Style{NumFmt: numFmt}); err != nil {
+					return err
+				}
+			}
+			c.S = timeStyles[numFmt]
+		}
+		return f.removeValueFormula(c, ws, sheet)
+	})
+}
+
+// setPreparedCellValue provides a function to set the value of the prepared
+// cell by given value, it returns the built-in number format ID which should
+// be applied for the cell without style, such as the time and duration values.
+func (f *File) setPreparedCellValue(c *xlsxC, value interface{}, date1904 bool) (int, error) {
+	c.XMLSpace = xml.Attr{}
+	switch v := value.(type) {
+	case string:
+		return 0, f.setCellStrValue(c, v)
+	case []byte:
+		return 0, f.setCellStrValue(c, string(v))
+	}
+	c.IS = nil
+	switch v := value.(type) {
+	case int:
+		c.T, c.V = setCellInt(v)
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		c.T, c.V = "", fmt.Sprint(v)
+	case float32:
+		c.T, c.V = setCellFloat(float64(v), -1, 32)
+	case float64:
+		c.T, c.V = setCellFloat(v, -1, 64)
+	case time.Duration:
+		_, d := setCellDuration(v)
+		c.setCellDefault(d)
+		return 21, nil
+	case time.Time:
+		isNum, err := c.setCellTime(v, date1904)
+		if isNum {
+			return 22, err
+		}
+		return 0, err
+	case bool:
+		c.T, c.V = setCellBool(v)
+	case nil:
+		c.setCellDefault("")
+	default:
+		return 0, f.setCellStrValue(c, fmt.Sprint(value))
+	}
+	return 0, nil
+}
+
 // getCellInfo does common preparation for all set cell value functions.
 func (ws *xlsxWorksheet) prepareCell(cell string) (*xlsxC, int, int, error) {
 	var err error
@@ -1284,6 +3049,23 @@ func (ws *xlsxWorksheet) prepareCell(cell string) (*xlsxC, int, int, error) {
 	return &ws.SheetData.Row[row-1].C[col-1], col, row, err
 }
 
+// getCell provides a function to get the pointer to the cell by given cell
+// reference and row number, it returns nil if the cell doesn't exist.
+func (ws *xlsxWorksheet) getCell(cell string, row int) *xlsxC {
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
+		if rowData.R != row {
+			continue
+		}
+		for colIdx := range rowData.C {
+			if rowData.C[colIdx].R == cell {
+				return &rowData.C[colIdx]
+			}
+		}
+	}
+	return nil
+}
+
 // getCellStringFunc does common value extraction workflow for all get cell
 // value function. Passed function implements specific part of required
 // logic.
@@ -1389,26 +3171,59 @@ func (f *File) applyNumFmt(c *xlsxC, styleSheet *xlsxStyleSheet, numFmtID int, d
 }
 
 // prepareCellStyle provides a function to prepare style index of cell in
-// worksheet by given column index and style index.
+// worksheet by given column index and style index. The style of the cell takes
+// precedence over the row style, and the row style takes precedence over the
+// column style.
 func (ws *xlsxWorksheet) prepareCellStyle(col, row, style int) int {
+	var (
+		rowStyle int
+		cols     []xlsxCol
+	)
+	if row <= len(ws.SheetData.Row) {
+		rowStyle = ws.SheetData.Row[row-1].S
+	}
+	if ws.Cols != nil {
+		cols = ws.Cols.Col
+	}
+	return inheritCellStyle(col, style, rowStyle, cols)
+}
+
+// inheritCellStyle provides a function to get the style index of cell by
+// given column number, style index of the cell, style index of the row and the
+// column definitions. The style of the cell takes precedence over the row
+// style, and the row style takes precedence over the column style.
+func inheritCellStyle(col, style, rowStyle int, cols []xlsxCol) int {
 	if style != 0 {
 		return style
 	}
-	if row <= len(ws.SheetData.Row) {
-		if styleID := ws.SheetData.Row[row-1].S; styleID != 0 {
-			return styleID
-		}
+	if rowStyle != 0 {
+		return rowStyle
 	}
-	if ws.Cols != nil {
-		for _, c := range ws.Cols.Col {
-			if c.Min <= col && col <= c.Max && c.Style != 0 {
-				return c.Style
-			}
+	for _, c := range cols {
+		if c.Min <= col && col <= c.Max && c.Style != 0 {
+			return c.Style
 		}
 	}
 	return style
 }
 
+// parseColStyle provides a function to parse the column range and style index
+// by given col element of the worksheet for the streaming readers.
+func parseColStyle(el *xml.StartElement) xlsxCol {
+	var col xlsxCol
+	for _, attr := range el.Attr {
+		switch attr.Name.Local {
+		case "min":
+			col.Min, _ = strconv.Atoi(attr.Value)
+		case "max":
+			col.Max, _ = strconv.Atoi(attr.Value)
+		case "style":
+			col.Style, _ = strconv.Atoi(attr.Value)
+		}
+	}
+	return col
+}
+
 // mergeCellsParser provides a function to check merged cells in worksheet by
 // given cell reference.
 func (ws *xlsxWorksheet) mergeCellsParser(cell string) (string, error) {
@@ -1417,29 +3232,12 @@ func (ws *xlsxWorksheet) mergeCellsParser(cell string) (string, error) {
 	if err != nil {
 		return cell, err
 	}
-	if ws.MergeCells != nil {
-		for i := 0; i < len(ws.MergeCells.Cells); i++ {
-			if ws.MergeCells.Cells[i] == nil {
-				ws.MergeCells.Cells = append(ws.MergeCells.Cells[:i], ws.MergeCells.Cells[i+1:]...)
-				i--
-				continue
-			}
-			if ref := ws.MergeCells.Cells[i].Ref; len(ws.MergeCells.Cells[i].rect) == 0 && ref != "" {
-				if strings.Count(ref, ":") != 1 {
-					ref += ":" + ref
-				}
-				rect, err := rangeRefToCoordinates(ref)
-				if err != nil {
-					return cell, err
-				}
-				_ = sortCoordinates(rect)
-				ws.MergeCells.Cells[i].rect = rect
-			}
-			if cellInRange([]int{col, row}, ws.MergeCells.Cells[i].rect) {
-				cell = strings.Split(ws.MergeCells.Cells[i].Ref, ":")[0]
-				break
-			}
-		}
+	mergeCell, err := ws.getMergeCell(col, row)
+	if err != nil {
+		return cell, err
+	}
+	if mergeCell != nil {
+		cell = strings.Split(mergeCell.Ref, ":")[0]
 	}
 	return cell, nil
 }
@@ -1471,14 +3269,8 @@ func cellInRange(cell, ref []int) bool {
 
 // isOverlap find if the given two rectangles overlap or not.
 func isOverlap(rect1, rect2 []int) bool {
-	return cellInRange([]int{rect1[0], rect1[1]}, rect2) ||
-		cellInRange([]int{rect1[2], rect1[1]}, rect2) ||
-		cellInRange([]int{rect1[0], rect1[3]}, rect2) ||
-		cellInRange([]int{rect1[2], rect1[3]}, rect2) ||
-		cellInRange([]int{rect2[0], rect2[1]}, rect1) ||
-		cellInRange([]int{rect2[2], rect2[1]}, rect1) ||
-		cellInRange([]int{rect2[0], rect2[3]}, rect1) ||
-		cellInRange([]int{rect2[2], rect2[3]}, rect1)
+	return rect1[0] <= rect2[2] && rect2[0] <= rect1[2] &&
+		rect1[1] <= rect2[3] && rect2[1] <= rect1[3]
 }
 
 // parseSharedFormula generate dynamic part of shared formula for target cell
@@ -3,12 +3,14 @@ package excelize
 import (
 	"fmt"
 	_ "image/jpeg"
+	"io"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -239,6 +241,26 @@ func TestSetCellValue(t *testing.T) {
 	f.WorkBook = nil
 	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.SetCellValue("Sheet1", "A1", time.Now().UTC()), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test the cells set by the SetCellValue and SetSheetRow are the same
+	values := []interface{}{
+		1, int8(-2), uint64(3), float32(0.1), 0.2, "a", []byte("b"), time.Hour,
+		time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), true, nil, struct{}{},
+	}
+	f = NewFile()
+	for i, value := range values {
+		cell, err := CoordinatesToCellName(i+1, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &values))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for i, c := range ws.(*xlsxWorksheet).SheetData.Row[0].C {
+		expected := ws.(*xlsxWorksheet).SheetData.Row[1].C[i]
+		assert.Equal(t, []string{expected.T, expected.V}, []string{c.T, c.V}, c.R)
+		assert.Equal(t, expected.S, c.S, c.R)
+	}
 }
 
 func TestSetCellValues(t *testing.T) {
@@ -450,6 +472,66 @@ func TestGetCellType(t *testing.T) {
 	cellType, err = f.GetCellType("Sheet1", "A1")
 	assert.NoError(t, err)
 	assert.Equal(t, CellTypeSharedString, cellType)
+	// Test get cell type of each types
+	dateStyle, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	timeFmt := "hh:mm:ss"
+	timeStyle, err := f.NewStyle(&Style{CustomNumFmt: &timeFmt})
+	assert.NoError(t, err)
+	literalFmt := "0 \"days\""
+	literalStyle, err := f.NewStyle(&Style{CustomNumFmt: &literalFmt})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 100))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", 45078))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A5", "A5", dateStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A6", 0.5))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A6", "A6", timeStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A7", 3))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A7", "A7", literalStyle))
+	assert.NoError(t, f.SetCellError("Sheet1", "A8", "#N/A"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A9", "A2*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A10", "A5+1"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A10", "A10", dateStyle))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A11", "1/0"))
+	assert.NoError(t, f.SetCellInt("Sheet1", "A12", 1))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A13", "text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A14", nil))
+	f.SetStringMode(StringModeInline)
+	assert.NoError(t, f.SetCellStr("Sheet1", "A15", "inline"))
+	for cell, expected := range map[string]CellType{
+		"A2": CellTypeNumber, "A3": CellTypeBool, "A4": CellTypeDate, "A5": CellTypeDate,
+		"A6": CellTypeDate, "A7": CellTypeNumber, "A8": CellTypeError, "A9": CellTypeFormula,
+		"A10": CellTypeFormula, "A12": CellTypeNumber, "A13": CellTypeSharedString,
+		"A14": CellTypeUnset, "A15": CellTypeInlineString,
+	} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	// Test the date and time number format check results are cached by the
+	// style index
+	for styleID, expected := range map[int]bool{dateStyle: true, timeStyle: true, literalStyle: false} {
+		dateTime, ok := f.Styles.dateTimeStyles[styleID]
+		assert.True(t, ok, styleID)
+		assert.Equal(t, expected, dateTime, styleID)
+	}
+	assert.False(t, f.isDateTimeStyle(len(f.Styles.CellXfs.Xf)))
+	// Test get cell type of the formula cells with cached values
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[8].C[0].T, ws.(*xlsxWorksheet).SheetData.Row[8].C[0].V = "", "200"
+	ws.(*xlsxWorksheet).SheetData.Row[9].C[0].T, ws.(*xlsxWorksheet).SheetData.Row[9].C[0].V = "", "45079"
+	ws.(*xlsxWorksheet).SheetData.Row[10].C[0].T, ws.(*xlsxWorksheet).SheetData.Row[10].C[0].V = "e", "#DIV/0!"
+	ws.(*xlsxWorksheet).SheetData.Row[11].C[0] = xlsxC{R: "A12", T: "str", V: "text", F: &xlsxF{Content: "A13"}}
+	for cell, expected := range map[string]CellType{
+		"A9": CellTypeNumber, "A10": CellTypeDate, "A11": CellTypeFormulaError, "A12": CellTypeFormulaString,
+	} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
 	_, err = f.GetCellType("Sheet1", "A")
 	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 	// Test get cell type with invalid sheet name
@@ -457,17 +539,78 @@ func TestGetCellType(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestSetCellError(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "#DIV/0!"))
+	assert.NoError(t, f.SetCellError("Sheet1", "A2", "#DIV/0!"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "1/0"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A4", "A2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A5", "CONCAT(\"a\",\"b\")"))
+	assert.NoError(t, f.SetCellError("Sheet1", "A6", "#n/a"))
+	_, err := f.CalcCellValue("Sheet1", "A3")
+	assert.EqualError(t, err, "#DIV/0!")
+	assert.NoError(t, f.SetCellError("Sheet1", "B3", err.Error()))
+	result, err := f.CalcCellValue("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "#DIV/0!", result)
+	assert.NoError(t, f.ConvertFormulasToValues("Sheet1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A5", "CONCAT(\"a\",\"b\")"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "e", ws.(*xlsxWorksheet).SheetData.Row[1].C[0].T)
+	// Test get cell type of the formula cell with cached error value
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[0].F = &xlsxF{Content: "1/0"}
+	for cell, expected := range map[string]CellType{
+		"A1": CellTypeSharedString, "A2": CellTypeError, "A3": CellTypeFormulaError,
+		"A4": CellTypeError, "A5": CellTypeFormulaString, "A6": CellTypeError, "B3": CellTypeError,
+	} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	for cell, expected := range map[string]string{"A2": "#DIV/0!", "A3": "#DIV/0!", "A6": "#N/A", "B3": "#DIV/0!"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	// Test set and get all supported error values with number format
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "C", style))
+	for i, value := range []string{
+		CellErrorCALC, CellErrorDIV, CellErrorGETTINGDATA, CellErrorNA, CellErrorNAME,
+		CellErrorNULL, CellErrorNUM, CellErrorREF, CellErrorSPILL, CellErrorVALUE,
+	} {
+		cell, err := CoordinatesToCellName(3, i+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellError("Sheet1", cell, value))
+		result, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, value, result, cell)
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeError, cellType, cell)
+	}
+	// Test set cell error with invalid error value
+	assert.EqualError(t, f.SetCellError("Sheet1", "A1", "#ERROR"), newInvalidCellErrorValueError("#ERROR").Error())
+	// Test set cell error with invalid sheet name
+	assert.EqualError(t, f.SetCellError("Sheet:1", "A1", "#N/A"), ErrSheetNameInvalid.Error())
+	// Test set cell error with invalid cell reference
+	assert.EqualError(t, f.SetCellError("Sheet1", "A", "#N/A"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.NoError(t, f.Close())
+}
+
 func TestGetValueFrom(t *testing.T) {
 	f := NewFile()
 	c := xlsxC{T: "s"}
 	sst, err := f.sharedStringsReader()
 	assert.NoError(t, err)
-	value, err := c.getValueFrom(f, sst, false)
+	value, err := c.getValueFrom(f, sst, false, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, "", value)
 
 	c = xlsxC{T: "s", V: " 1 "}
-	value, err = c.getValueFrom(f, &xlsxSST{Count: 1, SI: []xlsxSI{{}, {T: &xlsxT{Val: "s"}}}}, false)
+	value, err = c.getValueFrom(f, &xlsxSST{Count: 1, SI: []xlsxSI{{}, {T: &xlsxT{Val: "s"}}}}, false, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, "s", value)
 }
@@ -556,6 +699,135 @@ func TestOverflowNumericCell(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestCellFormulaR1C1(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C3", "=SUM(R[-2]C:R[-1]C5,R1)", FormulaOpts{R1C1: true}))
+	formula, err := f.GetCellFormula("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, "=SUM(C1:$E2,$1:$1)", formula)
+	formula, err = f.GetCellFormula("Sheet1", "C3", FormulaOpts{R1C1: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "=SUM(R[-2]C:R[-1]C5,R1:R1)", formula)
+	// Test get the shared formula in the R1C1 reference style
+	formulaType, ref := STCellFormulaTypeShared, "D1:D3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=A1+RC[-2]", FormulaOpts{Ref: &ref, Type: &formulaType, R1C1: true}))
+	formula, err = f.GetCellFormula("Sheet1", "D3", FormulaOpts{R1C1: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "=RC[-3]+RC[-2]", formula)
+	formula, err = f.GetCellFormula("Sheet1", "D3")
+	assert.NoError(t, err)
+	assert.Equal(t, "=A3+B3", formula)
+	// Test get the formula of the cell without formula
+	formula, err = f.GetCellFormula("Sheet1", "A1", FormulaOpts{R1C1: true})
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	// Test set formula with invalid R1C1 reference
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A1", "=R[-1]C", FormulaOpts{R1C1: true}), newInvalidR1C1RefError("R[-1]C").Error())
+}
+
+func TestSetCellFormulaDynamicArray(t *testing.T) {
+	f := NewFile()
+	formulaType, ref := STCellFormulaTypeArray, "C1:C3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "SEQUENCE(3)", FormulaOpts{Type: &formulaType}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "SORT(A1:A3)", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, uintPtr(1), ws.SheetData.Row[0].C[0].Cm)
+	assert.Equal(t, "A1", ws.SheetData.Row[0].C[0].F.Ref)
+	assert.Equal(t, uintPtr(1), ws.SheetData.Row[0].C[2].Cm)
+	metadata, err := f.metadataReader()
+	assert.NoError(t, err)
+	assert.Equal(t, "XLDAPR", metadata.MetadataTypes.MetadataType[0].Name)
+	assert.Len(t, metadata.FutureMetadata[0].Bk, 1)
+	assert.Equal(t, []xlsxMetadataBlock{{Rc: []xlsxMetadataRecord{{T: 1, V: 0}}}}, metadata.CellMetadata.Bk)
+	// Test the metadata part will be serialized on saving the workbook
+	assert.Equal(t, f.Metadata, metadata)
+	_, ok := f.Pkg.Load(defaultXMLPathMetadata)
+	assert.False(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellFormulaDynamicArray.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test update the value and formula of the cells will keep the cell metadata
+	f, err = OpenFile(filepath.Join("test", "TestSetCellFormulaDynamicArray.xlsx"))
+	assert.NoError(t, err)
+	content, ok := f.Pkg.Load(defaultXMLPathMetadata)
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `xmlns:xda="http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray"`)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "SEQUENCE(5)"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "text"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "SEQUENCE(2)", FormulaOpts{Type: &formulaType}))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, uintPtr(1), ws.SheetData.Row[0].C[0].Cm)
+	assert.Equal(t, "SEQUENCE(5)", ws.SheetData.Row[0].C[0].F.Content)
+	assert.Equal(t, uintPtr(1), ws.SheetData.Row[0].C[4].Cm)
+	// Test remove the formula of the cells will remove the cell metadata
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", 1))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", ""))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "SORT(A1:A3)", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.ClearRange("Sheet1", "C1", ClearOptions{Formulas: true}))
+	for _, col := range []int{0, 2, 4} {
+		assert.Nil(t, ws.SheetData.Row[0].C[col].F)
+		assert.Nil(t, ws.SheetData.Row[0].C[col].Cm)
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	assert.NoError(t, err)
+	var count int
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipSheetMetadata {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+	assert.NoError(t, f.Close())
+
+	// Test add dynamic array formula in the workbook with rich value metadata
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathMetadata, []byte(`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"><metadataTypes count="1"><metadataType name="XLRICHVALUE" minSupportedVersion="120000" copy="1" pasteAll="1" pasteValues="1" merge="1" splitFirst="1" rowColShift="1" clearFormats="1" clearComments="1" assign="1" coerce="1"/></metadataTypes><futureMetadata name="XLRICHVALUE" count="1"><bk><extLst><ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"><xlrd:rvb i="0"/></ext></extLst></bk></futureMetadata><valueMetadata count="1"><bk><rc t="1" v="0"/></bk></valueMetadata></metadata>`))
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSheetMetadata, "metadata.xml", "")
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "SEQUENCE(3)", FormulaOpts{Type: &formulaType}))
+	f.metadataWriter()
+	content, ok = f.Pkg.Load(defaultXMLPathMetadata)
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"`)
+	metadata, err = f.metadataReader()
+	assert.NoError(t, err)
+	assert.Len(t, metadata.MetadataTypes.MetadataType, 2)
+	assert.Equal(t, []xlsxMetadataBlock{{Rc: []xlsxMetadataRecord{{T: 2, V: 0}}}}, metadata.CellMetadata.Bk)
+	assert.Equal(t, []xlsxMetadataBlock{{Rc: []xlsxMetadataRecord{{T: 1, V: 0}}}}, metadata.ValueMetadata.Bk)
+	assert.Equal(t, `<extLst><ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"><xlrd:rvb i="0"/></ext></extLst>`, metadata.FutureMetadata[0].Bk[0].Content)
+	assert.NoError(t, f.Close())
+
+	// Test add dynamic array formula in the workbook with the collapsed dynamic
+	// array properties metadata record
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathMetadata, []byte(`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:xda="http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray"><metadataTypes count="1"><metadataType name="XLDAPR" minSupportedVersion="120000" copy="1" pasteAll="1" pasteValues="1" merge="1" splitFirst="1" rowColShift="1" clearFormats="1" clearComments="1" assign="1" coerce="1" cellMeta="1"/></metadataTypes><futureMetadata name="XLDAPR" count="3"><bk><extLst><ext uri="{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"><xda:dynamicArrayProperties fDynamic="1" fCollapsed="1"/></ext></extLst></bk><bk><extLst><ext uri="{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"><xda:dynamicArrayProperties fCollapsed="false" fDynamic="true"/></ext></extLst></bk><bk><extLst/></bk></futureMetadata><cellMetadata count="1"><bk><rc t="1" v="0"/></bk></cellMetadata></metadata>`))
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSheetMetadata, "metadata.xml", "")
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "SEQUENCE(3)", FormulaOpts{Type: &formulaType}))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, uintPtr(2), ws.SheetData.Row[0].C[0].Cm)
+	assert.Len(t, f.Metadata.FutureMetadata[0].Bk, 3)
+	assert.Equal(t, []xlsxMetadataBlock{{Rc: []xlsxMetadataRecord{{T: 1, V: 0}}}, {Rc: []xlsxMetadataRecord{{T: 1, V: 1}}}}, f.Metadata.CellMetadata.Bk)
+	assert.False(t, isDynamicArrayRecord(xlsxInnerXML{Content: "<extLst>"}))
+	assert.NoError(t, f.Close())
+
+	// Test add dynamic array formula with unsupported charset metadata
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathMetadata, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A1", "SEQUENCE(3)", FormulaOpts{Type: &formulaType}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add dynamic array formula with unsupported charset workbook relationships
+	f = NewFile()
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A1", "SEQUENCE(3)", FormulaOpts{Type: &formulaType}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add dynamic array formula with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A1", "SEQUENCE(3)", FormulaOpts{Type: &formulaType}), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetCellFormula(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -699,6 +971,61 @@ func TestGetCellRichText(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetCellInlineString(t *testing.T) {
+	// The worksheet with inline string cells in the form of the LibreOffice
+	// Calc generated spreadsheet
+	sheetXML := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheetData><row r="1" customFormat="false" ht="12.8" hidden="false" customHeight="false" outlineLevel="0" collapsed="false"><c r="A1" s="0" t="inlineStr"><is><t>Hello</t></is></c><c r="B1" s="0" t="inlineStr"><is><r><rPr><b val="true"/><sz val="10"/><rFont val="Arial"/><family val="2"/></rPr><t xml:space="preserve">Rich </t></r><r><rPr><sz val="10"/><rFont val="Arial"/><family val="2"/></rPr><t>Text</t></r></is></c><c r="C1" s="0" t="n"><v>1</v></c></row><row r="2" customFormat="false" ht="12.8" hidden="false" customHeight="false" outlineLevel="0" collapsed="false"><c r="A2" s="0" t="inlineStr"><is><t xml:space="preserve"> Space _x000D_</t></is></c><c r="B2" s="0" t="inlineStr"><is><t/></is></c></row></sheetData></worksheet>`
+	expected := [][]string{{"Hello", "Rich Text", "1"}, {" Space \r"}}
+	f := NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(sheetXML))
+	f.checked = nil
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCellInlineString.xlsx")))
+	assert.NoError(t, f.Close())
+
+	for _, opts := range []Options{{}, {UnzipXMLSizeLimit: 128}} {
+		f, err := OpenFile(filepath.Join("test", "TestGetCellInlineString.xlsx"), opts)
+		assert.NoError(t, err)
+		// Test get inline string by rows iterator
+		rows, err := f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, rows)
+		// Test get inline string by columns iterator
+		cols, err := f.GetCols("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"Hello", " Space \r"}, {"Rich Text", ""}, {"1"}}, cols)
+		// Test get inline string by cell reference
+		for cell, value := range map[string]string{"A1": "Hello", "B1": "Rich Text", "A2": " Space \r", "B2": ""} {
+			val, err := f.GetCellValue("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, value, val, cell)
+			cellType, err := f.GetCellType("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, CellTypeInlineString, cellType, cell)
+		}
+		// Test get rich text of the inline string
+		runs, err := f.GetCellRichText("Sheet1", "B1")
+		assert.NoError(t, err)
+		assert.Equal(t, []RichTextRun{
+			{Text: "Rich ", Font: &Font{Bold: true, Underline: "none", Family: "Arial", Size: 10}},
+			{Text: "Text", Font: &Font{Underline: "none", Family: "Arial", Size: 10}},
+		}, runs)
+		runs, err = f.GetCellRichText("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Nil(t, runs)
+		assert.NoError(t, f.Close())
+	}
+	// Test get rich text of the inline string cell without string item
+	f = NewFile()
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData = xlsxSheetData{Row: []xlsxRow{{R: 1, C: []xlsxC{{R: "A1", T: "inlineStr"}}}}}
+	runs, err := f.GetCellRichText("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, runs)
+}
+
 func TestSetCellRichText(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 35))
@@ -1006,3 +1333,714 @@ func TestSharedStringsError(t *testing.T) {
 func TestSIString(t *testing.T) {
 	assert.Empty(t, xlsxSI{}.String())
 }
+
+func TestSetStringMode(t *testing.T) {
+	f := NewFile()
+	count, err := f.SharedStringsCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	f.SetStringMode(StringModeInline)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", " <a & b>"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A2", "inline"))
+	cellType, err := f.GetCellType("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeInlineString, cellType)
+	count, err = f.SharedStringsCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	f.SetStringMode(StringModeShared)
+	assert.NoError(t, f.SetCellStr("Sheet1", "A3", "shared"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A4", "shared"))
+	cellType, err = f.GetCellType("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeSharedString, cellType)
+	count, err = f.SharedStringsCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"A1": " <a & b>", "A2": "inline", "A3": "shared", "A4": "shared"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	// Test get the number of shared strings with unsupported charset
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.SharedStringsCount()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func BenchmarkSetStringMode(b *testing.B) {
+	for _, c := range []struct {
+		name string
+		mode StringMode
+	}{{"shared", StringModeShared}, {"inline", StringModeInline}} {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				f := NewFile()
+				f.SetStringMode(c.mode)
+				for row := 1; row <= 10000; row++ {
+					if err := f.SetCellStr("Sheet1", "A"+strconv.Itoa(row), "unique string "+strconv.Itoa(row)); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if _, err := f.WriteTo(io.Discard); err != nil {
+					b.Fatal(err)
+				}
+				if err := f.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestOptimizeSharedStrings(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "shared"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A2", "unused"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A2", "inline"))
+	f.SetStringMode(StringModeInline)
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, cell := range []string{"A1", "A2", "A3"} {
+		assert.NoError(t, f.SetCellStr("Sheet2", cell, "inline"))
+	}
+	assert.NoError(t, f.SetCellStr("Sheet2", "A4", "shared"))
+	assert.NoError(t, f.SetCellRichText("Sheet2", "A5", []RichTextRun{{Text: "rich", Font: &Font{Bold: true}}}))
+	// Test optimize shared strings with the chartsheet
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Line, Series: []ChartSeries{{Values: "Sheet1!$B$1:$B$2"}}}))
+	assert.NoError(t, f.OptimizeSharedStrings())
+	count, err := f.SharedStringsCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, 7, f.SharedStrings.Count)
+	for sheet, cells := range map[string]map[string]string{
+		"Sheet1": {"A1": "shared", "A2": "inline"},
+		"Sheet2": {"A1": "inline", "A2": "inline", "A3": "inline", "A4": "shared", "A5": "rich"},
+	} {
+		for cell, expected := range cells {
+			cellType, err := f.GetCellType(sheet, cell)
+			assert.NoError(t, err)
+			assert.Equal(t, CellTypeSharedString, cellType)
+			value, err := f.GetCellValue(sheet, cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, value)
+		}
+	}
+	runs, err := f.GetCellRichText("Sheet2", "A5")
+	assert.NoError(t, err)
+	assert.True(t, runs[0].Font.Bold)
+	// Test write shared strings after optimization
+	f.SetStringMode(StringModeShared)
+	assert.NoError(t, f.SetCellStr("Sheet1", "A3", "inline"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A4", "new"))
+	count, err = f.SharedStringsCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOptimizeSharedStrings.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test optimize shared strings with the stream writer
+	f = NewFile()
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "unused"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "shared"))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet2", StreamWriterOptions{SharedStrings: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"unused", "stream"}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.OptimizeSharedStrings())
+	count, err = f.SharedStringsCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, f.Close())
+	// Test optimize shared strings with unsupported charset
+	f = NewFile()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.OptimizeSharedStrings(), "XML syntax error on line 1: invalid UTF-8")
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.OptimizeSharedStrings(), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func BenchmarkOptimizeSharedStrings(b *testing.B) {
+	for _, c := range []struct {
+		name     string
+		mode     StringMode
+		optimize bool
+	}{{"shared", StringModeShared, false}, {"inline", StringModeInline, false}, {"inline_optimized", StringModeInline, true}} {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var size int
+			for i := 0; i < b.N; i++ {
+				f := NewFile()
+				f.SetStringMode(c.mode)
+				for row := 1; row <= 10000; row++ {
+					if err := f.SetCellStr("Sheet1", "A"+strconv.Itoa(row), "duplicate string "+strconv.Itoa(row%10)); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if c.optimize {
+					if err := f.OptimizeSharedStrings(); err != nil {
+						b.Fatal(err)
+					}
+				}
+				buf, err := f.WriteToBuffer()
+				if err != nil {
+					b.Fatal(err)
+				}
+				size = buf.Len()
+				if err := f.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(size), "bytes/file")
+		})
+	}
+}
+
+func TestRemoveCell(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1+1"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B1", style))
+	assert.NoError(t, f.RemoveCell("Sheet1", "A1"))
+	assert.NoError(t, f.RemoveCell("Sheet1", "B1"))
+	// Test remove the cell which doesn't exist
+	assert.NoError(t, f.RemoveCell("Sheet1", "C10"))
+	assert.NoError(t, f.RemoveCell("Sheet1", "Z1"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []xlsxC{{R: "A1"}, {R: "B1"}}, ws.(*xlsxWorksheet).SheetData.Row[0].C)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCell.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestRemoveCell.xlsx"))
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	// Test remove cell with invalid cell reference
+	assert.EqualError(t, f.RemoveCell("Sheet1", "A"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test remove cell with invalid sheet name
+	assert.EqualError(t, f.RemoveCell("Sheet:1", "A1"), ErrSheetNameInvalid.Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestClearRange(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRows("Sheet1", "A1", [][]interface{}{{1, 2, 3}, {4, 5, 6}}))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C3", "A1+B1"))
+		assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "C3", style))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "C1", "Sheet1!A1", "Location"))
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Text: "comment"}))
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "D4", Text: "comment"}))
+		dv := NewDataValidation(true)
+		dv.Sqref = "A1:D4"
+		assert.NoError(t, dv.SetRange(0, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+		assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+		assert.NoError(t, f.MergeCell("Sheet1", "A5", "B5"))
+		return f
+	}
+	f := prepare()
+	assert.NoError(t, f.ClearRange("Sheet1", "A1:B3", ClearOptions{Values: true}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"", "", "3"}, {"", "", "6"}, {"", "", ""}}, rows)
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.NotZero(t, styleID)
+	ok, link, err := f.GetCellHyperLink("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "https://github.com/xuri/excelize", link)
+	assert.NoError(t, f.Close())
+
+	f = prepare()
+	assert.NoError(t, f.ClearRange("Sheet1", "A:C", ClearOptions{
+		Values: true, Formulas: true, Styles: true, Hyperlinks: true,
+		Comments: true, DataValidations: true, UnmergeCells: true,
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for _, row := range ws.(*xlsxWorksheet).SheetData.Row {
+		for _, c := range row.C {
+			assert.False(t, c.hasValue(), c.R)
+		}
+	}
+	assert.Nil(t, ws.(*xlsxWorksheet).Hyperlinks)
+	assert.Nil(t, ws.(*xlsxWorksheet).MergeCells)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "D4", comments[0].Cell)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "D1:D4", dvs[0].Sqref)
+	assert.NoError(t, f.Close())
+
+	// Test clear everything in the range
+	f = prepare()
+	assert.NoError(t, f.SetCellValue("Sheet1", "E6", "E6"))
+	assert.NoError(t, f.ClearRange("Sheet1", "A1:E6", ClearOptions{All: true}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for _, row := range ws.(*xlsxWorksheet).SheetData.Row {
+		for _, c := range row.C {
+			assert.False(t, c.hasValue(), c.R)
+			assert.Zero(t, c.S, c.R)
+		}
+	}
+	assert.Nil(t, ws.(*xlsxWorksheet).Hyperlinks)
+	assert.Nil(t, ws.(*xlsxWorksheet).MergeCells)
+	assert.Equal(t, "A1", ws.(*xlsxWorksheet).Dimension.Ref)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, dvs)
+	assert.NoError(t, f.Close())
+
+	// Test clear values recalculates the dimension of the worksheet
+	f = prepare()
+	assert.NoError(t, f.ClearRange("Sheet1", "C1:C3", ClearOptions{Values: true, Formulas: true, Styles: true}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "A1:B3", ws.(*xlsxWorksheet).Dimension.Ref)
+	assert.NoError(t, f.Close())
+
+	// Test clear range overlaps part of the merged cells
+	f = prepare()
+	assert.Equal(t, ErrClearMergedCells, f.ClearRange("Sheet1", "B5", ClearOptions{Values: true}))
+	assert.NoError(t, f.ClearRange("Sheet1", "A5:C5", ClearOptions{Values: true}))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	// Test clear range with invalid range reference
+	assert.EqualError(t, f.ClearRange("Sheet1", "A1:B", ClearOptions{}), newInvalidRangeRefError("A1:B").Error())
+	// Test clear range with invalid sheet name
+	assert.EqualError(t, f.ClearRange("Sheet:1", "A1", ClearOptions{}), ErrSheetNameInvalid.Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestCopyRange(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, "text"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "A1+$B$1+SUM(A:A)+Sheet2!A1"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	formulaType, ref := STCellFormulaTypeShared, "A3:B3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "A1*2", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "merged"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A4", "B4"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C4", "D4"))
+
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:C4", "Sheet2", "D5", CopyOptions{}))
+	for cell, expected := range map[string]string{"D5": "1", "E5": "text", "D8": "merged"} {
+		val, err := f.GetCellValue("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	for cell, expected := range map[string]string{"F5": "D5+$B$1+SUM(D:D)+Sheet2!D5", "D7": "D5*2", "E7": "E5*2"} {
+		formula, err := f.GetCellFormula("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet2", "D5")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	mergeCells, err := f.GetMergeCells("Sheet2")
+	assert.NoError(t, err)
+	if assert.Len(t, mergeCells, 1) {
+		assert.Equal(t, "D8", mergeCells[0].GetStartAxis())
+		assert.Equal(t, "E8", mergeCells[0].GetEndAxis())
+	}
+	// Test the source cells are kept unchanged
+	formula, err := f.GetCellFormula("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "B1*2", formula)
+
+	// Test copy values only on the same worksheet with overlapped range
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:C3", "Sheet1", "B1", CopyOptions{ValuesOnly: true}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "1", "text"}, rows[0])
+	for _, cell := range []string{"B1", "D1", "B3"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+	}
+	styleID, err = f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	// Test copy range clears the destination cells beyond the source cells
+	for cell, value := range map[string]interface{}{"F1": 1, "G1": "text", "F2": true, "G2": 2.5} {
+		assert.NoError(t, f.SetCellValue("Sheet2", cell, value))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet2", "G2", "F1*2"))
+	assert.NoError(t, f.SetCellStyle("Sheet2", "F1", "G2", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "H1", "copy"))
+	assert.NoError(t, f.CopyRange("Sheet1", "H1:I2", "Sheet2", "F1", CopyOptions{}))
+	for cell, expected := range map[string]string{"F1": "copy", "G1": "", "F2": "", "G2": ""} {
+		val, err := f.GetCellValue("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+		formula, err := f.GetCellFormula("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+		styleID, err := f.GetCellStyle("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Zero(t, styleID, cell)
+	}
+	// Test copy range with the references out of the worksheet
+	assert.NoError(t, f.SetCellFormula("Sheet2", "B1", "A1"))
+	assert.NoError(t, f.CopyRange("Sheet2", "B1", "Sheet2", "A2", CopyOptions{}))
+	formula, err = f.GetCellFormula("Sheet2", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "#REF!", formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopyRange.xlsx")))
+	// Test copy range with invalid references
+	assert.Equal(t, newInvalidRangeRefError("A1:B"), f.CopyRange("Sheet1", "A1:B", "Sheet2", "A1", CopyOptions{}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.CopyRange("Sheet1", "A1", "Sheet2", "A", CopyOptions{}))
+	assert.Equal(t, ErrColumnNumber, f.CopyRange("Sheet1", "A1:B1", "Sheet2", "XFD1", CopyOptions{}))
+	assert.Equal(t, ErrMaxRows, f.CopyRange("Sheet1", "A1:A2", "Sheet2", "A1048576", CopyOptions{}))
+	// Test copy range on not exists worksheet
+	assert.EqualError(t, f.CopyRange("SheetN", "A1", "Sheet2", "A1", CopyOptions{}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.CopyRange("Sheet1", "A1", "SheetN", "A1", CopyOptions{}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCellValueWithMeta(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 0))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1/A2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "A1*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B3", "UNSUPPORTED()"))
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B3", style))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	cells := ws.(*xlsxWorksheet).SheetData.Row[0].C
+	cells[1].T, cells[1].V = "e", "#DIV/0!"
+	cells = ws.(*xlsxWorksheet).SheetData.Row[2].C
+	cells[1].T, cells[1].V = "e", "#NAME?"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "Sheet1!B1", "Location"))
+
+	value, meta, err := f.GetCellValueWithMeta("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", value)
+	assert.Equal(t, CellValueMeta{Type: CellTypeNumber, HyperlinkType: "External", HyperlinkTarget: "https://github.com/xuri/excelize"}, meta)
+	value, meta, err = f.GetCellValueWithMeta("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "0", value)
+	assert.Equal(t, CellValueMeta{Type: CellTypeNumber, HyperlinkType: "Location", HyperlinkTarget: "Sheet1!B1"}, meta)
+	// Test get the cached error value
+	value, meta, err = f.GetCellValueWithMeta("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "#DIV/0!", value)
+	assert.Equal(t, CellValueMeta{Type: CellTypeFormulaError, Formula: "A1/A2", Error: "#DIV/0!"}, meta)
+	value, meta, err = f.GetCellValueWithMeta("Sheet1", "B1", CellValueOptions{ErrorValue: "N/A"})
+	assert.NoError(t, err)
+	assert.Equal(t, "N/A", value)
+	assert.Equal(t, "#DIV/0!", meta.Error)
+	// Test recalculate the formula cells
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 1))
+	value, meta, err = f.GetCellValueWithMeta("Sheet1", "B1", CellValueOptions{Recalculate: true, ErrorValue: "N/A"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.50", value)
+	assert.Empty(t, meta.Error)
+	value, _, err = f.GetCellValueWithMeta("Sheet1", "B2", CellValueOptions{Recalculate: true, RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", value)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 0))
+	value, meta, err = f.GetCellValueWithMeta("Sheet1", "B2", CellValueOptions{Recalculate: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "3.00", value)
+	assert.Empty(t, meta.Error)
+	value, meta, err = f.GetCellValueWithMeta("Sheet1", "B1", CellValueOptions{Recalculate: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "#DIV/0!", value)
+	assert.Equal(t, "#DIV/0!", meta.Error)
+	// Test recalculate the formula which can't be evaluated
+	value, meta, err = f.GetCellValueWithMeta("Sheet1", "B3", CellValueOptions{Recalculate: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "#NAME?", value)
+	assert.Equal(t, "#NAME?", meta.Error)
+	// Test get cell value with invalid cell reference
+	_, _, err = f.GetCellValueWithMeta("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get cell value on not exists worksheet
+	_, _, err = f.GetCellValueWithMeta("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestCopyRangeTranspose(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for cell, value := range map[string]interface{}{"A1": 1, "B1": 2, "C1": 3, "A2": "a", "B2": "b"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "A1+B1+D1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C2"))
+	// Test transpose the range without formulas
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:C2", "Sheet2", "E1", CopyOptions{Transpose: true}))
+	for cell, expected := range map[string]string{"E1": "1", "E2": "2", "E3": "3", "F1": "a", "F2": "b"} {
+		value, err := f.GetCellValue("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet2", "E3")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	mergeCells, err := f.GetMergeCells("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "F2", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "F3", mergeCells[0].GetEndAxis())
+	// Test transpose the range with formulas
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:C2", "Sheet2", "H1", CopyOptions{Transpose: true, TransposeFormulas: true}))
+	formula, err = f.GetCellFormula("Sheet2", "H3")
+	assert.NoError(t, err)
+	assert.Equal(t, "H1+H2+I3", formula)
+	// Test transpose the range out of the worksheet
+	assert.Equal(t, ErrColumnNumber, f.CopyRange("Sheet1", "A1:A2", "Sheet2", "XFD1", CopyOptions{Transpose: true}))
+	assert.Equal(t, ErrMaxRows, f.CopyRange("Sheet1", "A1:B1", "Sheet2", "A1048576", CopyOptions{Transpose: true}))
+	assert.NoError(t, f.Close())
+}
+
+func TestCellPhonetic(t *testing.T) {
+	f := NewFile()
+	runs := []PhoneticRun{
+		{StartIndex: 0, EndIndex: 2, Text: "トウキョウ"},
+		{StartIndex: 2, EndIndex: 3, Text: "ト"},
+	}
+	props := PhoneticProperties{FontID: 1, Type: "Hiragana", Alignment: "center", Visible: true}
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "東京都"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "東京都"))
+	assert.NoError(t, f.SetCellPhonetic("Sheet1", "A1", runs, props))
+	// Test set the same phonetic hints for the cell with the same value
+	assert.NoError(t, f.SetCellPhonetic("Sheet1", "A2", runs, PhoneticProperties{FontID: 1, Type: "Hiragana", Alignment: "center"}))
+	cnt, err := f.SharedStringsCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cnt)
+	// Test phonetic hints will be kept on rewriting the same value
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "東京都"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A2", "東京都"))
+	f.SetStringMode(StringModeInline)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "東京都"))
+	assert.NoError(t, f.SetCellPhonetic("Sheet1", "A3", runs, props))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", []byte("東京都")))
+	for _, cell := range []string{"A1", "A2", "A3"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "東京都", val)
+		phoneticRuns, phoneticProps, err := f.GetCellPhonetic("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, runs, phoneticRuns)
+		assert.Equal(t, cell != "A2", phoneticProps.Visible)
+		assert.Equal(t, "Hiragana", phoneticProps.Type)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCellPhonetic.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCellPhonetic.xlsx"))
+	assert.NoError(t, err)
+	phoneticRuns, phoneticProps, err := f.GetCellPhonetic("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, runs, phoneticRuns)
+	assert.Equal(t, props, phoneticProps)
+	// Test phonetic hints will be dropped on changing the value
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "大阪府"))
+	phoneticRuns, _, err = f.GetCellPhonetic("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, phoneticRuns)
+	// Test remove phonetic hints
+	assert.NoError(t, f.SetCellPhonetic("Sheet1", "A2", nil, PhoneticProperties{}))
+	phoneticRuns, phoneticProps, err = f.GetCellPhonetic("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Nil(t, phoneticRuns)
+	assert.Equal(t, PhoneticProperties{}, phoneticProps)
+	// Test get phonetic hints for the cell without string value
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 1))
+	phoneticRuns, _, err = f.GetCellPhonetic("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Nil(t, phoneticRuns)
+	// Test get phonetic hints for the not exists cell without creating it
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	rows := len(ws.(*xlsxWorksheet).SheetData.Row)
+	phoneticRuns, phoneticProps, err = f.GetCellPhonetic("Sheet1", "Z100")
+	assert.NoError(t, err)
+	assert.Nil(t, phoneticRuns)
+	assert.Equal(t, PhoneticProperties{}, phoneticProps)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, rows)
+	// Test set phonetic hints with invalid settings
+	assert.Equal(t, ErrCellPhonetic, f.SetCellPhonetic("Sheet1", "B1", runs, props))
+	for _, invalidRuns := range [][]PhoneticRun{
+		{{StartIndex: 0, EndIndex: 0, Text: "ト"}},
+		{{StartIndex: 0, EndIndex: 4, Text: "ト"}},
+		{{StartIndex: 0, EndIndex: 1}},
+		{{StartIndex: 0, EndIndex: 2, Text: "ト"}, {StartIndex: 1, EndIndex: 3, Text: "ト"}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.SetCellPhonetic("Sheet1", "A3", invalidRuns, props))
+	}
+	assert.Equal(t, ErrParameterInvalid, f.SetCellPhonetic("Sheet1", "A3", runs, PhoneticProperties{FontID: -1}))
+	assert.EqualError(t, f.SetCellPhonetic("Sheet1", "A3", runs, PhoneticProperties{Type: "x"}), newInvalidOptionalValue("Type", "x", supportedPhoneticTypes).Error())
+	assert.EqualError(t, f.SetCellPhonetic("Sheet1", "A3", runs, PhoneticProperties{Alignment: "x"}), newInvalidOptionalValue("Alignment", "x", supportedPhoneticAlignments).Error())
+	// Test set and get phonetic hints with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellPhonetic("Sheet1", "A", runs, props))
+	_, _, err = f.GetCellPhonetic("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test set and get phonetic hints on not exists worksheet
+	assert.EqualError(t, f.SetCellPhonetic("SheetN", "A1", runs, props), "sheet SheetN does not exist")
+	_, _, err = f.GetCellPhonetic("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+	// Test set and get phonetic hints with unsupported charset shared string table
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A"))
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellPhonetic("Sheet1", "A1", runs, props), "XML syntax error on line 1: invalid UTF-8")
+	f.SharedStrings = nil
+	_, _, err = f.GetCellPhonetic("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetSharedStringIndex(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "foo"))
+	// Test set shared string after the shared string table was reset
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, []byte(`<sst xmlns="`+NameSpaceSpreadSheet.Value+`"><si><t>bar</t></si></sst>`))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "foo"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "bar"))
+	for val, expected := range map[string]int{"foo": 1, "bar": 0} {
+		idx, ok := f.sharedStringsMap.load(val)
+		assert.True(t, ok)
+		assert.Equal(t, expected, idx, val)
+	}
+	assert.Len(t, f.SharedStrings.SI, 2)
+	for cell, expected := range map[string]string{"B1": "foo", "C1": "bar"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	// Test set shared string after the shared string table was replaced
+	f.SharedStrings = &xlsxSST{SI: []xlsxSI{{T: &xlsxT{Val: "baz"}}}, Count: 1, UniqueCount: 1}
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "baz"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", "foo"))
+	for cell, expected := range map[string]string{"D1": "baz", "E1": "foo"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	assert.Len(t, f.SharedStrings.SI, 2)
+	assert.NoError(t, f.Close())
+}
+
+func TestSetSharedStringConcurrency(t *testing.T) {
+	f := NewFile()
+	for i := 2; i <= 8; i++ {
+		_, err := f.NewSheet("Sheet" + strconv.Itoa(i))
+		assert.NoError(t, err)
+	}
+	wg := new(sync.WaitGroup)
+	for i := 1; i <= 8; i++ {
+		wg.Add(1)
+		go func(sheet string) {
+			defer wg.Done()
+			for row := 1; row <= 1000; row++ {
+				assert.NoError(t, f.SetCellValue(sheet, "A"+strconv.Itoa(row), "string "+strconv.Itoa(row)))
+				assert.NoError(t, f.SetCellValue(sheet, "B"+strconv.Itoa(row), sheet+" "+strconv.Itoa(row)))
+			}
+		}("Sheet" + strconv.Itoa(i))
+	}
+	wg.Wait()
+	sst, err := f.sharedStringsReader()
+	assert.NoError(t, err)
+	assert.Len(t, sst.SI, 9000)
+	assert.Equal(t, 9000, sst.UniqueCount)
+	for i := 1; i <= 8; i++ {
+		sheet := "Sheet" + strconv.Itoa(i)
+		for _, row := range []int{1, 500, 1000} {
+			val, err := f.GetCellValue(sheet, "A"+strconv.Itoa(row))
+			assert.NoError(t, err)
+			assert.Equal(t, "string "+strconv.Itoa(row), val)
+			val, err = f.GetCellValue(sheet, "B"+strconv.Itoa(row))
+			assert.NoError(t, err)
+			assert.Equal(t, sheet+" "+strconv.Itoa(row), val)
+		}
+	}
+	assert.NoError(t, f.Close())
+}
+
+func BenchmarkSetSharedStringParallel(b *testing.B) {
+	// Run with the -cpu flag, such as -cpu 1,2,4, to compare the throughput of
+	// the shared string table with the different number of goroutines
+	f := NewFile()
+	if _, err := f.setSharedString("init"); err != nil {
+		b.Fatal(err)
+	}
+	var worker int32
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		prefix := "string " + strconv.Itoa(int(atomic.AddInt32(&worker, 1))) + " "
+		for i := 0; pb.Next(); i++ {
+			if _, err := f.setSharedString(prefix + strconv.Itoa(i%100000)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSetCellValueConcurrent(b *testing.B) {
+	f := NewFile()
+	sheets := make([]string, 8)
+	for i := range sheets {
+		sheets[i] = "Sheet" + strconv.Itoa(i+1)
+		if i > 0 {
+			if _, err := f.NewSheet(sheets[i]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	var worker int32
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		sheet := sheets[int(atomic.AddInt32(&worker, 1)-1)%len(sheets)]
+		for i := 0; pb.Next(); i++ {
+			cell, _ := CoordinatesToCellName(i%16+1, i/16%100000+1)
+			if err := f.SetCellValue(sheet, cell, "string "+strconv.Itoa(i%100000)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
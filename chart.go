@@ -13,12 +13,15 @@ package excelize
 
 import (
 	"fmt"
+	"github.com/xuri/excelize/v2/xencoding/json"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"strconv"
 	"strings"
 )
 
-// ChartType is the type of supported chart types.
+// ChartType is the type of supported chart types. The chart type will be
+// marshaled as its enumeration name such as "Col3DClustered" in JSON, and
+// both the name and the ID of the chart type are accepted on unmarshaling.
 type ChartType byte
 
 // This section defines the currently supported chart types enumeration.
@@ -80,6 +83,121 @@ const (
 	Bubble3D
 )
 
+// chartTypeNames defined the names of the supported chart types, which are
+// used for marshaling and unmarshaling the chart types.
+var chartTypeNames = []string{
+	"Area",
+	"AreaStacked",
+	"AreaPercentStacked",
+	"Area3D",
+	"Area3DStacked",
+	"Area3DPercentStacked",
+	"Bar",
+	"BarStacked",
+	"BarPercentStacked",
+	"Bar3DClustered",
+	"Bar3DStacked",
+	"Bar3DPercentStacked",
+	"Bar3DConeClustered",
+	"Bar3DConeStacked",
+	"Bar3DConePercentStacked",
+	"Bar3DPyramidClustered",
+	"Bar3DPyramidStacked",
+	"Bar3DPyramidPercentStacked",
+	"Bar3DCylinderClustered",
+	"Bar3DCylinderStacked",
+	"Bar3DCylinderPercentStacked",
+	"Col",
+	"ColStacked",
+	"ColPercentStacked",
+	"Col3D",
+	"Col3DClustered",
+	"Col3DStacked",
+	"Col3DPercentStacked",
+	"Col3DCone",
+	"Col3DConeClustered",
+	"Col3DConeStacked",
+	"Col3DConePercentStacked",
+	"Col3DPyramid",
+	"Col3DPyramidClustered",
+	"Col3DPyramidStacked",
+	"Col3DPyramidPercentStacked",
+	"Col3DCylinder",
+	"Col3DCylinderClustered",
+	"Col3DCylinderStacked",
+	"Col3DCylinderPercentStacked",
+	"Doughnut",
+	"Line",
+	"Line3D",
+	"Pie",
+	"Pie3D",
+	"PieOfPie",
+	"BarOfPie",
+	"Radar",
+	"Scatter",
+	"Surface3D",
+	"WireframeSurface3D",
+	"Contour",
+	"WireframeContour",
+	"Bubble",
+	"Bubble3D",
+}
+
+// String returns the name of the chart type.
+func (t ChartType) String() string {
+	if int(t) < len(chartTypeNames) {
+		return chartTypeNames[t]
+	}
+	return "ChartType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// MarshalText encodes the chart type as its name.
+func (t ChartType) MarshalText() ([]byte, error) {
+	if int(t) >= len(chartTypeNames) {
+		return nil, newUnsupportedChartType(t)
+	}
+	return []byte(chartTypeNames[t]), nil
+}
+
+// UnmarshalText decodes the chart type by given name of the chart type, the
+// name is case-insensitive.
+func (t *ChartType) UnmarshalText(text []byte) error {
+	for idx, name := range chartTypeNames {
+		if strings.EqualFold(name, string(text)) {
+			*t = ChartType(idx)
+			return nil
+		}
+	}
+	return newUnsupportedChartTypeName(string(text))
+}
+
+// MarshalJSON encodes the chart type as a JSON string of its name.
+func (t ChartType) MarshalJSON() ([]byte, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes the chart type by given JSON string of the name or
+// JSON number of the ID of the chart type.
+func (t *ChartType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return t.UnmarshalText([]byte(name))
+	}
+	var id int
+	if err := json.Unmarshal(data, &id); err != nil {
+		return err
+	}
+	if id < 0 || id >= len(chartTypeNames) {
+		return newUnsupportedChartTypeName(string(data))
+	}
+	*t = ChartType(id)
+	return nil
+}
+
 // This section defines the default value of chart properties.
 var (
 	chartView3DRotX = map[ChartType]int{
@@ -478,6 +596,43 @@ var (
 		Contour:          "none",
 		WireframeContour: "none",
 	}
+	dataLabelSeparator = map[string]string{
+		"comma":     ", ",
+		"semicolon": "; ",
+		"period":    ". ",
+		"newLine":   "\n",
+		"space":     " ",
+	}
+	pieChartTypes = map[ChartType]bool{
+		Doughnut: true, Pie: true, Pie3D: true, PieOfPie: true, BarOfPie: true,
+	}
+	dataLabelPosition = map[string]string{
+		"center":     "ctr",
+		"insideEnd":  "inEnd",
+		"insideBase": "inBase",
+		"outsideEnd": "outEnd",
+		"bestFit":    "bestFit",
+		"left":       "l",
+		"right":      "r",
+		"above":      "t",
+		"below":      "b",
+	}
+	chartDataLabelPositions = map[ChartType][]string{
+		Bar:               {"center", "insideEnd", "insideBase", "outsideEnd"},
+		BarStacked:        {"center", "insideEnd", "insideBase"},
+		BarPercentStacked: {"center", "insideEnd", "insideBase"},
+		Col:               {"center", "insideEnd", "insideBase", "outsideEnd"},
+		ColStacked:        {"center", "insideEnd", "insideBase"},
+		ColPercentStacked: {"center", "insideEnd", "insideBase"},
+		Line:              {"center", "left", "right", "above", "below"},
+		Pie:               {"center", "insideEnd", "outsideEnd", "bestFit"},
+		Pie3D:             {"center", "insideEnd", "outsideEnd", "bestFit"},
+		PieOfPie:          {"center", "insideEnd", "outsideEnd", "bestFit"},
+		BarOfPie:          {"center", "insideEnd", "outsideEnd", "bestFit"},
+		Scatter:           {"center", "left", "right", "above", "below"},
+		Bubble:            {"center", "left", "right", "above", "below"},
+		Bubble3D:          {"center", "left", "right", "above", "below"},
+	}
 )
 
 // parseChartOptions provides a function to parse the format settings of the
@@ -504,6 +659,9 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts.Format.ScaleY == 0 {
 		opts.Format.ScaleY = defaultPictureScale
 	}
+	if opts.Format.Positioning != "" && inStrSlice(supportedPositioning, opts.Format.Positioning, true) == -1 {
+		return opts, ErrParameterInvalid
+	}
 	if opts.Legend.Position == "" {
 		opts.Legend.Position = defaultChartLegendPosition
 	}
@@ -524,6 +682,33 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts.ShowBlanksAs == "" {
 		opts.ShowBlanksAs = defaultChartShowBlanksAs
 	}
+	if _, ok := dataLabelSeparator[opts.PlotArea.Separator]; opts.PlotArea.Separator != "" && !ok {
+		return opts, ErrParameterInvalid
+	}
+	for _, series := range opts.Series {
+		if position := series.DataLabel.Position; position != "" &&
+			inStrSlice(chartDataLabelPositions[opts.Type], position, true) == -1 {
+			return opts, ErrParameterInvalid
+		}
+	}
+	for _, pos := range []*float64{opts.TitleLayout.X, opts.TitleLayout.Y} {
+		if pos != nil && (*pos < 0 || *pos > 1) {
+			return opts, ErrParameterInvalid
+		}
+	}
+	for _, axis := range []*ChartAxis{&opts.XAxis, &opts.YAxis} {
+		if axis.Crosses != "" && inStrSlice([]string{"autoZero", "max", "min"}, axis.Crosses, true) == -1 {
+			return opts, ErrParameterInvalid
+		}
+		if axis.AxisPosition != "" && inStrSlice([]string{"nextTo", "low", "high", "none"}, axis.AxisPosition, true) == -1 {
+			return opts, ErrParameterInvalid
+		}
+		for _, tickMark := range []string{axis.MajorTickMark, axis.MinorTickMark} {
+			if tickMark != "" && inStrSlice([]string{"cross", "in", "none", "out"}, tickMark, true) == -1 {
+				return opts, ErrParameterInvalid
+			}
+		}
+	}
 	return opts, nil
 }
 
@@ -675,6 +860,8 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Fill
 //	Line
 //	Marker
+//	Order
+//	DataLabel
 //
 // Name: Set the name for the series. The name is displayed in the chart legend
 // and in the formula bar. The 'Name' property is optional and if it isn't
@@ -699,8 +886,13 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // value of width is outside the range, the default width of the line is 2pt.
 //
 // Marker: This sets the marker of the line chart and scatter chart. The range
-// of optional field 'Size' is 2-72 (default value is 5). The enumeration value
-// of optional field 'Symbol' are (default value is 'auto'):
+// of optional field 'Size' is 2-72 (default value is 5). The optional field
+// 'Fill' sets the fill color of the marker, and the optional field 'Border'
+// sets the border color and width of the marker, the range of border width is
+// 0.25pt - 999pt. The marker follows the series color if they are not
+// specified. The marker will not be shown and the fill and border settings
+// will be ignored if the 'Symbol' is 'none'. The enumeration value of optional
+// field 'Symbol' are (default value is 'auto'):
 //
 //	circle
 //	dash
@@ -715,10 +907,67 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	x
 //	auto
 //
+// Order: This sets the plot order of the series, which decides the stacking
+// order of the series in the stacked charts, independent of the order of the
+// series in the options. The 'Order' property is optional, and the series
+// will be plotted in the order of the series in the options and combo charts
+// by default, starting from 0. The orders of all series in the chart must be
+// unique and non-negative, and the series without order will take the unused
+// orders in the order of the series. For example, plot the first series of
+// three on the top of the stacked area chart:
+//
+//	top, bottom, middle := 2, 0, 1
+//	series := []excelize.ChartSeries{
+//	    {Name: "Sheet1!$A$2", Values: "Sheet1!$B$2:$D$2", Order: &top},
+//	    {Name: "Sheet1!$A$3", Values: "Sheet1!$B$3:$D$3", Order: &bottom},
+//	    {Name: "Sheet1!$A$4", Values: "Sheet1!$B$4:$D$4", Order: &middle},
+//	}
+//
+// DataLabel: This sets the data labels of the series, which overrides the data
+// labels settings of the chart plot area for the series. The 'DataLabel'
+// property is optional. The optional field 'NumFmt' sets the number format
+// of the data labels, the optional field 'Font' sets the font of the data
+// labels, and the optional fields 'ShowBubbleSize', 'ShowCatName',
+// 'ShowPercent', 'ShowSerName' and 'ShowVal' specify which contents of the
+// data labels will be shown for the series, the settings of the plot area
+// will be used if these fields are not set. Note that the data labels will be
+// shown only if one of these contents was enabled by the series or the plot
+// area. The optional field 'Position' sets the position of the data labels.
+// The available positions depend on the chart type:
+//
+//	 Chart type                          | Positions
+//	-------------------------------------+----------------------------------------------
+//	 Bar, Col                            | center, insideEnd, insideBase, outsideEnd
+//	 BarStacked, BarPercentStacked,      | center, insideEnd, insideBase
+//	 ColStacked, ColPercentStacked       |
+//	 Line, Scatter, Bubble, Bubble3D     | center, left, right, above, below
+//	 Pie, Pie3D, PieOfPie, BarOfPie      | center, insideEnd, outsideEnd, bestFit
+//
+// For example, show the values of the series in currency format without
+// decimals above the line:
+//
+//	showVal := true
+//	series := []excelize.ChartSeries{
+//	    {
+//	        Name:   "Sheet1!$A$2",
+//	        Values: "Sheet1!$B$2:$D$2",
+//	        DataLabel: excelize.ChartDataLabel{
+//	            NumFmt:   excelize.ChartNumFmt{CustomNumFmt: "$#,##0"},
+//	            Position: "above",
+//	            Font:     excelize.Font{Bold: true, Color: "#1F4E79"},
+//	            ShowVal:  &showVal,
+//	        },
+//	    },
+//	}
+//
 // Set properties of the chart legend. The options that can be set are:
 //
 //	Position
 //	ShowLegendKey
+//	Overlay
+//	Font
+//	Fill
+//	Border
 //
 // Position: Set the position of the chart legend. The default legend position
 // is bottom. The available positions are:
@@ -733,15 +982,43 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // ShowLegendKey: Set the legend keys shall be shown in data labels. The default
 // value is false.
 //
+// Overlay: Specifies that the legend shall be allowed to overlap the plot area
+// of the chart. The default value is false.
+//
+// Font: Set the font properties of the legend text, the Bold, Italic,
+// Underline, Family, Size and Color of the font are supported.
+//
+// Fill: Set the solid fill color of the legend area, the first color in the
+// Color field will be used.
+//
+// Border: Set the border color and width (in points) of the legend area.
+//
 // Set properties of the chart title. The properties that can be set are:
 //
 //	Title
+//	TitleLayout
 //
 // Title: Set the name (title) for the chart. The name is displayed above the
 // chart. The name can also be a formula such as Sheet1!$A$1 or a list with a
 // sheet name. The name property is optional. The default is to have no chart
 // title.
 //
+// TitleLayout: Set the layout of the chart title. The optional field 'Overlay'
+// specifies that the title shall be allowed to overlap the plot area of the
+// chart. The optional fields 'X' and 'Y' specifies the manual position of the
+// title as the fractions (between 0 and 1) of the chart width and height from
+// the top-left corner of the chart. The title is auto positioned by default.
+// For example, place the title in the top-left corner of the chart and overlap
+// the plot area:
+//
+//	x, y := 0.02, 0.02
+//	err := f.AddChart("Sheet1", "E1", &excelize.Chart{
+//	    Type:        excelize.Col,
+//	    Series:      series,
+//	    Title:       []excelize.RichTextRun{{Text: "Sales"}},
+//	    TitleLayout: excelize.ChartLayout{Overlay: true, X: &x, Y: &y},
+//	})
+//
 // Specifies how blank cells are plotted on the chart by 'ShowBlanksAs'. The
 // default value is gap. The options that can be set are:
 //
@@ -765,6 +1042,7 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // be set are:
 //
 //	SecondPlotValues
+//	Separator
 //	ShowBubbleSize
 //	ShowCatName
 //	ShowLeaderLines
@@ -775,6 +1053,16 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // SecondPlotValues: Specifies the values in second plot for the 'pieOfPie' and
 // 'barOfPie' chart.
 //
+// Separator: Specifies the separator between the multiple fields shown in a
+// data label. The 'Separator' property is optional. The default separator is
+// decided by the application. The available separators are:
+//
+//	comma
+//	semicolon
+//	period
+//	newLine
+//	space
+//
 // ShowBubbleSize: Specifies the bubble size shall be shown in a data label. The
 // 'ShowBubbleSize' property is optional. The default value is false.
 //
@@ -782,6 +1070,9 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // label. The 'ShowCatName' property is optional. The default value is true.
 //
 // ShowLeaderLines: Specifies leader lines shall be shown for data labels. The
+// leader lines connect the data labels which have been moved away from the
+// data points, this works for all chart types in Excel 2013 and later, and
+// for the pie and doughnut charts in the earlier versions. The
 // 'ShowLeaderLines' property is optional. The default value is false.
 //
 // ShowPercent: Specifies that the percentage shall be shown in a data label.
@@ -806,6 +1097,11 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Font
 //	NumFmt
 //	Title
+//	Crosses
+//	CrossesAt
+//	AxisPosition
+//	MajorTickMark
+//	MinorTickMark
 //
 // The properties of 'YAxis' that can be set are:
 //
@@ -821,6 +1117,11 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	LogBase
 //	NumFmt
 //	Title
+//	Crosses
+//	CrossesAt
+//	AxisPosition
+//	MajorTickMark
+//	MinorTickMark
 //
 // None: Disable axes.
 //
@@ -834,7 +1135,10 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //
 // Secondary: Specifies the current series vertical axis as the secondary axis,
 // this only works for the second and later chart in the combo chart. The
-// default value is false.
+// default value is false. The 'Maximum', 'Minimum', 'MajorUnit', 'LogBase',
+// 'NumFmt' and axis crossing options of the combo chart will be applied to the
+// secondary axis, and the primary axes always use the options of the first
+// chart.
 //
 // TickLabelSkip: Specifies how many tick labels to skip between label that is
 // drawn. The 'TickLabelSkip' property is optional. The default value is auto.
@@ -870,9 +1174,48 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // Title: Specifies that the primary horizontal or vertical axis title and
 // resize chart. The 'Title' property is optional.
 //
+// Crosses: Specifies where the axis crosses the perpendicular axis, the value
+// can be 'autoZero', 'max' or 'min'. For example, set the 'Crosses' of the
+// 'XAxis' to 'min' to place the horizontal axis at the minimum value of the
+// vertical axis for the chart with negative values. The 'Crosses' property is
+// optional. The default value is 'autoZero'.
+//
+// CrossesAt: Specifies the value on the perpendicular axis where the axis
+// crosses, which takes precedence over the 'Crosses' property. The
+// 'CrossesAt' property is optional.
+//
+// AxisPosition: Specifies the position of the tick labels of the axis, the
+// value can be 'nextTo', 'low', 'high' or 'none'. The 'AxisPosition' property
+// is optional. The default value is 'nextTo'.
+//
+// MajorTickMark: Specifies the major tick marks of the axis, the value can be
+// 'cross', 'in', 'none' or 'out'. The 'MajorTickMark' property is optional.
+// The default value is 'none'.
+//
+// MinorTickMark: Specifies the minor tick marks of the axis, the value can be
+// 'cross', 'in', 'none' or 'out'. The 'MinorTickMark' property is optional.
+// The default value is 'none'.
+//
+// The primary axes are shared by the charts in the combo chart, so the
+// 'Crosses', 'CrossesAt', 'AxisPosition', 'MajorTickMark' and 'MinorTickMark'
+// of the primary axes will use the settings of the main chart.
+//
 // Set chart size by 'Dimension' property. The 'Dimension' property is optional.
 // The default width is 480, and height is 260.
 //
+// Set the graphic object properties of the chart by 'Format' property, the
+// 'Format' property is optional. The optional field 'Locked' indicates whether
+// lock the chart, the default value is 'false'. Locking the chart has no
+// effect unless the worksheet is protected by the 'ProtectSheet' function
+// without the 'EditObjects' option, in this case the locked chart can't be
+// selected, moved or resized, and the unlocked chart remains editable. The
+// optional field 'LockAspectRatio' indicates whether lock aspect ratio for the
+// chart. The optional field 'Positioning' specifies how the chart is moved or
+// resized with the underlying cells (edit as), the value can be 'oneCell'
+// (move but don't size with cells), 'twoCell' (move and size with cells) and
+// 'absolute' (don't move or size with cells). The default positioning is move
+// and size with cells.
+//
 // combo: Specifies the create a chart that combines two or more chart types in
 // a single chart. For example, create a clustered column - line chart with
 // data Sheet1!$E$1:$L$15:
@@ -980,6 +1323,9 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	    }
 //	}
 func (f *File) AddChart(sheet, cell string, chart *Chart, combo ...*Chart) error {
+	if err := f.checkReadOnly("AddChart"); err != nil {
+		return err
+	}
 	// Read worksheet data
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -1014,6 +1360,9 @@ func (f *File) AddChart(sheet, cell string, chart *Chart, combo ...*Chart) error
 // and properties set. In Excel a chartsheet is a worksheet that only contains
 // a chart.
 func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error {
+	if err := f.checkReadOnly("AddChartSheet"); err != nil {
+		return err
+	}
 	// Check if the worksheet already exists
 	idx, err := f.GetSheetIndex(sheet)
 	if err != nil {
@@ -1089,12 +1438,43 @@ func (f *File) getChartOptions(opts *Chart, combo []*Chart) (*Chart, []*Chart, e
 	if _, ok := chartValAxNumFmtFormatCode[options.Type]; !ok {
 		return options, comboCharts, newUnsupportedChartType(options.Type)
 	}
+	charts, orders := append([]*Chart{options}, comboCharts...), map[int]bool{}
+	for _, chart := range charts {
+		for _, series := range chart.Series {
+			if series.Order == nil {
+				continue
+			}
+			if *series.Order < 0 || orders[*series.Order] {
+				return options, comboCharts, ErrChartSeriesOrder
+			}
+			orders[*series.Order] = true
+		}
+	}
+	// Assign the orders which are not used by the specified orders to the
+	// series without order, in the order of the series
+	var free int
+	for _, chart := range charts {
+		chart.seriesOrder = make([]int, len(chart.Series))
+		for i, series := range chart.Series {
+			if series.Order != nil {
+				chart.seriesOrder[i] = *series.Order
+				continue
+			}
+			for orders[free] {
+				free++
+			}
+			chart.seriesOrder[i], orders[free] = free, true
+		}
+	}
 	return options, comboCharts, err
 }
 
 // DeleteChart provides a function to delete chart in spreadsheet by given
 // worksheet name and cell reference.
 func (f *File) DeleteChart(sheet, cell string) error {
+	if err := f.checkReadOnly("DeleteChart"); err != nil {
+		return err
+	}
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
@@ -1,9 +1,13 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bytes"
+	"errors"
 	"fmt"
+	"github.com/xuri/excelize/v2/xencoding/json"
 	"github.com/xuri/excelize/v2/xencoding/xml"
+	"io"
 	"path/filepath"
 	"testing"
 
@@ -94,12 +98,49 @@ func TestChartSize(t *testing.T) {
 	}
 
 	if !assert.Equal(t, 14, anchor.To.Col, "Expected 'to' column 14") ||
-		!assert.Equal(t, 29, anchor.To.Row, "Expected 'to' row 29") {
+		!assert.Equal(t, 27, anchor.To.Row, "Expected 'to' row 27") {
 
 		t.FailNow()
 	}
 }
 
+func TestChartOffsetEMUs(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}}
+	// Test the offsets in EMUs take precedence over the offsets in pixels
+	assert.NoError(t, f.AddChart("Sheet1", "B2", &Chart{Type: Col, Series: series, Format: GraphicOptions{
+		OffsetX: 10, OffsetY: 10, OffsetXEMU: PixelsToEMUs(10.5), OffsetYEMU: 12345,
+	}}))
+	// Test the offsets in pixels
+	assert.NoError(t, f.AddChart("Sheet1", "B20", &Chart{Type: Col, Series: series, Format: GraphicOptions{
+		OffsetX: 10, OffsetY: 5,
+	}}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr := drawing.(*xlsxWsDr)
+	for i, expected := range []struct{ from, to [4]int }{
+		{[4]int{1, 100013, 1, 12345}, [4]int{8, 404813, 14, 12345}},
+		{[4]int{1, 95250, 19, 47625}, [4]int{8, 400050, 32, 47625}},
+	} {
+		from, to := wsDr.TwoCellAnchor[i].From, wsDr.TwoCellAnchor[i].To
+		assert.Equal(t, expected.from, [4]int{from.Col, from.ColOff, from.Row, from.RowOff})
+		assert.Equal(t, expected.to, [4]int{to.Col, to.ColOff, to.Row, to.RowOff})
+	}
+	// Test the position of the chart on the worksheet with base column width
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetFormatPr = &xlsxSheetFormatPr{BaseColWidth: 10, DefaultRowHeight: 12}
+	assert.Equal(t, 80, f.getColWidth("Sheet1", 1))
+	assert.Equal(t, 16, f.getRowHeight("Sheet1", 1))
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectEMUs("Sheet1", 1, 1, 0, 0, 480*EMU, 260*EMU)
+	assert.Equal(t, [6]int{0, 0, 6, 16, 0, 4 * EMU}, [6]int{colStart, rowStart, colEnd, rowEnd, x2, y2})
+	// Test the units conversion
+	assert.Equal(t, 100013, PixelsToEMUs(10.5))
+	assert.Equal(t, 3.0, EMUsToPixels(3*EMU))
+	assert.Equal(t, 20.0, RowHeightToPixels(15))
+	assert.NoError(t, f.Close())
+}
+
 func TestAddDrawingChart(t *testing.T) {
 	f := NewFile()
 	assert.EqualError(t, f.addDrawingChart("SheetN", "", "", 0, 0, 0, nil), newCellNameToCoordinatesError("", newInvalidCellNameError("")).Error())
@@ -335,7 +376,11 @@ func TestAddChartSheet(t *testing.T) {
 	f.SetActiveSheet(sheetIdx)
 
 	// Test cell value on chartsheet
-	assert.EqualError(t, f.SetCellValue("Chart1", "A1", true), "sheet Chart1 is not a worksheet")
+	err := f.SetCellValue("Chart1", "A1", true)
+	assert.EqualError(t, err, "sheet Chart1 is not a worksheet")
+	var notWorksheet ErrNotWorksheet
+	assert.True(t, errors.As(err, &notWorksheet))
+	assert.Equal(t, "Chart1", notWorksheet.SheetName)
 	// Test add chartsheet on already existing name sheet
 
 	assert.EqualError(t, f.AddChartSheet("Sheet1", &Chart{Type: Col3DClustered, Series: series, Title: []RichTextRun{{Text: "Fruit 3D Clustered Column Chart"}}}), ErrExistsSheet.Error())
@@ -501,3 +546,488 @@ func TestChartWithLogarithmicBase(t *testing.T) {
 		}
 	}
 }
+
+func TestChartSecondaryAxisNumFmt(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"A", 1200, 0.25}, {"B", 3400, 0.5}, {"C", 5600, 0.75}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$1:$A$3", Values: "Sheet1!$B$1:$B$3"}},
+		YAxis:  ChartAxis{NumFmt: ChartNumFmt{CustomNumFmt: "$#,##0.00"}, MajorUnit: 1000},
+	}, &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$1:$A$3", Values: "Sheet1!$C$1:$C$3"}},
+		YAxis: ChartAxis{
+			Secondary: true, NumFmt: ChartNumFmt{CustomNumFmt: "0.00%"},
+			Maximum: float64Ptr(1), Minimum: float64Ptr(0), MajorUnit: 0.25,
+		},
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartSecondaryAxisNumFmt.xlsx")))
+
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	valAx := chartSpace.Chart.PlotArea.ValAx
+	assert.Len(t, valAx, 2)
+	assert.Equal(t, 100000001, *valAx[0].AxID.Val)
+	assert.Equal(t, "$#,##0.00", valAx[0].NumFmt.FormatCode)
+	assert.Equal(t, 1000.0, *valAx[0].MajorUnit.Val)
+	assert.Nil(t, valAx[0].Scaling.Max)
+	assert.Equal(t, "0.00%", valAx[1].NumFmt.FormatCode)
+	assert.Equal(t, 0.25, *valAx[1].MajorUnit.Val)
+	assert.Equal(t, 1.0, *valAx[1].Scaling.Max.Val)
+	assert.Equal(t, 0.0, *valAx[1].Scaling.Min.Val)
+	// Test the secondary axis with the default number format
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$1:$A$3", Values: "Sheet1!$B$1:$B$3"}},
+	}, &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$1:$A$3", Values: "Sheet1!$C$1:$C$3"}},
+		YAxis:  ChartAxis{Secondary: true},
+	}))
+	chartSpace = xlsxChartSpace{}
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	assert.Equal(t, "General", chartSpace.Chart.PlotArea.ValAx[1].NumFmt.FormatCode)
+}
+
+func TestChartDataLabels(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"A", 5}, {"B", 3}, {"C", 8}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	series := []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$1:$A$3", Values: "Sheet1!$B$1:$B$3"}}
+	plotArea := ChartPlotArea{ShowCatName: true, ShowPercent: true, ShowLeaderLines: true, Separator: "newLine"}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Pie, Series: series, PlotArea: plotArea}))
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{Type: Col, Series: series, PlotArea: ChartPlotArea{ShowVal: true, Separator: "semicolon"}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartDataLabels.xlsx")))
+
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), "<separator>&#xA;</separator><showLeaderLines val=\"1\"></showLeaderLines><leaderLines>")
+	assert.Contains(t, string(content.([]byte)), `<extLst><ext uri="`+ExtURIChartDataLabels+`" xmlns:c15="`+NameSpaceDrawingMLChart2012.Value+`"><c15:showLeaderLines val="1"/></ext></extLst>`)
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), "<separator>; </separator><showLeaderLines val=\"0\"></showLeaderLines></dLbls>")
+	// Test the leader lines of the data labels for the column chart
+	dLbls := f.drawChartDLbls(&Chart{Type: Col, PlotArea: ChartPlotArea{ShowLeaderLines: true}})
+	assert.Nil(t, dLbls.LeaderLines)
+	assert.Nil(t, dLbls.Separator)
+	assert.NotNil(t, dLbls.ExtLst)
+	// Test add chart with invalid data labels separator
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E31", &Chart{Type: Col, Series: series, PlotArea: ChartPlotArea{Separator: "tab"}}))
+}
+
+func TestChartSeriesOrder(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"A", 1, 2, 3}, {"B", 4, 5, 6}, {"C", 7, 8, 9}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1", Order: intPtr(2)},
+		{Name: "Sheet1!$A$2", Values: "Sheet1!$B$2:$D$2", Order: intPtr(0)},
+		{Name: "Sheet1!$A$3", Values: "Sheet1!$B$3:$D$3", Order: intPtr(1)},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: AreaStacked, Series: series}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartSeriesOrder.xlsx")))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	for i, expected := range []int{2, 0, 1} {
+		ser := (*chartSpace.Chart.PlotArea.AreaChart.Ser)[i]
+		assert.Equal(t, i, *ser.IDx.Val)
+		assert.Equal(t, expected, *ser.Order.Val)
+	}
+	// Test add chart with the default order of the combo chart
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{Type: Col, Series: series[:1]},
+		&Chart{Type: Line, Series: []ChartSeries{{Name: "Sheet1!$A$2", Values: "Sheet1!$B$2:$D$2"}}}))
+	// Test add chart with the series without order take the unused orders
+	assert.NoError(t, f.AddChart("Sheet1", "E31", &Chart{Type: Col, Series: []ChartSeries{
+		{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}, {Name: "Sheet1!$A$2", Values: "Sheet1!$B$2:$D$2", Order: intPtr(0)},
+	}}, &Chart{Type: Line, Series: []ChartSeries{{Name: "Sheet1!$A$3", Values: "Sheet1!$B$3:$D$3"}}}))
+	chartSpace = xlsxChartSpace{}
+	content, ok = f.Pkg.Load("xl/charts/chart3.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	var orders []int
+	for _, ser := range *chartSpace.Chart.PlotArea.BarChart.Ser {
+		orders = append(orders, *ser.Order.Val)
+	}
+	for _, ser := range *chartSpace.Chart.PlotArea.LineChart.Ser {
+		orders = append(orders, *ser.Order.Val)
+	}
+	assert.Equal(t, []int{1, 0, 2}, orders)
+	// Test add chart with duplicate series order
+	assert.Equal(t, ErrChartSeriesOrder, f.AddChart("Sheet1", "E46", &Chart{Type: Col, Series: series[:1]},
+		&Chart{Type: Line, Series: []ChartSeries{{Name: "Sheet1!$A$3", Values: "Sheet1!$B$3:$D$3", Order: intPtr(2)}}}))
+	// Test add chart with negative series order
+	assert.Equal(t, ErrChartSeriesOrder, f.AddChart("Sheet1", "E31", &Chart{Type: Col, Series: []ChartSeries{
+		{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1", Order: intPtr(-1)},
+	}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartSeriesDataLabel(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1", DataLabel: ChartDataLabel{
+			NumFmt:   ChartNumFmt{CustomNumFmt: "$#,##0"},
+			Position: "outsideEnd",
+			Font:     Font{Bold: true, Size: 10, Color: "#1F4E79"},
+		}},
+		{Name: "Sheet1!$A$2", Values: "Sheet1!$B$2:$D$2"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series, PlotArea: ChartPlotArea{ShowVal: true}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartSeriesDataLabel.xlsx")))
+	opts := &Chart{Type: Col, Series: series, PlotArea: ChartPlotArea{ShowVal: true}}
+	dLbls := f.drawChartSeriesDLbls(0, opts)
+	assert.Equal(t, &cNumFmt{FormatCode: "$#,##0"}, dLbls.NumFmt)
+	assert.Equal(t, "outEnd", *dLbls.DLblPos.Val)
+	assert.True(t, *dLbls.ShowVal.Val)
+	assert.True(t, dLbls.TxPr.P.PPr.DefRPr.B)
+	assert.Equal(t, 1000.0, dLbls.TxPr.P.PPr.DefRPr.Sz)
+	assert.Equal(t, "1F4E79", *dLbls.TxPr.P.PPr.DefRPr.SolidFill.SrgbClr.Val)
+	// Test the series without data label settings inherits the chart settings
+	assert.Equal(t, f.drawChartDLbls(opts), f.drawChartSeriesDLbls(1, opts))
+	// Test the data labels of the series in scatter and surface chart
+	opts.Type = Scatter
+	assert.Equal(t, "outEnd", *f.drawChartSeriesDLbls(0, opts).DLblPos.Val)
+	assert.Nil(t, f.drawChartSeriesDLbls(1, opts))
+	opts.Type = Surface3D
+	assert.Nil(t, f.drawChartSeriesDLbls(0, opts))
+	// Test the series overrides the contents of the data labels of the chart
+	opts = &Chart{Type: Line, Series: []ChartSeries{
+		{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1", DataLabel: ChartDataLabel{ShowVal: boolPtr(true), ShowCatName: boolPtr(true), ShowSerName: boolPtr(false)}},
+		{Name: "Sheet1!$A$2", Values: "Sheet1!$B$2:$D$2"},
+	}, PlotArea: ChartPlotArea{ShowSerName: true}}
+	dLbls = f.drawChartSeriesDLbls(0, opts)
+	assert.True(t, *dLbls.ShowVal.Val)
+	assert.False(t, *dLbls.ShowSerName.Val)
+	assert.True(t, *dLbls.ShowCatName.Val)
+	assert.False(t, *dLbls.ShowPercent.Val)
+	dLbls = f.drawChartSeriesDLbls(1, opts)
+	assert.False(t, *dLbls.ShowVal.Val)
+	assert.True(t, *dLbls.ShowSerName.Val)
+	assert.NoError(t, f.AddChart("Sheet1", "E20", opts))
+	// Test add chart with the data label position of the series which is not
+	// supported by the chart type
+	for chartType, position := range map[ChartType]string{
+		ColStacked: "outsideEnd", Line: "bestFit", Pie: "above", Area: "center", Col: "x",
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E20", &Chart{Type: chartType, Series: []ChartSeries{
+			{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1", DataLabel: ChartDataLabel{Position: position}},
+		}}))
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestChartTitleLayout(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}}
+	title := []RichTextRun{{Text: "Sales"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Col, Series: series, Title: title,
+		TitleLayout: ChartLayout{Overlay: true, X: float64Ptr(0.02), Y: float64Ptr(0.05)},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{Type: Col, Series: series, Title: title}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartTitleLayout.xlsx")))
+	for chart, expected := range map[string]string{
+		"xl/charts/chart1.xml": `<layout><manualLayout><xMode val="edge"></xMode><yMode val="edge"></yMode><x val="0.02"></x><y val="0.05"></y></manualLayout></layout><overlay val="1"></overlay>`,
+		"xl/charts/chart2.xml": `</tx><overlay val="0"></overlay>`,
+	} {
+		content, ok := f.Pkg.Load(chart)
+		assert.True(t, ok)
+		assert.Contains(t, string(content.([]byte)), expected)
+	}
+	// Test add chart with only horizontal manual position of the title
+	title2 := f.drawChartTitle(&Chart{Title: title, TitleLayout: ChartLayout{X: float64Ptr(0)}})
+	assert.Equal(t, &cManualLayout{
+		XMode: &attrValString{Val: stringPtr("edge")},
+		X:     &attrValFloat{Val: float64Ptr(0)},
+	}, title2.Layout.ManualLayout)
+	// Test add chart without title
+	assert.Nil(t, f.drawChartTitle(&Chart{TitleLayout: ChartLayout{X: float64Ptr(0)}}))
+	// Test add chart with invalid title position
+	for _, layout := range []ChartLayout{{X: float64Ptr(-0.1)}, {Y: float64Ptr(1.1)}} {
+		assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E40", &Chart{Type: Col, Series: series, Title: title, TitleLayout: layout}))
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestChartProtection(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}}
+	// Test add the locked chart and unlocked chart on the protected worksheet
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series, Format: GraphicOptions{
+		Locked: boolPtr(true), LockAspectRatio: true, Positioning: "oneCell",
+	}}))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{Type: Col, Series: series, Format: GraphicOptions{
+		Locked: boolPtr(false), Positioning: "absolute",
+	}}))
+	assert.NoError(t, f.ProtectSheet("Sheet1", &SheetProtectionOptions{Password: "password"}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.True(t, ws.(*xlsxWorksheet).SheetProtection.Objects)
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr := drawing.(*xlsxWsDr)
+	assert.Len(t, wsDr.TwoCellAnchor, 2)
+	for i, expected := range []struct {
+		editAs, graphicFrameLocks string
+		locked                    bool
+	}{
+		{"oneCell", `<a:graphicFrameLocks noChangeAspect="true"></a:graphicFrameLocks>`, true},
+		{"absolute", `<a:graphicFrameLocks></a:graphicFrameLocks>`, false},
+	} {
+		anchor := wsDr.TwoCellAnchor[i]
+		assert.Equal(t, expected.editAs, anchor.EditAs)
+		assert.Equal(t, expected.locked, anchor.ClientData.FLocksWithSheet)
+		assert.True(t, anchor.ClientData.FPrintsWithSheet)
+		assert.Contains(t, anchor.GraphicFrame, "<xdr:cNvGraphicFramePr>"+expected.graphicFrameLocks+"</xdr:cNvGraphicFramePr>")
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartProtection.xlsx")))
+	// Test protect the worksheet with editing objects allowed
+	assert.NoError(t, f.ProtectSheet("Sheet1", &SheetProtectionOptions{EditObjects: true}))
+	assert.False(t, ws.(*xlsxWorksheet).SheetProtection.Objects)
+	// Test add chart with invalid positioning without adding the relationship
+	// of the chart part
+	rels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	count := len(rels.Relationships)
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E40", &Chart{Type: Col, Series: series, Format: GraphicOptions{Positioning: "x"}}))
+	assert.Len(t, rels.Relationships, count)
+	assert.NoError(t, f.Close())
+}
+
+func TestChartLegend(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series, Legend: ChartLegend{
+		Position: "top",
+		Overlay:  true,
+		Font:     Font{Bold: true, Family: "Arial", Size: 12, Color: "#777777"},
+		Fill:     Fill{Type: "pattern", Color: []string{"#F2F2F2"}, Pattern: 1},
+		Border:   ChartBorder{Color: "000000", Width: 1.5},
+	}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartLegend.xlsx")))
+	legend := f.drawChartLegend(&Chart{Legend: ChartLegend{
+		Position: "top",
+		Overlay:  true,
+		Font:     Font{Bold: true, Family: "Arial", Size: 12, Color: "#777777"},
+		Fill:     Fill{Type: "pattern", Color: []string{"#F2F2F2"}, Pattern: 1},
+		Border:   ChartBorder{Color: "000000", Width: 1.5},
+	}})
+	assert.Equal(t, "t", *legend.LegendPos.Val)
+	assert.True(t, *legend.Overlay.Val)
+	assert.Equal(t, "F2F2F2", *legend.SpPr.SolidFill.SrgbClr.Val)
+	assert.Equal(t, "000000", *legend.SpPr.Ln.SolidFill.SrgbClr.Val)
+	assert.Equal(t, 19050, legend.SpPr.Ln.W)
+	rPr := legend.TxPr.P.PPr.DefRPr
+	assert.True(t, rPr.B)
+	assert.Equal(t, 1200.0, rPr.Sz)
+	assert.Equal(t, "Arial", rPr.Latin.Typeface)
+	assert.Equal(t, "777777", *rPr.SolidFill.SrgbClr.Val)
+	assert.Equal(t, 0, legend.TxPr.BodyPr.Rot)
+	// Test the legend with the border width only
+	legend = f.drawChartLegend(&Chart{Legend: ChartLegend{Border: ChartBorder{Width: 2}}})
+	assert.Nil(t, legend.SpPr.SolidFill)
+	assert.Equal(t, 25400, legend.SpPr.Ln.W)
+	assert.Equal(t, "tx1", legend.SpPr.Ln.SolidFill.SchemeClr.Val)
+	// Test the legend with the default settings
+	legend = f.drawChartLegend(&Chart{Legend: ChartLegend{Position: "bottom"}})
+	assert.Equal(t, &cLegend{
+		LegendPos: &attrValString{Val: stringPtr("b")},
+		Overlay:   &attrValBool{Val: boolPtr(false)},
+	}, legend)
+	assert.NoError(t, f.Close())
+}
+
+func TestChartSeriesMarker(t *testing.T) {
+	f := NewFile()
+	opts := &Chart{Type: Line, Series: []ChartSeries{
+		{Marker: ChartMarker{Symbol: "circle", Fill: Fill{Color: []string{"#FF0000"}}, Border: ChartBorder{Color: "00FF00", Width: 2}}},
+		{Marker: ChartMarker{Symbol: "none", Size: 10, Fill: Fill{Color: []string{"FF0000"}}}},
+		{Marker: ChartMarker{Border: ChartBorder{Color: "0000FF"}}},
+	}}
+	marker := f.drawChartSeriesMarker(0, opts)
+	assert.Equal(t, "circle", *marker.Symbol.Val)
+	assert.Equal(t, "FF0000", *marker.SpPr.SolidFill.SrgbClr.Val)
+	assert.Nil(t, marker.SpPr.SolidFill.SchemeClr)
+	assert.Equal(t, "00FF00", *marker.SpPr.Ln.SolidFill.SrgbClr.Val)
+	assert.Equal(t, 25400, marker.SpPr.Ln.W)
+	// Test the marker with none symbol
+	marker = f.drawChartSeriesMarker(1, opts)
+	assert.Equal(t, &cMarker{Symbol: &attrValString{Val: stringPtr("none")}}, marker)
+	// Test the marker with border color only
+	marker = f.drawChartSeriesMarker(2, opts)
+	assert.Equal(t, "accent3", marker.SpPr.SolidFill.SchemeClr.Val)
+	assert.Equal(t, "0000FF", *marker.SpPr.Ln.SolidFill.SrgbClr.Val)
+	assert.Equal(t, 9252, marker.SpPr.Ln.W)
+	// Test the marker of the series without the default color
+	opts.Series = append(make([]ChartSeries, 6), ChartSeries{Marker: ChartMarker{Fill: Fill{Color: []string{"FFFF00"}}}})
+	marker = f.drawChartSeriesMarker(6, opts)
+	assert.Equal(t, "FFFF00", *marker.SpPr.SolidFill.SrgbClr.Val)
+	assert.Nil(t, marker.SpPr.Ln.SolidFill)
+	assert.NoError(t, f.AddChart("Sheet1", "E1", opts))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartSeriesMarker.xlsx")))
+}
+
+func TestChartAxisCrossing(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"A", -5, 3}, {"B", 2, -4}, {"C", 8, 6}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	series := []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$1:$A$3", Values: "Sheet1!$B$1:$B$3"}}
+	series2 := []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$1:$A$3", Values: "Sheet1!$C$1:$C$3"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Col, Series: series,
+		XAxis: ChartAxis{Crosses: "min", AxisPosition: "low"},
+		YAxis: ChartAxis{CrossesAt: 2, AxisPosition: "high"},
+	}, &Chart{Type: Line, Series: series2, XAxis: ChartAxis{Crosses: "max"}}))
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{
+		Type: Col, Series: series, XAxis: ChartAxis{Crosses: "max"},
+	}, &Chart{Type: Line, Series: series2, YAxis: ChartAxis{Secondary: true, CrossesAt: -2}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartAxisCrossing.xlsx")))
+
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	catAx, valAx := chartSpace.Chart.PlotArea.CatAx[0], chartSpace.Chart.PlotArea.ValAx[0]
+	assert.Equal(t, "min", *catAx.Crosses.Val)
+	assert.Nil(t, catAx.CrossesAt)
+	assert.Equal(t, "low", *catAx.TickLblPos.Val)
+	assert.Nil(t, valAx.Crosses)
+	assert.Equal(t, 2.0, *valAx.CrossesAt.Val)
+	assert.Equal(t, "high", *valAx.TickLblPos.Val)
+	// Test the secondary axis crossing in the combo chart
+	chartSpace = xlsxChartSpace{}
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	assert.Len(t, chartSpace.Chart.PlotArea.ValAx, 2)
+	catAx, valAx = chartSpace.Chart.PlotArea.CatAx[0], chartSpace.Chart.PlotArea.ValAx[0]
+	assert.Equal(t, "max", *catAx.Crosses.Val)
+	assert.Equal(t, "autoZero", *valAx.Crosses.Val)
+	assert.Nil(t, valAx.CrossesAt)
+	assert.Nil(t, chartSpace.Chart.PlotArea.ValAx[1].Crosses)
+	assert.Equal(t, -2.0, *chartSpace.Chart.PlotArea.ValAx[1].CrossesAt.Val)
+	// Test add chart with invalid axis crossing and tick labels position
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E32", &Chart{Type: Col, Series: series, XAxis: ChartAxis{Crosses: "middle"}}))
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E32", &Chart{Type: Col, Series: series, YAxis: ChartAxis{AxisPosition: "left"}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartAxisTickMarks(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$1:$A$3", Values: "Sheet1!$B$1:$B$3"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Col, Series: series,
+		XAxis: ChartAxis{MajorTickMark: "out", MinorTickMark: "in"},
+		YAxis: ChartAxis{Crosses: "max", MajorTickMark: "cross"},
+	}))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	catAx, valAx := chartSpace.Chart.PlotArea.CatAx[0], chartSpace.Chart.PlotArea.ValAx[0]
+	assert.Equal(t, "out", *catAx.MajorTickMark.Val)
+	assert.Equal(t, "in", *catAx.MinorTickMark.Val)
+	assert.Equal(t, "max", *valAx.Crosses.Val)
+	assert.Equal(t, "cross", *valAx.MajorTickMark.Val)
+	assert.Equal(t, "none", *valAx.MinorTickMark.Val)
+	// Test add chart with invalid tick marks
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E16", &Chart{Type: Col, Series: series, XAxis: ChartAxis{MajorTickMark: "inside"}}))
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E16", &Chart{Type: Col, Series: series, YAxis: ChartAxis{MinorTickMark: "outside"}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartJSON(t *testing.T) {
+	enable, disable, maximum := true, false, 100.0
+	expected := Chart{
+		Type: Col3DClustered,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", Marker: ChartMarker{Symbol: "none", Size: 10}},
+			{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3", Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}}},
+		},
+		Format:     GraphicOptions{ScaleX: 1, ScaleY: 1, PrintObject: &enable, LockAspectRatio: false, Locked: &disable},
+		Legend:     ChartLegend{Position: "left", ShowLegendKey: false},
+		Title:      []RichTextRun{{Text: "Fruit 3D Clustered Column Chart", Font: &Font{Bold: true}}},
+		VaryColors: &disable,
+		YAxis:      ChartAxis{MajorGridLines: true, Maximum: &maximum},
+		PlotArea:   ChartPlotArea{ShowVal: true, NumFmt: ChartNumFmt{CustomNumFmt: "0.00%"}},
+	}
+	data := `{
+		"type": "Col3DClustered",
+		"series": [
+			{"name": "Sheet1!$A$2", "categories": "Sheet1!$B$1:$D$1", "values": "Sheet1!$B$2:$D$2", "marker": {"symbol": "none", "size": 10}},
+			{"name": "Sheet1!$A$3", "categories": "Sheet1!$B$1:$D$1", "values": "Sheet1!$B$3:$D$3", "fill": {"type": "pattern", "pattern": 1, "color": ["FF0000"]}}
+		],
+		"format": {"scaleX": 1, "scaleY": 1, "printObject": true, "locked": false},
+		"legend": {"position": "left"},
+		"title": [{"text": "Fruit 3D Clustered Column Chart", "font": {"bold": true}}],
+		"varyColors": false,
+		"yAxis": {"majorGridLines": true, "maximum": 100},
+		"plotArea": {"showVal": true, "numFmt": {"customNumFmt": "0.00%"}}
+	}`
+	var chart Chart
+	assert.NoError(t, json.Unmarshal([]byte(data), &chart))
+	assert.Equal(t, expected, chart)
+	// Test marshal the chart options and unmarshal it
+	encoded, err := json.Marshal(expected)
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"type":"Col3DClustered"`)
+	var decoded Chart
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, expected, decoded)
+	// Test add the chart from JSON produces the same chart as the hand-built
+	var parts []string
+	for _, c := range []*Chart{&expected, &chart} {
+		f := NewFile()
+		assert.NoError(t, f.AddChart("Sheet1", "E1", c))
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		assert.NoError(t, err)
+		for _, file := range zr.File {
+			if file.Name == "xl/charts/chart1.xml" {
+				rc, err := file.Open()
+				assert.NoError(t, err)
+				content, err := io.ReadAll(rc)
+				assert.NoError(t, err)
+				parts = append(parts, string(content))
+			}
+		}
+	}
+	assert.Len(t, parts, 2)
+	assert.Equal(t, parts[0], parts[1])
+}
+
+func TestChartTypeJSON(t *testing.T) {
+	for data, expected := range map[string]ChartType{`"Area"`: Area, `"bubble3d"`: Bubble3D, `21`: Col} {
+		var chartType ChartType
+		assert.NoError(t, json.Unmarshal([]byte(data), &chartType))
+		assert.Equal(t, expected, chartType)
+	}
+	var chartType ChartType
+	assert.EqualError(t, json.Unmarshal([]byte(`"Unknown"`), &chartType), "unsupported chart type Unknown")
+	assert.EqualError(t, json.Unmarshal([]byte(`55`), &chartType), "unsupported chart type 55")
+	assert.Error(t, json.Unmarshal([]byte(`true`), &chartType))
+	encoded, err := json.Marshal(Radar)
+	assert.NoError(t, err)
+	assert.Equal(t, `"Radar"`, string(encoded))
+	_, err = json.Marshal(ChartType(55))
+	assert.Error(t, err)
+	assert.Equal(t, "Pie3D", Pie3D.String())
+	assert.Equal(t, "ChartType(55)", ChartType(55).String())
+	text, err := Scatter.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "Scatter", string(text))
+}
@@ -15,8 +15,10 @@ import (
 	"bytes"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/mohae/deepcopy"
 )
@@ -30,6 +32,23 @@ const (
 	EMU                    int     = 9525
 )
 
+// calibriCharWidths defined the approximate width in pixels of the printable
+// ASCII characters with the default 11 points Calibri font.
+var calibriCharWidths = map[rune]float64{
+	' ': 3, '!': 3, '"': 5, '#': 7, '$': 7, '%': 10, '&': 10, '\'': 3,
+	'(': 4, ')': 4, '*': 7, '+': 7, ',': 4, '-': 4, '.': 4, '/': 5,
+	'0': 7, '1': 7, '2': 7, '3': 7, '4': 7, '5': 7, '6': 7, '7': 7, '8': 7, '9': 7,
+	':': 4, ';': 4, '<': 7, '=': 7, '>': 7, '?': 6, '@': 12,
+	'A': 8, 'B': 8, 'C': 8, 'D': 9, 'E': 7, 'F': 7, 'G': 9, 'H': 9, 'I': 3,
+	'J': 4, 'K': 7, 'L': 6, 'M': 12, 'N': 9, 'O': 10, 'P': 7, 'Q': 10, 'R': 8,
+	'S': 7, 'T': 7, 'U': 9, 'V': 8, 'W': 12, 'X': 7, 'Y': 7, 'Z': 7,
+	'[': 4, '\\': 5, ']': 4, '^': 7, '_': 7, '`': 4,
+	'a': 7, 'b': 7, 'c': 6, 'd': 7, 'e': 7, 'f': 4, 'g': 6, 'h': 7, 'i': 3,
+	'j': 3, 'k': 6, 'l': 3, 'm': 11, 'n': 7, 'o': 7, 'p': 7, 'q': 7, 'r': 5,
+	's': 5, 't': 4, 'u': 7, 'v': 6, 'w': 10, 'x': 6, 'y': 6, 'z': 5,
+	'{': 4, '|': 6, '}': 4, '~': 7,
+}
+
 // Cols defines an iterator to a sheet
 type Cols struct {
 	err                                    error
@@ -105,6 +124,9 @@ func (cols *Cols) Rows(opts ...Options) ([]string, error) {
 		switch xmlElement := token.(type) {
 		case xml.StartElement:
 			rowIterator.inElement = xmlElement.Name.Local
+			if rowIterator.inElement == "col" {
+				rowIterator.cols = append(rowIterator.cols, parseColStyle(&xmlElement))
+			}
 			if rowIterator.inElement == "row" {
 				rowIterator.cellCol = 0
 				rowIterator.cellRow++
@@ -112,6 +134,7 @@ func (cols *Cols) Rows(opts ...Options) ([]string, error) {
 				if attrR != 0 {
 					rowIterator.cellRow = attrR
 				}
+				rowIterator.rowStyle = extractRowOpts(xmlElement.Attr).StyleID
 			}
 			if cols.rowXMLHandler(&rowIterator, &xmlElement, decoder); rowIterator.err != nil {
 				return rowIterator.cells, rowIterator.err
@@ -182,7 +205,8 @@ func (cols *Cols) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 		if rowIterator.cellCol == cols.curCol {
 			colCell := xlsxC{}
 			_ = decoder.DecodeElement(&colCell, xmlElement)
-			val, _ := colCell.getValueFrom(cols.f, cols.sst, cols.rawCellValue)
+			style := inheritCellStyle(rowIterator.cellCol, colCell.S, rowIterator.rowStyle, rowIterator.cols)
+			val, _ := colCell.getValueFrom(cols.f, cols.sst, cols.rawCellValue, style)
 			rowIterator.cells = append(rowIterator.cells, val)
 		}
 	}
@@ -290,6 +314,9 @@ func (f *File) GetColVisible(sheet, col string) (bool, error) {
 //
 //	err := f.SetColVisible("Sheet1", "D:F", false)
 func (f *File) SetColVisible(sheet, columns string, visible bool) error {
+	if err := f.checkReadOnly("SetColVisible"); err != nil {
+		return err
+	}
 	min, max, err := f.parseColRange(columns)
 	if err != nil {
 		return err
@@ -378,6 +405,9 @@ func (f *File) parseColRange(columns string) (min, max int, err error) {
 //
 //	err := f.SetColOutlineLevel("Sheet1", "D", 2)
 func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
+	if err := f.checkReadOnly("SetColOutlineLevel"); err != nil {
+		return err
+	}
 	if level > 7 || level < 1 {
 		return ErrOutlineLevel
 	}
@@ -417,7 +447,11 @@ func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
 // SetColStyle provides a function to set style of columns by given worksheet
 // name, columns range and style ID. This function is concurrency safe. Note
 // that this will overwrite the existing styles for the columns, it won't
-// append or merge style with existing styles.
+// append or merge style with existing styles. By default, the style will be
+// also applied to the existing cells in the columns, set the Cascade field of
+// the optional ColStyleOptions to false to keep the styles of the existing
+// cells. The style of a cell is resolved in order of the cell's own style, the
+// row style and the column style.
 //
 // For example set style of column H on Sheet1:
 //
@@ -426,7 +460,17 @@ func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
 // Set style of columns C:F on Sheet1:
 //
 //	err = f.SetColStyle("Sheet1", "C:F", style)
-func (f *File) SetColStyle(sheet, columns string, styleID int) error {
+//
+// Set style of columns C:F on Sheet1 without changing the existing cells:
+//
+//	cascade := false
+//	err = f.SetColStyle("Sheet1", "C:F", style, excelize.ColStyleOptions{
+//	    Cascade: &cascade,
+//	})
+func (f *File) SetColStyle(sheet, columns string, styleID int, opts ...ColStyleOptions) error {
+	if err := f.checkReadOnly("SetColStyle"); err != nil {
+		return err
+	}
 	min, max, err := f.parseColRange(columns)
 	if err != nil {
 		return err
@@ -469,6 +513,11 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 		return fc
 	})
 	ws.mu.Unlock()
+	for _, opt := range opts {
+		if opt.Cascade != nil && !*opt.Cascade {
+			return err
+		}
+	}
 	if rows := len(ws.SheetData.Row); rows > 0 {
 		for col := min; col <= max; col++ {
 			from, _ := CoordinatesToCellName(col, 1)
@@ -484,6 +533,9 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 //
 //	err := f.SetColWidth("Sheet1", "A", "H", 20)
 func (f *File) SetColWidth(sheet, startCol, endCol string, width float64) error {
+	if err := f.checkReadOnly("SetColWidth"); err != nil {
+		return err
+	}
 	min, max, err := f.parseColRange(startCol + ":" + endCol)
 	if err != nil {
 		return err
@@ -524,6 +576,340 @@ func (f *File) SetColWidth(sheet, startCol, endCol string, width float64) error
 	return err
 }
 
+// SetColWidths provides a function to set the width of multiple columns by
+// given worksheet name and the map of the column names and widths. The column
+// definitions of the worksheet will be updated in a single pass, and the
+// adjacent columns with the same width and properties will be collapsed into
+// a single column definition on saving. This function is concurrency safe.
+// For example, set the width of the columns A, C and D on Sheet1:
+//
+//	err := f.SetColWidths("Sheet1", map[string]float64{"A": 12, "C": 20, "D": 20})
+func (f *File) SetColWidths(sheet string, widths map[string]float64) error {
+	if err := f.checkReadOnly("SetColWidths"); err != nil {
+		return err
+	}
+	targets, colWidths := make([]int, 0, len(widths)), make(map[int]float64, len(widths))
+	for name, width := range widths {
+		col, err := ColumnNameToNumber(name)
+		if err != nil {
+			return err
+		}
+		if width > MaxColumnWidth {
+			return ErrColumnWidth
+		}
+		if _, ok := colWidths[col]; !ok {
+			targets = append(targets, col)
+		}
+		colWidths[col] = width
+	}
+	sort.Ints(targets)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if len(targets) == 0 {
+		return err
+	}
+	if ws.Cols == nil {
+		ws.Cols = &xlsxCols{}
+	}
+	sort.SliceStable(ws.Cols.Col, func(i, j int) bool {
+		return ws.Cols.Col[i].Min < ws.Cols.Col[j].Min
+	})
+	setWidth := func(c xlsxCol, col int) xlsxCol {
+		c.Min, c.Max = col, col
+		c.Width, c.CustomWidth = float64Ptr(colWidths[col]), true
+		return c
+	}
+	cols, idx := make([]xlsxCol, 0, len(ws.Cols.Col)+len(targets)), 0
+	for _, c := range ws.Cols.Col {
+		for ; idx < len(targets) && targets[idx] < c.Min; idx++ {
+			cols = append(cols, setWidth(xlsxCol{}, targets[idx]))
+		}
+		start := c.Min
+		for ; idx < len(targets) && targets[idx] <= c.Max; idx++ {
+			if targets[idx] > start {
+				piece := c
+				piece.Min, piece.Max = start, targets[idx]-1
+				cols = append(cols, piece)
+			}
+			cols = append(cols, setWidth(c, targets[idx]))
+			start = targets[idx] + 1
+		}
+		if start <= c.Max {
+			c.Min = start
+			cols = append(cols, c)
+		}
+	}
+	for ; idx < len(targets); idx++ {
+		cols = append(cols, setWidth(xlsxCol{}, targets[idx]))
+	}
+	ws.Cols.Col = cols
+	return err
+}
+
+// ColumnsIterator defines an iterator to the column definitions of a
+// worksheet.
+type ColumnsIterator struct {
+	cols   []xlsxCol
+	curCol int
+}
+
+// ColumnsIterator returns an iterator to read the column definitions of the
+// worksheet, which including the width, visibility, style and outline level
+// of the columns. The adjacent columns with the same definition will be
+// returned as one column definition, and the columns without definition will
+// be skipped. The iterator reads a snapshot of the column definitions taken
+// when calling this function. This function is concurrency safe. For example:
+//
+//	cols, err := f.ColumnsIterator("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cols.Next() {
+//	    col := cols.Column()
+//	    fmt.Println(col.StartCol, col.EndCol, col.Width, col.Hidden)
+//	}
+func (f *File) ColumnsIterator(sheet string) (*ColumnsIterator, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	iter := &ColumnsIterator{curCol: -1}
+	if ws.Cols == nil || len(ws.Cols.Col) == 0 {
+		return iter, err
+	}
+	snapshot := &xlsxWorksheet{Cols: &xlsxCols{Col: make([]xlsxCol, len(ws.Cols.Col))}}
+	copy(snapshot.Cols.Col, ws.Cols.Col)
+	f.mergeExpandedCols(snapshot)
+	iter.cols = snapshot.Cols.Col
+	return iter, err
+}
+
+// Next will return true if it finds the next column definition.
+func (cols *ColumnsIterator) Next() bool {
+	cols.curCol++
+	return cols.curCol < len(cols.cols)
+}
+
+// Column returns the current column definition.
+func (cols *ColumnsIterator) Column() ColOpts {
+	var opts ColOpts
+	if cols.curCol < 0 || cols.curCol >= len(cols.cols) {
+		return opts
+	}
+	c := cols.cols[cols.curCol]
+	opts.StartCol, _ = ColumnNumberToName(c.Min)
+	opts.EndCol, _ = ColumnNumberToName(c.Max)
+	if c.Width != nil {
+		opts.Width = *c.Width
+	}
+	opts.CustomWidth, opts.Hidden, opts.StyleID = c.CustomWidth, c.Hidden, c.Style
+	opts.OutlineLevel, opts.Collapsed = c.OutlineLevel, c.Collapsed
+	return opts
+}
+
+// AutoFitColumn provides a function to set the width of columns to fit the
+// formatted value of the cells by given worksheet name and column names. The
+// width of each character is approximated with the metrics of the default
+// Calibri font, and scaled by the font size and bold settings of the cell
+// style. The cells in hidden rows and the cells in merged range which
+// spanning multiple columns will be skipped, and the columns without any
+// visible value will keep the original width. All columns containing cells
+// will be fitted if no column name was given. For example, fit the width of
+// the column A, and the columns from C to E on Sheet1:
+//
+//	err := f.AutoFitColumn("Sheet1", "A", "C:E")
+func (f *File) AutoFitColumn(sheet string, cols ...string) error {
+	if err := f.checkReadOnly("AutoFitColumn"); err != nil {
+		return err
+	}
+	return f.AutoFitColumnWithOptions(sheet, AutoFitColumnOptions{IgnoreMergeCells: true}, cols...)
+}
+
+// AutoFitColumnWithOptions provides a function to set the width of columns to
+// fit the formatted value of the cells by given worksheet name, auto fit
+// options and column names. For example, fit the width of column B on Sheet1
+// with the maximum width of 50 characters, and measure the merged cells:
+//
+//	err := f.AutoFitColumnWithOptions("Sheet1", excelize.AutoFitColumnOptions{
+//	    MaxWidth: 50,
+//	}, "B")
+func (f *File) AutoFitColumnWithOptions(sheet string, opts AutoFitColumnOptions, cols ...string) error {
+	if err := f.checkReadOnly("AutoFitColumnWithOptions"); err != nil {
+		return err
+	}
+	if opts.MaxWidth < 0 || opts.MaxWidth > MaxColumnWidth {
+		return ErrColumnWidth
+	}
+	if opts.MaxWidth == 0 {
+		opts.MaxWidth = MaxColumnWidth
+	}
+	fitCols := map[int]bool{}
+	for _, col := range cols {
+		min, max, err := f.parseColRange(col)
+		if err != nil {
+			return err
+		}
+		for c := min; c <= max; c++ {
+			fitCols[c] = true
+		}
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	styleSheet, err := f.stylesReader()
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	widths, err := f.measureColumns(ws, sst, styleSheet, fitCols, opts)
+	if err != nil {
+		return err
+	}
+	for col, width := range widths {
+		name, _ := ColumnNumberToName(col)
+		if err = f.SetColWidth(sheet, name, name, width); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// measureColumns provides a function to measure the width of the columns by
+// given worksheet, shared string table, style sheet, columns to be measured
+// and auto fit options. All columns will be measured if the columns map is
+// empty.
+func (f *File) measureColumns(ws *xlsxWorksheet, sst *xlsxSST, styleSheet *xlsxStyleSheet, fitCols map[int]bool, opts AutoFitColumnOptions) (map[int]float64, error) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var mergedRects [][]int
+	if ws.MergeCells != nil && opts.IgnoreMergeCells {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			if mergeCell == nil {
+				continue
+			}
+			rect, err := mergeCell.Rect()
+			if err != nil {
+				return nil, err
+			}
+			if rect[0] != rect[2] {
+				mergedRects = append(mergedRects, rect)
+			}
+		}
+	}
+	widths := map[int]float64{}
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
+		if rowData.Hidden {
+			continue
+		}
+		for colIdx := range rowData.C {
+			c := &rowData.C[colIdx]
+			col, row, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return nil, err
+			}
+			if len(fitCols) > 0 && !fitCols[col] {
+				continue
+			}
+			if inMergedRects(mergedRects, col, row) {
+				continue
+			}
+			style := ws.prepareCellStyle(col, row, c.S)
+			val, err := c.getValueFrom(f, sst, false, style)
+			if err != nil {
+				return nil, err
+			}
+			if val == "" {
+				continue
+			}
+			width := measureTextWidth(val, styleSheet, style)
+			if width > opts.MaxWidth {
+				width = opts.MaxWidth
+			}
+			if width > widths[col] {
+				widths[col] = width
+			}
+		}
+	}
+	return widths, nil
+}
+
+// inMergedRects returns if the given cell coordinates in any merged range.
+func inMergedRects(rects [][]int, col, row int) bool {
+	for _, rect := range rects {
+		if cellInRange([]int{col, row}, rect) {
+			return true
+		}
+	}
+	return false
+}
+
+// measureTextWidth provides a function to measure the column width of the
+// text measured as the number of characters of the maximum digit width by
+// given cell value, style sheet and style index.
+func measureTextWidth(text string, styleSheet *xlsxStyleSheet, styleIdx int) float64 {
+	size, bold := 11.0, false
+	if styleSheet != nil && styleSheet.CellXfs != nil && styleSheet.Fonts != nil &&
+		styleIdx >= 0 && styleIdx < len(styleSheet.CellXfs.Xf) {
+		if fontID := styleSheet.CellXfs.Xf[styleIdx].FontID; fontID != nil &&
+			*fontID >= 0 && *fontID < len(styleSheet.Fonts.Font) {
+			fnt := styleSheet.Fonts.Font[*fontID]
+			if fnt.Sz != nil && fnt.Sz.Val != nil && *fnt.Sz.Val > 0 {
+				size = *fnt.Sz.Val
+			}
+			bold = fnt.B != nil && (fnt.B.Val == nil || *fnt.B.Val)
+		}
+	}
+	var pixels float64
+	for _, line := range strings.Split(text, "\n") {
+		var linePixels float64
+		for _, r := range line {
+			linePixels += charWidthPixels(r)
+		}
+		pixels = math.Max(pixels, linePixels)
+	}
+	pixels *= size / 11
+	if bold {
+		pixels *= 1.07
+	}
+	// Convert pixels to the number of characters with 5 pixels padding, and
+	// truncate to the nearest 1/256 of the character width.
+	width := math.Trunc((pixels+5)/7*256) / 256
+	return math.Min(width, MaxColumnWidth)
+}
+
+// charWidthPixels returns the approximate width in pixels of the character
+// with the default 11 points Calibri font.
+func charWidthPixels(r rune) float64 {
+	if width, ok := calibriCharWidths[r]; ok {
+		return width
+	}
+	if unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana) || (r >= 0xFF00 && r <= 0xFFEF) {
+		return 15
+	}
+	return 7
+}
+
 // flatCols provides a method for the column's operation functions to flatten
 // and check the worksheet columns.
 func flatCols(col xlsxCol, cols []xlsxCol, replacer func(fc, c xlsxCol) xlsxCol) []xlsxCol {
@@ -557,6 +943,19 @@ func flatCols(col xlsxCol, cols []xlsxCol, replacer func(fc, c xlsxCol) xlsxCol)
 
 // positionObjectPixels calculate the vertices that define the position of a
 // graphical object within the worksheet in pixels.
+func (f *File) positionObjectPixels(sheet string, col, row, x1, y1, width, height int) (int, int, int, int, int, int) {
+	return f.positionObject(sheet, col, row, x1, y1, width, height, 1)
+}
+
+// positionObjectEMUs calculate the vertices that define the position of a
+// graphical object within the worksheet in EMUs.
+func (f *File) positionObjectEMUs(sheet string, col, row, x1, y1, width, height int) (int, int, int, int, int, int) {
+	return f.positionObject(sheet, col, row, x1, y1, width, height, EMU)
+}
+
+// positionObject calculate the vertices that define the position of a
+// graphical object within the worksheet, the offsets and sizes are measured in
+// the given unit, which is the number of that unit per pixel.
 //
 //	      +------------+------------+
 //	      |     A      |      B     |
@@ -603,18 +1002,18 @@ func flatCols(col xlsxCol, cols []xlsxCol, replacer func(fc, c xlsxCol) xlsxCol)
 //
 //	width           # Width of object frame.
 //	height          # Height of object frame.
-func (f *File) positionObjectPixels(sheet string, col, row, x1, y1, width, height int) (int, int, int, int, int, int) {
+func (f *File) positionObject(sheet string, col, row, x1, y1, width, height, unit int) (int, int, int, int, int, int) {
 	colIdx, rowIdx := col-1, row-1
 	// Adjust start column for offsets that are greater than the col width.
-	for x1 >= f.getColWidth(sheet, colIdx+1) {
+	for x1 >= f.getColWidth(sheet, colIdx+1)*unit {
 		colIdx++
-		x1 -= f.getColWidth(sheet, colIdx)
+		x1 -= f.getColWidth(sheet, colIdx) * unit
 	}
 
 	// Adjust start row for offsets that are greater than the row height.
-	for y1 >= f.getRowHeight(sheet, rowIdx+1) {
+	for y1 >= f.getRowHeight(sheet, rowIdx+1)*unit {
 		rowIdx++
-		y1 -= f.getRowHeight(sheet, rowIdx)
+		y1 -= f.getRowHeight(sheet, rowIdx) * unit
 	}
 
 	// Initialized end cell to the same as the start cell.
@@ -624,15 +1023,15 @@ func (f *File) positionObjectPixels(sheet string, col, row, x1, y1, width, heigh
 	height += y1
 
 	// Subtract the underlying cell widths to find end cell of the object.
-	for width >= f.getColWidth(sheet, colEnd+1) {
+	for width >= f.getColWidth(sheet, colEnd+1)*unit {
 		colEnd++
-		width -= f.getColWidth(sheet, colEnd)
+		width -= f.getColWidth(sheet, colEnd) * unit
 	}
 
 	// Subtract the underlying cell heights to find end cell of the object.
-	for height >= f.getRowHeight(sheet, rowEnd+1) {
+	for height >= f.getRowHeight(sheet, rowEnd+1)*unit {
 		rowEnd++
-		height -= f.getRowHeight(sheet, rowEnd)
+		height -= f.getRowHeight(sheet, rowEnd) * unit
 	}
 
 	// The end vertices are whatever is left from the width and height.
@@ -653,11 +1052,16 @@ func (f *File) getColWidth(sheet string, col int) int {
 			}
 		}
 		if width != 0 {
-			return int(convertColWidthToPixels(width))
+			return int(ColWidthToPixels(width))
 		}
 	}
 	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
-		return int(convertColWidthToPixels(ws.SheetFormatPr.DefaultColWidth))
+		return int(ColWidthToPixels(ws.SheetFormatPr.DefaultColWidth))
+	}
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.BaseColWidth > 0 {
+		// The default column width is the base column width plus the
+		// padding, and rounded up to the nearest multiple of 8 pixels.
+		return int(math.Ceil((float64(ws.SheetFormatPr.BaseColWidth)*7+5)/8) * 8)
 	}
 	// Optimization for when the column widths haven't changed.
 	return int(defaultColWidthPixels)
@@ -731,10 +1135,15 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 //	err := f.InsertCols("Sheet1", "C", 2)
 //
 // Use this method with caution, which will affect changes in references such
-// as formulas, charts, and so on. If there is any referenced value of the
-// worksheet, it will cause a file error when you open it. The excelize only
-// partially updates these references currently.
+// as formulas, charts, and so on. The references in the formulas, defined
+// names, merged cells, tables, data validations, conditional formats,
+// comments and chart series will be updated, the comments on the deleted cells
+// will be removed, and the references to the deleted cells will be replaced
+// with the #REF! error.
 func (f *File) InsertCols(sheet, col string, n int) error {
+	if err := f.checkReadOnly("InsertCols"); err != nil {
+		return err
+	}
 	num, err := ColumnNameToNumber(col)
 	if err != nil {
 		return err
@@ -751,10 +1160,15 @@ func (f *File) InsertCols(sheet, col string, n int) error {
 //	err := f.RemoveCol("Sheet1", "C")
 //
 // Use this method with caution, which will affect changes in references such
-// as formulas, charts, and so on. If there is any referenced value of the
-// worksheet, it will cause a file error when you open it. The excelize only
-// partially updates these references currently.
+// as formulas, charts, and so on. The references in the formulas, defined
+// names, merged cells, tables, data validations, conditional formats,
+// comments and chart series will be updated, the comments on the deleted cells
+// will be removed, and the references to the deleted cells will be replaced
+// with the #REF! error.
 func (f *File) RemoveCol(sheet, col string) error {
+	if err := f.checkReadOnly("RemoveCol"); err != nil {
+		return err
+	}
 	num, err := ColumnNameToNumber(col)
 	if err != nil {
 		return err
@@ -777,11 +1191,26 @@ func (f *File) RemoveCol(sheet, col string) error {
 	return f.adjustHelper(sheet, columns, num, -1)
 }
 
-// convertColWidthToPixels provides function to convert the width of a cell
+// PixelsToEMUs provides a function to convert the pixels to EMUs (English
+// Metric Units) at 96 DPI, which is the unit of measurement of the offsets and
+// extents of the graphic objects. For example, get the EMUs of 10.5 pixels:
+//
+//	emus := excelize.PixelsToEMUs(10.5)
+func PixelsToEMUs(pixels float64) int {
+	return int(math.Round(pixels * float64(EMU)))
+}
+
+// EMUsToPixels provides a function to convert the EMUs (English Metric Units)
+// to pixels at 96 DPI.
+func EMUsToPixels(emus int) float64 {
+	return float64(emus) / float64(EMU)
+}
+
+// ColWidthToPixels provides a function to convert the width of a column
 // from user's units to pixels. Excel rounds the column width to the nearest
 // pixel. If the width hasn't been set by the user we use the default value.
 // If the column is hidden it has a value of zero.
-func convertColWidthToPixels(width float64) float64 {
+func ColWidthToPixels(width float64) float64 {
 	var padding float64 = 5
 	var pixels float64
 	var maxDigitWidth float64 = 7
@@ -2,6 +2,7 @@ package excelize
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -349,6 +350,77 @@ func TestSetColStyle(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, styleID, cellStyleID)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColStyle.xlsx")))
+	// Test set column style without cascading to the existing cells
+	numFmtStyleID, err := f.NewStyle(&Style{NumFmt: 10})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "F1", 0.25))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F2", 0.5))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "F2", "F2", styleID))
+	assert.NoError(t, f.SetColStyle("Sheet1", "F", numFmtStyleID, ColStyleOptions{Cascade: boolPtr(false)}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 0, ws.(*xlsxWorksheet).SheetData.Row[0].C[5].S)
+	assert.Equal(t, styleID, ws.(*xlsxWorksheet).SheetData.Row[1].C[5].S)
+	// Test the cell without style inherit the number format of column style
+	for cell, expected := range map[string]int{"F1": numFmtStyleID, "F2": styleID, "F3": numFmtStyleID} {
+		cellStyleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellStyleID, cell)
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "F3", 0.75))
+	for cell, expected := range map[string]string{"F1": "25.00%", "F2": "0.5", "F3": "75.00%"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	// Test the row style takes precedence over the column style
+	assert.NoError(t, f.SetRowStyle("Sheet1", 4, 4, styleID))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F4", 0.5))
+	cellStyleID, err = f.GetCellStyle("Sheet1", "F4")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, cellStyleID)
+	// Test set column style with cascading to the existing cells
+	assert.NoError(t, f.SetColStyle("Sheet1", "F", numFmtStyleID, ColStyleOptions{Cascade: boolPtr(true)}))
+	val, err := f.GetCellValue("Sheet1", "F2")
+	assert.NoError(t, err)
+	assert.Equal(t, "50.00%", val)
+	// Test the cells without style inherit the row and column styles on
+	// reading the cell values by the streaming readers
+	f2 := NewFile()
+	assert.NoError(t, f2.SetSheetRows("Sheet1", "A1", [][]interface{}{{0.25, 0.5}, {0.75, 1}}))
+	percentStyleID, err := f2.NewStyle(&Style{NumFmt: 10})
+	assert.NoError(t, err)
+	currencyStyleID, err := f2.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f2.SetColStyle("Sheet1", "B", percentStyleID, ColStyleOptions{Cascade: boolPtr(false)}))
+	ws, ok = f2.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[1].S = currencyStyleID
+	buf, err := f2.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f2.Close())
+	f2, err = OpenReader(buf)
+	assert.NoError(t, err)
+	expected := [][]string{{"0.25", "50.00%"}, {"0.75", "1.00"}}
+	rows, err := f2.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, rows)
+	var streamed [][]string
+	assert.NoError(t, f2.StreamRows("Sheet1", func(row int, cols []string) error {
+		streamed = append(streamed, cols)
+		return nil
+	}))
+	assert.Equal(t, expected, streamed)
+	cols, err := f2.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"0.25", "0.75"}, {"50.00%", "1.00"}}, cols)
+	result, err := f2.SearchSheet("Sheet1", "50.00%")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B1"}, result)
+	result, err = f2.SearchSheet("Sheet1", "1.00")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B2"}, result)
+	assert.NoError(t, f2.Close())
 	// Test set column style with unsupported charset style sheet
 	f.Styles = nil
 	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
@@ -392,7 +464,77 @@ func TestColWidth(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestColWidth.xlsx")))
-	convertRowHeightToPixels(0)
+	RowHeightToPixels(0)
+}
+
+func TestSetColWidths(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "F", 15))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D:E", false))
+	widths := map[string]float64{"A": 12, "C": 20, "E": 20, "H": 30}
+	for col := 100; col <= 10000; col++ {
+		name, err := ColumnNumberToName(col)
+		assert.NoError(t, err)
+		widths[name] = 8
+	}
+	assert.NoError(t, f.SetColWidths("Sheet1", widths))
+	for col, expected := range map[string]float64{"A": 12, "B": 15, "C": 20, "D": 15, "E": 20, "F": 15, "G": defaultColWidth, "H": 30, "CV": 8, "NTP": 8} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	for col, expected := range map[string]bool{"C": true, "D": false, "E": false, "F": true} {
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visible, col)
+	}
+	// Test set columns width without columns
+	assert.NoError(t, f.SetColWidths("Sheet1", nil))
+	// Test the adjacent columns with the same width will be collapsed on saving
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColWidths.xlsx")))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	if assert.Len(t, ws.(*xlsxWorksheet).Cols.Col, 8) {
+		assert.Equal(t, 100, ws.(*xlsxWorksheet).Cols.Col[7].Min)
+		assert.Equal(t, 10000, ws.(*xlsxWorksheet).Cols.Col[7].Max)
+	}
+	// Test set columns width with invalid column name and width
+	assert.EqualError(t, f.SetColWidths("Sheet1", map[string]float64{"*": 10}), newInvalidColumnNameError("*").Error())
+	assert.Equal(t, ErrColumnWidth, f.SetColWidths("Sheet1", map[string]float64{"A": MaxColumnWidth + 1}))
+	// Test set columns width on not exists worksheet
+	assert.EqualError(t, f.SetColWidths("SheetN", map[string]float64{"A": 10}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestColumnsIteratorColOpts(t *testing.T) {
+	f := NewFile()
+	iter, err := f.ColumnsIterator("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, iter.Next())
+	assert.Equal(t, ColOpts{}, iter.Column())
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "C", 12))
+	assert.NoError(t, f.SetColVisible("Sheet1", "C", false))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "E", 2))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "F:G", style))
+	iter, err = f.ColumnsIterator("Sheet1")
+	assert.NoError(t, err)
+	var cols []ColOpts
+	for iter.Next() {
+		cols = append(cols, iter.Column())
+	}
+	assert.Equal(t, []ColOpts{
+		{StartCol: "A", EndCol: "B", Width: 12, CustomWidth: true},
+		{StartCol: "C", EndCol: "C", Width: 12, CustomWidth: true, Hidden: true},
+		{StartCol: "E", EndCol: "E", CustomWidth: true, OutlineLevel: 2},
+		{StartCol: "F", EndCol: "G", Width: defaultColWidth, StyleID: style},
+	}, cols)
+	assert.Equal(t, ColOpts{}, iter.Column())
+	// Test get columns iterator on not exists worksheet
+	_, err = f.ColumnsIterator("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
 }
 
 func TestGetColStyle(t *testing.T) {
@@ -461,5 +603,55 @@ func TestRemoveCol(t *testing.T) {
 }
 
 func TestConvertColWidthToPixels(t *testing.T) {
-	assert.Equal(t, -11.0, convertColWidthToPixels(-1))
+	assert.Equal(t, -11.0, ColWidthToPixels(-1))
+}
+
+func TestAutoFitColumn(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello World"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "Hi"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 1234567890))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "Merged cells text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", "C"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C1", "D1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", "E"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E2", "Text in the hidden row"))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, false))
+	assert.NoError(t, f.AutoFitColumn("Sheet1"))
+	for col, expected := range map[string]float64{"A": 10.140625, "B": 10.7109375, "C": 1.85546875, "E": 1.7109375} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.InDelta(t, expected, width, 0.0001, col)
+	}
+	// Test auto fit column with bold and large font size
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true, Size: 22}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.AutoFitColumn("Sheet1", "A"))
+	width, err := f.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.InDelta(t, 20.9, width, 0.1)
+	// Test auto fit column with options
+	assert.NoError(t, f.AutoFitColumnWithOptions("Sheet1", AutoFitColumnOptions{MaxWidth: 5}, "A:D"))
+	for col, expected := range map[string]float64{"A": 5, "B": 5, "C": 5} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	assert.EqualError(t, f.AutoFitColumnWithOptions("Sheet1", AutoFitColumnOptions{MaxWidth: 256}), ErrColumnWidth.Error())
+	// Test auto fit column with invalid column name
+	assert.EqualError(t, f.AutoFitColumn("Sheet1", "*"), newInvalidColumnNameError("*").Error())
+	// Test auto fit column on not exists worksheet
+	assert.EqualError(t, f.AutoFitColumn("SheetN"), "sheet SheetN does not exist")
+	// Test auto fit column with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitColumn("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestMeasureTextWidth(t *testing.T) {
+	assert.InDelta(t, 10.140625, measureTextWidth("Hello World\nHi", nil, 0), 0.0001)
+	assert.InDelta(t, 5, measureTextWidth("中文", nil, 0), 0.0001)
+	assert.Equal(t, float64(MaxColumnWidth), measureTextWidth(strings.Repeat("W", 300), nil, 0))
 }
@@ -45,6 +45,7 @@ var (
 	fatSect                     = -3
 	iterCount                   = 50000
 	packageEncryptionChunkSize  = 4096
+	packageCLSID                = []byte{0x0c, 0x00, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}
 	packageOffset               = 8 // First 8 bytes are the size of the stream
 	sheetProtectionSpinCount    = 1e5
 	workbookProtectionSpinCount = 1e5
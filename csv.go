@@ -0,0 +1,249 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/xuri/excelize/v2/xencoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvDelimiters defined the candidate delimiters for detecting the delimiter
+// of the CSV data.
+var csvDelimiters = []rune{',', ';', '\t', '|'}
+
+// csvDateLayouts defined the layouts of the date and time values which will
+// be parsed on importing CSV data with type inference, the boolean value
+// specifies if the layout contains the time part.
+var csvDateLayouts = []struct {
+	layout string
+	time   bool
+}{
+	{"2006-01-02", false},
+	{"2006/01/02", false},
+	{"2006-01-02 15:04:05", true},
+	{"2006-01-02T15:04:05", true},
+	{time.RFC3339, true},
+}
+
+// CSVImportOptions define the options for importing CSV data.
+//
+// Delimiter specifies the field delimiter, the delimiter will be detected
+// from the first line of the CSV data among comma, semicolon, tab and
+// vertical bar if it was not specified.
+//
+// StartCell specifies the top-left cell reference of the imported data, the
+// default value is A1.
+//
+// InferTypes specifies if convert the numbers, booleans (TRUE and FALSE) and
+// dates (such as 2006-01-02 and 2006-01-02 15:04:05) fields into the
+// corresponding cell types, all fields will be kept as strings by default.
+// The numbers with leading zeros will be kept as strings.
+//
+// MaxRows specifies the maximum number of rows allowed to be imported, the
+// ErrCSVMaxRows error will be returned when the CSV data exceeds this limit.
+// The default value is 0 which means no limit.
+type CSVImportOptions struct {
+	Delimiter  rune
+	StartCell  string
+	InferTypes bool
+	MaxRows    int
+}
+
+// CSVImportResult defined the number of the rows and non-empty cells written
+// by importing CSV data.
+type CSVImportResult struct {
+	Rows  int
+	Cells int
+}
+
+// ImportCSV provides a function to import CSV data from the io.Reader into
+// the worksheet by given worksheet name, the worksheet will be created if it
+// doesn't exist. The data will be written by the stream writer, so the
+// existing data in the worksheet will be replaced and the memory usage stays
+// flat for the huge CSV data. The number of the rows and cells written will be
+// returned, even if an error occurred. For example, import the CSV file with
+// type inference into Sheet2 starting at cell B2:
+//
+//	file, err := os.Open("data.csv")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	result, err := f.ImportCSV("Sheet2", file, excelize.CSVImportOptions{
+//	    StartCell:  "B2",
+//	    InferTypes: true,
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Printf("%d rows, %d cells imported\n", result.Rows, result.Cells)
+func (f *File) ImportCSV(sheet string, r io.Reader, opts ...CSVImportOptions) (CSVImportResult, error) {
+	if err := f.checkReadOnly("ImportCSV"); err != nil {
+		return CSVImportResult{}, err
+	}
+	if err := checkSheetName(sheet); err != nil {
+		return CSVImportResult{}, err
+	}
+	if _, _, err := CellNameToCoordinates(getCSVImportOptions(opts...).StartCell); err != nil {
+		return CSVImportResult{}, err
+	}
+	if idx, _ := f.GetSheetIndex(sheet); idx == -1 {
+		if _, err := f.NewSheet(sheet); err != nil {
+			return CSVImportResult{}, err
+		}
+	}
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return CSVImportResult{}, err
+	}
+	result, err := sw.ImportCSV(r, opts...)
+	if flushErr := sw.Flush(); err == nil {
+		err = flushErr
+	}
+	return result, err
+}
+
+// ImportCSV provides a function to import CSV data from the io.Reader as rows
+// for the StreamWriter. The StartCell in the options must be below the rows
+// which have been written, and you must call the 'Flush' function to end the
+// streaming writing process after that.
+func (sw *StreamWriter) ImportCSV(r io.Reader, opts ...CSVImportOptions) (CSVImportResult, error) {
+	var result CSVImportResult
+	options := getCSVImportOptions(opts...)
+	col, row, err := CellNameToCoordinates(options.StartCell)
+	if err != nil {
+		return result, err
+	}
+	br := bufio.NewReader(r)
+	if options.Delimiter == 0 {
+		options.Delimiter = detectCSVDelimiter(br)
+	}
+	reader := csv.NewReader(br)
+	reader.Comma, reader.FieldsPerRecord, reader.ReuseRecord = options.Delimiter, -1, true
+	dateStyle := -1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return result, err
+		}
+		if options.MaxRows > 0 && result.Rows >= options.MaxRows {
+			return result, ErrCSVMaxRows
+		}
+		cell, err := CoordinatesToCellName(col, row+result.Rows)
+		if err != nil {
+			return result, err
+		}
+		var cells int
+		values := make([]interface{}, len(record))
+		for i, field := range record {
+			if field == "" {
+				continue
+			}
+			cells++
+			if !options.InferTypes {
+				values[i] = field
+				continue
+			}
+			value, dateOnly := inferCSVValue(field)
+			if dateOnly {
+				if dateStyle == -1 {
+					if dateStyle, err = sw.file.NewStyle(&Style{NumFmt: 14}); err != nil {
+						return result, err
+					}
+				}
+				value = Cell{StyleID: dateStyle, Value: value}
+			}
+			values[i] = value
+		}
+		if err = sw.SetRow(cell, values); err != nil {
+			return result, err
+		}
+		result.Rows++
+		result.Cells += cells
+	}
+}
+
+// getCSVImportOptions provides a function to parse the optional settings for
+// importing CSV data.
+func getCSVImportOptions(opts ...CSVImportOptions) CSVImportOptions {
+	options := CSVImportOptions{StartCell: "A1"}
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.StartCell == "" {
+		options.StartCell = "A1"
+	}
+	return options
+}
+
+// detectCSVDelimiter provides a function to detect the delimiter by counting
+// the candidate delimiters outside the quoted fields in the first line of the
+// CSV data, the comma will be returned if no candidate delimiter was found.
+func detectCSVDelimiter(br *bufio.Reader) rune {
+	line, _ := br.Peek(br.Size())
+	if idx := bytes.IndexByte(line, '\n'); idx != -1 {
+		line = line[:idx]
+	}
+	counts := make(map[rune]int, len(csvDelimiters))
+	var quoted bool
+	for _, r := range string(line) {
+		if r == '"' {
+			quoted = !quoted
+			continue
+		}
+		if !quoted {
+			counts[r]++
+		}
+	}
+	delimiter := csvDelimiters[0]
+	for _, r := range csvDelimiters[1:] {
+		if counts[r] > counts[delimiter] {
+			delimiter = r
+		}
+	}
+	return delimiter
+}
+
+// inferCSVValue provides a function to convert the CSV field into the number,
+// boolean or time value, the field will be returned as is if it can't be
+// converted. The second return value specifies if the field is a date without
+// time part.
+func inferCSVValue(field string) (interface{}, bool) {
+	switch strings.ToUpper(field) {
+	case "TRUE":
+		return true, false
+	case "FALSE":
+		return false, false
+	}
+	if isNum, precision, _ := isNumeric(field); isNum && precision <= 15 &&
+		!(len(field) > 1 && field[0] == '0' && field[1] != '.') {
+		if num, err := strconv.ParseFloat(field, 64); err == nil {
+			return num, false
+		}
+	}
+	for _, date := range csvDateLayouts {
+		if t, err := time.Parse(date.layout, field); err == nil {
+			return t, !date.time
+		}
+	}
+	return field, false
+}
@@ -0,0 +1,94 @@
+package excelize
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportCSV(t *testing.T) {
+	f := NewFile()
+	data := "Name;Amount;Active;Date;Code\n\"Smith; J\";12.5;TRUE;2023-05-01;007\nDoe;3;false;2023-05-01 08:30:00;\n"
+	result, err := f.ImportCSV("Sheet2", strings.NewReader(data), CSVImportOptions{StartCell: "B2", InferTypes: true})
+	assert.NoError(t, err)
+	assert.Equal(t, CSVImportResult{Rows: 3, Cells: 14}, result)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "Name", "Amount", "Active", "Date", "Code"},
+		{"", "Smith; J", "12.5", "TRUE", "05-01-23", "007"},
+		{"", "Doe", "3", "FALSE", "5/1/23 08:30"},
+	}, rows)
+	for cell, expected := range map[string]CellType{
+		"C3": CellTypeNumber, "D3": CellTypeBool, "E3": CellTypeDate, "F3": CellTypeInlineString,
+	} {
+		cellType, err := f.GetCellType("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test import CSV data into the stream writer
+	f = NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Header"}))
+	result, err = sw.ImportCSV(strings.NewReader("1,2\n3,4\n"), CSVImportOptions{StartCell: "A2", InferTypes: true})
+	assert.NoError(t, err)
+	assert.Equal(t, CSVImportResult{Rows: 2, Cells: 4}, result)
+	// Test import CSV data into the stream writer with rows already written
+	_, err = sw.ImportCSV(strings.NewReader("5,6"), CSVImportOptions{StartCell: "A3"})
+	assert.Equal(t, newStreamSetRowError(3), err)
+	assert.NoError(t, sw.Flush())
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Header"}, {"1", "2"}, {"3", "4"}}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test import CSV data without type inference
+	f = NewFile()
+	result, err = f.ImportCSV("Sheet1", strings.NewReader("a\t1\nTRUE\t\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, CSVImportResult{Rows: 2, Cells: 3}, result)
+	cellType, err := f.GetCellType("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeInlineString, cellType)
+	// Test import CSV data exceeds the maximum rows
+	result, err = f.ImportCSV("Sheet1", strings.NewReader("1\n2\n3\n"), CSVImportOptions{MaxRows: 2})
+	assert.ErrorIs(t, err, ErrCSVMaxRows)
+	assert.Equal(t, CSVImportResult{Rows: 2, Cells: 2}, result)
+	// Test import invalid CSV data
+	_, err = f.ImportCSV("Sheet1", strings.NewReader("a,\"b\nc"))
+	assert.Error(t, err)
+	// Test import CSV data with invalid start cell
+	_, err = f.ImportCSV("Sheet1", strings.NewReader("a"), CSVImportOptions{StartCell: "A"})
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test import CSV data which exceeds the maximum rows of the worksheet
+	_, err = f.ImportCSV("Sheet1", strings.NewReader("1\n2\n"), CSVImportOptions{StartCell: "A1048576"})
+	assert.ErrorIs(t, err, ErrMaxRows)
+	// Test import CSV data with invalid sheet name
+	_, err = f.ImportCSV("Sheet:1", strings.NewReader("a"))
+	assert.ErrorIs(t, err, ErrSheetNameInvalid)
+	assert.NoError(t, f.Close())
+}
+
+func TestDetectCSVDelimiter(t *testing.T) {
+	for data, expected := range map[string]rune{
+		"a,b,c\n1;2":       ',',
+		"a;b;c":            ';',
+		"a\tb\n1,2,3":      '\t',
+		"\"a,b,c\"|d|e\n1": '|',
+		"":                 ',',
+	} {
+		assert.Equal(t, expected, detectCSVDelimiter(bufio.NewReader(strings.NewReader(data))), data)
+	}
+}
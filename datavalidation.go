@@ -14,6 +14,7 @@ package excelize
 import (
 	"fmt"
 	"math"
+	"path/filepath"
 	"strings"
 	"unicode/utf16"
 )
@@ -105,14 +106,26 @@ func (dv *DataValidation) SetError(style DataValidationErrorStyle, title, msg st
 	dv.ErrorStyle = &strStyle
 }
 
-// SetInput set prompt notice.
+// SetInput set prompt notice. The data validation with only the prompt notice
+// set will be added as the type none, which shows the input message on
+// selecting the cell without restricting the input. For example, show an
+// input message on Sheet1!A1:B2:
+//
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "A1:B2"
+//	dv.SetInput("Tips", "Enter the product code")
+//	err := f.AddDataValidation("Sheet1", dv)
 func (dv *DataValidation) SetInput(title, msg string) {
+	if dv.Type == "" {
+		dv.Type = convDataValidationType(typeNone)
+	}
 	dv.ShowInputMessage = true
 	dv.PromptTitle = &title
 	dv.Prompt = &msg
 }
 
-// SetDropList data validation list.
+// SetDropList data validation list. The in-cell drop-down arrow of the list
+// is visible by default, use SetShowDropDown to hide it.
 func (dv *DataValidation) SetDropList(keys []string) error {
 	formula := strings.Join(keys, ",")
 	if MaxFieldLength < len(utf16.Encode([]rune(formula))) {
@@ -123,6 +136,21 @@ func (dv *DataValidation) SetDropList(keys []string) error {
 	return nil
 }
 
+// SetShowDropDown provides a function to set whether the in-cell drop-down
+// arrow of the list data validation is visible. Note that the showDropDown
+// attribute in the Office Open XML is inverted, the attribute value 1 (the
+// ShowDropDown field is true) hides the drop-down arrow. For example, hide
+// the in-cell drop-down arrow of the list on Sheet1!A1:
+//
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "A1"
+//	dv.SetDropList([]string{"1", "2", "3"})
+//	dv.SetShowDropDown(false)
+//	err := f.AddDataValidation("Sheet1", dv)
+func (dv *DataValidation) SetShowDropDown(visible bool) {
+	dv.ShowDropDown = !visible
+}
+
 // SetRange provides function to set data validation range in drop list, only
 // accepts int, float64, or string data type formula argument.
 func (dv *DataValidation) SetRange(f1, f2 interface{}, t DataValidationType, o DataValidationOperator) error {
@@ -170,11 +198,35 @@ func (dv *DataValidation) SetRange(f1, f2 interface{}, t DataValidationType, o D
 //	dv.Sqref = "A7:B8"
 //	dv.SetSqrefDropList("$E$1:$E$3")
 //	err := f.AddDataValidation("Sheet1", dv)
+//
+// The source reference range in another workbook, such as
+// [Book1.xlsx]Sheet1!$E$1:$E$3, is unsupported, and the AddDataValidation
+// function will return the ErrUnsupportedExternalRef error.
 func (dv *DataValidation) SetSqrefDropList(sqref string) {
 	dv.Formula1 = fmt.Sprintf("<formula1>%s</formula1>", sqref)
 	dv.Type = convDataValidationType(typeList)
 }
 
+// SetDropListFromName provides function to set data validation list in drop
+// list by given defined name, this allows the drop list exceeds the limit of
+// 255 characters and can be reused across the data validations. The defined
+// name must be exists in the workbook scope or the scope of the worksheet
+// which the data validation added to. For example, set data validation on
+// Sheet1!A1:A10 with the list in the defined name MyList:
+//
+//	err := f.SetDefinedName(&excelize.DefinedName{
+//	    Name:     "MyList",
+//	    RefersTo: "Sheet2!$A$1:$A$100",
+//	})
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "A1:A10"
+//	dv.SetDropListFromName("MyList")
+//	err = f.AddDataValidation("Sheet1", dv)
+func (dv *DataValidation) SetDropListFromName(name string) {
+	dv.Formula1 = fmt.Sprintf("<formula1>%s</formula1>", strings.TrimPrefix(name, "="))
+	dv.Type = convDataValidationType(typeList)
+}
+
 // SetSqref provides function to set data validation range in drop list.
 func (dv *DataValidation) SetSqref(sqref string) {
 	if dv.Sqref == "" {
@@ -247,10 +299,22 @@ func convDataValidationOperator(o DataValidationOperator) string {
 //	dv.SetDropList([]string{"1", "2", "3"})
 //	err = f.AddDataValidation("Sheet1", dv)
 func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
+	if err := f.checkReadOnly("AddDataValidation"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	if err = f.checkDataValidationDefinedName(sheet, dv); err != nil {
+		return err
+	}
+	if err = f.checkDataValidationExternalRef(dv); err != nil {
+		return err
+	}
+	if dv.Type == "" && dv.Formula1 == "" && dv.Formula2 == "" {
+		dv.Type = convDataValidationType(typeNone)
+	}
 	if nil == ws.DataValidations {
 		ws.DataValidations = new(xlsxDataValidations)
 	}
@@ -259,6 +323,63 @@ func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	return err
 }
 
+// checkDataValidationDefinedName provides a function to check if the defined
+// name which referenced by the list source of the data validation exists in
+// the workbook scope or the scope of the given worksheet.
+func (f *File) checkDataValidationDefinedName(sheet string, dv *DataValidation) error {
+	if dv.Type != convDataValidationType(typeList) {
+		return nil
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(dv.Formula1, "<formula1>"), "</formula1>")
+	if name == "" || checkDefinedName(name) != nil {
+		return nil
+	}
+	if _, _, err := CellNameToCoordinates(name); err == nil {
+		return nil
+	}
+	for _, definedName := range f.GetDefinedName() {
+		if strings.EqualFold(definedName.Name, name) &&
+			(definedName.Scope == "Workbook" || strings.EqualFold(definedName.Scope, sheet)) {
+			return nil
+		}
+	}
+	return ErrDefinedNameScope
+}
+
+// checkDataValidationExternalRef provides a function to check if the formulas
+// of the data validation reference another workbook, such as
+// [Book1.xlsx]Sheet1!$A$1:$A$3, or the defined name in another workbook, such
+// as Book1.xlsx!MyList. Excel requires the external references in the data
+// validation to be stored with the external link parts, which is not
+// supported, and repairs the workbook by removing all data validations of the
+// worksheet, so return an error instead.
+func (f *File) checkDataValidationExternalRef(dv *DataValidation) error {
+	for _, formula := range dataValidationFormulaRegexp.FindAllStringSubmatch(dv.Formula1+dv.Formula2, -1) {
+		var external bool
+		replaceFormulaSheetRefs(xmlUnescaper.Replace(formula[2]), func(sheet string) (string, bool) {
+			external = external || strings.Contains(sheet, "]") || f.isExternalWorkbookName(sheet)
+			return sheet, false
+		})
+		if external {
+			return ErrUnsupportedExternalRef
+		}
+	}
+	return nil
+}
+
+// isExternalWorkbookName provides a function to check if the given name before
+// the exclamation mark in the formula is the file name of another workbook,
+// which has the workbook file extension and is not a worksheet name of the
+// workbook.
+func (f *File) isExternalWorkbookName(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	if _, ok := supportedContentTypes[ext]; !ok && ext != ".xls" && ext != ".xlsb" {
+		return false
+	}
+	idx, _ := f.GetSheetIndex(name)
+	return idx == -1
+}
+
 // GetDataValidations returns data validations list by given worksheet name.
 func (f *File) GetDataValidations(sheet string) ([]*DataValidation, error) {
 	ws, err := f.workSheetReader(sheet)
@@ -271,10 +392,131 @@ func (f *File) GetDataValidations(sheet string) ([]*DataValidation, error) {
 	return ws.DataValidations.DataValidation, err
 }
 
+// CopyDataValidation provides a function to copy the data validation which
+// covers the given source reference sequence to the target reference sequence
+// by given worksheet name. The copied data validation will be added as a new
+// independent data validation with the target reference sequence, and the
+// source data validation keeps unchanged. For example, copy the data
+// validation of Sheet1!A1:A10 to Sheet1!C1:C10 and Sheet1!E1:E10:
+//
+//	err := f.CopyDataValidation("Sheet1", "A1:A10", "C1:C10 E1:E10")
+func (f *File) CopyDataValidation(sheet, fromSqref, toSqref string) error {
+	if err := f.checkReadOnly("CopyDataValidation"); err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	fromRects, err := sqrefToCoordinates(fromSqref)
+	if err != nil {
+		return err
+	}
+	toRects, err := sqrefToCoordinates(toSqref)
+	if err != nil {
+		return err
+	}
+	if len(fromRects) == 0 || len(toRects) == 0 {
+		return ErrParameterInvalid
+	}
+	if ws.DataValidations == nil {
+		return ErrDataValidationNotFound
+	}
+	for _, dv := range ws.DataValidations.DataValidation {
+		// Skip the reference sequence of the data validation which can't be
+		// parsed, such as the whole column reference
+		rects, _ := sqrefToCoordinates(dv.Sqref)
+		if !coversRects(rects, fromRects) {
+			continue
+		}
+		newDV := *dv
+		for _, attr := range []**string{
+			&newDV.Error, &newDV.ErrorStyle, &newDV.ErrorTitle, &newDV.Prompt, &newDV.PromptTitle,
+		} {
+			if *attr != nil {
+				*attr = stringPtr(**attr)
+			}
+		}
+		newDV.Sqref = strings.Join(strings.Fields(toSqref), " ")
+		ws.DataValidations.DataValidation = append(ws.DataValidations.DataValidation, &newDV)
+		ws.DataValidations.Count = len(ws.DataValidations.DataValidation)
+		return err
+	}
+	return ErrDataValidationNotFound
+}
+
+// sqrefToCoordinates provides a function to convert the space-separated list
+// of the cell references or range references to the sorted coordinates of the
+// ranges, the references which can't be parsed will be skipped, and the
+// first error will be returned.
+func sqrefToCoordinates(sqref string) ([][]int, error) {
+	var (
+		rects    [][]int
+		firstErr error
+	)
+	for _, ref := range strings.Fields(sqref) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		_ = sortCoordinates(coordinates)
+		rects = append(rects, coordinates)
+	}
+	return rects, firstErr
+}
+
+// coversRects returns if the given ranges are covered by the union of the
+// ranges, each range will be split into the parts which are not covered by
+// each range of the union.
+func coversRects(union, rects [][]int) bool {
+	remaining := rects
+	for _, r := range union {
+		var parts [][]int
+		for _, rect := range remaining {
+			if !isOverlap(rect, r) {
+				parts = append(parts, rect)
+				continue
+			}
+			if rect[1] < r[1] {
+				parts = append(parts, []int{rect[0], rect[1], rect[2], r[1] - 1})
+			}
+			if rect[3] > r[3] {
+				parts = append(parts, []int{rect[0], r[3] + 1, rect[2], rect[3]})
+			}
+			top, bottom := rect[1], rect[3]
+			if r[1] > top {
+				top = r[1]
+			}
+			if r[3] < bottom {
+				bottom = r[3]
+			}
+			if rect[0] < r[0] {
+				parts = append(parts, []int{rect[0], top, r[0] - 1, bottom})
+			}
+			if rect[2] > r[2] {
+				parts = append(parts, []int{r[2] + 1, top, rect[2], bottom})
+			}
+		}
+		if remaining = parts; len(remaining) == 0 {
+			return true
+		}
+	}
+	return len(remaining) == 0
+}
+
 // DeleteDataValidation delete data validation by given worksheet name and
 // reference sequence. All data validations in the worksheet will be deleted
 // if not specify reference sequence parameter.
 func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
+	if err := f.checkReadOnly("DeleteDataValidation"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -12,8 +12,10 @@
 package excelize
 
 import (
+	"github.com/xuri/excelize/v2/xencoding/xml"
 	"math"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -169,6 +171,193 @@ func TestDataValidationError(t *testing.T) {
 	assert.EqualError(t, f.AddDataValidation("Sheet:1", nil), ErrSheetNameInvalid.Error())
 }
 
+func TestDataValidationInputMessageOnly(t *testing.T) {
+	f := NewFile()
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:B2"
+	dv.SetInput("Tips", "Enter the product code")
+	assert.Equal(t, "none", dv.Type)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	// Test add data validation without type and formula
+	dv = NewDataValidation(true)
+	dv.Sqref = "C1:C2"
+	dv.ShowInputMessage, dv.Prompt = true, stringPtr("Any value")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.Equal(t, "none", dv.Type)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDataValidationInputMessageOnly.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestDataValidationInputMessageOnly.xlsx"))
+	assert.NoError(t, err)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, dvs, 2) {
+		assert.Equal(t, "A1:B2", dvs[0].Sqref)
+		assert.Equal(t, "none", dvs[0].Type)
+		assert.True(t, dvs[0].ShowInputMessage)
+		assert.Equal(t, "Tips", *dvs[0].PromptTitle)
+		assert.Equal(t, "Enter the product code", *dvs[0].Prompt)
+		assert.Empty(t, dvs[0].Formula1)
+		assert.Empty(t, dvs[0].Operator)
+		assert.Nil(t, dvs[0].ErrorStyle)
+		assert.Equal(t, "none", dvs[1].Type)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestDataValidationDropListFromName(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for row := 1; row <= 100; row++ {
+		assert.NoError(t, f.SetCellStr("Sheet2", "A"+strconv.Itoa(row), strings.Repeat("s", 10)+strconv.Itoa(row)))
+	}
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "MyList", RefersTo: "Sheet2!$A$1:$A$100"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "LocalList", RefersTo: "Sheet2!$A$1:$A$10", Scope: "Sheet2"}))
+
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A10"
+	dv.SetDropListFromName("=MyList")
+	assert.Equal(t, "<formula1>MyList</formula1>", dv.Formula1)
+	assert.Equal(t, "list", dv.Type)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	// Test add data validation with the defined name in the worksheet scope
+	dv = NewDataValidation(true)
+	dv.Sqref = "B1:B10"
+	dv.SetDropListFromName("locallist")
+	assert.NoError(t, f.AddDataValidation("Sheet2", dv))
+	assert.Equal(t, ErrDefinedNameScope, f.AddDataValidation("Sheet1", dv))
+	// Test add data validation with not exists defined name
+	dv.SetDropListFromName("UnknownList")
+	assert.Equal(t, ErrDefinedNameScope, f.AddDataValidation("Sheet1", dv))
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDataValidationDropListFromName.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestDataValidationExternalRef(t *testing.T) {
+	f := NewFile()
+	for _, sqref := range []string{
+		"[Book1.xlsx]Sheet1!$A$1:$A$3",
+		"'[Book1.xlsx]Sheet 1'!$A$1:$A$3",
+		"'C:\\Data\\[Book1.xlsx]Sheet1'!$A$1:$A$3",
+		"[1]Sheet1!$A$1:$A$3",
+		"OFFSET([1]Sheet1!$A$1,0,0,3)",
+		"Book1.xlsx!MyList",
+		"'Book 1.xlsm'!MyList",
+		"'C:\\Data\\Book1.xls'!MyList",
+	} {
+		dv := NewDataValidation(true)
+		dv.Sqref = "A1:A10"
+		dv.SetSqrefDropList(sqref)
+		assert.Equal(t, ErrUnsupportedExternalRef, f.AddDataValidation("Sheet1", dv), sqref)
+	}
+	dv := NewDataValidation(true)
+	dv.Sqref = "B1:B10"
+	assert.NoError(t, dv.SetRange("[Book1.xlsx]Sheet1!$A$1", 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.Equal(t, ErrUnsupportedExternalRef, f.AddDataValidation("Sheet1", dv))
+	// Test add data validation with the references in the same workbook, and
+	// the list which contains brackets
+	_, err := f.NewSheet("Data.xlsx")
+	assert.NoError(t, err)
+	for _, sqref := range []string{"Sheet1!$E$1:$E$3", "'Sheet 1'!$E$1:$E$3", "$E$1:$E$3", "INDIRECT(\"Table1[Col]\")", "'Data.xlsx'!$A$1:$A$3"} {
+		dv = NewDataValidation(true)
+		dv.Sqref = "C1:C10"
+		dv.SetSqrefDropList(sqref)
+		assert.NoError(t, f.AddDataValidation("Sheet1", dv), sqref)
+	}
+	dv = NewDataValidation(true)
+	dv.Sqref = "D1:D10"
+	assert.NoError(t, dv.SetDropList([]string{"[Book1.xlsx]Sheet1!A1", "b"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 6)
+	assert.NoError(t, f.Close())
+}
+
+func TestDataValidationShowDropDown(t *testing.T) {
+	f := NewFile()
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A10"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2", "3"}))
+	assert.False(t, dv.ShowDropDown)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	// Test hide the in-cell drop-down arrow of the list
+	dv = NewDataValidation(true)
+	dv.Sqref = "B1:B10"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2", "3"}))
+	dv.SetShowDropDown(false)
+	assert.True(t, dv.ShowDropDown)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	output, err := xml.Marshal(ws.(*xlsxWorksheet).DataValidations)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<dataValidation allowBlank="true" sqref="A1:A10" type="list">`)
+	assert.Contains(t, string(output), `<dataValidation allowBlank="true" showDropDown="true" sqref="B1:B10" type="list">`)
+	dv.SetShowDropDown(true)
+	assert.False(t, dv.ShowDropDown)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDataValidationShowDropDown.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestCopyDataValidation(t *testing.T) {
+	f := NewFile()
+	// Test copy data validation without data validations
+	assert.Equal(t, ErrDataValidationNotFound, f.CopyDataValidation("Sheet1", "A1", "B1"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A10"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2", "3"}))
+	dv.SetInput("Tips", "Select a value")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.NoError(t, f.CopyDataValidation("Sheet1", "A2:A5", "C1:C10  E1:E10"))
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, dvs, 2) {
+		assert.Equal(t, "A1:A10", dvs[0].Sqref)
+		assert.Equal(t, "C1:C10 E1:E10", dvs[1].Sqref)
+		assert.Equal(t, dvs[0].Formula1, dvs[1].Formula1)
+		assert.Equal(t, "Select a value", *dvs[1].Prompt)
+		// Test the copied data validation is independent of the source
+		*dvs[1].Prompt = "Copied"
+		assert.Equal(t, "Select a value", *dvs[0].Prompt)
+	}
+	// Test copy data validation with the source range not covered
+	assert.Equal(t, ErrDataValidationNotFound, f.CopyDataValidation("Sheet1", "A10:A11", "G1"))
+	// Test copy data validation with invalid references
+	assert.Equal(t, ErrParameterInvalid, f.CopyDataValidation("Sheet1", "", "G1"))
+	assert.Equal(t, ErrParameterInvalid, f.CopyDataValidation("Sheet1", "A1", ""))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.CopyDataValidation("Sheet1", "A", "G1"))
+	assert.Equal(t, newCellNameToCoordinatesError("G", newInvalidCellNameError("G")), f.CopyDataValidation("Sheet1", "A1", "G"))
+	// Test copy data validation with the source range covered by multiple
+	// ranges of the data validation
+	assert.NoError(t, f.CopyDataValidation("Sheet1", "C5:C10 E1", "G1:G5"))
+	assert.Equal(t, ErrDataValidationNotFound, f.CopyDataValidation("Sheet1", "C5:E10", "G1:G5"))
+	// Test copy data validation with the data validation references can't be
+	// parsed, such as the whole column reference
+	dvs[0].Sqref = "A"
+	assert.Equal(t, ErrDataValidationNotFound, f.CopyDataValidation("Sheet1", "A1", "G1"))
+	assert.NoError(t, f.Close())
+	f = NewFile()
+	for _, sqref := range []string{"A:A", "C1:C3"} {
+		dv = NewDataValidation(true)
+		dv.Sqref = sqref
+		assert.NoError(t, dv.SetDropList([]string{"1", "2"}))
+		assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	}
+	assert.NoError(t, f.CopyDataValidation("Sheet1", "C1", "E1"))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 3)
+	assert.Equal(t, "E1", dvs[2].Sqref)
+	// Test copy data validation on not exists worksheet
+	assert.EqualError(t, f.CopyDataValidation("SheetN", "A1", "G1"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteDataValidation(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A1:B2"))
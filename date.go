@@ -21,6 +21,10 @@ const (
 	dayNanoseconds = 24 * time.Hour
 	maxDuration    = 290 * 364 * dayNanoseconds
 	roundEpsilon   = 1e-9
+	// date1904Offset is the number of days between the 1900 and 1904 date
+	// system epochs, the serial number 0 in the 1904 date system equals to
+	// the serial number 1462 in the 1900 date system.
+	date1904Offset = 1462
 )
 
 var (
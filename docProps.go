@@ -13,9 +13,14 @@ package excelize
 
 import (
 	"bytes"
+	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"io"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // SetAppProps provides a function to set document application properties. The
@@ -39,6 +44,11 @@ import (
 //	                   |
 //	 Company           | The name of a company associated with the document.
 //	                   |
+//	 Manager           | The name of the supervisor associated with the document.
+//	                   |
+//	 HyperlinkBase     | The base string used for evaluating relative hyperlinks
+//	                   | in this document.
+//	                   |
 //	 LinksUpToDate     | Indicates whether hyperlinks in a document are up-to-date. Set this
 //	                   | element to 'true' to indicate that hyperlinks are updated. Set this
 //	                   | element to 'false' to indicate that hyperlinks are outdated.
@@ -60,11 +70,16 @@ import (
 //	    ScaleCrop:         true,
 //	    DocSecurity:       3,
 //	    Company:           "Company Name",
+//	    Manager:           "Manager Name",
+//	    HyperlinkBase:     "https://github.com/xuri/excelize",
 //	    LinksUpToDate:     true,
 //	    HyperlinksChanged: true,
 //	    AppVersion:        "16.0000",
 //	})
 func (f *File) SetAppProps(appProperties *AppProperties) error {
+	if err := f.checkReadOnly("SetAppProps"); err != nil {
+		return err
+	}
 	var (
 		app                *xlsxProperties
 		err                error
@@ -78,7 +93,7 @@ func (f *File) SetAppProps(appProperties *AppProperties) error {
 		Decode(app); err != nil && err != io.EOF {
 		return err
 	}
-	fields = []string{"Application", "ScaleCrop", "DocSecurity", "Company", "LinksUpToDate", "HyperlinksChanged", "AppVersion"}
+	fields = []string{"Application", "ScaleCrop", "DocSecurity", "Company", "Manager", "HyperlinkBase", "LinksUpToDate", "HyperlinksChanged", "AppVersion"}
 	immutable, mutable = reflect.ValueOf(*appProperties), reflect.ValueOf(app).Elem()
 	for _, field = range fields {
 		immutableField := immutable.FieldByName(field)
@@ -109,6 +124,8 @@ func (f *File) GetAppProps() (ret *AppProperties, err error) {
 		ScaleCrop:         app.ScaleCrop,
 		DocSecurity:       app.DocSecurity,
 		Company:           app.Company,
+		Manager:           app.Manager,
+		HyperlinkBase:     app.HyperlinkBase,
 		LinksUpToDate:     app.LinksUpToDate,
 		HyperlinksChanged: app.HyperlinksChanged,
 		AppVersion:        app.AppVersion,
@@ -180,6 +197,9 @@ func (f *File) GetAppProps() (ret *AppProperties, err error) {
 //	    Version:        "1.0.0",
 //	})
 func (f *File) SetDocProps(docProperties *DocProperties) error {
+	if err := f.checkReadOnly("SetDocProps"); err != nil {
+		return err
+	}
 	var (
 		core               *decodeCoreProperties
 		err                error
@@ -271,3 +291,138 @@ func (f *File) GetDocProps() (ret *DocProperties, err error) {
 	}
 	return
 }
+
+// customPropertiesReader provides a function to get the pointer to the
+// structure after deserialization of docProps/custom.xml.
+func (f *File) customPropertiesReader() (*xlsxCustomProperties, error) {
+	props := new(xlsxCustomProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCustom)))).
+		Decode(props); err != nil && err != io.EOF {
+		return props, err
+	}
+	return props, nil
+}
+
+// SetCustomProperty provides a function to set the custom document property
+// by given property name and value. The supported value types are string,
+// integer, float, bool and time.Time, which will be stored as text, number,
+// yes or no and date typed custom properties. The existing property with the
+// same name will be replaced, and the property will be removed if the value
+// is nil. For example, set a custom property named "Reviewed" with the value
+// true:
+//
+//	err := f.SetCustomProperty("Reviewed", true)
+func (f *File) SetCustomProperty(name string, value interface{}) error {
+	if err := f.checkReadOnly("SetCustomProperty"); err != nil {
+		return err
+	}
+	if name == "" {
+		return ErrParameterRequired
+	}
+	var (
+		val      xlsxCustomPropertyValue
+		idx, pid = -1, 1
+	)
+	if value != nil {
+		var err error
+		if val, err = newCustomPropertyValue(value); err != nil {
+			return err
+		}
+	}
+	props, err := f.customPropertiesReader()
+	if err != nil {
+		return err
+	}
+	for i, prop := range props.Property {
+		if strings.EqualFold(prop.Name, name) {
+			idx = i
+		}
+		if prop.PID > pid {
+			pid = prop.PID
+		}
+		props.Property[i].Value.XMLName.Space = ""
+		props.Property[i].Value.XMLName.Local = "vt:" + prop.Value.XMLName.Local
+	}
+	switch {
+	case value == nil && idx == -1:
+		return err
+	case value == nil:
+		props.Property = append(props.Property[:idx], props.Property[idx+1:]...)
+	case idx == -1:
+		props.Property = append(props.Property, xlsxCustomProperty{
+			FmtID: "{D5CDD505-2E9C-101B-9397-08002B2CF9AE}", PID: pid + 1, Name: name, Value: val,
+		})
+	default:
+		props.Property[idx].Value = val
+	}
+	if _, ok := f.Pkg.Load(defaultXMLPathDocPropsCustom); !ok {
+		if err = f.setContentTypes("/"+defaultXMLPathDocPropsCustom, ContentTypeCustomProperties); err != nil {
+			return err
+		}
+		f.addRels("_rels/.rels", SourceRelationshipCustomProperties, defaultXMLPathDocPropsCustom, "")
+	}
+	props.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
+	output, err := xml.Marshal(props)
+	f.saveFileList(defaultXMLPathDocPropsCustom, output)
+	return err
+}
+
+// newCustomPropertyValue provides a function to create the variant type value
+// element of the custom property by given value.
+func newCustomPropertyValue(value interface{}) (xlsxCustomPropertyValue, error) {
+	val := xlsxCustomPropertyValue{XMLName: xml.Name{Local: "vt:i4"}}
+	switch v := value.(type) {
+	case string:
+		val.XMLName.Local, val.Content = "vt:lpwstr", v
+	case int:
+		val.Content = strconv.Itoa(v)
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			val.XMLName.Local = "vt:r8"
+		}
+	case int8, int16, int32, uint8, uint16:
+		val.Content = fmt.Sprint(v)
+	case int64, uint, uint32, uint64:
+		val.XMLName.Local, val.Content = "vt:r8", fmt.Sprint(v)
+	case float32:
+		val.XMLName.Local, val.Content = "vt:r8", strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		val.XMLName.Local, val.Content = "vt:r8", strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		val.XMLName.Local, val.Content = "vt:bool", strconv.FormatBool(v)
+	case time.Time:
+		val.XMLName.Local, val.Content = "vt:filetime", v.UTC().Format("2006-01-02T15:04:05Z")
+	default:
+		return val, ErrCustomPropertyType
+	}
+	return val, nil
+}
+
+// GetCustomProperty provides a function to get the custom document property
+// value by given property name. The value of the text, number, yes or no and
+// date typed custom properties will be returned as string, int or float64,
+// bool and time.Time, and the value of the other variant types will be
+// returned as string. It returns nil if the property doesn't exist.
+func (f *File) GetCustomProperty(name string) (interface{}, error) {
+	props, err := f.customPropertiesReader()
+	if err != nil {
+		return nil, err
+	}
+	for _, prop := range props.Property {
+		if !strings.EqualFold(prop.Name, name) {
+			continue
+		}
+		content := prop.Value.Content
+		switch prop.Value.XMLName.Local {
+		case "i1", "i2", "i4", "int", "ui1", "ui2":
+			return strconv.Atoi(content)
+		case "i8", "ui4", "ui8", "uint", "r4", "r8", "decimal":
+			return strconv.ParseFloat(content, 64)
+		case "bool":
+			return content == "true" || content == "1", err
+		case "filetime", "date":
+			return time.Parse(time.RFC3339, content)
+		}
+		return content, err
+	}
+	return nil, err
+}
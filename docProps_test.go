@@ -14,6 +14,7 @@ package excelize
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,10 +31,16 @@ func TestSetAppProps(t *testing.T) {
 		ScaleCrop:         true,
 		DocSecurity:       3,
 		Company:           "Company Name",
+		Manager:           "Manager Name",
+		HyperlinkBase:     "https://github.com/xuri/excelize",
 		LinksUpToDate:     true,
 		HyperlinksChanged: true,
 		AppVersion:        "16.0000",
 	}))
+	props, err := f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "Manager Name", props.Manager)
+	assert.Equal(t, "https://github.com/xuri/excelize", props.HyperlinkBase)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetAppProps.xlsx")))
 	f.Pkg.Store(defaultXMLPathDocPropsApp, nil)
 	assert.NoError(t, f.SetAppProps(&AppProperties{}))
@@ -116,3 +123,75 @@ func TestGetDocProps(t *testing.T) {
 	_, err = f.GetDocProps()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestCustomProperty(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2023, 6, 1, 8, 30, 0, 0, time.UTC)
+	for _, prop := range []struct {
+		name  string
+		value interface{}
+	}{
+		{"Text", "excelize"}, {"Int", 100}, {"Float", 1.5}, {"Bool", true}, {"Date", date},
+	} {
+		assert.NoError(t, f.SetCustomProperty(prop.name, prop.value))
+	}
+	assert.NoError(t, f.SetCustomProperty("int", 200))
+	assert.NoError(t, f.SetCustomProperty("Removed", nil))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCustomProperty.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestCustomProperty.xlsx"))
+	assert.NoError(t, err)
+	for name, expected := range map[string]interface{}{
+		"Text": "excelize", "Int": 200, "Float": 1.5, "Bool": true, "Date": date, "Removed": nil,
+	} {
+		value, err := f.GetCustomProperty(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, name)
+	}
+	props, err := f.customPropertiesReader()
+	assert.NoError(t, err)
+	assert.Len(t, props.Property, 5)
+	pids := map[int]bool{}
+	for _, prop := range props.Property {
+		assert.Equal(t, "{D5CDD505-2E9C-101B-9397-08002B2CF9AE}", prop.FmtID)
+		assert.GreaterOrEqual(t, prop.PID, 2)
+		pids[prop.PID] = true
+	}
+	assert.Len(t, pids, 5)
+	rels, err := f.relsReader("_rels/.rels")
+	assert.NoError(t, err)
+	var count int
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipCustomProperties {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+
+	// Test remove the custom property and add a new one
+	assert.NoError(t, f.SetCustomProperty("text", nil))
+	value, err := f.GetCustomProperty("Text")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	assert.NoError(t, f.SetCustomProperty("Size", int64(1<<40)))
+	value, err = f.GetCustomProperty("Size")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1<<40), value)
+	props, err = f.customPropertiesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 7, props.Property[len(props.Property)-1].PID)
+
+	// Test set custom property with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.SetCustomProperty("", 1))
+	assert.Equal(t, ErrCustomPropertyType, f.SetCustomProperty("Invalid", []int{}))
+	assert.NoError(t, f.Close())
+
+	// Test set and get custom property with unsupported charset
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathDocPropsCustom, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCustomProperty("Text", "excelize"), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetCustomProperty("Text")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -63,7 +63,7 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 		Lang:           &attrValString{Val: stringPtr("en-US")},
 		RoundedCorners: &attrValBool{Val: boolPtr(false)},
 		Chart: cChart{
-			Title: f.drawPlotAreaTitles(opts.Title, ""),
+			Title: f.drawChartTitle(opts),
 			View3D: &cView3D{
 				RotX:        &attrValInt{Val: intPtr(chartView3DRotX[opts.Type])},
 				RotY:        &attrValInt{Val: intPtr(chartView3DRotY[opts.Type])},
@@ -80,10 +80,7 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 				Thickness: &attrValInt{Val: intPtr(0)},
 			},
 			PlotArea: &cPlotArea{},
-			Legend: &cLegend{
-				LegendPos: &attrValString{Val: stringPtr(chartLegendPosition[opts.Legend.Position])},
-				Overlay:   &attrValBool{Val: boolPtr(false)},
-			},
+			Legend:   f.drawChartLegend(opts),
 
 			PlotVisOnly:      &attrValBool{Val: boolPtr(false)},
 			DispBlanksAs:     &attrValString{Val: stringPtr(opts.ShowBlanksAs)},
@@ -192,18 +189,79 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 			immutable.FieldByName(mutable.Type().Field(i).Name).Set(field)
 		}
 	}
-	addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[opts.Type](opts))
+	mainPlotArea := plotAreaFunc[opts.Type](opts)
+	addChart(xlsxChartSpace.Chart.PlotArea, mainPlotArea)
 	order := len(opts.Series)
 	for idx := range comboCharts {
 		comboCharts[idx].order = order
 		addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[comboCharts[idx].Type](comboCharts[idx]))
 		order += len(comboCharts[idx].Series)
 	}
+	if len(comboCharts) > 0 {
+		// The chart groups share the primary axes, keep the primary axes
+		// consistent with the main chart, and the secondary axes with the
+		// combo chart
+		primaryAxes := map[int]*cAxs{}
+		for _, ax := range append(append([]*cAxs{}, mainPlotArea.CatAx...), mainPlotArea.ValAx...) {
+			primaryAxes[*ax.AxID.Val] = ax
+		}
+		plotArea := xlsxChartSpace.Chart.PlotArea
+		for _, axs := range [][]*cAxs{plotArea.CatAx, plotArea.ValAx} {
+			for i, ax := range axs {
+				if primaryAx, ok := primaryAxes[*ax.AxID.Val]; ok {
+					axs[i] = primaryAx
+				}
+			}
+		}
+	}
 	chart, _ := xml.Marshal(xlsxChartSpace)
 	media := "xl/charts/chart" + strconv.Itoa(count+1) + ".xml"
 	f.saveFileList(media, chart)
 }
 
+// drawChartLegend provides a function to draw the c:legend element by given
+// format sets.
+func (f *File) drawChartLegend(opts *Chart) *cLegend {
+	legend := &cLegend{
+		LegendPos: &attrValString{Val: stringPtr(chartLegendPosition[opts.Legend.Position])},
+		Overlay:   &attrValBool{Val: boolPtr(opts.Legend.Overlay)},
+	}
+	if len(opts.Legend.Fill.Color) > 0 || opts.Legend.Border.Color != "" || opts.Legend.Border.Width != 0 {
+		legend.SpPr = &cSpPr{}
+		if len(opts.Legend.Fill.Color) > 0 {
+			legend.SpPr.SolidFill = &aSolidFill{
+				SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(opts.Legend.Fill.Color[0], "#"))},
+			}
+		}
+		if opts.Legend.Border.Color != "" || opts.Legend.Border.Width != 0 {
+			legend.SpPr.Ln = &aLn{W: f.ptToEMUs(opts.Legend.Border.Width), SolidFill: &aSolidFill{
+				SchemeClr: &aSchemeClr{Val: "tx1", LumMod: &attrValInt{Val: intPtr(15000)}, LumOff: &attrValInt{Val: intPtr(85000)}},
+			}}
+			if opts.Legend.Border.Width == 0 {
+				legend.SpPr.Ln.W = 9525
+			}
+			if opts.Legend.Border.Color != "" {
+				legend.SpPr.Ln.SolidFill = &aSolidFill{
+					SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(opts.Legend.Border.Color, "#"))},
+				}
+			}
+		}
+	}
+	if opts.Legend.Font != (Font{}) {
+		legend.TxPr = f.drawChartTxPr(&opts.Legend.Font)
+	}
+	return legend
+}
+
+// drawChartTxPr provides a function to draw the c:txPr element of the chart
+// elements with horizontal text by given font settings.
+func (f *File) drawChartTxPr(font *Font) *cTxPr {
+	txPr := f.drawPlotAreaTxPr(nil)
+	txPr.BodyPr = aBodyPr{Rot: 0, SpcFirstLastPara: true, VertOverflow: "ellipsis", Vert: "horz", Wrap: "square", Anchor: "ctr", AnchorCtr: true}
+	setChartFont(&txPr.P.PPr.DefRPr, font)
+	return txPr
+}
+
 // drawBaseChart provides a function to draw the c:plotArea element for bar,
 // and column series charts by given format sets.
 func (f *File) drawBaseChart(opts *Chart) *cPlotArea {
@@ -709,6 +767,16 @@ func (f *File) drawChartShape(opts *Chart) *attrValString {
 	return nil
 }
 
+// getSeriesOrder returns the plot order of the chart series by given series
+// index, the orders are assigned on checking the chart options, and the index
+// of the series in the chart will be returned if the orders are not assigned.
+func (opts *Chart) getSeriesOrder(k int) int {
+	if k < len(opts.seriesOrder) {
+		return opts.seriesOrder[k]
+	}
+	return k + opts.order
+}
+
 // drawChartSeries provides a function to draw the c:ser element by given
 // format sets.
 func (f *File) drawChartSeries(opts *Chart) *[]cSer {
@@ -716,7 +784,7 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 	for k := range opts.Series {
 		ser = append(ser, cSer{
 			IDx:   &attrValInt{Val: intPtr(k + opts.order)},
-			Order: &attrValInt{Val: intPtr(k + opts.order)},
+			Order: &attrValInt{Val: intPtr(opts.getSeriesOrder(k))},
 			Tx: &cTx{
 				StrRef: &cStrRef{
 					F: opts.Series[k].Name,
@@ -725,7 +793,7 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 			SpPr:             f.drawChartSeriesSpPr(k, opts),
 			Marker:           f.drawChartSeriesMarker(k, opts),
 			DPt:              f.drawChartSeriesDPt(k, opts),
-			DLbls:            f.drawChartSeriesDLbls(opts),
+			DLbls:            f.drawChartSeriesDLbls(k, opts),
 			InvertIfNegative: &attrValBool{Val: boolPtr(false)},
 			Cat:              f.drawChartSeriesCat(opts.Series[k], opts),
 			Smooth:           &attrValBool{Val: boolPtr(opts.Series[k].Line.Smooth)},
@@ -849,6 +917,11 @@ func (f *File) drawChartSeriesMarker(i int, opts *Chart) *cMarker {
 	if symbol := stringPtr(opts.Series[i].Marker.Symbol); *symbol != "" {
 		marker.Symbol = &attrValString{Val: symbol}
 	}
+	chartSeriesMarker := map[ChartType]*cMarker{Scatter: marker, Line: marker}
+	if opts.Series[i].Marker.Symbol == "none" {
+		marker.Size = nil
+		return chartSeriesMarker[opts.Type]
+	}
 	if size := intPtr(opts.Series[i].Marker.Size); *size != 0 {
 		marker.Size = &attrValInt{Val: size}
 	}
@@ -869,10 +942,37 @@ func (f *File) drawChartSeriesMarker(i int, opts *Chart) *cMarker {
 			},
 		}
 	}
-	chartSeriesMarker := map[ChartType]*cMarker{Scatter: marker, Line: marker}
+	f.drawChartSeriesMarkerSpPr(marker, &opts.Series[i].Marker)
 	return chartSeriesMarker[opts.Type]
 }
 
+// drawChartSeriesMarkerSpPr provides a function to set the fill and border
+// color and width of the c:marker element by given marker format sets.
+func (f *File) drawChartSeriesMarkerSpPr(marker *cMarker, opts *ChartMarker) {
+	if len(opts.Fill.Color) == 0 && opts.Border.Color == "" && opts.Border.Width == 0 {
+		return
+	}
+	if marker.SpPr == nil {
+		marker.SpPr = &cSpPr{}
+	}
+	if len(opts.Fill.Color) > 0 {
+		marker.SpPr.SolidFill = &aSolidFill{
+			SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(opts.Fill.Color[0], "#"))},
+		}
+	}
+	if marker.SpPr.Ln == nil {
+		marker.SpPr.Ln = &aLn{W: 9252}
+	}
+	if opts.Border.Color != "" {
+		marker.SpPr.Ln.SolidFill = &aSolidFill{
+			SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(opts.Border.Color, "#"))},
+		}
+	}
+	if opts.Border.Width != 0 {
+		marker.SpPr.Ln.W = f.ptToEMUs(opts.Border.Width)
+	}
+}
+
 // drawChartSeriesXVal provides a function to draw the c:xVal element by given
 // chart series and format sets.
 func (f *File) drawChartSeriesXVal(v ChartSeries, opts *Chart) *cCat {
@@ -935,7 +1035,7 @@ func (f *File) drawChartNumFmt(labels ChartNumFmt) *cNumFmt {
 // drawChartDLbls provides a function to draw the c:dLbls element by given
 // format sets.
 func (f *File) drawChartDLbls(opts *Chart) *cDLbls {
-	return &cDLbls{
+	dLbls := &cDLbls{
 		NumFmt:          f.drawChartNumFmt(opts.PlotArea.NumFmt),
 		ShowLegendKey:   &attrValBool{Val: boolPtr(opts.Legend.ShowLegendKey)},
 		ShowVal:         &attrValBool{Val: boolPtr(opts.PlotArea.ShowVal)},
@@ -945,18 +1045,52 @@ func (f *File) drawChartDLbls(opts *Chart) *cDLbls {
 		ShowPercent:     &attrValBool{Val: boolPtr(opts.PlotArea.ShowPercent)},
 		ShowLeaderLines: &attrValBool{Val: boolPtr(opts.PlotArea.ShowLeaderLines)},
 	}
+	if separator, ok := dataLabelSeparator[opts.PlotArea.Separator]; ok {
+		dLbls.Separator = stringPtr(separator)
+	}
+	if opts.PlotArea.ShowLeaderLines {
+		if pieChartTypes[opts.Type] {
+			dLbls.LeaderLines = &cChartLines{SpPr: f.drawPlotAreaSpPr()}
+		}
+		dLbls.ExtLst = &xlsxExtLst{Ext: `<ext uri="` + ExtURIChartDataLabels + `" xmlns:c15="` +
+			NameSpaceDrawingMLChart2012.Value + `"><c15:showLeaderLines val="1"/></ext>`}
+	}
+	return dLbls
 }
 
 // drawChartSeriesDLbls provides a function to draw the c:dLbls element by
-// given format sets.
-func (f *File) drawChartSeriesDLbls(opts *Chart) *cDLbls {
+// given data series index and format sets, the data labels settings of the
+// series overrides the settings of the chart.
+func (f *File) drawChartSeriesDLbls(i int, opts *Chart) *cDLbls {
 	dLbls := f.drawChartDLbls(opts)
-	chartSeriesDLbls := map[ChartType]*cDLbls{
-		Scatter: nil, Surface3D: nil, WireframeSurface3D: nil, Contour: nil, WireframeContour: nil, Bubble: nil, Bubble3D: nil,
+	chartSeriesDLbls := map[ChartType]bool{
+		Scatter: true, Surface3D: false, WireframeSurface3D: false, Contour: false, WireframeContour: false, Bubble: true, Bubble3D: true,
 	}
-	if _, ok := chartSeriesDLbls[opts.Type]; ok {
+	label := opts.Series[i].DataLabel
+	if supported, ok := chartSeriesDLbls[opts.Type]; ok && (!supported || label == (ChartDataLabel{})) {
 		return nil
 	}
+	if numFmt := f.drawChartNumFmt(label.NumFmt); numFmt != nil {
+		dLbls.NumFmt = numFmt
+	}
+	if label.Font != (Font{}) {
+		dLbls.TxPr = f.drawChartTxPr(&label.Font)
+	}
+	if label.Position != "" {
+		dLbls.DLblPos = &attrValString{Val: stringPtr(dataLabelPosition[label.Position])}
+	}
+	for _, show := range []struct {
+		val  *bool
+		attr **attrValBool
+	}{
+		{label.ShowBubbleSize, &dLbls.ShowBubbleSize}, {label.ShowCatName, &dLbls.ShowCatName},
+		{label.ShowPercent, &dLbls.ShowPercent}, {label.ShowSerName, &dLbls.ShowSerName},
+		{label.ShowVal, &dLbls.ShowVal},
+	} {
+		if show.val != nil {
+			*show.attr = &attrValBool{Val: boolPtr(*show.val)}
+		}
+	}
 	return dLbls
 }
 
@@ -1007,6 +1141,7 @@ func (f *File) drawPlotAreaCatAx(opts *Chart) []*cAxs {
 	if opts.XAxis.TickLabelSkip != 0 {
 		axs[0].TickLblSkip = &attrValInt{Val: intPtr(opts.XAxis.TickLabelSkip)}
 	}
+	setAxisCrossing(axs[0], &opts.XAxis, "autoZero")
 	if opts.order > 0 && opts.YAxis.Secondary {
 		axs = append(axs, &cAxs{
 			AxID: &attrValInt{Val: intPtr(opts.XAxis.axID)},
@@ -1083,6 +1218,7 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 	if pos, ok := valTickLblPos[opts.Type]; ok {
 		axs[0].TickLblPos.Val = stringPtr(pos)
 	}
+	setAxisCrossing(axs[0], &opts.YAxis, "autoZero")
 	if opts.YAxis.MajorUnit != 0 {
 		axs[0].MajorUnit = &attrValFloat{Val: float64Ptr(opts.YAxis.MajorUnit)}
 	}
@@ -1105,10 +1241,41 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 			Crosses:       &attrValString{Val: stringPtr("max")},
 			CrossBetween:  &attrValString{Val: stringPtr(chartValAxCrossBetween[opts.Type])},
 		})
+		axs[1].Scaling.LogBase = logBase
+		axs[1].NumFmt = &cNumFmt{FormatCode: chartValAxNumFmtFormatCode[opts.Type]}
+		if numFmt := f.drawChartNumFmt(opts.YAxis.NumFmt); numFmt != nil {
+			axs[1].NumFmt = numFmt
+		}
+		if opts.YAxis.MajorUnit != 0 {
+			axs[1].MajorUnit = &attrValFloat{Val: float64Ptr(opts.YAxis.MajorUnit)}
+		}
+		setAxisCrossing(axs[1], &opts.YAxis, "max")
 	}
 	return axs
 }
 
+// setAxisCrossing provides a function to set the position where the axis
+// crosses the perpendicular axis, the position of the tick labels and the
+// tick marks by given axis options and the default crossing position.
+func setAxisCrossing(ax *cAxs, opts *ChartAxis, crosses string) {
+	ax.Crosses, ax.CrossesAt = &attrValString{Val: stringPtr(crosses)}, nil
+	if opts.Crosses != "" {
+		ax.Crosses.Val = stringPtr(opts.Crosses)
+	}
+	if opts.CrossesAt != 0 {
+		ax.Crosses, ax.CrossesAt = nil, &attrValFloat{Val: float64Ptr(opts.CrossesAt)}
+	}
+	if opts.AxisPosition != "" {
+		ax.TickLblPos = &attrValString{Val: stringPtr(opts.AxisPosition)}
+	}
+	if opts.MajorTickMark != "" {
+		ax.MajorTickMark = &attrValString{Val: stringPtr(opts.MajorTickMark)}
+	}
+	if opts.MinorTickMark != "" {
+		ax.MinorTickMark = &attrValString{Val: stringPtr(opts.MinorTickMark)}
+	}
+}
+
 // drawPlotAreaSerAx provides a function to draw the c:serAx element.
 func (f *File) drawPlotAreaSerAx(opts *Chart) []*cAxs {
 	max := &attrValFloat{Val: opts.YAxis.Maximum}
@@ -1137,6 +1304,30 @@ func (f *File) drawPlotAreaSerAx(opts *Chart) []*cAxs {
 	}
 }
 
+// drawChartTitle provides a function to draw the c:title element of the chart
+// with the layout settings.
+func (f *File) drawChartTitle(opts *Chart) *cTitle {
+	title := f.drawPlotAreaTitles(opts.Title, "")
+	if title == nil {
+		return title
+	}
+	title.Overlay.Val = boolPtr(opts.TitleLayout.Overlay)
+	if opts.TitleLayout.X == nil && opts.TitleLayout.Y == nil {
+		return title
+	}
+	manualLayout := &cManualLayout{}
+	if opts.TitleLayout.X != nil {
+		manualLayout.XMode = &attrValString{Val: stringPtr("edge")}
+		manualLayout.X = &attrValFloat{Val: opts.TitleLayout.X}
+	}
+	if opts.TitleLayout.Y != nil {
+		manualLayout.YMode = &attrValString{Val: stringPtr("edge")}
+		manualLayout.Y = &attrValFloat{Val: opts.TitleLayout.Y}
+	}
+	title.Layout = &cLayout{ManualLayout: manualLayout}
+	return title
+}
+
 // drawPlotAreaTitles provides a function to draw the c:title element.
 func (f *File) drawPlotAreaTitles(runs []RichTextRun, vert string) *cTitle {
 	if len(runs) == 0 {
@@ -1223,19 +1414,33 @@ func (f *File) drawPlotAreaTxPr(opts *ChartAxis) *cTxPr {
 		},
 	}
 	if opts != nil {
-		cTxPr.P.PPr.DefRPr.B = opts.Font.Bold
-		cTxPr.P.PPr.DefRPr.I = opts.Font.Italic
-		if idx := inStrSlice(supportedDrawingUnderlineTypes, opts.Font.Underline, true); idx != -1 {
-			cTxPr.P.PPr.DefRPr.U = supportedDrawingUnderlineTypes[idx]
-		}
-		if opts.Font.Color != "" {
-			cTxPr.P.PPr.DefRPr.SolidFill.SchemeClr = nil
-			cTxPr.P.PPr.DefRPr.SolidFill.SrgbClr = &attrValString{Val: stringPtr(strings.ReplaceAll(strings.ToUpper(opts.Font.Color), "#", ""))}
-		}
+		setChartFont(&cTxPr.P.PPr.DefRPr, &opts.Font)
 	}
 	return cTxPr
 }
 
+// setChartFont provides a function to set the run properties of the chart
+// text by given font settings.
+func setChartFont(rPr *aRPr, font *Font) {
+	rPr.B = font.Bold
+	rPr.I = font.Italic
+	if idx := inStrSlice(supportedDrawingUnderlineTypes, font.Underline, true); idx != -1 {
+		rPr.U = supportedDrawingUnderlineTypes[idx]
+	}
+	if font.Color != "" {
+		rPr.SolidFill.SchemeClr = nil
+		rPr.SolidFill.SrgbClr = &attrValString{Val: stringPtr(strings.ReplaceAll(strings.ToUpper(font.Color), "#", ""))}
+	}
+	if font.Size > 0 {
+		rPr.Sz = font.Size * 100
+	}
+	if font.Family != "" {
+		rPr.Latin = &xlsxCTTextFont{Typeface: font.Family}
+		rPr.Ea = &aEa{Typeface: font.Family}
+		rPr.Cs = &aCs{Typeface: font.Family}
+	}
+}
+
 // drawingParser provides a function to parse drawingXML. In order to solve
 // the problem that the label structure is changed after serialization and
 // deserialization, two different structures: decodeWsDr and encodeWsDr are
@@ -1296,7 +1501,8 @@ func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rI
 	}
 	width = int(float64(width) * opts.ScaleX)
 	height = int(float64(height) * opts.ScaleY)
-	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, opts.OffsetX, opts.OffsetY, width, height)
+	offsetX, offsetY := opts.offsetEMUs()
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectEMUs(sheet, col, row, offsetX, offsetY, width*EMU, height*EMU)
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return err
@@ -1305,14 +1511,14 @@ func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rI
 	twoCellAnchor.EditAs = opts.Positioning
 	from := xlsxFrom{}
 	from.Col = colStart
-	from.ColOff = opts.OffsetX * EMU
+	from.ColOff = offsetX
 	from.Row = rowStart
-	from.RowOff = opts.OffsetY * EMU
+	from.RowOff = offsetY
 	to := xlsxTo{}
 	to.Col = colEnd
-	to.ColOff = x2 * EMU
+	to.ColOff = x2
 	to.Row = rowEnd
-	to.RowOff = y2 * EMU
+	to.RowOff = y2
 	twoCellAnchor.From = &from
 	twoCellAnchor.To = &to
 
@@ -1322,6 +1528,9 @@ func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rI
 				ID:   cNvPrID,
 				Name: "Chart " + strconv.Itoa(cNvPrID),
 			},
+			CNvGraphicFramePr: xlsxCNvGraphicFramePr{
+				GraphicFrameLocks: &xlsxGraphicFrameLocks{NoChangeAspect: opts.LockAspectRatio},
+			},
 		},
 		Graphic: &xlsxGraphic{
 			GraphicData: &xlsxGraphicData{
@@ -1365,6 +1574,9 @@ func (f *File) addSheetDrawingChart(drawingXML string, rID int, opts *GraphicOpt
 				ID:   cNvPrID,
 				Name: "Chart " + strconv.Itoa(cNvPrID),
 			},
+			CNvGraphicFramePr: xlsxCNvGraphicFramePr{
+				GraphicFrameLocks: &xlsxGraphicFrameLocks{NoChangeAspect: opts.LockAspectRatio},
+			},
 		},
 		Graphic: &xlsxGraphic{
 			GraphicData: &xlsxGraphicData{
@@ -14,6 +14,7 @@ package excelize
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // newInvalidColumnNameError defined the error message on receiving the
@@ -46,24 +47,90 @@ func newInvalidNameError(name string) error {
 	return fmt.Errorf("invalid name %q, the name should be starts with a letter or underscore, can not include a space or character, and can not conflict with an existing name in the workbook", name)
 }
 
+// newInvalidODSTimeError defined the error message on receiving the invalid
+// time value of the cell in the OpenDocument spreadsheet.
+func newInvalidODSTimeError(value string) error {
+	return fmt.Errorf("invalid OpenDocument time value %s", value)
+}
+
+// newInvalidThemeColorError defined the error message on receiving the
+// invalid hex RGB color value of the theme color.
+func newInvalidThemeColorError(color string) error {
+	return fmt.Errorf("invalid theme color %q, the color should be a hex RGB value", color)
+}
+
+// newInvalidR1C1RefError defined the error message on receiving the invalid
+// reference in the R1C1 reference style.
+func newInvalidR1C1RefError(ref string) error {
+	return fmt.Errorf("invalid R1C1 reference %s", ref)
+}
+
+// newInvalidCellErrorValueError defined the error message on receiving the
+// invalid error value of the cell.
+func newInvalidCellErrorValueError(value string) error {
+	return fmt.Errorf("invalid cell error value %q", value)
+}
+
+// newInvalidRangeRefError defined the error message on receiving the invalid
+// cell or range reference.
+func newInvalidRangeRefError(ref string) error {
+	return fmt.Errorf("invalid range reference %q", ref)
+}
+
+// newInvalidOptionalValue defined the error message on receiving the invalid
+// optional value.
+func newInvalidOptionalValue(name, value string, values []string) error {
+	return fmt.Errorf("invalid %s value %q, acceptable value should be one of %s", name, value, strings.Join(values, ", "))
+}
+
 // newUnsupportedChartType defined the error message on receiving the chart
 // type are unsupported.
 func newUnsupportedChartType(chartType ChartType) error {
 	return fmt.Errorf("unsupported chart type %d", chartType)
 }
 
+// newUnsupportedChartTypeName defined the error message on receiving the
+// chart type name which is unsupported.
+func newUnsupportedChartTypeName(name string) error {
+	return fmt.Errorf("unsupported chart type %s", name)
+}
+
+// newUnsupportedFieldTypeError defined the error message on receiving the
+// struct field with unsupported type.
+func newUnsupportedFieldTypeError(name, fieldType string) error {
+	return fmt.Errorf("unsupported type %s of the field %s", fieldType, name)
+}
+
 // newUnzipSizeLimitError defined the error message on unzip size exceeds the
 // limit.
 func newUnzipSizeLimitError(unzipSizeLimit int64) error {
 	return fmt.Errorf("unzip size exceeds the %d bytes limit", unzipSizeLimit)
 }
 
+// newUnzipPartSizeLimitError defined the error message on unzip size of the
+// part exceeds the limit.
+func newUnzipPartSizeLimitError(name string, unzipPartSizeLimit int64) error {
+	return fmt.Errorf("unzip size of %s exceeds the %d bytes limit", name, unzipPartSizeLimit)
+}
+
+// newInvalidPaperSizeError defined the error message on receiving the invalid
+// paper size of the page layout.
+func newInvalidPaperSizeError(size int) error {
+	return fmt.Errorf("invalid paper size %d", size)
+}
+
 // newInvalidStyleID defined the error message on receiving the invalid style
 // ID.
 func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d", styleID)
 }
 
+// newCellValueConvertError defined the error message on converting the cell
+// value into the type of the struct field failed.
+func newCellValueConvertError(cell, value, field string, err error) error {
+	return fmt.Errorf("cannot convert the value %q of the cell %s to the field %s: %v", value, cell, field, err)
+}
+
 // newFieldLengthError defined the error message on receiving the field length
 // overflow.
 func newFieldLengthError(name string) error {
@@ -85,7 +152,7 @@ func newNoExistSheetError(name string) error {
 // newNotWorksheetError defined the error message on receiving a sheet which
 // not a worksheet.
 func newNotWorksheetError(name string) error {
-	return fmt.Errorf("sheet %s is not a worksheet", name)
+	return ErrNotWorksheet{SheetName: name}
 }
 
 // newStreamSetRowError defined the error message on the stream writer
@@ -106,10 +173,55 @@ func newUnknownFilterTokenError(token string) error {
 	return fmt.Errorf("unknown operator: %s", token)
 }
 
+// ErrMergeCellOverlap defined the error message on the merged range overlaps
+// with an existing merged range in the worksheet.
+type ErrMergeCellOverlap struct {
+	Ref, OverlapRef string
+}
+
+// Error returns the error message of merged range overlapped.
+func (err ErrMergeCellOverlap) Error() string {
+	return fmt.Sprintf("the merged range %s overlaps with the existing merged range %s", err.Ref, err.OverlapRef)
+}
+
+// ErrWorkbookReadOnly defined the error message on modifying or saving the
+// read-only workbook, such as the workbook opened from the XLSB file. Use
+// errors.As to check the error returned by the functions.
+type ErrWorkbookReadOnly struct {
+	Func string
+}
+
+// Error returns the error message of the read-only workbook.
+func (err ErrWorkbookReadOnly) Error() string {
+	return fmt.Sprintf("unsupported %s on the read-only workbook", err.Func)
+}
+
+// ErrNotWorksheet defined the error message on receiving a sheet which is not
+// a worksheet, such as a chart sheet or a dialog sheet. Use errors.As to check
+// the error returned by the functions.
+type ErrNotWorksheet struct {
+	SheetName string
+}
+
+// Error returns the error message of the sheet is not a worksheet.
+func (err ErrNotWorksheet) Error() string {
+	return fmt.Sprintf("sheet %s is not a worksheet", err.SheetName)
+}
+
 var (
 	// ErrStreamSetColWidth defined the error message on set column width in
 	// stream writing mode.
 	ErrStreamSetColWidth = errors.New("must call the SetColWidth function before the SetRow function")
+	// ErrCSVMaxRows defined the error message on the number of rows of the
+	// importing CSV data exceeds the limit.
+	ErrCSVMaxRows = errors.New("the number of rows of the CSV data exceeds the limit")
+	// ErrStreamWriterToExists defined the error message on create more than
+	// one stream writer which writes to io.Writer for a workbook.
+	ErrStreamWriterToExists = errors.New("only one stream writer is allowed to write to io.Writer for a workbook")
+	// ErrStreamWriterToEncrypted defined the error message on create the
+	// stream writer which writes to io.Writer for the password protected
+	// workbook.
+	ErrStreamWriterToEncrypted = errors.New("the stream writer which writes to io.Writer is unsupported for the password protected workbook")
 	// ErrStreamSetPanes defined the error message on set panes in stream
 	// writing mode.
 	ErrStreamSetPanes = errors.New("must call the SetPanes function before the SetRow function")
@@ -184,6 +296,12 @@ var (
 	ErrFontLength = fmt.Errorf("the length of the font family name must be less than or equal to %d", MaxFontFamilyLength)
 	// ErrFontSize defined the error message on the size of the font is invalid.
 	ErrFontSize = fmt.Errorf("font size must be between %d and %d points", MinFontSize, MaxFontSize)
+	// ErrChartSeriesOrder defined the error message on receive the duplicate
+	// or negative order of the chart series.
+	ErrChartSeriesOrder = errors.New("the order of the chart series must be unique and non-negative")
+	// ErrFillPattern defined the error message on the pattern of the fill is
+	// invalid.
+	ErrFillPattern = errors.New("fill pattern must be between 0 and 18")
 	// ErrSheetIdx defined the error message on receive the invalid worksheet
 	// index.
 	ErrSheetIdx = errors.New("invalid worksheet index")
@@ -201,12 +319,27 @@ var (
 	// ErrDataValidationRange defined the error message on set decimal range
 	// exceeds limit.
 	ErrDataValidationRange = errors.New("data validation range exceeds limit")
+	// ErrDataValidationNotFound defined the error message on not found the
+	// data validation which covers the given range.
+	ErrDataValidationNotFound = errors.New("no data validation covers the range")
+	// ErrUnsupportedExternalRef defined the error message on the formula of the
+	// data validation references another workbook.
+	ErrUnsupportedExternalRef = errors.New("the data validation formula referencing another workbook is unsupported")
 	// ErrCellCharsLength defined the error message for receiving a cell
 	// characters length that exceeds the limit.
 	ErrCellCharsLength = fmt.Errorf("cell value must be 0-%d characters", TotalCellChars)
 	// ErrOptionsUnzipSizeLimit defined the error message for receiving
 	// invalid UnzipSizeLimit and UnzipXMLSizeLimit.
 	ErrOptionsUnzipSizeLimit = errors.New("the value of UnzipSizeLimit should be greater than or equal to UnzipXMLSizeLimit")
+	// ErrOptionsUnzipPartSizeLimit defined the error message for receiving
+	// invalid UnzipPartSizeLimit.
+	ErrOptionsUnzipPartSizeLimit = errors.New("the value of UnzipPartSizeLimit should be less than or equal to UnzipSizeLimit")
+	// ErrSharedStringCountLimit defined the error message on the number of
+	// shared string items exceeds the MaxSharedStringCount limit.
+	ErrSharedStringCountLimit = errors.New("the number of shared strings exceeds the limit")
+	// ErrCellCountLimit defined the error message on the number of cells in
+	// the worksheet exceeds the MaxCellCount limit.
+	ErrCellCountLimit = errors.New("the number of cells exceeds the limit")
 	// ErrSave defined the error message for saving file.
 	ErrSave = errors.New("no path defined for file, consider File.WriteTo or File.Write")
 	// ErrAttrValBool defined the error message on marshal and unmarshal
@@ -258,4 +391,32 @@ var (
 	// ErrorFormControlValue defined the error message for receiving a scroll
 	// value exceeds limit.
 	ErrorFormControlValue = fmt.Errorf("scroll value must be between 0 and %d", MaxFormControlValue)
+	// ErrCellPhonetic defined the error message on setting phonetic hints for
+	// the cell without string value.
+	ErrCellPhonetic = errors.New("phonetic hints only can be set for the cell with string value")
+	// ErrIndexedColorPalette defined the error message on there is no unused
+	// entry in the indexed color palette.
+	ErrIndexedColorPalette = errors.New("there is no unused entry in the indexed color palette")
+	// ErrTabRatio defined the error message on receiving the tab ratio of the
+	// workbook view exceeds limit.
+	ErrTabRatio = errors.New("tab ratio must be between 0 and 1000")
+	// ErrPageSetupAdjustTo defined the error message for receiving a page
+	// setup scaling percentage exceeds limit.
+	ErrPageSetupAdjustTo = errors.New("adjust to value must be between 10 and 400")
+	// ErrCircularReference defined the error message on the formula refers to
+	// its own cell directly or indirectly when the iterative calculation is
+	// disabled.
+	ErrCircularReference = errors.New("circular reference")
+	// ErrRefMode defined the error message on receiving the invalid reference
+	// mode of the workbook.
+	ErrRefMode = errors.New("the reference mode must be either A1 or R1C1")
+	// ErrCustomPropertyType defined the error message on receiving the
+	// unsupported type of the custom property value.
+	ErrCustomPropertyType = errors.New("unsupported custom property value type")
+	// ErrClearMergedCells defined the error message on clearing the range
+	// which overlaps part of the merged cells.
+	ErrClearMergedCells = errors.New("cannot clear part of the merged cells")
+	// ErrThemeColorIndex defined the error message on receiving the theme
+	// color index exceeds limit.
+	ErrThemeColorIndex = errors.New("theme color index must be between 0 and 11")
 )
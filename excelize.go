@@ -15,13 +15,17 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"context"
+	"errors"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"io"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/net/html/charset"
 )
@@ -30,18 +34,24 @@ import (
 type File struct {
 	mu               sync.Mutex
 	options          *Options
+	readOnly         bool
 	xmlAttr          map[string][]xml.Attr
 	checked          map[string]bool
 	sheetMap         map[string]string
 	streams          map[string]*StreamWriter
 	tempFiles        sync.Map
-	sharedStringsMap map[string]int
+	sharedStringsMap *sharedStringsIndex
 	sharedStringItem [][]uint
 	sharedStringTemp *os.File
+	stringMode       StringMode
+	calcMu           sync.Mutex
+	calcNow          *time.Time
+	calcRand         *rand.Rand
 	CalcChain        *xlsxCalcChain
 	Comments         map[string]*xlsxComments
 	ContentTypes     *xlsxTypes
 	Drawings         sync.Map
+	Metadata         *xlsxMetadata
 	Path             string
 	SharedStrings    *xlsxSST
 	Sheet            sync.Map
@@ -70,6 +80,24 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // RawCellValue specifies if apply the number format for the cell value or get
 // the raw value.
 //
+// FillMergedCells specifies if fill each cell of the merged range with the
+// value of the top-left cell of the range on getting rows by GetRows, the
+// default value is false.
+//
+// Deterministic specifies if write the parts of the spreadsheet in a stable
+// order on saving, so that saving the same workbook twice produces the same
+// bytes. The zip entries are always written without modification time, and
+// the relationship IDs and XML attributes are generated in a stable order.
+// The random salt of the encrypted spreadsheet still introduces
+// nondeterminism. The calculated values of the formulas which depend on the
+// current time or random numbers such as NOW and RAND are also
+// nondeterministic, use SetCalcContext to specify the Now and RandSeed for
+// them. The default value is false.
+//
+// UpdateSheetDimensions specifies if recalculate the used range of the
+// worksheets which were read or modified and update the dimension of them on
+// saving the spreadsheet, the default value is false.
+//
 // UnzipSizeLimit specifies to unzip size limit in bytes on open the
 // spreadsheet, this value should be greater than or equal to
 // UnzipXMLSizeLimit, the default size limit is 16GB.
@@ -80,6 +108,21 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // should be less than or equal to UnzipSizeLimit, the default value is
 // 16MB.
 //
+// UnzipPartSizeLimit specifies the unzip size limit in bytes of each part in
+// the spreadsheet package, this value should be less than or equal to
+// UnzipSizeLimit, the default value is 0 which means only the UnzipSizeLimit
+// will be applied.
+//
+// MaxSharedStringCount specifies the maximum number of shared string items
+// allowed in the shared string table, the ErrSharedStringCountLimit error
+// will be returned on reading the shared string table which exceeds this
+// limit. The default value is 0 which means no limit.
+//
+// MaxCellCount specifies the maximum number of cells allowed in each
+// worksheet, the ErrCellCountLimit error will be returned on reading the
+// worksheet which exceeds this limit. The default value is 0 which means no
+// limit.
+//
 // ShortDatePattern specifies the short date number format code. In the
 // spreadsheet applications, date formats display date and time serial numbers
 // as date values. Date formats that begin with an asterisk (*) respond to
@@ -95,15 +138,21 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // CultureInfo specifies the country code for applying built-in language number
 // format code these effect by the system's local language settings.
 type Options struct {
-	MaxCalcIterations uint
-	Password          string
-	RawCellValue      bool
-	UnzipSizeLimit    int64
-	UnzipXMLSizeLimit int64
-	ShortDatePattern  string
-	LongDatePattern   string
-	LongTimePattern   string
-	CultureInfo       CultureName
+	MaxCalcIterations     uint
+	Password              string
+	RawCellValue          bool
+	FillMergedCells       bool
+	Deterministic         bool
+	UpdateSheetDimensions bool
+	UnzipSizeLimit        int64
+	UnzipXMLSizeLimit     int64
+	UnzipPartSizeLimit    int64
+	MaxSharedStringCount  int
+	MaxCellCount          int
+	ShortDatePattern      string
+	LongDatePattern       string
+	LongTimePattern       string
+	CultureInfo           CultureName
 }
 
 // OpenFile take the name of a spreadsheet file and returns a populated
@@ -139,7 +188,7 @@ func newFile() *File {
 		tempFiles:        sync.Map{},
 		Comments:         make(map[string]*xlsxComments),
 		Drawings:         sync.Map{},
-		sharedStringsMap: make(map[string]int),
+		sharedStringsMap: newSharedStringsIndex(),
 		Sheet:            sync.Map{},
 		DecodeVMLDrawing: make(map[string]*decodeVmlDrawing),
 		VMLDrawing:       make(map[string]*vmlDrawing),
@@ -166,13 +215,36 @@ func (f *File) checkOpenReaderOptions() error {
 	if f.options.UnzipXMLSizeLimit > f.options.UnzipSizeLimit {
 		return ErrOptionsUnzipSizeLimit
 	}
+	if f.options.UnzipPartSizeLimit < 0 || f.options.UnzipPartSizeLimit > f.options.UnzipSizeLimit {
+		return ErrOptionsUnzipPartSizeLimit
+	}
 	return f.checkDateTimePattern()
 }
 
 // OpenReader read data stream from io.Reader and return a populated
-// spreadsheet file.
+// spreadsheet file. The OpenDocument spreadsheet (ODS) will be detected by the
+// mimetype part and converted into the workbook with the cell values, value
+// types, number formats and merged cells, which can be read by the functions
+// such as GetRows and GetCellValue, and saved as the XLSX file.
+// The XLSB workbook will be converted into a read-only workbook with the
+// cell values, formulas, number formats and merged cells of the worksheets,
+// and the ErrWorkbookReadOnly error will be returned on modifying or saving
+// it.
 func OpenReader(r io.Reader, opts ...Options) (*File, error) {
-	b, err := io.ReadAll(r)
+	return OpenReaderWithContext(context.Background(), r, opts...)
+}
+
+// OpenReaderWithContext read data stream from io.Reader and return a
+// populated spreadsheet file, the reading and unzipping will be aborted and
+// the context error will be returned when the context is canceled or its
+// deadline exceeded. For example, open the spreadsheet with 10 seconds
+// timeout:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	f, err := excelize.OpenReaderWithContext(ctx, r)
+func OpenReaderWithContext(ctx context.Context, r io.Reader, opts ...Options) (*File, error) {
+	b, err := io.ReadAll(&contextReader{ctx: ctx, r: r})
 	if err != nil {
 		return nil, err
 	}
@@ -193,14 +265,23 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 		}
 		return nil, err
 	}
-	file, sheetCount, err := f.ReadZipReader(zr)
+	file, sheetCount, err := f.readZipReader(ctx, zr)
 	if err != nil {
 		return nil, err
 	}
+	if isOpenDocument(file) {
+		return openODS(file, f.options)
+	}
 	f.SheetCount = sheetCount
 	for k, v := range file {
 		f.Pkg.Store(k, v)
 	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	if wbPath := f.getWorkbookPath(); isXLSB(wbPath) {
+		return f.openXLSB(wbPath)
+	}
 	if f.CalcChain, err = f.calcChainReader(); err != nil {
 		return f, err
 	}
@@ -235,19 +316,14 @@ func (f *File) xmlNewDecoder(rdr io.Reader) (ret *xml.Decoder) {
 	return
 }
 
-// setDefaultTimeStyle provides a function to set default numbers format for
-// time.Time type cell value by given worksheet name, cell reference and
-// number format code.
-func (f *File) setDefaultTimeStyle(sheet, cell string, format int) error {
-	s, err := f.GetCellStyle(sheet, cell)
-	if err != nil {
-		return err
+// checkReadOnly provides a function to check if the workbook is read-only, the
+// ErrWorkbookReadOnly error with the given function name will be returned for
+// the read-only workbook.
+func (f *File) checkReadOnly(fn string) error {
+	if f.readOnly {
+		return ErrWorkbookReadOnly{Func: fn}
 	}
-	if s == 0 {
-		style, _ := f.NewStyle(&Style{NumFmt: format})
-		err = f.SetCellStyle(sheet, cell, cell, style)
-	}
-	return err
+	return nil
 }
 
 // workSheetReader provides a function to get the pointer to the structure
@@ -275,11 +351,15 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 		}
 	}
 	ws = new(xlsxWorksheet)
+	content := namespaceStrictToTransitional(f.readBytes(name))
 	if _, ok := f.xmlAttr[name]; !ok {
-		d := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readBytes(name))))
+		d := f.xmlNewDecoder(bytes.NewReader(content))
 		f.xmlAttr[name] = append(f.xmlAttr[name], getRootElement(d)...)
 	}
-	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readBytes(name)))).
+	if err = f.checkCellCount(content); err != nil {
+		return
+	}
+	if err = f.xmlNewDecoder(bytes.NewReader(content)).
 		Decode(ws); err != nil && err != io.EOF {
 		return
 	}
@@ -298,6 +378,78 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 	return
 }
 
+// chartSheetReader provides a function to get the pointer to the chartsheet
+// structure after deserialization by given sheet name, the path of the
+// chartsheet part will be returned too. The nil pointer will be returned if the
+// given sheet is not a chartsheet.
+func (f *File) chartSheetReader(sheet string) (cs *xlsxChartsheet, name string, err error) {
+	var ok bool
+	if err = checkSheetName(sheet); err != nil {
+		return
+	}
+	if name, ok = f.getSheetXMLPath(sheet); !ok {
+		err = newNoExistSheetError(sheet)
+		return
+	}
+	if !strings.HasPrefix(name, "xl/chartsheets") {
+		return
+	}
+	cs = new(xlsxChartsheet)
+	if _, ok := f.xmlAttr[name]; !ok {
+		d := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readBytes(name))))
+		f.xmlAttr[name] = append(f.xmlAttr[name], getRootElement(d)...)
+	}
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readBytes(name)))).
+		Decode(cs); err != nil && err != io.EOF {
+		return
+	}
+	err = nil
+	return
+}
+
+// chartSheetWriter provides a function to save the chartsheet structure after
+// serialization by given path of the chartsheet part.
+func (f *File) chartSheetWriter(name string, cs *xlsxChartsheet) {
+	output, _ := xml.Marshal(cs)
+	f.saveFileList(name, replaceRelationshipsBytes(f.replaceNameSpaceBytes(name, output)))
+}
+
+// checkCellCount provides a function to check if the number of cells in the
+// worksheet XML content exceeds the MaxCellCount limit. The cell elements are
+// counted on the token stream, so the reading will be aborted as soon as the
+// limit is crossed without building the worksheet structure.
+func (f *File) checkCellCount(content []byte) error {
+	if f.options == nil || f.options.MaxCellCount <= 0 {
+		return nil
+	}
+	var (
+		cells int
+		inRow bool
+		d     = f.xmlNewDecoder(bytes.NewReader(content))
+	)
+	for {
+		token, err := d.RawToken()
+		if err != nil {
+			return nil
+		}
+		switch el := token.(type) {
+		case xml.StartElement:
+			if el.Name.Local == "row" {
+				inRow = true
+			}
+			if inRow && el.Name.Local == "c" {
+				if cells++; cells > f.options.MaxCellCount {
+					return ErrCellCountLimit
+				}
+			}
+		case xml.EndElement:
+			if el.Name.Local == "row" {
+				inRow = false
+			}
+		}
+	}
+}
+
 // checkSheet provides a function to fill each row element and make that is
 // continuous in a worksheet of XML.
 func (ws *xlsxWorksheet) checkSheet() {
@@ -447,16 +599,23 @@ func (f *File) addRels(relPath, relType, target, targetMode string) int {
 //	    </c>
 //	</row>
 func (f *File) UpdateLinkedValue() error {
+	if err := f.checkReadOnly("UpdateLinkedValue"); err != nil {
+		return err
+	}
 	wb, err := f.workbookReader()
 	if err != nil {
 		return err
 	}
-	// recalculate formulas
-	wb.CalcPr = nil
+	// recalculate formulas, and keep the reference mode of the workbook
+	if wb.CalcPr != nil && wb.CalcPr.RefMode != "" {
+		wb.CalcPr = &xlsxCalcPr{RefMode: wb.CalcPr.RefMode}
+	} else {
+		wb.CalcPr = nil
+	}
 	for _, name := range f.GetSheetList() {
 		ws, err := f.workSheetReader(name)
 		if err != nil {
-			if err.Error() == newNotWorksheetError(name).Error() {
+			if errors.As(err, &ErrNotWorksheet{}) {
 				continue
 			}
 			return err
@@ -498,6 +657,9 @@ func (f *File) UpdateLinkedValue() error {
 //	    return
 //	}
 func (f *File) AddVBAProject(file []byte) error {
+	if err := f.checkReadOnly("AddVBAProject"); err != nil {
+		return err
+	}
 	var err error
 	// Check vbaProject.bin exists first.
 	if !bytes.Contains(file, oleIdentifier) {
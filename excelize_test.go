@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"image/color"
@@ -14,6 +15,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -328,6 +330,89 @@ func TestOpenReader(t *testing.T) {
 	assert.EqualError(t, err, zip.ErrAlgorithm.Error())
 }
 
+func TestOpenReaderWithContext(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	f, err := OpenReaderWithContext(context.Background(), bytes.NewReader(b))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	// Test open spreadsheet with canceled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = OpenReaderWithContext(ctx, bytes.NewReader(b))
+	assert.ErrorIs(t, err, context.Canceled)
+	// Test unzip spreadsheet with canceled context
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	assert.NoError(t, err)
+	_, _, err = NewFile().readZipReader(ctx, zr)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = readFile(ctx, zr.File[0])
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestOpenReaderLimits(t *testing.T) {
+	// Test open spreadsheet with unzip part size limit
+	_, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipPartSizeLimit: 100})
+	assert.Contains(t, err.Error(), "exceeds the 100 bytes limit")
+	_, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipSizeLimit: 100, UnzipXMLSizeLimit: 100, UnzipPartSizeLimit: 101})
+	assert.Equal(t, ErrOptionsUnzipPartSizeLimit, err)
+	_, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipPartSizeLimit: -1})
+	assert.Equal(t, ErrOptionsUnzipPartSizeLimit, err)
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipPartSizeLimit: UnzipSizeLimit})
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	// Test read the shared string table which exceeds the limit
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{MaxSharedStringCount: 1})
+	assert.NoError(t, err)
+	_, err = f.GetRows("Sheet1")
+	assert.Equal(t, ErrSharedStringCountLimit, err)
+	assert.NoError(t, f.Close())
+
+	// Test read the worksheet which exceeds the cell count limit
+	buf := new(bytes.Buffer)
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{4, 5, 6}))
+	assert.NoError(t, f.Write(buf))
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()), Options{MaxCellCount: 5})
+	assert.NoError(t, err)
+	_, err = f.GetRows("Sheet1")
+	assert.Equal(t, ErrCellCountLimit, err)
+	_, err = f.GetCellValue("Sheet1", "A1")
+	assert.Equal(t, ErrCellCountLimit, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()), Options{MaxCellCount: 6})
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "2", "3"}, {"4", "5", "6"}}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test the reading is aborted on the broken XML content after the limit
+	// crossed, and the broken content within the limit returns a syntax error
+	f = NewFile(Options{MaxCellCount: 1, MaxSharedStringCount: 1})
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1"><c r="A1"/><c r="B1"/><c`))
+	_, err = f.GetCellValue("Sheet1", "A1")
+	assert.Equal(t, ErrCellCountLimit, err)
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, []byte(`<sst count="2" uniqueCount="2"><si><t>a</t></si><si><t>b</t></si><si`))
+	_, err = f.sharedStringsReader()
+	assert.Equal(t, ErrSharedStringCountLimit, err)
+	f.options.MaxSharedStringCount = 3
+	_, err = f.sharedStringsReader()
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	f.Pkg.Store(defaultXMLPathSharedStrings, []byte(`<sst count="3" uniqueCount="2"><si><t>a</t></si><si><t>b</t></si></sst>`))
+	sst, err := f.sharedStringsReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, sst.Count)
+	assert.Equal(t, 2, sst.UniqueCount)
+	assert.Len(t, sst.SI, 2)
+	assert.NoError(t, f.Close())
+}
+
 func TestBrokenFile(t *testing.T) {
 	// Test write file with broken file struct
 	f := File{}
@@ -482,6 +567,138 @@ func TestGetCellHyperLink(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetCellHyperLinkOpts(t *testing.T) {
+	f := NewFile()
+	display, tooltip := "Display value", "Hover text"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External", HyperlinkOpts{
+		Display: &display,
+		Tooltip: &tooltip,
+	}))
+	opts, err := f.GetCellHyperLinkOpts("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, HyperlinkOpts{Display: &display, Tooltip: &tooltip}, opts)
+	// Test get hyperlink options on the cell without hyperlink
+	opts, err = f.GetCellHyperLinkOpts("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, HyperlinkOpts{}, opts)
+	// Test get hyperlink options with invalid cell reference
+	_, err = f.GetCellHyperLinkOpts("Sheet1", "A")
+	assert.EqualError(t, err, newInvalidCellNameError("A").Error())
+	// Test get hyperlink options with not exist worksheet
+	_, err = f.GetCellHyperLinkOpts("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestGetCellHyperLinkType(t *testing.T) {
+	f := NewFile()
+	tooltip := "Go to totals"
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Totals", RefersTo: "Sheet1!$A$10"}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "#Sheet1!A10", "Location", HyperlinkOpts{Tooltip: &tooltip}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A3", "Totals", "Location", HyperlinkOpts{Tooltip: &tooltip}))
+	for cell, expected := range map[string][]string{
+		"A1": {"External", "https://github.com/xuri/excelize"},
+		"A2": {"Location", "Sheet1!A10"},
+		"A3": {"Location", "Totals"},
+		"A4": {"", ""},
+	} {
+		linkType, err := f.GetCellHyperLinkType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], linkType, cell)
+		_, target, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], target, cell)
+	}
+	opts, err := f.GetCellHyperLinkOpts("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, HyperlinkOpts{Tooltip: &tooltip}, opts)
+	// Test the internal hyperlinks were saved without the relationships
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxHyperlink{
+		{Ref: "A1", RID: "rId1"},
+		{Ref: "A2", Location: "Sheet1!A10", Tooltip: tooltip},
+		{Ref: "A3", Location: "Totals", Tooltip: tooltip},
+	}, ws.Hyperlinks.Hyperlink)
+	// Test get hyperlink type with invalid cell reference
+	_, err = f.GetCellHyperLinkType("Sheet1", "A")
+	assert.EqualError(t, err, newInvalidCellNameError("A").Error())
+	// Test get hyperlink type with not exist worksheet
+	_, err = f.GetCellHyperLinkType("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestDeleteCellHyperLink(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B2", "https://github.com", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C3", "Sheet1!A10", "Location"))
+	assert.NoError(t, f.DeleteCellHyperLink("Sheet1", "A1"))
+	link, _, err := f.GetCellHyperLink("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.False(t, link)
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 1)
+	assert.Equal(t, "https://github.com", rels.Relationships[0].Target)
+	// Test delete hyperlinks in the range
+	assert.NoError(t, f.DeleteCellHyperLinks("Sheet1", "C4:B1"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).Hyperlinks)
+	assert.Empty(t, rels.Relationships)
+	// Test delete hyperlink on the cell without hyperlink
+	assert.NoError(t, f.DeleteCellHyperLink("Sheet1", "A1"))
+	// Test keep the relationship which is referenced by other hyperlinks
+	ws.(*xlsxWorksheet).Hyperlinks = &xlsxHyperlinks{Hyperlink: []xlsxHyperlink{
+		{Ref: "A1", RID: "rId1"}, {Ref: "B1:C2", RID: "rId1"},
+	}}
+	rels.Relationships = []xlsxRelationship{{ID: "rId1", Type: SourceRelationshipHyperLink, Target: "https://github.com", TargetMode: "External"}}
+	assert.NoError(t, f.DeleteCellHyperLink("Sheet1", "A1"))
+	assert.Len(t, rels.Relationships, 1)
+	assert.NoError(t, f.DeleteCellHyperLink("Sheet1", "C2"))
+	assert.Empty(t, rels.Relationships)
+	// Test delete hyperlinks with invalid range reference
+	assert.EqualError(t, f.DeleteCellHyperLinks("Sheet1", "A"), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.DeleteCellHyperLink("Sheet1", "A"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test delete hyperlinks with invalid hyperlink reference
+	ws.(*xlsxWorksheet).Hyperlinks = &xlsxHyperlinks{Hyperlink: []xlsxHyperlink{{Ref: "A:A"}}}
+	assert.EqualError(t, f.DeleteCellHyperLink("Sheet1", "A1"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test delete hyperlinks with not exist worksheet
+	assert.EqualError(t, f.DeleteCellHyperLink("SheetN", "A1"), "sheet SheetN does not exist")
+	// Test replace the external hyperlink with the location hyperlink
+	f = NewFile()
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "Sheet1!A10", "Location"))
+	rels, err = f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	assert.Empty(t, rels.Relationships)
+}
+
+func TestSetCellHyperLinkTarget(t *testing.T) {
+	f := NewFile()
+	for cell, link := range map[string][]string{
+		"A1": {`\\server\share\file.xlsx`, `file:///\\server\share\file.xlsx`},
+		"A2": {"mailto:user@example.com?subject=Hello World", "mailto:user@example.com?subject=Hello%20World"},
+		"A3": {"https://github.com/xuri/excelize", "https://github.com/xuri/excelize"},
+		"A4": {"mailto:user@example.com", "mailto:user@example.com"},
+		"A5": {"MAILTO:a b@example.com,c@example.com?subject=A&B 100%&body=1+1=2 #3&cc", "MAILTO:a%20b@example.com,c@example.com?subject=A&B%20100%25&body=1%2B1%3D2%20%233&cc"},
+		"A6": {"mailto:user@example.com?subject=Hello%20World&body=%E4%BD%A0%E5%A5%BD", "mailto:user@example.com?subject=Hello%20World&body=%E4%BD%A0%E5%A5%BD"},
+		"A7": {"mailto:user@example.com?body=你好", "mailto:user@example.com?body=%E4%BD%A0%E5%A5%BD"},
+	} {
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", cell, link[0], "External"))
+		_, target, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, link[1], target)
+	}
+}
+
 func TestSetSheetBackground(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -491,6 +708,32 @@ func TestSetSheetBackground(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestDeleteSheetBackground(t *testing.T) {
+	f := NewFile()
+	img, err := os.ReadFile(filepath.Join("test", "images", "background.jpg"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetBackgroundFromBytes("Sheet1", ".jpg", img))
+	assert.NoError(t, f.SetSheetBackgroundFromBytes("Sheet1", ".jpg", img))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	rID := ws.(*xlsxWorksheet).Picture.RID
+	assert.Equal(t, "../media/image1.jpeg", f.getSheetRelationshipsTargetByID("Sheet1", rID))
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 1)
+
+	assert.NoError(t, f.DeleteSheetBackground("Sheet1"))
+	assert.Nil(t, ws.(*xlsxWorksheet).Picture)
+	assert.Empty(t, f.getSheetRelationshipsTargetByID("Sheet1", rID))
+	// Test delete sheet background on the worksheet without background
+	assert.NoError(t, f.DeleteSheetBackground("Sheet1"))
+	// Test delete sheet background on not exist worksheet
+	assert.EqualError(t, f.DeleteSheetBackground("SheetN"), "sheet SheetN does not exist")
+	// Test delete sheet background with invalid sheet name
+	assert.EqualError(t, f.DeleteSheetBackground("Sheet:1"), ErrSheetNameInvalid.Error())
+	assert.NoError(t, f.Close())
+}
+
 func TestSetSheetBackgroundErrors(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -903,9 +1146,12 @@ func TestSetCellStyleFill(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, f.SetCellStyle("Sheet1", "O23", "O23", style))
 
-	style, err = f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 19}})
+	style, err = f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1}})
 	assert.NoError(t, err)
 	assert.NoError(t, f.SetCellStyle("Sheet1", "O23", "O23", style))
+	// Test set pattern fill with invalid pattern
+	_, err = f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 19}})
+	assert.Equal(t, ErrFillPattern, err)
 
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellStyleFill.xlsx")))
 }
@@ -1012,6 +1258,224 @@ func TestCopySheetError(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheetError.xlsx")))
 }
 
+func TestCopySheetFrom(t *testing.T) {
+	src := NewFile()
+	_, err := src.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetCellValue("Sheet 2", "A1", 10))
+	// Prepare cell values, styles, merged cells and dimensions
+	assert.NoError(t, src.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", 1.5, true}))
+	assert.NoError(t, src.SetCellRichText("Sheet1", "A2", []RichTextRun{{Text: "bold", Font: &Font{Bold: true}}, {Text: " text"}}))
+	numFmt := "0.000"
+	style, err := src.NewStyle(&Style{Font: &Font{Bold: true, Color: "FF0000"}, CustomNumFmt: &numFmt})
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetCellStyle("Sheet1", "B1", "B1", style))
+	assert.NoError(t, src.SetColStyle("Sheet1", "D", style))
+	assert.NoError(t, src.MergeCell("Sheet1", "A5", "B6"))
+	assert.NoError(t, src.SetColWidth("Sheet1", "A", "A", 25))
+	assert.NoError(t, src.SetRowHeight("Sheet1", 2, 30))
+	// Prepare formulas reference the current and other worksheets
+	assert.NoError(t, src.SetCellFormula("Sheet1", "C2", "Sheet1!B1*2"))
+	assert.NoError(t, src.SetCellFormula("Sheet1", "C3", "'Sheet 2'!A1+B1"))
+	// Prepare data validation, conditional format and hyperlinks
+	dv := NewDataValidation(true)
+	dv.Sqref = "E1:E5"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2"}))
+	assert.NoError(t, src.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(true)
+	dv.Sqref = "E6:E10"
+	dv.SetSqrefDropList("Sheet1!$A$1:$A$3")
+	assert.NoError(t, src.AddDataValidation("Sheet1", dv))
+	format, err := src.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetConditionalFormat("Sheet1", "B1:B5", []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Format: format, Value: "1"}}))
+	assert.NoError(t, src.SetConditionalFormat("Sheet1", "C1:C5", []ConditionalFormatOptions{{Type: "formula", Criteria: "Sheet1!$B$1>0", Format: format}}))
+	assert.NoError(t, src.SetCellHyperLink("Sheet1", "F1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, src.SetCellHyperLink("Sheet1", "F2", "Sheet1!A1", "Location"))
+	// Prepare images, charts and defined names
+	assert.NoError(t, src.AddPicture("Sheet1", "H1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, src.AddChart("Sheet1", "H10", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$1:$A$3", Values: "Sheet1!$B$1:$B$3"}},
+	}))
+	assert.NoError(t, src.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$B$1:$B$2", Scope: "Sheet1"}))
+	assert.NoError(t, src.SetDefinedName(&DefinedName{Name: "Total", RefersTo: "Sheet1!$B$1"}))
+	assert.NoError(t, src.SaveAs(filepath.Join("test", "TestCopySheetFromSource.xlsx")))
+	assert.NoError(t, src.Close())
+
+	src, err = OpenFile(filepath.Join("test", "TestCopySheetFromSource.xlsx"))
+	assert.NoError(t, err)
+	f := NewFile()
+	_, err = f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.jpg"), nil))
+	assert.NoError(t, f.CopySheetFrom(src, "Sheet1", "Copy"))
+	// Test copy worksheet into the workbook which already has the same formats
+	assert.NoError(t, f.CopySheetFrom(src, "Sheet1", "Sheet1"))
+
+	for _, sheet := range []string{"Copy", "Sheet1"} {
+		rows, err := f.GetRows(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Name", "1.500", "TRUE"}, rows[0])
+		assert.Equal(t, "bold text", rows[1][0])
+		runs, err := f.GetCellRichText(sheet, "A2")
+		assert.NoError(t, err)
+		assert.True(t, runs[0].Font.Bold)
+		formula, err := f.GetCellFormula(sheet, "C2")
+		assert.NoError(t, err)
+		assert.Equal(t, sheet+"!B1*2", formula)
+		formula, err = f.GetCellFormula(sheet, "C3")
+		assert.NoError(t, err)
+		assert.Equal(t, "'[1]Sheet 2'!A1+B1", formula)
+		mergeCells, err := f.GetMergeCells(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, mergeCells, 1)
+		assert.Equal(t, "A5", mergeCells[0].GetStartAxis())
+		width, err := f.GetColWidth(sheet, "A")
+		assert.NoError(t, err)
+		assert.Equal(t, 25.0, width)
+		height, err := f.GetRowHeight(sheet, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 30.0, height)
+		dvs, err := f.GetDataValidations(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, dvs, 2)
+		assert.Equal(t, "<formula1>"+sheet+"!$A$1:$A$3</formula1>", dvs[1].Formula1)
+		cfs, err := f.GetConditionalFormats(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, "FF9A0511", f.Styles.Dxfs.Dxfs[cfs["B1:B5"][0].Format].Font.Color.RGB)
+		assert.Equal(t, sheet+"!$B$1>0", cfs["C1:C5"][0].Criteria)
+		link, target, err := f.GetCellHyperLink(sheet, "F1")
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, "https://github.com/xuri/excelize", target)
+		_, target, err = f.GetCellHyperLink(sheet, "F2")
+		assert.NoError(t, err)
+		assert.Equal(t, sheet+"!A1", target)
+		pics, err := f.GetPictures(sheet, "H1")
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		styleID, err := f.GetCellStyle(sheet, "B1")
+		assert.NoError(t, err)
+		xf := f.Styles.CellXfs.Xf[styleID]
+		assert.NotNil(t, f.Styles.Fonts.Font[*xf.FontID].B)
+		assert.Equal(t, "0.000", f.Styles.NumFmts.NumFmt[*xf.NumFmtID-164].FormatCode)
+		colStyleID, err := f.GetColStyle(sheet, "D")
+		assert.NoError(t, err)
+		assert.Equal(t, styleID, colStyleID)
+	}
+	// Test the styles and images were deduplicated
+	assert.Len(t, f.Styles.CellXfs.Xf, 3)
+	assert.Len(t, f.Styles.Dxfs.Dxfs, 1)
+	assert.Equal(t, 2, f.countMedia())
+	assert.Equal(t, 2, f.countCharts())
+	// Test the references in the copied chart series refer to the new worksheet
+	for chart, sheet := range map[string]string{"xl/charts/chart1.xml": "Copy", "xl/charts/chart2.xml": "Sheet1"} {
+		var chartSpace xlsxChartSpace
+		content, ok := f.Pkg.Load(chart)
+		assert.True(t, ok)
+		assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+		ser := (*chartSpace.Chart.PlotArea.BarChart.Ser)[0]
+		assert.Equal(t, sheet+"!$B$1:$B$3", ser.Val.NumRef.F)
+		assert.Equal(t, sheet+"!$A$1:$A$3", ser.Cat.StrRef.F)
+	}
+	assert.Len(t, f.WorkBook.ExternalReferences.ExternalReference, 1)
+	assert.Equal(t, []DefinedName{
+		{Name: "Amount", RefersTo: "Copy!$B$1:$B$2", Scope: "Copy"},
+		{Name: "Amount", RefersTo: "Sheet1!$B$1:$B$2", Scope: "Sheet1"},
+	}, f.GetDefinedName())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheetFrom.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test copy worksheet with converting formulas to values
+	f, err = OpenFile(filepath.Join("test", "TestCopySheetFrom.xlsx"))
+	assert.NoError(t, err)
+	pics, err := f.GetPictures("Copy", "H1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	ws, ok := src.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[2].T, ws.(*xlsxWorksheet).SheetData.Row[2].C[2].V = "str", "cached"
+	assert.NoError(t, f.CopySheetFrom(src, "Sheet1", "Values", CopySheetOptions{FormulasToValues: true}))
+	formula, err := f.GetCellFormula("Values", "C3")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	val, err := f.GetCellValue("Values", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, "cached", val)
+	formula, err = f.GetCellFormula("Values", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Values!B1*2", formula)
+	assert.NoError(t, f.Close())
+
+	// Test copy worksheet with shared formulas
+	f = NewFile()
+	for r := 1; r <= 5; r++ {
+		assert.NoError(t, src.SetCellValue("Sheet1", fmt.Sprintf("G%d", r), nil))
+	}
+	ws, ok = src.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for r, formula := range []xlsxF{
+		{Content: "Sheet1!B1*2+'Sheet 2'!A1", T: STCellFormulaTypeShared, Ref: "G1:G3", Si: intPtr(0)},
+		{T: STCellFormulaTypeShared, Si: intPtr(0)},
+		{T: STCellFormulaTypeShared, Si: intPtr(0)},
+		{Content: "Sheet1!B1", T: STCellFormulaTypeShared, Ref: "G4:G5", Si: intPtr(1)},
+		{T: STCellFormulaTypeShared, Si: intPtr(1)},
+	} {
+		formula := formula
+		ws.(*xlsxWorksheet).SheetData.Row[r].C[6] = xlsxC{R: fmt.Sprintf("G%d", r+1), F: &formula, T: "str", V: "cached"}
+	}
+	assert.NoError(t, f.CopySheetFrom(src, "Sheet1", "Copy"))
+	for cell, expected := range map[string]string{
+		"G1": "Copy!B1*2+'[1]Sheet 2'!A1", "G2": "Copy!B2*2+'[1]Sheet 2'!A2", "G3": "Copy!B3*2+'[1]Sheet 2'!A3",
+		"G4": "Copy!B1", "G5": "Copy!B2",
+	} {
+		formula, err = f.GetCellFormula("Copy", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula)
+	}
+	copied, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Equal(t, &xlsxF{Content: "Copy!B1", T: STCellFormulaTypeShared, Ref: "G4:G5", Si: intPtr(1)}, copied.(*xlsxWorksheet).SheetData.Row[3].C[6].F)
+	// Test copy worksheet with converting shared formulas to values
+	assert.NoError(t, f.CopySheetFrom(src, "Sheet1", "Values", CopySheetOptions{FormulasToValues: true}))
+	for _, cell := range []string{"G1", "G2", "G3"} {
+		formula, err = f.GetCellFormula("Values", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula)
+		val, err = f.GetCellValue("Values", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "cached", val)
+	}
+	formula, err = f.GetCellFormula("Values", "G5")
+	assert.NoError(t, err)
+	assert.Equal(t, "Values!B2", formula)
+	assert.NoError(t, f.Close())
+
+	// Test copy worksheet from the workbook without path
+	f = NewFile()
+	src.Path = ""
+	assert.NoError(t, f.CopySheetFrom(src, "Sheet1", "Sheet1"))
+	formula, err = f.GetCellFormula("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	assert.Nil(t, f.WorkBook.ExternalReferences)
+	// Test copy worksheet in the same workbook
+	assert.NoError(t, f.CopySheetFrom(f, "Sheet1", "Sheet2"))
+	val, err = f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Name", val)
+	// Test copy worksheet with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.CopySheetFrom(nil, "Sheet1", "Sheet3"))
+	assert.EqualError(t, f.CopySheetFrom(src, "SheetN", "Sheet3"), "sheet SheetN does not exist")
+	assert.Equal(t, ErrSheetNameInvalid, f.CopySheetFrom(src, "Sheet1", "Sheet:3"))
+	// Test copy worksheet with unsupported charset shared string table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopySheetFrom(src, "Sheet1", "Sheet3"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, src.Close())
+}
+
 func TestGetSheetComments(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, "", f.getSheetComments("sheet0"))
@@ -1289,6 +1753,151 @@ func TestSetSheetRow(t *testing.T) {
 	assert.EqualError(t, f.SetSheetRow("Sheet1", "B27", &f), ErrParameterInvalid.Error())
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetRow.xlsx")))
 	assert.NoError(t, f.Close())
+
+	// Test set worksheet row with typed slices
+	f = NewFile()
+	date := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"a", "b"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]float64{1.5, 2}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]int{3, 4}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]time.Time{date}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A5", &[]bool{true}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "b"}, {"1.5", "2"}, {"3", "4"}, {"6/1/23 00:00"}, {"TRUE"}}, rows)
+
+	// Test set worksheet row with nil values
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B1", style))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{nil, "c"}, SheetCellsOptions{SkipNil: true}))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A2", &[]interface{}{nil, 5}, SheetCellsOptions{SkipNil: true}))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "c"}, rows[0])
+	assert.Equal(t, []string{"1.5", "2"}, rows[1])
+	assert.Equal(t, []string{"5", "4"}, rows[2])
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{nil}))
+	value, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	assert.NoError(t, f.Close())
+}
+
+func TestSetSheetRows(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C3", "A1"))
+	assert.NoError(t, f.SetSheetRows("Sheet1", "B2", [][]interface{}{
+		{"Name", "Score", "Duration"},
+		{"Excelize", 100, time.Hour},
+		{nil, []byte("bytes"), 1.5},
+	}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, {"", "Name", "Score", "Duration"}, {"", "Excelize", "100", "01:00:00"}, {"", "", "bytes", "1.5"}}, rows)
+	formula, err := f.GetCellFormula("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	// Test set worksheet rows with invalid cell reference
+	assert.EqualError(t, f.SetSheetRows("Sheet1", "A", nil), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set worksheet rows exceeds the maximum number of columns
+	assert.EqualError(t, f.SetSheetRows("Sheet1", "XFD1", [][]interface{}{{1, 2}}), ErrColumnNumber.Error())
+	// Test set worksheet rows with invalid sheet name
+	assert.EqualError(t, f.SetSheetRows("Sheet:1", "A1", nil), ErrSheetNameInvalid.Error())
+	// Test set worksheet rows with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetRows("Sheet1", "A1", nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetSheetRowStruct(t *testing.T) {
+	type Audit struct {
+		Author  string
+		private string
+	}
+	type Note struct {
+		Text string
+	}
+	type Order struct {
+		Audit
+		*Note
+		ID      int       `excel:"Order ID"`
+		Amount  float64   `excel:"Amount,format=#,##0.00"`
+		Date    time.Time `excel:",format=yyyy-mm-dd"`
+		Paid    *bool
+		Comment string `excel:"-"`
+	}
+	paid := true
+	date := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRowStruct("Sheet1", "B2", []*Order{
+		{Audit: Audit{Author: "Alice"}, ID: 1, Amount: 1234.5, Date: date, Paid: &paid, Comment: "skipped"},
+		nil,
+		{ID: 2, Amount: 99, Date: date, Note: &Note{Text: "note"}},
+	}))
+	assert.NoError(t, f.SetSheetRowStruct("Sheet1", "B6", Order{ID: 3}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "Author", "Text", "Order ID", "Amount", "Date", "Paid"},
+		{"", "Alice", "", "1", "1,234.50", "2023-05-01", "TRUE"},
+		nil,
+		{"", "", "note", "2", "99.00", "2023-05-01"},
+		{"", "", "", "3", "0", "0001-01-01T00:00:00Z"},
+	}, rows)
+	// Test set row by struct with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetSheetRowStruct("Sheet1", "A", Order{}))
+	// Test set row by invalid value
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetRowStruct("Sheet1", "A1", []int{1}))
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetRowStruct("Sheet1", "A1", nil))
+	// Test set row by struct with unsupported field type
+	assert.EqualError(t, f.SetSheetRowStruct("Sheet1", "A1", struct{ Tags []string }{}), "unsupported type []string of the field Tags")
+	// Test set row by struct with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.SetSheetRowStruct("Sheet:1", "A1", []Order{{}}))
+	assert.Equal(t, ErrSheetNameInvalid, f.SetSheetRowStruct("Sheet:1", "A1", Order{}))
+	// Test set row by struct exceeds the maximum column
+	assert.Equal(t, ErrColumnNumber, f.SetSheetRowStruct("Sheet1", "XFD1", []Order{}))
+	assert.Equal(t, ErrColumnNumber, f.SetSheetRowStruct("Sheet1", "XFD1", Order{}))
+	assert.NoError(t, f.Close())
+
+	// Test set row by struct with the fields of the named types
+	type (
+		Score  int
+		Ratio  float32
+		Count  uint16
+		Name   string
+		Active bool
+	)
+	score, active := Score(-7), Active(true)
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRowStruct("Sheet1", "A1", struct {
+		Score  *Score
+		Ratio  Ratio
+		Count  Count
+		Name   Name
+		Active *Active
+		Time   time.Duration
+	}{&score, 0.5, 65535, "name", &active, time.Hour}))
+	for _, expected := range []struct {
+		cell, value string
+		typ         CellType
+	}{
+		{"A1", "-7", CellTypeNumber}, {"B1", "0.5", CellTypeNumber}, {"C1", "65535", CellTypeNumber},
+		{"D1", "name", CellTypeSharedString}, {"E1", "1", CellTypeBool}, {"F1", "0.041666668", CellTypeDate},
+	} {
+		val, err := f.GetCellValue("Sheet1", expected.cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected.value, val, expected.cell)
+		typ, err := f.GetCellType("Sheet1", expected.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.typ, typ, expected.cell)
+	}
+	assert.NoError(t, f.Close())
 }
 
 func TestHSL(t *testing.T) {
@@ -1473,15 +2082,6 @@ func TestUnprotectWorkbook(t *testing.T) {
 	assert.EqualError(t, f.UnprotectWorkbook(), "XML syntax error on line 1: invalid UTF-8")
 }
 
-func TestSetDefaultTimeStyle(t *testing.T) {
-	f := NewFile()
-	// Test set default time style on not exists worksheet.
-	assert.EqualError(t, f.setDefaultTimeStyle("SheetN", "", 0), "sheet SheetN does not exist")
-
-	// Test set default time style on invalid cell
-	assert.EqualError(t, f.setDefaultTimeStyle("Sheet1", "", 42), newCellNameToCoordinatesError("", newInvalidCellNameError("")).Error())
-}
-
 func TestAddVBAProject(t *testing.T) {
 	f := NewFile()
 	file, err := os.ReadFile(filepath.Join("test", "Book1.xlsx"))
@@ -1696,3 +2296,202 @@ func BenchmarkOpenFile(b *testing.B) {
 		}
 	}
 }
+
+func TestPreserveRichData(t *testing.T) {
+	f := NewFile()
+	// The rich data parts are written by hand according to the rich value
+	// schema of the linked data types, not saved by Excel
+	parts := map[string]string{
+		"xl/metadata.xml":                         `<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"><metadataTypes count="1"><metadataType name="XLRICHVALUE" minSupportedVersion="120000" copy="1" pasteAll="1" pasteValues="1" merge="1" splitFirst="1" rowColShift="1" clearFormats="1" clearComments="1" assign="1" coerce="1"/></metadataTypes><futureMetadata name="XLRICHVALUE" count="1"><bk><extLst><ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"><xlrd:rvb i="0"/></ext></extLst></bk></futureMetadata><valueMetadata count="1"><bk><rc t="1" v="0"/></bk></valueMetadata></metadata>`,
+		"xl/richData/rdrichvalue.xml":             `<rvData xmlns="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata" count="1"><rv s="0"><v>Microsoft Corporation</v><v>MSFT</v></rv></rvData>`,
+		"xl/richData/rdrichvaluestructure.xml":    `<rvStructures xmlns="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata" count="1"><s t="_linkedentity2"><k n="_DisplayString" t="s"/><k n="Ticker symbol" t="s"/></s></rvStructures>`,
+		"xl/richData/rdRichValueTypes.xml":        `<rvTypesInfo xmlns="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata2"><global><keyFlags><key name="_Self"><flag name="ExcludeFromFile" value="1"/></key></keyFlags></global></rvTypesInfo>`,
+		"xl/richData/richValueRel.xml":            `<richValueRels xmlns="http://schemas.microsoft.com/office/spreadsheetml/2022/richvaluerel"/>`,
+		"xl/richData/_rels/richValueRel.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"/>`,
+	}
+	for path, content := range parts {
+		f.Pkg.Store(path, []byte(content))
+	}
+	for _, rel := range [][]string{
+		{"http://schemas.openxmlformats.org/officeDocument/2006/relationships/sheetMetadata", "metadata.xml", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheetMetadata+xml"},
+		{"http://schemas.microsoft.com/office/2017/06/relationships/rdRichValue", "richData/rdrichvalue.xml", "application/vnd.ms-excel.rdrichvalue+xml"},
+		{"http://schemas.microsoft.com/office/2017/06/relationships/rdRichValueStructure", "richData/rdrichvaluestructure.xml", "application/vnd.ms-excel.rdrichvaluestructure+xml"},
+		{"http://schemas.microsoft.com/office/2017/06/relationships/rdRichValueTypes", "richData/rdRichValueTypes.xml", "application/vnd.ms-excel.rdrichvaluetypes+xml"},
+		{"http://schemas.microsoft.com/office/2022/10/relationships/richValueRel", "richData/richValueRel.xml", "application/vnd.ms-excel.richvaluerel+xml"},
+	} {
+		f.addRels(f.getWorkbookRelsPath(), rel[0], rel[1], "")
+		assert.NoError(t, f.setContentTypes("/xl/"+rel[1], rel[2]))
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row = []xlsxRow{{R: 1, C: []xlsxC{{R: "A1", T: "e", V: formulaErrorVALUE, Vm: uintPtr(1)}}}}
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	// Test edit the unrelated cells in the workbook with linked data types
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "Ticker"))
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 1))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPreserveRichData.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestPreserveRichData.xlsx"))
+	assert.NoError(t, err)
+	for path, content := range parts {
+		data, ok := f.Pkg.Load(path)
+		assert.True(t, ok, path)
+		assert.Equal(t, content, string(data.([]byte)), path)
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	assert.NoError(t, err)
+	var targets []string
+	for _, rel := range rels.Relationships {
+		targets = append(targets, rel.Target)
+	}
+	assert.Subset(t, targets, []string{"metadata.xml", "richData/rdrichvalue.xml", "richData/rdrichvaluestructure.xml", "richData/rdRichValueTypes.xml", "richData/richValueRel.xml"})
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, uintPtr(1), ws.SheetData.Row[1].C[0].Vm)
+	// Test overwrite the linked data type cell value will unbind the rich value
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "MSFT"))
+	assert.Nil(t, ws.SheetData.Row[1].C[0].Vm)
+	// Test clear the formula only will keep the formula and the rich value of
+	// the cell
+	ws.SheetData.Row[1].C[0] = xlsxC{R: "A2", T: "e", V: formulaErrorVALUE, F: &xlsxF{Content: "B1"}, Vm: uintPtr(1)}
+	assert.NoError(t, f.ClearRange("Sheet1", "A2", ClearOptions{Formulas: true}))
+	assert.Equal(t, &xlsxF{Content: "B1"}, ws.SheetData.Row[1].C[0].F)
+	assert.Equal(t, uintPtr(1), ws.SheetData.Row[1].C[0].Vm)
+	// Test overwrite the rich value cell with formula will remove the formula
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "MSFT"))
+	assert.Nil(t, ws.SheetData.Row[1].C[0].F)
+	assert.Nil(t, ws.SheetData.Row[1].C[0].Vm)
+	assert.NoError(t, f.Close())
+}
+
+func TestPreserveExtLst(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Price", "Total"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B3"}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A2:A3", []ConditionalFormatOptions{{Type: "duplicate"}}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment"}))
+	_, err := f.NewConditionalStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	// Prepare workbook with the extension lists in the workbook, worksheet,
+	// table, styles, shared strings and comments parts
+	exts := map[string][][]string{
+		"xl/workbook.xml": {
+			{"</workbookView>", `<extLst><ext uri="{A8765BA9-456A-4DAB-B4F3-ACF838C121DE}" xmlns:x15="http://schemas.microsoft.com/office/spreadsheetml/2010/11/main"><x15:workbookViewPr showTimelines="1"/></ext></extLst>`},
+			{"</workbook>", `<extLst><ext uri="{FCE2AD5D-F65C-4FA6-A056-5C36A1767C68}" xmlns:x15="http://schemas.microsoft.com/office/spreadsheetml/2010/11/main"><x15:dataModel><x15:modelTables><x15:modelTable id="Sales" name="Sales" connection="Query - Sales"/></x15:modelTables></x15:dataModel></ext></extLst>`},
+		},
+		"xl/worksheets/sheet1.xml": {
+			{"</sheetView>", `<extLst><ext uri="{4D3B3A48-7B6C-4C1B-9E5D-2B1B1C8E7F01}" xmlns:x16="http://schemas.microsoft.com/office/spreadsheetml/2014/11/main"><x16:sheetViewPr/></ext></extLst>`},
+			{"</c>", `<extLst><ext uri="{3D5F7A9C-2B4E-4C6D-8E0F-1A2B3C4D5E6F}" xmlns:x16="http://schemas.microsoft.com/office/spreadsheetml/2014/11/main"><x16:cellPr/></ext></extLst>`},
+			{"</row>", `<extLst><ext uri="{B2D4E6F8-1A3C-4E5F-8A9B-0C1D2E3F4A5B}" xmlns:x16="http://schemas.microsoft.com/office/spreadsheetml/2014/11/main"><x16:rowPr/></ext></extLst>`},
+			{"</conditionalFormatting>", `<extLst><ext uri="{9C5C8A2B-7E0C-4C2A-8E1A-3F5B6D7E8F90}" xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"><x14:id>{00000000-000E-0000-0000-000001000000}</x14:id></ext></extLst>`},
+			{"</worksheet>", `<extLst><ext uri="{7E03D99C-DC04-49d9-9315-930204A7B6E9}" xmlns:x15="http://schemas.microsoft.com/office/spreadsheetml/2010/11/main"><x15:timelineRefs><x15:timelineRef r:id="rId2"/></x15:timelineRefs></ext></extLst>`},
+		},
+		"xl/tables/table1.xml": {
+			{"</autoFilter>", `<extLst><ext uri="{2946ED86-A175-432a-8AC1-64E0C546D7DE}" xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"><x14:autoFilterPr/></ext></extLst>`},
+			{"</tableColumn></tableColumns>", `<calculatedColumnFormula>Table1[[#This Row],[Price]]*2</calculatedColumnFormula><extLst><ext uri="{FCC71383-01E1-4257-9335-427F07BE8D7F}" xmlns:xlmsforms="http://schemas.microsoft.com/office/spreadsheetml/2023/msForms"><xlmsforms:question id="1"/></ext></extLst>`},
+			{"</table>", `<extLst><ext uri="{504A1905-F514-4f6f-8877-14C23A59335A}" xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"><x14:table altText="Sales"/></ext></extLst>`},
+		},
+		"xl/styles.xml": {
+			{"</xf></cellXfs>", `<extLst><ext uri="{C7286773-470A-42A8-94C5-96B5CB345126}" xmlns:xfpb="http://schemas.microsoft.com/office/spreadsheetml/2022/featurepropertybag"><xfpb:xfComplement i="0"/></ext></extLst>`},
+			{"</cellStyle>", `<extLst><ext uri="{931C1A2C-8B4A-4F3B-A5D6-0E2F1B3C4D5E}" xmlns:xr9="http://schemas.microsoft.com/office/spreadsheetml/2016/revision9"><xr9:uid val="{00000000-0000-0000-0000-000000000000}"/></ext></extLst>`},
+			{"</dxf>", `<extLst><ext uri="{0417FA29-78FA-4A13-93AC-8FF0FAFDF519}" xmlns:xfpb="http://schemas.microsoft.com/office/spreadsheetml/2022/featurepropertybag"><xfpb:DXFComplement i="0"/></ext></extLst>`},
+		},
+		"xl/sharedStrings.xml": {
+			{"</sst>", `<extLst><ext uri="{6F1C2A3B-4D5E-4F60-8A7B-9C0D1E2F3A4B}" xmlns:x16="http://schemas.microsoft.com/office/spreadsheetml/2014/11/main"><x16:sstPr/></ext></extLst>`},
+		},
+		"xl/comments1.xml": {
+			{"</comments>", `<extLst><ext uri="{5A6B7C8D-9E0F-4A1B-8C2D-3E4F5A6B7C8D}" xmlns:x16="http://schemas.microsoft.com/office/spreadsheetml/2014/11/main"><x16:commentsPr/></ext></extLst>`},
+		},
+	}
+	readParts := func(b []byte) map[string]string {
+		zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		assert.NoError(t, err)
+		parts := make(map[string]string)
+		for _, item := range zr.File {
+			rc, err := item.Open()
+			assert.NoError(t, err)
+			content, err := io.ReadAll(rc)
+			assert.NoError(t, err)
+			assert.NoError(t, rc.Close())
+			parts[item.Name] = string(content)
+		}
+		return parts
+	}
+	source := new(bytes.Buffer)
+	zw := zip.NewWriter(source)
+	for name, content := range readParts(buf.Bytes()) {
+		for _, ext := range exts[name] {
+			assert.Contains(t, content, ext[0])
+			content = strings.Replace(content, ext[0], ext[1]+ext[0], 1)
+		}
+		fi, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = fi.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+
+	// Test the extension lists are preserved after open and save the workbook,
+	// and the table part will be re-serialized by inserting rows
+	f, err = OpenReader(source)
+	assert.NoError(t, err)
+	_, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "Note"))
+	_, err = f.NewStyle(&Style{Font: &Font{Italic: true}})
+	assert.NoError(t, err)
+	_, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	parts := readParts(buf.Bytes())
+	for name, items := range exts {
+		for _, ext := range items {
+			assert.Contains(t, parts[name], ext[1]+strings.SplitAfter(ext[0], ">")[0], name)
+		}
+	}
+	assert.Contains(t, parts["xl/tables/table1.xml"], `ref="A1:B4"`)
+
+	// Test the extension lists of the workbooks saved by Excel are preserved
+	// verbatim after the parts were re-serialized
+	for _, test := range []struct {
+		name, part string
+		modify     func(f *File) error
+	}{
+		{"Book1.xlsx", "xl/styles.xml", func(f *File) error {
+			_, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+			return err
+		}},
+		{"SharedStrings.xlsx", "xl/styles.xml", func(f *File) error {
+			_, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+			return err
+		}},
+		{"MergeCell.xlsx", "xl/workbook.xml", func(f *File) error {
+			return f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1"})
+		}},
+	} {
+		source, err := os.ReadFile(filepath.Join("test", test.name))
+		assert.NoError(t, err)
+		extLst := regexp.MustCompile(`<extLst>.*</extLst>`).FindString(readParts(source)[test.part])
+		assert.NotEmpty(t, extLst, test.name)
+		f, err := OpenReader(bytes.NewReader(source))
+		assert.NoError(t, err)
+		assert.NoError(t, test.modify(f))
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+		assert.Contains(t, readParts(buf.Bytes())[test.part], extLst, test.name)
+	}
+}
@@ -14,10 +14,12 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -67,6 +69,9 @@ func (f *File) Save(opts ...Options) error {
 // SaveAs provides a function to create or update to a spreadsheet at the
 // provided path.
 func (f *File) SaveAs(name string, opts ...Options) error {
+	if err := f.checkReadOnly("SaveAs"); err != nil {
+		return err
+	}
 	if len(name) > MaxFilePathLength {
 		return ErrMaxFilePathLength
 	}
@@ -110,6 +115,26 @@ func (f *File) Write(w io.Writer, opts ...Options) error {
 
 // WriteTo implements io.WriterTo to write the file.
 func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
+	return f.WriteToWithContext(context.Background(), w, opts...)
+}
+
+// WriteToWithContext provides a function to write the file to io.Writer, the
+// writing will be aborted and the context error will be returned when the
+// context is canceled or its deadline exceeded. The worksheets will be
+// serialized into the writer incrementally, and the worksheets in the system
+// temporary directory will be copied without loading into memory. For
+// example, write the spreadsheet into the HTTP response with 30 seconds
+// timeout:
+//
+//	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+//	defer cancel()
+//	if _, err := f.WriteToWithContext(ctx, w); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) WriteToWithContext(ctx context.Context, w io.Writer, opts ...Options) (int64, error) {
+	if err := f.checkReadOnly("WriteTo"); err != nil {
+		return 0, err
+	}
 	for i := range opts {
 		f.options = &opts[i]
 	}
@@ -122,23 +147,25 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 			return 0, err
 		}
 	}
+	cw := &contextWriter{ctx: ctx, w: w}
 	if f.options != nil && f.options.Password != "" {
 		buf, err := f.WriteToBuffer()
 		if err != nil {
 			return 0, err
 		}
-		return buf.WriteTo(w)
-	}
-	if err := f.writeDirectToWriter(w); err != nil {
-		return 0, err
+		return buf.WriteTo(cw)
 	}
-	return 0, nil
+	err := f.writeDirectToWriter(cw)
+	return cw.n, err
 }
 
 // WriteToBuffer provides a function to get bytes.Buffer from the saved file,
 // and it allocates space in memory. Be careful when the file size is large.
 func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
+	if err := f.checkReadOnly("WriteToBuffer"); err != nil {
+		return buf, err
+	}
 	zw := zip.NewWriter(buf)
 
 	if err := f.writeToZip(zw); err != nil {
@@ -170,64 +197,108 @@ func (f *File) writeDirectToWriter(w io.Writer) error {
 	return zw.Close()
 }
 
+// zipEntry defined the path of a part in the zip package and the function to
+// write the content of the part.
+type zipEntry struct {
+	path  string
+	write func(w io.Writer) error
+}
+
 // writeToZip provides a function to write to zip.Writer
 func (f *File) writeToZip(zw *zip.Writer) error {
 	f.calcChainWriter()
 	f.commentsWriter()
 	f.contentTypesWriter()
 	f.drawingsWriter()
+	f.metadataWriter()
 	f.vmlDrawingWriter()
 	f.workBookWriter()
-	f.workSheetWriter()
 	f.relsWriter()
-	_ = f.sharedStringsLoader()
+	if f.SharedStrings != nil {
+		_ = f.sharedStringsLoader()
+	}
 	f.sharedStringsWriter()
 	f.styleSheetWriter()
 	f.themeWriter()
 
+	var (
+		err     error
+		entries []zipEntry
+		written = make(map[string]bool)
+	)
 	for path, stream := range f.streams {
-		fi, err := zw.Create(path)
-		if err != nil {
-			return err
-		}
-		var from io.Reader
-		from, err = stream.rawData.Reader()
-		if err != nil {
-			_ = stream.rawData.Close()
-			return err
+		written[path] = true
+		if stream.zw != nil {
+			continue
 		}
-		_, err = io.Copy(fi, from)
-		if err != nil {
+		rawData := stream.rawData
+		entries = append(entries, zipEntry{path: path, write: func(w io.Writer) error {
+			from, err := rawData.Reader()
+			if err != nil {
+				_ = rawData.Close()
+				return err
+			}
+			_, err = io.Copy(w, from)
 			return err
-		}
+		}})
 	}
-	var err error
-	f.Pkg.Range(func(path, content interface{}) bool {
-		if err != nil {
-			return false
-		}
-		if _, ok := f.streams[path.(string)]; ok {
+	f.Sheet.Range(func(p, ws interface{}) bool {
+		path := p.(string)
+		if written[path] || ws == nil {
 			return true
 		}
-		var fi io.Writer
-		fi, err = zw.Create(path.(string))
-		if err != nil {
-			return false
-		}
-		_, err = fi.Write(content.([]byte))
+		entries = append(entries, zipEntry{path: path, write: func(w io.Writer) error {
+			return f.writeWorkSheet(w, path, ws.(*xlsxWorksheet))
+		}})
+		written[path] = true
 		return true
 	})
-	f.tempFiles.Range(func(path, content interface{}) bool {
-		if _, ok := f.Pkg.Load(path); ok {
+	f.Pkg.Range(func(p, content interface{}) bool {
+		path := p.(string)
+		if written[path] {
 			return true
 		}
-		var fi io.Writer
-		fi, err = zw.Create(path.(string))
-		if err != nil {
-			return false
+		entries = append(entries, zipEntry{path: path, write: func(w io.Writer) error {
+			_, err := w.Write(content.([]byte))
+			return err
+		}})
+		written[path] = true
+		return true
+	})
+	f.tempFiles.Range(func(p, _ interface{}) bool {
+		path := p.(string)
+		if written[path] || path == defaultTempFileSST {
+			return true
 		}
-		_, err = fi.Write(f.readBytes(path.(string)))
+		entries = append(entries, zipEntry{path: path, write: func(w io.Writer) error {
+			file, err := f.readTemp(path)
+			if err != nil {
+				return err
+			}
+			if _, err = io.Copy(w, file); err != nil {
+				_ = file.Close()
+				return err
+			}
+			return file.Close()
+		}})
 		return true
 	})
+	if f.options != nil && f.options.Deterministic {
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].path == defaultXMLPathContentTypes || entries[j].path == defaultXMLPathContentTypes {
+				return entries[i].path == defaultXMLPathContentTypes
+			}
+			return entries[i].path < entries[j].path
+		})
+	}
+	for _, entry := range entries {
+		var fi io.Writer
+		if fi, err = zw.Create(entry.path); err != nil {
+			return err
+		}
+		if err = entry.write(fi); err != nil {
+			return err
+		}
+	}
 	return err
 }
@@ -1,8 +1,11 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bufio"
 	"bytes"
+	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -36,6 +39,48 @@ func BenchmarkWrite(b *testing.B) {
 	}
 }
 
+func BenchmarkWriteTo(b *testing.B) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	if err != nil {
+		b.Fatal(err)
+	}
+	row := make([]interface{}, 20)
+	for col := range row {
+		row[col] = "This is test data"
+	}
+	for r := 1; r <= 100000; r++ {
+		cell, _ := CoordinatesToCellName(1, r)
+		if err := sw.SetRow(cell, row); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := sw.Flush(); err != nil {
+		b.Fatal(err)
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f, err := OpenReader(bytes.NewReader(buf.Bytes()), Options{UnzipXMLSizeLimit: 1 << 10})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := f.WriteTo(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestWriteTo(t *testing.T) {
 	// Test WriteToBuffer err
 	{
@@ -67,11 +112,17 @@ func TestWriteTo(t *testing.T) {
 	{
 		f, buf := File{tempFiles: sync.Map{}}, bytes.Buffer{}
 		const maxUint16 = 1<<16 - 1
-		f.tempFiles.Store("s", "")
 		f.tempFiles.Store(strings.Repeat("s", maxUint16+1), "")
 		_, err := f.WriteTo(bufio.NewWriter(&buf))
 		assert.EqualError(t, err, "zip: FileHeader.Name too long")
 	}
+	// Test write with not exist temporary file
+	{
+		f, buf := File{tempFiles: sync.Map{}}, bytes.Buffer{}
+		f.tempFiles.Store("s", "")
+		_, err := f.WriteTo(bufio.NewWriter(&buf))
+		assert.Error(t, err)
+	}
 	// Test write with unsupported workbook file format
 	{
 		f, buf := File{Pkg: sync.Map{}}, bytes.Buffer{}
@@ -90,8 +141,95 @@ func TestWriteTo(t *testing.T) {
 	}
 }
 
+func TestWriteToWithContext(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "updated"))
+	var buf bytes.Buffer
+	n, err := f.WriteToWithContext(context.Background(), &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	// Test the worksheets in the system temporary directory are not loaded
+	// into memory on saving
+	_, ok := f.Pkg.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	// Test write with canceled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = f.WriteToWithContext(ctx, &bytes.Buffer{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(&buf)
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"Sheet1!A1": "updated", "Sheet1!A19": "Total:", "Sheet2!A1": "Monitor"} {
+		parts := strings.Split(cell, "!")
+		value, err := f.GetCellValue(parts[0], parts[1])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+	rows, err := f.GetRows("Sheet2")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, rows)
+	assert.NoError(t, f.Close())
+}
+
+func TestReplaceWriter(t *testing.T) {
+	var buf bytes.Buffer
+	rw := &replaceWriter{w: &buf, old: []byte("abc"), new: []byte("X")}
+	for _, p := range []string{"1a", "bc2ab", "c", "abcab"} {
+		n, err := rw.Write([]byte(p))
+		assert.NoError(t, err)
+		assert.Equal(t, len(p), n)
+	}
+	assert.NoError(t, rw.Flush())
+	assert.Equal(t, "1X2XXab", buf.String())
+}
+
 func TestClose(t *testing.T) {
 	f := NewFile()
 	f.tempFiles.Store("/d/", "/d/")
 	require.Error(t, f.Close())
 }
+
+func TestWriteToDeterministic(t *testing.T) {
+	newWorkbook := func() *File {
+		f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+		assert.NoError(t, err)
+		_, err = f.NewSheet("Sheet3")
+		assert.NoError(t, err)
+		assert.NoError(t, f.AddComment("Sheet3", Comment{Cell: "A1", Author: "Excelize", Text: "comment"}))
+		assert.NoError(t, f.AddChart("Sheet3", "B1", &Chart{
+			Type:   Col,
+			Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$2:$D$2"}},
+		}))
+		assert.NoError(t, f.AddPicture("Sheet3", "F1", filepath.Join("test", "images", "excel.png"), nil))
+		assert.NoError(t, f.AddTable("Sheet3", &Table{Range: "H1:J3"}))
+		assert.NoError(t, f.SetConditionalFormat("Sheet3", "A1:A3",
+			[]ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6"}}))
+		sw, err := f.NewStreamWriter("Sheet3")
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow("A1", []interface{}{"stream"}))
+		assert.NoError(t, sw.Flush())
+		return f
+	}
+	save := func(f *File) []byte {
+		buf := new(bytes.Buffer)
+		_, err := f.WriteTo(buf, Options{Deterministic: true})
+		assert.NoError(t, err)
+		return buf.Bytes()
+	}
+	f1, f2 := newWorkbook(), newWorkbook()
+	expected := save(f1)
+	assert.Equal(t, expected, save(f1))
+	assert.Equal(t, expected, save(f2))
+	assert.NoError(t, f1.Close())
+	assert.NoError(t, f2.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(expected), int64(len(expected)))
+	assert.NoError(t, err)
+	assert.Equal(t, defaultXMLPathContentTypes, zr.File[0].Name)
+	for i := 2; i < len(zr.File); i++ {
+		assert.Less(t, zr.File[i-1].Name, zr.File[i].Name)
+	}
+}
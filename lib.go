@@ -15,18 +15,28 @@ import (
 	"archive/zip"
 	"bytes"
 	"container/list"
+	"context"
 	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"io"
 	"math/big"
 	"os"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 // ReadZipReader extract spreadsheet with given options.
 func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
+	return f.readZipReader(context.Background(), r)
+}
+
+// readZipReader extract spreadsheet with given options, the unzipping will be
+// aborted when the context is done.
+func (f *File) readZipReader(ctx context.Context, r *zip.Reader) (map[string][]byte, int, error) {
 	var (
 		err     error
 		docPart = map[string]string{
@@ -38,11 +48,17 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 		unzipSize  int64
 	)
 	for _, v := range r.File {
+		if err = ctx.Err(); err != nil {
+			return nil, 0, err
+		}
 		fileSize := v.FileInfo().Size()
 		unzipSize += fileSize
 		if unzipSize > f.options.UnzipSizeLimit {
 			return fileList, worksheets, newUnzipSizeLimitError(f.options.UnzipSizeLimit)
 		}
+		if f.options.UnzipPartSizeLimit > 0 && fileSize > f.options.UnzipPartSizeLimit {
+			return fileList, worksheets, newUnzipPartSizeLimitError(v.Name, f.options.UnzipPartSizeLimit)
+		}
 		fileName := strings.ReplaceAll(v.Name, "\\", "/")
 		if partName, ok := docPart[strings.ToLower(fileName)]; ok {
 			fileName = partName
@@ -62,7 +78,7 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 				}
 			}
 		}
-		if fileList[fileName], err = readFile(v); err != nil {
+		if fileList[fileName], err = readFile(ctx, v); err != nil {
 			return nil, 0, err
 		}
 	}
@@ -133,17 +149,35 @@ func (f *File) saveFileList(name string, content []byte) {
 }
 
 // Read file content as string in an archive file.
-func readFile(file *zip.File) ([]byte, error) {
+func readFile(ctx context.Context, file *zip.File) ([]byte, error) {
 	rc, err := file.Open()
 	if err != nil {
 		return nil, err
 	}
 	dat := make([]byte, 0, file.FileInfo().Size())
 	buff := bytes.NewBuffer(dat)
-	_, _ = io.Copy(buff, rc)
+	if _, err = io.Copy(buff, &contextReader{ctx: ctx, r: rc}); err != nil && ctx.Err() != nil {
+		_ = rc.Close()
+		return nil, err
+	}
 	return buff.Bytes(), rc.Close()
 }
 
+// contextReader wraps an io.Reader and returns the context error on reading
+// when the context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read implements the io.Reader interface.
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
 // SplitCellName splits cell name to column name and row number.
 //
 // Example:
@@ -273,6 +307,185 @@ func CoordinatesToCellName(col, row int, abs ...bool) (string, error) {
 	return sign + colName + sign + strconv.Itoa(row), err
 }
 
+// r1c1RefRegexp matches the cell reference, whole row reference or whole
+// column reference in the R1C1 reference style at the beginning of the string.
+var r1c1RefRegexp = regexp.MustCompile(`^(?i)(?:R(\[-?\d+\]|\d*))?(?:C(\[-?\d+\]|\d*))?`)
+
+// FormulaA1ToR1C1 provides a function to convert the formula in the A1
+// reference style into the R1C1 reference style by given formula and the
+// reference of the cell which contains the formula. The relative references
+// will be converted to the offsets from the cell. For example:
+//
+//	excelize.FormulaA1ToR1C1("SUM(A1:B2,$C$3)", "C3") // returns "SUM(R[-2]C[-2]:R[-1]C[-1],R3C3)", nil
+//	excelize.FormulaA1ToR1C1("B$1+2:$4", "C3") // returns "R1C[-1]+R[-1]:R4", nil
+func FormulaA1ToR1C1(formula, cell string) (string, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return formula, err
+	}
+	return formulaA1ToR1C1(formula, col, row), err
+}
+
+// FormulaR1C1ToA1 provides a function to convert the formula in the R1C1
+// reference style into the A1 reference style by given formula and the
+// reference of the cell which contains the formula. The offsets in the square
+// brackets will be converted to the relative references, and the others will
+// be converted to the absolute references. For example:
+//
+//	excelize.FormulaR1C1ToA1("SUM(R[-2]C[-2]:R[-1]C[-1],R3C3)", "C3") // returns "SUM(A1:B2,$C$3)", nil
+//	excelize.FormulaR1C1ToA1("R[-1]C5+R2", "C3") // returns "$E2+$2:$2", nil
+func FormulaR1C1ToA1(formula, cell string) (string, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return formula, err
+	}
+	return formulaR1C1ToA1(formula, col, row)
+}
+
+// formulaA1ToR1C1 provides a function to convert the references in the
+// formula from the A1 reference style into the R1C1 reference style, the
+// relative references will be converted relative to the cell by given column
+// and row number.
+func formulaA1ToR1C1(formula string, col, row int) string {
+	return traverseFormulaRefs(formula, func(sheet, ref string) string {
+		refParts, ok := parseRef(ref)
+		if !ok {
+			return ref
+		}
+		parts := make([]string, len(refParts))
+		for i, p := range refParts {
+			parts[i] = p.r1c1(col, row)
+		}
+		return strings.Join(parts, ":")
+	})
+}
+
+// r1c1 returns the part of the reference in the R1C1 reference style, the
+// relative row and column number will be converted relative to the cell by
+// given column and row number.
+func (p refPart) r1c1(col, row int) string {
+	var buf strings.Builder
+	for _, part := range []struct {
+		prefix, abs string
+		num, base   int
+	}{
+		{"R", p.rowAbs, p.rowNum, row},
+		{"C", p.colAbs, p.colNum, col},
+	} {
+		if part.num == 0 {
+			continue
+		}
+		buf.WriteString(part.prefix)
+		if part.abs != "" {
+			buf.WriteString(strconv.Itoa(part.num))
+			continue
+		}
+		if offset := part.num - part.base; offset != 0 {
+			buf.WriteString("[" + strconv.Itoa(offset) + "]")
+		}
+	}
+	return buf.String()
+}
+
+// formulaR1C1ToA1 provides a function to convert the references in the
+// formula from the R1C1 reference style into the A1 reference style, the
+// offsets will be converted relative to the cell by given column and row
+// number.
+func formulaR1C1ToA1(formula string, col, row int) (string, error) {
+	var buf strings.Builder
+	isDelimiter := func(i int) bool {
+		return i == 0 || strings.IndexByte(" ,;()+-*/^&=<>{}%!:\r\n\t", formula[i-1]) != -1
+	}
+	for i := 0; i < len(formula); {
+		start, c := i, formula[i]
+		switch {
+		case c == '"' || c == '\'':
+			for i++; i < len(formula); i++ {
+				if formula[i] == c {
+					if i+1 < len(formula) && formula[i+1] == c {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			if i++; i > len(formula) {
+				i = len(formula)
+			}
+		case c == '[':
+			for depth := 0; i < len(formula); i++ {
+				if formula[i] == '[' {
+					depth++
+				} else if formula[i] == ']' {
+					if depth--; depth == 0 {
+						break
+					}
+				}
+			}
+			if i++; i > len(formula) {
+				i = len(formula)
+			}
+		case isDelimiter(i):
+			p, n, err := parseR1C1RefPart(formula[i:], col, row)
+			if err != nil {
+				return formula, err
+			}
+			if n == 0 {
+				i++
+				break
+			}
+			ref := p.String()
+			if (p.colNum == 0 || p.rowNum == 0) && !strings.HasSuffix(buf.String(), ":") &&
+				(i+n == len(formula) || formula[i+n] != ':') {
+				ref += ":" + ref
+			}
+			buf.WriteString(ref)
+			i += n
+			continue
+		default:
+			i++
+		}
+		buf.WriteString(formula[start:i])
+	}
+	return buf.String(), nil
+}
+
+// parseR1C1RefPart provides a function to parse the part of the cell or range
+// reference in the R1C1 reference style at the beginning of the string by
+// given column and row number of the cell which contains the reference. It
+// returns the length of the reference, which is 0 if the string doesn't begin
+// with a reference.
+func parseR1C1RefPart(s string, col, row int) (refPart, int, error) {
+	var p refPart
+	m := r1c1RefRegexp.FindStringSubmatchIndex(s)
+	n := m[1]
+	if n == 0 || n < len(s) && (s[n] == '_' || s[n] == '.' || s[n] == '(' || s[n] == '!' ||
+		unicode.IsLetter(rune(s[n])) || unicode.IsDigit(rune(s[n]))) {
+		return p, 0, nil
+	}
+	parse := func(start, end, base, max int) (int, string, bool) {
+		if start == -1 {
+			return 0, "", true
+		}
+		num, abs := base, ""
+		if spec := s[start:end]; spec != "" && spec[0] == '[' {
+			offset, _ := strconv.Atoi(spec[1 : len(spec)-1])
+			num += offset
+		} else if spec != "" {
+			num, _ = strconv.Atoi(spec)
+			abs = "$"
+		}
+		return num, abs, num >= 1 && num <= max
+	}
+	var rowOK, colOK bool
+	p.rowNum, p.rowAbs, rowOK = parse(m[2], m[3], row, TotalRows)
+	p.colNum, p.colAbs, colOK = parse(m[4], m[5], col, MaxColumns)
+	if !rowOK || !colOK {
+		return p, n, newInvalidR1C1RefError(s[:n])
+	}
+	return p, n, nil
+}
+
 // rangeRefToCoordinates provides a function to convert range reference to a
 // pair of coordinates.
 func rangeRefToCoordinates(ref string) ([]int, error) {
@@ -328,6 +541,231 @@ func (f *File) coordinatesToRangeRef(coordinates []int, abs ...bool) (string, er
 	return firstCell + ":" + lastCell, err
 }
 
+// SplitRangeRef provides a function to split the cell or range reference
+// which qualified by the worksheet name into the worksheet name and the
+// reference. The single quotes around the worksheet name will be removed, and
+// the escaped single quotes in the name will be unescaped. The worksheet name
+// will be empty if the reference is not qualified. For example:
+//
+//	excelize.SplitRangeRef("'Sheet 1'!$A$1:$B$2") // returns "Sheet 1", "$A$1:$B$2", nil
+//	excelize.SplitRangeRef("'It''s'!A1") // returns "It's", "A1", nil
+//	excelize.SplitRangeRef("A1:B2") // returns "", "A1:B2", nil
+func SplitRangeRef(ref string) (string, string, error) {
+	idx := strings.LastIndex(ref, "!")
+	if idx == -1 {
+		if ref == "" {
+			return "", ref, newInvalidRangeRefError(ref)
+		}
+		return "", ref, nil
+	}
+	sheet, rng := ref[:idx], ref[idx+1:]
+	if strings.HasPrefix(sheet, "'") {
+		if len(sheet) < 3 || !strings.HasSuffix(sheet, "'") ||
+			strings.Contains(strings.ReplaceAll(sheet[1:len(sheet)-1], "''", ""), "'") {
+			return "", "", newInvalidRangeRefError(ref)
+		}
+		sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+	}
+	if sheet == "" || rng == "" {
+		return "", "", newInvalidRangeRefError(ref)
+	}
+	return sheet, rng, nil
+}
+
+// RangeIterator provides a function to get the iterator of the cells in the
+// range by given cell reference, range reference, whole columns or whole rows
+// reference, and the reference could be qualified by the worksheet name. The
+// iterator returns the column and row number of the cells row by row, and
+// returns false after the last cell. For example, iterate the cells in the
+// range A1:C10:
+//
+//	next, err := excelize.RangeIterator("Sheet1!$A$1:$C$10")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for col, row, ok := next(); ok; col, row, ok = next() {
+//	    fmt.Println(col, row)
+//	}
+func RangeIterator(ref string) (func() (int, int, bool), error) {
+	_, rect, err := parseRangeRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	col, row := rect[0]-1, rect[1]
+	return func() (int, int, bool) {
+		if col++; col > rect[2] {
+			col, row = rect[0], row+1
+		}
+		if row > rect[3] {
+			return 0, 0, false
+		}
+		return col, row, true
+	}, err
+}
+
+// RangesIntersect provides a function to get the intersection of two cell or
+// range references, it returns the intersection range reference without the
+// worksheet name and true if the references overlap, otherwise returns false.
+// The references qualified by different worksheet names never intersect, and
+// the reference which not qualified by the worksheet name will be considered
+// on the same worksheet with the other one. For example:
+//
+//	excelize.RangesIntersect("A1:C3", "'Sheet1'!$B$2:$D$4") // returns "B2:C3", true
+//	excelize.RangesIntersect("A1:A10", "3:3") // returns "A3", true
+//	excelize.RangesIntersect("A1:B2", "C3:D4") // returns "", false
+func RangesIntersect(a, b string) (string, bool) {
+	sheetA, rectA, err := parseRangeRef(a)
+	if err != nil {
+		return "", false
+	}
+	sheetB, rectB, err := parseRangeRef(b)
+	if err != nil {
+		return "", false
+	}
+	if sheetA != "" && sheetB != "" && !strings.EqualFold(sheetA, sheetB) || !isOverlap(rectA, rectB) {
+		return "", false
+	}
+	rect := []int{rectA[0], rectA[1], rectA[2], rectA[3]}
+	for i := range rect {
+		if (i < 2 && rectB[i] > rect[i]) || (i >= 2 && rectB[i] < rect[i]) {
+			rect[i] = rectB[i]
+		}
+	}
+	return rectToRangeRef(rect), true
+}
+
+// UnionRanges provides a function to get the union of the cell or range
+// references, each reference could be a space separated reference sequence.
+// It returns the space separated reference sequence without the worksheet
+// name, the references contained by other references will be removed, and the
+// adjacent or overlapped references will be merged if the merged reference is
+// still a rectangle. The worksheet names of the references and the invalid
+// references will be ignored. For example:
+//
+//	excelize.UnionRanges("A1:B2", "A3:B4 C1", "B2") // returns "A1:B4 C1"
+func UnionRanges(refs ...string) string {
+	var rects [][]int
+	for _, ref := range refs {
+		for _, field := range strings.Fields(ref) {
+			if _, rect, err := parseRangeRef(field); err == nil {
+				rects = append(rects, rect)
+			}
+		}
+	}
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(rects) && !merged; i++ {
+			for j := 0; j < len(rects) && !merged; j++ {
+				if rect, ok := unionRect(rects[i], rects[j]); i != j && ok {
+					rects[i], merged = rect, true
+					rects = append(rects[:j], rects[j+1:]...)
+				}
+			}
+		}
+	}
+	parts := make([]string, len(rects))
+	for i, rect := range rects {
+		parts[i] = rectToRangeRef(rect)
+	}
+	return strings.Join(parts, " ")
+}
+
+// unionRect provides a function to get the union of two ranges by given
+// sorted coordinates, it returns false if the union is not a rectangle.
+func unionRect(a, b []int) ([]int, bool) {
+	span := func(lo1, hi1, lo2, hi2 int) (int, int, bool) {
+		if lo1 > hi2+1 || lo2 > hi1+1 {
+			return 0, 0, false
+		}
+		if lo2 < lo1 {
+			lo1 = lo2
+		}
+		if hi2 > hi1 {
+			hi1 = hi2
+		}
+		return lo1, hi1, true
+	}
+	switch {
+	case cellInRange(b[:2], a) && cellInRange(b[2:], a):
+		return a, true
+	case cellInRange(a[:2], b) && cellInRange(a[2:], b):
+		return b, true
+	case a[0] == b[0] && a[2] == b[2]:
+		if lo, hi, ok := span(a[1], a[3], b[1], b[3]); ok {
+			return []int{a[0], lo, a[2], hi}, true
+		}
+	case a[1] == b[1] && a[3] == b[3]:
+		if lo, hi, ok := span(a[0], a[2], b[0], b[2]); ok {
+			return []int{lo, a[1], hi, a[3]}, true
+		}
+	}
+	return nil, false
+}
+
+// subtractRect provides a function to subtract the range b from the range a by
+// given sorted coordinates, it returns the remaining ranges of a.
+func subtractRect(a, b []int) [][]int {
+	if !isOverlap(a, b) {
+		return [][]int{a}
+	}
+	var (
+		rects [][]int
+		top   = a[1]
+		btm   = a[3]
+	)
+	if b[1] > a[1] {
+		rects, top = append(rects, []int{a[0], a[1], a[2], b[1] - 1}), b[1]
+	}
+	if b[3] < a[3] {
+		rects, btm = append(rects, []int{a[0], b[3] + 1, a[2], a[3]}), b[3]
+	}
+	if b[0] > a[0] {
+		rects = append(rects, []int{a[0], top, b[0] - 1, btm})
+	}
+	if b[2] < a[2] {
+		rects = append(rects, []int{b[2] + 1, top, a[2], btm})
+	}
+	return rects
+}
+
+// parseRangeRef provides a function to parse the cell reference, range
+// reference, whole columns or whole rows reference which could be qualified
+// by the worksheet name into the worksheet name and the sorted coordinates of
+// the range.
+func parseRangeRef(ref string) (string, []int, error) {
+	sheet, rng, err := SplitRangeRef(ref)
+	if err != nil {
+		return sheet, nil, err
+	}
+	refParts, ok := parseRef(rng)
+	if !ok {
+		return sheet, nil, newInvalidRangeRefError(ref)
+	}
+	first, last := refParts[0], refParts[len(refParts)-1]
+	rect := []int{first.colNum, first.rowNum, last.colNum, last.rowNum}
+	if first.colNum == 0 {
+		rect[0], rect[2] = 1, MaxColumns
+	}
+	if first.rowNum == 0 {
+		rect[1], rect[3] = 1, TotalRows
+	}
+	_ = sortCoordinates(rect)
+	return sheet, rect, err
+}
+
+// rectToRangeRef provides a function to convert the sorted coordinates of the
+// range into the range reference, it returns the cell reference if the range
+// contains only one cell.
+func rectToRangeRef(rect []int) string {
+	firstCell, _ := CoordinatesToCellName(rect[0], rect[1])
+	if rect[0] == rect[2] && rect[1] == rect[3] {
+		return firstCell
+	}
+	lastCell, _ := CoordinatesToCellName(rect[2], rect[3])
+	return firstCell + ":" + lastCell
+}
+
 // getDefinedNameRefTo convert defined name to reference range.
 func (f *File) getDefinedNameRefTo(definedNameName string, currentSheet string) (refTo string) {
 	var workbookRefTo, worksheetRefTo string
@@ -415,6 +853,106 @@ func inFloat64Slice(a []float64, x float64) int {
 	return -1
 }
 
+// quoteSheetName provides a function to quote the worksheet name in the
+// formula references, when the name contains characters other than letters,
+// digits, underscores and periods, starts with a digit or looks like a cell
+// reference. The external workbook index prefix, such as [1], will be kept
+// inside the quotes.
+func quoteSheetName(name string) string {
+	sheet := name
+	if idx := strings.LastIndex(name, "]"); strings.HasPrefix(name, "[") && idx != -1 {
+		sheet = name[idx+1:]
+	}
+	quote := sheet == "" || unicode.IsDigit([]rune(sheet)[0])
+	for _, r := range sheet {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' {
+			quote = true
+			break
+		}
+	}
+	if _, _, err := CellNameToCoordinates(sheet); err == nil {
+		quote = true
+	}
+	if !quote {
+		return name
+	}
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// replaceFormulaSheetRefs provides a function to replace the worksheet name
+// of each sheet-qualified reference in the given formula. The replace
+// function receives the unquoted worksheet name and returns the new unquoted
+// name, and whether to replace it. String literals, error values and the
+// structured references will be kept as is.
+func replaceFormulaSheetRefs(formula string, replace func(sheet string) (string, bool)) string {
+	var (
+		buf               strings.Builder
+		last, word, depth int
+		errorValues       = []string{
+			formulaErrorDIV, formulaErrorNAME, formulaErrorNA, formulaErrorNUM,
+			formulaErrorVALUE, formulaErrorREF, formulaErrorNULL, formulaErrorSPILL,
+			formulaErrorCALC, formulaErrorGETTINGDATA,
+		}
+	)
+	closeQuote := func(start int, quote byte) int {
+		for i := start; i < len(formula); i++ {
+			if formula[i] == quote {
+				if i+1 < len(formula) && formula[i+1] == quote {
+					i++
+					continue
+				}
+				return i
+			}
+		}
+		return len(formula)
+	}
+	for i := 0; i < len(formula); i++ {
+		switch c := formula[i]; {
+		case depth > 0:
+			if c == '[' {
+				depth++
+			} else if c == ']' {
+				depth--
+			}
+		case c == '"':
+			i, word = closeQuote(i+1, '"'), i+1
+		case c == '\'':
+			end := closeQuote(i+1, '\'')
+			if end+1 < len(formula) && formula[end+1] == '!' {
+				if sheet, ok := replace(strings.ReplaceAll(formula[i+1:end], "''", "'")); ok {
+					buf.WriteString(formula[last:i])
+					buf.WriteString(quoteSheetName(sheet))
+					last = end + 1
+				}
+			}
+			i, word = end, end+1
+		case c == '#' && i == word:
+			for _, errorValue := range errorValues {
+				if strings.HasPrefix(strings.ToUpper(formula[i:]), errorValue) {
+					i += len(errorValue) - 1
+					break
+				}
+			}
+			word = i + 1
+		case c == '[':
+			depth++
+		case c == '!':
+			if word < i {
+				if sheet, ok := replace(formula[word:i]); ok {
+					buf.WriteString(formula[last:word])
+					buf.WriteString(quoteSheetName(sheet))
+					last = i
+				}
+			}
+			word = i + 1
+		case strings.IndexByte(" ,;:()+-*/^&=<>{}%\r\n\t", c) != -1:
+			word = i + 1
+		}
+	}
+	buf.WriteString(formula[last:])
+	return buf.String()
+}
+
 // boolPtr returns a pointer to a bool with the given value.
 func boolPtr(b bool) *bool { return &b }
 
@@ -621,12 +1159,79 @@ func getXMLNamespace(space string, attr []xml.Attr) string {
 // replaceNameSpaceBytes provides a function to replace the XML root element
 // attribute by the given component part path and XML content.
 func (f *File) replaceNameSpaceBytes(path string, contentMarshal []byte) []byte {
+	sourceXmlns, targetXmlns := f.nameSpaceReplacement(path)
+	return bytesReplace(contentMarshal, sourceXmlns, targetXmlns, -1)
+}
+
+// nameSpaceReplacement provides a function to get the source and target XML
+// namespace declarations of the root element by given part path.
+func (f *File) nameSpaceReplacement(path string) ([]byte, []byte) {
 	sourceXmlns := []byte(`xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
 	targetXmlns := []byte(templateNamespaceIDMap)
 	if attr, ok := f.xmlAttr[path]; ok {
 		targetXmlns = []byte(genXMLNamespace(attr))
 	}
-	return bytesReplace(contentMarshal, sourceXmlns, bytes.ReplaceAll(targetXmlns, []byte(" mc:Ignorable=\"r\""), []byte{}), -1)
+	return sourceXmlns, bytes.ReplaceAll(targetXmlns, []byte(" mc:Ignorable=\"r\""), []byte{})
+}
+
+// replaceWriter wraps an io.Writer and replaces all the occurrences of the old
+// bytes with the new bytes in the written data, the tail of the data which
+// may be the beginning of the old bytes will be kept until the next writing
+// or flushing.
+type replaceWriter struct {
+	w        io.Writer
+	old, new []byte
+	buf      []byte
+}
+
+// Write implements the io.Writer interface.
+func (rw *replaceWriter) Write(p []byte) (int, error) {
+	rw.buf = append(rw.buf, p...)
+	for {
+		idx := bytes.Index(rw.buf, rw.old)
+		if idx == -1 {
+			break
+		}
+		if _, err := rw.w.Write(rw.buf[:idx]); err != nil {
+			return 0, err
+		}
+		if _, err := rw.w.Write(rw.new); err != nil {
+			return 0, err
+		}
+		rw.buf = rw.buf[idx+len(rw.old):]
+	}
+	if keep := len(rw.old) - 1; len(rw.buf) > keep {
+		if _, err := rw.w.Write(rw.buf[:len(rw.buf)-keep]); err != nil {
+			return 0, err
+		}
+		rw.buf = rw.buf[:copy(rw.buf, rw.buf[len(rw.buf)-keep:])]
+	}
+	return len(p), nil
+}
+
+// Flush writes the kept data to the underlying writer.
+func (rw *replaceWriter) Flush() error {
+	_, err := rw.w.Write(rw.buf)
+	rw.buf = rw.buf[:0]
+	return err
+}
+
+// contextWriter wraps an io.Writer, counts the written bytes and returns the
+// context error on writing when the context is done.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+	n   int64
+}
+
+// Write implements the io.Writer interface.
+func (cw *contextWriter) Write(p []byte) (int, error) {
+	if err := cw.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
 }
 
 // addNameSpaces provides a function to add an XML attribute by the given
@@ -811,3 +1416,131 @@ func (stack *Stack) Len() int {
 func (stack *Stack) Empty() bool {
 	return stack.list.Len() == 0
 }
+
+// timeType defined the type of the time.Time.
+var timeType = reflect.TypeOf(time.Time{})
+
+// durationType defined the type of the time.Duration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// structField directly maps the field of the struct which could be set as
+// the cell value by the struct field tag.
+type structField struct {
+	index  []int
+	name   string
+	format string
+}
+
+// getStructFields provides a function to get the fields of the struct type
+// by the "excel" field tags, the tag consists of the header name and the
+// optional number format, for example `excel:"Amount,format=#,##0.00"`, the
+// number format is the rest of the tag so it may contain commas. The
+// field name will be used as the header name if it was omitted, the fields
+// tagged with "-" and the unexported fields will be skipped, and the fields
+// of the embedded structs will be flattened.
+func getStructFields(typ reflect.Type, index []int) []structField {
+	var fields []structField
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("excel")
+		if tag == "-" {
+			continue
+		}
+		idx := append(append([]int{}, index...), i)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && tag == "" && fieldType.Kind() == reflect.Struct && fieldType != timeType {
+			fields = append(fields, getStructFields(fieldType, idx)...)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		f := structField{index: idx, name: field.Name}
+		if i := strings.Index(tag, ",format="); i != -1 {
+			f.format, tag = tag[i+len(",format="):], tag[:i]
+		}
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			f.name = name
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// getStructFieldValue provides a function to get the value of the struct
+// field by given index sequence, the false will be returned if the field is
+// in a nil embedded struct pointer.
+func getStructFieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, idx := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return v, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+	return v, true
+}
+
+// allocStructFieldValue provides a function to get the settable value of the
+// struct field by given index sequence, the nil embedded struct pointers will
+// be allocated, the false will be returned if the field is not settable.
+func allocStructFieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, idx := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return v, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+	return v, v.CanSet()
+}
+
+// isStructFieldTypeSupported provides a function to check if the struct field
+// type is string, bool, the numeric types, time.Time or the pointers to them.
+func isStructFieldTypeSupported(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return typ == timeType
+}
+
+// getStructFieldCellValue provides a function to get the cell value of the
+// struct field by the kind of the field, so that the values of the named
+// string, boolean and numeric types will be converted to the underlying
+// types. The time.Time and time.Duration values will be returned as is.
+func getStructFieldCellValue(value reflect.Value) interface{} {
+	if typ := value.Type(); typ == timeType || typ == durationType {
+		return value.Interface()
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint()
+	case reflect.Float32:
+		return float32(value.Float())
+	case reflect.Float64:
+		return value.Float()
+	}
+	return value.Interface()
+}
@@ -374,3 +374,168 @@ func TestUnzipToTemp(t *testing.T) {
 	_, err = f.unzipToTemp(z.File[0])
 	assert.EqualError(t, err, "EOF")
 }
+
+func TestReplaceFormulaSheetRefs(t *testing.T) {
+	replace := func(sheet string) (string, bool) {
+		if sheet == "Sheet1" {
+			return "[1]My Sheet", true
+		}
+		return sheet, sheet == "It's"
+	}
+	for formula, expected := range map[string]string{
+		"Sheet1!A1+Sheet2!B2":               "'[1]My Sheet'!A1+Sheet2!B2",
+		"SUM('Sheet1'!A1:B2,1)":             "SUM('[1]My Sheet'!A1:B2,1)",
+		"\"Sheet1!A1\"&'It''s'!A1":          "\"Sheet1!A1\"&'It''s'!A1",
+		"IFERROR(#DIV/0!,Sheet1!#REF!)":     "IFERROR(#DIV/0!,'[1]My Sheet'!#REF!)",
+		"Table1[[#This Row],[Sheet1!]]+A1":  "Table1[[#This Row],[Sheet1!]]+A1",
+		"[2]Sheet1!A1+{1,2;3,4}*Sheet1!C:C": "[2]Sheet1!A1+{1,2;3,4}*'[1]My Sheet'!C:C",
+	} {
+		assert.Equal(t, expected, replaceFormulaSheetRefs(formula, replace), formula)
+	}
+	for name, expected := range map[string]string{
+		"Sheet1": "Sheet1", "[1]Sheet1": "[1]Sheet1", "My Sheet": "'My Sheet'",
+		"It's": "'It''s'", "1Sheet": "'1Sheet'", "A1": "'A1'", "": "''",
+	} {
+		assert.Equal(t, expected, quoteSheetName(name), name)
+	}
+}
+
+func TestFormulaA1ToR1C1(t *testing.T) {
+	for formula, expected := range map[string]string{
+		"=A1+$B$2":                  "=R[-4]C[-2]+R2C2",
+		"SUM(C5:$D5)":               "SUM(RC:RC4)",
+		"Sheet2!C:C*'Sheet 3'!$5:6": "Sheet2!C:C*'Sheet 3'!R5:R[1]",
+		"IF(A1=\"B2\",TRUE,ABC)":    "IF(R[-4]C[-2]=\"B2\",TRUE,ABC)",
+		"LOG10(100)":                "LOG10(100)",
+		"Table1[[#This Row],[A]]":   "Table1[[#This Row],[A]]",
+	} {
+		result, err := FormulaA1ToR1C1(formula, "C5")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, formula)
+	}
+	// Test convert formula with invalid cell reference
+	_, err := FormulaA1ToR1C1("A1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
+func TestFormulaR1C1ToA1(t *testing.T) {
+	for formula, expected := range map[string]string{
+		"=R[-4]C[-2]+R2C2":                 "=A1+$B$2",
+		"SUM(RC:RC4)":                      "SUM(C5:$D5)",
+		"R[-1]C5+R2":                       "$E4+$2:$2",
+		"SUM(C[-1]:C,R:R[2])":              "SUM(B:C,5:7)",
+		"Sheet2!C*'Sheet 3'!R5:R[1]":       "Sheet2!C:C*'Sheet 3'!$5:6",
+		"IF(r[-4]c[-2]=\"RC\",TRUE,ROW())": "IF(A1=\"RC\",TRUE,ROW())",
+		"COUNT(Table1[[#This Row],[R]])":   "COUNT(Table1[[#This Row],[R]])",
+		"[1]Sheet1!R1C1&'It''s'!RC":        "[1]Sheet1!$A$1&'It''s'!C5",
+		"RC_1+RC.1+RC(1)+RCA":              "RC_1+RC.1+RC(1)+RCA",
+	} {
+		result, err := FormulaR1C1ToA1(formula, "C5")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, formula)
+	}
+	// Test round-trip conversion
+	for _, formula := range []string{"SUM(R[-4]C[-2]:R2C2)", "R[-1]C5+R2:R2", "C[-1]:C"} {
+		a1, err := FormulaR1C1ToA1(formula, "C5")
+		assert.NoError(t, err)
+		r1c1, err := FormulaA1ToR1C1(a1, "C5")
+		assert.NoError(t, err)
+		assert.Equal(t, formula, r1c1)
+	}
+	// Test convert formula with the reference out of range
+	for _, formula := range []string{"R[-5]C", "RC[-3]", "R1048577C1", "R1C0"} {
+		_, err := FormulaR1C1ToA1(formula, "C5")
+		assert.EqualError(t, err, newInvalidR1C1RefError(formula).Error())
+	}
+	// Test convert formula with invalid cell reference
+	_, err := FormulaR1C1ToA1("RC", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
+func TestSplitRangeRef(t *testing.T) {
+	for _, c := range []struct{ ref, sheet, rng string }{
+		{"A1:B2", "", "A1:B2"},
+		{"Sheet1!$A$1", "Sheet1", "$A$1"},
+		{"'Sheet 1'!A1:B2", "Sheet 1", "A1:B2"},
+		{"'It''s'!A:A", "It's", "A:A"},
+		{"'a!b'!1:2", "a!b", "1:2"},
+	} {
+		sheet, rng, err := SplitRangeRef(c.ref)
+		assert.NoError(t, err, c.ref)
+		assert.Equal(t, c.sheet, sheet, c.ref)
+		assert.Equal(t, c.rng, rng, c.ref)
+	}
+	for _, ref := range []string{"", "!A1", "Sheet1!", "'Sheet1!A1", "''!A1", "'It's'!A1"} {
+		_, _, err := SplitRangeRef(ref)
+		assert.EqualError(t, err, newInvalidRangeRefError(ref).Error(), ref)
+	}
+}
+
+func TestRangeIterator(t *testing.T) {
+	next, err := RangeIterator("'Sheet 1'!$B$3:A2")
+	assert.NoError(t, err)
+	var cells [][]int
+	for col, row, ok := next(); ok; col, row, ok = next() {
+		cells = append(cells, []int{col, row})
+	}
+	assert.Equal(t, [][]int{{1, 2}, {2, 2}, {1, 3}, {2, 3}}, cells)
+	_, _, ok := next()
+	assert.False(t, ok)
+
+	next, err = RangeIterator("C5")
+	assert.NoError(t, err)
+	col, row, ok := next()
+	assert.Equal(t, []interface{}{3, 5, true}, []interface{}{col, row, ok})
+	_, _, ok = next()
+	assert.False(t, ok)
+
+	next, err = RangeIterator("2:2")
+	assert.NoError(t, err)
+	var count int
+	for _, _, ok := next(); ok; _, _, ok = next() {
+		count++
+	}
+	assert.Equal(t, MaxColumns, count)
+
+	_, err = RangeIterator("A1:B")
+	assert.EqualError(t, err, newInvalidRangeRefError("A1:B").Error())
+	_, err = RangeIterator("Sheet1!")
+	assert.EqualError(t, err, newInvalidRangeRefError("Sheet1!").Error())
+}
+
+func TestRangesIntersect(t *testing.T) {
+	for _, c := range []struct {
+		a, b, expected string
+		ok             bool
+	}{
+		{"A1:C3", "'Sheet1'!$B$2:$D$4", "B2:C3", true},
+		{"Sheet1!A1:C3", "sheet1!C3:D4", "C3", true},
+		{"A1:A10", "3:3", "A3", true},
+		{"B:C", "A2:D3", "B2:C3", true},
+		{"A1:B2", "C3:D4", "", false},
+		{"Sheet1!A1:B2", "Sheet2!A1:B2", "", false},
+		{"A1:B2", "A1:", "", false},
+		{"A", "A1", "", false},
+	} {
+		ref, ok := RangesIntersect(c.a, c.b)
+		assert.Equal(t, c.expected, ref, c.a, c.b)
+		assert.Equal(t, c.ok, ok, c.a, c.b)
+	}
+}
+
+func TestUnionRanges(t *testing.T) {
+	for _, c := range []struct {
+		refs     []string
+		expected string
+	}{
+		{[]string{"A1:B2", "A3:B4 C1", "B2"}, "A1:B4 C1"},
+		{[]string{"A1", "B1", "C1", "A2:C2"}, "A1:C2"},
+		{[]string{"A1:B2", "C3:D4"}, "A1:B2 C3:D4"},
+		{[]string{"A1:B3", "B2:C3"}, "A1:B3 B2:C3"},
+		{[]string{"Sheet1!A1:A5", "A4:A8", "invalid"}, "A1:A8"},
+		{[]string{"A:A", "B:B"}, "A1:B1048576"},
+		{nil, ""},
+	} {
+		assert.Equal(t, c.expected, UnionRanges(c.refs...), c.refs)
+	}
+}
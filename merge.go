@@ -11,7 +11,10 @@
 
 package excelize
 
-import "strings"
+import (
+	"sort"
+	"strings"
+)
 
 // Rect gets merged cell rectangle coordinates sequence.
 func (mc *xlsxMergeCell) Rect() ([]int, error) {
@@ -26,6 +29,124 @@ func (mc *xlsxMergeCell) Rect() ([]int, error) {
 	return mc.rect, err
 }
 
+// mergeCellsIndex directly maps the interval index of the merged cells in a
+// worksheet. The merged cells are sorted by the start row, and the maximum
+// end row of the merged cells up to each position are recorded for pruning
+// the candidates on lookup. The index will be rebuilt once the merged cells
+// of the worksheet has been changed.
+type mergeCellsIndex struct {
+	mergeCells *xlsxMergeCells
+	count      int
+	cells      []*xlsxMergeCell
+	maxRows    []int
+}
+
+// resetMergeCellsIndex provides a function to invalidate the merged cells
+// index of the worksheet.
+func (ws *xlsxWorksheet) resetMergeCellsIndex() {
+	ws.mergeCellsIdx = nil
+}
+
+// mergeCellsIndexer provides a function to get the merged cells index of the
+// worksheet, the index will be built if it does not exist or out of date.
+func (ws *xlsxWorksheet) mergeCellsIndexer() (*mergeCellsIndex, error) {
+	if ws.MergeCells == nil {
+		return nil, nil
+	}
+	if idx := ws.mergeCellsIdx; idx != nil && idx.mergeCells == ws.MergeCells &&
+		idx.count == len(ws.MergeCells.Cells) {
+		return idx, nil
+	}
+	idx := &mergeCellsIndex{mergeCells: ws.MergeCells}
+	for i := 0; i < len(ws.MergeCells.Cells); i++ {
+		mergeCell := ws.MergeCells.Cells[i]
+		if mergeCell == nil {
+			ws.MergeCells.Cells = append(ws.MergeCells.Cells[:i], ws.MergeCells.Cells[i+1:]...)
+			i--
+			continue
+		}
+		if mergeCell.Ref == "" {
+			continue
+		}
+		rect, err := mergeCell.Rect()
+		if err != nil {
+			return nil, err
+		}
+		_ = sortCoordinates(rect)
+		idx.cells = append(idx.cells, mergeCell)
+	}
+	sort.SliceStable(idx.cells, func(i, j int) bool {
+		return idx.cells[i].rect[1] < idx.cells[j].rect[1]
+	})
+	idx.maxRows = make([]int, len(idx.cells))
+	for i, mergeCell := range idx.cells {
+		idx.maxRows[i] = mergeCell.rect[3]
+		if i > 0 && idx.maxRows[i-1] > idx.maxRows[i] {
+			idx.maxRows[i] = idx.maxRows[i-1]
+		}
+	}
+	idx.count = len(ws.MergeCells.Cells)
+	ws.mergeCellsIdx = idx
+	return idx, nil
+}
+
+// overlapCells returns the merged cells which overlaps with the given
+// rectangle coordinates, the returned merged cells are sorted by the start
+// row in descending order.
+func (idx *mergeCellsIndex) overlapCells(rect []int) []*xlsxMergeCell {
+	var cells []*xlsxMergeCell
+	if idx == nil {
+		return cells
+	}
+	i := sort.Search(len(idx.cells), func(i int) bool {
+		return idx.cells[i].rect[1] > rect[3]
+	})
+	for i--; i >= 0 && idx.maxRows[i] >= rect[1]; i-- {
+		if isOverlap(rect, idx.cells[i].rect) {
+			cells = append(cells, idx.cells[i])
+		}
+	}
+	return cells
+}
+
+// getMergeCell provides a function to get the merged cells which contains the
+// given cell coordinates, and returns nil if the cell not in any merged cells.
+func (ws *xlsxWorksheet) getMergeCell(col, row int) (*xlsxMergeCell, error) {
+	idx, err := ws.mergeCellsIndexer()
+	if err != nil {
+		return nil, err
+	}
+	if cells := idx.overlapCells([]int{col, row, col, row}); len(cells) > 0 {
+		return cells[len(cells)-1], err
+	}
+	return nil, err
+}
+
+// GetCellMergeRange provides a function to get the range reference of the
+// merged cells which contains the given cell by given worksheet name and
+// cell reference. The second returned value will be false if the cell is not
+// in any merged cells. For example, get the merged range contains C5 on
+// Sheet1:
+//
+//	ref, ok, err := f.GetCellMergeRange("Sheet1", "C5")
+func (f *File) GetCellMergeRange(sheet, cell string) (string, bool, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return "", false, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return "", false, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	mergeCell, err := ws.getMergeCell(col, row)
+	if err != nil || mergeCell == nil {
+		return "", false, err
+	}
+	return mergeCell.Ref, true, err
+}
+
 // MergeCell provides a function to merge cells by given range reference and
 // sheet name. Merging cells only keeps the upper-left cell value, and
 // discards the other values. For example create a merged cell of D3:E9 on
@@ -34,22 +155,12 @@ func (mc *xlsxMergeCell) Rect() ([]int, error) {
 //	err := f.MergeCell("Sheet1", "D3", "E9")
 //
 // If you create a merged cell that overlaps with another existing merged cell,
-// those merged cells that already exist will be removed. The cell references
-// tuple after merging in the following range will be: A1(x3,y1) D1(x2,y1)
-// A8(x3,y4) D8(x2,y4)
-//
-//	             B1(x1,y1)      D1(x2,y1)
-//	           +------------------------+
-//	           |                        |
-//	A4(x3,y3)  |    C4(x4,y3)           |
-//	+------------------------+          |
-//	|          |             |          |
-//	|          |B5(x1,y2)    | D5(x2,y2)|
-//	|          +------------------------+
-//	|                        |
-//	|A8(x3,y4)      C8(x4,y4)|
-//	+------------------------+
+// an ErrMergeCellOverlap error will be returned, please unmerge the existing
+// merged cell by the UnmergeCell function before merging the cells.
 func (f *File) MergeCell(sheet, hCell, vCell string) error {
+	if err := f.checkReadOnly("MergeCell"); err != nil {
+		return err
+	}
 	rect, err := rangeRefToCoordinates(hCell + ":" + vCell)
 	if err != nil {
 		return err
@@ -67,6 +178,14 @@ func (f *File) MergeCell(sheet, hCell, vCell string) error {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 	ref := hCell + ":" + vCell
+	idx, err := ws.mergeCellsIndexer()
+	if err != nil {
+		return err
+	}
+	if cells := idx.overlapCells(rect); len(cells) > 0 {
+		return ErrMergeCellOverlap{Ref: ref, OverlapRef: cells[len(cells)-1].Ref}
+	}
+	ws.resetMergeCellsIndex()
 	if ws.MergeCells != nil {
 		ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: ref, rect: rect})
 	} else {
@@ -81,8 +200,15 @@ func (f *File) MergeCell(sheet, hCell, vCell string) error {
 //
 //	err := f.UnmergeCell("Sheet1", "D3", "E9")
 //
+// Unmerge the merged cells which contains the cell C5 on Sheet1:
+//
+//	err := f.UnmergeCell("Sheet1", "C5", "C5")
+//
 // Attention: overlapped range will also be unmerged.
 func (f *File) UnmergeCell(sheet, hCell, vCell string) error {
+	if err := f.checkReadOnly("UnmergeCell"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -104,6 +230,7 @@ func (f *File) UnmergeCell(sheet, hCell, vCell string) error {
 	if err = f.mergeOverlapCells(ws); err != nil {
 		return err
 	}
+	ws.resetMergeCellsIndex()
 	i := 0
 	for _, mergeCell := range ws.MergeCells.Cells {
 		if mergeCell == nil {
@@ -242,6 +369,7 @@ func (f *File) mergeOverlapCells(ws *xlsxWorksheet) error {
 		}
 	}
 	ws.MergeCells.Count, ws.MergeCells.Cells = len(mergeCells), mergeCells
+	ws.resetMergeCellsIndex()
 	return nil
 }
 
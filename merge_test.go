@@ -2,6 +2,7 @@ package excelize
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,22 +16,29 @@ func TestMergeCell(t *testing.T) {
 	assert.EqualError(t, f.MergeCell("Sheet1", "A", "B"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 	for _, cells := range [][]string{
 		{"D9", "D9"},
-		{"D9", "E9"},
 		{"H14", "G13"},
-		{"C9", "D8"},
-		{"F11", "G13"},
-		{"H7", "B15"},
+		{"C9", "C8"},
 		{"D11", "F13"},
 		{"G10", "K12"},
 	} {
 		assert.NoError(t, f.MergeCell("Sheet1", cells[0], cells[1]))
 	}
+	// Test merge cells which overlaps with the existing merged cells
+	for _, cells := range [][]string{
+		{"D9", "E9", "D9:E9", "D9:D9"},
+		{"C9", "D8", "C8:D9", "C8:C9"},
+		{"F11", "G13", "F11:G13", "G10:K12"},
+		{"H7", "B15", "B7:H15", "C8:C9"},
+		{"A12", "L12", "A12:L12", "G10:K12"},
+	} {
+		assert.Equal(t, ErrMergeCellOverlap{Ref: cells[2], OverlapRef: cells[3]}, f.MergeCell("Sheet1", cells[0], cells[1]))
+	}
 	assert.NoError(t, f.SetCellValue("Sheet1", "G11", "set value in merged cell"))
-	assert.NoError(t, f.SetCellInt("Sheet1", "H11", 100))
+	assert.NoError(t, f.SetCellInt("Sheet1", "H13", 100))
 	assert.NoError(t, f.SetCellValue("Sheet1", "I11", 0.5))
 	assert.NoError(t, f.SetCellHyperLink("Sheet1", "J11", "https://github.com/xuri/excelize", "External"))
 	assert.NoError(t, f.SetCellFormula("Sheet1", "G12", "SUM(Sheet1!B19,Sheet1!C19)"))
-	value, err := f.GetCellValue("Sheet1", "H11")
+	value, err := f.GetCellValue("Sheet1", "H14")
 	assert.Equal(t, "100", value)
 	assert.NoError(t, err)
 	// Merged cell ref is single coordinate
@@ -47,25 +55,32 @@ func TestMergeCell(t *testing.T) {
 	for _, cells := range [][]string{
 		{"D11", "F13"},
 		{"G10", "K12"},
-		{"B1", "D5"}, // B1:D5
-		{"E1", "F5"}, // E1:F5
+		{"B1", "D5"},
+		{"E1", "F5"},
 		{"H2", "I5"},
-		{"I4", "J6"}, // H2:J6
 		{"M2", "N5"},
-		{"L4", "M6"}, // L2:N6
 		{"P4", "Q7"},
-		{"O2", "P5"}, // O2:Q7
 		{"A9", "B12"},
-		{"B7", "C9"}, // A7:C12
+		{"I8", "I9"},
 		{"E9", "F10"},
+	} {
+		assert.NoError(t, f.MergeCell("Sheet3", cells[0], cells[1]))
+	}
+	for _, cells := range [][]string{
+		{"I4", "J6"},
+		{"L4", "M6"},
+		{"O2", "P5"},
+		{"B7", "C9"},
 		{"D8", "G12"},
-		{"I8", "I12"},
 		{"I10", "K10"},
-		{"M8", "Q13"},
-		{"N10", "O11"},
+		{"H8", "J8"},
 	} {
-		assert.NoError(t, f.MergeCell("Sheet3", cells[0], cells[1]))
+		err := f.MergeCell("Sheet3", cells[0], cells[1])
+		assert.IsType(t, ErrMergeCellOverlap{}, err, cells)
 	}
+	mergeCells, err := f.GetMergeCells("Sheet3")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 10)
 
 	// Test merge cells on not exists worksheet
 	assert.EqualError(t, f.MergeCell("SheetN", "N10", "O11"), "sheet SheetN does not exist")
@@ -92,7 +107,11 @@ func TestMergeCell(t *testing.T) {
 func TestMergeCellOverlap(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C2"))
-	assert.NoError(t, f.MergeCell("Sheet1", "B2", "D3"))
+	assert.EqualError(t, f.MergeCell("Sheet1", "B2", "D3"), "the merged range B2:D3 overlaps with the existing merged range A1:C2")
+	// Test save the workbook with the overlapped merged cells
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells = append(ws.(*xlsxWorksheet).MergeCells.Cells, &xlsxMergeCell{Ref: "B2:D3"})
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMergeCellOverlap.xlsx")))
 
 	f, err := OpenFile(filepath.Join("test", "TestMergeCellOverlap.xlsx"))
@@ -209,6 +228,60 @@ func TestUnmergeCell(t *testing.T) {
 	assert.EqualError(t, f.UnmergeCell("Sheet1", "A2", "B3"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 }
 
+func TestGetCellMergeRange(t *testing.T) {
+	f := NewFile()
+	for _, ref := range []string{"A1:B2", "D1:D10", "A5:C6", "F3:F3", "E8:F9"} {
+		cells := strings.Split(ref, ":")
+		assert.NoError(t, f.MergeCell("Sheet1", cells[0], cells[1]))
+	}
+	for cell, expected := range map[string]string{
+		"A1": "A1:B2", "B2": "A1:B2", "D5": "D1:D10", "D10": "D1:D10",
+		"C5": "A5:C6", "F3": "F3:F3", "E9": "E8:F9", "D8": "D1:D10",
+	} {
+		ref, ok, err := f.GetCellMergeRange("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, ok, cell)
+		assert.Equal(t, expected, ref, cell)
+	}
+	for _, cell := range []string{"C1", "A3", "D11", "F2", "A8", "XFD1048576"} {
+		ref, ok, err := f.GetCellMergeRange("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.False(t, ok, cell)
+		assert.Empty(t, ref, cell)
+	}
+	// Test the index will be invalidated after unmerge cells by a cell in
+	// the merged range
+	assert.NoError(t, f.UnmergeCell("Sheet1", "C5", "C5"))
+	_, ok, err := f.GetCellMergeRange("Sheet1", "C5")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, f.MergeCell("Sheet1", "A5", "C5"))
+	ref, ok, err := f.GetCellMergeRange("Sheet1", "B5")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "A5:C5", ref)
+	// Test the index will be invalidated after insert rows
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 2))
+	ref, ok, err = f.GetCellMergeRange("Sheet1", "B7")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "A7:C7", ref)
+	// Test get merged range with invalid cell reference
+	_, _, err = f.GetCellMergeRange("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get merged range on not exists worksheet
+	_, _, err = f.GetCellMergeRange("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get merged range with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{nil, {Ref: "A:A"}}}
+	_, _, err = f.GetCellMergeRange("Sheet1", "A1")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	assert.EqualError(t, f.MergeCell("Sheet1", "A1", "B1"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
 func TestFlatMergedCells(t *testing.T) {
 	ws := &xlsxWorksheet{MergeCells: &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: ""}}}}
 	assert.EqualError(t, flatMergedCells(ws, [][]*xlsxMergeCell{}), "cannot convert cell \"\" to coordinates: invalid cell name \"\"")
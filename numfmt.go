@@ -4702,6 +4702,67 @@ func (f *File) getBuiltInNumFmtCode(numFmtID int) (string, bool) {
 	return "", false
 }
 
+// isDateTimeNumFmt provides a function to check if the given number format
+// code contains the date or time tokens.
+func isDateTimeNumFmt(numFmt string) bool {
+	p := nfp.NumberFormatParser()
+	for _, section := range p.Parse(numFmt) {
+		for _, token := range section.Items {
+			if inStrSlice(supportedDateTimeTokenTypes, token.TType, true) != -1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDateTimeStyle provides a function to check if the number format of the
+// given cell style index is a date or time number format. The result will be
+// cached by the style index, since the number format of the existing cell
+// style will not be changed.
+func (f *File) isDateTimeStyle(styleIdx int) bool {
+	styleSheet, err := f.stylesReader()
+	if err != nil || styleSheet.CellXfs == nil || styleIdx <= 0 {
+		return false
+	}
+	styleSheet.mu.Lock()
+	defer styleSheet.mu.Unlock()
+	if styleIdx >= len(styleSheet.CellXfs.Xf) {
+		return false
+	}
+	if dateTime, ok := styleSheet.dateTimeStyles[styleIdx]; ok {
+		return dateTime
+	}
+	if styleSheet.dateTimeStyles == nil {
+		styleSheet.dateTimeStyles = make(map[int]bool)
+	}
+	dateTime := f.isDateTimeNumFmtID(styleSheet, styleSheet.CellXfs.Xf[styleIdx].NumFmtID)
+	styleSheet.dateTimeStyles[styleIdx] = dateTime
+	return dateTime
+}
+
+// isDateTimeNumFmtID provides a function to check if the given built-in or
+// custom number format ID is a date or time number format.
+func (f *File) isDateTimeNumFmtID(styleSheet *xlsxStyleSheet, numFmtID *int) bool {
+	if numFmtID == nil {
+		return false
+	}
+	if fmtCode, ok := f.getBuiltInNumFmtCode(*numFmtID); ok {
+		return isDateTimeNumFmt(fmtCode)
+	}
+	if styleSheet.NumFmts != nil {
+		for _, numFmt := range styleSheet.NumFmts.NumFmt {
+			if numFmt.NumFmtID == *numFmtID {
+				if numFmt.FormatCode16 != "" {
+					return isDateTimeNumFmt(numFmt.FormatCode16)
+				}
+				return isDateTimeNumFmt(numFmt.FormatCode)
+			}
+		}
+	}
+	return false
+}
+
 // prepareNumberic split the number into two before and after parts by a
 // decimal point.
 func (nf *numberFormat) prepareNumberic(value string) {
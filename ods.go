@@ -0,0 +1,340 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"github.com/xuri/excelize/v2/xencoding/xml"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// odsDurationRegexp defined the regular expression for parsing the ISO 8601
+// duration of the time cell value in the OpenDocument spreadsheet.
+var odsDurationRegexp = regexp.MustCompile(`^(-)?P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// odsReader directly maps the state of converting the OpenDocument
+// spreadsheet into the workbook.
+type odsReader struct {
+	f          *File
+	sheet      string
+	sheetCount int
+	row        int
+	rowRepeat  int
+	cells      []odsTableCell
+	dataStyles map[string]string
+	numFmts    map[string]string
+	styleIDs   map[string]int
+}
+
+// isOpenDocument provides a function to check if the files of the package is
+// an OpenDocument spreadsheet by the mimetype part.
+func isOpenDocument(files map[string][]byte) bool {
+	return string(bytes.TrimSpace(files["mimetype"])) == mimeTypeODS
+}
+
+// openODS provides a function to convert the OpenDocument spreadsheet into the
+// workbook by given files of the package, the cell values, value types, number
+// formats and merged cells will be converted.
+func openODS(files map[string][]byte, opts *Options) (*File, error) {
+	r := &odsReader{
+		f:          NewFile(*opts),
+		dataStyles: make(map[string]string),
+		numFmts:    make(map[string]string),
+		styleIDs:   make(map[string]int),
+	}
+	for _, name := range []string{"styles.xml", "content.xml"} {
+		if err := r.decode(files[name]); err != nil {
+			return nil, err
+		}
+	}
+	return r.f, nil
+}
+
+// decode provides a function to decode the XML part of the OpenDocument
+// spreadsheet and write the tables into the workbook.
+func (r *odsReader) decode(content []byte) error {
+	decoder := r.f.xmlNewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if element, ok := token.(xml.EndElement); ok {
+			if element.Name == (xml.Name{Space: nameSpaceODSTable, Local: "table-row"}) {
+				if err = r.writeRow(); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Space {
+		case nameSpaceODSTable:
+			err = r.decodeTableElement(decoder, start)
+		case nameSpaceODSStyle:
+			if start.Name.Local == "style" {
+				var style odsStyle
+				if err = decoder.DecodeElement(&style, &start); err == nil && style.Family == "table-cell" {
+					r.dataStyles[style.Name] = style.DataStyleName
+				}
+			}
+		case nameSpaceODSNumber:
+			switch start.Name.Local {
+			case "number-style", "percentage-style", "currency-style":
+				var style odsNumberStyle
+				if err = decoder.DecodeElement(&style, &start); err == nil {
+					r.numFmts[style.Name] = style.numFmt()
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// decodeTableElement provides a function to decode the table, table row and
+// table cell elements of the OpenDocument spreadsheet.
+func (r *odsReader) decodeTableElement(decoder *xml.Decoder, start xml.StartElement) error {
+	switch start.Name.Local {
+	case "table":
+		var name string
+		for _, attr := range start.Attr {
+			if attr.Name == (xml.Name{Space: nameSpaceODSTable, Local: "name"}) {
+				name = attr.Value
+			}
+		}
+		return r.newSheet(name)
+	case "table-row":
+		r.cells, r.rowRepeat = r.cells[:0], 1
+		for _, attr := range start.Attr {
+			if attr.Name == (xml.Name{Space: nameSpaceODSTable, Local: "number-rows-repeated"}) {
+				r.rowRepeat = odsRepeated(attr.Value)
+			}
+		}
+	case "table-cell", "covered-table-cell":
+		var cell odsTableCell
+		if err := decoder.DecodeElement(&cell, &start); err != nil {
+			return err
+		}
+		r.cells = append(r.cells, cell)
+	}
+	return nil
+}
+
+// newSheet provides a function to create the worksheet for the table by given
+// table name, the default worksheet of the workbook will be renamed for the
+// first table.
+func (r *odsReader) newSheet(name string) error {
+	r.sheetCount++
+	if name == "" {
+		name = "Sheet" + strconv.Itoa(r.sheetCount)
+	}
+	r.sheet, r.row = name, 1
+	if r.sheetCount == 1 {
+		return r.f.SetSheetName(r.f.GetSheetName(0), name)
+	}
+	_, err := r.f.NewSheet(name)
+	return err
+}
+
+// writeRow provides a function to write the cells of the decoded table row
+// into the worksheet.
+func (r *odsReader) writeRow() error {
+	var empty = true
+	for _, cell := range r.cells {
+		if !cell.isEmpty() {
+			empty = false
+			break
+		}
+	}
+	defer func() { r.row += r.rowRepeat }()
+	if empty || r.sheet == "" {
+		return nil
+	}
+	for row := r.row; row < r.row+r.rowRepeat && row <= TotalRows; row++ {
+		col := 1
+		for _, cell := range r.cells {
+			repeat := odsRepeated(cell.ColumnsRepeated)
+			for c := col; c < col+repeat && c <= MaxColumns && !cell.isEmpty(); c++ {
+				if err := r.writeCell(c, row, &cell); err != nil {
+					return err
+				}
+			}
+			col += repeat
+		}
+	}
+	return nil
+}
+
+// writeCell provides a function to write the cell value, number format and
+// merged cell into the worksheet by given coordinates and table cell.
+func (r *odsReader) writeCell(col, row int, cell *odsTableCell) error {
+	ref, err := CoordinatesToCellName(col, row)
+	if err != nil {
+		return err
+	}
+	if err = r.setCellValue(ref, cell); err != nil {
+		return err
+	}
+	colSpan, rowSpan := odsRepeated(cell.ColumnsSpanned), odsRepeated(cell.RowsSpanned)
+	if colSpan == 1 && rowSpan == 1 {
+		return nil
+	}
+	bottomRight, err := CoordinatesToCellName(col+colSpan-1, row+rowSpan-1)
+	if err != nil {
+		return err
+	}
+	return r.f.MergeCell(r.sheet, ref, bottomRight)
+}
+
+// setCellValue provides a function to set the cell value and number format by
+// given cell reference and table cell.
+func (r *odsReader) setCellValue(ref string, cell *odsTableCell) error {
+	switch cell.ValueType {
+	case "float", "currency", "percentage":
+		value, err := strconv.ParseFloat(cell.Value, 64)
+		if err != nil {
+			return err
+		}
+		if err = r.f.SetCellFloat(r.sheet, ref, value, -1, 64); err != nil {
+			return err
+		}
+		if numFmt := r.numFmts[r.dataStyles[cell.StyleName]]; numFmt != "" {
+			return r.setCellNumFmt(ref, 0, numFmt)
+		}
+		if cell.ValueType == "percentage" {
+			return r.setCellNumFmt(ref, 10, "")
+		}
+		return nil
+	case "date":
+		date, err := time.Parse("2006-01-02T15:04:05", cell.DateValue)
+		if err != nil {
+			if date, err = time.Parse("2006-01-02", cell.DateValue); err != nil {
+				return err
+			}
+			if err = r.f.SetCellValue(r.sheet, ref, date); err != nil {
+				return err
+			}
+			return r.setCellNumFmt(ref, 14, "")
+		}
+		if err = r.f.SetCellValue(r.sheet, ref, date); err != nil {
+			return err
+		}
+		return r.setCellNumFmt(ref, 22, "")
+	case "time":
+		value, err := parseODSDuration(cell.TimeValue)
+		if err != nil {
+			return err
+		}
+		if err = r.f.SetCellFloat(r.sheet, ref, value, -1, 64); err != nil {
+			return err
+		}
+		if value >= 1 || value < 0 {
+			return r.setCellNumFmt(ref, 46, "")
+		}
+		return r.setCellNumFmt(ref, 21, "")
+	case "boolean":
+		return r.f.SetCellBool(r.sheet, ref, cell.BooleanValue == "true")
+	}
+	if cell.StringValue != "" {
+		return r.f.SetCellStr(r.sheet, ref, cell.StringValue)
+	}
+	paragraphs := make([]string, len(cell.Paragraphs))
+	for i, p := range cell.Paragraphs {
+		paragraphs[i] = p.Text
+	}
+	return r.f.SetCellStr(r.sheet, ref, strings.Join(paragraphs, "\n"))
+}
+
+// setCellNumFmt provides a function to set the built-in or custom number
+// format of the cell by given cell reference.
+func (r *odsReader) setCellNumFmt(ref string, numFmt int, customNumFmt string) error {
+	key := strconv.Itoa(numFmt) + customNumFmt
+	styleID, ok := r.styleIDs[key]
+	if !ok {
+		style := &Style{NumFmt: numFmt}
+		if customNumFmt != "" {
+			style.CustomNumFmt = &customNumFmt
+		}
+		var err error
+		if styleID, err = r.f.NewStyle(style); err != nil {
+			return err
+		}
+		r.styleIDs[key] = styleID
+	}
+	return r.f.SetCellStyle(r.sheet, ref, ref, styleID)
+}
+
+// isEmpty provides a function to check if the table cell has no value.
+func (c *odsTableCell) isEmpty() bool {
+	return c.XMLName.Local != "table-cell" || (c.ValueType == "" && c.StringValue == "" && len(c.Paragraphs) == 0)
+}
+
+// numFmt provides a function to convert the number style of the
+// OpenDocument spreadsheet into the number format code.
+func (s *odsNumberStyle) numFmt() string {
+	if s.Number == nil {
+		return ""
+	}
+	numFmt := "0"
+	if s.Number.Grouping {
+		numFmt = "#,##0"
+	}
+	if places, _ := strconv.Atoi(s.Number.DecimalPlaces); places > 0 {
+		numFmt += "." + strings.Repeat("0", places)
+	}
+	if s.XMLName.Local == "percentage-style" {
+		numFmt += "%"
+	}
+	return numFmt
+}
+
+// odsRepeated provides a function to parse the number of the repeated or
+// spanned rows or columns, the default value is 1.
+func odsRepeated(value string) int {
+	if n, err := strconv.Atoi(value); err == nil && n > 1 {
+		return n
+	}
+	return 1
+}
+
+// parseODSDuration provides a function to convert the ISO 8601 duration of
+// the time cell value in the OpenDocument spreadsheet into the fraction of
+// days.
+func parseODSDuration(value string) (float64, error) {
+	matches := odsDurationRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, newInvalidODSTimeError(value)
+	}
+	var seconds float64
+	for i, unit := range []float64{86400, 3600, 60, 1} {
+		if matches[i+2] != "" {
+			n, _ := strconv.ParseFloat(matches[i+2], 64)
+			seconds += n * unit
+		}
+	}
+	if matches[1] == "-" {
+		seconds = -seconds
+	}
+	return seconds / 86400, nil
+}
@@ -0,0 +1,109 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// prepareODS provides a function to create the OpenDocument spreadsheet
+// package by given styles and content of the body.
+func prepareODS(t *testing.T, styles, body string) *bytes.Buffer {
+	const root = `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0">`
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"mimetype":    mimeTypeODS,
+		"content.xml": root + `<office:automatic-styles>` + styles + `</office:automatic-styles><office:body><office:spreadsheet>` + body + `</office:spreadsheet></office:body></office:document-content>`,
+	} {
+		fw, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf
+}
+
+func TestOpenODS(t *testing.T) {
+	styles := `<number:number-style style:name="N2"><number:number number:decimal-places="2" number:grouping="true"/></number:number-style>` +
+		`<number:percentage-style style:name="N3"><number:number number:decimal-places="1"/><number:text>%</number:text></number:percentage-style>` +
+		`<style:style style:name="ce1" style:family="table-cell" style:data-style-name="N2"/>` +
+		`<style:style style:name="ce2" style:family="table-cell" style:data-style-name="N3"/>`
+	body := `<table:table table:name="Data">` +
+		`<table:table-row><table:table-cell office:value-type="string"><text:p>Name</text:p></table:table-cell><table:table-cell table:number-columns-spanned="2" office:value-type="string"><text:p>Merged</text:p></table:table-cell><table:covered-table-cell/></table:table-row>` +
+		`<table:table-row><table:table-cell office:value-type="string"><text:p>a<text:s text:c="2"/>b</text:p><text:p>c</text:p></table:table-cell><table:table-cell office:value-type="float" office:value="1234.5" table:style-name="ce1"/><table:table-cell office:value-type="percentage" office:value="0.125" table:style-name="ce2"/></table:table-row>` +
+		`<table:table-row><table:table-cell office:value-type="date" office:date-value="2023-05-01"/><table:table-cell office:value-type="date" office:date-value="2023-05-01T08:30:00"/><table:table-cell office:value-type="time" office:time-value="PT12H00M00S"/><table:table-cell office:value-type="boolean" office:boolean-value="true"/></table:table-row>` +
+		`<table:table-row table:number-rows-repeated="2"><table:table-cell/><table:table-cell table:number-columns-repeated="2" office:value-type="float" office:value="7"/></table:table-row>` +
+		`<table:table-row table:number-rows-repeated="1048570"><table:table-cell table:number-columns-repeated="16384"/></table:table-row>` +
+		`</table:table><table:table table:name="Second"><table:table-row><table:table-cell office:value-type="percentage" office:value="0.5"/></table:table-row></table:table>`
+	f, err := OpenReader(prepareODS(t, styles, body))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Data", "Second"}, f.GetSheetList())
+	rows, err := f.GetRows("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Merged"},
+		{"a  b\nc", "1,234.50", "12.5%"},
+		{"05-01-23", "5/1/23 08:30", "12:00:00", "TRUE"},
+		{"", "7", "7"},
+		{"", "7", "7"},
+	}, rows)
+	mergeCells, err := f.GetMergeCells("Data")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "B1:C1", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	raw, err := f.GetCellValue("Data", "A3", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "45047", raw)
+	raw, err = f.GetCellValue("Data", "C3", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.5", raw)
+	cellValue, err := f.GetCellValue("Second", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "50.00%", cellValue)
+
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	cellValue, err = f.GetCellValue("Data", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "1,234.50", cellValue)
+	assert.NoError(t, f.Close())
+
+	// Test open the OpenDocument spreadsheet with invalid cell values
+	for _, cell := range []string{
+		`<table:table-cell office:value-type="float" office:value="x"/>`,
+		`<table:table-cell office:value-type="date" office:date-value="x"/>`,
+		`<table:table-cell office:value-type="time" office:time-value="x"/>`,
+	} {
+		_, err = OpenReader(prepareODS(t, "", `<table:table table:name="Sheet1"><table:table-row>`+cell+`</table:table-row></table:table>`))
+		assert.Error(t, err)
+	}
+	_, err = OpenReader(prepareODS(t, "", `<table:table table:name="Sheet1"><table:table-row><table:table-cell office:value-type="time" office:time-value="x"/></table:table-row></table:table>`))
+	assert.EqualError(t, err, "invalid OpenDocument time value x")
+	// Test open the OpenDocument spreadsheet with invalid table name
+	_, err = OpenReader(prepareODS(t, "", `<table:table table:name="a:b"/>`))
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test open the OpenDocument spreadsheet with unsupported charset content
+	_, err = OpenReader(prepareODS(t, "", `<table:table table:name="Sheet1"><table:table-row><table:table-cell><text:p>`))
+	assert.Error(t, err)
+}
+
+func TestParseODSDuration(t *testing.T) {
+	for value, expected := range map[string]float64{
+		"PT12H":         0.5,
+		"PT06H00M00S":   0.25,
+		"P1DT12H":       1.5,
+		"-PT12H":        -0.5,
+		"PT00H00M43.2S": 0.0005,
+	} {
+		actual, err := parseODSDuration(value)
+		assert.NoError(t, err)
+		assert.InDelta(t, expected, actual, 1e-12, value)
+	}
+}
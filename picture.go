@@ -19,10 +19,25 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
 
+// offsetEMUs returns the horizontal and vertical offsets of the graphic object
+// in EMUs, the OffsetXEMU and OffsetYEMU take precedence over the OffsetX and
+// OffsetY in pixels.
+func (opts *GraphicOptions) offsetEMUs() (int, int) {
+	x, y := opts.OffsetX*EMU, opts.OffsetY*EMU
+	if opts.OffsetXEMU != 0 {
+		x = opts.OffsetXEMU
+	}
+	if opts.OffsetYEMU != 0 {
+		y = opts.OffsetYEMU
+	}
+	return x, y
+}
+
 // parseGraphicOptions provides a function to parse the format settings of
 // the picture with default value.
 func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
@@ -131,6 +146,12 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // The optional parameter "OffsetY" specifies the vertical offset of the graph
 // object with the cell, the default value of that is 0.
 //
+// The optional parameters "OffsetXEMU" and "OffsetYEMU" specifies the
+// horizontal and vertical offsets of the graph object with the cell in EMUs
+// (English Metric Units) for precise positioning, which take precedence over
+// the "OffsetX" and "OffsetY" in pixels. Use the PixelsToEMUs function to
+// convert the pixels to EMUs.
+//
 // The optional parameter "ScaleX" specifies the horizontal scale of graph
 // object, the default value of that is 1.0 which presents 100%.
 //
@@ -151,6 +172,9 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // size with cells). If you don't set this parameter, the default positioning
 // is to move and size with cells.
 func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error {
+	if err := f.checkReadOnly("AddPicture"); err != nil {
+		return err
+	}
 	var err error
 	// Check picture exists first.
 	if _, err = os.Stat(name); os.IsNotExist(err) {
@@ -205,6 +229,9 @@ func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error
 //	    }
 //	}
 func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
+	if err := f.checkReadOnly("AddPictureFromBytes"); err != nil {
+		return err
+	}
 	var drawingHyperlinkRID int
 	var hyperlinkType string
 	ext, ok := supportedImageTypes[strings.ToLower(pic.Extension)]
@@ -344,7 +371,8 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 		width = int(float64(width) * opts.ScaleX)
 		height = int(float64(height) * opts.ScaleY)
 	}
-	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, opts.OffsetX, opts.OffsetY, width, height)
+	offsetX, offsetY := opts.offsetEMUs()
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectEMUs(sheet, col, row, offsetX, offsetY, width*EMU, height*EMU)
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return err
@@ -353,14 +381,14 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	twoCellAnchor.EditAs = opts.Positioning
 	from := xlsxFrom{}
 	from.Col = colStart
-	from.ColOff = opts.OffsetX * EMU
+	from.ColOff = offsetX
 	from.Row = rowStart
-	from.RowOff = opts.OffsetY * EMU
+	from.RowOff = offsetY
 	to := xlsxTo{}
 	to.Col = colEnd
-	to.ColOff = x2 * EMU
+	to.ColOff = x2
 	to.Row = rowEnd
-	to.RowOff = y2 * EMU
+	to.RowOff = y2
 	twoCellAnchor.From = &from
 	twoCellAnchor.To = &to
 	pic := xlsxPic{}
@@ -457,7 +485,11 @@ func (f *File) setContentTypePartImageExtensions() error {
 	for _, file := range content.Defaults {
 		delete(imageTypes, file.Extension)
 	}
-	for extension, prefix := range imageTypes {
+	for _, extension := range []string{"bmp", "jpeg", "png", "gif", "svg", "tiff", "emf", "wmf", "emz", "wmz"} {
+		prefix, ok := imageTypes[extension]
+		if !ok {
+			continue
+		}
 		content.Defaults = append(content.Defaults, xlsxDefault{
 			Extension:   extension,
 			ContentType: prefix + extension,
@@ -502,20 +534,24 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
 		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
 		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"oleObject":     "/xl/embeddings/oleObject" + strconv.Itoa(index) + ".bin",
 		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
 		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
 		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
 		"sharedStrings": "/xl/sharedStrings.xml",
+		"theme":         "/xl/theme/theme1.xml",
 	}
 	contentTypes := map[string]string{
 		"chart":         ContentTypeDrawingML,
 		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
 		"comments":      ContentTypeSpreadSheetMLComments,
 		"drawings":      ContentTypeDrawing,
+		"oleObject":     ContentTypeOLEObject,
 		"table":         ContentTypeSpreadSheetMLTable,
 		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
 		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
 		"sharedStrings": ContentTypeSpreadSheetMLSharedStrings,
+		"theme":         ContentTypeTheme,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
@@ -618,6 +654,9 @@ func (f *File) GetPictures(sheet, cell string) ([]Picture, error) {
 // worksheet name and cell reference. Note that the image file won't be deleted
 // from the document currently.
 func (f *File) DeletePicture(sheet, cell string) error {
+	if err := f.checkReadOnly("DeletePicture"); err != nil {
+		return err
+	}
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
@@ -635,6 +674,132 @@ func (f *File) DeletePicture(sheet, cell string) error {
 	return f.deleteDrawing(col, row, drawingXML, "Pic")
 }
 
+// GetMedia provides a function to get all media and embedded objects stored in
+// the folder xl/media and xl/embeddings of the spreadsheet, with the content
+// type, raw content, and the parts and sheets which reference each of them.
+// The items which aren't referenced by any sheet will be flagged by the
+// Unreferenced field. For example, extract all media files of the spreadsheet:
+//
+//	media, err := f.GetMedia()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, m := range media {
+//	    if m.Unreferenced {
+//	        continue
+//	    }
+//	    if err := os.WriteFile(filepath.Base(m.Name), m.File, 0644); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) GetMedia() ([]MediaFile, error) {
+	var names, relsPaths []string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		name := k.(string)
+		if strings.HasPrefix(name, "xl/media/") || strings.HasPrefix(name, "xl/embeddings/") {
+			names = append(names, name)
+		}
+		if strings.HasSuffix(name, ".rels") {
+			relsPaths = append(relsPaths, name)
+		}
+		return true
+	})
+	f.Relationships.Range(func(k, v interface{}) bool {
+		if _, ok := f.Pkg.Load(k.(string)); !ok {
+			relsPaths = append(relsPaths, k.(string))
+		}
+		return true
+	})
+	sort.Strings(names)
+	sort.Strings(relsPaths)
+	// Build the map of the parts and the parts which reference them
+	referrers := map[string][]string{}
+	for _, relsPath := range relsPaths {
+		rels, err := f.relsReader(relsPath)
+		if err != nil {
+			return nil, err
+		}
+		if rels == nil {
+			continue
+		}
+		source := path.Join(path.Dir(path.Dir(relsPath)), strings.TrimSuffix(path.Base(relsPath), ".rels"))
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.TargetMode == "External" {
+				continue
+			}
+			target := strings.TrimPrefix(rel.Target, "/")
+			if !strings.HasPrefix(rel.Target, "/") {
+				target = path.Join(path.Dir(source), rel.Target)
+			}
+			if inStrSlice(referrers[target], source, true) == -1 {
+				referrers[target] = append(referrers[target], source)
+			}
+		}
+		rels.mu.Unlock()
+	}
+	sheets := map[string]string{}
+	for sheet, sheetXMLPath := range f.sheetMap {
+		sheets[sheetXMLPath] = sheet
+	}
+	f.mu.Lock()
+	content, err := f.contentTypesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	var media []MediaFile
+	for _, name := range names {
+		file, _ := f.Pkg.Load(name)
+		item := MediaFile{Name: name, ContentType: getPartContentType(content, name), File: file.([]byte), References: referrers[name]}
+		// Find the sheets which reference the item through the drawings and charts
+		visited, queue, referenced := map[string]bool{name: true}, []string{name}, map[string]bool{}
+		for len(queue) > 0 {
+			part := queue[0]
+			queue = queue[1:]
+			for _, referrer := range referrers[part] {
+				if visited[referrer] {
+					continue
+				}
+				visited[referrer] = true
+				if sheet, ok := sheets[referrer]; ok {
+					referenced[sheet] = true
+					continue
+				}
+				queue = append(queue, referrer)
+			}
+		}
+		for _, sheet := range f.GetSheetList() {
+			if referenced[sheet] {
+				item.Sheets = append(item.Sheets, sheet)
+			}
+		}
+		item.Unreferenced = len(item.Sheets) == 0
+		media = append(media, item)
+	}
+	return media, err
+}
+
+// getPartContentType provides a function to get the content type of the part
+// by given content types and part name.
+func getPartContentType(content *xlsxTypes, name string) string {
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	for _, override := range content.Overrides {
+		if strings.TrimPrefix(override.PartName, "/") == name {
+			return override.ContentType
+		}
+	}
+	ext := strings.TrimPrefix(path.Ext(name), ".")
+	for _, def := range content.Defaults {
+		if strings.EqualFold(def.Extension, ext) {
+			return def.ContentType
+		}
+	}
+	return ""
+}
+
 // getPicture provides a function to get picture base name and raw content
 // embed in spreadsheet by given coordinates and drawing relationships.
 func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string) (pics []Picture, err error) {
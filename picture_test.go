@@ -246,6 +246,54 @@ func TestDeletePicture(t *testing.T) {
 	assert.NoError(t, NewFile().DeletePicture("Sheet1", "A1"))
 }
 
+func TestGetMedia(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.SetSheetBackground("Sheet2", filepath.Join("test", "images", "excel.jpg")))
+	assert.NoError(t, f.AddOLEObject("Sheet2", "B2", &OLEObjectOptions{File: []byte("Excelize"), FileName: "data.txt"}))
+	f.Pkg.Store("xl/media/image9.gif", []byte("GIF89a"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetMedia.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetMedia.xlsx"))
+	assert.NoError(t, err)
+	media, err := f.GetMedia()
+	assert.NoError(t, err)
+	assert.Len(t, media, 5)
+	items := map[string]MediaFile{}
+	for _, m := range media {
+		items[m.Name] = m
+	}
+	assert.Equal(t, "image/png", items["xl/media/image1.png"].ContentType)
+	assert.Equal(t, []string{"xl/drawings/drawing1.xml"}, items["xl/media/image1.png"].References)
+	assert.Equal(t, []string{"Sheet1"}, items["xl/media/image1.png"].Sheets)
+	assert.Equal(t, []string{"Sheet2"}, items["xl/media/image2.jpeg"].Sheets)
+	assert.Equal(t, []string{"xl/drawings/vmlDrawing1.vml", "xl/worksheets/sheet2.xml"}, items["xl/media/image3.png"].References)
+	assert.Equal(t, []string{"Sheet2"}, items["xl/media/image3.png"].Sheets)
+	assert.Equal(t, ContentTypeOLEObject, items["xl/embeddings/oleObject1.bin"].ContentType)
+	assert.Equal(t, []string{"Sheet2"}, items["xl/embeddings/oleObject1.bin"].Sheets)
+	assert.False(t, items["xl/embeddings/oleObject1.bin"].Unreferenced)
+	assert.Equal(t, []byte("GIF89a"), items["xl/media/image9.gif"].File)
+	assert.Empty(t, items["xl/media/image9.gif"].References)
+	assert.True(t, items["xl/media/image9.gif"].Unreferenced)
+	assert.NoError(t, f.Close())
+
+	// Test get media with unsupported charset relationships
+	f = NewFile()
+	f.Relationships.Delete("xl/_rels/workbook.xml.rels")
+	f.Pkg.Store("xl/_rels/workbook.xml.rels", MacintoshCyrillicCharset)
+	_, err = f.GetMedia()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get media with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	_, err = f.GetMedia()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestDrawingResize(t *testing.T) {
 	f := NewFile()
 	// Test calculate drawing resize on not exists worksheet
@@ -135,6 +135,9 @@ type PivotTableField struct {
 //	    }
 //	}
 func (f *File) AddPivotTable(opts *PivotTableOptions) error {
+	if err := f.checkReadOnly("AddPivotTable"); err != nil {
+		return err
+	}
 	// parameter validation
 	_, pivotTableSheetPath, err := f.parseFormatPivotTableSet(opts)
 	if err != nil {
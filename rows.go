@@ -18,7 +18,10 @@ import (
 	"io"
 	"math"
 	"os"
+	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mohae/deepcopy"
 )
@@ -29,7 +32,10 @@ import (
 // the applied value will be used, otherwise the original value will be used.
 // GetRows fetched the rows with value or formula cells, the continually blank
 // cells in the tail of each row will be skipped, so the length of each row
-// may be inconsistent.
+// may be inconsistent. The merged cells only hold the value in the top-left
+// cell of the range, set the FillMergedCells option to fill each cell of the
+// merged range with that value, the first defined merged range will be used
+// if the merged ranges are overlapped.
 //
 // For example, get and traverse the value of all cells by rows on a worksheet
 // named 'Sheet1':
@@ -55,6 +61,10 @@ func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
 		cur++
 		row, err := rows.Columns(opts...)
 		if err != nil {
+			if err == ErrCellCountLimit || err == ErrSharedStringCountLimit {
+				_ = rows.Close()
+				return nil, err
+			}
 			break
 		}
 		results = append(results, row)
@@ -62,13 +72,236 @@ func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
 			max = cur
 		}
 	}
-	return results[:max], rows.Close()
+	if err = rows.Close(); err != nil || !getOptions(opts...).FillMergedCells {
+		return results[:max], err
+	}
+	return f.fillMergedCells(sheet, results[:max])
+}
+
+// fillMergedCells provides a function to fill each cell of the merged ranges
+// with the value of the top-left cell of the range by given worksheet name
+// and rows. The first defined merged range will be used if the merged ranges
+// are overlapped. The coordinates of the merged ranges are parsed by the
+// merged cells index of the worksheet, and the merged ranges are filled in
+// reverse order, so that the cells in the overlapped ranges will be
+// overwritten by the first defined one without checking each cell.
+func (f *File) fillMergedCells(sheet string, results [][]string) ([][]string, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return results, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	idx, err := ws.mergeCellsIndexer()
+	if err != nil || idx == nil {
+		return results, err
+	}
+	mergeCells := ws.MergeCells.Cells
+	values := make([]string, len(mergeCells))
+	for i, mergeCell := range mergeCells {
+		if rect := mergeCell.rect; rect != nil && rect[1] <= len(results) && rect[0] <= len(results[rect[1]-1]) {
+			values[i] = results[rect[1]-1][rect[0]-1]
+		}
+	}
+	for i := len(mergeCells) - 1; i >= 0; i-- {
+		rect, value := mergeCells[i].rect, values[i]
+		if rect == nil {
+			continue
+		}
+		for row := rect[1]; row <= rect[3]; row++ {
+			if value == "" && row > len(results) {
+				break
+			}
+			for len(results) < row {
+				results = append(results, []string{})
+			}
+			cols := results[row-1]
+			if value != "" && len(cols) < rect[2] {
+				cols = append(cols, make([]string, rect[2]-len(cols))...)
+			}
+			for col := rect[0]; col <= rect[2] && col <= len(cols); col++ {
+				cols[col-1] = value
+			}
+			results[row-1] = cols
+		}
+	}
+	return results, err
+}
+
+// GetSheetRowsStruct reads the rows of the worksheet into the slice of structs
+// by given worksheet name and a pointer to the slice of structs or pointers to
+// structs. The first row of the worksheet is the header row, the columns will
+// be matched with the struct fields by the header names specified in the
+// "excel" struct field tags, or the field names if the tags were omitted, the
+// header names are matched case-insensitively if no exact match was found.
+// The fields without the matched column, the fields tagged with "-" and the
+// unexported fields will be skipped, and the fields of the embedded structs
+// will be flattened. Each row after the header row will be appended as a
+// struct to the slice, and the blank rows will be skipped. The raw cell
+// values will be converted into the types of the fields, the supported field
+// types are string, bool, the numeric types, time.Time and the pointers to
+// them, the pointer fields will be nil for the empty cells. The time.Time
+// field accepts the date serial numbers and the date strings, such as
+// 2006-01-02 and 2006-01-02 15:04:05. An error identifying the cell will be
+// returned if a cell value can't be converted. For example, read the orders
+// on Sheet1:
+//
+//	type Order struct {
+//	    ID     int       `excel:"Order ID"`
+//	    Amount float64   `excel:"Amount"`
+//	    Date   time.Time `excel:"Date"`
+//	    Paid   *bool     `excel:"Paid"`
+//	}
+//	var orders []Order
+//	if err := f.GetSheetRowsStruct("Sheet1", &orders); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) GetSheetRowsStruct(sheet string, out interface{}) error {
+	val := reflect.ValueOf(out)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Slice {
+		return ErrParameterInvalid
+	}
+	slice, typ := val.Elem(), val.Elem().Type().Elem()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return ErrParameterInvalid
+	}
+	var date1904 bool
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb != nil && wb.WorkbookPr != nil {
+		date1904 = wb.WorkbookPr.Date1904
+	}
+	rows, err := f.GetRows(sheet, Options{RawCellValue: true})
+	if err != nil {
+		return err
+	}
+	fields, columns := getStructFields(typ, nil), map[int]structField{}
+	if len(rows) > 0 {
+		for _, field := range fields {
+			col := inStrSlice(rows[0], field.name, true)
+			if col == -1 {
+				col = inStrSlice(rows[0], field.name, false)
+			}
+			if col == -1 {
+				continue
+			}
+			if fieldType := typ.FieldByIndex(field.index).Type; !isStructFieldTypeSupported(fieldType) {
+				return newUnsupportedFieldTypeError(field.name, fieldType.String())
+			}
+			columns[col] = field
+		}
+	}
+	for r := 1; r < len(rows); r++ {
+		if len(rows[r]) == 0 {
+			continue
+		}
+		item := reflect.New(typ).Elem()
+		for col, value := range rows[r] {
+			field, ok := columns[col]
+			if !ok || value == "" {
+				continue
+			}
+			fieldValue, ok := allocStructFieldValue(item, field.index)
+			if !ok {
+				continue
+			}
+			if err = setStructFieldValue(fieldValue, value, date1904); err != nil {
+				cell, _ := CoordinatesToCellName(col+1, r+1)
+				return newCellValueConvertError(cell, value, field.name, err)
+			}
+		}
+		if slice.Type().Elem().Kind() == reflect.Ptr {
+			item = item.Addr()
+		}
+		slice.Set(reflect.Append(slice, item))
+	}
+	return nil
+}
+
+// setStructFieldValue provides a function to convert the raw cell value into
+// the type of the struct field and set the field by given field value, raw
+// cell value and the date system of the workbook.
+func setStructFieldValue(field reflect.Value, value string, date1904 bool) error {
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setStructFieldValue(ptr.Elem(), value, date1904); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			num, floatErr := strconv.ParseFloat(value, 64)
+			if floatErr != nil || num != math.Trunc(num) || field.OverflowInt(int64(num)) {
+				return err
+			}
+			n = int64(num)
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			num, floatErr := strconv.ParseFloat(value, 64)
+			if floatErr != nil || num < 0 || num != math.Trunc(num) || field.OverflowUint(uint64(num)) {
+				return err
+			}
+			n = uint64(num)
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	default:
+		t, err := parseCellTime(value, date1904)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+	}
+	return nil
+}
+
+// parseCellTime provides a function to convert the raw cell value into the
+// time by given raw cell value and the date system of the workbook, the value
+// could be a date serial number or a date string.
+func parseCellTime(value string, date1904 bool) (time.Time, error) {
+	if num, err := strconv.ParseFloat(value, 64); err == nil {
+		return ExcelDateToTime(num, date1904)
+	}
+	var err error
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006/01/02", "2006-01-02"} {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, err
+		}
+	}
+	return time.Time{}, err
 }
 
 // Rows defines an iterator to a sheet.
 type Rows struct {
 	err                     error
 	curRow, seekRow         int
+	cellCount               int
 	needClose, rawCellValue bool
 	sheet                   string
 	f                       *File
@@ -77,6 +310,7 @@ type Rows struct {
 	decoder                 *xml.Decoder
 	token                   xml.Token
 	curRowOpts, seekRowOpts RowOpts
+	cols                    []xlsxCol
 }
 
 // Next will return true if it finds the next row element.
@@ -93,6 +327,9 @@ func (rows *Rows) Next() bool {
 		}
 		switch xmlElement := token.(type) {
 		case xml.StartElement:
+			if xmlElement.Name.Local == "col" {
+				rows.cols = append(rows.cols, parseColStyle(&xmlElement))
+			}
 			if xmlElement.Name.Local == "row" {
 				rows.curRow++
 				if rowNum, _ := attrValToInt("r", xmlElement.Attr); rowNum != 0 {
@@ -139,8 +376,10 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 	var rowIterator rowXMLIterator
 	var token xml.Token
 	rows.rawCellValue = getOptions(opts...).RawCellValue
-	if rows.sst, rowIterator.err = rows.f.sharedStringsReader(); rowIterator.err != nil {
-		return rowIterator.cells, rowIterator.err
+	if rows.sst == nil {
+		if rows.sst, rowIterator.err = rows.f.sharedStringsReader(); rowIterator.err != nil {
+			return rowIterator.cells, rowIterator.err
+		}
 	}
 	for {
 		if rows.token != nil {
@@ -165,6 +404,12 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 					return rowIterator.cells, rowIterator.err
 				}
 			}
+			if rowIterator.inElement == "c" && rows.f.options != nil && rows.f.options.MaxCellCount > 0 {
+				if rows.cellCount++; rows.cellCount > rows.f.options.MaxCellCount {
+					rows.token = nil
+					return rowIterator.cells, ErrCellCountLimit
+				}
+			}
 			if rows.rowXMLHandler(&rowIterator, &xmlElement, rows.rawCellValue); rowIterator.err != nil {
 				rows.token = nil
 				return rowIterator.cells, rowIterator.err
@@ -213,10 +458,11 @@ func (err ErrSheetNotExist) Error() string {
 
 // rowXMLIterator defined runtime use field for the worksheet row SAX parser.
 type rowXMLIterator struct {
-	err              error
-	inElement        string
-	cellCol, cellRow int
-	cells            []string
+	err                        error
+	inElement                  string
+	cellCol, cellRow, rowStyle int
+	cells                      []string
+	cols                       []xlsxCol
 }
 
 // rowXMLHandler parse the row XML element of the worksheet.
@@ -231,15 +477,20 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 			}
 		}
 		blank := rowIterator.cellCol - len(rowIterator.cells)
-		if val, _ := colCell.getValueFrom(rows.f, rows.sst, raw); val != "" || colCell.F != nil {
+		style := inheritCellStyle(rowIterator.cellCol, colCell.S, rows.seekRowOpts.StyleID, rows.cols)
+		if val, _ := colCell.getValueFrom(rows.f, rows.sst, raw, style); val != "" || colCell.F != nil {
 			rowIterator.cells = append(appendSpace(blank, rowIterator.cells), val)
 		}
 	}
 }
 
 // Rows returns a rows iterator, used for streaming reading data for a
-// worksheet with a large data. This function is concurrency safe. For
-// example:
+// worksheet with a large data. This function is concurrency safe. The
+// iterator reads a snapshot of the worksheet and the shared string table
+// taken when calling this function, so the changes made to the workbook after
+// that, such as setting cell values or optimizing the shared strings, will
+// not be visible for the iterator and will not affect the iteration, call
+// this function again to read the latest data. For example:
 //
 //	rows, err := f.Rows("Sheet1")
 //	if err != nil {
@@ -260,6 +511,18 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 //	    fmt.Println(err)
 //	}
 func (f *File) Rows(sheet string) (*Rows, error) {
+	rows, err := f.worksheetDecoder(sheet)
+	if rows == nil {
+		return nil, err
+	}
+	rows.sst = f.sharedStringsSnapshot()
+	return rows, err
+}
+
+// worksheetDecoder provides a function to flush the worksheet by given
+// worksheet name and create the rows iterator with the worksheet XML decoder,
+// without reading the shared string table.
+func (f *File) worksheetDecoder(sheet string) (*Rows, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return nil, err
 	}
@@ -281,6 +544,182 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 	return &rows, err
 }
 
+// sharedStringsSnapshot provides a function to get a snapshot of the shared
+// string table for the rows iterator. The string items will be appended to
+// the shared string table without changing the existing items, and the shared
+// string table will be replaced by a new one on optimizing, so the snapshot
+// only keeps the string items by current length. It returns nil if the shared
+// string table can't be read or which stored in the system temporary
+// directory.
+func (f *File) sharedStringsSnapshot() *xlsxSST {
+	if _, ok := f.tempFiles.Load(defaultXMLPathSharedStrings); ok {
+		return nil
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil || sst == nil {
+		return nil
+	}
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+	return &xlsxSST{SI: sst.SI[:len(sst.SI):len(sst.SI)]}
+}
+
+// StreamRows provides a function to traverse the rows of a worksheet by given
+// worksheet name and callback function. The worksheet will be decoded row by
+// row with a SAX-style decoder, and the callback function will be invoked for
+// each row with the row number and the cell values of the row, so that the
+// worksheet with huge amounts of data can be processed with bounded memory.
+// The shared string table will not be parsed into memory unless it has been
+// read by other functions, the string items will be written into the system
+// temporary directory on reading the first shared string cell, and read by
+// offset for each cell. Return a non-nil error from the callback function to
+// stop the traversal, and the error will be returned by this function. For
+// example, print the cell values of the first 10 rows on Sheet1:
+//
+//	errStop := errors.New("stop")
+//	err := f.StreamRows("Sheet1", func(row int, cols []string) error {
+//	    if row > 10 {
+//	        return errStop
+//	    }
+//	    fmt.Println(row, cols)
+//	    return nil
+//	})
+//	if err != nil && err != errStop {
+//	    fmt.Println(err)
+//	}
+func (f *File) StreamRows(sheet string, fn func(row int, cols []string) error, opts ...Options) error {
+	rows, err := f.worksheetDecoder(sheet)
+	if err != nil {
+		if rows != nil {
+			_ = rows.Close()
+		}
+		return err
+	}
+	if err = rows.streamRows(fn, getOptions(opts...).RawCellValue); err != nil {
+		_ = rows.Close()
+		return err
+	}
+	return rows.Close()
+}
+
+// streamRows decode the row elements of the worksheet one by one, and invoke
+// the callback function with the cell values of each row.
+func (rows *Rows) streamRows(fn func(row int, cols []string) error, raw bool) error {
+	var (
+		cells []string
+		err   error
+	)
+	for {
+		token, _ := rows.decoder.Token()
+		if token == nil {
+			return nil
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			if xmlElement.Name.Local == "col" {
+				rows.cols = append(rows.cols, parseColStyle(&xmlElement))
+			}
+			if xmlElement.Name.Local == "row" {
+				rows.seekRowOpts = extractRowOpts(xmlElement.Attr)
+				rowNum, _ := attrValToInt("r", xmlElement.Attr)
+				if rowNum == 0 {
+					rowNum = rows.curRow + 1
+				}
+				// Invoke the callback function for the omitted empty rows
+				for rows.curRow++; rows.curRow < rowNum; rows.curRow++ {
+					if err = fn(rows.curRow, nil); err != nil {
+						return err
+					}
+				}
+				cells, rows.seekRow = nil, 0
+			}
+			if xmlElement.Name.Local == "c" {
+				if cells, err = rows.streamCell(&xmlElement, cells, raw); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if xmlElement.Name.Local == "row" {
+				if err = fn(rows.curRow, cells); err != nil {
+					return err
+				}
+			}
+			if xmlElement.Name.Local == "sheetData" {
+				return nil
+			}
+		}
+	}
+}
+
+// streamCell decode the cell element of the worksheet, and append the cell
+// value to the given cell values of the row. The shared string will be read
+// by index from the system temporary directory if the shared string table has
+// not been loaded into memory.
+func (rows *Rows) streamCell(xmlElement *xml.StartElement, cells []string, raw bool) ([]string, error) {
+	if rows.f.options != nil && rows.f.options.MaxCellCount > 0 {
+		if rows.cellCount++; rows.cellCount > rows.f.options.MaxCellCount {
+			return cells, ErrCellCountLimit
+		}
+	}
+	var (
+		c   xlsxC
+		val string
+		err error
+	)
+	rows.seekRow++
+	if err = rows.decoder.DecodeElement(&c, xmlElement); err != nil {
+		return cells, err
+	}
+	if c.R != "" {
+		if rows.seekRow, _, err = CellNameToCoordinates(c.R); err != nil {
+			return cells, err
+		}
+	}
+	style := inheritCellStyle(rows.seekRow, c.S, rows.seekRowOpts.StyleID, rows.cols)
+	if c.T == "s" && c.V != "" {
+		if val, err = rows.f.getSharedStringItem(c.V); err != nil {
+			return cells, err
+		}
+		val, _ = rows.f.formattedValue(&xlsxC{S: style, V: val}, raw, CellTypeSharedString)
+	} else {
+		val, _ = c.getValueFrom(rows.f, nil, raw, style)
+	}
+	if val != "" || c.F != nil {
+		cells = append(appendSpace(rows.seekRow-len(cells), cells), val)
+	}
+	return cells, err
+}
+
+// getSharedStringItem provides a function to get the shared string item by
+// given index. The parsed shared string table will be used if it has been
+// loaded into memory, otherwise the string items will be written into the
+// system temporary directory at the first time, and read by offset.
+func (f *File) getSharedStringItem(v string) (string, error) {
+	idx, _ := strconv.Atoi(strings.TrimSpace(v))
+	f.mu.Lock()
+	sst := f.SharedStrings
+	f.mu.Unlock()
+	if sst != nil {
+		sst.mu.Lock()
+		defer sst.mu.Unlock()
+		if len(sst.SI) > idx && idx >= 0 {
+			return sst.SI[idx].String(), nil
+		}
+		return v, nil
+	}
+	if f.sharedStringTemp == nil {
+		if err := f.writeSharedStringsTemp(); err != nil {
+			_ = f.sharedStringTemp.Close()
+			f.sharedStringTemp = nil
+			return v, err
+		}
+	}
+	if idx < 0 {
+		return v, nil
+	}
+	return f.getFromStringItem(idx), nil
+}
+
 // getFromStringItem build shared string item offset list from system temporary
 // file at one time, and return value by given to string index.
 func (f *File) getFromStringItem(index int) string {
@@ -295,40 +734,54 @@ func (f *File) getFromStringItem(index int) string {
 		}
 		return string(buf)
 	}
-	needClose, decoder, tempFile, err := f.xmlDecoder(defaultXMLPathSharedStrings)
-	if needClose && err == nil {
-		defer func() {
-			err = tempFile.Close()
-		}()
-	}
+	_ = f.writeSharedStringsTemp()
+	return f.getFromStringItem(index)
+}
+
+// writeSharedStringsTemp provides a function to decode the shared string
+// table item by item, write the string items into the system temporary file
+// and build the offset list of the string items.
+func (f *File) writeSharedStringsTemp() error {
 	f.sharedStringItem = [][]uint{}
 	f.sharedStringTemp, _ = os.CreateTemp(os.TempDir(), "excelize-")
 	f.tempFiles.Store(defaultTempFileSST, f.sharedStringTemp.Name())
-	var (
-		inElement string
-		i, offset uint
-	)
+	// Disable the lock-free shared strings index until the temporary file
+	// was removed by the shared strings loader
+	f.sharedStringsMap.sst.Store((*xlsxSST)(nil))
+	_, inMemory := f.Pkg.Load(defaultXMLPathSharedStrings)
+	if _, inTemp := f.tempFiles.Load(defaultXMLPathSharedStrings); !inMemory && !inTemp {
+		return nil
+	}
+	needClose, decoder, tempFile, err := f.xmlDecoder(defaultXMLPathSharedStrings)
+	if err != nil {
+		return err
+	}
+	if needClose {
+		defer tempFile.Close()
+	}
+	var offset uint
 	for {
-		token, _ := decoder.Token()
+		token, err := decoder.Token()
 		if token == nil {
-			break
+			if err == io.EOF {
+				return nil
+			}
+			return err
 		}
-		switch xmlElement := token.(type) {
-		case xml.StartElement:
-			inElement = xmlElement.Name.Local
-			if inElement == "si" {
-				si := xlsxSI{}
-				_ = decoder.DecodeElement(&si, &xmlElement)
-
-				startIdx := offset
-				n, _ := f.sharedStringTemp.WriteString(si.String())
-				offset += uint(n)
-				f.sharedStringItem = append(f.sharedStringItem, []uint{startIdx, offset})
-				i++
+		if xmlElement, ok := token.(xml.StartElement); ok && xmlElement.Name.Local == "si" {
+			if f.options != nil && f.options.MaxSharedStringCount > 0 && len(f.sharedStringItem) >= f.options.MaxSharedStringCount {
+				return ErrSharedStringCountLimit
+			}
+			si := xlsxSI{}
+			if err = decoder.DecodeElement(&si, &xmlElement); err != nil {
+				return err
 			}
+			startIdx := offset
+			n, _ := f.sharedStringTemp.WriteString(si.String())
+			offset += uint(n)
+			f.sharedStringItem = append(f.sharedStringItem, []uint{startIdx, offset})
 		}
 	}
-	return f.getFromStringItem(index)
 }
 
 // xmlDecoder creates XML decoder by given path in the zip from memory data
@@ -340,6 +793,11 @@ func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
 		tempFile *os.File
 	)
 	if content = f.readXML(name); len(content) > 0 {
+		if _, ok := f.streams[name]; ok {
+			// Copy the content in the buffer of the stream writer, which
+			// may be changed by writing
+			content = append([]byte(nil), content...)
+		}
 		return false, f.xmlNewDecoder(bytes.NewReader(content)), tempFile, err
 	}
 	tempFile, err = f.readTemp(name)
@@ -351,6 +809,9 @@ func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
 //
 //	err := f.SetRowHeight("Sheet1", 1, 50)
 func (f *File) SetRowHeight(sheet string, row int, height float64) error {
+	if err := f.checkReadOnly("SetRowHeight"); err != nil {
+		return err
+	}
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -379,11 +840,11 @@ func (f *File) getRowHeight(sheet string, row int) int {
 	for i := range ws.SheetData.Row {
 		v := &ws.SheetData.Row[i]
 		if v.R == row && v.Ht != nil {
-			return int(convertRowHeightToPixels(*v.Ht))
+			return int(RowHeightToPixels(*v.Ht))
 		}
 	}
 	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultRowHeight > 0 {
-		return int(convertRowHeightToPixels(ws.SheetFormatPr.DefaultRowHeight))
+		return int(RowHeightToPixels(ws.SheetFormatPr.DefaultRowHeight))
 	}
 	// Optimization for when the row heights haven't changed.
 	return int(defaultRowHeightPixels)
@@ -417,6 +878,52 @@ func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
 	return ht, nil
 }
 
+// decodeSharedStrings provides a function to decode the shared string table
+// item by item, the reading will be aborted with ErrSharedStringCountLimit as
+// soon as the number of string items exceeds the MaxSharedStringCount limit.
+func (f *File) decodeSharedStrings(content []byte, sst *xlsxSST) error {
+	d := f.xmlNewDecoder(bytes.NewReader(content))
+	for {
+		token, err := d.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		el, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch el.Name.Local {
+		case "sst":
+			sst.XMLName = el.Name
+			for _, attr := range el.Attr {
+				switch attr.Name.Local {
+				case "count":
+					sst.Count, _ = strconv.Atoi(attr.Value)
+				case "uniqueCount":
+					sst.UniqueCount, _ = strconv.Atoi(attr.Value)
+				}
+			}
+		case "si":
+			if f.options != nil && f.options.MaxSharedStringCount > 0 && len(sst.SI) >= f.options.MaxSharedStringCount {
+				return ErrSharedStringCountLimit
+			}
+			var si xlsxSI
+			if err = d.DecodeElement(&si, &el); err != nil {
+				return err
+			}
+			sst.SI = append(sst.SI, si)
+		case "extLst":
+			sst.ExtLst = new(xlsxExtLst)
+			if err = d.DecodeElement(sst.ExtLst, &el); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // sharedStringsReader provides a function to get the pointer to the structure
 // after deserialization of xl/sharedStrings.xml.
 func (f *File) sharedStringsReader() (*xlsxSST, error) {
@@ -424,11 +931,13 @@ func (f *File) sharedStringsReader() (*xlsxSST, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	relPath := f.getWorkbookRelsPath()
+	if f.SharedStrings != nil && f.sharedStringsMap.table() != f.SharedStrings {
+		f.sharedStringsMap.build(f.SharedStrings)
+	}
 	if f.SharedStrings == nil {
 		var sharedStrings xlsxSST
 		ss := f.readXML(defaultXMLPathSharedStrings)
-		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(ss))).
-			Decode(&sharedStrings); err != nil && err != io.EOF {
+		if err = f.decodeSharedStrings(namespaceStrictToTransitional(ss), &sharedStrings); err != nil {
 			return f.SharedStrings, err
 		}
 		if sharedStrings.Count == 0 {
@@ -438,11 +947,7 @@ func (f *File) sharedStringsReader() (*xlsxSST, error) {
 			sharedStrings.UniqueCount = sharedStrings.Count
 		}
 		f.SharedStrings = &sharedStrings
-		for i := range sharedStrings.SI {
-			if sharedStrings.SI[i].T != nil {
-				f.sharedStringsMap[sharedStrings.SI[i].T.Val] = i
-			}
-		}
+		f.sharedStringsMap.build(f.SharedStrings)
 		if err = f.addContentTypePart(0, "sharedStrings"); err != nil {
 			return f.SharedStrings, err
 		}
@@ -467,6 +972,9 @@ func (f *File) sharedStringsReader() (*xlsxSST, error) {
 //
 //	err := f.SetRowVisible("Sheet1", 2, false)
 func (f *File) SetRowVisible(sheet string, row int, visible bool) error {
+	if err := f.checkReadOnly("SetRowVisible"); err != nil {
+		return err
+	}
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -506,6 +1014,9 @@ func (f *File) GetRowVisible(sheet string, row int) (bool, error) {
 //
 //	err := f.SetRowOutlineLevel("Sheet1", 2, 1)
 func (f *File) SetRowOutlineLevel(sheet string, row int, level uint8) error {
+	if err := f.checkReadOnly("SetRowOutlineLevel"); err != nil {
+		return err
+	}
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -546,10 +1057,15 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 //	err := f.RemoveRow("Sheet1", 3)
 //
 // Use this method with caution, which will affect changes in references such
-// as formulas, charts, and so on. If there is any referenced value of the
-// worksheet, it will cause a file error when you open it. The excelize only
-// partially updates these references currently.
+// as formulas, charts, and so on. The references in the formulas, defined
+// names, merged cells, tables, data validations, conditional formats,
+// comments and chart series will be updated, the comments on the deleted cells
+// will be removed, and the references to the deleted cells will be replaced
+// with the #REF! error.
 func (f *File) RemoveRow(sheet string, row int) error {
+	if err := f.checkReadOnly("RemoveRow"); err != nil {
+		return err
+	}
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -580,10 +1096,15 @@ func (f *File) RemoveRow(sheet string, row int) error {
 //	err := f.InsertRows("Sheet1", 3, 2)
 //
 // Use this method with caution, which will affect changes in references such
-// as formulas, charts, and so on. If there is any referenced value of the
-// worksheet, it will cause a file error when you open it. The excelize only
-// partially updates these references currently.
+// as formulas, charts, and so on. The references in the formulas, defined
+// names, merged cells, tables, data validations, conditional formats,
+// comments and chart series will be updated, the comments on the deleted cells
+// will be removed, and the references to the deleted cells will be replaced
+// with the #REF! error.
 func (f *File) InsertRows(sheet string, row, n int) error {
+	if err := f.checkReadOnly("InsertRows"); err != nil {
+		return err
+	}
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -601,10 +1122,15 @@ func (f *File) InsertRows(sheet string, row, n int) error {
 //	err := f.DuplicateRow("Sheet1", 2)
 //
 // Use this method with caution, which will affect changes in references such
-// as formulas, charts, and so on. If there is any referenced value of the
-// worksheet, it will cause a file error when you open it. The excelize only
-// partially updates these references currently.
+// as formulas, charts, and so on. The references in the formulas, defined
+// names, merged cells, tables, data validations, conditional formats,
+// comments and chart series will be updated, the comments on the deleted cells
+// will be removed, and the references to the deleted cells will be replaced
+// with the #REF! error.
 func (f *File) DuplicateRow(sheet string, row int) error {
+	if err := f.checkReadOnly("DuplicateRow"); err != nil {
+		return err
+	}
 	return f.DuplicateRowTo(sheet, row, row+1)
 }
 
@@ -613,11 +1139,20 @@ func (f *File) DuplicateRow(sheet string, row int) error {
 //
 //	err := f.DuplicateRowTo("Sheet1", 2, 7)
 //
+// The conditional formats and data validations which cover the copied row
+// will be applied to the destination row, and the hyperlinks and notes on the
+// copied row will be copied to the destination row.
+//
 // Use this method with caution, which will affect changes in references such
-// as formulas, charts, and so on. If there is any referenced value of the
-// worksheet, it will cause a file error when you open it. The excelize only
-// partially updates these references currently.
+// as formulas, charts, and so on. The references in the formulas, defined
+// names, merged cells, tables, data validations, conditional formats,
+// comments and chart series will be updated, the comments on the deleted cells
+// will be removed, and the references to the deleted cells will be replaced
+// with the #REF! error.
 func (f *File) DuplicateRowTo(sheet string, row, row2 int) error {
+	if err := f.checkReadOnly("DuplicateRowTo"); err != nil {
+		return err
+	}
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -669,9 +1204,127 @@ func (f *File) DuplicateRowTo(sheet string, row, row2 int) error {
 	} else {
 		ws.SheetData.Row = append(ws.SheetData.Row, rowCopy)
 	}
+	f.duplicateSqrefs(ws, row, row2)
+	f.duplicateHyperlinks(ws, sheet, row, row2)
+	if err := f.duplicateComments(sheet, row, row2); err != nil {
+		return err
+	}
 	return f.duplicateMergeCells(sheet, ws, row, row2)
 }
 
+// duplicateSqrefs extends the ranges of the conditional formats and data
+// validations which cover the copied row to the destination row, the rules
+// which don't cover the copied row will not be changed.
+func (f *File) duplicateSqrefs(ws *xlsxWorksheet, row, row2 int) {
+	if row > row2 {
+		row++
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		cf.SQRef = duplicateSqref(cf.SQRef, row, row2)
+	}
+	if ws.DataValidations != nil {
+		for _, dv := range ws.DataValidations.DataValidation {
+			dv.Sqref = duplicateSqref(dv.Sqref, row, row2)
+		}
+	}
+}
+
+// duplicateSqref provides a function to add the destination row to the
+// space-separated list of range references by given copied row and the
+// destination row, the range will be extended if it's adjacent to the
+// destination row.
+func duplicateSqref(sqref string, row, row2 int) string {
+	refs := strings.Fields(sqref)
+	rects := make([][]int, len(refs))
+	for i, ref := range refs {
+		if _, rect, err := parseRangeRef(ref); err == nil {
+			rects[i] = rect
+		}
+	}
+	for _, rect := range rects {
+		if rect == nil || rect[1] > row || rect[3] < row {
+			continue
+		}
+		var covered bool
+		for i, r := range rects {
+			if r == nil || r[0] > rect[0] || r[2] < rect[2] {
+				continue
+			}
+			if covered = r[1] <= row2 && row2 <= r[3]; covered {
+				break
+			}
+			if r[0] != rect[0] || r[2] != rect[2] {
+				continue
+			}
+			if covered = r[3]+1 == row2; covered {
+				r[3] = row2
+			} else if covered = r[1]-1 == row2; covered {
+				r[1] = row2
+			}
+			if covered {
+				refs[i] = rectToRangeRef(r)
+				break
+			}
+		}
+		if !covered {
+			newRect := []int{rect[0], row2, rect[2], row2}
+			refs, rects = append(refs, rectToRangeRef(newRect)), append(rects, newRect)
+		}
+	}
+	return strings.Join(refs, " ")
+}
+
+// duplicateHyperlinks copies the hyperlinks on the copied row to the
+// destination row, the relationship of the external hyperlink will be copied,
+// so that the hyperlinks don't share the same relationship.
+func (f *File) duplicateHyperlinks(ws *xlsxWorksheet, sheet string, row, row2 int) {
+	if ws.Hyperlinks == nil {
+		return
+	}
+	if row > row2 {
+		row++
+	}
+	sheetPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		_, rect, err := parseRangeRef(link.Ref)
+		if err != nil || rect[1] != row || rect[3] != row {
+			continue
+		}
+		link.Ref = rectToRangeRef([]int{rect[0], row2, rect[2], row2})
+		if link.RID != "" {
+			target := f.getSheetRelationshipsTargetByID(sheet, link.RID)
+			link.RID = "rId" + strconv.Itoa(f.addRels(sheetRels, SourceRelationshipHyperLink, target, "External"))
+		}
+		ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink, link)
+	}
+}
+
+// duplicateComments copies the notes on the copied row to the destination
+// row, the placeholder comments of the threaded comments will not be copied.
+func (f *File) duplicateComments(sheet string, row, row2 int) error {
+	if row > row2 {
+		row++
+	}
+	notes, err := f.GetNotes(sheet)
+	if err != nil {
+		return err
+	}
+	for _, note := range notes {
+		col, r, err := CellNameToCoordinates(note.Cell)
+		if err != nil || r != row {
+			continue
+		}
+		if note.Cell, err = CoordinatesToCellName(col, row2); err != nil {
+			return err
+		}
+		if err = f.AddNote(sheet, note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // duplicateMergeCells merge cells in the destination row if there are single
 // row merged cells in the copied row.
 func (f *File) duplicateMergeCells(sheet string, ws *xlsxWorksheet, row, row2 int) error {
@@ -806,6 +1459,9 @@ func (r *xlsxRow) hasAttr() bool {
 //
 //	err := f.SetRowStyle("Sheet1", 1, 10, styleID)
 func (f *File) SetRowStyle(sheet string, start, end, styleID int) error {
+	if err := f.checkReadOnly("SetRowStyle"); err != nil {
+		return err
+	}
 	if end < start {
 		start, end = end, start
 	}
@@ -841,12 +1497,42 @@ func (f *File) SetRowStyle(sheet string, start, end, styleID int) error {
 	return nil
 }
 
-// convertRowHeightToPixels provides a function to convert the height of a
-// cell from user's units to pixels. If the height hasn't been set by the user
-// we use the default value. If the row is hidden it has a value of zero.
-func convertRowHeightToPixels(height float64) float64 {
+// GetRowStyle provides a function to get the style ID of the row by given
+// worksheet name and row number. It will return 0 if the row doesn't have a
+// custom style. This function is concurrency safe. For example, get the style
+// ID of row 2 on Sheet1:
+//
+//	styleID, err := f.GetRowStyle("Sheet1", 2)
+func (f *File) GetRowStyle(sheet string, row int) (int, error) {
+	if row < 1 {
+		return 0, newInvalidRowNumberError(row)
+	}
+	if row > TotalRows {
+		return 0, ErrMaxRows
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return 0, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, r := range ws.SheetData.Row {
+		if r.R == row {
+			return r.S, err
+		}
+	}
+	return 0, err
+}
+
+// RowHeightToPixels provides a function to convert the height of a row from
+// points to pixels at 96 DPI. If the height hasn't been set by the user we use
+// the default value. If the row is hidden it has a value of zero.
+func RowHeightToPixels(height float64) float64 {
 	if height == 0 {
 		return 0
 	}
-	return math.Ceil(4.0 / 3.4 * height)
+	return math.Ceil(height * 96 / 72)
 }
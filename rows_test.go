@@ -2,10 +2,14 @@ package excelize
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,6 +25,73 @@ func TestGetRows(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetSheetRowsStruct(t *testing.T) {
+	type Audit struct {
+		Author string
+	}
+	type Order struct {
+		*Audit
+		ID      int       `excel:"Order ID"`
+		Amount  float64   `excel:"Amount,format=#,##0.00"`
+		Date    time.Time `excel:",format=yyyy-mm-dd"`
+		Paid    *bool
+		Count   uint8  `excel:"count"`
+		Comment string `excel:"-"`
+	}
+	paid := true
+	date := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRowStruct("Sheet1", "A1", []Order{
+		{Audit: &Audit{Author: "Alice"}, ID: 1, Amount: 1234.5, Date: date, Paid: &paid},
+		{ID: 2, Amount: 99, Date: date},
+	}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F1", "COUNT"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F2", 3))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D3", "2023-05-02 08:30:00"))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A5", &[]interface{}{"", 3.0, nil, "2023/05/03"}))
+
+	var orders []*Order
+	assert.NoError(t, f.GetSheetRowsStruct("Sheet1", &orders))
+	assert.Equal(t, []*Order{
+		{Audit: &Audit{Author: "Alice"}, ID: 1, Amount: 1234.5, Date: date, Paid: &paid, Count: 3},
+		{ID: 2, Amount: 99, Date: time.Date(2023, 5, 2, 8, 30, 0, 0, time.UTC)},
+		{ID: 3, Date: time.Date(2023, 5, 3, 0, 0, 0, 0, time.UTC)},
+	}, orders)
+	var values []Order
+	assert.NoError(t, f.GetSheetRowsStruct("Sheet1", &values))
+	assert.Len(t, values, 3)
+	assert.Equal(t, "Alice", values[0].Author)
+
+	// Test get rows by struct with invalid cell value
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", "N/A"))
+	assert.EqualError(t, f.GetSheetRowsStruct("Sheet1", &orders), "cannot convert the value \"N/A\" of the cell C3 to the field Amount: strconv.ParseFloat: parsing \"N/A\": invalid syntax")
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 99))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", 3.5))
+	assert.EqualError(t, f.GetSheetRowsStruct("Sheet1", &orders), "cannot convert the value \"3.5\" of the cell B5 to the field Order ID: strconv.ParseInt: parsing \"3.5\": invalid syntax")
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", 3))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F2", 256))
+	assert.EqualError(t, f.GetSheetRowsStruct("Sheet1", &orders), "cannot convert the value \"256\" of the cell F2 to the field count: strconv.ParseUint: parsing \"256\": value out of range")
+	assert.NoError(t, f.SetCellValue("Sheet1", "F2", 3))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E2", "yes"))
+	assert.EqualError(t, f.GetSheetRowsStruct("Sheet1", &orders), "cannot convert the value \"yes\" of the cell E2 to the field Paid: strconv.ParseBool: parsing \"yes\": invalid syntax")
+	assert.NoError(t, f.SetCellValue("Sheet1", "E2", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D2", "May 1"))
+	assert.EqualError(t, f.GetSheetRowsStruct("Sheet1", &orders), "cannot convert the value \"May 1\" of the cell D2 to the field Date: parsing time \"May 1\" as \"2006-01-02\": cannot parse \"May 1\" as \"2006\"")
+	// Test get rows by struct with unsupported field type
+	assert.EqualError(t, f.GetSheetRowsStruct("Sheet1", &[]struct{ Amount []string }{}), "unsupported type []string of the field Amount")
+	// Test get rows by invalid value
+	assert.Equal(t, ErrParameterInvalid, f.GetSheetRowsStruct("Sheet1", orders))
+	assert.Equal(t, ErrParameterInvalid, f.GetSheetRowsStruct("Sheet1", &[]int{}))
+	assert.Equal(t, ErrParameterInvalid, f.GetSheetRowsStruct("Sheet1", nil))
+	// Test get rows by struct on not exists worksheet
+	assert.EqualError(t, f.GetSheetRowsStruct("SheetN", &orders), "sheet SheetN does not exist")
+	// Test get rows by struct with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.GetSheetRowsStruct("Sheet1", &orders), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestRows(t *testing.T) {
 	const sheet2 = "Sheet2"
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
@@ -73,12 +144,15 @@ func TestRows(t *testing.T) {
 	assert.NoError(t, f.Close())
 
 	// Test rows iterator with unsupported charset shared strings table
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
 	f.SharedStrings = nil
 	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
 	rows, err = f.Rows(sheet2)
 	assert.NoError(t, err)
 	_, err = rows.Columns()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
 }
 
 func TestRowsIterator(t *testing.T) {
@@ -138,6 +212,148 @@ func TestRowsGetRowOpts(t *testing.T) {
 	assert.Equal(t, expectedRowStyleID3, rowOpts)
 }
 
+func TestRowsSnapshot(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 10; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), fmt.Sprintf("Row %d", row)))
+	}
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	// Test the changes made after creating the rows iterator are invisible
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for row := 1; row <= 20; row++ {
+			assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), fmt.Sprintf("Changed %d", row)))
+		}
+		assert.NoError(t, f.OptimizeSharedStrings())
+	}()
+	var results [][]string
+	for rows.Next() {
+		cols, err := rows.Columns()
+		assert.NoError(t, err)
+		results = append(results, cols)
+		if len(results) == 5 {
+			<-done
+		}
+	}
+	assert.NoError(t, rows.Close())
+	if assert.Len(t, results, 10) {
+		for idx, cols := range results {
+			assert.Equal(t, []string{fmt.Sprintf("Row %d", idx+1)}, cols)
+		}
+	}
+	// Test the rows iterator reads the latest data
+	result, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, result, 20)
+	assert.Equal(t, []string{"Changed 20"}, result[19])
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamRows(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	require.NoError(t, err)
+	expected, err := f.GetRows("Sheet2")
+	assert.NoError(t, err)
+	var collectedRows [][]string
+	assert.NoError(t, f.StreamRows("Sheet2", func(row int, cols []string) error {
+		assert.Equal(t, len(collectedRows)+1, row)
+		collectedRows = append(collectedRows, cols)
+		return nil
+	}))
+	assert.Equal(t, expected, collectedRows[:len(expected)])
+	// Test stop traversal by callback function error
+	errStop, count := errors.New("stop"), 0
+	assert.Equal(t, errStop, f.StreamRows("Sheet2", func(row int, cols []string) error {
+		if count++; row == 2 {
+			return errStop
+		}
+		return nil
+	}))
+	assert.Equal(t, 2, count)
+	// Test stream rows with not exist worksheet
+	assert.EqualError(t, f.StreamRows("SheetN", nil), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test stream rows with shared string table in system temporary directory
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	require.NoError(t, err)
+	var cells []string
+	assert.NoError(t, f.StreamRows("Sheet1", func(row int, cols []string) error {
+		if row == 19 {
+			cells = cols
+		}
+		return nil
+	}))
+	assert.Equal(t, "Total:", cells[0])
+	assert.NoError(t, f.Close())
+
+	// Test stream rows without parsing the shared string table into memory
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"))
+	require.NoError(t, err)
+	collectedRows = nil
+	assert.NoError(t, f.StreamRows("Sheet2", func(row int, cols []string) error {
+		collectedRows = append(collectedRows, cols)
+		return nil
+	}))
+	assert.Nil(t, f.SharedStrings)
+	assert.Equal(t, expected, collectedRows[:len(expected)])
+	assert.NoError(t, f.Close())
+
+	// Test stream rows with omitted empty rows
+	f = NewFile()
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row = []xlsxRow{{R: 3, C: []xlsxC{{R: "B3", T: "s", V: "0"}}}}
+	f.Pkg.Store(defaultXMLPathSharedStrings, []byte(`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><si><t>a</t></si></sst>`))
+	collectedRows = nil
+	assert.NoError(t, f.StreamRows("Sheet1", func(row int, cols []string) error {
+		assert.Equal(t, len(collectedRows)+1, row)
+		collectedRows = append(collectedRows, cols)
+		return nil
+	}))
+	assert.Equal(t, [][]string{nil, nil, {"", "a"}}, collectedRows)
+	assert.NoError(t, f.Close())
+
+	// Test stream rows with exceeds the maximum number of cells
+	f = NewFile(Options{MaxCellCount: 1})
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2}))
+	assert.Equal(t, ErrCellCountLimit, f.StreamRows("Sheet1", func(row int, cols []string) error {
+		return nil
+	}))
+	assert.NoError(t, f.Close())
+
+	// Test stream rows with exceeds the maximum number of shared strings
+	f = NewFile(Options{MaxSharedStringCount: 1})
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"a", "b"}))
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, []byte(`<sst><si><t>a</t></si><si><t>b</t></si></sst>`))
+	assert.Equal(t, ErrSharedStringCountLimit, f.StreamRows("Sheet1", func(row int, cols []string) error {
+		return nil
+	}))
+	assert.NoError(t, f.Close())
+
+	// Test stream rows with invalid cell reference
+	f = NewFile()
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row = []xlsxRow{{R: 1, C: []xlsxC{{R: "A"}}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.StreamRows("Sheet1", func(row int, cols []string) error {
+		return nil
+	}))
+	assert.NoError(t, f.Close())
+
+	// Test stream rows with unsupported charset shared strings table
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.StreamRows("Sheet1", func(row int, cols []string) error {
+		return nil
+	}), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestRowsError(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -211,7 +427,7 @@ func TestRowHeight(t *testing.T) {
 		t.FailNow()
 	}
 
-	assert.Equal(t, 0.0, convertColWidthToPixels(0))
+	assert.Equal(t, 0.0, ColWidthToPixels(0))
 }
 
 func TestColumns(t *testing.T) {
@@ -891,6 +1107,69 @@ func TestDuplicateRowTo(t *testing.T) {
 	assert.EqualError(t, f.DuplicateRowTo("Sheet:1", 1, 2), ErrSheetNameInvalid.Error())
 }
 
+func TestDuplicateRowSqrefsAndHyperlinks(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 10; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), row))
+	}
+	format := []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Value: "5"}}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A2:B10", format))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C5:C6", format))
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A3"
+	assert.NoError(t, dv.SetRange(1, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A3", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B3", "Sheet1!A1", "Location"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).Hyperlinks.Hyperlink = append(ws.(*xlsxWorksheet).Hyperlinks.Hyperlink, xlsxHyperlink{Ref: "C3:D3", Location: "Sheet1!A2"})
+	// Test duplicate row within the range of the rules
+	assert.NoError(t, f.DuplicateRowTo("Sheet1", 3, 4))
+	assert.Equal(t, "A2:B11", ws.(*xlsxWorksheet).ConditionalFormatting[0].SQRef)
+	assert.Equal(t, "C6:C7", ws.(*xlsxWorksheet).ConditionalFormatting[1].SQRef)
+	assert.Equal(t, "A1:A4", ws.(*xlsxWorksheet).DataValidations.DataValidation[0].Sqref)
+	// Test duplicate row to the adjacent row of the rules
+	assert.NoError(t, f.DuplicateRowTo("Sheet1", 4, 5))
+	assert.Equal(t, "A2:B12", ws.(*xlsxWorksheet).ConditionalFormatting[0].SQRef)
+	assert.Equal(t, "A1:A5", ws.(*xlsxWorksheet).DataValidations.DataValidation[0].Sqref)
+	// Test duplicate row out of the range of the rules
+	assert.NoError(t, f.DuplicateRowTo("Sheet1", 1, 20))
+	assert.Equal(t, "A2:B12", ws.(*xlsxWorksheet).ConditionalFormatting[0].SQRef)
+	assert.Equal(t, "A1:A5 A20", ws.(*xlsxWorksheet).DataValidations.DataValidation[0].Sqref)
+	assert.NoError(t, f.DuplicateRowTo("Sheet1", 12, 15))
+	assert.Equal(t, "A2:B12 A15:B15", ws.(*xlsxWorksheet).ConditionalFormatting[0].SQRef)
+	assert.Equal(t, "C7:C8", ws.(*xlsxWorksheet).ConditionalFormatting[1].SQRef)
+	// Test the hyperlinks are copied with their own relationships
+	refs := map[string]xlsxHyperlink{}
+	for _, link := range ws.(*xlsxWorksheet).Hyperlinks.Hyperlink {
+		refs[link.Ref] = link
+	}
+	assert.Len(t, refs, 9)
+	for _, ref := range []string{"A3", "A4", "A5"} {
+		link, target, err := f.GetCellHyperLink("Sheet1", ref)
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, "https://github.com/xuri/excelize", target)
+	}
+	assert.NotEqual(t, refs["A3"].RID, refs["A4"].RID)
+	assert.NotEqual(t, refs["A4"].RID, refs["A5"].RID)
+	assert.Equal(t, "Sheet1!A1", refs["B5"].Location)
+	assert.Equal(t, "Sheet1!A2", refs["C4:D4"].Location)
+	// Test remove the duplicated row keeps the hyperlinks on other rows
+	assert.NoError(t, f.RemoveRow("Sheet1", 4))
+	link, target, err := f.GetCellHyperLink("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	link, target, err = f.GetCellHyperLink("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDuplicateRowSqrefsAndHyperlinks.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
 func TestDuplicateMergeCells(t *testing.T) {
 	f := File{}
 	ws := &xlsxWorksheet{MergeCells: &xlsxMergeCells{
@@ -899,13 +1178,22 @@ func TestDuplicateMergeCells(t *testing.T) {
 	assert.EqualError(t, f.duplicateMergeCells("Sheet1", ws, 0, 0), `cannot convert cell "-" to coordinates: invalid cell name "-"`)
 	ws.MergeCells.Cells[0].Ref = "A1:B1"
 	assert.EqualError(t, f.duplicateMergeCells("SheetN", ws, 1, 2), "sheet SheetN does not exist")
+	// Test duplicate the merged cells which overlap the existing merged cells
+	// in the destination row will return an error
+	f2 := NewFile()
+	assert.NoError(t, f2.MergeCell("Sheet1", "A1", "B1"))
+	assert.NoError(t, f2.MergeCell("Sheet1", "A2", "A3"))
+	ws, err := f2.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrMergeCellOverlap{Ref: "A2:B2", OverlapRef: "A2:A3"}, f2.duplicateMergeCells("Sheet1", ws, 1, 2))
+	assert.NoError(t, f2.Close())
 }
 
 func TestGetValueFromInlineStr(t *testing.T) {
 	c := &xlsxC{T: "inlineStr"}
 	f := NewFile()
 	d := &xlsxSST{}
-	val, err := c.getValueFrom(f, d, false)
+	val, err := c.getValueFrom(f, d, false, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, "", val)
 }
@@ -925,7 +1213,7 @@ func TestGetValueFromNumber(t *testing.T) {
 		"2.220000ddsf0000000002-r": "2.220000ddsf0000000002-r",
 	} {
 		c.V = input
-		val, err := c.getValueFrom(f, d, false)
+		val, err := c.getValueFrom(f, d, false, 0)
 		assert.NoError(t, err)
 		assert.Equal(t, expected, val)
 	}
@@ -975,6 +1263,18 @@ func TestSetRowStyle(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, style2, cellStyleID)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRowStyle.xlsx")))
+	// Test get row style
+	for row, expected := range map[int]int{1: style2, 5: style2, 6: 0, 100: 0} {
+		styleID, err := f.GetRowStyle("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID, row)
+	}
+	_, err = f.GetRowStyle("Sheet1", 0)
+	assert.EqualError(t, err, newInvalidRowNumberError(0).Error())
+	_, err = f.GetRowStyle("Sheet1", TotalRows+1)
+	assert.EqualError(t, err, ErrMaxRows.Error())
+	_, err = f.GetRowStyle("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
 	// Test set row style with unsupported charset style sheet
 	f.Styles = nil
 	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
@@ -1100,6 +1400,63 @@ func BenchmarkRows(b *testing.B) {
 	}
 }
 
+func BenchmarkStreamRows(b *testing.B) {
+	for _, rows := range []int{1000, 10000, 100000} {
+		b.Run(strconv.Itoa(rows), func(b *testing.B) {
+			f := NewFile()
+			sw, err := f.NewStreamWriter("Sheet1")
+			if err != nil {
+				b.Fatal(err)
+			}
+			for row := 1; row <= rows; row++ {
+				cell, _ := CoordinatesToCellName(1, row)
+				if err := sw.SetRow(cell, []interface{}{row, "text" + strconv.Itoa(row%100), 1.5, true}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if err := sw.Flush(); err != nil {
+				b.Fatal(err)
+			}
+			path := filepath.Join(b.TempDir(), "BenchmarkStreamRows.xlsx")
+			if err := f.SaveAs(path); err != nil {
+				b.Fatal(err)
+			}
+			if err := f.Close(); err != nil {
+				b.Fatal(err)
+			}
+			// Store the worksheet in the system temporary directory, and
+			// report the peak heap size in use during the traversal
+			if f, err = OpenFile(path, Options{UnzipXMLSizeLimit: 1024}); err != nil {
+				b.Fatal(err)
+			}
+			var memStats runtime.MemStats
+			var peak uint64
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				runtime.GC()
+				runtime.ReadMemStats(&memStats)
+				base := memStats.HeapInuse
+				if err := f.StreamRows("Sheet1", func(row int, cols []string) error {
+					if row%1000 == 0 {
+						runtime.ReadMemStats(&memStats)
+						if memStats.HeapInuse > base && memStats.HeapInuse-base > peak {
+							peak = memStats.HeapInuse - base
+						}
+					}
+					return nil
+				}); err != nil {
+					b.Error(err)
+				}
+			}
+			b.ReportMetric(float64(peak), "peak-heap-B")
+			if err := f.Close(); err != nil {
+				b.Error(err)
+			}
+		})
+	}
+}
+
 // trimSliceSpace trim continually blank element in the tail of slice.
 func trimSliceSpace(s []string) []string {
 	for {
@@ -1111,3 +1468,52 @@ func trimSliceSpace(s []string) []string {
 	}
 	return s
 }
+
+func TestGetRowsFillMergedCells(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"horizontal", nil, nil, "D1"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "vertical"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", "overlap"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A2", "A4"))
+	// Test the merged ranges without value
+	assert.NoError(t, f.MergeCell("Sheet1", "E1", "E2"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells = append(ws.(*xlsxWorksheet).MergeCells.Cells,
+		&xlsxMergeCell{Ref: "C3:D4"}, &xlsxMergeCell{Ref: "D4:B5"}, nil)
+
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"horizontal", "", "", "D1"}, {"vertical"}, {"", "", "overlap"}}, rows)
+
+	rows, err = f.GetRows("Sheet1", Options{FillMergedCells: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"horizontal", "horizontal", "horizontal", "D1"},
+		{"vertical"},
+		{"vertical", "", "overlap", "overlap"},
+		{"vertical", "", "overlap", "overlap"},
+	}, rows)
+
+	// Test get rows with thousands of merged ranges
+	f = NewFile()
+	for row := 1; row <= 4000; row += 2 {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), row))
+		assert.NoError(t, f.MergeCell("Sheet1", fmt.Sprintf("A%d", row), fmt.Sprintf("B%d", row+1)))
+	}
+	rows, err = f.GetRows("Sheet1", Options{FillMergedCells: true})
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 4000) {
+		assert.Equal(t, []string{"3999", "3999"}, rows[3999])
+	}
+	assert.NoError(t, f.Close())
+
+	// Test get rows with invalid merged range reference
+	f = NewFile()
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	_, err = f.GetRows("Sheet1", Options{FillMergedCells: true})
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
@@ -289,6 +289,9 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 //	wavyHeavy
 //	wavyDbl
 func (f *File) AddShape(sheet string, opts *Shape) error {
+	if err := f.checkReadOnly("AddShape"); err != nil {
+		return err
+	}
 	options, err := parseShapeOptions(opts)
 	if err != nil {
 		return err
@@ -332,8 +335,9 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 	width := int(float64(opts.Width) * opts.Format.ScaleX)
 	height := int(float64(opts.Height) * opts.Format.ScaleY)
 
-	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, fromCol, fromRow, opts.Format.OffsetX, opts.Format.OffsetY,
-		width, height)
+	offsetX, offsetY := opts.Format.offsetEMUs()
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectEMUs(sheet, fromCol, fromRow, offsetX, offsetY,
+		width*EMU, height*EMU)
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return err
@@ -342,14 +346,14 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 	twoCellAnchor.EditAs = opts.Format.Positioning
 	from := xlsxFrom{}
 	from.Col = colStart
-	from.ColOff = opts.Format.OffsetX * EMU
+	from.ColOff = offsetX
 	from.Row = rowStart
-	from.RowOff = opts.Format.OffsetY * EMU
+	from.RowOff = offsetY
 	to := xlsxTo{}
 	to.Col = colEnd
-	to.ColOff = x2 * EMU
+	to.ColOff = x2
 	to.Row = rowEnd
-	to.RowOff = y2 * EMU
+	to.RowOff = y2
 	twoCellAnchor.From = &from
 	twoCellAnchor.To = &to
 	var solidColor string
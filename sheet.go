@@ -30,11 +30,24 @@ import (
 	"github.com/mohae/deepcopy"
 )
 
+// SheetVisibility is the type of sheet visibility state.
+type SheetVisibility byte
+
+// Sheet visibility states enumeration.
+const (
+	SheetVisible SheetVisibility = iota
+	SheetHidden
+	SheetVeryHidden
+)
+
 // NewSheet provides the function to create a new sheet by given a worksheet
 // name and returns the index of the sheets in the workbook after it appended.
 // Note that when creating a new workbook, the default worksheet named
 // `Sheet1` will be created.
 func (f *File) NewSheet(sheet string) (int, error) {
+	if err := f.checkReadOnly("NewSheet"); err != nil {
+		return -1, err
+	}
 	var err error
 	if err = checkSheetName(sheet); err != nil {
 		return -1, err
@@ -102,77 +115,122 @@ func (f *File) getWorksheetPath(relTarget string) (path string) {
 	return path
 }
 
-// mergeExpandedCols merge expanded columns.
+// mergeExpandedCols merge expanded columns. The adjacent columns with the same
+// width, style and other properties will be collapsed into a single column
+// definition.
 func (f *File) mergeExpandedCols(ws *xlsxWorksheet) {
-	sort.Slice(ws.Cols.Col, func(i, j int) bool {
+	sort.SliceStable(ws.Cols.Col, func(i, j int) bool {
 		return ws.Cols.Col[i].Min < ws.Cols.Col[j].Min
 	})
-	var columns []xlsxCol
-	for i, n := 0, len(ws.Cols.Col); i < n; {
-		left := i
-		for i++; i < n && reflect.DeepEqual(
-			xlsxCol{
-				BestFit:      ws.Cols.Col[i-1].BestFit,
-				Collapsed:    ws.Cols.Col[i-1].Collapsed,
-				CustomWidth:  ws.Cols.Col[i-1].CustomWidth,
-				Hidden:       ws.Cols.Col[i-1].Hidden,
-				Max:          ws.Cols.Col[i-1].Max + 1,
-				Min:          ws.Cols.Col[i-1].Min + 1,
-				OutlineLevel: ws.Cols.Col[i-1].OutlineLevel,
-				Phonetic:     ws.Cols.Col[i-1].Phonetic,
-				Style:        ws.Cols.Col[i-1].Style,
-				Width:        ws.Cols.Col[i-1].Width,
-			}, ws.Cols.Col[i]); i++ {
-		}
-		column := deepcopy.Copy(ws.Cols.Col[left]).(xlsxCol)
-		if left < i-1 {
-			column.Max = ws.Cols.Col[i-1].Min
-		}
-		columns = append(columns, column)
+	columns := make([]xlsxCol, 0, len(ws.Cols.Col))
+	for _, col := range ws.Cols.Col {
+		if n := len(columns); n > 0 && columns[n-1].Max+1 == col.Min {
+			prev := columns[n-1]
+			prev.Min, prev.Max = col.Min, col.Max
+			if reflect.DeepEqual(prev, col) {
+				columns[n-1].Max = col.Max
+				continue
+			}
+		}
+		columns = append(columns, deepcopy.Copy(col).(xlsxCol))
 	}
 	ws.Cols.Col = columns
 }
 
-// workSheetWriter provides a function to save xl/worksheets/sheet%d.xml after
-// serialize structure.
-func (f *File) workSheetWriter() {
-	var (
-		arr     []byte
-		buffer  = bytes.NewBuffer(arr)
-		encoder = xml.NewEncoder(buffer)
-	)
-	f.Sheet.Range(func(p, ws interface{}) bool {
-		if ws != nil {
-			sheet := ws.(*xlsxWorksheet)
-			if sheet.MergeCells != nil && len(sheet.MergeCells.Cells) > 0 {
-				_ = f.mergeOverlapCells(sheet)
-			}
-			if sheet.Cols != nil && len(sheet.Cols.Col) > 0 {
-				f.mergeExpandedCols(sheet)
-			}
-			sheet.SheetData.Row = trimRow(&sheet.SheetData)
-			if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
-				f.addNameSpaces(p.(string), SourceRelationship)
-			}
-			if sheet.DecodeAlternateContent != nil {
-				sheet.AlternateContent = &xlsxAlternateContent{
-					Content: sheet.DecodeAlternateContent.Content,
-					XMLNSMC: SourceRelationshipCompatibility.Value,
-				}
-			}
-			sheet.DecodeAlternateContent = nil
-			// reusing buffer
-			_ = encoder.Encode(sheet)
-			f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string), buffer.Bytes())))
-			ok := f.checked[p.(string)]
-			if ok {
-				f.Sheet.Delete(p.(string))
-				f.checked[p.(string)] = false
-			}
-			buffer.Reset()
+// flushWorkSheet provides a function to serialize the worksheet by given
+// worksheet XML path and save it into the file list.
+func (f *File) flushWorkSheet(p string, sheet *xlsxWorksheet, buffer *bytes.Buffer, encoder *xml.Encoder) {
+	f.prepareWorkSheet(p, sheet)
+	// reusing buffer
+	_ = encoder.Encode(sheet)
+	f.saveFileList(p, replaceRelationshipsBytes(f.replaceNameSpaceBytes(p, buffer.Bytes())))
+}
+
+// writeWorkSheet provides a function to serialize the worksheet by given
+// worksheet XML path and write it to the given writer incrementally without
+// buffering the whole worksheet XML in memory.
+func (f *File) writeWorkSheet(w io.Writer, p string, sheet *xlsxWorksheet) error {
+	f.prepareWorkSheet(p, sheet)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	source, target := f.nameSpaceReplacement(p)
+	rw := &replaceWriter{w: w, old: []byte(`xmlns:relationships="http://schemas.openxmlformats.org/officeDocument/2006/relationships" relationships`), new: []byte("r")}
+	nw := &replaceWriter{w: rw, old: source, new: target}
+	if err := xml.NewEncoder(nw).Encode(sheet); err != nil {
+		return err
+	}
+	if err := nw.Flush(); err != nil {
+		return err
+	}
+	return rw.Flush()
+}
+
+// prepareWorkSheet provides a function to prepare the worksheet for
+// serialization by given worksheet XML path.
+func (f *File) prepareWorkSheet(p string, sheet *xlsxWorksheet) {
+	if sheet.MergeCells != nil && len(sheet.MergeCells.Cells) > 0 {
+		_ = f.mergeOverlapCells(sheet)
+	}
+	if sheet.Cols != nil && len(sheet.Cols.Col) > 0 {
+		f.mergeExpandedCols(sheet)
+	}
+	sheet.SheetData.Row = trimRow(&sheet.SheetData)
+	if f.options != nil && f.options.UpdateSheetDimensions {
+		sheet.updateDimension()
+	}
+	if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
+		f.addNameSpaces(p, SourceRelationship)
+	}
+	if sheet.DecodeAlternateContent != nil {
+		sheet.AlternateContent = &xlsxAlternateContent{
+			Content: sheet.DecodeAlternateContent.Content,
+			XMLNSMC: SourceRelationshipCompatibility.Value,
 		}
-		return true
-	})
+	}
+	sheet.DecodeAlternateContent = nil
+}
+
+// ReleaseSheet provides a function to release the parsed worksheet by given
+// worksheet name. The worksheet parts are parsed on demand at the first time
+// of access, and the parsed worksheet will be kept in memory until the
+// workbook was saved. Use this function to serialize the parsed worksheet
+// back to the raw XML content and free the memory of the parsed worksheet
+// when it doesn't need to be accessed for a while, the worksheet will be
+// parsed again on next access. The worksheets which never be accessed are
+// written from the original content on saving the workbook. For example,
+// release the worksheet Sheet1 after reading it:
+//
+//	rows, err := f.GetRows("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.ReleaseSheet("Sheet1"); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) ReleaseSheet(sheet string) error {
+	if err := checkSheetName(sheet); err != nil {
+		return err
+	}
+	name, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return newNoExistSheetError(sheet)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ws, ok := f.Sheet.Load(name)
+	if !ok || ws == nil {
+		return nil
+	}
+	sheetXML := ws.(*xlsxWorksheet)
+	sheetXML.mu.Lock()
+	defer sheetXML.mu.Unlock()
+	buffer := new(bytes.Buffer)
+	f.flushWorkSheet(name, sheetXML, buffer, xml.NewEncoder(buffer))
+	f.Sheet.Delete(name)
+	f.checked[name] = false
+	return nil
 }
 
 // trimRow provides a function to trim empty rows.
@@ -267,8 +325,12 @@ func replaceRelationshipsBytes(content []byte) []byte {
 // SetActiveSheet provides a function to set the default active sheet of the
 // workbook by a given index. Note that the active index is different from the
 // ID returned by function GetSheetMap(). It should be greater than or equal to 0
-// and less than the total worksheet numbers.
+// and less than the total worksheet numbers. The active sheet of the read-only
+// workbook will not be changed.
 func (f *File) SetActiveSheet(index int) {
+	if f.readOnly {
+		return
+	}
 	if index < 0 {
 		index = 0
 	}
@@ -347,12 +409,103 @@ func (f *File) getActiveSheetID() int {
 	return 0
 }
 
+// MoveSheet provides a function to move the worksheet, chart sheet, or dialog
+// sheet to the given position in the workbook by the sheet name and the
+// zero-based target index. The sheet ID, relationships, defined names scope,
+// and the active sheet of the workbook will be kept, and only the tab of the
+// active sheet will be selected after moving. The sheet order of the workbook
+// could be get by the GetSheetOrder function. For example, move the worksheet
+// named Sheet3 to be the first sheet of the workbook:
+//
+//	err := f.MoveSheet("Sheet3", 0)
+func (f *File) MoveSheet(sheet string, toIndex int) error {
+	if err := f.checkReadOnly("MoveSheet"); err != nil {
+		return err
+	}
+	if err := checkSheetName(sheet); err != nil {
+		return err
+	}
+	index, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	if index == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if toIndex < 0 || toIndex >= len(wb.Sheets.Sheet) {
+		return ErrSheetIdx
+	}
+	if index == toIndex {
+		return nil
+	}
+	moveIdx := func(idx int) int {
+		switch {
+		case idx == index:
+			return toIndex
+		case index < toIndex && idx > index && idx <= toIndex:
+			return idx - 1
+		case index > toIndex && idx >= toIndex && idx < index:
+			return idx + 1
+		}
+		return idx
+	}
+	sheets := make([]xlsxSheet, len(wb.Sheets.Sheet))
+	for idx, s := range wb.Sheets.Sheet {
+		sheets[moveIdx(idx)] = s
+	}
+	wb.Sheets.Sheet = sheets
+	if wb.DefinedNames != nil {
+		for i, dn := range wb.DefinedNames.DefinedName {
+			if dn.LocalSheetID != nil {
+				localSheetID := moveIdx(*dn.LocalSheetID)
+				wb.DefinedNames.DefinedName[i].LocalSheetID = &localSheetID
+			}
+		}
+	}
+	activeTab := moveIdx(0)
+	if wb.BookViews != nil {
+		for i, view := range wb.BookViews.WorkBookView {
+			if view.ActiveTab < len(sheets) {
+				view.ActiveTab = moveIdx(view.ActiveTab)
+			}
+			if view.FirstSheet > view.ActiveTab {
+				view.FirstSheet = view.ActiveTab
+			}
+			wb.BookViews.WorkBookView[i] = view
+		}
+		if len(wb.BookViews.WorkBookView) > 0 {
+			activeTab = wb.BookViews.WorkBookView[0].ActiveTab
+		}
+	}
+	if activeTab < len(sheets) {
+		f.SetActiveSheet(activeTab)
+	}
+	return nil
+}
+
+// GetSheetOrder provides a function to get the worksheets, chart sheets, and
+// dialog sheets name list in the order of the sheet tabs of the workbook,
+// including the hidden and very hidden sheets. The index of the sheet name in
+// the list is the zero-based index used by the MoveSheet and SetActiveSheet
+// functions. This function returns the same list as the GetSheetList
+// function, since the sheets are stored in the order of the sheet tabs.
+func (f *File) GetSheetOrder() []string {
+	return f.GetSheetList()
+}
+
 // SetSheetName provides a function to set the worksheet name by given source and
 // target worksheet names. Maximum 31 characters are allowed in sheet title and
 // this function only changes the name of the sheet and will not update the
 // sheet name in the formula or reference associated with the cell. So there
 // may be problem formula error or reference missing.
 func (f *File) SetSheetName(source, target string) error {
+	if err := f.checkReadOnly("SetSheetName"); err != nil {
+		return err
+	}
 	var err error
 	if err = checkSheetName(source); err != nil {
 		return err
@@ -501,8 +654,14 @@ func (f *File) getSheetXMLPath(sheet string) (string, bool) {
 
 // SetSheetBackground provides a function to set background picture by given
 // worksheet name and file path. Supported image types: BMP, EMF, EMZ, GIF,
-// JPEG, JPG, PNG, SVG, TIF, TIFF, WMF, and WMZ.
+// JPEG, JPG, PNG, SVG, TIF, TIFF, WMF, and WMZ. The picture will be tiled to
+// fill the worksheet, the existing background picture of the worksheet will
+// be replaced. Note that the background picture only displays on the screen
+// and will not be printed.
 func (f *File) SetSheetBackground(sheet, picture string) error {
+	if err := f.checkReadOnly("SetSheetBackground"); err != nil {
+		return err
+	}
 	var err error
 	// Check picture exists first.
 	if _, err = os.Stat(picture); os.IsNotExist(err) {
@@ -514,8 +673,12 @@ func (f *File) SetSheetBackground(sheet, picture string) error {
 
 // SetSheetBackgroundFromBytes provides a function to set background picture by
 // given worksheet name, extension name and image data. Supported image types:
-// BMP, EMF, EMZ, GIF, JPEG, JPG, PNG, SVG, TIF, TIFF, WMF, and WMZ.
+// BMP, EMF, EMZ, GIF, JPEG, JPG, PNG, SVG, TIF, TIFF, WMF, and WMZ. Note that
+// the background picture only displays on the screen and will not be printed.
 func (f *File) SetSheetBackgroundFromBytes(sheet, extension string, picture []byte) error {
+	if err := f.checkReadOnly("SetSheetBackgroundFromBytes"); err != nil {
+		return err
+	}
 	if len(picture) == 0 {
 		return ErrParameterInvalid
 	}
@@ -529,6 +692,9 @@ func (f *File) setSheetBackground(sheet, extension string, file []byte) error {
 	if !ok {
 		return ErrImgExt
 	}
+	if err := f.DeleteSheetBackground(sheet); err != nil {
+		return err
+	}
 	name := f.addMedia(file, imageType)
 	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
 	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
@@ -540,12 +706,34 @@ func (f *File) setSheetBackground(sheet, extension string, file []byte) error {
 	return f.setContentTypePartImageExtensions()
 }
 
+// DeleteSheetBackground provides a function to delete the background picture
+// by given worksheet name. Note that the image file won't be deleted from the
+// document currently.
+func (f *File) DeleteSheetBackground(sheet string) error {
+	if err := f.checkReadOnly("DeleteSheetBackground"); err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.Picture == nil {
+		return err
+	}
+	f.deleteSheetRelationships(sheet, ws.Picture.RID)
+	ws.Picture = nil
+	return err
+}
+
 // DeleteSheet provides a function to delete worksheet in a workbook by given
 // worksheet name. Use this method with caution, which will affect changes in
 // references such as formulas, charts, and so on. If there is any referenced
 // value of the deleted worksheet, it will cause a file error when you open
 // it. This function will be invalid when only one worksheet is left.
 func (f *File) DeleteSheet(sheet string) error {
+	if err := f.checkReadOnly("DeleteSheet"); err != nil {
+		return err
+	}
 	if err := checkSheetName(sheet); err != nil {
 		return err
 	}
@@ -658,6 +846,9 @@ func (f *File) deleteSheetFromContentTypes(target string) error {
 //	}
 //	err := f.CopySheet(1, index)
 func (f *File) CopySheet(from, to int) error {
+	if err := f.checkReadOnly("CopySheet"); err != nil {
+		return err
+	}
 	if from < 0 || to < 0 || from == to || f.GetSheetName(from) == "" || f.GetSheetName(to) == "" {
 		return ErrSheetIdx
 	}
@@ -693,6 +884,503 @@ func (f *File) copySheet(from, to int) error {
 	return err
 }
 
+// CopySheetFrom provides a function to copy the worksheet from another
+// workbook by given source workbook, source and destination worksheet name.
+// The destination worksheet will be created if it doesn't exist, otherwise
+// its contents will be replaced. This function copies the cell values and
+// formulas, styles, merged cells, column widths, row heights, data
+// validations, conditional formats, hyperlinks, images, charts and the
+// defined names scoped to the worksheet. The cell styles and the conditional
+// formats will be remapped into the style sheet of the destination workbook,
+// and the existing same formats will be reused. Tables, comments, form
+// controls, and the OLE objects of the source worksheet are not supported
+// for copying currently.
+//
+// The formulas which reference other worksheets of the source workbook will
+// be rewritten as external references, such as [1]Sheet2!A1, which linked to
+// the path of the source workbook. Set the FormulasToValues field in the
+// options to convert these formulas to their cached values instead. Note
+// that the formulas will be always converted to values if the source
+// workbook was not opened from a file. The references to the source
+// worksheet in the chart series, data validation and conditional formatting
+// formulas will be rewritten to the destination worksheet, and the
+// references to other worksheets in them will be kept as is. For example,
+// copy the worksheet Sheet1 in the workbook Book1.xlsx into the current
+// workbook as the worksheet named Data:
+//
+//	src, err := excelize.OpenFile("Book1.xlsx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer func() {
+//	    if err := src.Close(); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}()
+//	err = f.CopySheetFrom(src, "Sheet1", "Data")
+func (f *File) CopySheetFrom(src *File, srcSheet, dstSheet string, opts ...CopySheetOptions) error {
+	if err := f.checkReadOnly("CopySheetFrom"); err != nil {
+		return err
+	}
+	if src == nil {
+		return ErrParameterInvalid
+	}
+	var options CopySheetOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	if err := checkSheetName(dstSheet); err != nil {
+		return err
+	}
+	src.mu.Lock()
+	srcWs, err := src.workSheetReader(srcSheet)
+	src.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	srcWs.mu.Lock()
+	ws := deepcopy.Copy(srcWs).(*xlsxWorksheet)
+	srcWs.mu.Unlock()
+	if _, err = f.NewSheet(dstSheet); err != nil {
+		return err
+	}
+	c := &sheetCopier{src: src, dst: f, srcSheet: srcSheet, dstSheet: dstSheet, opts: options, parts: map[string]string{}}
+	if err = c.copyCells(ws); err != nil {
+		return err
+	}
+	c.copyFormulas(ws)
+	if err = c.copyRels(ws); err != nil {
+		return err
+	}
+	if err = c.copyDefinedNames(); err != nil {
+		return err
+	}
+	if ws.SheetViews != nil && len(ws.SheetViews.SheetView) > 0 {
+		ws.SheetViews.SheetView[0].TabSelected = false
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(dstSheet)
+	srcSheetXMLPath, _ := src.getSheetXMLPath(srcSheet)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.xmlAttr[sheetXMLPath] = append([]xml.Attr{}, src.xmlAttr[srcSheetXMLPath]...)
+	if f.checked == nil {
+		f.checked = make(map[string]bool)
+	}
+	f.checked[sheetXMLPath] = true
+	f.Sheet.Store(sheetXMLPath, ws)
+	return err
+}
+
+// sheetCopier provides the state of copying worksheet across workbooks.
+type sheetCopier struct {
+	src, dst           *File
+	srcSheet, dstSheet string
+	opts               CopySheetOptions
+	styles             *styleCopier
+	linkIdx            int
+	parts              map[string]string
+}
+
+// external returns if the worksheet was copied from another workbook.
+func (c *sheetCopier) external() bool {
+	return c.src != c.dst
+}
+
+// replaceSheetRef returns the worksheet name in the destination workbook by
+// given worksheet name referenced in the formulas of the source worksheet.
+// The references to other worksheets of the source workbook will be replaced
+// with external references, and the second returned value will be true if
+// the formula needs to be converted to value.
+func (c *sheetCopier) replaceSheetRef(formula string, link bool) (string, bool, error) {
+	var (
+		toValue bool
+		err     error
+		sheets  = c.src.GetSheetList()
+	)
+	result := replaceFormulaSheetRefs(formula, func(sheet string) (string, bool) {
+		if strings.EqualFold(sheet, c.srcSheet) {
+			return c.dstSheet, true
+		}
+		idx := inStrSlice(sheets, sheet, false)
+		if idx == -1 {
+			return sheet, false
+		}
+		if !link || c.opts.FormulasToValues || c.src.Path == "" {
+			toValue = true
+			return sheet, false
+		}
+		if c.linkIdx == 0 {
+			if c.linkIdx, err = c.dst.addExternalLink(filepath.ToSlash(c.src.Path), sheets); err != nil {
+				return sheet, false
+			}
+		}
+		return "[" + strconv.Itoa(c.linkIdx) + "]" + sheets[idx], true
+	})
+	return result, toValue, err
+}
+
+// renameSheetRef provides a function to replace the references to the source
+// worksheet in the given formula with the destination worksheet name.
+func (c *sheetCopier) renameSheetRef(formula string) string {
+	return replaceFormulaSheetRefs(formula, func(sheet string) (string, bool) {
+		if strings.EqualFold(sheet, c.srcSheet) {
+			return c.dstSheet, true
+		}
+		return sheet, false
+	})
+}
+
+// renameXMLSheetRef provides a function to replace the references to the
+// source worksheet in the escaped formula elements of the given inner XML,
+// which are matched by the given regular expression.
+func (c *sheetCopier) renameXMLSheetRef(exp *regexp.Regexp, innerXML string) string {
+	return exp.ReplaceAllStringFunc(innerXML, func(match string) string {
+		sub := exp.FindStringSubmatch(match)
+		formula := xmlUnescaper.Replace(sub[2])
+		ref := c.renameSheetRef(formula)
+		if ref == formula {
+			return match
+		}
+		var buf bytes.Buffer
+		_ = xml.EscapeText(&buf, []byte(ref))
+		return sub[1] + buf.String() + sub[3]
+	})
+}
+
+// copyFormulas provides a function to replace the references to the source
+// worksheet in the data validation and conditional formatting formulas of the
+// copied worksheet with the destination worksheet name.
+func (c *sheetCopier) copyFormulas(ws *xlsxWorksheet) {
+	if ws.DataValidations != nil {
+		for _, dv := range ws.DataValidations.DataValidation {
+			dv.Formula1 = c.renameXMLSheetRef(dataValidationFormulaRegexp, dv.Formula1)
+			dv.Formula2 = c.renameXMLSheetRef(dataValidationFormulaRegexp, dv.Formula2)
+		}
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			for i := range rule.Formula {
+				rule.Formula[i] = c.renameSheetRef(rule.Formula[i])
+			}
+		}
+	}
+	if ws.ExtLst != nil {
+		ws.ExtLst.Ext = c.renameXMLSheetRef(chartFormulaRegexp, ws.ExtLst.Ext)
+	}
+}
+
+// copySharedString provides a function to add the string item of the source
+// workbook shared string table into the destination workbook, and returns the
+// index of the string item in the destination shared string table.
+func (c *sheetCopier) copySharedString(si xlsxSI) (int, error) {
+	if len(si.R) == 0 && len(si.RPh) == 0 && si.PhoneticPr == nil {
+		var val string
+		if si.T != nil {
+			val = si.T.Val
+		}
+		return c.dst.setSharedString(val)
+	}
+	if err := c.dst.sharedStringsLoader(); err != nil {
+		return 0, err
+	}
+	sst, err := c.dst.sharedStringsReader()
+	if err != nil {
+		return 0, err
+	}
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+	sst.Count++
+	sst.UniqueCount++
+	sst.SI = append(sst.SI, deepcopy.Copy(si).(xlsxSI))
+	return sst.UniqueCount - 1, err
+}
+
+// copyCells provides a function to remap the cell styles, shared strings and
+// formulas of the copied worksheet.
+func (c *sheetCopier) copyCells(ws *xlsxWorksheet) error {
+	var (
+		sst *xlsxSST
+		err error
+	)
+	if !c.external() {
+		return err
+	}
+	if c.styles, err = newStyleCopier(c.src, c.dst); err != nil {
+		return err
+	}
+	if err = c.src.sharedStringsLoader(); err != nil {
+		return err
+	}
+	if sst, err = c.src.sharedStringsReader(); err != nil {
+		return err
+	}
+	formulas, sharedToValue, sharedCells := map[string]*xlsxF{}, map[int]bool{}, map[string]int{}
+	for r := range ws.SheetData.Row {
+		for i := range ws.SheetData.Row[r].C {
+			cell := &ws.SheetData.Row[r].C[i]
+			if cell.F == nil {
+				continue
+			}
+			// The cells in the shared formula group derived from the master
+			// cell, so only the formula of the master cell will be rewritten
+			shared := cell.F.T == STCellFormulaTypeShared && cell.F.Si != nil
+			if shared && cell.F.Content == "" {
+				sharedCells[cell.R] = *cell.F.Si
+				continue
+			}
+			result, toValue, err := c.replaceSheetRef(cell.F.Content, true)
+			if err != nil {
+				return err
+			}
+			if shared {
+				sharedToValue[*cell.F.Si] = toValue
+			}
+			if toValue {
+				formulas[cell.R] = nil
+				continue
+			}
+			if result != cell.F.Content {
+				formulas[cell.R] = &xlsxF{Content: result}
+				if cell.F.T == STCellFormulaTypeArray || shared {
+					formulas[cell.R].T, formulas[cell.R].Ref, formulas[cell.R].Si = cell.F.T, cell.F.Ref, cell.F.Si
+				}
+			}
+		}
+	}
+	for ref, si := range sharedCells {
+		if sharedToValue[si] {
+			formulas[ref] = nil
+		}
+	}
+	for r := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[r]
+		if row.S, err = c.styles.cellXf(row.S); err != nil {
+			return err
+		}
+		for i := range row.C {
+			cell := &row.C[i]
+			if cell.S, err = c.styles.cellXf(cell.S); err != nil {
+				return err
+			}
+			if idx, err := strconv.Atoi(cell.V); cell.T == "s" && err == nil && idx >= 0 && idx < len(sst.SI) {
+				if idx, err = c.copySharedString(sst.SI[idx]); err != nil {
+					return err
+				}
+				cell.V = strconv.Itoa(idx)
+			}
+			if formula, ok := formulas[cell.R]; ok {
+				if cell.F = formula; formula == nil && cell.T == "str" {
+					idx, err := c.dst.setSharedString(cell.V)
+					if err != nil {
+						return err
+					}
+					cell.T, cell.V = "s", strconv.Itoa(idx)
+				}
+			}
+		}
+	}
+	if ws.Cols != nil {
+		for i := range ws.Cols.Col {
+			if ws.Cols.Col[i].Style, err = c.styles.cellXf(ws.Cols.Col[i].Style); err != nil {
+				return err
+			}
+		}
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			if rule.DxfID != nil {
+				rule.DxfID = intPtr(c.styles.dxf(*rule.DxfID))
+			}
+		}
+	}
+	if ws.Hyperlinks != nil {
+		for i := range ws.Hyperlinks.Hyperlink {
+			link := &ws.Hyperlinks.Hyperlink[i]
+			if link.Location, _, err = c.replaceSheetRef(link.Location, false); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// copyRels provides a function to copy the hyperlinks, drawings and the
+// background picture relationships of the worksheet, the relationship parts
+// will be added into the destination workbook with new part names, and the
+// relationship IDs will be kept. The unsupported relationships will be
+// removed from the copied worksheet.
+func (c *sheetCopier) copyRels(ws *xlsxWorksheet) error {
+	ws.LegacyDrawing, ws.LegacyDrawingHF, ws.DrawingHF, ws.TableParts = nil, nil, nil, nil
+	ws.OleObjects, ws.Controls, ws.AlternateContent, ws.DecodeAlternateContent = nil, nil, nil, nil
+	if ws.PageSetUp != nil {
+		ws.PageSetUp.RID = ""
+	}
+	srcSheetXMLPath, _ := c.src.getSheetXMLPath(c.srcSheet)
+	sheetXMLPath, _ := c.dst.getSheetXMLPath(c.dstSheet)
+	rels := &xlsxRelationships{}
+	srcRels, err := c.src.relsReader(path.Dir(srcSheetXMLPath) + "/_rels/" + path.Base(srcSheetXMLPath) + ".rels")
+	if err != nil {
+		return err
+	}
+	if srcRels != nil {
+		for _, rel := range srcRels.Relationships {
+			switch rel.Type {
+			case SourceRelationshipHyperLink:
+			case SourceRelationshipDrawingML, SourceRelationshipImage:
+				if rel.TargetMode != "External" {
+					if rel.Target, err = c.copyPart(path.Dir(srcSheetXMLPath), rel.Target); err != nil {
+						return err
+					}
+				}
+			default:
+				continue
+			}
+			rels.Relationships = append(rels.Relationships, rel)
+		}
+	}
+	if ws.Drawing != nil && !hasRelationship(rels, ws.Drawing.RID) {
+		ws.Drawing = nil
+	}
+	if ws.Picture != nil && !hasRelationship(rels, ws.Picture.RID) {
+		ws.Picture = nil
+	}
+	c.dst.Relationships.Store(path.Dir(sheetXMLPath)+"/_rels/"+path.Base(sheetXMLPath)+".rels", rels)
+	return err
+}
+
+// hasRelationship returns if the relationship with the given ID exists.
+func hasRelationship(rels *xlsxRelationships, rID string) bool {
+	for _, rel := range rels.Relationships {
+		if rel.ID == rID {
+			return true
+		}
+	}
+	return false
+}
+
+// copyPart provides a function to copy the part and its relationship parts
+// of the source workbook into the destination workbook by given directory of
+// the part which has the relationship and the relationship target, returns
+// the relationship target for the copied part. The image parts will be
+// deduplicated.
+func (c *sheetCopier) copyPart(dir, target string) (string, error) {
+	name := path.Join(dir, target)
+	if strings.HasPrefix(target, "/") {
+		name = strings.TrimPrefix(target, "/")
+	}
+	relTarget := func(partName string) string {
+		if strings.HasPrefix(target, "/") {
+			return "/" + partName
+		}
+		rel, _ := filepath.Rel(dir, partName)
+		return filepath.ToSlash(rel)
+	}
+	if partName, ok := c.parts[name]; ok {
+		return relTarget(partName), nil
+	}
+	content := c.src.readXML(name)
+	if wsDr, ok := c.src.Drawings.Load(name); ok && wsDr != nil {
+		output, err := xml.Marshal(wsDr.(*xlsxWsDr))
+		if err != nil {
+			return target, err
+		}
+		content = append([]byte(xml.Header), output...)
+	}
+	if strings.HasPrefix(name, "xl/media/") {
+		c.parts[name] = c.dst.addMedia(content, path.Ext(name))
+		return relTarget(c.parts[name]), c.dst.setContentTypePartImageExtensions()
+	}
+	if strings.HasPrefix(name, "xl/charts/chart") {
+		content = []byte(c.renameXMLSheetRef(chartFormulaRegexp, string(content)))
+	}
+	ext := path.Ext(name)
+	prefix := strings.TrimRight(strings.TrimSuffix(name, ext), "0123456789")
+	partName := name
+	for idx := 1; ; idx++ {
+		partName = prefix + strconv.Itoa(idx) + ext
+		_, inPkg := c.dst.Pkg.Load(partName)
+		_, inDrawings := c.dst.Drawings.Load(partName)
+		if !inPkg && !inDrawings {
+			break
+		}
+	}
+	c.parts[name] = partName
+	c.dst.Pkg.Store(partName, content)
+	contentTypes, err := c.src.contentTypesReader()
+	if err != nil {
+		return target, err
+	}
+	for _, override := range contentTypes.Overrides {
+		if strings.TrimPrefix(override.PartName, "/") == name {
+			if err = c.dst.setContentTypes("/"+partName, override.ContentType); err != nil {
+				return target, err
+			}
+		}
+	}
+	srcRels, err := c.src.relsReader(path.Dir(name) + "/_rels/" + path.Base(name) + ".rels")
+	if err != nil || srcRels == nil {
+		return relTarget(partName), err
+	}
+	rels := &xlsxRelationships{}
+	for _, rel := range srcRels.Relationships {
+		if rel.TargetMode != "External" {
+			if rel.Target, err = c.copyPart(path.Dir(name), rel.Target); err != nil {
+				return target, err
+			}
+		}
+		rels.Relationships = append(rels.Relationships, rel)
+	}
+	c.dst.Relationships.Store(path.Dir(partName)+"/_rels/"+path.Base(partName)+".rels", rels)
+	return relTarget(partName), err
+}
+
+// copyDefinedNames provides a function to copy the defined names scoped to
+// the source worksheet into the destination workbook.
+func (c *sheetCopier) copyDefinedNames() error {
+	srcIdx, err := c.src.GetSheetIndex(c.srcSheet)
+	if err != nil {
+		return err
+	}
+	dstIdx, err := c.dst.GetSheetIndex(c.dstSheet)
+	if err != nil {
+		return err
+	}
+	srcWb, err := c.src.workbookReader()
+	if err != nil || srcWb.DefinedNames == nil {
+		return err
+	}
+	wb, err := c.dst.workbookReader()
+	if err != nil {
+		return err
+	}
+	for _, dn := range srcWb.DefinedNames.DefinedName {
+		if dn.LocalSheetID == nil || *dn.LocalSheetID != srcIdx {
+			continue
+		}
+		definedName := deepcopy.Copy(dn).(xlsxDefinedName)
+		definedName.LocalSheetID = intPtr(dstIdx)
+		if c.external() {
+			if definedName.Data, _, err = c.replaceSheetRef(dn.Data, true); err != nil {
+				return err
+			}
+		}
+		if wb.DefinedNames == nil {
+			wb.DefinedNames = &xlsxDefinedNames{}
+		}
+		var exists bool
+		for i, name := range wb.DefinedNames.DefinedName {
+			if name.LocalSheetID != nil && *name.LocalSheetID == dstIdx && name.Name == dn.Name {
+				wb.DefinedNames.DefinedName[i], exists = definedName, true
+			}
+		}
+		if !exists {
+			wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, definedName)
+		}
+	}
+	return err
+}
+
 // getSheetState returns sheet visible enumeration by given hidden status.
 func getSheetState(visible bool, veryHidden []bool) string {
 	state := "hidden"
@@ -711,6 +1399,9 @@ func getSheetState(visible bool, veryHidden []bool) string {
 //
 //	err := f.SetSheetVisible("Sheet1", false)
 func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) error {
+	if err := f.checkReadOnly("SetSheetVisible"); err != nil {
+		return err
+	}
 	if err := checkSheetName(sheet); err != nil {
 		return err
 	}
@@ -728,7 +1419,7 @@ func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) e
 	}
 	count, state := 0, getSheetState(visible, veryHidden)
 	for _, v := range wb.Sheets.Sheet {
-		if v.State != state {
+		if v.State == "" || v.State == "visible" || strings.EqualFold(v.Name, sheet) {
 			count++
 		}
 	}
@@ -748,6 +1439,28 @@ func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) e
 	return err
 }
 
+// SetSheetVisibility provides a function to set the visibility state of the
+// sheet by given sheet name and visibility state. The very hidden sheet can't
+// be unhidden by the Excel user interface, and only could be made visible
+// programmatically. A workbook must contain at least one visible sheet, and
+// the activated sheet can't be hidden. For example, make Sheet1 very hidden:
+//
+//	err := f.SetSheetVisibility("Sheet1", excelize.SheetVeryHidden)
+func (f *File) SetSheetVisibility(sheet string, visibility SheetVisibility) error {
+	if err := f.checkReadOnly("SetSheetVisibility"); err != nil {
+		return err
+	}
+	switch visibility {
+	case SheetVisible:
+		return f.SetSheetVisible(sheet, true)
+	case SheetHidden:
+		return f.SetSheetVisible(sheet, false)
+	case SheetVeryHidden:
+		return f.SetSheetVisible(sheet, false, true)
+	}
+	return ErrParameterInvalid
+}
+
 // setPanes set create freeze panes and split panes by given options.
 func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 	if panes == nil {
@@ -901,6 +1614,9 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 //
 //	err := f.SetPanes("Sheet1", &excelize.Panes{Freeze: false, Split: false})
 func (f *File) SetPanes(sheet string, panes *Panes) error {
+	if err := f.checkReadOnly("SetPanes"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -972,11 +1688,41 @@ func (f *File) GetSheetVisible(sheet string) (bool, error) {
 	return visible, nil
 }
 
+// GetSheetVisibility provides a function to get the visibility state of the
+// sheet by given sheet name. For example, get visibility state of Sheet1:
+//
+//	visibility, err := f.GetSheetVisibility("Sheet1")
+func (f *File) GetSheetVisibility(sheet string) (SheetVisibility, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return SheetVisible, err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return SheetVisible, err
+	}
+	for _, v := range wb.Sheets.Sheet {
+		if strings.EqualFold(v.Name, sheet) {
+			switch v.State {
+			case "hidden":
+				return SheetHidden, err
+			case "veryHidden":
+				return SheetVeryHidden, err
+			}
+			return SheetVisible, err
+		}
+	}
+	return SheetVisible, ErrSheetNotExist{sheet}
+}
+
 // SearchSheet provides a function to get cell reference by given worksheet name,
-// cell value, and regular expression. The function doesn't support searching
-// on the calculated result, formatted numbers and conditional lookup
-// currently. If it is a merged cell, it will return the cell reference of the
-// upper left cell of the merged range reference.
+// cell value, and regular expression. The function searches on the cell values
+// which are the same as the GetCellValue function returns, the values were
+// formatted by the number format of the cells, so the date cells can be found
+// by the formatted text. The function doesn't support searching on the
+// calculated result and conditional lookup currently. The cells covered by the
+// merged cells except the upper left cell will be skipped, since their values
+// are not displayed. The worksheet XML will be parsed on the token stream
+// without building the worksheet structure.
 //
 // An example of search the cell reference of the value of "100" on Sheet1:
 //
@@ -1001,10 +1747,13 @@ func (f *File) SearchSheet(sheet, value string, reg ...bool) ([]string, error) {
 	if !ok {
 		return result, ErrSheetNotExist{sheet}
 	}
-	if ws, ok := f.Sheet.Load(name); ok && ws != nil {
+	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
+		ws := worksheet.(*xlsxWorksheet)
+		ws.mu.Lock()
 		// Flush data
-		output, _ := xml.Marshal(ws.(*xlsxWorksheet))
+		output, _ := xml.Marshal(ws)
 		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+		ws.mu.Unlock()
 	}
 	return f.searchSheet(name, value, regSearch)
 }
@@ -1013,16 +1762,28 @@ func (f *File) SearchSheet(sheet, value string, reg ...bool) ([]string, error) {
 // name, cell value, and regular expression.
 func (f *File) searchSheet(name, value string, regSearch bool) (result []string, err error) {
 	var (
-		cellName, inElement string
-		cellCol, row        int
-		sst                 *xlsxSST
+		cellName, inElement    string
+		cellCol, row, rowStyle int
+		cols                   []xlsxCol
+		cells, mergeCells      [][]int
+		sst                    *xlsxSST
+		regex                  *regexp.Regexp
 	)
-
+	if regSearch {
+		if regex, err = regexp.Compile(value); err != nil {
+			return
+		}
+	}
 	if sst, err = f.sharedStringsReader(); err != nil {
 		return
 	}
-	regex := regexp.MustCompile(value)
-	decoder := f.xmlNewDecoder(bytes.NewReader(f.readBytes(name)))
+	needClose, decoder, tempFile, err := f.xmlDecoder(name)
+	if needClose && err == nil {
+		defer tempFile.Close()
+	}
+	if err != nil {
+		return
+	}
 	for {
 		var token xml.Token
 		token, err = decoder.Token()
@@ -1035,16 +1796,31 @@ func (f *File) searchSheet(name, value string, regSearch bool) (result []string,
 		switch xmlElement := token.(type) {
 		case xml.StartElement:
 			inElement = xmlElement.Name.Local
+			if inElement == "col" {
+				cols = append(cols, parseColStyle(&xmlElement))
+			}
 			if inElement == "row" {
 				row, err = attrValToInt("r", xmlElement.Attr)
 				if err != nil {
 					return
 				}
+				rowStyle = extractRowOpts(xmlElement.Attr).StyleID
+			}
+			if inElement == "mergeCell" {
+				for _, attr := range xmlElement.Attr {
+					if attr.Name.Local != "ref" {
+						continue
+					}
+					if rect, err := rangeRefToCoordinates(attr.Value); err == nil && sortCoordinates(rect) == nil {
+						mergeCells = append(mergeCells, rect)
+					}
+				}
 			}
 			if inElement == "c" {
 				colCell := xlsxC{}
 				_ = decoder.DecodeElement(&colCell, &xmlElement)
-				val, _ := colCell.getValueFrom(f, sst, false)
+				cellCol, _, _ = CellNameToCoordinates(colCell.R)
+				val, _ := colCell.getValueFrom(f, sst, false, inheritCellStyle(cellCol, colCell.S, rowStyle, cols))
 				if regSearch {
 					if !regex.MatchString(val) {
 						continue
@@ -1063,11 +1839,305 @@ func (f *File) searchSheet(name, value string, regSearch bool) (result []string,
 					return result, err
 				}
 				result = append(result, cellName)
+				cells = append(cells, []int{cellCol, row})
 			}
 		default:
 		}
 	}
-	return
+	if len(mergeCells) == 0 {
+		return
+	}
+	var matches []string
+	for i, cell := range cells {
+		var hidden bool
+		for _, rect := range mergeCells {
+			if cellInRange(cell, rect) && (cell[0] != rect[0] || cell[1] != rect[1]) {
+				hidden = true
+				break
+			}
+		}
+		if !hidden {
+			matches = append(matches, result[i])
+		}
+	}
+	return matches, err
+}
+
+// ReplaceInSheet provides a function to replace the matched substrings in the
+// string cells by given worksheet name, the old and new substrings, and
+// returns the number of the replaced substrings. The old substring will be
+// treated as a regular expression if the first optional parameter was true,
+// and the new substring can contain the submatches such as "$1". The numeric,
+// boolean and formula cells will not be changed unless the second optional
+// parameter was true, and the cached results of the formula cells which
+// formula text was changed will be removed. The shared strings are not
+// modified in place, so that the other cells which reference the same shared
+// string will not be changed. Note that the matches across the runs of the
+// rich text will not be replaced, the phonetic runs of the string which base
+// text was changed will be removed, and the phonetic runs after the replaced
+// text will be moved. For example, replace "Old Company" with "New Company"
+// on Sheet1:
+//
+//	count, err := f.ReplaceInSheet("Sheet1", "Old Company", "New Company")
+//
+// Replace the dates in the format of "YYYY/MM/DD" with "YYYY-MM-DD" on Sheet1,
+// includes the formula text:
+//
+//	count, err := f.ReplaceInSheet("Sheet1", `(\d{4})/(\d{2})/(\d{2})`, "$1-$2-$3", true, true)
+func (f *File) ReplaceInSheet(sheet, oldValue, newValue string, reg ...bool) (int, error) {
+	if err := f.checkReadOnly("ReplaceInSheet"); err != nil {
+		return 0, err
+	}
+	if oldValue == "" {
+		return 0, ErrParameterRequired
+	}
+	var (
+		regex      *regexp.Regexp
+		err        error
+		inFormulas = len(reg) > 1 && reg[1]
+	)
+	if len(reg) > 0 && reg[0] {
+		if regex, err = regexp.Compile(oldValue); err != nil {
+			return 0, err
+		}
+	}
+	replace := func(text string) (string, []textEdit) {
+		return replaceText(text, oldValue, newValue, regex)
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return 0, err
+	}
+	f.mu.Unlock()
+	if err = f.sharedStringsLoader(); err != nil {
+		return 0, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return 0, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var (
+		total         int
+		sharedIdxes   = map[int]bool{}
+		sharedFormula = func(c *xlsxC) bool {
+			return c.F.T == STCellFormulaTypeShared && c.F.Si != nil
+		}
+	)
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			c := &ws.SheetData.Row[rowIdx].C[colIdx]
+			if c.F != nil {
+				if inFormulas && c.F.Content != "" {
+					content, edits := replace(c.F.Content)
+					if len(edits) == 0 {
+						continue
+					}
+					if sharedFormula(c) {
+						sharedIdxes[*c.F.Si] = true
+					}
+					c.F.Content, c.T, c.V, total = content, "", "", total+len(edits)
+				}
+				continue
+			}
+			switch c.T {
+			case "str":
+				text, edits := replace(c.V)
+				c.V, total = text, total+len(edits)
+			case "s":
+				idx, err := strconv.Atoi(strings.TrimSpace(c.V))
+				if err != nil {
+					continue
+				}
+				sst.mu.Lock()
+				if idx < 0 || idx >= len(sst.SI) {
+					sst.mu.Unlock()
+					continue
+				}
+				si := sst.SI[idx]
+				sst.mu.Unlock()
+				if len(si.R) == 0 && len(si.RPh) == 0 && si.PhoneticPr == nil {
+					text, edits := replace(si.String())
+					if len(edits) == 0 {
+						continue
+					}
+					if idx, err = f.setSharedString(text); err != nil {
+						return total, err
+					}
+					c.V, total = strconv.Itoa(idx), total+len(edits)
+					continue
+				}
+				item, count := replaceStringItem(si, replace)
+				if count == 0 {
+					continue
+				}
+				sst.mu.Lock()
+				sst.SI = append(sst.SI, item)
+				sst.Count++
+				sst.UniqueCount++
+				c.V, total = strconv.Itoa(len(sst.SI)-1), total+count
+				sst.mu.Unlock()
+			case "inlineStr":
+				if c.IS == nil {
+					continue
+				}
+				item, count := replaceStringItem(*c.IS, replace)
+				*c.IS, total = item, total+count
+			}
+		}
+	}
+	// Remove the cached results of the cells which reference the changed
+	// shared formulas
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			c := &ws.SheetData.Row[rowIdx].C[colIdx]
+			if c.F != nil && sharedFormula(c) && sharedIdxes[*c.F.Si] {
+				c.T, c.V = "", ""
+			}
+		}
+	}
+	return total, err
+}
+
+// textEdit directly maps the replaced substring in the text, the start and
+// end are the rune offsets of the replaced substring in the original text,
+// and the length is the number of runes of the replacement.
+type textEdit struct {
+	start, end, length int
+}
+
+// replaceText provides a function to replace the substrings in the text by
+// given old and new substrings, the old substring will be treated as a
+// regular expression if the compiled regular expression is not nil. It
+// returns the new text and the replaced substrings in the original text.
+func replaceText(text, oldValue, newValue string, regex *regexp.Regexp) (string, []textEdit) {
+	var (
+		matches [][]int
+		buf     strings.Builder
+		edits   []textEdit
+		last    int
+		offset  int
+	)
+	if regex != nil {
+		matches = regex.FindAllStringSubmatchIndex(text, -1)
+	} else {
+		for pos := 0; pos <= len(text); {
+			idx := strings.Index(text[pos:], oldValue)
+			if idx == -1 {
+				break
+			}
+			matches = append(matches, []int{pos + idx, pos + idx + len(oldValue)})
+			pos += idx + len(oldValue)
+		}
+	}
+	if len(matches) == 0 {
+		return text, nil
+	}
+	for _, match := range matches {
+		replacement := newValue
+		if regex != nil {
+			replacement = string(regex.ExpandString(nil, newValue, text, match))
+		}
+		offset += utf8.RuneCountInString(text[last:match[0]])
+		edit := textEdit{start: offset, length: utf8.RuneCountInString(replacement)}
+		offset += utf8.RuneCountInString(text[match[0]:match[1]])
+		edit.end = offset
+		buf.WriteString(text[last:match[0]])
+		buf.WriteString(replacement)
+		edits, last = append(edits, edit), match[1]
+	}
+	buf.WriteString(text[last:])
+	return buf.String(), edits
+}
+
+// replaceStringItem provides a function to replace the text of the string
+// item by given replace function, and returns the new string item and the
+// number of the replaced substrings. The phonetic properties will be kept,
+// the phonetic runs which base text was changed will be removed, and the
+// phonetic runs after the replaced text will be moved.
+func replaceStringItem(si xlsxSI, replace func(text string) (string, []textEdit)) (xlsxSI, int) {
+	item := xlsxSI{PhoneticPr: si.PhoneticPr}
+	var edits []textEdit
+	if si.T != nil {
+		var text string
+		if text, edits = replace(si.T.Val); len(edits) > 0 {
+			t := xlsxT{Val: text}
+			_, t.Space = trimCellValue(text, false)
+			item.T = &t
+		} else {
+			item.T = si.T
+		}
+	}
+	offset := 0
+	if si.T != nil {
+		offset = utf8.RuneCountInString(si.T.Val)
+	}
+	runs, runEdits := replaceRichTextRuns(si.R, replace, offset)
+	if edits = append(edits, runEdits...); len(edits) == 0 {
+		return si, 0
+	}
+	item.R = runs
+	for _, run := range si.RPh {
+		if run == nil || run.Sb > run.Eb {
+			continue
+		}
+		if shifted, ok := shiftPhoneticRun(run, edits); ok {
+			item.RPh = append(item.RPh, shifted)
+		}
+	}
+	return item, len(edits)
+}
+
+// shiftPhoneticRun provides a function to move the phonetic run by given
+// replaced substrings, and returns false if the base text of the phonetic run
+// was changed.
+func shiftPhoneticRun(run *xlsxPhoneticRun, edits []textEdit) (*xlsxPhoneticRun, bool) {
+	sb, eb, delta := int(run.Sb), int(run.Eb), 0
+	for _, edit := range edits {
+		if edit.end <= sb {
+			delta += edit.length - (edit.end - edit.start)
+			continue
+		}
+		if edit.start < eb {
+			return nil, false
+		}
+	}
+	if delta == 0 {
+		return run, true
+	}
+	return &xlsxPhoneticRun{Sb: uint32(sb + delta), Eb: uint32(eb + delta), T: run.T}, true
+}
+
+// replaceRichTextRuns provides a function to replace the text in each run of
+// the rich text by given replace function and the rune offset of the first
+// run, and returns the new runs and the replaced substrings.
+func replaceRichTextRuns(runs []xlsxR, replace func(text string) (string, []textEdit), offset int) ([]xlsxR, []textEdit) {
+	var edits []textEdit
+	newRuns := make([]xlsxR, len(runs))
+	for i, run := range runs {
+		newRuns[i] = run
+		if run.T == nil {
+			continue
+		}
+		text, runEdits := replace(run.T.Val)
+		for _, edit := range runEdits {
+			edit.start, edit.end = edit.start+offset, edit.end+offset
+			edits = append(edits, edit)
+		}
+		if offset += utf8.RuneCountInString(run.T.Val); len(runEdits) == 0 {
+			continue
+		}
+		t := xlsxT{Val: text}
+		_, t.Space = trimCellValue(text, false)
+		newRuns[i].T = &t
+	}
+	if len(edits) == 0 {
+		return runs, edits
+	}
+	return newRuns, edits
 }
 
 // attrValToInt provides a function to convert the local names to an integer
@@ -1113,7 +2183,7 @@ func attrValToBool(name string, attrs []xml.Attr) (val bool, err error) {
 }
 
 // SetHeaderFooter provides a function to set headers and footers by given
-// worksheet name and the control characters.
+// worksheet or chartsheet name and the control characters.
 //
 // Headers and footers are specified using the following settings fields:
 //
@@ -1227,37 +2297,375 @@ func attrValToBool(name string, attrs []xml.Attr) (val bool, err error) {
 // that same page
 //
 // - No footer on the first page
+//
+// Instead of the formatting codes, the headers and footers can be also
+// specified by the structured segments with the fields OddHeaderSegments,
+// OddFooterSegments, EvenHeaderSegments, EvenFooterSegments,
+// FirstHeaderSegments and FirstFooterSegments, which take precedence over the
+// corresponding string type fields. The literal "&" in the text runs will be
+// escaped automatically. Note that the "picture" field only marks the position
+// of the picture, and adding the picture is not supported currently. For
+// example, set the odd page header with the text "R&D" in bold in the left
+// section, and the page number of total pages in the right section:
+//
+//	err := f.SetHeaderFooter("Sheet1", &excelize.HeaderFooterOptions{
+//	    OddHeaderSegments: &excelize.HeaderFooterSegments{
+//	        Left: []excelize.HeaderFooterRun{
+//	            {Text: "R&D", Font: &excelize.Font{Bold: true}},
+//	        },
+//	        Right: []excelize.HeaderFooterRun{
+//	            {Text: "Page "}, {Field: "page"}, {Text: " of "}, {Field: "pages"},
+//	        },
+//	    },
+//	})
 func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
-	ws, err := f.workSheetReader(sheet)
+	if err := f.checkReadOnly("SetHeaderFooter"); err != nil {
+		return err
+	}
+	cs, name, err := f.chartSheetReader(sheet)
 	if err != nil {
 		return err
 	}
-	if opts == nil {
-		ws.HeaderFooter = nil
-		return err
+	var ws *xlsxWorksheet
+	if cs != nil {
+		ws = cs.printSettings()
+		defer func() {
+			cs.HeaderFooter = ws.HeaderFooter
+			f.chartSheetWriter(name, cs)
+		}()
+	} else if ws, err = f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	if opts == nil {
+		ws.HeaderFooter = nil
+		return err
+	}
+	font, err := f.readDefaultFont()
+	if err != nil {
+		return err
+	}
+	hf := &xlsxHeaderFooter{
+		AlignWithMargins: opts.AlignWithMargins,
+		DifferentFirst:   opts.DifferentFirst,
+		DifferentOddEven: opts.DifferentOddEven,
+		ScaleWithDoc:     opts.ScaleWithDoc,
+	}
+	for _, field := range []struct {
+		name     string
+		value    string
+		segments *HeaderFooterSegments
+		target   *string
+	}{
+		{"OddHeader", opts.OddHeader, opts.OddHeaderSegments, &hf.OddHeader},
+		{"OddFooter", opts.OddFooter, opts.OddFooterSegments, &hf.OddFooter},
+		{"EvenHeader", opts.EvenHeader, opts.EvenHeaderSegments, &hf.EvenHeader},
+		{"EvenFooter", opts.EvenFooter, opts.EvenFooterSegments, &hf.EvenFooter},
+		{"FirstHeader", opts.FirstHeader, opts.FirstHeaderSegments, &hf.FirstHeader},
+		{"FirstFooter", opts.FirstFooter, opts.FirstFooterSegments, &hf.FirstFooter},
+	} {
+		value := field.value
+		if field.segments != nil {
+			if value, err = field.segments.format(newHeaderFooterFont(font)); err != nil {
+				return err
+			}
+		}
+		if len(utf16.Encode([]rune(value))) > MaxFieldLength {
+			return newFieldLengthError(field.name)
+		}
+		*field.target = value
+	}
+	ws.HeaderFooter = hf
+	return err
+}
+
+// GetHeaderFooter provides a function to get headers and footers by given
+// worksheet name. The formatting codes of the headers and footers will be
+// also parsed into the structured segments.
+func (f *File) GetHeaderFooter(sheet string) (HeaderFooterOptions, error) {
+	var opts HeaderFooterOptions
+	ws, err := f.printSettingsReader(sheet)
+	if err != nil {
+		return opts, err
+	}
+	if ws.HeaderFooter == nil {
+		return opts, err
+	}
+	font, err := f.readDefaultFont()
+	if err != nil {
+		return opts, err
+	}
+	hf := ws.HeaderFooter
+	opts = HeaderFooterOptions{
+		AlignWithMargins: hf.AlignWithMargins,
+		DifferentFirst:   hf.DifferentFirst,
+		DifferentOddEven: hf.DifferentOddEven,
+		ScaleWithDoc:     hf.ScaleWithDoc,
+		OddHeader:        hf.OddHeader,
+		OddFooter:        hf.OddFooter,
+		EvenHeader:       hf.EvenHeader,
+		EvenFooter:       hf.EvenFooter,
+		FirstHeader:      hf.FirstHeader,
+		FirstFooter:      hf.FirstFooter,
+	}
+	for _, field := range []struct {
+		value  string
+		target **HeaderFooterSegments
+	}{
+		{hf.OddHeader, &opts.OddHeaderSegments},
+		{hf.OddFooter, &opts.OddFooterSegments},
+		{hf.EvenHeader, &opts.EvenHeaderSegments},
+		{hf.EvenFooter, &opts.EvenFooterSegments},
+		{hf.FirstHeader, &opts.FirstHeaderSegments},
+		{hf.FirstFooter, &opts.FirstFooterSegments},
+	} {
+		if field.value != "" {
+			*field.target = parseHeaderFooterSegments(field.value, newHeaderFooterFont(font))
+		}
+	}
+	return opts, err
+}
+
+// headerFooterFields defined the formatting codes of the header and footer
+// fields.
+var headerFooterFields = map[string]byte{
+	"page":       'P',
+	"pages":      'N',
+	"date":       'D',
+	"time":       'T',
+	"file_name":  'F',
+	"file_path":  'Z',
+	"sheet_name": 'A',
+	"picture":    'G',
+}
+
+// headerFooterFont directly maps the font state in the section of header and
+// footer.
+type headerFooterFont struct {
+	family, underline, color, vertAlign string
+	size                                float64
+	bold, italic, strike                bool
+}
+
+// newHeaderFooterFont returns the default font state in the section of header
+// and footer by given default font of the workbook.
+func newHeaderFooterFont(font *xlsxFont) headerFooterFont {
+	var hff headerFooterFont
+	if font != nil && font.Name != nil && font.Name.Val != nil {
+		hff.family = *font.Name.Val
+	}
+	if font != nil && font.Sz != nil && font.Sz.Val != nil {
+		hff.size = *font.Sz.Val
+	}
+	return hff
+}
+
+// apply returns a new font state by given font settings of the run.
+func (hff headerFooterFont) apply(font *Font) headerFooterFont {
+	if font == nil {
+		return hff
+	}
+	if font.Family != "" {
+		hff.family = font.Family
+	}
+	if font.Size > 0 {
+		hff.size = font.Size
+	}
+	hff.bold, hff.italic, hff.strike = font.Bold, font.Italic, font.Strike
+	hff.underline, hff.vertAlign = font.Underline, font.VertAlign
+	hff.color = strings.ToUpper(strings.TrimPrefix(font.Color, "#"))
+	return hff
+}
+
+// style returns the font type string of the font state.
+func (hff headerFooterFont) style() string {
+	switch {
+	case hff.bold && hff.italic:
+		return "Bold Italic"
+	case hff.bold:
+		return "Bold"
+	case hff.italic:
+		return "Italic"
+	}
+	return "Regular"
+}
+
+// font returns the font settings of the run by given font state and the
+// default font state, it will return nil if the font state is the default.
+func (hff headerFooterFont) font(def headerFooterFont) *Font {
+	if hff == def {
+		return nil
+	}
+	font := &Font{
+		Bold: hff.bold, Italic: hff.italic, Underline: hff.underline,
+		Strike: hff.strike, Color: hff.color, VertAlign: hff.vertAlign,
+	}
+	if hff.family != def.family {
+		font.Family = hff.family
 	}
+	if hff.size != def.size {
+		font.Size = hff.size
+	}
+	return font
+}
 
-	v := reflect.ValueOf(*opts)
-	// Check 6 string type fields: OddHeader, OddFooter, EvenHeader, EvenFooter,
-	// FirstFooter, FirstHeader
-	for i := 4; i < v.NumField()-1; i++ {
-		if len(utf16.Encode([]rune(v.Field(i).String()))) > MaxFieldLength {
-			return newFieldLengthError(v.Type().Field(i).Name)
+// format provides a function to build the formatting codes of the header or
+// footer by given default font state.
+func (s *HeaderFooterSegments) format(def headerFooterFont) (string, error) {
+	var buf strings.Builder
+	for _, section := range []struct {
+		code byte
+		runs []HeaderFooterRun
+	}{{'L', s.Left}, {'C', s.Center}, {'R', s.Right}} {
+		if len(section.runs) == 0 {
+			continue
+		}
+		buf.WriteByte('&')
+		buf.WriteByte(section.code)
+		cur := def
+		for _, run := range section.runs {
+			next := def.apply(run.Font)
+			writeHeaderFooterFontCodes(&buf, cur, next)
+			cur = next
+			if run.Field != "" {
+				code, ok := headerFooterFields[run.Field]
+				if !ok {
+					return buf.String(), newInvalidOptionalValue("Field", run.Field,
+						[]string{"page", "pages", "date", "time", "file_name", "file_path", "sheet_name", "picture"})
+				}
+				buf.WriteByte('&')
+				buf.WriteByte(code)
+				continue
+			}
+			buf.WriteString(strings.ReplaceAll(run.Text, "&", "&&"))
 		}
 	}
-	ws.HeaderFooter = &xlsxHeaderFooter{
-		AlignWithMargins: opts.AlignWithMargins,
-		DifferentFirst:   opts.DifferentFirst,
-		DifferentOddEven: opts.DifferentOddEven,
-		ScaleWithDoc:     opts.ScaleWithDoc,
-		OddHeader:        opts.OddHeader,
-		OddFooter:        opts.OddFooter,
-		EvenHeader:       opts.EvenHeader,
-		EvenFooter:       opts.EvenFooter,
-		FirstFooter:      opts.FirstFooter,
-		FirstHeader:      opts.FirstHeader,
+	return buf.String(), nil
+}
+
+// writeHeaderFooterFontCodes provides a function to write the formatting codes
+// for changing the font state of header and footer.
+func writeHeaderFooterFontCodes(buf *strings.Builder, cur, next headerFooterFont) {
+	sizeChanged := cur.size != next.size
+	if sizeChanged {
+		buf.WriteString("&" + strconv.FormatFloat(next.size, 'f', -1, 64))
 	}
-	return err
+	// Always write the font name after the font size to separate the font size
+	// from the following digits.
+	if sizeChanged || cur.family != next.family || cur.style() != next.style() {
+		family := "-"
+		if cur.family != next.family {
+			family = next.family
+		}
+		buf.WriteString(fmt.Sprintf("&\"%s,%s\"", family, next.style()))
+	}
+	toggles := map[string]string{"single": "&U", "double": "&E", "superscript": "&X", "subscript": "&Y"}
+	if cur.underline != next.underline {
+		buf.WriteString(toggles[cur.underline] + toggles[next.underline])
+	}
+	if cur.strike != next.strike {
+		buf.WriteString("&S")
+	}
+	if cur.vertAlign != next.vertAlign {
+		buf.WriteString(toggles[cur.vertAlign] + toggles[next.vertAlign])
+	}
+	if cur.color != next.color {
+		color := next.color
+		if color == "" {
+			color = "000000"
+		}
+		buf.WriteString("&K" + color)
+	}
+}
+
+// parseHeaderFooterSegments provides a function to parse the formatting codes
+// of the header or footer into the structured segments by given default font
+// state.
+func parseHeaderFooterSegments(value string, def headerFooterFont) *HeaderFooterSegments {
+	var (
+		segments = &HeaderFooterSegments{}
+		section  = &segments.Center
+		cur      = def
+		text     strings.Builder
+		runes    = []rune(value)
+		flush    = func() {
+			if text.Len() > 0 {
+				*section = append(*section, HeaderFooterRun{Text: text.String(), Font: cur.font(def)})
+				text.Reset()
+			}
+		}
+		fields = map[rune]string{}
+	)
+	for field, code := range headerFooterFields {
+		fields[rune(code)] = field
+	}
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '&' || i == len(runes)-1 {
+			text.WriteRune(runes[i])
+			continue
+		}
+		i++
+		code := runes[i]
+		if code == '&' {
+			text.WriteRune(code)
+			continue
+		}
+		flush()
+		switch {
+		case code == 'L' || code == 'C' || code == 'R':
+			section, cur = map[rune]*[]HeaderFooterRun{'L': &segments.Left, 'C': &segments.Center, 'R': &segments.Right}[code], def
+		case code == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			font := strings.SplitN(string(runes[i+1:end]), ",", 2)
+			if font[0] != "-" {
+				cur.family = font[0]
+			}
+			if len(font) == 2 {
+				cur.bold, cur.italic = strings.Contains(font[1], "Bold"), strings.Contains(font[1], "Italic")
+			}
+			i = end
+		case '0' <= code && code <= '9':
+			end := i
+			for end < len(runes) && ('0' <= runes[end] && runes[end] <= '9' || runes[end] == '.') {
+				end++
+			}
+			if size, err := strconv.ParseFloat(string(runes[i:end]), 64); err == nil {
+				cur.size = size
+			}
+			i = end - 1
+		case code == 'K' && i+6 < len(runes):
+			if cur.color = string(runes[i+1 : i+7]); cur.color == "000000" {
+				cur.color = ""
+			}
+			i += 6
+		case code == 'B':
+			cur.bold = !cur.bold
+		case code == 'I':
+			cur.italic = !cur.italic
+		case code == 'S':
+			cur.strike = !cur.strike
+		case code == 'U' || code == 'E':
+			value := map[rune]string{'U': "single", 'E': "double"}[code]
+			if cur.underline == value {
+				value = ""
+			}
+			cur.underline = value
+		case code == 'X' || code == 'Y':
+			value := map[rune]string{'X': "superscript", 'Y': "subscript"}[code]
+			if cur.vertAlign == value {
+				value = ""
+			}
+			cur.vertAlign = value
+		default:
+			if field, ok := fields[code]; ok {
+				*section = append(*section, HeaderFooterRun{Field: field, Font: cur.font(def)})
+			}
+		}
+	}
+	flush()
+	return segments
 }
 
 // ProtectSheet provides a function to prevent other users from accidentally or
@@ -1275,6 +2683,9 @@ func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
 //	    EditScenarios:       true,
 //	})
 func (f *File) ProtectSheet(sheet string, opts *SheetProtectionOptions) error {
+	if err := f.checkReadOnly("ProtectSheet"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -1322,6 +2733,9 @@ func (f *File) ProtectSheet(sheet string, opts *SheetProtectionOptions) error {
 // specified the second optional password parameter to remove sheet
 // protection with password verification.
 func (f *File) UnprotectSheet(sheet string, password ...string) error {
+	if err := f.checkReadOnly("UnprotectSheet"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -1370,7 +2784,38 @@ func checkSheetName(name string) error {
 	return nil
 }
 
-// SetPageLayout provides a function to sets worksheet page layout.
+// SetPageLayout provides a function to sets worksheet page layout, including
+// the orientation, paper size, scaling, first page number, print in black and
+// white and the page margins. The orientation should be "portrait" or
+// "landscape", the scaling percentage specified by AdjustTo should be between
+// 10 and 400. Set the FitToWidth or FitToHeight will enable the fit to page
+// print option, and the scaling will be ignored. For example, set the page
+// layout of Sheet1 to landscape A4 paper with 0.5 inch margins:
+//
+//	size, orientation, margin := 9, "landscape", 0.5
+//	err := f.SetPageLayout("Sheet1", &excelize.PageLayoutOptions{
+//	    Size:        &size,
+//	    Orientation: &orientation,
+//	    Margins: &excelize.PageLayoutMarginsOptions{
+//	        Top:    &margin,
+//	        Bottom: &margin,
+//	        Left:   &margin,
+//	        Right:  &margin,
+//	    },
+//	})
+//
+// This function also supports the chartsheet, in this case only the paper
+// size, orientation, first page number, print in black and white and the page
+// margins will be applied, the scaling and fit to page options are not
+// applicable to the chartsheet.
+//
+// Fit all columns on one page wide, and as many pages tall as needed:
+//
+//	width, height := 1, 0
+//	err := f.SetPageLayout("Sheet1", &excelize.PageLayoutOptions{
+//	    FitToWidth:  &width,
+//	    FitToHeight: &height,
+//	})
 //
 // The following shows the paper size sorted by excelize index number:
 //
@@ -1493,17 +2938,87 @@ func checkSheetName(name string) error {
 //	   117 | PRC Envelope #9 Rotated (324 mm x 229 mm)
 //	   118 | PRC Envelope #10 Rotated (458 mm x 324 mm)
 func (f *File) SetPageLayout(sheet string, opts *PageLayoutOptions) error {
-	ws, err := f.workSheetReader(sheet)
+	if err := f.checkReadOnly("SetPageLayout"); err != nil {
+		return err
+	}
+	cs, name, err := f.chartSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	var ws *xlsxWorksheet
+	if cs == nil {
+		if ws, err = f.workSheetReader(sheet); err != nil {
+			return err
+		}
+	}
 	if opts == nil {
 		return err
 	}
+	if opts.Size != nil && (*opts.Size < 1 || *opts.Size > 118 || *opts.Size == 48 || *opts.Size == 49) {
+		return newInvalidPaperSizeError(*opts.Size)
+	}
+	if opts.Orientation != nil && *opts.Orientation != "portrait" && *opts.Orientation != "landscape" {
+		return newInvalidOptionalValue("Orientation", *opts.Orientation, []string{"portrait", "landscape"})
+	}
+	if opts.AdjustTo != nil && (*opts.AdjustTo < 10 || *opts.AdjustTo > 400) {
+		return ErrPageSetupAdjustTo
+	}
+	if (opts.FitToHeight != nil && *opts.FitToHeight < 0) || (opts.FitToWidth != nil && *opts.FitToWidth < 0) {
+		return ErrParameterInvalid
+	}
+	if cs != nil {
+		cs.setPageLayout(opts)
+		f.chartSheetWriter(name, cs)
+		return err
+	}
 	ws.setPageSetUp(opts)
+	if opts.Margins != nil {
+		ws.setPageMargins(opts.Margins)
+	}
 	return err
 }
 
+// setPageLayout set page setup and page margins settings for the chartsheet
+// by given options. The scaling and print centering options are not
+// applicable to the chartsheet and will be ignored.
+func (cs *xlsxChartsheet) setPageLayout(opts *PageLayoutOptions) {
+	ws := cs.printSettings()
+	ws.setPageSetUp(&PageLayoutOptions{
+		Size:            opts.Size,
+		Orientation:     opts.Orientation,
+		FirstPageNumber: opts.FirstPageNumber,
+		BlackAndWhite:   opts.BlackAndWhite,
+	})
+	if opts.Margins != nil {
+		ws.setPageMargins(opts.Margins)
+	}
+	cs.PageSetup, cs.PageMargins = ws.PageSetUp, ws.PageMargins
+}
+
+// printSettings returns a worksheet which only contains the page setup, page
+// margins and header footer settings of the chartsheet, that allows reuse the
+// print settings functions of the worksheet.
+func (cs *xlsxChartsheet) printSettings() *xlsxWorksheet {
+	return &xlsxWorksheet{
+		PageSetUp:    cs.PageSetup,
+		PageMargins:  cs.PageMargins,
+		HeaderFooter: cs.HeaderFooter,
+	}
+}
+
+// printSettingsReader provides a function to get the print settings of the
+// worksheet or chartsheet by given sheet name.
+func (f *File) printSettingsReader(sheet string) (*xlsxWorksheet, error) {
+	cs, _, err := f.chartSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	if cs != nil {
+		return cs.printSettings(), err
+	}
+	return f.workSheetReader(sheet)
+}
+
 // newPageSetUp initialize page setup settings for the worksheet if which not
 // exist.
 func (ws *xlsxWorksheet) newPageSetUp() {
@@ -1518,7 +3033,7 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) {
 		ws.newPageSetUp()
 		ws.PageSetUp.PaperSize = opts.Size
 	}
-	if opts.Orientation != nil && (*opts.Orientation == "portrait" || *opts.Orientation == "landscape") {
+	if opts.Orientation != nil {
 		ws.newPageSetUp()
 		ws.PageSetUp.Orientation = *opts.Orientation
 	}
@@ -1527,7 +3042,7 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) {
 		ws.PageSetUp.FirstPageNumber = strconv.Itoa(int(*opts.FirstPageNumber))
 		ws.PageSetUp.UseFirstPageNumber = true
 	}
-	if opts.AdjustTo != nil && 10 <= *opts.AdjustTo && *opts.AdjustTo <= 400 {
+	if opts.AdjustTo != nil {
 		ws.newPageSetUp()
 		ws.PageSetUp.Scale = int(*opts.AdjustTo)
 	}
@@ -1539,26 +3054,34 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) {
 		ws.newPageSetUp()
 		ws.PageSetUp.FitToWidth = opts.FitToWidth
 	}
+	if opts.FitToHeight != nil || opts.FitToWidth != nil {
+		ws.setSheetProps(&SheetPropsOptions{FitToPage: boolPtr(true)})
+	} else if opts.AdjustTo != nil && ws.SheetPr != nil && ws.SheetPr.PageSetUpPr != nil {
+		ws.SheetPr.PageSetUpPr.FitToPage = false
+	}
 	if opts.BlackAndWhite != nil {
 		ws.newPageSetUp()
 		ws.PageSetUp.BlackAndWhite = *opts.BlackAndWhite
 	}
 }
 
-// GetPageLayout provides a function to gets worksheet page layout.
+// GetPageLayout provides a function to gets worksheet page layout, including
+// the page margins. The paper size will be 1 (Letter) if it was not specified,
+// which is the default paper size of the spreadsheet, so that the returned
+// options can be passed into the SetPageLayout function.
 func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 	opts := PageLayoutOptions{
-		Size:            intPtr(0),
+		Size:            intPtr(1),
 		Orientation:     stringPtr("portrait"),
 		FirstPageNumber: uintPtr(1),
 		AdjustTo:        uintPtr(100),
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.printSettingsReader(sheet)
 	if err != nil {
 		return opts, err
 	}
 	if ws.PageSetUp != nil {
-		if ws.PageSetUp.PaperSize != nil {
+		if ws.PageSetUp.PaperSize != nil && *ws.PageSetUp.PaperSize > 0 {
 			opts.Size = ws.PageSetUp.PaperSize
 		}
 		if ws.PageSetUp.Orientation != "" {
@@ -1578,6 +3101,16 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 		}
 		opts.BlackAndWhite = boolPtr(ws.PageSetUp.BlackAndWhite)
 	}
+	if ws.SheetPr != nil && ws.SheetPr.PageSetUpPr != nil && ws.SheetPr.PageSetUpPr.FitToPage {
+		if opts.FitToHeight == nil {
+			opts.FitToHeight = intPtr(1)
+		}
+		if opts.FitToWidth == nil {
+			opts.FitToWidth = intPtr(1)
+		}
+	}
+	margins := ws.getPageMargins()
+	opts.Margins = &margins
 	return opts, err
 }
 
@@ -1592,6 +3125,9 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 //	    Scope:    "Sheet2",
 //	})
 func (f *File) SetDefinedName(definedName *DefinedName) error {
+	if err := f.checkReadOnly("SetDefinedName"); err != nil {
+		return err
+	}
 	if definedName.Name == "" || definedName.RefersTo == "" {
 		return ErrParameterInvalid
 	}
@@ -1640,6 +3176,9 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 //	    Scope:    "Sheet2",
 //	})
 func (f *File) DeleteDefinedName(definedName *DefinedName) error {
+	if err := f.checkReadOnly("DeleteDefinedName"); err != nil {
+		return err
+	}
 	wb, err := f.workbookReader()
 	if err != nil {
 		return err
@@ -1688,6 +3227,9 @@ func (f *File) GetDefinedName() []DefinedName {
 // GroupSheets provides a function to group worksheets by given worksheets
 // name. Group worksheets must contain an active worksheet.
 func (f *File) GroupSheets(sheets []string) error {
+	if err := f.checkReadOnly("GroupSheets"); err != nil {
+		return err
+	}
 	// Check an active worksheet in group worksheets
 	var inActiveSheet bool
 	activeSheet := f.GetActiveSheetIndex()
@@ -1725,6 +3267,9 @@ func (f *File) GroupSheets(sheets []string) error {
 
 // UngroupSheets provides a function to ungroup worksheets.
 func (f *File) UngroupSheets() error {
+	if err := f.checkReadOnly("UngroupSheets"); err != nil {
+		return err
+	}
 	activeSheet := f.GetActiveSheetIndex()
 	for index, sheet := range f.GetSheetList() {
 		if activeSheet == index {
@@ -1746,6 +3291,9 @@ func (f *File) UngroupSheets() error {
 // reference, so the content before the page break will be printed on one page
 // and after the page break on another.
 func (f *File) InsertPageBreak(sheet, cell string) error {
+	if err := f.checkReadOnly("InsertPageBreak"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -1809,8 +3357,12 @@ func (ws *xlsxWorksheet) insertPageBreak(cell string) error {
 }
 
 // RemovePageBreak remove a page break by given worksheet name and cell
-// reference.
+// reference. The row page break before the row of the cell and the column page
+// break before the column of the cell will be removed.
 func (f *File) RemovePageBreak(sheet, cell string) error {
+	if err := f.checkReadOnly("RemovePageBreak"); err != nil {
+		return err
+	}
 	var (
 		ws       *xlsxWorksheet
 		row, col int
@@ -1824,41 +3376,258 @@ func (f *File) RemovePageBreak(sheet, cell string) error {
 	}
 	col--
 	row--
-	if col == row && col == 0 {
+	removeBrk := func(ID int, brks *xlsxBreaks) {
+		for i, brk := range brks.Brk {
+			if brk.ID == ID {
+				brks.Brk = append(brks.Brk[:i], brks.Brk[i+1:]...)
+				brks.Count = len(brks.Brk)
+				if brk.Man {
+					brks.ManualBreakCount--
+				}
+				return
+			}
+		}
+	}
+	if ws.RowBreaks != nil && row != 0 {
+		removeBrk(row, &ws.RowBreaks.xlsxBreaks)
+	}
+	if ws.ColBreaks != nil && col != 0 {
+		removeBrk(col, &ws.ColBreaks.xlsxBreaks)
+	}
+	return err
+}
+
+// GetPageBreaks provides a function to get the row and column page breaks by
+// given worksheet name. The Number of the page break is the row or column
+// number which the next printed page begins with. For example, get the page
+// breaks of Sheet1:
+//
+//	breaks, err := f.GetPageBreaks("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, brk := range breaks.Rows {
+//	    fmt.Println("page break before row", brk.Number, "manual", brk.Manual)
+//	}
+func (f *File) GetPageBreaks(sheet string) (PageBreaks, error) {
+	var breaks PageBreaks
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return breaks, err
+	}
+	getBrks := func(brks *xlsxBreaks) []PageBreak {
+		var result []PageBreak
+		for _, brk := range brks.Brk {
+			result = append(result, PageBreak{Number: brk.ID + 1, Manual: brk.Man})
+		}
+		return result
+	}
+	if ws.RowBreaks != nil {
+		breaks.Rows = getBrks(&ws.RowBreaks.xlsxBreaks)
+	}
+	if ws.ColBreaks != nil {
+		breaks.Columns = getBrks(&ws.ColBreaks.xlsxBreaks)
+	}
+	return breaks, err
+}
+
+// ResetAllPageBreaks provides a function to remove all row and column page
+// breaks by given worksheet name.
+func (f *File) ResetAllPageBreaks(sheet string) error {
+	if err := f.checkReadOnly("ResetAllPageBreaks"); err != nil {
 		return err
 	}
-	removeBrk := func(ID int, brks []*xlsxBrk) []*xlsxBrk {
-		for i, brk := range brks {
-			if brk.ID == ID {
-				brks = append(brks[:i], brks[i+1:]...)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.RowBreaks, ws.ColBreaks = nil, nil
+	return err
+}
+
+// flags returns the pointers to the ignored error types of the ignored error
+// element, which are indexed by the ignored error type.
+func (ie *xlsxIgnoredError) flags() []*bool {
+	return []*bool{
+		&ie.EvalError, &ie.TwoDigitTextYear, &ie.NumberStoredAsText,
+		&ie.Formula, &ie.FormulaRange, &ie.UnlockedFormula,
+		&ie.EmptyCellReference, &ie.ListDataValidation, &ie.CalculatedColumn,
+	}
+}
+
+// types returns the ignored error types of the ignored error element.
+func (ie *xlsxIgnoredError) types() []IgnoredErrorType {
+	var types []IgnoredErrorType
+	for t, flag := range ie.flags() {
+		if *flag {
+			types = append(types, IgnoredErrorType(t))
+		}
+	}
+	return types
+}
+
+// prepareIgnoredErrorsRef provides a function to check the ignored error types
+// and parse the space separated cell or range references sequence into the
+// sorted coordinates of the ranges.
+func prepareIgnoredErrorsRef(ref string, types []IgnoredErrorType) ([][]int, error) {
+	for _, t := range types {
+		if int(t) >= len((&xlsxIgnoredError{}).flags()) {
+			return nil, ErrParameterInvalid
+		}
+	}
+	var rects [][]int
+	for _, field := range strings.Fields(ref) {
+		_, rect, err := parseRangeRef(field)
+		if err != nil {
+			return nil, err
+		}
+		rects = append(rects, rect)
+	}
+	if len(rects) == 0 {
+		return nil, newInvalidRangeRefError(ref)
+	}
+	return rects, nil
+}
+
+// AddIgnoredErrors provides a function to ignore the errors of the given
+// types in the cell or range references by given worksheet name, the
+// reference could be a space separated references sequence. The spreadsheet
+// application will not flag the cells with the green triangles for the
+// ignored errors. The references of the same ignored error type will be
+// merged if the merged reference is still a rectangle. For example, ignore the
+// numbers stored as text error in the range A1:A10 on Sheet1:
+//
+//	err := f.AddIgnoredErrors("Sheet1", "A1:A10", excelize.IgnoredErrorNumberStoredAsText)
+//
+// The supported ignored error types are:
+//
+//	IgnoredErrorEvalError
+//	IgnoredErrorTwoDigitTextYear
+//	IgnoredErrorNumberStoredAsText
+//	IgnoredErrorFormula
+//	IgnoredErrorFormulaRange
+//	IgnoredErrorUnlockedFormula
+//	IgnoredErrorEmptyCellReference
+//	IgnoredErrorListDataValidation
+//	IgnoredErrorCalculatedColumn
+func (f *File) AddIgnoredErrors(sheet, ref string, types ...IgnoredErrorType) error {
+	if err := f.checkReadOnly("AddIgnoredErrors"); err != nil {
+		return err
+	}
+	if len(types) == 0 {
+		return ErrParameterRequired
+	}
+	if _, err := prepareIgnoredErrorsRef(ref, types); err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.IgnoredErrors == nil {
+		ws.IgnoredErrors = &xlsxIgnoredErrors{}
+	}
+	for _, t := range types {
+		idx := -1
+		for i := range ws.IgnoredErrors.IgnoredError {
+			if types := ws.IgnoredErrors.IgnoredError[i].types(); len(types) == 1 && types[0] == t {
+				idx = i
+				break
 			}
 		}
-		return brks
+		if idx == -1 {
+			ie := xlsxIgnoredError{}
+			*ie.flags()[t] = true
+			ws.IgnoredErrors.IgnoredError = append(ws.IgnoredErrors.IgnoredError, ie)
+			idx = len(ws.IgnoredErrors.IgnoredError) - 1
+		}
+		ie := &ws.IgnoredErrors.IgnoredError[idx]
+		ie.Sqref = UnionRanges(ie.Sqref, ref)
+	}
+	return err
+}
+
+// GetIgnoredErrors provides a function to get the ignored errors by given
+// worksheet name.
+func (f *File) GetIgnoredErrors(sheet string) ([]IgnoredErrorsOptions, error) {
+	var opts []IgnoredErrorsOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.IgnoredErrors == nil {
+		return opts, err
+	}
+	for _, ie := range ws.IgnoredErrors.IgnoredError {
+		opts = append(opts, IgnoredErrorsOptions{Ref: ie.Sqref, Types: ie.types()})
 	}
-	if ws.RowBreaks == nil || ws.ColBreaks == nil {
+	return opts, err
+}
+
+// RemoveIgnoredErrors provides a function to stop ignoring the errors of the
+// given types in the cell or range references by given worksheet name, and
+// all ignored error types will be removed if the types not specified. For
+// example, stop ignoring all errors in the range A1:A5 on Sheet1:
+//
+//	err := f.RemoveIgnoredErrors("Sheet1", "A1:A5")
+func (f *File) RemoveIgnoredErrors(sheet, ref string, types ...IgnoredErrorType) error {
+	if err := f.checkReadOnly("RemoveIgnoredErrors"); err != nil {
 		return err
 	}
-	rowBrks := len(ws.RowBreaks.Brk)
-	colBrks := len(ws.ColBreaks.Brk)
-	if rowBrks > 0 && rowBrks == colBrks {
-		ws.RowBreaks.Brk = removeBrk(row, ws.RowBreaks.Brk)
-		ws.ColBreaks.Brk = removeBrk(col, ws.ColBreaks.Brk)
-		ws.RowBreaks.Count = len(ws.RowBreaks.Brk)
-		ws.ColBreaks.Count = len(ws.ColBreaks.Brk)
-		ws.RowBreaks.ManualBreakCount--
-		ws.ColBreaks.ManualBreakCount--
+	rects, err := prepareIgnoredErrorsRef(ref, types)
+	if err != nil {
 		return err
 	}
-	if rowBrks > 0 && rowBrks > colBrks {
-		ws.RowBreaks.Brk = removeBrk(row, ws.RowBreaks.Brk)
-		ws.RowBreaks.Count = len(ws.RowBreaks.Brk)
-		ws.RowBreaks.ManualBreakCount--
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.IgnoredErrors == nil {
 		return err
 	}
-	if colBrks > 0 && colBrks > rowBrks {
-		ws.ColBreaks.Brk = removeBrk(col, ws.ColBreaks.Brk)
-		ws.ColBreaks.Count = len(ws.ColBreaks.Brk)
-		ws.ColBreaks.ManualBreakCount--
+	if len(types) == 0 {
+		for t := range (&xlsxIgnoredError{}).flags() {
+			types = append(types, IgnoredErrorType(t))
+		}
+	}
+	var result []xlsxIgnoredError
+	for _, ie := range ws.IgnoredErrors.IgnoredError {
+		removed := ie
+		for t, flag := range ie.flags() {
+			removing := false
+			for _, rt := range types {
+				removing = removing || int(rt) == t
+			}
+			*flag = *flag && !removing
+			*removed.flags()[t] = *removed.flags()[t] && removing
+		}
+		if len(removed.types()) == 0 {
+			result = append(result, ie)
+			continue
+		}
+		var remains []string
+		for _, field := range strings.Fields(removed.Sqref) {
+			_, rect, err := parseRangeRef(field)
+			if err != nil {
+				continue
+			}
+			pieces := [][]int{rect}
+			for _, r := range rects {
+				var next [][]int
+				for _, piece := range pieces {
+					next = append(next, subtractRect(piece, r)...)
+				}
+				pieces = next
+			}
+			for _, piece := range pieces {
+				remains = append(remains, rectToRangeRef(piece))
+			}
+		}
+		if removed.Sqref = UnionRanges(remains...); removed.Sqref != "" {
+			result = append(result, removed)
+		}
+		if len(ie.types()) > 0 {
+			result = append(result, ie)
+		}
+	}
+	ws.IgnoredErrors.IgnoredError = result
+	if len(result) == 0 && ws.IgnoredErrors.ExtLst == nil {
+		ws.IgnoredErrors = nil
 	}
 	return err
 }
@@ -1933,6 +3702,9 @@ func (ws *xlsxWorksheet) makeContiguousColumns(fromRow, toRow, colCount int) {
 // reference style(e.g., "A1:D5"). Passing an empty range reference will remove
 // the used range of the worksheet.
 func (f *File) SetSheetDimension(sheet string, rangeRef string) error {
+	if err := f.checkReadOnly("SetSheetDimension"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -1975,3 +3747,105 @@ func (f *File) GetSheetDimension(sheet string) (string, error) {
 	}
 	return ref, err
 }
+
+// UpdateSheetDimension provides the method to recalculate the used range of
+// the worksheet by the cells in the worksheet, and update the dimension of
+// the worksheet with it. The dimension element in the spreadsheet generated
+// by other applications may be stale, use this function to correct it. The
+// cells which only have the style but without value are also counted as used
+// cells, and the dimension will be set as "A1" if the worksheet is empty.
+func (f *File) UpdateSheetDimension(sheet string) error {
+	if err := f.checkReadOnly("UpdateSheetDimension"); err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.updateDimension()
+	return err
+}
+
+// UsedRange provides the method to get the used range of the worksheet by
+// given worksheet name, the used range is calculated by the cells in the
+// worksheet instead of the dimension element of the worksheet. The cells
+// which only have the style but without value will be ignored if the
+// ignoreEmpty is true. An empty string will be returned if there are no used
+// cells in the worksheet. For example, get the used range of the cells which
+// contain value or formula in the worksheet named Sheet1:
+//
+//	ref, err := f.UsedRange("Sheet1", true)
+func (f *File) UsedRange(sheet string, ignoreEmpty bool) (string, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return "", err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.usedRange(ignoreEmpty), err
+}
+
+// usedRange calculates the used range of the worksheet by the cells in the
+// worksheet, the cells in each row are sorted, so only the first and last
+// used cells in each row need to be found. The placeholder cells without
+// value and style are always ignored.
+func (ws *xlsxWorksheet) usedRange(ignoreEmpty bool) string {
+	used := func(c *xlsxC) bool {
+		return c.V != "" || c.F != nil || c.IS != nil || (!ignoreEmpty && c.S != 0)
+	}
+	minCol, minRow, maxCol, maxRow := 0, 0, 0, 0
+	for rowIdx := range ws.SheetData.Row {
+		cells := ws.SheetData.Row[rowIdx].C
+		first, last := 0, len(cells)-1
+		for first <= last && !used(&cells[first]) {
+			first++
+		}
+		for last >= first && !used(&cells[last]) {
+			last--
+		}
+		if first > last {
+			continue
+		}
+		firstCol, row, err := CellNameToCoordinates(cells[first].R)
+		if err != nil {
+			continue
+		}
+		lastCol, _, err := CellNameToCoordinates(cells[last].R)
+		if err != nil {
+			continue
+		}
+		if minRow == 0 || row < minRow {
+			minRow = row
+		}
+		if row > maxRow {
+			maxRow = row
+		}
+		if minCol == 0 || firstCol < minCol {
+			minCol = firstCol
+		}
+		if lastCol > maxCol {
+			maxCol = lastCol
+		}
+	}
+	if minRow == 0 {
+		return ""
+	}
+	topLeft, _ := CoordinatesToCellName(minCol, minRow)
+	if minCol == maxCol && minRow == maxRow {
+		return topLeft
+	}
+	bottomRight, _ := CoordinatesToCellName(maxCol, maxRow)
+	return topLeft + ":" + bottomRight
+}
+
+// updateDimension updates the dimension of the worksheet by the used range of
+// the worksheet.
+func (ws *xlsxWorksheet) updateDimension() {
+	ref := ws.usedRange(false)
+	if ref == "" {
+		ref = "A1"
+	}
+	ws.Dimension = &xlsxDimension{Ref: ref}
+}
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -169,6 +171,39 @@ func TestSearchSheet(t *testing.T) {
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", true))
 	_, err = f.SearchSheet("Sheet1", "")
 	assert.NoError(t, err)
+	// Test search on the displayed value of the date cell
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)))
+	style, err := f.NewStyle(&Style{NumFmt: 15})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", style))
+	result, err = f.SearchSheet("Sheet1", "31-Jan-23")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B2"}, result)
+	result, err = f.SearchSheet("Sheet1", "^\\d+-Jan", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B2"}, result)
+	// Test search skips the cells covered by the merged cells
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.SetCellValue("Sheet1", "C2", "merged"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D3", "C2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E3", "merged"))
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[2] = xlsxC{R: "C3", T: "inlineStr", IS: &xlsxSI{T: &xlsxT{Val: "merged"}}}
+	result, err = f.SearchSheet("Sheet1", "merged")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C2", "E3"}, result)
+	// Test search with invalid regular expression
+	_, err = f.SearchSheet("Sheet1", "[", true)
+	assert.EqualError(t, err, "error parsing regexp: missing closing ]: `[`")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSearchSheet.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test search on the worksheet which stored in the system temporary directory
+	f, err = OpenFile(filepath.Join("test", "TestSearchSheet.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	result, err = f.SearchSheet("Sheet1", "31-Jan-23")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B2"}, result)
+	assert.NoError(t, f.Close())
 
 	f = NewFile()
 	f.Sheet.Delete("xl/worksheets/sheet1.xml")
@@ -195,6 +230,153 @@ func TestSearchSheet(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestReplaceInSheet(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		assert.NoError(t, f.SetCellValue(sheet, "A1", "Old Co, Old Co"))
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "Old Co, Old Co"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", 100))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A4", "\"Old Co\"&\"!\""))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A5", []RichTextRun{
+		{Text: "Old ", Font: &Font{Bold: true}}, {Text: "Co"}, {Text: " Old Co"},
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row = append(ws.(*xlsxWorksheet).SheetData.Row, xlsxRow{
+		R: 6, C: []xlsxC{{R: "A6", T: "inlineStr", IS: &xlsxSI{T: &xlsxT{Val: "Old Co"}, R: []xlsxR{{T: &xlsxT{Val: " Old Co"}}}}}},
+	})
+	count, err := f.ReplaceInSheet("Sheet1", "Old Co", "New Co")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, count)
+	for cell, expected := range map[string]string{
+		"A1": "New Co, New Co", "A2": "New Co, New Co", "A3": "100",
+		"A4": "", "A5": "Old Co New Co", "A6": "New Co New Co",
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	runs, err := f.GetCellRichText("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.Len(t, runs, 3)
+	assert.True(t, runs[0].Font.Bold)
+	formula, err := f.GetCellFormula("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "\"Old Co\"&\"!\"", formula)
+	// Test the shared string referenced by other worksheet is not changed
+	value, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Old Co, Old Co", value)
+	// Test replace in the formula text with regular expression
+	count, err = f.ReplaceInSheet("Sheet1", `(Old|New) (Co)`, "$2 $1", true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, count)
+	formula, err = f.GetCellFormula("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "\"Co Old\"&\"!\"", formula)
+	value, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Co New, Co New", value)
+	count, err = f.ReplaceInSheet("Sheet1", "Not Exists", "")
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestReplaceInSheet.xlsx")))
+	// Test replace with invalid parameters
+	_, err = f.ReplaceInSheet("Sheet1", "", "")
+	assert.Equal(t, ErrParameterRequired, err)
+	_, err = f.ReplaceInSheet("Sheet1", "[", "", true)
+	assert.EqualError(t, err, "error parsing regexp: missing closing ]: `[`")
+	// Test replace on not exists worksheet
+	_, err = f.ReplaceInSheet("SheetN", "A", "B")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+	// Test replace in the string cells, and the formula cells with cached results
+	f = NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "\"Old\"&B1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "\"Old\"&D1", FormulaOpts{Type: stringPtr(STCellFormulaTypeShared), Ref: stringPtr("C1:C2")}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	sheetData := &ws.(*xlsxWorksheet).SheetData
+	for _, c := range []*xlsxC{&sheetData.Row[0].C[0], &sheetData.Row[0].C[2], &sheetData.Row[1].C[2]} {
+		c.T, c.V = "str", "Old"
+	}
+	sheetData.Row = append(sheetData.Row, xlsxRow{R: 3, C: []xlsxC{{R: "A3", T: "str", V: "Old Co"}}})
+	count, err = f.ReplaceInSheet("Sheet1", "Old", "New")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	value, err = f.GetCellValue("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "New Co", value)
+	value, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Old", value)
+	count, err = f.ReplaceInSheet("Sheet1", "Old", "New", false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	for _, cell := range []string{"A1", "C1", "C2"} {
+		value, err = f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, value, cell)
+	}
+	for cell, expected := range map[string]string{"A1": "\"New\"&B1", "C2": "\"New\"&D2"} {
+		formula, err = f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula)
+	}
+	assert.NoError(t, f.Close())
+	// Test replace in the shared string with phonetic runs
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Old"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "Old"))
+	sst, err := f.sharedStringsReader()
+	assert.NoError(t, err)
+	sst.SI = append(sst.SI, xlsxSI{
+		T:          &xlsxT{Val: "東京 Old"},
+		RPh:        []*xlsxPhoneticRun{{Sb: 0, Eb: 2, T: "トウキョウ"}, {Sb: 3, Eb: 6, T: "オールド"}},
+		PhoneticPr: &xlsxPhoneticPr{FontID: intPtr(1)},
+	})
+	sst.Count++
+	sst.UniqueCount++
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].V = "1"
+	count, err = f.ReplaceInSheet("Sheet1", "Old", "New")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	si := sst.SI[2]
+	assert.Equal(t, "東京 New", si.T.Val)
+	assert.Equal(t, []*xlsxPhoneticRun{{Sb: 0, Eb: 2, T: "トウキョウ"}}, si.RPh)
+	assert.Equal(t, &xlsxPhoneticPr{FontID: intPtr(1)}, si.PhoneticPr)
+	assert.Equal(t, "東京 Old", sst.SI[1].T.Val)
+	value, err = f.GetCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "New", value)
+	// Test replace with different length moves the phonetic runs after it
+	ws.(*xlsxWorksheet).SheetData.Row[1].C[0] = xlsxC{R: "A2", T: "inlineStr", IS: &xlsxSI{
+		T:   &xlsxT{Val: "Old 東京 Old 大阪"},
+		RPh: []*xlsxPhoneticRun{{Sb: 4, Eb: 6, T: "トウキョウ"}, {Sb: 7, Eb: 10, T: "オールド"}, {Sb: 11, Eb: 13, T: "オオサカ"}},
+	}}
+	count, err = f.ReplaceInSheet("Sheet1", "Old", "Co", true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	is := ws.(*xlsxWorksheet).SheetData.Row[1].C[0].IS
+	assert.Equal(t, "Co 東京 Co 大阪", is.T.Val)
+	assert.Equal(t, []*xlsxPhoneticRun{{Sb: 3, Eb: 5, T: "トウキョウ"}, {Sb: 9, Eb: 11, T: "オオサカ"}}, is.RPh)
+	// Test replace on not exists worksheet
+	_, err = f.ReplaceInSheet("SheetN", "A", "B")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+	// Test replace with unsupported charset shared strings table
+	f = NewFile()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.ReplaceInSheet("Sheet1", "A", "B")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetPageLayout(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetPageLayout("Sheet1", nil))
@@ -209,21 +391,185 @@ func TestSetPageLayout(t *testing.T) {
 		FitToHeight:     intPtr(2),
 		FitToWidth:      intPtr(2),
 		BlackAndWhite:   boolPtr(true),
+		Margins: &PageLayoutMarginsOptions{
+			Bottom:       float64Ptr(0.5),
+			Footer:       float64Ptr(0.2),
+			Header:       float64Ptr(0.2),
+			Left:         float64Ptr(0.5),
+			Right:        float64Ptr(0.5),
+			Top:          float64Ptr(0.5),
+			Horizontally: boolPtr(true),
+			Vertically:   boolPtr(false),
+		},
 	}
 	assert.NoError(t, f.SetPageLayout("Sheet1", &expected))
 	opts, err := f.GetPageLayout("Sheet1")
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	margins, err := f.GetPageMargins("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, *expected.Margins, margins)
+	// Test set page layout with A4 paper size
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{Size: intPtr(9)}))
+	opts, err = f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 9, *opts.Size)
+	assert.Equal(t, "landscape", *opts.Orientation)
+	// Test set page layout with invalid paper size
+	for _, size := range []int{0, 48, 49, 119} {
+		assert.EqualError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{Size: intPtr(size)}), newInvalidPaperSizeError(size).Error())
+	}
+	// Test set page layout with invalid orientation
+	assert.EqualError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{Orientation: stringPtr("x")}),
+		newInvalidOptionalValue("Orientation", "x", []string{"portrait", "landscape"}).Error())
+	// Test set page layout with invalid scaling
+	for _, adjustTo := range []uint{9, 401} {
+		assert.Equal(t, ErrPageSetupAdjustTo, f.SetPageLayout("Sheet1", &PageLayoutOptions{AdjustTo: uintPtr(adjustTo)}))
+	}
+	opts, err = f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 9, *opts.Size)
+	assert.Equal(t, uint(120), *opts.AdjustTo)
 	// Test set page layout on not exists worksheet
 	assert.EqualError(t, f.SetPageLayout("SheetN", nil), "sheet SheetN does not exist")
 	// Test set page layout with invalid sheet name
 	assert.EqualError(t, f.SetPageLayout("Sheet:1", nil), ErrSheetNameInvalid.Error())
 }
 
+func TestSetPageLayoutFitToPage(t *testing.T) {
+	f := NewFile()
+	// Test fit all columns on one page wide and as many pages tall as needed
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{
+		AdjustTo:    uintPtr(120),
+		FitToWidth:  intPtr(1),
+		FitToHeight: intPtr(0),
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.True(t, ws.(*xlsxWorksheet).SheetPr.PageSetUpPr.FitToPage)
+	output, err := xml.Marshal(ws.(*xlsxWorksheet).PageSetUp)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `fitToHeight="0" fitToWidth="1"`)
+	props, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, *props.FitToPage)
+	opts, err := f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, *opts.FitToHeight)
+	assert.Equal(t, 1, *opts.FitToWidth)
+	// Test get the default fit to pages with fit to page print option
+	ws.(*xlsxWorksheet).PageSetUp.FitToHeight = nil
+	ws.(*xlsxWorksheet).PageSetUp.FitToWidth = nil
+	opts, err = f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *opts.FitToHeight)
+	assert.Equal(t, 1, *opts.FitToWidth)
+	// Test set the scaling will disable the fit to page print option
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{AdjustTo: uintPtr(80)}))
+	assert.False(t, ws.(*xlsxWorksheet).SheetPr.PageSetUpPr.FitToPage)
+	opts, err = f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(80), *opts.AdjustTo)
+	assert.Nil(t, opts.FitToHeight)
+	assert.Nil(t, opts.FitToWidth)
+	// Test set page layout with invalid fit to pages
+	assert.Equal(t, ErrParameterInvalid, f.SetPageLayout("Sheet1", &PageLayoutOptions{FitToWidth: intPtr(-1)}))
+	assert.Equal(t, ErrParameterInvalid, f.SetPageLayout("Sheet1", &PageLayoutOptions{FitToHeight: intPtr(-1)}))
+}
+
+func TestChartSheetPrintSettings(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}}
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.SetPageLayout("Chart1", &PageLayoutOptions{
+		Size:          intPtr(9),
+		Orientation:   stringPtr("landscape"),
+		AdjustTo:      uintPtr(120),
+		FitToWidth:    intPtr(1),
+		BlackAndWhite: boolPtr(true),
+		Margins:       &PageLayoutMarginsOptions{Top: float64Ptr(0.5), Horizontally: boolPtr(true)},
+	}))
+	assert.NoError(t, f.SetPageMargins("Chart1", &PageLayoutMarginsOptions{Left: float64Ptr(0.25)}))
+	assert.NoError(t, f.SetHeaderFooter("Chart1", &HeaderFooterOptions{OddHeader: "&CBoard Pack", OddFooter: "&RPage &P"}))
+	content, ok := f.Pkg.Load("xl/chartsheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<pageSetup blackAndWhite="true" orientation="landscape" paperSize="9"></pageSetup>`)
+	assert.Contains(t, string(content.([]byte)), `<headerFooter><oddHeader>&amp;CBoard Pack</oddHeader><oddFooter>&amp;RPage &amp;P</oddFooter></headerFooter><drawing r:id="rId1"></drawing>`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartSheetPrintSettings.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestChartSheetPrintSettings.xlsx"))
+	assert.NoError(t, err)
+	opts, err := f.GetPageLayout("Chart1")
+	assert.NoError(t, err)
+	assert.Equal(t, 9, *opts.Size)
+	assert.Equal(t, "landscape", *opts.Orientation)
+	assert.Equal(t, uint(100), *opts.AdjustTo)
+	assert.Nil(t, opts.FitToWidth)
+	assert.True(t, *opts.BlackAndWhite)
+	margins, err := f.GetPageMargins("Chart1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, *margins.Top)
+	assert.Equal(t, 0.25, *margins.Left)
+	assert.Nil(t, margins.Horizontally)
+	hf, err := f.GetHeaderFooter("Chart1")
+	assert.NoError(t, err)
+	assert.Equal(t, "&CBoard Pack", hf.OddHeader)
+	assert.Equal(t, "&RPage &P", hf.OddFooter)
+	// Test remove the headers and footers of the chartsheet
+	assert.NoError(t, f.SetHeaderFooter("Chart1", nil))
+	hf, err = f.GetHeaderFooter("Chart1")
+	assert.NoError(t, err)
+	assert.Empty(t, hf.OddHeader)
+	// Test set page layout on the chartsheet with invalid options
+	assert.Equal(t, newInvalidPaperSizeError(0), f.SetPageLayout("Chart1", &PageLayoutOptions{Size: intPtr(0)}))
+	assert.NoError(t, f.SetPageLayout("Chart1", nil))
+	assert.NoError(t, f.SetPageMargins("Chart1", nil))
+	// Test set page layout on the chartsheet with unsupported charset
+	f.Pkg.Store("xl/chartsheets/sheet2.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetPageLayout("Chart1", nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetPageMargins("Chart1", nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetHeaderFooter("Chart1", nil), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetPageMargins("Chart1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestGetPageLayout(t *testing.T) {
 	f := NewFile()
+	opts, err := f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PageLayoutOptions{
+		Size:            intPtr(1),
+		Orientation:     stringPtr("portrait"),
+		FirstPageNumber: uintPtr(1),
+		AdjustTo:        uintPtr(100),
+		Margins: &PageLayoutMarginsOptions{
+			Bottom: float64Ptr(0.75),
+			Footer: float64Ptr(0.3),
+			Header: float64Ptr(0.3),
+			Left:   float64Ptr(0.7),
+			Right:  float64Ptr(0.7),
+			Top:    float64Ptr(0.75),
+		},
+	}, opts)
+	// Test set the page layout by the default options
+	assert.NoError(t, f.SetPageLayout("Sheet1", &opts))
+	// Test get page layout with the invalid paper size
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).PageSetUp.PaperSize = intPtr(0)
+	opts, err = f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *opts.Size)
+	// Test get and set the default page layout of the chartsheet
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$2"}}}))
+	opts, err = f.GetPageLayout("Chart1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *opts.Size)
+	assert.NoError(t, f.SetPageLayout("Chart1", &opts))
 	// Test get page layout on not exists worksheet
-	_, err := f.GetPageLayout("SheetN")
+	_, err = f.GetPageLayout("SheetN")
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 	// Test get page layout with invalid sheet name
 	_, err = f.GetPageLayout("Sheet:1")
@@ -261,6 +607,92 @@ func TestSetHeaderFooter(t *testing.T) {
 		FirstHeader:      `&CCenter &"-,Bold"Bold&"-,Regular"HeaderU+000A&D`,
 	}))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetHeaderFooter.xlsx")))
+	// Test set header and footer with segments
+	segments := &HeaderFooterSegments{
+		Left: []HeaderFooterRun{
+			{Text: "R&D", Font: &Font{Bold: true}},
+			{Text: " Dept."},
+		},
+		Center: []HeaderFooterRun{
+			{Field: "sheet_name", Font: &Font{Family: "Arial", Italic: true}},
+			{Text: "2024", Font: &Font{Size: 14, Color: "FF0000", Underline: "double"}},
+		},
+		Right: []HeaderFooterRun{
+			{Text: "Page "}, {Field: "page"}, {Text: " of "}, {Field: "pages"},
+			{Text: "x", Font: &Font{Strike: true, VertAlign: "superscript"}},
+		},
+	}
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		DifferentFirst:      true,
+		OddHeader:           "&R&P",
+		OddHeaderSegments:   segments,
+		FirstFooterSegments: &HeaderFooterSegments{Center: []HeaderFooterRun{{Field: "file_name"}}},
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, `&L&"-,Bold"R&&D&"-,Regular" Dept.&C&"Arial,Italic"&A&14&"Calibri,Regular"&E&KFF00002024&RPage &P of &N&S&Xx`,
+		ws.(*xlsxWorksheet).HeaderFooter.OddHeader)
+	assert.Equal(t, "&C&F", ws.(*xlsxWorksheet).HeaderFooter.FirstFooter)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetHeaderFooter.xlsx")))
+	// Test get header and footer
+	opts, err := f.GetHeaderFooter("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, opts.DifferentFirst)
+	assert.Equal(t, ws.(*xlsxWorksheet).HeaderFooter.OddHeader, opts.OddHeader)
+	assert.Equal(t, segments, opts.OddHeaderSegments)
+	assert.Equal(t, &HeaderFooterSegments{Center: []HeaderFooterRun{{Field: "file_name"}}}, opts.FirstFooterSegments)
+	assert.Nil(t, opts.EvenHeaderSegments)
+	// Test set header and footer with invalid field
+	assert.Equal(t, newInvalidOptionalValue("Field", "unknown", []string{"page", "pages", "date", "time", "file_name", "file_path", "sheet_name", "picture"}),
+		f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{EvenHeaderSegments: &HeaderFooterSegments{Left: []HeaderFooterRun{{Field: "unknown"}}}}))
+	// Test set header and footer with segments exceeds the maximum length
+	assert.EqualError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		EvenFooterSegments: &HeaderFooterSegments{Right: []HeaderFooterRun{{Text: strings.Repeat("&", MaxFieldLength/2+1)}}},
+	}), newFieldLengthError("EvenFooter").Error())
+	// Test set header and footer with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{}), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetHeaderFooter(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetHeaderFooter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, HeaderFooterOptions{}, opts)
+	// Test get header and footer with formatting codes
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		DifferentOddEven: true,
+		OddHeader:        `Center &BBold&B &10&I&UText&U&D&O&&`,
+		EvenFooter:       `&L&"Times New Roman,Bold Italic"&K0000FFA&S&YB&Y&S&K000000&E&EC&R&T&`,
+	}))
+	opts, err = f.GetHeaderFooter("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, opts.DifferentOddEven)
+	assert.Equal(t, &HeaderFooterSegments{Center: []HeaderFooterRun{
+		{Text: "Center "},
+		{Text: "Bold", Font: &Font{Bold: true}},
+		{Text: " "},
+		{Text: "Text", Font: &Font{Size: 10, Italic: true, Underline: "single"}},
+		{Field: "date", Font: &Font{Size: 10, Italic: true}},
+		{Text: "&", Font: &Font{Size: 10, Italic: true}},
+	}}, opts.OddHeaderSegments)
+	assert.Equal(t, &HeaderFooterSegments{
+		Left: []HeaderFooterRun{
+			{Text: "A", Font: &Font{Family: "Times New Roman", Bold: true, Italic: true, Color: "0000FF"}},
+			{Text: "B", Font: &Font{Family: "Times New Roman", Bold: true, Italic: true, Color: "0000FF", Strike: true, VertAlign: "subscript"}},
+			{Text: "C", Font: &Font{Family: "Times New Roman", Bold: true, Italic: true}},
+		},
+		Right: []HeaderFooterRun{{Field: "time"}, {Text: "&"}},
+	}, opts.EvenFooterSegments)
+	// Test get header and footer on not exists worksheet
+	_, err = f.GetHeaderFooter("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get header and footer with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetHeaderFooter("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestDefinedName(t *testing.T) {
@@ -377,6 +809,107 @@ func TestRemovePageBreak(t *testing.T) {
 	// Test remove page break with invalid sheet name
 	assert.EqualError(t, f.RemovePageBreak("Sheet:1", "A3"), ErrSheetNameInvalid.Error())
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemovePageBreak.xlsx")))
+	// Test remove the row and column page breaks independently
+	assert.NoError(t, f.InsertPageBreak("Sheet2", "D5"))
+	assert.NoError(t, f.RemovePageBreak("Sheet2", "A5"))
+	breaks, err := f.GetPageBreaks("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, PageBreaks{
+		Rows:    nil,
+		Columns: []PageBreak{{Number: 3, Manual: true}, {Number: 4, Manual: true}},
+	}, breaks)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 0, ws.(*xlsxWorksheet).RowBreaks.ManualBreakCount)
+	assert.Equal(t, 2, ws.(*xlsxWorksheet).ColBreaks.ManualBreakCount)
+}
+
+func TestIgnoredErrors(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetIgnoredErrors("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, opts)
+	// Test add ignored errors with adjacent references will be merged
+	assert.NoError(t, f.AddIgnoredErrors("Sheet1", "A1:A10", IgnoredErrorNumberStoredAsText))
+	assert.NoError(t, f.AddIgnoredErrors("Sheet1", "A11:A20 $C$1", IgnoredErrorNumberStoredAsText, IgnoredErrorFormula))
+	assert.NoError(t, f.AddIgnoredErrors("Sheet1", "B1:B20", IgnoredErrorNumberStoredAsText))
+	opts, err = f.GetIgnoredErrors("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []IgnoredErrorsOptions{
+		{Ref: "A1:B20 C1", Types: []IgnoredErrorType{IgnoredErrorNumberStoredAsText}},
+		{Ref: "A11:A20 C1", Types: []IgnoredErrorType{IgnoredErrorFormula}},
+	}, opts)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestIgnoredErrors.xlsx")))
+	// Test remove ignored errors of the given type
+	assert.NoError(t, f.RemoveIgnoredErrors("Sheet1", "A5:B6", IgnoredErrorNumberStoredAsText))
+	opts, err = f.GetIgnoredErrors("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []IgnoredErrorsOptions{
+		{Ref: "A1:B4 A7:B20 C1", Types: []IgnoredErrorType{IgnoredErrorNumberStoredAsText}},
+		{Ref: "A11:A20 C1", Types: []IgnoredErrorType{IgnoredErrorFormula}},
+	}, opts)
+	// Test remove all types of ignored errors
+	assert.NoError(t, f.RemoveIgnoredErrors("Sheet1", "C1 A15:A20"))
+	opts, err = f.GetIgnoredErrors("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []IgnoredErrorsOptions{
+		{Ref: "A1:B4 A7:B14 B15:B20", Types: []IgnoredErrorType{IgnoredErrorNumberStoredAsText}},
+		{Ref: "A11:A14", Types: []IgnoredErrorType{IgnoredErrorFormula}},
+	}, opts)
+	// Test remove a type of the ignored error element with multiple types
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).IgnoredErrors = &xlsxIgnoredErrors{IgnoredError: []xlsxIgnoredError{
+		{Sqref: "D1:D10", EvalError: true, TwoDigitTextYear: true},
+	}}
+	assert.NoError(t, f.RemoveIgnoredErrors("Sheet1", "D1:D5", IgnoredErrorTwoDigitTextYear, IgnoredErrorFormula))
+	opts, err = f.GetIgnoredErrors("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []IgnoredErrorsOptions{
+		{Ref: "D6:D10", Types: []IgnoredErrorType{IgnoredErrorTwoDigitTextYear}},
+		{Ref: "D1:D10", Types: []IgnoredErrorType{IgnoredErrorEvalError}},
+	}, opts)
+	assert.NoError(t, f.RemoveIgnoredErrors("Sheet1", "D:D"))
+	assert.Nil(t, ws.(*xlsxWorksheet).IgnoredErrors)
+	assert.NoError(t, f.RemoveIgnoredErrors("Sheet1", "D:D"))
+	// Test add and remove ignored errors with invalid settings
+	assert.Equal(t, ErrParameterRequired, f.AddIgnoredErrors("Sheet1", "A1"))
+	assert.Equal(t, ErrParameterInvalid, f.AddIgnoredErrors("Sheet1", "A1", IgnoredErrorCalculatedColumn+1))
+	assert.Equal(t, ErrParameterInvalid, f.RemoveIgnoredErrors("Sheet1", "A1", IgnoredErrorCalculatedColumn+1))
+	assert.EqualError(t, f.AddIgnoredErrors("Sheet1", "", IgnoredErrorFormula), newInvalidRangeRefError("").Error())
+	assert.EqualError(t, f.AddIgnoredErrors("Sheet1", "A1 X", IgnoredErrorFormula), newInvalidRangeRefError("X").Error())
+	// Test add, get and remove ignored errors on not exists worksheet
+	assert.EqualError(t, f.AddIgnoredErrors("SheetN", "A1", IgnoredErrorFormula), "sheet SheetN does not exist")
+	_, err = f.GetIgnoredErrors("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.RemoveIgnoredErrors("SheetN", "A1"), "sheet SheetN does not exist")
+}
+
+func TestGetPageBreaks(t *testing.T) {
+	f := NewFile()
+	breaks, err := f.GetPageBreaks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PageBreaks{}, breaks)
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "C5"))
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "A10"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).RowBreaks.Brk = append(ws.(*xlsxWorksheet).RowBreaks.Brk, &xlsxBrk{ID: 20, Max: MaxColumns - 1})
+	breaks, err = f.GetPageBreaks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PageBreaks{
+		Rows:    []PageBreak{{Number: 5, Manual: true}, {Number: 10, Manual: true}, {Number: 21}},
+		Columns: []PageBreak{{Number: 3, Manual: true}},
+	}, breaks)
+	// Test reset all page breaks
+	assert.NoError(t, f.ResetAllPageBreaks("Sheet1"))
+	breaks, err = f.GetPageBreaks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PageBreaks{}, breaks)
+	// Test get and reset page breaks on not exists worksheet
+	_, err = f.GetPageBreaks("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.ResetAllPageBreaks("SheetN"), "sheet SheetN does not exist")
 }
 
 func TestGetSheetName(t *testing.T) {
@@ -437,6 +970,66 @@ func TestSetActiveSheet(t *testing.T) {
 	f.SetActiveSheet(idx)
 }
 
+func TestMoveSheet(t *testing.T) {
+	f := NewFile()
+	for _, name := range []string{"Sheet2", "Sheet3", "Sheet4"} {
+		_, err := f.NewSheet(name)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetSheetVisible("Sheet2", false, true))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Name3", RefersTo: "Sheet3!$A$1", Scope: "Sheet3"}))
+	f.SetActiveSheet(2)
+	// Test move the active sheet before the very hidden sheet
+	assert.NoError(t, f.MoveSheet("Sheet3", 0))
+	assert.Equal(t, []string{"Sheet3", "Sheet1", "Sheet2", "Sheet4"}, f.GetSheetOrder())
+	assert.Equal(t, 0, f.GetActiveSheetIndex())
+	assert.Equal(t, "Sheet3", f.GetSheetMap()[3])
+	assert.Equal(t, "Sheet3", f.GetDefinedName()[0].Scope)
+	// Test move other sheet after the very hidden sheet and the active sheet
+	assert.NoError(t, f.MoveSheet("Sheet1", 3))
+	assert.Equal(t, []string{"Sheet3", "Sheet2", "Sheet4", "Sheet1"}, f.GetSheetOrder())
+	assert.Equal(t, 0, f.GetActiveSheetIndex())
+	f.SetActiveSheet(3)
+	f.WorkBook.BookViews.WorkBookView[0].FirstSheet = 3
+	assert.NoError(t, f.MoveSheet("Sheet1", 1))
+	assert.Equal(t, []string{"Sheet3", "Sheet1", "Sheet2", "Sheet4"}, f.GetSheetOrder())
+	assert.Equal(t, 1, f.GetActiveSheetIndex())
+	assert.Equal(t, 1, f.WorkBook.BookViews.WorkBookView[0].FirstSheet)
+	assert.Equal(t, "Sheet3", f.GetDefinedName()[0].Scope)
+	visible, err := f.GetSheetVisible("Sheet2")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	// Test move sheet to the current position
+	assert.NoError(t, f.MoveSheet("Sheet1", 1))
+	assert.Equal(t, []string{"Sheet3", "Sheet1", "Sheet2", "Sheet4"}, f.GetSheetOrder())
+	// Test only the tab of the active sheet is selected after moving
+	ws, err := f.workSheetReader("Sheet4")
+	assert.NoError(t, err)
+	ws.SheetViews.SheetView[0].TabSelected = true
+	assert.NoError(t, f.MoveSheet("Sheet4", 0))
+	assert.Equal(t, []string{"Sheet4", "Sheet3", "Sheet1", "Sheet2"}, f.GetSheetOrder())
+	assert.Equal(t, 2, f.GetActiveSheetIndex())
+	for _, sheet := range f.GetSheetOrder() {
+		ws, err := f.workSheetReader(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, sheet == "Sheet1", ws.SheetViews.SheetView[0].TabSelected, sheet)
+	}
+	// Test move the first sheet without the workbook views
+	f.WorkBook.BookViews = nil
+	assert.NoError(t, f.MoveSheet("Sheet4", 3))
+	assert.Equal(t, []string{"Sheet3", "Sheet1", "Sheet2", "Sheet4"}, f.GetSheetOrder())
+	assert.Equal(t, 3, f.GetActiveSheetIndex())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveSheet.xlsx")))
+	// Test move sheet with invalid sheet name
+	assert.EqualError(t, f.MoveSheet("Sheet:1", 0), ErrSheetNameInvalid.Error())
+	// Test move not exists sheet
+	assert.EqualError(t, f.MoveSheet("SheetN", 0), "sheet SheetN does not exist")
+	// Test move sheet with invalid index
+	assert.EqualError(t, f.MoveSheet("Sheet1", -1), ErrSheetIdx.Error())
+	assert.EqualError(t, f.MoveSheet("Sheet1", 4), ErrSheetIdx.Error())
+	assert.NoError(t, f.Close())
+}
+
 func TestSetSheetName(t *testing.T) {
 	f := NewFile()
 	// Test set worksheet with the same name
@@ -454,10 +1047,11 @@ func TestWorksheetWriter(t *testing.T) {
 	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(worksheet, 1)))
 	f.checked = nil
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 2))
-	f.workSheetWriter()
-	value, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
 	assert.True(t, ok)
-	assert.Equal(t, fmt.Sprintf(worksheet, 2), string(value.([]byte)))
+	buf := new(bytes.Buffer)
+	assert.NoError(t, f.writeWorkSheet(buf, "xl/worksheets/sheet1.xml", ws.(*xlsxWorksheet)))
+	assert.Equal(t, fmt.Sprintf(worksheet, 2), buf.String())
 }
 
 func TestGetWorkbookPath(t *testing.T) {
@@ -535,6 +1129,59 @@ func TestGetSheetVisible(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestSetSheetVisibility(t *testing.T) {
+	f := NewFile()
+	for _, name := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(name)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetSheetVisibility("Sheet2", SheetHidden))
+	assert.NoError(t, f.SetSheetVisibility("Sheet3", SheetVeryHidden))
+	for sheet, expected := range map[string]SheetVisibility{
+		"Sheet1": SheetVisible, "Sheet2": SheetHidden, "Sheet3": SheetVeryHidden,
+	} {
+		visibility, err := f.GetSheetVisibility(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visibility, sheet)
+	}
+	visible, err := f.GetSheetVisible("Sheet3")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	// Test hide the last visible sheet
+	assert.NoError(t, f.SetSheetVisibility("Sheet1", SheetVeryHidden))
+	visibility, err := f.GetSheetVisibility("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, SheetVisible, visibility)
+	assert.NoError(t, f.SetSheetVisibility("Sheet3", SheetVisible))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetVisibility.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSetSheetVisibility.xlsx"))
+	assert.NoError(t, err)
+	for sheet, expected := range map[string]SheetVisibility{
+		"Sheet1": SheetVisible, "Sheet2": SheetHidden, "Sheet3": SheetVisible,
+	} {
+		visibility, err := f.GetSheetVisibility(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visibility, sheet)
+	}
+	// Test set sheet visibility with invalid visibility state
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetVisibility("Sheet1", SheetVisibility(3)))
+	// Test get sheet visibility on not exists sheet
+	_, err = f.GetSheetVisibility("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get sheet visibility with invalid sheet name
+	_, err = f.GetSheetVisibility("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	assert.NoError(t, f.Close())
+	// Test get sheet visibility with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetSheetVisibility("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetSheetIndex(t *testing.T) {
 	f := NewFile()
 	// Test get sheet index with invalid sheet name
@@ -716,3 +1363,93 @@ func TestSheetDimension(t *testing.T) {
 	assert.Empty(t, dimension)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
+
+func TestUpdateSheetDimension(t *testing.T) {
+	f := NewFile()
+	// Test get used range and update dimension on an empty worksheet
+	ref, err := f.UsedRange("Sheet1", false)
+	assert.NoError(t, err)
+	assert.Empty(t, ref)
+	assert.NoError(t, f.SetSheetDimension("Sheet1", "A1:Z100"))
+	assert.NoError(t, f.UpdateSheetDimension("Sheet1"))
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", dimension)
+
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 1))
+	ref, err = f.UsedRange("Sheet1", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "C3", ref)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", "text"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E4", "C3*2"))
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "G2", "H8", style))
+	ref, err = f.UsedRange("Sheet1", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:H8", ref)
+	// Test get used range ignore the cells only have the style
+	ref, err = f.UsedRange("Sheet1", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "B3:E5", ref)
+	assert.NoError(t, f.UpdateSheetDimension("Sheet1"))
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:H8", dimension)
+
+	// Test update the dimensions of the worksheets on saving
+	assert.NoError(t, f.SetSheetDimension("Sheet1", "A1"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUpdateSheetDimension.xlsx"), Options{UpdateSheetDimensions: true}))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestUpdateSheetDimension.xlsx"))
+	assert.NoError(t, err)
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:H8", dimension)
+	assert.NoError(t, f.Close())
+
+	// Test update dimension and get used range on not exists worksheet
+	f = NewFile()
+	assert.EqualError(t, f.UpdateSheetDimension("SheetN"), "sheet SheetN does not exist")
+	_, err = f.UsedRange("SheetN", false)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestReleaseSheet(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	raw, ok := f.Pkg.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	// Test worksheets are parsed on demand
+	_, ok = f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "released"))
+	_, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.ReleaseSheet("Sheet1"))
+	_, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	// Test get cell value after the worksheet was released
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "released", val)
+	// Test release the worksheet which has not been parsed
+	assert.NoError(t, f.ReleaseSheet("Sheet2"))
+	// Test release the worksheet with invalid sheet name
+	assert.EqualError(t, f.ReleaseSheet("Sheet:1"), ErrSheetNameInvalid.Error())
+	// Test release not exist worksheet
+	assert.EqualError(t, f.ReleaseSheet("SheetN"), "sheet SheetN does not exist")
+	// Test untouched worksheets are saved with the original content
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	content, ok := f.Pkg.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Equal(t, raw, content)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "released", val)
+	assert.NoError(t, f.Close())
+}
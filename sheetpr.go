@@ -13,8 +13,24 @@ package excelize
 
 import "reflect"
 
-// SetPageMargins provides a function to set worksheet page margins.
+// SetPageMargins provides a function to set worksheet or chartsheet page
+// margins. The horizontally and vertically centering options are not
+// applicable to the chartsheet and will be ignored.
 func (f *File) SetPageMargins(sheet string, opts *PageLayoutMarginsOptions) error {
+	if err := f.checkReadOnly("SetPageMargins"); err != nil {
+		return err
+	}
+	cs, name, err := f.chartSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if cs != nil {
+		if opts != nil {
+			cs.setPageLayout(&PageLayoutOptions{Margins: opts})
+			f.chartSheetWriter(name, cs)
+		}
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -22,6 +38,12 @@ func (f *File) SetPageMargins(sheet string, opts *PageLayoutMarginsOptions) erro
 	if opts == nil {
 		return err
 	}
+	ws.setPageMargins(opts)
+	return err
+}
+
+// setPageMargins set page margins settings for the worksheet by given options.
+func (ws *xlsxWorksheet) setPageMargins(opts *PageLayoutMarginsOptions) {
 	preparePageMargins := func(ws *xlsxWorksheet) {
 		if ws.PageMargins == nil {
 			ws.PageMargins = new(xlsxPageMargins)
@@ -48,11 +70,18 @@ func (f *File) SetPageMargins(sheet string, opts *PageLayoutMarginsOptions) erro
 		preparePrintOptions(ws)
 		ws.PrintOptions.VerticalCentered = *opts.Vertically
 	}
-	return err
 }
 
-// GetPageMargins provides a function to get worksheet page margins.
+// GetPageMargins provides a function to get worksheet or chartsheet page
+// margins.
 func (f *File) GetPageMargins(sheet string) (PageLayoutMarginsOptions, error) {
+	ws, err := f.printSettingsReader(sheet)
+	return ws.getPageMargins(), err
+}
+
+// getPageMargins returns the page margins settings of the worksheet, the
+// default value will be returned if the settings not exist.
+func (ws *xlsxWorksheet) getPageMargins() PageLayoutMarginsOptions {
 	opts := PageLayoutMarginsOptions{
 		Bottom: float64Ptr(0.75),
 		Footer: float64Ptr(0.3),
@@ -61,9 +90,8 @@ func (f *File) GetPageMargins(sheet string) (PageLayoutMarginsOptions, error) {
 		Right:  float64Ptr(0.7),
 		Top:    float64Ptr(0.75),
 	}
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
-		return opts, err
+	if ws == nil {
+		return opts
 	}
 	if ws.PageMargins != nil {
 		opts.Bottom = float64Ptr(ws.PageMargins.Bottom)
@@ -77,6 +105,55 @@ func (f *File) GetPageMargins(sheet string) (PageLayoutMarginsOptions, error) {
 		opts.Horizontally = boolPtr(ws.PrintOptions.HorizontalCentered)
 		opts.Vertically = boolPtr(ws.PrintOptions.VerticalCentered)
 	}
+	return opts
+}
+
+// SetPrintOptions provides a function to set the print options of the
+// worksheet. The print options are independent from the display settings of
+// the sheet view, which could be set by the SetSheetView function. For example,
+// hide the gridlines on screen, but print the gridlines and the row and column
+// headings on Sheet1:
+//
+//	enable, disable := true, false
+//	if err := f.SetSheetView("Sheet1", -1, &excelize.ViewOptions{
+//	    ShowGridLines: &disable,
+//	}); err != nil {
+//	    fmt.Println(err)
+//	}
+//	err := f.SetPrintOptions("Sheet1", &excelize.PrintOptions{
+//	    GridLines: &enable,
+//	    Headings:  &enable,
+//	})
+func (f *File) SetPrintOptions(sheet string, opts *PrintOptions) error {
+	if err := f.checkReadOnly("SetPrintOptions"); err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || opts == nil {
+		return err
+	}
+	if ws.PrintOptions == nil {
+		ws.PrintOptions = new(xlsxPrintOptions)
+	}
+	if opts.GridLines != nil {
+		ws.PrintOptions.GridLines = *opts.GridLines
+	}
+	if opts.Headings != nil {
+		ws.PrintOptions.Headings = *opts.Headings
+	}
+	return err
+}
+
+// GetPrintOptions provides a function to get the print options of the
+// worksheet.
+func (f *File) GetPrintOptions(sheet string) (PrintOptions, error) {
+	opts := PrintOptions{GridLines: boolPtr(false), Headings: boolPtr(false)}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.PrintOptions == nil {
+		return opts, err
+	}
+	opts.GridLines = boolPtr(ws.PrintOptions.GridLines)
+	opts.Headings = boolPtr(ws.PrintOptions.Headings)
 	return opts, err
 }
 
@@ -157,6 +234,9 @@ func (ws *xlsxWorksheet) setSheetProps(opts *SheetPropsOptions) {
 
 // SetSheetProps provides a function to set worksheet properties.
 func (f *File) SetSheetProps(sheet string, opts *SheetPropsOptions) error {
+	if err := f.checkReadOnly("SetSheetProps"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
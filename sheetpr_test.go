@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -43,6 +44,44 @@ func TestGetPageMargins(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestPrintOptions(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetPrintOptions("Sheet1", nil))
+	opts, err := f.GetPrintOptions("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PrintOptions{GridLines: boolPtr(false), Headings: boolPtr(false)}, opts)
+	// Test hide the gridlines on screen, but print the gridlines and headings
+	assert.NoError(t, f.SetSheetView("Sheet1", -1, &ViewOptions{ShowGridLines: boolPtr(false)}))
+	expected := PrintOptions{GridLines: boolPtr(true), Headings: boolPtr(true)}
+	assert.NoError(t, f.SetPrintOptions("Sheet1", &expected))
+	opts, err = f.GetPrintOptions("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	view, err := f.GetSheetView("Sheet1", -1)
+	assert.NoError(t, err)
+	assert.False(t, *view.ShowGridLines)
+	assert.True(t, *view.ShowRowColHeaders)
+	// Test show the gridlines on screen, but don't print the gridlines
+	assert.NoError(t, f.SetSheetView("Sheet1", -1, &ViewOptions{ShowGridLines: boolPtr(true)}))
+	assert.NoError(t, f.SetPrintOptions("Sheet1", &PrintOptions{GridLines: boolPtr(false)}))
+	opts, err = f.GetPrintOptions("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PrintOptions{GridLines: boolPtr(false), Headings: boolPtr(true)}, opts)
+	view, err = f.GetSheetView("Sheet1", -1)
+	assert.NoError(t, err)
+	assert.True(t, *view.ShowGridLines)
+	// Test the print options are kept on setting page margins
+	assert.NoError(t, f.SetPageMargins("Sheet1", &PageLayoutMarginsOptions{Horizontally: boolPtr(true)}))
+	opts, err = f.GetPrintOptions("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, *opts.Headings)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPrintOptions.xlsx")))
+	// Test set and get print options on not exists worksheet
+	assert.EqualError(t, f.SetPrintOptions("SheetN", nil), "sheet SheetN does not exist")
+	_, err = f.GetPrintOptions("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestSetSheetProps(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetSheetProps("Sheet1", nil))
@@ -84,6 +123,20 @@ func TestSetSheetProps(t *testing.T) {
 	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{TabColorTheme: intPtr(1)}))
 	ws.(*xlsxWorksheet).SheetPr = nil
 	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{TabColorTint: float64Ptr(1)}))
+	// Test get tab color with theme and tint, and code name after save
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{
+		CodeName: stringPtr("Sheet1Code"), TabColorTheme: intPtr(0), TabColorTint: float64Ptr(-0.25),
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetProps.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestSetSheetProps.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1Code", *opts.CodeName)
+	assert.Equal(t, 0, *opts.TabColorTheme)
+	assert.Equal(t, -0.25, *opts.TabColorTint)
+	assert.Equal(t, "", *opts.TabColorRGB)
 
 	// Test set worksheet properties on not exists worksheet
 	assert.EqualError(t, f.SetSheetProps("SheetN", nil), "sheet SheetN does not exist")
@@ -70,9 +70,78 @@ func (view *xlsxSheetView) setSheetView(opts *ViewOptions) {
 	}
 }
 
+// SetSheetGridColor provides a function to set the gridline color of the
+// worksheet by given worksheet name and hex RGB color code. The color will be
+// mapped to the indexed color palette of the workbook, if the color doesn't
+// exist in the palette, an entry which isn't referenced by the styles, tab
+// colors, gridlines, charts or VML drawings will be replaced with it. Set the
+// color to an empty string to restore the automatic gridline color. For
+// example, set the gridline color of the worksheet named Sheet1 to light gray:
+//
+//	err := f.SetSheetGridColor("Sheet1", "#C0C0C0")
+//
+// The gridline color is only visible while the gridlines of the worksheet are
+// shown, use the SetSheetView function to hide or show gridlines.
+func (f *File) SetSheetGridColor(sheet, color string) error {
+	if err := f.checkReadOnly("SetSheetGridColor"); err != nil {
+		return err
+	}
+	if _, err := f.getSheetView(sheet, 0); err != nil {
+		return err
+	}
+	var colorID *int
+	if color != "" {
+		idx, err := f.getIndexedColorID(sheet, color)
+		if err != nil {
+			return err
+		}
+		colorID = intPtr(idx)
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	for idx := range ws.SheetViews.SheetView {
+		view := &ws.SheetViews.SheetView[idx]
+		view.ColorID, view.DefaultGridColor = colorID, nil
+		if colorID != nil {
+			view.DefaultGridColor = boolPtr(false)
+		}
+	}
+	return err
+}
+
+// GetSheetGridColor provides a function to get the hex RGB gridline color of
+// the worksheet by given worksheet name. An empty string will be returned if
+// the worksheet uses the automatic gridline color.
+func (f *File) GetSheetGridColor(sheet string) (string, error) {
+	view, err := f.getSheetView(sheet, 0)
+	if err != nil {
+		return "", err
+	}
+	if view.DefaultGridColor == nil || *view.DefaultGridColor || view.ColorID == nil {
+		return "", err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if palette := s.getIndexedColors(); *view.ColorID >= 0 && *view.ColorID < len(palette) {
+		return "#" + palette[*view.ColorID], err
+	}
+	return "", err
+}
+
 // SetSheetView sets sheet view options. The viewIndex may be negative and if
 // so is counted backward (-1 is the last view).
 func (f *File) SetSheetView(sheet string, viewIndex int, opts *ViewOptions) error {
+	if err := f.checkReadOnly("SetSheetView"); err != nil {
+		return err
+	}
 	view, err := f.getSheetView(sheet, viewIndex)
 	if err != nil {
 		return err
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,3 +49,98 @@ func TestGetView(t *testing.T) {
 	_, err = f.GetSheetView("SheetN", 0)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
+
+func TestSheetGridColor(t *testing.T) {
+	f := NewFile()
+	color, err := f.GetSheetGridColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, color)
+	// Test set gridline color which exists in the indexed color palette
+	assert.NoError(t, f.SetSheetGridColor("Sheet1", "#c0c0c0"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, intPtr(22), ws.(*xlsxWorksheet).SheetViews.SheetView[0].ColorID)
+	assert.Equal(t, boolPtr(false), ws.(*xlsxWorksheet).SheetViews.SheetView[0].DefaultGridColor)
+	assert.Nil(t, f.Styles.Colors)
+	color, err = f.GetSheetGridColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "#C0C0C0", color)
+	// Test set gridline color which doesn't exist in the indexed color palette
+	assert.NoError(t, f.SetSheetGridColor("Sheet1", "A6A6A6"))
+	assert.Equal(t, intPtr(63), ws.(*xlsxWorksheet).SheetViews.SheetView[0].ColorID)
+	assert.Len(t, f.Styles.Colors.IndexedColors.RgbColor, 64)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetGridColor("Sheet2", "#123456"))
+	assert.NoError(t, f.SetSheetGridColor("Sheet1", "#123456"))
+	assert.Equal(t, intPtr(62), ws.(*xlsxWorksheet).SheetViews.SheetView[0].ColorID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSheetGridColor.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSheetGridColor.xlsx"))
+	assert.NoError(t, err)
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		color, err = f.GetSheetGridColor(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, "#123456", color)
+	}
+	// Test restore the automatic gridline color
+	assert.NoError(t, f.SetSheetGridColor("Sheet1", ""))
+	color, err = f.GetSheetGridColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, color)
+	// Test set gridline color with invalid color
+	for _, color := range []string{"#FFF", "GGGGGG", "#-12345"} {
+		assert.Equal(t, ErrParameterInvalid, f.SetSheetGridColor("Sheet1", color))
+	}
+	// Test set gridline color without unused entry in the palette
+	f.Styles.Dxfs = &xlsxDxfs{}
+	for idx := 8; idx < 64; idx++ {
+		f.Styles.Dxfs.Dxfs = append(f.Styles.Dxfs.Dxfs, &xlsxDxf{Font: &xlsxFont{Color: &xlsxColor{Indexed: idx}}})
+	}
+	assert.Equal(t, ErrIndexedColorPalette, f.SetSheetGridColor("Sheet1", "#654321"))
+	// Test set and get gridline color on not exists worksheet
+	assert.EqualError(t, f.SetSheetGridColor("SheetN", "#C0C0C0"), "sheet SheetN does not exist")
+	_, err = f.GetSheetGridColor("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+	// Test set and get gridline color with unsupported charset style sheet
+	f = NewFile()
+	assert.NoError(t, f.SetSheetGridColor("Sheet1", "#C0C0C0"))
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetGridColor("Sheet1", "#C0C0C0"), "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	_, err = f.GetSheetGridColor("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test set gridline color with unsupported charset worksheet
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetGridColor("Sheet1", "#C0C0C0"), "XML syntax error on line 1: invalid UTF-8")
+	// Test set gridline color keeps the palette entries referenced by the tab
+	// colors, charts and VML drawings
+	f = NewFile()
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{TabColorIndexed: intPtr(63)}))
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$2"}}}))
+	cs, csPath, err := f.chartSheetReader("Chart1")
+	assert.NoError(t, err)
+	cs.SheetPr = &xlsxChartsheetPr{TabColor: &xlsxColor{Indexed: 62}}
+	f.chartSheetWriter(csPath, cs)
+	f.Pkg.Store("xl/charts/chart2.xml", []byte(`<c:chartSpace><x:color indexed="61"/></c:chartSpace>`))
+	f.Pkg.Store("xl/drawings/vmlDrawing2.vml", []byte(`<xml><v:shape fillcolor="infoBackground [60]" strokecolor="#000000 [59]"/></xml>`))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment"}))
+	assert.NoError(t, f.SetSheetGridColor("Sheet1", "#123456"))
+	color, err = f.GetSheetGridColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "#123456", color)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, intPtr(58), ws.(*xlsxWorksheet).SheetViews.SheetView[0].ColorID)
+	// Test set gridline color with unsupported charset chartsheet
+	f.Pkg.Store(csPath, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetGridColor("Sheet1", "#654321"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -390,6 +390,9 @@ func (f *File) addSparklineGroupByStyle(ID int) *xlsxX14SparklineGroup {
 //	 Reverse     | Used to specify if enable plot data right-to-left
 //	 SeriesColor | An RGB Color is specified as RRGGBB
 func (f *File) AddSparkline(sheet string, opts *SparklineOptions) error {
+	if err := f.checkReadOnly("AddSparkline"); err != nil {
+		return err
+	}
 	var (
 		err                 error
 		ws                  *xlsxWorksheet
@@ -0,0 +1,505 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"errors"
+	"github.com/xuri/excelize/v2/xencoding/xml"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ss2003Alignments defined the mapping of the horizontal and vertical
+	// alignment types into the SpreadsheetML 2003 XML format.
+	ss2003Alignments = map[string]string{
+		"general":          "Automatic",
+		"left":             "Left",
+		"center":           "Center",
+		"right":            "Right",
+		"fill":             "Fill",
+		"justify":          "Justify",
+		"centerContinuous": "CenterAcrossSelection",
+		"distributed":      "Distributed",
+		"top":              "Top",
+		"bottom":           "Bottom",
+	}
+	// ss2003BorderStyles defined the mapping of the border styles into the
+	// line style and weight of the SpreadsheetML 2003 XML format.
+	ss2003BorderStyles = map[string]ss2003Border{
+		"thin":             {LineStyle: "Continuous", Weight: 1},
+		"medium":           {LineStyle: "Continuous", Weight: 2},
+		"dashed":           {LineStyle: "Dash", Weight: 1},
+		"dotted":           {LineStyle: "Dot", Weight: 1},
+		"thick":            {LineStyle: "Continuous", Weight: 3},
+		"double":           {LineStyle: "Double", Weight: 3},
+		"hair":             {LineStyle: "Continuous"},
+		"mediumDashed":     {LineStyle: "Dash", Weight: 2},
+		"dashDot":          {LineStyle: "DashDot", Weight: 1},
+		"mediumDashDot":    {LineStyle: "DashDot", Weight: 2},
+		"dashDotDot":       {LineStyle: "DashDotDot", Weight: 1},
+		"mediumDashDotDot": {LineStyle: "DashDotDot", Weight: 2},
+		"slantDashDot":     {LineStyle: "SlantDashDot", Weight: 2},
+	}
+	// ss2003Patterns defined the mapping of the fill pattern types into the
+	// SpreadsheetML 2003 XML format.
+	ss2003Patterns = map[string]string{
+		"solid":           "Solid",
+		"mediumGray":      "Gray50",
+		"darkGray":        "Gray75",
+		"lightGray":       "Gray25",
+		"darkHorizontal":  "HorzStripe",
+		"darkVertical":    "VertStripe",
+		"darkDown":        "ReverseDiagStripe",
+		"darkUp":          "DiagStripe",
+		"darkGrid":        "DiagCross",
+		"darkTrellis":     "ThickDiagCross",
+		"lightHorizontal": "ThinHorzStripe",
+		"lightVertical":   "ThinVertStripe",
+		"lightDown":       "ThinReverseDiagStripe",
+		"lightUp":         "ThinDiagStripe",
+		"lightGrid":       "ThinHorzCross",
+		"lightTrellis":    "ThinDiagCross",
+		"gray125":         "Gray125",
+		"gray0625":        "Gray0625",
+	}
+	// ss2003Underlines defined the mapping of the font underline types into
+	// the SpreadsheetML 2003 XML format.
+	ss2003Underlines = map[string]string{
+		"single":           "Single",
+		"double":           "Double",
+		"singleAccounting": "SingleAccounting",
+		"doubleAccounting": "DoubleAccounting",
+	}
+)
+
+// SaveAsXMLSpreadsheet2003 provides a function to export the workbook into
+// the SpreadsheetML 2003 XML format (XML Spreadsheet 2003) by given writer.
+// The cell values, basic styles (font, fill, borders, alignment and number
+// formats), merged cells, column widths and row heights of the worksheets
+// will be exported, other elements which not supported by this format, such
+// as charts, pictures and comments will be ignored. The formulas will be
+// exported in the R1C1 reference style with the cached values, and only the
+// cached values will be exported if the CachedFormulaValues option was set.
+// For example, export the workbook into the file named Book1.xml:
+//
+//	file, err := os.Create("Book1.xml")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	if err := f.SaveAsXMLSpreadsheet2003(file); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) SaveAsXMLSpreadsheet2003(w io.Writer, opts ...XMLSpreadsheet2003Options) error {
+	var options XMLSpreadsheet2003Options
+	for _, opt := range opts {
+		options = opt
+	}
+	styleSheet, err := f.stylesReader()
+	if err != nil {
+		return err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	wb := ss2003Workbook{
+		XMLNS:     nameSpaceSpreadsheet2003,
+		XMLNSo:    nameSpaceSpreadsheet2003Office,
+		XMLNSx:    nameSpaceSpreadsheet2003Excel,
+		XMLNSss:   nameSpaceSpreadsheet2003,
+		XMLNShtml: nameSpaceSpreadsheet2003HTML,
+	}
+	styleIDs := map[int]bool{}
+	for _, sheet := range f.GetSheetList() {
+		worksheet, err := f.ss2003Worksheet(sheet, sst, styleIDs, &options)
+		if err != nil {
+			if errors.As(err, &ErrNotWorksheet{}) {
+				continue
+			}
+			return err
+		}
+		wb.Worksheet = append(wb.Worksheet, *worksheet)
+	}
+	wb.Styles = f.ss2003Styles(styleSheet, styleIDs)
+	if _, err = io.WriteString(w, xml.Header+"<?mso-application progid=\"Excel.Sheet\"?>\n"); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(wb)
+}
+
+// ss2003Worksheet provides a function to convert the worksheet into the
+// Worksheet element of the SpreadsheetML 2003 XML format by given worksheet
+// name, the style indexes used by the worksheet will be added into the given
+// style indexes set.
+func (f *File) ss2003Worksheet(sheet string, sst *xlsxSST, styleIDs map[int]bool, opts *XMLSpreadsheet2003Options) (*ss2003Worksheet, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	worksheet := &ss2003Worksheet{Name: sheet}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			column := ss2003Column{Index: col.Min, Span: col.Max - col.Min}
+			if col.Width != nil {
+				column.Width = ColWidthToPixels(*col.Width) * 0.75
+			}
+			if col.Hidden {
+				column.Hidden = 1
+			}
+			if col.Style != 0 {
+				column.StyleID, styleIDs[col.Style] = ss2003StyleID(col.Style), true
+			}
+			worksheet.Table.Column = append(worksheet.Table.Column, column)
+		}
+	}
+	merged, covered, err := ss2003MergeCells(ws)
+	if err != nil {
+		return nil, err
+	}
+	rows := make(map[int]*ss2003Row)
+	for _, r := range ws.SheetData.Row {
+		row := &ss2003Row{Index: r.R}
+		if r.CustomHeight && r.Ht != nil {
+			row.Height = *r.Ht
+		}
+		if r.Hidden {
+			row.Hidden = 1
+		}
+		for i := range r.C {
+			col, _, err := CellNameToCoordinates(r.C[i].R)
+			if err != nil {
+				return nil, err
+			}
+			if covered[[2]int{col, r.R}] {
+				continue
+			}
+			cell, err := f.ss2003Cell(ws, &r.C[i], col, r.R, sst, opts)
+			if err != nil {
+				return nil, err
+			}
+			if r.C[i].S != 0 {
+				styleIDs[r.C[i].S] = true
+			}
+			row.Cell = append(row.Cell, cell)
+		}
+		rows[r.R] = row
+	}
+	for cell, span := range merged {
+		row, ok := rows[cell[1]]
+		if !ok {
+			row = &ss2003Row{Index: cell[1]}
+			rows[cell[1]] = row
+		}
+		idx := -1
+		for i := range row.Cell {
+			if row.Cell[i].Index == cell[0] {
+				idx = i
+			}
+		}
+		if idx == -1 {
+			row.Cell, idx = append(row.Cell, ss2003Cell{Index: cell[0]}), len(row.Cell)
+		}
+		row.Cell[idx].MergeAcross, row.Cell[idx].MergeDown = span[0], span[1]
+	}
+	for _, row := range rows {
+		sort.Slice(row.Cell, func(i, j int) bool { return row.Cell[i].Index < row.Cell[j].Index })
+		worksheet.Table.Row = append(worksheet.Table.Row, *row)
+	}
+	sort.Slice(worksheet.Table.Row, func(i, j int) bool {
+		return worksheet.Table.Row[i].Index < worksheet.Table.Row[j].Index
+	})
+	return worksheet, nil
+}
+
+// ss2003MergeCells provides a function to get the number of the merged
+// columns and rows for the top-left cell of each merged range, and the cells
+// covered by the merged ranges except the top-left cells.
+func ss2003MergeCells(ws *xlsxWorksheet) (map[[2]int][2]int, map[[2]int]bool, error) {
+	merged, covered := make(map[[2]int][2]int), make(map[[2]int]bool)
+	if ws.MergeCells == nil {
+		return merged, covered, nil
+	}
+	for _, mc := range ws.MergeCells.Cells {
+		if mc == nil {
+			continue
+		}
+		rect, err := mc.Rect()
+		if err != nil {
+			return merged, covered, err
+		}
+		_ = sortCoordinates(rect)
+		merged[[2]int{rect[0], rect[1]}] = [2]int{rect[2] - rect[0], rect[3] - rect[1]}
+		for col := rect[0]; col <= rect[2]; col++ {
+			for row := rect[1]; row <= rect[3]; row++ {
+				if col != rect[0] || row != rect[1] {
+					covered[[2]int{col, row}] = true
+				}
+			}
+		}
+	}
+	return merged, covered, nil
+}
+
+// ss2003Cell provides a function to convert the cell into the Cell element of
+// the SpreadsheetML 2003 XML format by given cell and the coordinates of it.
+func (f *File) ss2003Cell(ws *xlsxWorksheet, c *xlsxC, col, row int, sst *xlsxSST, opts *XMLSpreadsheet2003Options) (ss2003Cell, error) {
+	cell := ss2003Cell{Index: col}
+	if c.S != 0 {
+		cell.StyleID = ss2003StyleID(c.S)
+	}
+	if c.F != nil && !opts.CachedFormulaValues {
+		formula := c.F.Content
+		if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+			formula = getSharedFormula(ws, *c.F.Si, c.R)
+		}
+		if formula != "" && !strings.ContainsAny(formula, "[]") {
+			cell.Formula = "=" + formulaA1ToR1C1(formula, col, row)
+		}
+	}
+	value, dataType := c.V, "Number"
+	switch c.T {
+	case "b":
+		dataType = "Boolean"
+	case "e":
+		dataType = "Error"
+	case "str":
+		dataType = "String"
+	case "s", "inlineStr":
+		var err error
+		if value, err = c.getValueFrom(f, sst, true, c.S); err != nil {
+			return cell, err
+		}
+		dataType = "String"
+	case "d":
+		value, _ = (&xlsxC{V: c.V}).getCellDate(f, false)
+		if isNum, _, _ := isNumeric(value); !isNum {
+			dataType = "String"
+		}
+	}
+	if value != "" {
+		cell.Data = &ss2003Data{Type: dataType, Value: value}
+	}
+	return cell, nil
+}
+
+// ss2003Styles provides a function to convert the cell formats into the
+// Styles element of the SpreadsheetML 2003 XML format by given style indexes.
+func (f *File) ss2003Styles(styleSheet *xlsxStyleSheet, styleIDs map[int]bool) *ss2003Styles {
+	styleSheet.mu.Lock()
+	defer styleSheet.mu.Unlock()
+	ids := make([]int, 0, len(styleIDs))
+	for styleID := range styleIDs {
+		ids = append(ids, styleID)
+	}
+	sort.Ints(ids)
+	styles := &ss2003Styles{Style: []ss2003Style{f.ss2003Style(styleSheet, 0)}}
+	styles.Style[0].Name = "Normal"
+	for _, styleID := range ids {
+		styles.Style = append(styles.Style, f.ss2003Style(styleSheet, styleID))
+	}
+	return styles
+}
+
+// ss2003Style provides a function to convert the cell format into the Style
+// element of the SpreadsheetML 2003 XML format by given style index.
+func (f *File) ss2003Style(styleSheet *xlsxStyleSheet, styleID int) ss2003Style {
+	style := ss2003Style{ID: ss2003StyleID(styleID)}
+	if styleSheet.CellXfs == nil || styleID < 0 || styleID >= len(styleSheet.CellXfs.Xf) {
+		return style
+	}
+	xf := styleSheet.CellXfs.Xf[styleID]
+	if xf.Alignment != nil {
+		style.Alignment = newSS2003Alignment(xf.Alignment)
+	}
+	if xf.BorderID != nil && styleSheet.Borders != nil && *xf.BorderID < len(styleSheet.Borders.Border) {
+		style.Borders = newSS2003Borders(styleSheet.Borders.Border[*xf.BorderID])
+	}
+	if xf.FontID != nil && styleSheet.Fonts != nil && *xf.FontID < len(styleSheet.Fonts.Font) {
+		style.Font = newSS2003Font(styleSheet.Fonts.Font[*xf.FontID])
+	}
+	if xf.FillID != nil && styleSheet.Fills != nil && *xf.FillID < len(styleSheet.Fills.Fill) {
+		style.Interior = newSS2003Interior(styleSheet.Fills.Fill[*xf.FillID])
+	}
+	if xf.NumFmtID != nil && *xf.NumFmtID != 0 {
+		if numFmt := f.ss2003NumFmt(styleSheet, *xf.NumFmtID); numFmt != "" {
+			style.NumberFormat = &ss2003NumberFormat{Format: numFmt}
+		}
+	}
+	return style
+}
+
+// ss2003NumFmt provides a function to get the built-in or custom number
+// format code by given number format index.
+func (f *File) ss2003NumFmt(styleSheet *xlsxStyleSheet, numFmtID int) string {
+	if numFmt, ok := f.getBuiltInNumFmtCode(numFmtID); ok {
+		return numFmt
+	}
+	if styleSheet.NumFmts != nil {
+		for _, numFmt := range styleSheet.NumFmts.NumFmt {
+			if numFmt != nil && numFmt.NumFmtID == numFmtID {
+				return numFmt.FormatCode
+			}
+		}
+	}
+	return ""
+}
+
+// newSS2003Alignment provides a function to convert the alignment of the cell
+// format into the SpreadsheetML 2003 XML format.
+func newSS2003Alignment(alignment *xlsxAlignment) *ss2003Alignment {
+	a := &ss2003Alignment{
+		Horizontal: ss2003Alignments[alignment.Horizontal],
+		Vertical:   ss2003Alignments[alignment.Vertical],
+		Indent:     alignment.Indent,
+	}
+	if alignment.ShrinkToFit {
+		a.ShrinkToFit = 1
+	}
+	if alignment.WrapText {
+		a.WrapText = 1
+	}
+	switch rotation := alignment.TextRotation; {
+	case rotation == 255:
+		a.VerticalText = 1
+	case rotation > 90 && rotation <= 180:
+		a.Rotate = 90 - rotation
+	case rotation > 0 && rotation <= 90:
+		a.Rotate = rotation
+	}
+	if *a == (ss2003Alignment{}) {
+		return nil
+	}
+	return a
+}
+
+// newSS2003Borders provides a function to convert the border of the cell
+// format into the SpreadsheetML 2003 XML format.
+func newSS2003Borders(border *xlsxBorder) *ss2003Borders {
+	if border == nil {
+		return nil
+	}
+	lines := []struct {
+		position string
+		line     xlsxLine
+		visible  bool
+	}{
+		{"Left", border.Left, true},
+		{"Top", border.Top, true},
+		{"Right", border.Right, true},
+		{"Bottom", border.Bottom, true},
+		{"DiagonalLeft", border.Diagonal, border.DiagonalDown},
+		{"DiagonalRight", border.Diagonal, border.DiagonalUp},
+	}
+	var borders ss2003Borders
+	for _, line := range lines {
+		b, ok := ss2003BorderStyles[line.line.Style]
+		if !ok || !line.visible {
+			continue
+		}
+		b.Position, b.Color = line.position, ss2003Color(line.line.Color)
+		borders.Border = append(borders.Border, b)
+	}
+	if len(borders.Border) == 0 {
+		return nil
+	}
+	return &borders
+}
+
+// newSS2003Font provides a function to convert the font of the cell format
+// into the SpreadsheetML 2003 XML format.
+func newSS2003Font(font *xlsxFont) *ss2003Font {
+	if font == nil {
+		return nil
+	}
+	isTrue := func(val *attrValBool) int {
+		if val != nil && (val.Val == nil || *val.Val) {
+			return 1
+		}
+		return 0
+	}
+	f := &ss2003Font{
+		Color:         ss2003Color(font.Color),
+		Bold:          isTrue(font.B),
+		Italic:        isTrue(font.I),
+		StrikeThrough: isTrue(font.Strike),
+	}
+	if font.Name != nil && font.Name.Val != nil {
+		f.FontName = *font.Name.Val
+	}
+	if font.Sz != nil && font.Sz.Val != nil {
+		f.Size = *font.Sz.Val
+	}
+	if font.U != nil {
+		f.Underline = "Single"
+		if font.U.Val != nil {
+			f.Underline = ss2003Underlines[*font.U.Val]
+		}
+	}
+	return f
+}
+
+// newSS2003Interior provides a function to convert the pattern fill of the
+// cell format into the SpreadsheetML 2003 XML format.
+func newSS2003Interior(fill *xlsxFill) *ss2003Interior {
+	if fill == nil || fill.PatternFill == nil {
+		return nil
+	}
+	pattern, ok := ss2003Patterns[fill.PatternFill.PatternType]
+	if !ok {
+		return nil
+	}
+	if pattern == "Solid" {
+		return &ss2003Interior{Color: ss2003Color(fill.PatternFill.FgColor), Pattern: pattern}
+	}
+	return &ss2003Interior{
+		Color:        ss2003Color(fill.PatternFill.BgColor),
+		Pattern:      pattern,
+		PatternColor: ss2003Color(fill.PatternFill.FgColor),
+	}
+}
+
+// ss2003Color provides a function to convert the RGB or indexed color into
+// the hex color of the SpreadsheetML 2003 XML format, the theme colors and
+// automatic colors will be ignored.
+func ss2003Color(color *xlsxColor) string {
+	if color == nil {
+		return ""
+	}
+	switch len(color.RGB) {
+	case 8:
+		return "#" + strings.ToUpper(color.RGB[2:])
+	case 6:
+		return "#" + strings.ToUpper(color.RGB)
+	}
+	if color.Theme == nil && !color.Auto && color.Indexed > 0 && color.Indexed < len(IndexedColorMapping) {
+		return "#" + IndexedColorMapping[color.Indexed]
+	}
+	return ""
+}
+
+// ss2003StyleID provides a function to get the ID of the style in the
+// SpreadsheetML 2003 XML format by given style index.
+func ss2003StyleID(styleID int) string {
+	if styleID == 0 {
+		return "Default"
+	}
+	return "s" + strconv.Itoa(styleID)
+}
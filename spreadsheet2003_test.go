@@ -0,0 +1,81 @@
+package excelize
+
+import (
+	"bytes"
+	"github.com/xuri/excelize/v2/xencoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAsXMLSpreadsheet2003(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Name"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 3.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", true))
+	assert.NoError(t, f.SetCellStr("Sheet1", "D1", "a&b"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 2))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B3", "SUM(B1:B2)*$A$1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C3", "Table1[[#This Row],[Name]]"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D3", "SUM(A:A,2:$4)"))
+	assert.NoError(t, f.MergeCell("Sheet1", "E5", "F6"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "B", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	style, err := f.NewStyle(&Style{
+		Font:      &Font{Bold: true, Italic: true, Underline: "double", Family: "Arial", Size: 12, Color: "FF0000"},
+		Fill:      Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:    []Border{{Type: "left", Color: "0000FF", Style: 2}, {Type: "bottom", Style: 4}},
+		Alignment: &Alignment{Horizontal: "center", Vertical: "top", WrapText: true, TextRotation: 135},
+		NumFmt:    10,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", style))
+	customNumFmt := "0.000"
+	style, err = f.NewStyle(&Style{CustomNumFmt: &customNumFmt, Fill: Fill{Type: "pattern", Pattern: 4, Color: []string{"00FF00"}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", style))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "x"))
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$B$2"}},
+	}))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.SaveAsXMLSpreadsheet2003(&buf))
+	output := buf.String()
+	assert.Contains(t, output, `<?mso-application progid="Excel.Sheet"?>`)
+	assert.Contains(t, output, `<Workbook xmlns="urn:schemas-microsoft-com:office:spreadsheet"`)
+	assert.Contains(t, output, `<Style ss:ID="s1"><Alignment ss:Horizontal="Center" ss:Vertical="Top" ss:Rotate="-45" ss:WrapText="1"></Alignment><Borders><Border ss:Position="Left" ss:LineStyle="Continuous" ss:Weight="2" ss:Color="#0000FF"></Border><Border ss:Position="Bottom" ss:LineStyle="Dot" ss:Weight="1"></Border></Borders><Font ss:FontName="Arial" ss:Size="12" ss:Color="#FF0000" ss:Bold="1" ss:Italic="1" ss:Underline="Double"></Font><Interior ss:Color="#FFFF00" ss:Pattern="Solid"></Interior><NumberFormat ss:Format="0.00%"></NumberFormat></Style>`)
+	assert.Contains(t, output, `<Style ss:ID="s2"><Font ss:FontName="Calibri" ss:Size="11"></Font><Interior ss:Pattern="Gray25" ss:PatternColor="#00FF00"></Interior><NumberFormat ss:Format="0.000"></NumberFormat>`)
+	assert.Contains(t, output, `<Column ss:Index="1" ss:Span="1" ss:Width="109.5"></Column>`)
+	assert.Contains(t, output, `<Row ss:Index="2" ss:Height="30">`)
+	assert.Contains(t, output, `<Cell ss:Index="1"><Data ss:Type="String">Name</Data></Cell><Cell ss:Index="2" ss:StyleID="s1"><Data ss:Type="Number">3.5</Data></Cell><Cell ss:Index="3"><Data ss:Type="Boolean">1</Data></Cell><Cell ss:Index="4"><Data ss:Type="String">a&amp;b</Data></Cell>`)
+	assert.Contains(t, output, `<Cell ss:Index="2" ss:Formula="=SUM(R[-2]C:R[-1]C)*R1C1"></Cell>`)
+	assert.Contains(t, output, `<Cell ss:Index="3"></Cell>`)
+	assert.Contains(t, output, `<Cell ss:Index="4" ss:Formula="=SUM(C[-3]:C[-3],R[-1]:R4)"></Cell>`)
+	assert.Contains(t, output, `<Row ss:Index="5"><Cell ss:Index="5" ss:MergeAcross="1" ss:MergeDown="1"></Cell></Row>`)
+	assert.NotContains(t, output, `<Row ss:Index="6">`)
+	assert.Contains(t, output, `<Worksheet ss:Name="Sheet2">`)
+	assert.NotContains(t, output, `Chart1`)
+
+	// Test the exported document is well-formed
+	var wb ss2003Workbook
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), &wb))
+
+	// Test export the cached values of the formula cells
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[1].T, ws.(*xlsxWorksheet).SheetData.Row[2].C[1].V = "", "7"
+	buf.Reset()
+	assert.NoError(t, f.SaveAsXMLSpreadsheet2003(&buf, XMLSpreadsheet2003Options{CachedFormulaValues: true}))
+	assert.NotContains(t, buf.String(), "ss:Formula")
+	assert.Contains(t, buf.String(), `<Cell ss:Index="2"><Data ss:Type="Number">7</Data></Cell>`)
+
+	// Test export the workbook with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SaveAsXMLSpreadsheet2003(&buf), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -12,6 +12,7 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bytes"
 	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
@@ -36,6 +37,8 @@ type StreamWriter struct {
 	mergeCellsCount int
 	mergeCells      strings.Builder
 	tableParts      string
+	sharedStrings   bool
+	zw              *zip.Writer
 }
 
 // NewStreamWriter returns stream writer struct by given worksheet name used for
@@ -112,7 +115,17 @@ type StreamWriter struct {
 //	err := sw.SetRow("A1", []interface{}{
 //	    excelize.Cell{Value: 1}},
 //	    excelize.RowOpts{StyleID: styleID, Height: 20, Hidden: false});
-func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
+//
+// Freeze the first row as the header row of the worksheet with stream writer,
+// the panes must be written before the sheet data, so it can only be
+// configured when creating the stream writer or by the 'SetPanes' function
+// before the 'SetRow' function:
+//
+//	sw, err := f.NewStreamWriter("Sheet1", excelize.StreamWriterOptions{FreezeRow: 1})
+func (f *File) NewStreamWriter(sheet string, opts ...StreamWriterOptions) (*StreamWriter, error) {
+	if err := f.checkReadOnly("NewStreamWriter"); err != nil {
+		return nil, err
+	}
 	if err := checkSheetName(sheet); err != nil {
 		return nil, err
 	}
@@ -130,6 +143,19 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 	if err != nil {
 		return nil, err
 	}
+	for _, opt := range opts {
+		sw.sharedStrings = opt.SharedStrings
+		if opt.FreezeRow == 0 && opt.FreezeCol == 0 {
+			continue
+		}
+		panes, err := freezePanes(opt.FreezeRow, opt.FreezeCol)
+		if err != nil {
+			return nil, err
+		}
+		if err = sw.worksheet.setPanes(panes); err != nil {
+			return nil, err
+		}
+	}
 
 	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
 	if f.streams == nil {
@@ -142,6 +168,60 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 	return sw, err
 }
 
+// NewStreamWriterTo returns stream writer struct by given worksheet name and
+// io.Writer, which writes the rows of the worksheet directly into the zip
+// entry of the worksheet in the spreadsheet written to the io.Writer, so the
+// worksheet data never resides in memory or in the temporary file. The other
+// parts of the workbook such as the styles and shared strings are buffered as
+// usual, and are written to the io.Writer by the 'Flush' function, so don't
+// call the 'Save', 'SaveAs' or 'WriteTo' functions after that. Only one stream
+// writer is allowed to write to an io.Writer for a workbook, and the password
+// protected workbook is not supported. For example, write a large worksheet
+// into a file:
+//
+//	out, err := os.Create("Book1.xlsx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer out.Close()
+//	sw, err := f.NewStreamWriterTo("Sheet1", out)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := sw.SetRow("A1", []interface{}{"Data"}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := sw.Flush(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) NewStreamWriterTo(sheet string, w io.Writer, opts ...StreamWriterOptions) (*StreamWriter, error) {
+	if err := f.checkReadOnly("NewStreamWriterTo"); err != nil {
+		return nil, err
+	}
+	if f.options != nil && f.options.Password != "" {
+		return nil, ErrStreamWriterToEncrypted
+	}
+	for _, stream := range f.streams {
+		if stream.zw != nil {
+			return nil, ErrStreamWriterToExists
+		}
+	}
+	sw, err := f.NewStreamWriter(sheet, opts...)
+	if err != nil {
+		return nil, err
+	}
+	sw.zw = zip.NewWriter(w)
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	if sw.rawData.out, err = sw.zw.Create(sheetXMLPath); err != nil {
+		delete(f.streams, sheetXMLPath)
+		return nil, err
+	}
+	return sw, err
+}
+
 // AddTable creates an Excel table for the StreamWriter using the given
 // cell range and format set. For example, create a table of A1:D5:
 //
@@ -284,7 +364,7 @@ func (sw *StreamWriter) getRowValues(hRow, hCol, vCol int) (res []string, err er
 			if col < hCol || col > vCol {
 				continue
 			}
-			res[col-hCol], _ = c.getValueFrom(sw.file, nil, false)
+			res[col-hCol], _ = c.getValueFrom(sw.file, nil, false, inheritCellStyle(col, c.S, row.S, nil))
 		}
 		return res, nil
 	}
@@ -331,6 +411,54 @@ type RowOpts struct {
 	OutlineLevel int
 }
 
+// StreamWriterOptions define the options for creating the stream writer.
+//
+// FreezeRow specifies the number of the top rows to be frozen, for example,
+// set it to 1 for freezing the header row.
+//
+// FreezeCol specifies the number of the left columns to be frozen.
+//
+// SharedStrings specifies if write the string cell values into the shared
+// string table. The string cell values will be written as inline strings
+// (t="inlineStr") by default, which avoids building the shared string table
+// in memory for the worksheet with many unique strings. Set it to true for
+// reducing the file size of the worksheet with many duplicate strings.
+type StreamWriterOptions struct {
+	FreezeRow     int
+	FreezeCol     int
+	SharedStrings bool
+}
+
+// freezePanes provides a function to create the freeze panes settings by
+// given number of frozen rows and columns.
+func freezePanes(row, col int) (*Panes, error) {
+	if row < 0 || col < 0 {
+		return nil, ErrParameterInvalid
+	}
+	if row >= TotalRows {
+		return nil, ErrMaxRows
+	}
+	topLeftCell, err := CoordinatesToCellName(col+1, row+1)
+	if err != nil {
+		return nil, err
+	}
+	activePane := "bottomRight"
+	if col == 0 {
+		activePane = "bottomLeft"
+	}
+	if row == 0 {
+		activePane = "topRight"
+	}
+	return &Panes{
+		Freeze:      true,
+		XSplit:      col,
+		YSplit:      row,
+		TopLeftCell: topLeftCell,
+		ActivePane:  activePane,
+		Selection:   []Selection{{SQRef: topLeftCell, ActiveCell: topLeftCell, Pane: activePane}},
+	}, nil
+}
+
 // marshalAttrs prepare attributes of the row.
 func (r *RowOpts) marshalAttrs() (strings.Builder, error) {
 	var (
@@ -533,9 +661,9 @@ func (sw *StreamWriter) setCellValFunc(c *xlsxC, val interface{}) error {
 	case float64:
 		c.T, c.V = setCellFloat(val, -1, 64)
 	case string:
-		c.setCellValue(val)
+		err = sw.setCellStr(c, val)
 	case []byte:
-		c.setCellValue(string(val))
+		err = sw.setCellStr(c, string(val))
 	case time.Duration:
 		c.T, c.V = setCellDuration(val)
 	case time.Time:
@@ -548,11 +676,23 @@ func (sw *StreamWriter) setCellValFunc(c *xlsxC, val interface{}) error {
 		c.T, c.IS = "inlineStr", &xlsxSI{}
 		c.IS.R, err = setRichText(val)
 	default:
-		c.setCellValue(fmt.Sprint(val))
+		err = sw.setCellStr(c, fmt.Sprint(val))
 	}
 	return err
 }
 
+// setCellStr provides a function to set string type value of a cell, the
+// value will be written into the shared string table if the stream writer
+// was created with the SharedStrings option, otherwise as an inline string.
+func (sw *StreamWriter) setCellStr(c *xlsxC, val string) (err error) {
+	if sw.sharedStrings && c.F == nil {
+		c.T, c.V, err = sw.file.setCellString(val)
+		return
+	}
+	c.setCellValue(val)
+	return
+}
+
 // setCellIntFunc is a wrapper of SetCellInt.
 func setCellIntFunc(c *xlsxC, val interface{}) (err error) {
 	switch val := val.(type) {
@@ -681,7 +821,14 @@ func (sw *StreamWriter) Flush() error {
 	delete(sw.file.checked, sheetPath)
 	sw.file.Pkg.Delete(sheetPath)
 
-	return nil
+	if sw.zw == nil {
+		return nil
+	}
+	if err := sw.file.writeToZip(sw.zw); err != nil {
+		_ = sw.zw.Close()
+		return err
+	}
+	return sw.zw.Close()
 }
 
 // bulkAppendFields bulk-appends fields in a worksheet by specified field
@@ -699,10 +846,13 @@ func bulkAppendFields(w io.Writer, ws *xlsxWorksheet, from, to int) {
 // bufferedWriter uses a temp file to store an extended buffer. Writes are
 // always made to an in-memory buffer, which will always succeed. The buffer
 // is written to the temp file with Sync, which may return an error.
-// Therefore, Sync should be periodically called and the error checked.
+// Therefore, Sync should be periodically called and the error checked. The
+// buffer will be written to the out writer instead of the temp file if it
+// was specified.
 type bufferedWriter struct {
 	tmp *os.File
 	buf bytes.Buffer
+	out io.Writer
 }
 
 // Write to the in-memory buffer. The error is always nil.
@@ -738,7 +888,7 @@ func (bw *bufferedWriter) Sync() (err error) {
 	if bw.buf.Len() < StreamChunkSize {
 		return nil
 	}
-	if bw.tmp == nil {
+	if bw.out == nil && bw.tmp == nil {
 		bw.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
 		if err != nil {
 			// can not use local storage
@@ -748,9 +898,13 @@ func (bw *bufferedWriter) Sync() (err error) {
 	return bw.Flush()
 }
 
-// Flush the entire in-memory buffer to the temp file, if a temp file is being
-// used.
+// Flush the entire in-memory buffer to the out writer or the temp file, if a
+// temp file is being used.
 func (bw *bufferedWriter) Flush() error {
+	if bw.out != nil {
+		_, err := bw.buf.WriteTo(bw.out)
+		return err
+	}
 	if bw.tmp == nil {
 		return nil
 	}
@@ -1,11 +1,14 @@
 package excelize
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -36,6 +39,84 @@ func BenchmarkStreamWriter(b *testing.B) {
 	b.ReportAllocs()
 }
 
+func BenchmarkStreamWriterSharedStrings(b *testing.B) {
+	for _, c := range []struct {
+		name          string
+		sharedStrings bool
+		unique        bool
+	}{
+		{"inline_unique", false, true}, {"shared_unique", true, true},
+		{"inline_duplicate", false, false}, {"shared_duplicate", true, false},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var size int
+			for i := 0; i < b.N; i++ {
+				f := NewFile()
+				sw, err := f.NewStreamWriter("Sheet1", StreamWriterOptions{SharedStrings: c.sharedStrings})
+				if err != nil {
+					b.Fatal(err)
+				}
+				for rowID := 1; rowID <= 10000; rowID++ {
+					val := "string " + strconv.Itoa(rowID%10)
+					if c.unique {
+						val = "string " + strconv.Itoa(rowID)
+					}
+					if err = sw.SetRow("A"+strconv.Itoa(rowID), []interface{}{val}); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if err = sw.Flush(); err != nil {
+					b.Fatal(err)
+				}
+				buf, err := f.WriteToBuffer()
+				if err != nil {
+					b.Fatal(err)
+				}
+				size = buf.Len()
+				if err = f.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(size), "bytes/file")
+		})
+	}
+}
+
+func TestStreamWriterSharedStrings(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1", StreamWriterOptions{SharedStrings: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"a", []byte("b"), "a", Cell{Formula: "A1", Value: "a"}, 1}))
+	assert.NoError(t, sw.Flush())
+	count, err := f.SharedStringsCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "b", "a", "a", "1"}}, rows)
+	for cell, expected := range map[string]CellType{"A1": CellTypeSharedString, "B1": CellTypeSharedString, "D1": CellTypeFormulaString} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	assert.NoError(t, f.Close())
+	// Test write shared strings with unsupported charset
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1", StreamWriterOptions{SharedStrings: true})
+	assert.NoError(t, err)
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, sw.SetRow("A1", []interface{}{"a"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamWriter(t *testing.T) {
 	file := NewFile()
 	streamWriter, err := file.NewStreamWriter("Sheet1")
@@ -188,6 +269,103 @@ func TestStreamSetPanes(t *testing.T) {
 	assert.ErrorIs(t, streamWriter.SetPanes(paneOpts), ErrStreamSetPanes)
 }
 
+func TestStreamWriterFreezePanes(t *testing.T) {
+	for _, c := range []struct {
+		opts     StreamWriterOptions
+		expected Panes
+	}{
+		{StreamWriterOptions{FreezeRow: 1}, Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft", Selection: []Selection{{SQRef: "A2", ActiveCell: "A2", Pane: "bottomLeft"}}}},
+		{StreamWriterOptions{FreezeCol: 2}, Panes{Freeze: true, XSplit: 2, TopLeftCell: "C1", ActivePane: "topRight", Selection: []Selection{{SQRef: "C1", ActiveCell: "C1", Pane: "topRight"}}}},
+		{StreamWriterOptions{FreezeRow: 1, FreezeCol: 1}, Panes{Freeze: true, XSplit: 1, YSplit: 1, TopLeftCell: "B2", ActivePane: "bottomRight", Selection: []Selection{{SQRef: "B2", ActiveCell: "B2", Pane: "bottomRight"}}}},
+	} {
+		f := NewFile()
+		sw, err := f.NewStreamWriter("Sheet1", c.opts)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow("A1", []interface{}{"Header"}))
+		assert.NoError(t, sw.SetRow("A2", []interface{}{1}))
+		assert.NoError(t, sw.Flush())
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+
+		f, err = OpenReader(buf)
+		assert.NoError(t, err)
+		panes, err := f.GetPanes("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, panes)
+		assert.NoError(t, f.Close())
+	}
+	// Test create stream writer with invalid freeze panes options
+	f := NewFile()
+	_, err := f.NewStreamWriter("Sheet1", StreamWriterOptions{FreezeRow: -1})
+	assert.ErrorIs(t, err, ErrParameterInvalid)
+	_, err = f.NewStreamWriter("Sheet1", StreamWriterOptions{FreezeRow: TotalRows})
+	assert.ErrorIs(t, err, ErrMaxRows)
+	_, err = f.NewStreamWriter("Sheet1", StreamWriterOptions{FreezeCol: MaxColumns})
+	assert.ErrorIs(t, err, ErrColumnNumber)
+	assert.NoError(t, f.Close())
+}
+
+func TestNewStreamWriterTo(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "normal"))
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	sw, err := f.NewStreamWriterTo("Sheet1", &buf, StreamWriterOptions{FreezeRow: 1})
+	assert.NoError(t, err)
+	// Test create another stream writer which writes to io.Writer
+	_, err = f.NewStreamWriterTo("Sheet2", &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrStreamWriterToExists)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{Cell{StyleID: styleID, Value: "Header"}}))
+	for row := 2; row <= 1000; row++ {
+		cell, err := CoordinatesToCellName(1, row)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{row, "text"}))
+	}
+	assert.NoError(t, sw.rawData.Sync())
+	assert.NoError(t, sw.rawData.Flush())
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(&buf)
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1000)
+	assert.Equal(t, []string{"1000", "text"}, rows[999])
+	cellStyle, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, cellStyle)
+	value, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "normal", value)
+	assert.NoError(t, f.Close())
+
+	// Test write to the closed io.Writer
+	f = NewFile()
+	pr, pw := io.Pipe()
+	assert.NoError(t, pr.Close())
+	sw, err = f.NewStreamWriterTo("Sheet1", pw)
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Header"}))
+	assert.ErrorIs(t, sw.Flush(), io.ErrClosedPipe)
+	assert.NoError(t, f.Close())
+
+	// Test create stream writer which writes to io.Writer with invalid sheet name
+	f = NewFile()
+	_, err = f.NewStreamWriterTo("Sheet:1", &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrSheetNameInvalid)
+	// Test create stream writer which writes to io.Writer for the password
+	// protected workbook
+	f.options = &Options{Password: "password"}
+	_, err = f.NewStreamWriterTo("Sheet1", &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrStreamWriterToEncrypted)
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamTable(t *testing.T) {
 	file := NewFile()
 	defer func() {
@@ -13,16 +13,98 @@ package excelize
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"io"
 	"math"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/mohae/deepcopy"
+)
+
+// styleFillPatterns defined the list of cell fill pattern types, the index of
+// the list is the pattern ID of the fill style.
+var styleFillPatterns = []string{
+	"none",
+	"solid",
+	"mediumGray",
+	"darkGray",
+	"lightGray",
+	"darkHorizontal",
+	"darkVertical",
+	"darkDown",
+	"darkUp",
+	"darkGrid",
+	"darkTrellis",
+	"lightHorizontal",
+	"lightVertical",
+	"lightDown",
+	"lightUp",
+	"lightGrid",
+	"lightTrellis",
+	"gray125",
+	"gray0625",
+}
+
+// indexedColorExp and vmlIndexedColorExp match the references to the indexed
+// color palette in the chart parts and the color attributes of the VML drawing
+// parts, such as fillcolor="infoBackground [80]", and x14CfRulePriorityExp
+// matches the priority of the conditional formatting rules in the worksheet
+// extensions.
+var (
+	indexedColorExp      = regexp.MustCompile(`\bindexed="(\d+)"`)
+	vmlIndexedColorExp   = regexp.MustCompile(`color2?="[^"]*\[(\d+)\]"`)
+	x14CfRulePriorityExp = regexp.MustCompile(`<(?:\w+:)?cfRule\s[^>]*?\bpriority="(\d+)"`)
 )
 
+// styleBorders defined the list of cell border line styles, the index of the
+// list is the style ID of the border style.
+var styleBorders = []string{
+	"none",
+	"thin",
+	"medium",
+	"dashed",
+	"dotted",
+	"thick",
+	"double",
+	"hair",
+	"mediumDashed",
+	"dashDot",
+	"mediumDashDot",
+	"dashDotDot",
+	"mediumDashDotDot",
+	"slantDashDot",
+}
+
+// styleFillVariants returns the list of gradient cell fill variants without
+// colors, the index of the list is the shading ID of the fill style.
+func styleFillVariants() []xlsxGradientFill {
+	return []xlsxGradientFill{
+		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 270, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 180, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 255, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 315, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path"},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Left: 1, Right: 1},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Top: 1},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Left: 1, Right: 1, Top: 1},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 0.5, Left: 0.5, Right: 0.5, Top: 0.5},
+	}
+}
+
 // validType defined the list of valid validation types.
 var validType = map[string]string{
 	"cell":          "cellIs",
@@ -33,8 +115,8 @@ var validType = map[string]string{
 	"unique":        "uniqueValues",
 	"top":           "top10",
 	"bottom":        "top10",
-	"text":          "text",              // Doesn't support currently
-	"time_period":   "timePeriod",        // Doesn't support currently
+	"text":          "text",
+	"time_period":   "timePeriod",
 	"blanks":        "containsBlanks",    // Doesn't support currently
 	"no_blanks":     "notContainsBlanks", // Doesn't support currently
 	"errors":        "containsErrors",    // Doesn't support currently
@@ -70,13 +152,16 @@ var criteriaType = map[string]string{
 	"ends with":                "endsWith",
 	"yesterday":                "yesterday",
 	"today":                    "today",
+	"tomorrow":                 "tomorrow",
 	"last 7 days":              "last7Days",
 	"last week":                "lastWeek",
 	"this week":                "thisWeek",
-	"continue week":            "continueWeek",
+	"next week":                "nextWeek",
+	"continue week":            "nextWeek",
 	"last month":               "lastMonth",
 	"this month":               "thisMonth",
-	"continue month":           "continueMonth",
+	"next month":               "nextMonth",
+	"continue month":           "nextMonth",
 }
 
 // operatorType defined the list of valid operator types.
@@ -98,8 +183,9 @@ var operatorType = map[string]string{
 	"thisMonth":          "this month",
 	"containsText":       "containing",
 	"lastWeek":           "last week",
-	"continueWeek":       "continue week",
-	"continueMonth":      "continue month",
+	"tomorrow":           "tomorrow",
+	"nextWeek":           "next week",
+	"nextMonth":          "next month",
 	"notBetween":         "not between",
 	"greaterThanOrEqual": "greater than or equal to",
 }
@@ -156,6 +242,9 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 			return style, ErrFontSize
 		}
 	}
+	if style.Fill.Type == "pattern" && (style.Fill.Pattern < 0 || style.Fill.Pattern >= len(styleFillPatterns)) {
+		return style, ErrFillPattern
+	}
 	if style.CustomNumFmt != nil && len(*style.CustomNumFmt) == 0 {
 		err = ErrCustomNumFmt
 	}
@@ -180,6 +269,11 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 diagonalDown | Diagonal down border
 //	 diagonalUp   | Diagonal up border
 //
+// The diagonal down and diagonal up borders share the same diagonal line of
+// the cell, use both of them with the same color and style to cross out the
+// cell, the color and style of the last one will be used if they are
+// different.
+//
 // The following table shows the border styles used in 'Border.Style' supported
 // by excelize index number:
 //
@@ -222,8 +316,11 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 3-5   | Vertical        | 12-15 | From corner
 //	 6-8   | Diagonal Up     | 16    | From center
 //
-// The following table shows the pattern styles used in 'Fill.Pattern' supported
-// by excelize index number:
+// The 'Fill.Color' of the pattern fill sets the foreground color and the
+// background color of the pattern in order, the solid pattern will be used if
+// only one color is given without the pattern. The 'Fill.Pattern' must be
+// between 0 and 18. The following table shows the pattern styles used in
+// 'Fill.Pattern' supported by excelize index number:
 //
 //	 Index | Style           | Index | Style
 //	-------+-----------------+-------+-----------------
@@ -964,6 +1061,9 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //
 // Cell Sheet1!A6 in the Excel Application: martes, 04 de Julio de 2017
 func (f *File) NewStyle(style *Style) (int, error) {
+	if err := f.checkReadOnly("NewStyle"); err != nil {
+		return 0, err
+	}
 	var (
 		fs                                  *Style
 		font                                *xlsxFont
@@ -1111,6 +1211,9 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (int, error) {
 // format by given style format. The parameters are the same with the NewStyle
 // function.
 func (f *File) NewConditionalStyle(style *Style) (int, error) {
+	if err := f.checkReadOnly("NewConditionalStyle"); err != nil {
+		return 0, err
+	}
 	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
@@ -1198,6 +1301,9 @@ func (f *File) GetDefaultFont() (string, error) {
 
 // SetDefaultFont changes the default font in the workbook.
 func (f *File) SetDefaultFont(fontName string) error {
+	if err := f.checkReadOnly("SetDefaultFont"); err != nil {
+		return err
+	}
 	font, err := f.readDefaultFont()
 	if err != nil {
 		return err
@@ -1437,54 +1543,13 @@ func getFillID(styleSheet *xlsxStyleSheet, style *Style) (fillID int) {
 // newFills provides a function to add fill elements in the styles.xml by
 // given cell format settings.
 func newFills(style *Style, fg bool) *xlsxFill {
-	patterns := []string{
-		"none",
-		"solid",
-		"mediumGray",
-		"darkGray",
-		"lightGray",
-		"darkHorizontal",
-		"darkVertical",
-		"darkDown",
-		"darkUp",
-		"darkGrid",
-		"darkTrellis",
-		"lightHorizontal",
-		"lightVertical",
-		"lightDown",
-		"lightUp",
-		"lightGrid",
-		"lightTrellis",
-		"gray125",
-		"gray0625",
-	}
-	variants := []xlsxGradientFill{
-		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 270, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 180, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 255, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 315, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path"},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Left: 1, Right: 1},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Top: 1},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Left: 1, Right: 1, Top: 1},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 0.5, Left: 0.5, Right: 0.5, Top: 0.5},
-	}
-
 	var fill xlsxFill
 	switch style.Fill.Type {
 	case "gradient":
 		if len(style.Fill.Color) != 2 || style.Fill.Shading < 0 || style.Fill.Shading > 16 {
 			break
 		}
-		gradient := variants[style.Fill.Shading]
+		gradient := styleFillVariants()[style.Fill.Shading]
 		gradient.Stop[0].Color.RGB = getPaletteColor(style.Fill.Color[0])
 		gradient.Stop[1].Color.RGB = getPaletteColor(style.Fill.Color[1])
 		if len(gradient.Stop) == 3 {
@@ -1499,17 +1564,17 @@ func newFills(style *Style, fg bool) *xlsxFill {
 			break
 		}
 		var pattern xlsxPatternFill
-		pattern.PatternType = patterns[style.Fill.Pattern]
-		if fg {
-			if pattern.FgColor == nil {
-				pattern.FgColor = new(xlsxColor)
-			}
-			pattern.FgColor.RGB = getPaletteColor(style.Fill.Color[0])
+		pattern.PatternType = styleFillPatterns[style.Fill.Pattern]
+		if style.Fill.Pattern == 0 && len(style.Fill.Color) == 1 {
+			pattern.PatternType = styleFillPatterns[1]
+		}
+		if len(style.Fill.Color) > 1 {
+			pattern.FgColor = &xlsxColor{RGB: getPaletteColor(style.Fill.Color[0])}
+			pattern.BgColor = &xlsxColor{RGB: getPaletteColor(style.Fill.Color[1])}
+		} else if fg {
+			pattern.FgColor = &xlsxColor{RGB: getPaletteColor(style.Fill.Color[0])}
 		} else {
-			if pattern.BgColor == nil {
-				pattern.BgColor = new(xlsxColor)
-			}
-			pattern.BgColor.RGB = getPaletteColor(style.Fill.Color[0])
+			pattern.BgColor = &xlsxColor{RGB: getPaletteColor(style.Fill.Color[0])}
 		}
 		fill.PatternFill = &pattern
 	default:
@@ -1568,23 +1633,6 @@ func getBorderID(styleSheet *xlsxStyleSheet, style *Style) (borderID int) {
 // newBorders provides a function to add border elements in the styles.xml by
 // given borders format settings.
 func newBorders(style *Style) *xlsxBorder {
-	styles := []string{
-		"none",
-		"thin",
-		"medium",
-		"dashed",
-		"dotted",
-		"thick",
-		"double",
-		"hair",
-		"mediumDashed",
-		"dashDot",
-		"mediumDashDot",
-		"dashDotDot",
-		"mediumDashDotDot",
-		"slantDashDot",
-	}
-
 	var border xlsxBorder
 	for _, v := range style.Border {
 		if 0 <= v.Style && v.Style < 14 {
@@ -1592,23 +1640,23 @@ func newBorders(style *Style) *xlsxBorder {
 			color.RGB = getPaletteColor(v.Color)
 			switch v.Type {
 			case "left":
-				border.Left.Style = styles[v.Style]
+				border.Left.Style = styleBorders[v.Style]
 				border.Left.Color = &color
 			case "right":
-				border.Right.Style = styles[v.Style]
+				border.Right.Style = styleBorders[v.Style]
 				border.Right.Color = &color
 			case "top":
-				border.Top.Style = styles[v.Style]
+				border.Top.Style = styleBorders[v.Style]
 				border.Top.Color = &color
 			case "bottom":
-				border.Bottom.Style = styles[v.Style]
+				border.Bottom.Style = styleBorders[v.Style]
 				border.Bottom.Color = &color
 			case "diagonalUp":
-				border.Diagonal.Style = styles[v.Style]
+				border.Diagonal.Style = styleBorders[v.Style]
 				border.Diagonal.Color = &color
 				border.DiagonalUp = true
 			case "diagonalDown":
-				border.Diagonal.Style = styles[v.Style]
+				border.Diagonal.Style = styleBorders[v.Style]
 				border.Diagonal.Color = &color
 				border.DiagonalDown = true
 			}
@@ -1656,7 +1704,9 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 }
 
 // GetCellStyle provides a function to get cell style index by given worksheet
-// name and cell reference.
+// name and cell reference. If the cell doesn't have its own style, the row
+// style will be returned, and if the row doesn't have a style either, the
+// column style will be returned.
 func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -1672,6 +1722,278 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 	return ws.prepareCellStyle(col, row, ws.SheetData.Row[row-1].C[col-1].S), err
 }
 
+// GetStyle provides a function to get the style settings by given style
+// index. The theme colors and indexed colors of the font, fill and border
+// will be converted to the hex RGB color value based on the workbook theme
+// and the indexed color palette. For example, get the style settings of the
+// cell Sheet1!A1:
+//
+//	styleID, err := f.GetCellStyle("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	style, err := f.GetStyle(styleID)
+func (f *File) GetStyle(idx int) (*Style, error) {
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if idx < 0 || s.CellXfs == nil || idx >= len(s.CellXfs.Xf) {
+		return nil, newInvalidStyleID(idx)
+	}
+	style, xf := &Style{}, s.CellXfs.Xf[idx]
+	if isStyleApplied(xf.ApplyFont) && xf.FontID != nil && s.Fonts != nil &&
+		*xf.FontID >= 0 && *xf.FontID < len(s.Fonts.Font) {
+		style.Font = f.extractFont(s, s.Fonts.Font[*xf.FontID])
+	}
+	if isStyleApplied(xf.ApplyFill) && xf.FillID != nil && s.Fills != nil &&
+		*xf.FillID >= 0 && *xf.FillID < len(s.Fills.Fill) {
+		style.Fill = f.extractFill(s, s.Fills.Fill[*xf.FillID])
+	}
+	if isStyleApplied(xf.ApplyBorder) && xf.BorderID != nil && s.Borders != nil &&
+		*xf.BorderID >= 0 && *xf.BorderID < len(s.Borders.Border) {
+		style.Border = f.extractBorders(s, s.Borders.Border[*xf.BorderID])
+	}
+	if isStyleApplied(xf.ApplyAlignment) && xf.Alignment != nil {
+		style.Alignment = &Alignment{
+			Horizontal:      xf.Alignment.Horizontal,
+			Indent:          xf.Alignment.Indent,
+			JustifyLastLine: xf.Alignment.JustifyLastLine,
+			ReadingOrder:    xf.Alignment.ReadingOrder,
+			RelativeIndent:  xf.Alignment.RelativeIndent,
+			ShrinkToFit:     xf.Alignment.ShrinkToFit,
+			TextRotation:    xf.Alignment.TextRotation,
+			Vertical:        xf.Alignment.Vertical,
+			WrapText:        xf.Alignment.WrapText,
+		}
+	}
+	if isStyleApplied(xf.ApplyProtection) && xf.Protection != nil {
+		style.Protection = &Protection{Hidden: true, Locked: true}
+		if xf.Protection.Hidden != nil {
+			style.Protection.Hidden = *xf.Protection.Hidden
+		}
+		if xf.Protection.Locked != nil {
+			style.Protection.Locked = *xf.Protection.Locked
+		}
+	}
+	if xf.NumFmtID != nil {
+		style.NumFmt = *xf.NumFmtID
+		if _, ok := builtInNumFmt[*xf.NumFmtID]; !ok && s.NumFmts != nil {
+			for _, numFmt := range s.NumFmts.NumFmt {
+				if numFmt.NumFmtID == *xf.NumFmtID {
+					style.NumFmt, style.CustomNumFmt = 0, stringPtr(numFmt.FormatCode)
+					break
+				}
+			}
+		}
+	}
+	return style, err
+}
+
+// isStyleApplied returns whether the formatting record applies the style
+// component by given apply attribute, the component will be applied if the
+// attribute is omitted.
+func isStyleApplied(apply *bool) bool {
+	return apply == nil || *apply
+}
+
+// getStyleColor provides a function to get the hex RGB color value by given
+// color of the style sheet. The theme colors and indexed colors will be
+// converted based on the workbook theme and indexed color palette, and an
+// empty string will be returned for the automatic colors.
+func (f *File) getStyleColor(s *xlsxStyleSheet, c *xlsxColor) string {
+	if c == nil || c.Auto {
+		return ""
+	}
+	if c.RGB != "" {
+		if len(c.RGB) < 6 {
+			return ""
+		}
+		return strings.ToUpper(c.RGB[len(c.RGB)-6:])
+	}
+	if c.Theme != nil {
+		if color, err := f.ThemeColorToRGB(*c.Theme, c.Tint); err == nil && len(color) == 8 {
+			return color[2:]
+		}
+		return ""
+	}
+	if palette := s.getIndexedColors(); c.Indexed >= 0 && c.Indexed < len(palette) {
+		return palette[c.Indexed]
+	}
+	return ""
+}
+
+// extractFont provides a function to convert the font of the style sheet
+// into the font settings.
+func (f *File) extractFont(s *xlsxStyleSheet, fnt *xlsxFont) *Font {
+	font := &Font{}
+	if fnt.B != nil {
+		font.Bold = fnt.B.Val == nil || *fnt.B.Val
+	}
+	if fnt.I != nil {
+		font.Italic = fnt.I.Val == nil || *fnt.I.Val
+	}
+	if fnt.Strike != nil {
+		font.Strike = fnt.Strike.Val == nil || *fnt.Strike.Val
+	}
+	if fnt.U != nil {
+		font.Underline = "single"
+		if fnt.U.Val != nil {
+			font.Underline = *fnt.U.Val
+		}
+	}
+	if fnt.Name != nil && fnt.Name.Val != nil {
+		font.Family = *fnt.Name.Val
+	}
+	if fnt.Sz != nil && fnt.Sz.Val != nil {
+		font.Size = *fnt.Sz.Val
+	}
+	if fnt.Color != nil {
+		font.Color = f.getStyleColor(s, fnt.Color)
+		font.ColorIndexed = fnt.Color.Indexed
+		font.ColorTheme = fnt.Color.Theme
+		font.ColorTint = fnt.Color.Tint
+	}
+	return font
+}
+
+// extractFill provides a function to convert the fill of the style sheet
+// into the fill settings, the fill settings will be empty if the cell has no
+// fill.
+func (f *File) extractFill(s *xlsxStyleSheet, fill *xlsxFill) Fill {
+	var fl Fill
+	if fill.PatternFill != nil {
+		pattern := inStrSlice(styleFillPatterns, fill.PatternFill.PatternType, true)
+		if pattern <= 0 {
+			return fl
+		}
+		fl.Type, fl.Pattern = "pattern", pattern
+		fgColor := f.getStyleColor(s, fill.PatternFill.FgColor)
+		bgColor := f.getStyleColor(s, fill.PatternFill.BgColor)
+		if fgColor != "" {
+			fl.Color = []string{fgColor}
+			if bgColor != "" {
+				fl.Color = append(fl.Color, bgColor)
+			}
+		} else if bgColor != "" {
+			fl.Color = []string{bgColor}
+		}
+	}
+	if fill.GradientFill != nil && len(fill.GradientFill.Stop) > 1 {
+		gradient := xlsxGradientFill{
+			Bottom: fill.GradientFill.Bottom, Degree: fill.GradientFill.Degree,
+			Left: fill.GradientFill.Left, Right: fill.GradientFill.Right,
+			Top: fill.GradientFill.Top, Type: fill.GradientFill.Type,
+		}
+		for _, stop := range fill.GradientFill.Stop {
+			gradient.Stop = append(gradient.Stop, &xlsxGradientFillStop{Position: stop.Position})
+		}
+		fl.Type = "gradient"
+		for shading, variant := range styleFillVariants() {
+			if reflect.DeepEqual(variant, gradient) {
+				fl.Shading = shading
+				break
+			}
+		}
+		fl.Color = []string{
+			f.getStyleColor(s, &fill.GradientFill.Stop[0].Color),
+			f.getStyleColor(s, &fill.GradientFill.Stop[1].Color),
+		}
+	}
+	return fl
+}
+
+// extractBorders provides a function to convert the border of the style
+// sheet into the borders settings.
+func (f *File) extractBorders(s *xlsxStyleSheet, border *xlsxBorder) []Border {
+	var borders []Border
+	addBorder := func(typ string, line xlsxLine) {
+		if idx := inStrSlice(styleBorders, line.Style, true); idx > 0 {
+			borders = append(borders, Border{Type: typ, Color: f.getStyleColor(s, line.Color), Style: idx})
+		}
+	}
+	addBorder("left", border.Left)
+	addBorder("right", border.Right)
+	addBorder("top", border.Top)
+	addBorder("bottom", border.Bottom)
+	if border.DiagonalUp {
+		addBorder("diagonalUp", border.Diagonal)
+	}
+	if border.DiagonalDown {
+		addBorder("diagonalDown", border.Diagonal)
+	}
+	return borders
+}
+
+// getCellStyleSettings provides a function to get the style settings of the
+// cell by given worksheet name and cell reference.
+func (f *File) getCellStyleSettings(sheet, cell string) (*Style, error) {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	return f.GetStyle(styleID)
+}
+
+// GetCellFont provides a function to get the font settings of the cell by
+// given worksheet name and cell reference. The row or column style will be
+// used if the cell doesn't have its own style.
+func (f *File) GetCellFont(sheet, cell string) (*Font, error) {
+	style, err := f.getCellStyleSettings(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	return style.Font, err
+}
+
+// GetCellFill provides a function to get the fill settings of the cell by
+// given worksheet name and cell reference. The type of the fill settings will
+// be empty if the cell has no fill. For example, get the background color of
+// the cell Sheet1!A1:
+//
+//	fill, err := f.GetCellFill("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if fill.Type == "pattern" && len(fill.Color) > 0 {
+//	    fmt.Println(fill.Color[0])
+//	}
+func (f *File) GetCellFill(sheet, cell string) (Fill, error) {
+	style, err := f.getCellStyleSettings(sheet, cell)
+	if err != nil {
+		return Fill{}, err
+	}
+	return style.Fill, err
+}
+
+// GetCellBorder provides a function to get the borders settings of the cell
+// by given worksheet name and cell reference.
+func (f *File) GetCellBorder(sheet, cell string) ([]Border, error) {
+	style, err := f.getCellStyleSettings(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	return style.Border, err
+}
+
+// GetCellAlignment provides a function to get the alignment settings of the
+// cell by given worksheet name and cell reference. The nil will be returned
+// if the cell has no alignment settings.
+func (f *File) GetCellAlignment(sheet, cell string) (*Alignment, error) {
+	style, err := f.getCellStyleSettings(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	return style.Alignment, err
+}
+
 // SetCellStyle provides a function to add style attribute for cells by given
 // worksheet name, range reference and style ID. This function is concurrency
 // safe. Note that diagonalDown and diagonalUp type border should be use same
@@ -1776,6 +2098,9 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 //	}
 //	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
 func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
+	if err := f.checkReadOnly("SetCellStyle"); err != nil {
+		return err
+	}
 	hCol, hRow, err := CellNameToCoordinates(hCell)
 	if err != nil {
 		return err
@@ -1853,6 +2178,9 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	 text          | Criteria
 //	               | Value
 //	 average       | Criteria
+//	               | AboveAverage
+//	               | StdDev
+//	               | EqualAverage
 //	 duplicate     | (none)
 //	 unique        | (none)
 //	 top           | Criteria
@@ -2019,6 +2347,39 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 // value when the criteria is either "between" or "not between". See the
 // previous example.
 //
+// type: text - The text type is used to specify Excel's "Text that Contains"
+// style conditional format, the 'Criteria' parameter should be one of
+// "containing", "not containing", "begins with" and "ends with", and the
+// 'Value' parameter is the text to be compared with the cell value:
+//
+//	// Highlight cells rules: Text that Contains...
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:     "text",
+//	            Criteria: "containing",
+//	            Format:   format,
+//	            Value:    "foo",
+//	        },
+//	    },
+//	)
+//
+// type: time_period - The time_period type is used to specify Excel's "Dates
+// Occurring" style conditional format, the 'Criteria' parameter should be one
+// of "yesterday", "today", "tomorrow", "last 7 days", "last week", "this week",
+// "next week", "last month", "this month" and "next month":
+//
+//	// Highlight cells rules: A Date Occurring...
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:     "time_period",
+//	            Criteria: "last 7 days",
+//	            Format:   format,
+//	        },
+//	    },
+//	)
+//
 // type: average - The average type is used to specify Excel's "Average" style
 // conditional format:
 //
@@ -2046,6 +2407,24 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	    },
 //	)
 //
+// The 'StdDev' parameter is used to highlight the cells whose values are the
+// given number of standard deviations above or below the average, and the
+// 'EqualAverage' parameter specifies if the cells equal to the average should
+// be included:
+//
+//	// Top/Bottom rules: 2 std dev above average...
+//	err := f.SetConditionalFormat("Sheet1", "C1:C10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:         "average",
+//	            Criteria:     "=",
+//	            Format:       format1,
+//	            AboveAverage: true,
+//	            StdDev:       2,
+//	        },
+//	    },
+//	)
+//
 // type: duplicate - The duplicate type is used to highlight duplicate cells in
 // a range:
 //
@@ -2058,13 +2437,23 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //
 // type: unique - The unique type is used to highlight unique cells in a range:
 //
-//	// Highlight cells rules: Not Equal To...
+//	// Highlight cells rules: Unique Values...
 //	err := f.SetConditionalFormat("Sheet1", "A1:A10",
 //	    []excelize.ConditionalFormatOptions{
 //	        {Type: "unique", Criteria: "=", Format: format},
 //	    },
 //	)
 //
+// The duplicate and unique values are compared across all areas of the range
+// reference, for example, highlight duplicate values in the columns A and C by
+// the space-separated range reference:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10 C1:C10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {Type: "duplicate", Format: format},
+//	    },
+//	)
+//
 // type: top - The top type is used to specify the top n values by number or
 // percentage in a range:
 //
@@ -2247,9 +2636,38 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 // formatting rule when more than one rule is applied to a cell or a range of
 // cells. When this parameter is set then subsequent rules are not evaluated
 // if the current rule is true.
+//
+// Priority - used to set the priority of a conditional formatting rule. Excel
+// evaluates the rules of the worksheet in ascending order of priority, the
+// value 1 is the highest priority. The rule will be inserted at the given
+// priority, and the existing rules with the same or lower priority will be
+// moved down by one. If this parameter is not set, the rule will be appended
+// after all existing rules of the worksheet. The priorities of the rules on
+// the worksheet, includes the rules in the worksheet extension list, will be
+// renumbered from 1 without gaps. For example, highlight the cells greater
+// than 90 in green and stop evaluating, before the existing rules which
+// highlight the cells greater than 60:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:       "cell",
+//	            Criteria:   ">",
+//	            Format:     format,
+//	            Value:      "90",
+//	            StopIfTrue: true,
+//	            Priority:   1,
+//	        },
+//	    },
+//	)
 func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFormatOptions) error {
-	drawContFmtFunc := map[string]func(p int, ct, GUID string, fmtCond *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule){
+	if err := f.checkReadOnly("SetConditionalFormat"); err != nil {
+		return err
+	}
+	drawContFmtFunc := map[string]func(p int, ct, ref, GUID string, fmtCond *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule){
 		"cellIs":          drawCondFmtCellIs,
+		"text":            drawCondFmtText,
+		"timePeriod":      drawCondFmtTimePeriod,
 		"top10":           drawCondFmtTop10,
 		"aboveAverage":    drawCondFmtAboveAverage,
 		"duplicateValues": drawCondFmtDuplicateUniqueValues,
@@ -2271,8 +2689,16 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 		rules += len(cf.CfRule)
 	}
 	GUID := fmt.Sprintf("{00000000-0000-0000-%04X-%012X}", f.getSheetID(sheet), rules)
-	var cfRule []*xlsxCfRule
-	for p, v := range opts {
+	// The relative cell references in the formulas are based on the top-left
+	// cell of the first area in the range reference.
+	topLeftCell := strings.ReplaceAll(strings.Split(strings.Split(rangeRef, " ")[0], ":")[0], "$", "")
+	var (
+		cfRule     []*xlsxCfRule
+		x14CfRule  []*xlsxX14CfRule
+		priorities []int
+	)
+	// Validate and create all rules before changing the worksheet
+	for _, v := range opts {
 		var vt, ct string
 		var ok bool
 		// "type" is a required parameter, check for valid validation types.
@@ -2280,25 +2706,29 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 		if ok {
 			// Check for valid criteria types.
 			ct, ok = criteriaType[v.Criteria]
-			if ok || vt == "expression" || vt == "iconSet" {
+			if ok || vt == "expression" || vt == "iconSet" ||
+				vt == "duplicateValues" || vt == "uniqueValues" {
 				drawFunc, ok := drawContFmtFunc[vt]
 				if ok {
-					rule, x14rule := drawFunc(p, ct, GUID, &v)
+					rule, x14rule := drawFunc(0, ct, topLeftCell, GUID, &v)
 					if rule == nil {
 						return ErrParameterInvalid
 					}
 					if x14rule != nil {
-						if err = f.appendCfRule(ws, x14rule); err != nil {
-							return err
-						}
-						f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+						x14CfRule = append(x14CfRule, x14rule)
 					}
-					cfRule = append(cfRule, rule)
+					cfRule, priorities = append(cfRule, rule), append(priorities, v.Priority)
 				}
 			}
 		}
 	}
-
+	for _, x14rule := range x14CfRule {
+		if err = f.appendCfRule(ws, x14rule); err != nil {
+			return err
+		}
+		f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+	}
+	setCfRulePriorities(ws, cfRule, priorities)
 	ws.ConditionalFormatting = append(ws.ConditionalFormatting, &xlsxConditionalFormatting{
 		SQRef:  rangeRef,
 		CfRule: cfRule,
@@ -2306,6 +2736,70 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 	return err
 }
 
+// setCfRulePriorities provides a function to renumber the priorities of the
+// conditional formatting rules on the worksheet, includes the rules in the
+// worksheet extension list, and the new rules which have not been added to
+// the worksheet by given expected priorities. Each new rule will be inserted
+// at the expected priority, and the existing rules with the same or lower
+// priority will be moved down, the new rule will be appended after all rules
+// if the expected priority is not positive or greater than the number of the
+// rules. The priorities will be numbered from 1 without gaps.
+func setCfRulePriorities(ws *xlsxWorksheet, rules []*xlsxCfRule, priorities []int) {
+	type cfRulePriority struct {
+		priority, x14Idx int
+		rule             *xlsxCfRule
+	}
+	var (
+		entries []cfRulePriority
+		matches [][]int
+	)
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			entries = append(entries, cfRulePriority{priority: rule.Priority, x14Idx: -1, rule: rule})
+		}
+	}
+	if ws.ExtLst != nil {
+		matches = x14CfRulePriorityExp.FindAllStringSubmatchIndex(ws.ExtLst.Ext, -1)
+		for i, match := range matches {
+			priority, _ := strconv.Atoi(ws.ExtLst.Ext[match[2]:match[3]])
+			entries = append(entries, cfRulePriority{priority: priority, x14Idx: i})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].priority < entries[j].priority
+	})
+	for i, rule := range rules {
+		entry := cfRulePriority{x14Idx: -1, rule: rule}
+		if p := priorities[i]; p > 0 && p <= len(entries) {
+			entries = append(entries[:p-1], append([]cfRulePriority{entry}, entries[p-1:]...)...)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	x14Priorities := make([]int, len(matches))
+	for i, entry := range entries {
+		if entry.rule != nil {
+			entry.rule.Priority = i + 1
+			continue
+		}
+		x14Priorities[entry.x14Idx] = i + 1
+	}
+	if len(matches) == 0 {
+		return
+	}
+	var (
+		ext  strings.Builder
+		last int
+	)
+	for i, match := range matches {
+		ext.WriteString(ws.ExtLst.Ext[last:match[2]])
+		ext.WriteString(strconv.Itoa(x14Priorities[i]))
+		last = match[3]
+	}
+	ext.WriteString(ws.ExtLst.Ext[last:])
+	ws.ExtLst.Ext = ext.String()
+}
+
 // appendCfRule provides a function to append rules to conditional formatting.
 func (f *File) appendCfRule(ws *xlsxWorksheet, rule *xlsxX14CfRule) error {
 	var (
@@ -2368,6 +2862,30 @@ func extractCondFmtCellIs(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOp
 	return format
 }
 
+// extractCondFmtText provides a function to extract conditional format
+// settings for text cell values by given conditional formatting rule.
+func extractCondFmtText(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	return ConditionalFormatOptions{
+		StopIfTrue: c.StopIfTrue,
+		Type:       "text",
+		Criteria:   operatorType[c.Operator],
+		Format:     *c.DxfID,
+		Value:      c.Text,
+	}
+}
+
+// extractCondFmtTimePeriod provides a function to extract conditional format
+// settings for dates occurring in the time period by given conditional
+// formatting rule.
+func extractCondFmtTimePeriod(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	return ConditionalFormatOptions{
+		StopIfTrue: c.StopIfTrue,
+		Type:       "time_period",
+		Criteria:   operatorType[c.TimePeriod],
+		Format:     *c.DxfID,
+	}
+}
+
 // extractCondFmtTop10 provides a function to extract conditional format
 // settings for top N (default is top 10) by given conditional formatting
 // rule.
@@ -2396,6 +2914,8 @@ func extractCondFmtAboveAverage(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFo
 		Criteria:     "=",
 		Format:       *c.DxfID,
 		AboveAverage: *c.AboveAverage,
+		StdDev:       c.StdDev,
+		EqualAverage: c.EqualAverage,
 	}
 }
 
@@ -2532,6 +3052,11 @@ func extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatO
 func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalFormatOptions, error) {
 	extractContFmtFunc := map[string]func(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions{
 		"cellIs":          extractCondFmtCellIs,
+		"containsText":    extractCondFmtText,
+		"notContainsText": extractCondFmtText,
+		"beginsWith":      extractCondFmtText,
+		"endsWith":        extractCondFmtText,
+		"timePeriod":      extractCondFmtTimePeriod,
 		"top10":           extractCondFmtTop10,
 		"aboveAverage":    extractCondFmtAboveAverage,
 		"duplicateValues": extractCondFmtDuplicateUniqueValues,
@@ -2551,7 +3076,9 @@ func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalForm
 		var opts []ConditionalFormatOptions
 		for _, cr := range cf.CfRule {
 			if extractFunc, ok := extractContFmtFunc[cr.Type]; ok {
-				opts = append(opts, extractFunc(cr, ws.ExtLst))
+				opt := extractFunc(cr, ws.ExtLst)
+				opt.Priority = cr.Priority
+				opts = append(opts, opt)
 			}
 		}
 		conditionalFormats[cf.SQRef] = opts
@@ -2562,6 +3089,9 @@ func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalForm
 // UnsetConditionalFormat provides a function to unset the conditional format
 // by given worksheet name and range reference.
 func (f *File) UnsetConditionalFormat(sheet, rangeRef string) error {
+	if err := f.checkReadOnly("UnsetConditionalFormat"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -2578,7 +3108,7 @@ func (f *File) UnsetConditionalFormat(sheet, rangeRef string) error {
 // drawCondFmtCellIs provides a function to create conditional formatting rule
 // for cell value (include between, not between, equal, not equal, greater
 // than and less than) by given priority, criteria type and format settings.
-func drawCondFmtCellIs(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtCellIs(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	c := &xlsxCfRule{
 		Priority:   p + 1,
 		StopIfTrue: format.StopIfTrue,
@@ -2596,10 +3126,70 @@ func drawCondFmtCellIs(p int, ct, GUID string, format *ConditionalFormatOptions)
 	return c, nil
 }
 
+// drawCondFmtText provides a function to create conditional formatting rule for
+// text cell values (include containing, not containing, begins with and ends
+// with) by given priority, criteria type and format settings.
+func drawCondFmtText(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+	text := strings.ReplaceAll(format.Value, "\"", "\"\"")
+	formula, ok := map[string]string{
+		"containsText": fmt.Sprintf("NOT(ISERROR(SEARCH(\"%s\",%s)))", text, ref),
+		"notContains":  fmt.Sprintf("ISERROR(SEARCH(\"%s\",%s))", text, ref),
+		"beginsWith":   fmt.Sprintf("LEFT(%s,LEN(\"%s\"))=\"%s\"", ref, text, text),
+		"endsWith":     fmt.Sprintf("RIGHT(%s,LEN(\"%s\"))=\"%s\"", ref, text, text),
+	}[ct]
+	if !ok {
+		return nil, nil
+	}
+	return &xlsxCfRule{
+		Priority:   p + 1,
+		StopIfTrue: format.StopIfTrue,
+		Type: map[string]string{
+			"containsText": "containsText",
+			"notContains":  "notContainsText",
+			"beginsWith":   "beginsWith",
+			"endsWith":     "endsWith",
+		}[ct],
+		Operator: ct,
+		Text:     format.Value,
+		Formula:  []string{formula},
+		DxfID:    intPtr(format.Format),
+	}, nil
+}
+
+// drawCondFmtTimePeriod provides a function to create conditional formatting
+// rule for dates occurring in the time period (include yesterday, today,
+// tomorrow, last 7 days, last week, this week, next week, last month, this
+// month and next month) by given priority, criteria type and format settings.
+func drawCondFmtTimePeriod(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+	formula, ok := map[string]string{
+		"yesterday": fmt.Sprintf("FLOOR(%s,1)=TODAY()-1", ref),
+		"today":     fmt.Sprintf("FLOOR(%s,1)=TODAY()", ref),
+		"tomorrow":  fmt.Sprintf("FLOOR(%s,1)=TODAY()+1", ref),
+		"last7Days": fmt.Sprintf("AND(TODAY()-FLOOR(%[1]s,1)<=6,FLOOR(%[1]s,1)<=TODAY())", ref),
+		"lastWeek":  fmt.Sprintf("AND(TODAY()-ROUNDDOWN(%[1]s,0)>=(WEEKDAY(TODAY())),TODAY()-ROUNDDOWN(%[1]s,0)<(WEEKDAY(TODAY())+7))", ref),
+		"thisWeek":  fmt.Sprintf("AND(TODAY()-ROUNDDOWN(%[1]s,0)<=WEEKDAY(TODAY())-1,ROUNDDOWN(%[1]s,0)-TODAY()<=7-WEEKDAY(TODAY()))", ref),
+		"nextWeek":  fmt.Sprintf("AND(ROUNDDOWN(%[1]s,0)-TODAY()>(7-WEEKDAY(TODAY())),ROUNDDOWN(%[1]s,0)-TODAY()<(15-WEEKDAY(TODAY())))", ref),
+		"lastMonth": fmt.Sprintf("AND(MONTH(%[1]s)=MONTH(EDATE(TODAY(),0-1)),YEAR(%[1]s)=YEAR(EDATE(TODAY(),0-1)))", ref),
+		"thisMonth": fmt.Sprintf("AND(MONTH(%[1]s)=MONTH(TODAY()),YEAR(%[1]s)=YEAR(TODAY()))", ref),
+		"nextMonth": fmt.Sprintf("AND(MONTH(%[1]s)=MONTH(EDATE(TODAY(),0+1)),YEAR(%[1]s)=YEAR(EDATE(TODAY(),0+1)))", ref),
+	}[ct]
+	if !ok {
+		return nil, nil
+	}
+	return &xlsxCfRule{
+		Priority:   p + 1,
+		StopIfTrue: format.StopIfTrue,
+		Type:       validType[format.Type],
+		TimePeriod: ct,
+		Formula:    []string{formula},
+		DxfID:      intPtr(format.Format),
+	}, nil
+}
+
 // drawCondFmtTop10 provides a function to create conditional formatting rule
 // for top N (default is top 10) by given priority, criteria type and format
 // settings.
-func drawCondFmtTop10(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtTop10(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	c := &xlsxCfRule{
 		Priority:   p + 1,
 		StopIfTrue: format.StopIfTrue,
@@ -2618,12 +3208,14 @@ func drawCondFmtTop10(p int, ct, GUID string, format *ConditionalFormatOptions)
 // drawCondFmtAboveAverage provides a function to create conditional
 // formatting rule for above average and below average by given priority,
 // criteria type and format settings.
-func drawCondFmtAboveAverage(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtAboveAverage(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	return &xlsxCfRule{
 		Priority:     p + 1,
 		StopIfTrue:   format.StopIfTrue,
 		Type:         validType[format.Type],
 		AboveAverage: boolPtr(format.AboveAverage),
+		StdDev:       format.StdDev,
+		EqualAverage: format.EqualAverage,
 		DxfID:        intPtr(format.Format),
 	}, nil
 }
@@ -2631,7 +3223,7 @@ func drawCondFmtAboveAverage(p int, ct, GUID string, format *ConditionalFormatOp
 // drawCondFmtDuplicateUniqueValues provides a function to create conditional
 // formatting rule for duplicate and unique values by given priority, criteria
 // type and format settings.
-func drawCondFmtDuplicateUniqueValues(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtDuplicateUniqueValues(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	return &xlsxCfRule{
 		Priority:   p + 1,
 		StopIfTrue: format.StopIfTrue,
@@ -2643,7 +3235,7 @@ func drawCondFmtDuplicateUniqueValues(p int, ct, GUID string, format *Conditiona
 // drawCondFmtColorScale provides a function to create conditional formatting
 // rule for color scale (include 2 color scale and 3 color scale) by given
 // priority, criteria type and format settings.
-func drawCondFmtColorScale(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtColorScale(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	minValue := format.MinValue
 	if minValue == "" {
 		minValue = "0"
@@ -2681,7 +3273,7 @@ func drawCondFmtColorScale(p int, ct, GUID string, format *ConditionalFormatOpti
 
 // drawCondFmtDataBar provides a function to create conditional formatting
 // rule for data bar by given priority, criteria type and format settings.
-func drawCondFmtDataBar(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtDataBar(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	var x14CfRule *xlsxX14CfRule
 	var extLst *xlsxExtLst
 	if format.BarSolid || format.BarDirection == "leftToRight" || format.BarDirection == "rightToLeft" || format.BarBorderColor != "" {
@@ -2718,7 +3310,7 @@ func drawCondFmtDataBar(p int, ct, GUID string, format *ConditionalFormatOptions
 
 // drawCondFmtExp provides a function to create conditional formatting rule
 // for expression by given priority, criteria type and format settings.
-func drawCondFmtExp(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtExp(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	return &xlsxCfRule{
 		Priority:   p + 1,
 		StopIfTrue: format.StopIfTrue,
@@ -2730,7 +3322,7 @@ func drawCondFmtExp(p int, ct, GUID string, format *ConditionalFormatOptions) (*
 
 // drawCondFmtIconSet provides a function to create conditional formatting rule
 // for icon set by given priority, criteria type and format settings.
-func drawCondFmtIconSet(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtIconSet(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	cfvo3 := &xlsxCfRule{IconSet: &xlsxIconSet{Cfvo: []*xlsxCfvo{
 		{Type: "percent", Val: "0"},
 		{Type: "percent", Val: "33"},
@@ -2786,6 +3378,195 @@ func getPaletteColor(color string) string {
 	return "FF" + strings.ReplaceAll(strings.ToUpper(color), "#", "")
 }
 
+// getIndexedColors provides a function to get the hex RGB color values of the
+// indexed color palette, the default palette will be returned if the palette
+// has not been modified.
+func (s *xlsxStyleSheet) getIndexedColors() []string {
+	palette := make([]string, 64)
+	copy(palette, IndexedColorMapping)
+	if s.Colors != nil && s.Colors.IndexedColors != nil {
+		for idx, c := range s.Colors.IndexedColors.RgbColor {
+			if idx < len(palette) && len(c.RGB) >= 6 {
+				palette[idx] = strings.ToUpper(c.RGB[len(c.RGB)-6:])
+			}
+		}
+	}
+	return palette
+}
+
+// getUsedIndexedColors provides a function to get the indexes of the indexed
+// color palette which have been referenced by the fonts, fills, borders and
+// differential formats of the style sheet.
+func (s *xlsxStyleSheet) getUsedIndexedColors() map[int]bool {
+	used := map[int]bool{}
+	addColor := func(c *xlsxColor) {
+		if c != nil && c.RGB == "" && c.Theme == nil && !c.Auto {
+			used[c.Indexed] = true
+		}
+	}
+	addFont := func(font *xlsxFont) {
+		if font != nil {
+			addColor(font.Color)
+		}
+	}
+	addFill := func(fill *xlsxFill) {
+		if fill != nil && fill.PatternFill != nil {
+			addColor(fill.PatternFill.FgColor)
+			addColor(fill.PatternFill.BgColor)
+		}
+		if fill != nil && fill.GradientFill != nil {
+			for _, stop := range fill.GradientFill.Stop {
+				addColor(&stop.Color)
+			}
+		}
+	}
+	addBorder := func(border *xlsxBorder) {
+		if border != nil {
+			for _, line := range []xlsxLine{border.Left, border.Right, border.Top, border.Bottom, border.Diagonal} {
+				addColor(line.Color)
+			}
+		}
+	}
+	if s.Fonts != nil {
+		for _, font := range s.Fonts.Font {
+			addFont(font)
+		}
+	}
+	if s.Fills != nil {
+		for _, fill := range s.Fills.Fill {
+			addFill(fill)
+		}
+	}
+	if s.Borders != nil {
+		for _, border := range s.Borders.Border {
+			addBorder(border)
+		}
+	}
+	if s.Dxfs != nil {
+		for _, dxf := range s.Dxfs.Dxfs {
+			addFont(dxf.Font)
+			addFill(dxf.Fill)
+			addBorder(dxf.Border)
+		}
+	}
+	return used
+}
+
+// getUsedIndexedColors provides a function to get the indexes of the indexed
+// color palette which have been referenced by the tab colors of the sheets,
+// the gridlines of the worksheets except the given one, the charts and the VML
+// drawings of the workbook.
+func (f *File) getUsedIndexedColors(sheet string) (map[int]bool, error) {
+	used := map[int]bool{}
+	addColor := func(c *xlsxColor) {
+		if c != nil && c.RGB == "" && c.Theme == nil && !c.Auto {
+			used[c.Indexed] = true
+		}
+	}
+	for _, name := range f.GetSheetList() {
+		ws, err := f.workSheetReader(name)
+		if err != nil {
+			if !errors.As(err, &ErrNotWorksheet{}) {
+				return used, err
+			}
+			cs, _, err := f.chartSheetReader(name)
+			if err != nil {
+				return used, err
+			}
+			if cs != nil && cs.SheetPr != nil {
+				addColor(cs.SheetPr.TabColor)
+			}
+			continue
+		}
+		if ws.SheetPr != nil {
+			addColor(ws.SheetPr.TabColor)
+		}
+		if ws.SheetViews != nil && !strings.EqualFold(name, sheet) {
+			for _, view := range ws.SheetViews.SheetView {
+				if view.ColorID != nil {
+					used[*view.ColorID] = true
+				}
+			}
+		}
+	}
+	addMatches := func(exp *regexp.Regexp, content []byte) {
+		for _, match := range exp.FindAllSubmatch(content, -1) {
+			if idx, err := strconv.Atoi(string(match[1])); err == nil {
+				used[idx] = true
+			}
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Pkg.Range(func(k, v interface{}) bool {
+		path := k.(string)
+		if strings.HasPrefix(path, "xl/charts/chart") {
+			addMatches(indexedColorExp, v.([]byte))
+		}
+		if _, ok := f.VMLDrawing[path]; !ok && strings.HasPrefix(path, "xl/drawings/vmlDrawing") {
+			addMatches(vmlIndexedColorExp, v.([]byte))
+		}
+		return true
+	})
+	for _, vml := range f.VMLDrawing {
+		if vml != nil {
+			content, _ := xml.Marshal(vml)
+			addMatches(vmlIndexedColorExp, content)
+		}
+	}
+	return used, nil
+}
+
+// getIndexedColorID provides a function to get the index of the given hex RGB
+// color in the indexed color palette. If the color doesn't exist in the
+// palette, an entry which not referenced by the style sheet or the gridlines
+// of other worksheets will be replaced with the color, and the entire palette
+// will be written out.
+func (f *File) getIndexedColorID(sheet, color string) (int, error) {
+	rgb := strings.ToUpper(strings.TrimPrefix(color, "#"))
+	if _, err := strconv.ParseUint(rgb, 16, 32); err != nil || len(rgb) != 6 {
+		return -1, ErrParameterInvalid
+	}
+	used, err := f.getUsedIndexedColors(sheet)
+	if err != nil {
+		return -1, err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return -1, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	palette := s.getIndexedColors()
+	// the indexes 0-7 are redundant of 8-15, so prefer the later
+	for i := 8; i < len(palette)+8; i++ {
+		if idx := i % len(palette); palette[idx] == rgb {
+			return idx, err
+		}
+	}
+	for idx := range s.getUsedIndexedColors() {
+		used[idx] = true
+	}
+	for idx := len(palette) - 1; idx >= 8; idx-- {
+		if used[idx] {
+			continue
+		}
+		palette[idx] = rgb
+		if s.Colors == nil {
+			s.Colors = &xlsxStyleColors{}
+		}
+		s.Colors.IndexedColors = &xlsxIndexedColors{}
+		for _, c := range palette {
+			s.Colors.IndexedColors.RgbColor = append(s.Colors.IndexedColors.RgbColor, xlsxRgbColor{RGB: "FF" + c})
+		}
+		return idx, err
+	}
+	return -1, ErrIndexedColorPalette
+}
+
 // themeReader provides a function to get the pointer to the xl/theme/theme1.xml
 // structure after deserialization.
 func (f *File) themeReader() (*xlsxTheme, error) {
@@ -2820,3 +3601,369 @@ func ThemeColor(baseColor string, tint float64) string {
 	br, bg, bb := HSLToRGB(h, s, l)
 	return fmt.Sprintf("FF%02X%02X%02X", br, bg, bb)
 }
+
+// getColors returns the pointers of the color slots in the color scheme, in
+// the order of the theme color index used by the spreadsheet, which starts
+// with the light 1, dark 1, light 2 and dark 2 colors.
+func (c *xlsxColorScheme) getColors() []*xlsxCTColor {
+	return []*xlsxCTColor{
+		&c.Lt1, &c.Dk1, &c.Lt2, &c.Dk2, &c.Accent1, &c.Accent2, &c.Accent3,
+		&c.Accent4, &c.Accent5, &c.Accent6, &c.Hlink, &c.FolHlink,
+	}
+}
+
+// getRGB returns the hex RGB value of the theme color, the last computed
+// value will be returned for the system color.
+func (c *xlsxCTColor) getRGB() string {
+	if c.SrgbClr != nil && c.SrgbClr.Val != nil {
+		return strings.ToUpper(*c.SrgbClr.Val)
+	}
+	if c.SysClr != nil {
+		return strings.ToUpper(c.SysClr.LastClr)
+	}
+	return ""
+}
+
+// setRGB provides a function to set the theme color by given hex RGB value,
+// the system color will be kept if the value was not changed.
+func (c *xlsxCTColor) setRGB(color string) {
+	if color == "" || strings.EqualFold(c.getRGB(), color) {
+		return
+	}
+	*c = xlsxCTColor{SrgbClr: &attrValString{Val: stringPtr(strings.ToUpper(color))}}
+}
+
+// getTextFont returns the typeface of the theme font.
+func getTextFont(font *xlsxCTTextFont) string {
+	if font == nil {
+		return ""
+	}
+	return font.Typeface
+}
+
+// setTextFont returns the theme font by given typeface, the font will be kept
+// if the typeface was empty.
+func setTextFont(font *xlsxCTTextFont, typeface string) *xlsxCTTextFont {
+	if typeface == "" {
+		return font
+	}
+	if font == nil || font.Typeface != typeface {
+		return &xlsxCTTextFont{Typeface: typeface}
+	}
+	return font
+}
+
+// GetTheme provides a function to get the color scheme and the major and
+// minor fonts of the workbook theme. The default Office theme will be returned
+// if the workbook doesn't contain a theme. For example, get the accent 1 color
+// of the theme:
+//
+//	theme, err := f.GetTheme()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(theme.ColorScheme.Accent1)
+func (f *File) GetTheme() (*Theme, error) {
+	theme, err := f.getTheme()
+	if err != nil {
+		return nil, err
+	}
+	clrScheme, fontScheme := theme.ThemeElements.ClrScheme, theme.ThemeElements.FontScheme
+	colors := clrScheme.getColors()
+	return &Theme{
+		Name: theme.Name,
+		ColorScheme: ThemeColorScheme{
+			Name:              clrScheme.Name,
+			Light1:            colors[0].getRGB(),
+			Dark1:             colors[1].getRGB(),
+			Light2:            colors[2].getRGB(),
+			Dark2:             colors[3].getRGB(),
+			Accent1:           colors[4].getRGB(),
+			Accent2:           colors[5].getRGB(),
+			Accent3:           colors[6].getRGB(),
+			Accent4:           colors[7].getRGB(),
+			Accent5:           colors[8].getRGB(),
+			Accent6:           colors[9].getRGB(),
+			Hyperlink:         colors[10].getRGB(),
+			FollowedHyperlink: colors[11].getRGB(),
+		},
+		MajorFont: ThemeFont{
+			Latin:         getTextFont(fontScheme.MajorFont.Latin),
+			EastAsian:     getTextFont(fontScheme.MajorFont.Ea),
+			ComplexScript: getTextFont(fontScheme.MajorFont.Cs),
+		},
+		MinorFont: ThemeFont{
+			Latin:         getTextFont(fontScheme.MinorFont.Latin),
+			EastAsian:     getTextFont(fontScheme.MinorFont.Ea),
+			ComplexScript: getTextFont(fontScheme.MinorFont.Cs),
+		},
+	}, nil
+}
+
+// getTheme provides a function to get the workbook theme, the default Office
+// theme will be returned if the workbook doesn't contain a theme.
+func (f *File) getTheme() (*xlsxTheme, error) {
+	if f.Theme != nil {
+		return f.Theme, nil
+	}
+	theme := &xlsxTheme{}
+	err := xml.Unmarshal([]byte(templateTheme), theme)
+	return theme, err
+}
+
+// SetTheme provides a function to set the color scheme and the major and
+// minor fonts of the workbook theme, the empty fields will keep the current
+// settings, and the format scheme of the theme will be kept as is. The
+// default Office theme will be used as the base theme if the workbook doesn't
+// contain a theme. For example, set the accent 1 color and the minor Latin
+// font of the theme:
+//
+//	theme, err := f.GetTheme()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	theme.ColorScheme.Accent1 = "1F4E79"
+//	theme.MinorFont.Latin = "Arial"
+//	err = f.SetTheme(theme)
+//
+// Note that the existing cell styles which reference the theme colors or
+// fonts will use the new theme settings.
+func (f *File) SetTheme(theme *Theme) error {
+	if err := f.checkReadOnly("SetTheme"); err != nil {
+		return err
+	}
+	if theme == nil {
+		return ErrParameterRequired
+	}
+	colors := []string{
+		theme.ColorScheme.Light1, theme.ColorScheme.Dark1, theme.ColorScheme.Light2,
+		theme.ColorScheme.Dark2, theme.ColorScheme.Accent1, theme.ColorScheme.Accent2,
+		theme.ColorScheme.Accent3, theme.ColorScheme.Accent4, theme.ColorScheme.Accent5,
+		theme.ColorScheme.Accent6, theme.ColorScheme.Hyperlink, theme.ColorScheme.FollowedHyperlink,
+	}
+	for i, color := range colors {
+		if color = strings.TrimPrefix(color, "#"); color == "" {
+			continue
+		}
+		if _, err := strconv.ParseUint(color, 16, 32); err != nil || len(color) != 6 {
+			return newInvalidThemeColorError(colors[i])
+		}
+		colors[i] = color
+	}
+	if f.Theme == nil {
+		if err := f.addTheme(); err != nil {
+			return err
+		}
+	}
+	if theme.Name != "" {
+		f.Theme.Name = theme.Name
+	}
+	clrScheme, fontScheme := &f.Theme.ThemeElements.ClrScheme, &f.Theme.ThemeElements.FontScheme
+	if theme.ColorScheme.Name != "" {
+		clrScheme.Name = theme.ColorScheme.Name
+	}
+	for i, c := range clrScheme.getColors() {
+		c.setRGB(colors[i])
+	}
+	fontScheme.MajorFont.Latin = setTextFont(fontScheme.MajorFont.Latin, theme.MajorFont.Latin)
+	fontScheme.MajorFont.Ea = setTextFont(fontScheme.MajorFont.Ea, theme.MajorFont.EastAsian)
+	fontScheme.MajorFont.Cs = setTextFont(fontScheme.MajorFont.Cs, theme.MajorFont.ComplexScript)
+	fontScheme.MinorFont.Latin = setTextFont(fontScheme.MinorFont.Latin, theme.MinorFont.Latin)
+	fontScheme.MinorFont.Ea = setTextFont(fontScheme.MinorFont.Ea, theme.MinorFont.EastAsian)
+	fontScheme.MinorFont.Cs = setTextFont(fontScheme.MinorFont.Cs, theme.MinorFont.ComplexScript)
+	return nil
+}
+
+// addTheme provides a function to add the default theme part to the workbook
+// which doesn't contain a theme.
+func (f *File) addTheme() error {
+	f.Pkg.Store(defaultXMLPathTheme, []byte(xml.Header+templateTheme))
+	theme, err := f.themeReader()
+	if err != nil {
+		return err
+	}
+	f.Theme = theme
+	if err = f.addContentTypePart(0, "theme"); err != nil {
+		return err
+	}
+	relPath := f.getWorkbookRelsPath()
+	rels, err := f.relsReader(relPath)
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipTheme {
+				return err
+			}
+		}
+	}
+	f.addRels(relPath, SourceRelationshipTheme, "theme/theme1.xml", "")
+	return err
+}
+
+// ThemeColorToRGB provides a function to get the ARGB color value by given
+// zero-based theme color index and tint value, based on the color scheme of
+// the current workbook theme. The theme color index and tint value are the
+// same as the ColorTheme and ColorTint fields of the font style. For example,
+// get the color value of the accent 1 color lighter 40%:
+//
+//	color, err := f.ThemeColorToRGB(4, 0.4)
+func (f *File) ThemeColorToRGB(idx int, tint float64) (string, error) {
+	if idx < 0 || idx > 11 {
+		return "", ErrThemeColorIndex
+	}
+	theme, err := f.getTheme()
+	if err != nil {
+		return "", err
+	}
+	baseColor := theme.ThemeElements.ClrScheme.getColors()[idx].getRGB()
+	if len(baseColor) != 6 {
+		return "", nil
+	}
+	return ThemeColor(baseColor, tint), nil
+}
+
+// styleCopier provides a function to copy the cell formats and the
+// differential formats from the style sheet of the source workbook into the
+// style sheet of the destination workbook. The fonts, fills, borders, number
+// formats and formatting records which already exist in the destination will
+// be reused.
+type styleCopier struct {
+	src, dst  *xlsxStyleSheet
+	xfs, dxfs map[int]int
+}
+
+// newStyleCopier returns a style copier by given source and destination
+// workbooks.
+func newStyleCopier(src, dst *File) (*styleCopier, error) {
+	src.mu.Lock()
+	srcStyles, err := src.stylesReader()
+	src.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	dst.mu.Lock()
+	dstStyles, err := dst.stylesReader()
+	dst.mu.Unlock()
+	return &styleCopier{src: srcStyles, dst: dstStyles, xfs: map[int]int{0: 0}, dxfs: map[int]int{}}, err
+}
+
+// copyNumFmt returns the number format ID in the destination style sheet by
+// given number format ID in the source style sheet.
+func (sc *styleCopier) copyNumFmt(numFmtID int) int {
+	if sc.src.NumFmts == nil || numFmtID < 164 {
+		return numFmtID
+	}
+	for _, numFmt := range sc.src.NumFmts.NumFmt {
+		if numFmt.NumFmtID != numFmtID {
+			continue
+		}
+		nextID := 164
+		if sc.dst.NumFmts == nil {
+			sc.dst.NumFmts = &xlsxNumFmts{}
+		}
+		for _, nf := range sc.dst.NumFmts.NumFmt {
+			if nf.FormatCode == numFmt.FormatCode {
+				return nf.NumFmtID
+			}
+			if nf.NumFmtID >= nextID {
+				nextID = nf.NumFmtID + 1
+			}
+		}
+		sc.dst.NumFmts.NumFmt = append(sc.dst.NumFmts.NumFmt, &xlsxNumFmt{NumFmtID: nextID, FormatCode: numFmt.FormatCode})
+		sc.dst.NumFmts.Count = len(sc.dst.NumFmts.NumFmt)
+		return nextID
+	}
+	return 0
+}
+
+// copyXfComponent returns the index of the formatting component (font, fill,
+// border or differential format) in the destination list by given pointer of
+// the list and the component, and append the component if it doesn't exist.
+func copyXfComponent(list, item interface{}) int {
+	items := reflect.ValueOf(list).Elem()
+	for idx := 0; idx < items.Len(); idx++ {
+		if reflect.DeepEqual(items.Index(idx).Interface(), item) {
+			return idx
+		}
+	}
+	items.Set(reflect.Append(items, reflect.ValueOf(deepcopy.Copy(item))))
+	return items.Len() - 1
+}
+
+// cellXf returns the cell style index in the destination workbook by given
+// cell style index in the source workbook.
+func (sc *styleCopier) cellXf(styleID int) (int, error) {
+	if id, ok := sc.xfs[styleID]; ok {
+		return id, nil
+	}
+	if sc.src.CellXfs == nil || styleID < 0 || styleID >= len(sc.src.CellXfs.Xf) {
+		return 0, nil
+	}
+	sc.dst.mu.Lock()
+	defer sc.dst.mu.Unlock()
+	xf := deepcopy.Copy(sc.src.CellXfs.Xf[styleID]).(xlsxXf)
+	if xf.NumFmtID != nil {
+		xf.NumFmtID = intPtr(sc.copyNumFmt(*xf.NumFmtID))
+	}
+	if xf.FontID != nil && sc.src.Fonts != nil && *xf.FontID < len(sc.src.Fonts.Font) {
+		if sc.dst.Fonts == nil {
+			sc.dst.Fonts = &xlsxFonts{}
+		}
+		xf.FontID = intPtr(copyXfComponent(&sc.dst.Fonts.Font, sc.src.Fonts.Font[*xf.FontID]))
+		sc.dst.Fonts.Count = len(sc.dst.Fonts.Font)
+	}
+	if xf.FillID != nil && sc.src.Fills != nil && *xf.FillID < len(sc.src.Fills.Fill) {
+		if sc.dst.Fills == nil {
+			sc.dst.Fills = &xlsxFills{}
+		}
+		xf.FillID = intPtr(copyXfComponent(&sc.dst.Fills.Fill, sc.src.Fills.Fill[*xf.FillID]))
+		sc.dst.Fills.Count = len(sc.dst.Fills.Fill)
+	}
+	if xf.BorderID != nil && sc.src.Borders != nil && *xf.BorderID < len(sc.src.Borders.Border) {
+		if sc.dst.Borders == nil {
+			sc.dst.Borders = &xlsxBorders{}
+		}
+		xf.BorderID = intPtr(copyXfComponent(&sc.dst.Borders.Border, sc.src.Borders.Border[*xf.BorderID]))
+		sc.dst.Borders.Count = len(sc.dst.Borders.Border)
+	}
+	xf.XfID = intPtr(0)
+	if sc.dst.CellXfs == nil {
+		sc.dst.CellXfs = &xlsxCellXfs{}
+	}
+	for idx, v := range sc.dst.CellXfs.Xf {
+		if reflect.DeepEqual(v, xf) {
+			sc.xfs[styleID] = idx
+			return idx, nil
+		}
+	}
+	if len(sc.dst.CellXfs.Xf) == MaxCellStyles {
+		return 0, ErrCellStyles
+	}
+	sc.dst.CellXfs.Xf = append(sc.dst.CellXfs.Xf, xf)
+	sc.dst.CellXfs.Count = len(sc.dst.CellXfs.Xf)
+	sc.xfs[styleID] = sc.dst.CellXfs.Count - 1
+	return sc.xfs[styleID], nil
+}
+
+// dxf returns the differential format index in the destination workbook by
+// given differential format index in the source workbook.
+func (sc *styleCopier) dxf(dxfID int) int {
+	if id, ok := sc.dxfs[dxfID]; ok {
+		return id
+	}
+	if sc.src.Dxfs == nil || dxfID < 0 || dxfID >= len(sc.src.Dxfs.Dxfs) {
+		return dxfID
+	}
+	sc.dst.mu.Lock()
+	defer sc.dst.mu.Unlock()
+	if sc.dst.Dxfs == nil {
+		sc.dst.Dxfs = &xlsxDxfs{}
+	}
+	sc.dxfs[dxfID] = copyXfComponent(&sc.dst.Dxfs.Dxfs, sc.src.Dxfs.Dxfs[dxfID])
+	sc.dst.Dxfs.Count = len(sc.dst.Dxfs.Dxfs)
+	return sc.dxfs[dxfID]
+}
@@ -1,9 +1,11 @@
 package excelize
 
 import (
+	"github.com/xuri/excelize/v2/xencoding/json"
 	"math"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -153,6 +155,130 @@ func TestSetConditionalFormat(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		label: "text containing",
+		format: []ConditionalFormatOptions{{
+			Type:     "text",
+			Criteria: "containing",
+			Value:    `say "hi"`,
+			Format:   1,
+		}},
+		rules: []*xlsxCfRule{{
+			Priority: 1,
+			Type:     "containsText",
+			Operator: "containsText",
+			Text:     `say "hi"`,
+			Formula:  []string{`NOT(ISERROR(SEARCH("say ""hi""",A1)))`},
+			DxfID:    intPtr(1),
+		}},
+	}, {
+		label: "text not containing",
+		format: []ConditionalFormatOptions{{
+			Type:     "text",
+			Criteria: "not containing",
+			Value:    "foo",
+			Format:   1,
+		}},
+		rules: []*xlsxCfRule{{
+			Priority: 1,
+			Type:     "notContainsText",
+			Operator: "notContains",
+			Text:     "foo",
+			Formula:  []string{`ISERROR(SEARCH("foo",A1))`},
+			DxfID:    intPtr(1),
+		}},
+	}, {
+		label: "text begins with",
+		format: []ConditionalFormatOptions{{
+			Type:     "text",
+			Criteria: "begins with",
+			Value:    "foo",
+			Format:   1,
+		}},
+		rules: []*xlsxCfRule{{
+			Priority: 1,
+			Type:     "beginsWith",
+			Operator: "beginsWith",
+			Text:     "foo",
+			Formula:  []string{`LEFT(A1,LEN("foo"))="foo"`},
+			DxfID:    intPtr(1),
+		}},
+	}, {
+		label: "text ends with",
+		format: []ConditionalFormatOptions{{
+			Type:     "text",
+			Criteria: "ends with",
+			Value:    "foo",
+			Format:   1,
+		}},
+		rules: []*xlsxCfRule{{
+			Priority: 1,
+			Type:     "endsWith",
+			Operator: "endsWith",
+			Text:     "foo",
+			Formula:  []string{`RIGHT(A1,LEN("foo"))="foo"`},
+			DxfID:    intPtr(1),
+		}},
+	}, {
+		label: "time period last 7 days",
+		format: []ConditionalFormatOptions{{
+			Type:     "time_period",
+			Criteria: "last 7 days",
+			Format:   1,
+		}},
+		rules: []*xlsxCfRule{{
+			Priority:   1,
+			Type:       "timePeriod",
+			TimePeriod: "last7Days",
+			Formula:    []string{"AND(TODAY()-FLOOR(A1,1)<=6,FLOOR(A1,1)<=TODAY())"},
+			DxfID:      intPtr(1),
+		}},
+	}, {
+		label: "time period this week",
+		format: []ConditionalFormatOptions{{
+			Type:     "time_period",
+			Criteria: "this week",
+			Format:   1,
+		}},
+		rules: []*xlsxCfRule{{
+			Priority:   1,
+			Type:       "timePeriod",
+			TimePeriod: "thisWeek",
+			Formula:    []string{"AND(TODAY()-ROUNDDOWN(A1,0)<=WEEKDAY(TODAY())-1,ROUNDDOWN(A1,0)-TODAY()<=7-WEEKDAY(TODAY()))"},
+			DxfID:      intPtr(1),
+		}},
+	}, {
+		label: "top 5 percent",
+		format: []ConditionalFormatOptions{{
+			Type:     "top",
+			Criteria: "=",
+			Value:    "5",
+			Percent:  true,
+			Format:   1,
+		}},
+		rules: []*xlsxCfRule{{
+			Priority: 1,
+			Type:     "top10",
+			Rank:     5,
+			Percent:  true,
+			DxfID:    intPtr(1),
+		}},
+	}, {
+		label: "2 standard deviations above average",
+		format: []ConditionalFormatOptions{{
+			Type:         "average",
+			Criteria:     "=",
+			AboveAverage: true,
+			StdDev:       2,
+			Format:       1,
+		}},
+		rules: []*xlsxCfRule{{
+			Priority:     1,
+			Type:         "aboveAverage",
+			AboveAverage: boolPtr(true),
+			StdDev:       2,
+			DxfID:        intPtr(1),
+		}},
 	}}
 
 	for _, testCase := range cases {
@@ -190,17 +316,88 @@ func TestSetConditionalFormat(t *testing.T) {
 	assert.True(t, ok)
 	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: "<ext><x14:conditionalFormattings></x14:conditionalFormatting></x14:conditionalFormattings></ext>"}
 	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", condFmts), "XML syntax error on line 1: element <conditionalFormattings> closed by </conditionalFormatting>")
+	// Test creating a text conditional format on the multi-area range reference
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRows("Sheet1", "B2", [][]interface{}{{"foobar", "bar"}, {"barfoo", "foo"}}))
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9C0006"}, Fill: Fill{Type: "pattern", Color: []string{"FFC7CE"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "$B$2:$B$3 C2:C3", []ConditionalFormatOptions{{Type: "text", Criteria: "begins with", Value: "foo", Format: format}}))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`LEFT(B2,LEN("foo"))="foo"`}, ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule[0].Formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetConditionalFormatText.xlsx")))
+	// Test creating a text conditional format with invalid criteria
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "text", Criteria: ">", Value: "foo"}}))
+	// Test creating a time period conditional format with invalid criteria
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "time_period", Criteria: "between"}}))
 	// Test creating a conditional format with invalid icon set style
 	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "unknown"}}), ErrParameterInvalid.Error())
 }
 
+func TestSetConditionalFormatDuplicateUniqueValues(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRows("Sheet1", "A1", [][]interface{}{{1, nil, 3}, {2, nil, 1}, {3, nil, 5}}))
+	_, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "006100"}})
+	assert.NoError(t, err)
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9C0006"}, Fill: Fill{Type: "pattern", Color: []string{"FFC7CE"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, format)
+	// Test set duplicate and unique values rules on multi-area range reference
+	const rangeRef = "A1:A3 C1:C3"
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", rangeRef, []ConditionalFormatOptions{
+		{Type: "duplicate", Format: format},
+		{Type: "unique", Criteria: "=", Format: format},
+	}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.ConditionalFormatting, 1)
+	assert.Equal(t, rangeRef, ws.ConditionalFormatting[0].SQRef)
+	assert.Equal(t, []*xlsxCfRule{
+		{Priority: 1, Type: "duplicateValues", DxfID: intPtr(format)},
+		{Priority: 2, Type: "uniqueValues", DxfID: intPtr(format)},
+	}, ws.ConditionalFormatting[0].CfRule)
+	assert.Len(t, f.Styles.Dxfs.Dxfs, 2)
+	assert.Equal(t, "FF9C0006", f.Styles.Dxfs.Dxfs[format].Font.Color.RGB)
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ConditionalFormatOptions{
+		{Type: "duplicate", Criteria: "=", Format: format, Priority: 1},
+		{Type: "unique", Criteria: "=", Format: format, Priority: 2},
+	}, opts[rangeRef])
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetConditionalFormatDuplicateUniqueValues.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test the rules are preserved after reopening the workbook
+	f, err = OpenFile(filepath.Join("test", "TestSetConditionalFormatDuplicateUniqueValues.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts[rangeRef], 2)
+	assert.NoError(t, f.Close())
+}
+
 func TestGetConditionalFormats(t *testing.T) {
 	for _, format := range [][]ConditionalFormatOptions{
 		{{Type: "cell", Format: 1, Criteria: "greater than", Value: "6"}},
 		{{Type: "cell", Format: 1, Criteria: "between", MinValue: "6", MaxValue: "8"}},
 		{{Type: "top", Format: 1, Criteria: "=", Value: "6"}},
 		{{Type: "bottom", Format: 1, Criteria: "=", Value: "6"}},
+		{{Type: "text", Format: 1, Criteria: "containing", Value: "foo"}},
+		{{Type: "text", Format: 1, Criteria: "not containing", Value: "foo"}},
+		{{Type: "text", Format: 1, Criteria: "begins with", Value: "foo"}},
+		{{Type: "text", Format: 1, Criteria: "ends with", Value: "foo"}},
+		{{Type: "time_period", Format: 1, Criteria: "yesterday"}},
+		{{Type: "time_period", Format: 1, Criteria: "today"}},
+		{{Type: "time_period", Format: 1, Criteria: "tomorrow"}},
+		{{Type: "time_period", Format: 1, Criteria: "last 7 days"}},
+		{{Type: "time_period", Format: 1, Criteria: "last week"}},
+		{{Type: "time_period", Format: 1, Criteria: "this week"}},
+		{{Type: "time_period", Format: 1, Criteria: "next week"}},
+		{{Type: "time_period", Format: 1, Criteria: "last month"}},
+		{{Type: "time_period", Format: 1, Criteria: "this month"}},
+		{{Type: "time_period", Format: 1, Criteria: "next month"}},
 		{{Type: "average", AboveAverage: true, Format: 1, Criteria: "="}},
+		{{Type: "average", AboveAverage: true, StdDev: 2, EqualAverage: true, Format: 1, Criteria: "="}},
+		{{Type: "top", Format: 1, Criteria: "=", Value: "5", Percent: true}},
 		{{Type: "duplicate", Format: 1, Criteria: "="}},
 		{{Type: "unique", Format: 1, Criteria: "="}},
 		{{Type: "3_color_scale", Criteria: "=", MinType: "num", MidType: "num", MaxType: "num", MinValue: "-10", MidValue: "50", MaxValue: "10", MinColor: "#FF0000", MidColor: "#00FF00", MaxColor: "#0000FF"}},
@@ -215,6 +412,7 @@ func TestGetConditionalFormats(t *testing.T) {
 		assert.NoError(t, err)
 		opts, err := f.GetConditionalFormats("Sheet1")
 		assert.NoError(t, err)
+		format[0].Priority = 1
 		assert.Equal(t, format, opts["A1:A2"])
 	}
 	// Test get conditional formats on no exists worksheet
@@ -226,6 +424,73 @@ func TestGetConditionalFormats(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestConditionalFormatPriority(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "60"},
+		{Type: "cell", Criteria: "<", Format: format, Value: "10"},
+	}))
+	// Test the rules of another range are appended after the existing rules
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "60"},
+	}))
+	// Test insert the overlapping rule with the highest priority
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:B10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "90", StopIfTrue: true, Priority: 1},
+		{Type: "cell", Criteria: "=", Format: format, Value: "0", Priority: 100},
+	}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	var priorities []int
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			priorities = append(priorities, rule.Priority)
+		}
+	}
+	assert.Equal(t, []int{2, 3, 4, 1, 5}, priorities)
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ConditionalFormatOptions{
+		{Type: "cell", Criteria: "greater than", Format: format, Value: "90", StopIfTrue: true, Priority: 1},
+		{Type: "cell", Criteria: "equal to", Format: format, Value: "0", Priority: 5},
+	}, opts["A1:B10"])
+	assert.Equal(t, 2, opts["A1:A10"][0].Priority)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestConditionalFormatPriority.xlsx")))
+	// Test the priorities are not changed on setting the invalid rules
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "60", Priority: 1},
+		{Type: "icon_set", IconStyle: "unknown"},
+	}))
+	priorities = nil
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			priorities = append(priorities, rule.Priority)
+		}
+	}
+	assert.Equal(t, []int{2, 3, 4, 1, 5}, priorities)
+	// Test renumber the priorities of the rules in the worksheet extension list
+	f = NewFile()
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ConditionalFormatting = []*xlsxConditionalFormatting{{SQRef: "A1:A10", CfRule: []*xlsxCfRule{{Type: "cellIs", Priority: 1}, {Type: "cellIs", Priority: 4}}}}
+	ws.ExtLst = &xlsxExtLst{Ext: `<ext uri="` + ExtURIConditionalFormattings + `" xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"><x14:conditionalFormattings><x14:conditionalFormatting xmlns:xm="http://schemas.microsoft.com/office/excel/2006/main"><x14:cfRule type="expression" priority="2" id="{00000000-0000-0000-0000-000000000000}"><xm:f>Sheet2!A1&gt;0</xm:f></x14:cfRule><xm:sqref>B1:B10</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings></ext>`}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "60", Priority: 2},
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6"},
+	}))
+	priorities = nil
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			priorities = append(priorities, rule.Priority)
+		}
+	}
+	assert.Equal(t, []int{1, 4, 2, 5}, priorities)
+	assert.Contains(t, ws.ExtLst.Ext, `<x14:cfRule type="expression" priority="3"`)
+	assert.NoError(t, f.Close())
+}
+
 func TestUnsetConditionalFormat(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 7))
@@ -438,6 +703,181 @@ func TestSetCellStyle(t *testing.T) {
 	assert.EqualError(t, f.SetCellStyle("Sheet1", "A1", "A2", 1), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetStyle(t *testing.T) {
+	f := NewFile()
+	expected := &Style{
+		Border: []Border{
+			{Type: "left", Color: "0000FF", Style: 3},
+			{Type: "bottom", Color: "FFFF00", Style: 5},
+			{Type: "diagonalUp", Color: "A020F0", Style: 8},
+		},
+		Fill:       Fill{Type: "gradient", Color: []string{"FFFFFF", "E0EBF5"}, Shading: 2},
+		Font:       &Font{Bold: true, Italic: true, Underline: "double", Family: "Times New Roman", Size: 12, Color: "777777"},
+		Alignment:  &Alignment{Horizontal: "center", Vertical: "top", WrapText: true},
+		Protection: &Protection{Hidden: true, Locked: false},
+		NumFmt:     14,
+	}
+	styleID, err := f.NewStyle(expected)
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, style)
+	// Test get style with the custom number format and the pattern fill
+	styleID, err = f.NewStyle(&Style{CustomNumFmt: stringPtr("0.000"), Fill: Fill{Type: "pattern", Color: []string{"#e0ebf5"}, Pattern: 1}})
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.000", *style.CustomNumFmt)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1}, style.Fill)
+	// Test get style with the theme and indexed colors
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	s.Fills.Fill = append(s.Fills.Fill, &xlsxFill{PatternFill: &xlsxPatternFill{PatternType: "solid", FgColor: &xlsxColor{Theme: intPtr(4), Tint: 0.4}}})
+	s.Borders.Border = append(s.Borders.Border, &xlsxBorder{Top: xlsxLine{Style: "thin", Color: &xlsxColor{Indexed: 10}}})
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xlsxXf{FillID: intPtr(len(s.Fills.Fill) - 1), BorderID: intPtr(len(s.Borders.Border) - 1)})
+	style, err = f.GetStyle(len(s.CellXfs.Xf) - 1)
+	assert.NoError(t, err)
+	color, err := f.ThemeColorToRGB(4, 0.4)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{color[2:]}, style.Fill.Color)
+	assert.Equal(t, []Border{{Type: "top", Color: "FF0000", Style: 1}}, style.Border)
+	// Test get style with invalid style ID
+	for _, idx := range []int{-1, len(s.CellXfs.Xf)} {
+		_, err = f.GetStyle(idx)
+		assert.EqualError(t, err, newInvalidStyleID(idx).Error())
+	}
+	// Test get style with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetStyle(0)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestPatternFill(t *testing.T) {
+	f := NewFile()
+	for _, c := range []struct {
+		fill     Fill
+		expected Fill
+		xml      *xlsxPatternFill
+	}{
+		{
+			fill:     Fill{Type: "pattern", Color: []string{"#808080", "FFFFFF"}, Pattern: 17},
+			expected: Fill{Type: "pattern", Color: []string{"808080", "FFFFFF"}, Pattern: 17},
+			xml:      &xlsxPatternFill{PatternType: "gray125", FgColor: &xlsxColor{RGB: "FF808080"}, BgColor: &xlsxColor{RGB: "FFFFFFFF"}},
+		},
+		{
+			fill:     Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 11},
+			expected: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 11},
+			xml:      &xlsxPatternFill{PatternType: "lightHorizontal", FgColor: &xlsxColor{RGB: "FFFF0000"}},
+		},
+		{
+			fill:     Fill{Type: "pattern", Color: []string{"FF0000"}},
+			expected: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1},
+			xml:      &xlsxPatternFill{PatternType: "solid", FgColor: &xlsxColor{RGB: "FFFF0000"}},
+		},
+	} {
+		styleID, err := f.NewStyle(&Style{Fill: c.fill})
+		assert.NoError(t, err)
+		s, err := f.stylesReader()
+		assert.NoError(t, err)
+		assert.Equal(t, c.xml, s.Fills.Fill[*s.CellXfs.Xf[styleID].FillID].PatternFill)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, style.Fill)
+	}
+	// Test create conditional style with the pattern fill
+	styleID, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}}})
+	assert.NoError(t, err)
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxPatternFill{PatternType: "solid", BgColor: &xlsxColor{RGB: "FFFF0000"}}, s.Dxfs.Dxfs[styleID].Fill.PatternFill)
+	// Test create style with invalid pattern
+	for _, pattern := range []int{-1, 19} {
+		_, err = f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: pattern}})
+		assert.Equal(t, ErrFillPattern, err)
+	}
+}
+
+func TestDiagonalBorder(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Border: []Border{
+		{Type: "diagonalDown", Color: "FF0000", Style: 1},
+		{Type: "diagonalUp", Color: "FF0000", Style: 1},
+	}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	border := s.Borders.Border[*s.CellXfs.Xf[styleID].BorderID]
+	assert.True(t, border.DiagonalDown)
+	assert.True(t, border.DiagonalUp)
+	assert.Equal(t, xlsxLine{Style: "thin", Color: &xlsxColor{RGB: "FFFF0000"}}, border.Diagonal)
+	// Test the diagonal borders round-trip after saving the workbook
+	file := filepath.Join("test", "TestDiagonalBorder.xlsx")
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(file)
+	assert.NoError(t, err)
+	borders, err := f.GetCellBorder("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Border{
+		{Type: "diagonalUp", Color: "FF0000", Style: 1},
+		{Type: "diagonalDown", Color: "FF0000", Style: 1},
+	}, borders)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCellStyleSettings(t *testing.T) {
+	f := NewFile()
+	fillStyle, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	borderStyle, err := f.NewStyle(&Style{Border: []Border{{Type: "top", Color: "00FF00", Style: 2}}})
+	assert.NoError(t, err)
+	alignStyle, err := f.NewStyle(&Style{Alignment: &Alignment{Horizontal: "right"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", fillStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", borderStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C1", "C1", alignStyle))
+	// Test get the style settings of the cell with fill only
+	fill, err := f.GetCellFill("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}, fill)
+	font, err := f.GetCellFont("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Calibri", font.Family)
+	assert.Equal(t, 11.0, font.Size)
+	border, err := f.GetCellBorder("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, border)
+	alignment, err := f.GetCellAlignment("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, alignment)
+	// Test get the style settings of the cell with border only
+	border, err = f.GetCellBorder("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Border{{Type: "top", Color: "00FF00", Style: 2}}, border)
+	fill, err = f.GetCellFill("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Empty(t, fill.Type)
+	// Test get the style settings of the cell with alignment only
+	alignment, err = f.GetCellAlignment("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Alignment{Horizontal: "right"}, alignment)
+	// Test get the style settings of the cell without style
+	fill, err = f.GetCellFill("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Empty(t, fill.Type)
+	// Test get the style settings on not exists worksheet
+	_, err = f.GetCellFont("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetCellFill("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetCellBorder("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetCellAlignment("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestGetStyleID(t *testing.T) {
 	f := NewFile()
 	styleID, err := f.getStyleID(&xlsxStyleSheet{}, nil)
@@ -474,6 +914,121 @@ func TestThemeColor(t *testing.T) {
 	}
 }
 
+func TestTheme(t *testing.T) {
+	f := NewFile()
+	theme, err := f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "Office Theme", theme.Name)
+	assert.Equal(t, ThemeColorScheme{
+		Name: "Office", Dark1: "000000", Light1: "FFFFFF", Dark2: "44546A", Light2: "E7E6E6",
+		Accent1: "5B9BD5", Accent2: "ED7D31", Accent3: "A5A5A5", Accent4: "FFC000", Accent5: "4472C4",
+		Accent6: "70AD47", Hyperlink: "0563C1", FollowedHyperlink: "954F72",
+	}, theme.ColorScheme)
+	assert.Equal(t, ThemeFont{Latin: "Calibri Light"}, theme.MajorFont)
+	assert.Equal(t, ThemeFont{Latin: "Calibri"}, theme.MinorFont)
+	color, err := f.ThemeColorToRGB(4, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "FF5B9BD5", color)
+	// Test set theme
+	theme.ColorScheme.Accent1 = "#1f4e79"
+	theme.MinorFont.Latin = "Arial"
+	assert.NoError(t, f.SetTheme(theme))
+	assert.NoError(t, f.SetTheme(&Theme{ColorScheme: ThemeColorScheme{Dark1: "112233"}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestTheme.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestTheme.xlsx"))
+	assert.NoError(t, err)
+	theme, err = f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "1F4E79", theme.ColorScheme.Accent1)
+	assert.Equal(t, "112233", theme.ColorScheme.Dark1)
+	assert.Equal(t, "FFFFFF", theme.ColorScheme.Light1)
+	assert.Equal(t, "Arial", theme.MinorFont.Latin)
+	assert.NotNil(t, f.Theme.ThemeElements.ClrScheme.Lt1.SysClr)
+	assert.Contains(t, f.Theme.ThemeElements.FmtScheme.FillStyleLst.FillStyleLst, "phClr")
+	for _, c := range []struct {
+		idx      int
+		tint     float64
+		expected string
+	}{
+		{1, 0, "FF112233"},
+		{4, 0, "FF1F4E79"},
+		{0, -0.5, "FF808080"},
+		{4, 1, "FFFFFFFF"},
+	} {
+		color, err := f.ThemeColorToRGB(c.idx, c.tint)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, color)
+	}
+	// Test set theme with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.SetTheme(nil))
+	assert.Equal(t, newInvalidThemeColorError("12345G"), f.SetTheme(&Theme{ColorScheme: ThemeColorScheme{Accent2: "12345G"}}))
+	assert.Equal(t, newInvalidThemeColorError("1234"), f.SetTheme(&Theme{ColorScheme: ThemeColorScheme{Accent2: "1234"}}))
+	// Test get theme color with invalid theme color index
+	for _, idx := range []int{-1, 12} {
+		_, err = f.ThemeColorToRGB(idx, 0)
+		assert.Equal(t, ErrThemeColorIndex, err)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test get and set theme on the workbook without theme
+	f = NewFile()
+	f.Pkg.Delete(defaultXMLPathTheme)
+	f.Theme = nil
+	theme, err = f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "Office Theme", theme.Name)
+	assert.Equal(t, "ED7D31", theme.ColorScheme.Accent2)
+	assert.Equal(t, ThemeFont{Latin: "Calibri"}, theme.MinorFont)
+	color, err = f.ThemeColorToRGB(5, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "FFED7D31", color)
+	assert.NoError(t, f.SetTheme(&Theme{ColorScheme: ThemeColorScheme{Accent2: "C00000"}}))
+	color, err = f.ThemeColorToRGB(5, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "FFC00000", color)
+	// Test get the cell style resolves the theme colors by the current theme
+	f = NewFile()
+	styleID, err := f.NewStyle(&Style{
+		Font:   &Font{ColorTheme: intPtr(4), ColorTint: 0.4},
+		Border: []Border{{Type: "left", Style: 1, Color: "000000"}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	s.Borders.Border[len(s.Borders.Border)-1].Left.Color = &xlsxColor{Theme: intPtr(5)}
+	for _, c := range []struct {
+		theme        *Theme
+		font, border string
+	}{
+		{nil, "9DC3E6", "ED7D31"},
+		{&Theme{ColorScheme: ThemeColorScheme{Accent1: "000000", Accent2: "C00000"}}, "666666", "C00000"},
+	} {
+		if c.theme != nil {
+			assert.NoError(t, f.SetTheme(c.theme))
+		}
+		styleID, err = f.GetCellStyle("Sheet1", "A1")
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, c.font, style.Font.Color)
+		assert.Equal(t, intPtr(4), style.Font.ColorTheme)
+		assert.Equal(t, c.border, style.Border[0].Color)
+	}
+	// Test set theme with unsupported charset content types
+	f = NewFile()
+	f.Theme, f.ContentTypes = nil, nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetTheme(&Theme{}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set theme with unsupported charset workbook relationships
+	f = NewFile()
+	f.Theme, f.Relationships = nil, sync.Map{}
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetTheme(&Theme{}), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetNumFmtID(t *testing.T) {
 	f := NewFile()
 
@@ -488,3 +1043,36 @@ func TestGetNumFmtID(t *testing.T) {
 	assert.NotEqual(t, id1, id2)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStyleNumFmt.xlsx")))
 }
+
+func TestStyleJSON(t *testing.T) {
+	decimalPlaces := 2
+	expected := Style{
+		Border:        []Border{{Type: "left", Color: "0000FF", Style: 3}},
+		Fill:          Fill{Type: "gradient", Color: []string{"FFFFFF", "E0EBF5"}, Shading: 1},
+		Font:          &Font{Bold: true, Family: "Times New Roman", Size: 12},
+		Alignment:     &Alignment{Horizontal: "center", WrapText: true},
+		NumFmt:        2,
+		DecimalPlaces: &decimalPlaces,
+	}
+	var style Style
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"border": [{"type": "left", "color": "0000FF", "style": 3}],
+		"fill": {"type": "gradient", "color": ["FFFFFF", "E0EBF5"], "shading": 1},
+		"font": {"bold": true, "family": "Times New Roman", "size": 12},
+		"alignment": {"horizontal": "center", "wrapText": true},
+		"numFmt": 2,
+		"decimalPlaces": 2
+	}`), &style))
+	assert.Equal(t, expected, style)
+	f := NewFile()
+	styleID, err := f.NewStyle(&expected)
+	assert.NoError(t, err)
+	decodedStyleID, err := f.NewStyle(&style)
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, decodedStyleID)
+
+	var formats []ConditionalFormatOptions
+	assert.NoError(t, json.Unmarshal([]byte(`[{"type": "cell", "criteria": ">", "format": 1, "value": "6"}]`), &formats))
+	assert.Equal(t, []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Format: 1, Value: "6"}}, formats)
+	assert.NoError(t, f.Close())
+}
@@ -80,6 +80,9 @@ func parseTableOptions(opts *Table) (*Table, error) {
 //	TableStyleMedium1 - TableStyleMedium28
 //	TableStyleDark1 - TableStyleDark11
 func (f *File) AddTable(sheet string, table *Table) error {
+	if err := f.checkReadOnly("AddTable"); err != nil {
+		return err
+	}
 	options, err := parseTableOptions(table)
 	if err != nil {
 		return err
@@ -335,6 +338,9 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 //	col   < 2000
 //	Price < 2000
 func (f *File) AutoFilter(sheet, rangeRef string, opts []AutoFilterOptions) error {
+	if err := f.checkReadOnly("AutoFilter"); err != nil {
+		return err
+	}
 	coordinates, err := rangeRefToCoordinates(rangeRef)
 	if err != nil {
 		return err
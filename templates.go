@@ -15,16 +15,18 @@
 package excelize
 
 const (
-	defaultXMLPathContentTypes  = "[Content_Types].xml"
-	defaultXMLPathDocPropsApp   = "docProps/app.xml"
-	defaultXMLPathDocPropsCore  = "docProps/core.xml"
-	defaultXMLPathCalcChain     = "xl/calcChain.xml"
-	defaultXMLPathSharedStrings = "xl/sharedStrings.xml"
-	defaultXMLPathStyles        = "xl/styles.xml"
-	defaultXMLPathTheme         = "xl/theme/theme1.xml"
-	defaultXMLPathWorkbook      = "xl/workbook.xml"
-	defaultXMLPathWorkbookRels  = "xl/_rels/workbook.xml.rels"
-	defaultTempFileSST          = "sharedStrings"
+	defaultXMLPathContentTypes   = "[Content_Types].xml"
+	defaultXMLPathDocPropsApp    = "docProps/app.xml"
+	defaultXMLPathDocPropsCore   = "docProps/core.xml"
+	defaultXMLPathDocPropsCustom = "docProps/custom.xml"
+	defaultXMLPathCalcChain      = "xl/calcChain.xml"
+	defaultXMLPathMetadata       = "xl/metadata.xml"
+	defaultXMLPathSharedStrings  = "xl/sharedStrings.xml"
+	defaultXMLPathStyles         = "xl/styles.xml"
+	defaultXMLPathTheme          = "xl/theme/theme1.xml"
+	defaultXMLPathWorkbook       = "xl/workbook.xml"
+	defaultXMLPathWorkbookRels   = "xl/_rels/workbook.xml.rels"
+	defaultTempFileSST           = "sharedStrings"
 )
 
 const templateDocpropsApp = `<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"><TotalTime>0</TotalTime><Application>Go Excelize</Application></Properties>`
@@ -13,8 +13,12 @@ package excelize
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
+	"image"
+	"image/color"
+	"image/png"
 	"io"
 	"path/filepath"
 	"strconv"
@@ -37,6 +41,8 @@ const (
 )
 
 // GetComments retrieves all comments in a worksheet by given worksheet name.
+// The rich text runs of the comment text and the fonts of them will be returned
+// in the Paragraph field of the comment.
 func (f *File) GetComments(sheet string) ([]Comment, error) {
 	var comments []Comment
 	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
@@ -52,6 +58,10 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 	if err != nil {
 		return comments, err
 	}
+	visible, err := f.getVisibleComments(sheet)
+	if err != nil {
+		return comments, err
+	}
 	if cmts != nil {
 		for _, cmt := range cmts.CommentList.Comment {
 			comment := Comment{}
@@ -60,6 +70,7 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 			}
 			comment.Cell = cmt.Ref
 			comment.AuthorID = cmt.AuthorID
+			_, comment.ShowComment = visible[cmt.Ref]
 			if cmt.Text.T != nil {
 				comment.Text += *cmt.Text.T
 			}
@@ -78,6 +89,50 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 	return comments, nil
 }
 
+// getVisibleComments provides a function to get the cell references of the
+// always visible comments in the worksheet by given worksheet name. The
+// shapes of the comments will be read from the cached VML drawing.
+func (f *File) getVisibleComments(sheet string) (map[string]struct{}, error) {
+	visible := map[string]struct{}{}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.LegacyDrawing == nil {
+		return visible, err
+	}
+	drawingVML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID), "..", "xl")
+	var shapes []string
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		for _, sp := range vml.Shape {
+			shapes = append(shapes, sp.Val)
+		}
+	} else {
+		d, err := f.decodeVMLDrawingReader(drawingVML)
+		if err != nil || d == nil {
+			return visible, err
+		}
+		for _, sp := range d.Shape {
+			shapes = append(shapes, sp.Val)
+		}
+	}
+	for _, val := range shapes {
+		// Skip parsing the shapes without the visible element
+		if !strings.Contains(val, "Visible") {
+			continue
+		}
+		var shapeVal decodeShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", val)), &shapeVal); err != nil {
+			continue
+		}
+		clientData := shapeVal.ClientData
+		if clientData.ObjectType != "Note" || clientData.Visible == nil || clientData.Row == nil || clientData.Column == nil {
+			continue
+		}
+		if cell, err := CoordinatesToCellName(*clientData.Column+1, *clientData.Row+1); err == nil {
+			visible[cell] = struct{}{}
+		}
+	}
+	return visible, nil
+}
+
 // getSheetComments provides the method to get the target comment reference by
 // given worksheet file path.
 func (f *File) getSheetComments(sheetFile string) string {
@@ -96,8 +151,11 @@ func (f *File) getSheetComments(sheetFile string) string {
 
 // AddComment provides the method to add comment in a sheet by given worksheet
 // name, cell reference and format set (such as author and text). Note that the
-// max author length is 255 and the max text length is 32512. For example, add
-// a comment in Sheet1!$A$30:
+// max author length is 255 and the max text length is 32512. The text of the
+// comment could be formatted with the rich text runs in the Paragraph, the font
+// size, font family and font color which not specified in the font of the run
+// will be the same as the default comment text. For example, add a comment
+// with the bold author prefix in Sheet1!$A$30:
 //
 //	err := f.AddComment("Sheet1", excelize.Comment{
 //	    Cell:   "A12",
@@ -108,6 +166,9 @@ func (f *File) getSheetComments(sheetFile string) string {
 //	    },
 //	})
 func (f *File) AddComment(sheet string, opts Comment) error {
+	if err := f.checkReadOnly("AddComment"); err != nil {
+		return err
+	}
 	return f.addVMLObject(vmlOptions{
 		sheet: sheet, Comment: opts,
 		FormControl: FormControl{Cell: opts.Cell, Type: FormControlNote},
@@ -120,6 +181,73 @@ func (f *File) AddComment(sheet string, opts Comment) error {
 //
 //	err := f.DeleteComment("Sheet1", "A30")
 func (f *File) DeleteComment(sheet, cell string) error {
+	if err := f.checkReadOnly("DeleteComment"); err != nil {
+		return err
+	}
+	return f.deleteComment(sheet, cell, false)
+}
+
+// AddNote provides the method to add a note in a worksheet by given worksheet
+// name and note options. The notes are the legacy comments of the worksheet,
+// which were called comments before Excel 365, and the threaded comments in
+// the same worksheet will be kept. A cell may have a note and a threaded
+// comment at the same time, the placeholder legacy comment of the threaded
+// comment will be kept, and the note will be added with its own shape. The
+// existing note of the cell will be replaced. The options are the same as the
+// AddComment function. For example, add a note in Sheet1!$A$3:
+//
+//	err := f.AddNote("Sheet1", excelize.Comment{
+//	    Cell:   "A3",
+//	    Author: "Excelize",
+//	    Text:   "This is a note.",
+//	})
+func (f *File) AddNote(sheet string, opts Comment) error {
+	if err := f.checkReadOnly("AddNote"); err != nil {
+		return err
+	}
+	return f.addVMLObject(vmlOptions{
+		sheet: sheet, note: true, Comment: opts,
+		FormControl: FormControl{Cell: opts.Cell, Type: FormControlNote},
+	})
+}
+
+// GetNotes retrieves all notes in a worksheet by given worksheet name. The
+// placeholder legacy comments of the threaded comments, which authors are
+// prefixed with "tc=", will not be returned.
+func (f *File) GetNotes(sheet string) ([]Comment, error) {
+	var notes []Comment
+	comments, err := f.GetComments(sheet)
+	for _, comment := range comments {
+		if !isThreadedCommentAuthor(comment.Author) {
+			notes = append(notes, comment)
+		}
+	}
+	return notes, err
+}
+
+// DeleteNote provides the method to delete a note in a worksheet by given
+// worksheet name and cell reference, the placeholder legacy comment of the
+// threaded comment in the cell will be kept. For example, delete the note in
+// Sheet1!$A$3:
+//
+//	err := f.DeleteNote("Sheet1", "A3")
+func (f *File) DeleteNote(sheet, cell string) error {
+	if err := f.checkReadOnly("DeleteNote"); err != nil {
+		return err
+	}
+	return f.deleteComment(sheet, cell, true)
+}
+
+// isThreadedCommentAuthor returns if the author of the legacy comment is the
+// placeholder author of the threaded comment.
+func isThreadedCommentAuthor(author string) bool {
+	return strings.HasPrefix(author, "tc=")
+}
+
+// deleteComment provides the method to delete comments in a worksheet by given
+// worksheet name and cell reference, the placeholder legacy comments of the
+// threaded comments will be kept if notesOnly is true.
+func (f *File) deleteComment(sheet, cell string, notesOnly bool) error {
 	if err := checkSheetName(sheet); err != nil {
 		return err
 	}
@@ -142,6 +270,10 @@ func (f *File) DeleteComment(sheet, cell string) error {
 			if cmt.Ref != cell {
 				continue
 			}
+			if notesOnly && cmt.AuthorID < len(cmts.Authors.Author) &&
+				isThreadedCommentAuthor(cmts.Authors.Author[cmt.AuthorID]) {
+				continue
+			}
 			if len(cmts.CommentList.Comment) > 1 {
 				cmts.CommentList.Comment = append(
 					cmts.CommentList.Comment[:i],
@@ -170,11 +302,11 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 	if err != nil {
 		return err
 	}
-	var authorID int
 	if cmts == nil {
 		cmts = &xlsxComments{Authors: xlsxAuthor{Author: []string{opts.Author}}}
 	}
-	if inStrSlice(cmts.Authors.Author, opts.Author, true) == -1 {
+	authorID := inStrSlice(cmts.Authors.Author, opts.Author, true)
+	if authorID == -1 {
 		cmts.Authors.Author = append(cmts.Authors.Author, opts.Author)
 		authorID = len(cmts.Authors.Author) - 1
 	}
@@ -217,15 +349,57 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 			}},
 		}
 		if run.Font != nil {
-			r.RPr = newRpr(run.Font)
+			r.RPr = newCommentRpr(run.Font, r.RPr)
 		}
 		cmt.Text.R = append(cmt.Text.R, r)
 	}
-	cmts.CommentList.Comment = append(cmts.CommentList.Comment, cmt)
+	if i := cmts.noteIndex(cmt.Ref); opts.note && i != -1 {
+		cmts.CommentList.Comment[i] = cmt
+	} else {
+		cmts.CommentList.Comment = append(cmts.CommentList.Comment, cmt)
+	}
 	f.Comments[commentsXML] = cmts
 	return err
 }
 
+// noteIndex returns the index of the note by given cell reference, the
+// placeholder legacy comments of the threaded comments will be skipped, -1
+// will be returned if the cell has no note.
+func (cmts *xlsxComments) noteIndex(ref string) int {
+	if cmts == nil {
+		return -1
+	}
+	for i, cmt := range cmts.CommentList.Comment {
+		if cmt.Ref != ref {
+			continue
+		}
+		if cmt.AuthorID < len(cmts.Authors.Author) &&
+			isThreadedCommentAuthor(cmts.Authors.Author[cmt.AuthorID]) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// newCommentRpr provides a function to create the run properties of the
+// comment text by given font, the font size, font family and font color which
+// not specified in the font will be inherited from the default run properties.
+func newCommentRpr(fnt *Font, defaultRpr *xlsxRPr) *xlsxRPr {
+	rpr := newRpr(fnt)
+	if rpr.Sz == nil {
+		rpr.Sz = defaultRpr.Sz
+	}
+	if rpr.RFont == nil {
+		rpr.RFont = defaultRpr.RFont
+	}
+	if rpr.Color == nil || (rpr.Color.RGB == "" && rpr.Color.Theme == nil && rpr.Color.Indexed == 0 && rpr.Color.Tint == 0) {
+		rpr.Color = defaultRpr.Color
+	}
+	rpr.Family = defaultRpr.Family
+	return rpr
+}
+
 // countComments provides a function to get comments files count storage in
 // the folder xl.
 func (f *File) countComments() int {
@@ -350,6 +524,9 @@ func (f *File) commentsWriter() {
 //	    Horizontally: true,
 //	})
 func (f *File) AddFormControl(sheet string, opts FormControl) error {
+	if err := f.checkReadOnly("AddFormControl"); err != nil {
+		return err
+	}
 	return f.addVMLObject(vmlOptions{
 		formCtrl: true, sheet: sheet, FormControl: opts,
 	})
@@ -361,6 +538,9 @@ func (f *File) AddFormControl(sheet string, opts FormControl) error {
 //
 //	err := f.DeleteFormControl("Sheet1", "A1")
 func (f *File) DeleteFormControl(sheet, cell string) error {
+	if err := f.checkReadOnly("DeleteFormControl"); err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -375,47 +555,9 @@ func (f *File) DeleteFormControl(sheet, cell string) error {
 	sheetRelationshipsDrawingVML := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
 	vmlID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
 	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
-	vml := f.VMLDrawing[drawingVML]
-	if vml == nil {
-		vml = &vmlDrawing{
-			XMLNSv:  "urn:schemas-microsoft-com:vml",
-			XMLNSo:  "urn:schemas-microsoft-com:office:office",
-			XMLNSx:  "urn:schemas-microsoft-com:office:excel",
-			XMLNSmv: "http://macVmlSchemaUri",
-			ShapeLayout: &xlsxShapeLayout{
-				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: vmlID},
-			},
-			ShapeType: &xlsxShapeType{
-				Stroke: &xlsxStroke{JoinStyle: "miter"},
-				VPath:  &vPath{GradientShapeOK: "t", ConnectType: "rect"},
-			},
-		}
-		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
-		d, err := f.decodeVMLDrawingReader(drawingVML)
-		if err != nil {
-			return err
-		}
-		if d != nil {
-			vml.ShapeType.ID = d.ShapeType.ID
-			vml.ShapeType.CoordSize = d.ShapeType.CoordSize
-			vml.ShapeType.Spt = d.ShapeType.Spt
-			vml.ShapeType.Path = d.ShapeType.Path
-			for _, v := range d.Shape {
-				s := xlsxShape{
-					ID:          v.ID,
-					Type:        v.Type,
-					Style:       v.Style,
-					Button:      v.Button,
-					Filled:      v.Filled,
-					FillColor:   v.FillColor,
-					InsetMode:   v.InsetMode,
-					Stroked:     v.Stroked,
-					StrokeColor: v.StrokeColor,
-					Val:         v.Val,
-				}
-				vml.Shape = append(vml.Shape, s)
-			}
-		}
+	vml, err := f.getVMLDrawing(drawingVML, vmlID)
+	if err != nil {
+		return err
 	}
 	for i, sp := range vml.Shape {
 		var shapeVal decodeShapeVal
@@ -510,11 +652,23 @@ func (f *File) addVMLObject(opts vmlOptions) error {
 		f.addSheetNameSpace(opts.sheet, SourceRelationship)
 		f.addSheetLegacyDrawing(opts.sheet, rID)
 	}
-	if err = f.addDrawingVML(vmlID, drawingVML, prepareFormCtrlOptions(&opts)); err != nil {
-		return err
+	commentsXML := "xl/comments" + strconv.Itoa(vmlID) + ".xml"
+	exists := false
+	if opts.note {
+		cmts, err := f.commentsReader(commentsXML)
+		if err != nil {
+			return err
+		}
+		exists = cmts.noteIndex(opts.Comment.Cell) != -1
+	}
+	// The existing note of the cell will be updated in place and the VML shape
+	// of it will be kept, the placeholder of the threaded comment is not a note
+	if !exists {
+		if err = f.addDrawingVML(vmlID, drawingVML, prepareFormCtrlOptions(&opts)); err != nil {
+			return err
+		}
 	}
 	if !opts.formCtrl {
-		commentsXML := "xl/comments" + strconv.Itoa(vmlID) + ".xml"
 		if err = f.addComment(commentsXML, opts); err != nil {
 			return err
 		}
@@ -796,6 +950,9 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 	if opts.FormControl.Type == FormControlNote {
 		sp.ClientData.MoveWithCells = stringPtr("")
 		sp.ClientData.SizeWithCells = stringPtr("")
+		if opts.Comment.ShowComment {
+			sp.ClientData.Visible = stringPtr("")
+		}
 	}
 	if !opts.formCtrl {
 		return &sp, nil
@@ -819,7 +976,7 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 		return err
 	}
 	anchor := fmt.Sprintf("%d, 23, %d, 0, %d, %d, %d, 5", col, row, col+opts.rows+2, col+opts.cols-1, row+opts.rows+2)
-	vmlID, vml, preset := 202, f.VMLDrawing[drawingVML], formCtrlPresets[opts.Type]
+	vmlID, preset := 202, formCtrlPresets[opts.Type]
 	style := "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden"
 	if opts.formCtrl {
 		vmlID = 201
@@ -827,58 +984,21 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 		colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(opts.sheet, col, row, opts.Format.OffsetX, opts.Format.OffsetY, int(opts.Width), int(opts.Height))
 		anchor = fmt.Sprintf("%d, 0, %d, 0, %d, %d, %d, %d", colStart, rowStart, colEnd, x2, rowEnd, y2)
 	}
-	if vml == nil {
-		vml = &vmlDrawing{
-			XMLNSv:  "urn:schemas-microsoft-com:vml",
-			XMLNSo:  "urn:schemas-microsoft-com:office:office",
-			XMLNSx:  "urn:schemas-microsoft-com:office:excel",
-			XMLNSmv: "http://macVmlSchemaUri",
-			ShapeLayout: &xlsxShapeLayout{
-				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: dataID},
-			},
-			ShapeType: &xlsxShapeType{
-				ID:        fmt.Sprintf("_x0000_t%d", vmlID),
-				CoordSize: "21600,21600",
-				Spt:       202,
-				Path:      "m0,0l0,21600,21600,21600,21600,0xe",
-				Stroke:    &xlsxStroke{JoinStyle: "miter"},
-				VPath:     &vPath{GradientShapeOK: "t", ConnectType: "rect"},
-			},
-		}
-		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
-		d, err := f.decodeVMLDrawingReader(drawingVML)
-		if err != nil {
-			return err
-		}
-		if d != nil {
-			vml.ShapeType.ID = d.ShapeType.ID
-			vml.ShapeType.CoordSize = d.ShapeType.CoordSize
-			vml.ShapeType.Spt = d.ShapeType.Spt
-			vml.ShapeType.Path = d.ShapeType.Path
-			for _, v := range d.Shape {
-				s := xlsxShape{
-					ID:          v.ID,
-					Type:        v.Type,
-					Style:       v.Style,
-					Button:      v.Button,
-					Filled:      v.Filled,
-					FillColor:   v.FillColor,
-					InsetMode:   v.InsetMode,
-					Stroked:     v.Stroked,
-					StrokeColor: v.StrokeColor,
-					Val:         v.Val,
-				}
-				vml.Shape = append(vml.Shape, s)
-			}
-		}
+	if !opts.formCtrl && opts.Comment.ShowComment {
+		style = strings.ReplaceAll(style, "visibility:hidden", "visibility:visible")
 	}
+	vml, err := f.getVMLDrawing(drawingVML, dataID)
+	if err != nil {
+		return err
+	}
+	vml.addShapeType(vmlID)
 	sp, err := f.addFormCtrlShape(preset, col, row, anchor, opts)
 	if err != nil {
 		return err
 	}
 	s, _ := xml.Marshal(sp)
 	shape := xlsxShape{
-		ID:          "_x0000_s1025",
+		ID:          fmt.Sprintf("_x0000_s%d", vml.nextShapeID()),
 		Type:        fmt.Sprintf("#_x0000_t%d", vmlID),
 		Style:       style,
 		Button:      preset.strokeButton,
@@ -893,6 +1013,291 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 	return err
 }
 
+// getVMLDrawing provides a function to get the VML drawing by given VML
+// drawing part path, the existing VML shapes in the part will be loaded, and
+// a new VML drawing will be created with the given data ID of the shape
+// layout if the part doesn't exist.
+func (f *File) getVMLDrawing(drawingVML string, dataID int) (*vmlDrawing, error) {
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		return vml, nil
+	}
+	vml := &vmlDrawing{
+		XMLNSv:  "urn:schemas-microsoft-com:vml",
+		XMLNSo:  "urn:schemas-microsoft-com:office:office",
+		XMLNSx:  "urn:schemas-microsoft-com:office:excel",
+		XMLNSmv: "http://macVmlSchemaUri",
+		ShapeLayout: &xlsxShapeLayout{
+			Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: dataID},
+		},
+	}
+	// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
+	d, err := f.decodeVMLDrawingReader(drawingVML)
+	if err != nil {
+		return nil, err
+	}
+	if d != nil {
+		for _, v := range d.ShapeType {
+			vml.ShapeType = append(vml.ShapeType, xlsxShapeType{
+				ID:        v.ID,
+				CoordSize: v.CoordSize,
+				Spt:       v.Spt,
+				Path:      v.Path,
+				Stroke:    &xlsxStroke{JoinStyle: "miter"},
+				VPath:     &vPath{GradientShapeOK: "t", ConnectType: "rect"},
+			})
+		}
+		for _, v := range d.Shape {
+			s := xlsxShape{
+				ID:          v.ID,
+				Type:        v.Type,
+				Style:       v.Style,
+				Button:      v.Button,
+				Filled:      v.Filled,
+				FillColor:   v.FillColor,
+				InsetMode:   v.InsetMode,
+				Stroked:     v.Stroked,
+				StrokeColor: v.StrokeColor,
+				Val:         v.Val,
+			}
+			vml.Shape = append(vml.Shape, s)
+		}
+	}
+	return vml, err
+}
+
+// addShapeType provides a function to add the shape type definition by given
+// shape type number into the VML drawing if it doesn't exist, such as 202 for
+// the comments, 201 for the form controls and 75 for the OLE object icons.
+func (vml *vmlDrawing) addShapeType(spt int) {
+	id := "_x0000_t" + strconv.Itoa(spt)
+	for _, shapeType := range vml.ShapeType {
+		if shapeType.ID == id {
+			return
+		}
+	}
+	vml.ShapeType = append(vml.ShapeType, xlsxShapeType{
+		ID:        id,
+		CoordSize: "21600,21600",
+		Spt:       spt,
+		Path:      "m0,0l0,21600,21600,21600,21600,0xe",
+		Stroke:    &xlsxStroke{JoinStyle: "miter"},
+		VPath:     &vPath{GradientShapeOK: "t", ConnectType: "rect"},
+	})
+}
+
+// nextShapeID provides a function to get an unused ID for the new shape in
+// the VML drawing, the shape IDs of the drawing are started at the data ID of
+// the shape layout multiplied by 1024.
+func (vml *vmlDrawing) nextShapeID() int {
+	shapeID := 1024
+	if vml.ShapeLayout != nil && vml.ShapeLayout.IDmap != nil && vml.ShapeLayout.IDmap.Data > 0 {
+		shapeID = vml.ShapeLayout.IDmap.Data * 1024
+	}
+	for _, sp := range vml.Shape {
+		if id, err := strconv.Atoi(strings.TrimPrefix(sp.ID, "_x0000_s")); err == nil && id > shapeID {
+			shapeID = id
+		}
+	}
+	return shapeID + 1
+}
+
+// AddOLEObject provides a function to embed a file as an OLE object which
+// displayed as an icon in the worksheet by given worksheet name, cell
+// reference and OLE object options. The file will be embedded as an OLE
+// package object, and can be opened with the associated application by
+// double-clicking the icon in the spreadsheet application. For example, embed
+// a PDF file in the worksheet named Sheet1 with a custom EMF icon:
+//
+//	file, err := os.ReadFile("manual.pdf")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	icon, err := os.ReadFile("icon.emf")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddOLEObject("Sheet1", "B2", &excelize.OLEObjectOptions{
+//	    File:          file,
+//	    FileName:      "manual.pdf",
+//	    Icon:          icon,
+//	    IconExtension: ".emf",
+//	})
+func (f *File) AddOLEObject(sheet, cell string, opts *OLEObjectOptions) error {
+	if err := f.checkReadOnly("AddOLEObject"); err != nil {
+		return err
+	}
+	if opts == nil || len(opts.File) == 0 || opts.FileName == "" {
+		return ErrParameterRequired
+	}
+	options := *opts
+	if options.Width <= 0 {
+		options.Width = 64
+	}
+	if options.Height <= 0 {
+		options.Height = 64
+	}
+	if len(options.Icon) == 0 {
+		options.Icon, options.IconExtension = oleObjectIcon(options.Width, options.Height), ".png"
+	}
+	if _, ok := supportedImageTypes[strings.ToLower(options.IconExtension)]; !ok {
+		return ErrImgExt
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	vmlID := f.countVMLDrawing() + 1
+	sheetRelationshipsDrawingVML := "../drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+	if ws.LegacyDrawing != nil {
+		sheetRelationshipsDrawingVML = f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
+		vmlID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
+	} else {
+		rID := f.addRels(sheetRels, SourceRelationshipDrawingVML, sheetRelationshipsDrawingVML, "")
+		f.addSheetLegacyDrawing(sheet, rID)
+	}
+	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+	vml, err := f.getVMLDrawing(drawingVML, vmlID)
+	if err != nil {
+		return err
+	}
+	vml.addShapeType(75)
+	// Add the embedded object and icon image parts
+	oleObjectID := f.countOLEObjects() + 1
+	oleObjectPath := "xl/embeddings/oleObject" + strconv.Itoa(oleObjectID) + ".bin"
+	f.Pkg.Store(oleObjectPath, oleObjectPackage(options.FileName, options.File))
+	media := f.addMedia(options.Icon, strings.ToLower(options.IconExtension))
+	drawingVMLRels := "xl/drawings/_rels/" + filepath.Base(drawingVML) + ".rels"
+	imageRID := f.addRels(drawingVMLRels, SourceRelationshipImage, strings.Replace(media, "xl", "..", 1), "")
+	oleObjectRID := f.addRels(sheetRels, SourceRelationshipOLEObject, "../embeddings/oleObject"+strconv.Itoa(oleObjectID)+".bin", "")
+	objectImageRID := f.addRels(sheetRels, SourceRelationshipImage, strings.Replace(media, "xl", "..", 1), "")
+	// Add the VML shape which displays the icon
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, options.OffsetX, options.OffsetY, options.Width, options.Height)
+	shapeID := vml.nextShapeID()
+	sp, _ := xml.Marshal(encodeShape{
+		Fill:      &vFill{Color2: "window [65]"},
+		ImageData: &vImageData{RelID: "rId" + strconv.Itoa(imageRID)},
+		ClientData: &xClientData{
+			ObjectType:    "Pict",
+			SizeWithCells: stringPtr(""),
+			Anchor:        fmt.Sprintf("%d, %d, %d, %d, %d, %d, %d, %d", colStart, options.OffsetX, rowStart, options.OffsetY, colEnd, x2, rowEnd, y2),
+			CF:            "Pict",
+			AutoPict:      stringPtr(""),
+		},
+	})
+	vml.Shape = append(vml.Shape, xlsxShape{
+		ID:          fmt.Sprintf("_x0000_s%d", shapeID),
+		Type:        "#_x0000_t75",
+		Style:       fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:%dpt;height:%dpt;z-index:%d", options.Width*3/4, options.Height*3/4, len(vml.Shape)+1),
+		Filled:      "t",
+		FillColor:   "window [65]",
+		Stroked:     "t",
+		StrokeColor: "windowText [64]",
+		InsetMode:   "auto",
+		Val:         string(sp[13 : len(sp)-14]),
+	})
+	f.VMLDrawing[drawingVML] = vml
+	// Add the OLE object element in the worksheet
+	oleObject := xlsxOleObject{
+		ProgID: "Package", DvAspect: "DVASPECT_ICON", ShapeID: shapeID,
+		RID: "rId" + strconv.Itoa(oleObjectRID),
+	}
+	fallback, _ := xml.Marshal(oleObject)
+	oleObject.ObjectPr = &xlsxObjectPr{
+		DefaultSize: boolPtr(false), AutoPict: boolPtr(false), RID: "rId" + strconv.Itoa(objectImageRID),
+		Anchor: &xlsxObjectAnchor{
+			MoveWithCells: true, SizeWithCells: true,
+			From: xlsxFrom{Col: colStart, ColOff: options.OffsetX * EMU, Row: rowStart, RowOff: options.OffsetY * EMU},
+			To:   xlsxTo{Col: colEnd, ColOff: x2 * EMU, Row: rowEnd, RowOff: y2 * EMU},
+		},
+	}
+	choice, _ := xml.Marshal(oleObject)
+	if ws.OleObjects == nil {
+		ws.OleObjects = &xlsxInnerXML{}
+	}
+	ws.OleObjects.Content += fmt.Sprintf(`<mc:AlternateContent xmlns:mc="%s"><mc:Choice Requires="x14">%s</mc:Choice><mc:Fallback>%s</mc:Fallback></mc:AlternateContent>`,
+		SourceRelationshipCompatibility.Value, choice, fallback)
+	f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+	f.addSheetNameSpace(sheet, NameSpaceDrawingMLSpreadSheet)
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	if err = f.addContentTypePart(oleObjectID, "oleObject"); err != nil {
+		return err
+	}
+	if err = f.setContentTypePartImageExtensions(); err != nil {
+		return err
+	}
+	return f.setContentTypePartVMLExtensions()
+}
+
+// countOLEObjects provides a function to get embedded OLE objects count
+// storage in the folder xl/embeddings.
+func (f *File) countOLEObjects() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/embeddings/oleObject") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// oleObjectPackage provides a function to create the compound file of the
+// OLE package object by given file name and file content. The file will be
+// stored in the Ole10Native stream of the compound file.
+func oleObjectPackage(name string, content []byte) []byte {
+	var native bytes.Buffer
+	writeUint16 := func(v int) {
+		_ = binary.Write(&native, binary.LittleEndian, uint16(v))
+	}
+	writeUint32 := func(v int) {
+		_ = binary.Write(&native, binary.LittleEndian, uint32(v))
+	}
+	label := filepath.Base(name)
+	writeUint16(2)
+	native.WriteString(label + "\x00")
+	native.WriteString(name + "\x00")
+	writeUint16(0)
+	writeUint16(3)
+	writeUint32(len(name) + 1)
+	native.WriteString(name + "\x00")
+	writeUint32(len(content))
+	native.Write(content)
+	stream := make([]byte, 4, native.Len()+4)
+	binary.LittleEndian.PutUint32(stream, uint32(native.Len()))
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5, clsID: packageCLSID}},
+	}
+	compoundFile.put("\x01Ole10Native", append(stream, native.Bytes()...))
+	return compoundFile.write()
+}
+
+// oleObjectIcon provides a function to create a placeholder icon image in PNG
+// format for the embedded OLE object by given width and height in pixels.
+func oleObjectIcon(width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	border, fill := color.RGBA{R: 128, G: 128, B: 128, A: 255}, color.RGBA{R: 242, G: 242, B: 242, A: 255}
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, fill)
+			if x == 0 || y == 0 || x == width-1 || y == height-1 {
+				img.Set(x, y, border)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
 // GetFormControls retrieves all form controls in a worksheet by a given
 // worksheet name. Note that, this function does not support getting the width
 // and height of the form controls currently.
@@ -22,7 +22,7 @@ type vmlDrawing struct {
 	XMLNSx      string           `xml:"xmlns:x,attr"`
 	XMLNSmv     string           `xml:"xmlns:mv,attr"`
 	ShapeLayout *xlsxShapeLayout `xml:"o:shapelayout"`
-	ShapeType   *xlsxShapeType   `xml:"v:shapetype"`
+	ShapeType   []xlsxShapeType  `xml:"v:shapetype"`
 	Shape       []xlsxShape      `xml:"v:shape"`
 }
 
@@ -91,6 +91,14 @@ type oFill struct {
 	Type string `xml:"type,attr,omitempty"`
 }
 
+// vImageData directly maps the v:imagedata element. This element is used to
+// draw an image in the VML shape, the o:relid attribute references to the
+// image in the relationships of the VML drawing part.
+type vImageData struct {
+	RelID string `xml:"o:relid,attr"`
+	Title string `xml:"o:title,attr"`
+}
+
 // vShadow directly maps the v:shadow element. This element must be defined
 // within a Shape element. In addition, the On attribute must be set to True.
 type vShadow struct {
@@ -131,6 +139,8 @@ type xClientData struct {
 	MoveWithCells *string `xml:"x:MoveWithCells"`
 	SizeWithCells *string `xml:"x:SizeWithCells"`
 	Anchor        string  `xml:"x:Anchor"`
+	CF            string  `xml:"x:CF,omitempty"`
+	AutoPict      *string `xml:"x:AutoPict"`
 	Locked        string  `xml:"x:Locked,omitempty"`
 	PrintObject   string  `xml:"x:PrintObject,omitempty"`
 	AutoFill      string  `xml:"x:AutoFill,omitempty"`
@@ -139,6 +149,7 @@ type xClientData struct {
 	TextVAlign    string  `xml:"x:TextVAlign,omitempty"`
 	Row           *int    `xml:"x:Row"`
 	Column        *int    `xml:"x:Column"`
+	Visible       *string `xml:"x:Visible"`
 	Checked       int     `xml:"x:Checked,omitempty"`
 	FmlaLink      string  `xml:"x:FmlaLink,omitempty"`
 	NoThreeD      *string `xml:"x:NoThreeD"`
@@ -155,8 +166,8 @@ type xClientData struct {
 // decodeVmlDrawing defines the structure used to parse the file
 // xl/drawings/vmlDrawing%d.vml.
 type decodeVmlDrawing struct {
-	ShapeType decodeShapeType `xml:"urn:schemas-microsoft-com:vml shapetype"`
-	Shape     []decodeShape   `xml:"urn:schemas-microsoft-com:vml shape"`
+	ShapeType []decodeShapeType `xml:"urn:schemas-microsoft-com:vml shapetype"`
+	Shape     []decodeShape     `xml:"urn:schemas-microsoft-com:vml shape"`
 }
 
 // decodeShapeType defines the structure used to parse the shapetype element in
@@ -238,6 +249,7 @@ type decodeVMLClientData struct {
 	FmlaMacro  string
 	Column     *int
 	Row        *int
+	Visible    *string
 	Checked    int
 	FmlaLink   string
 	Val        uint
@@ -251,6 +263,7 @@ type decodeVMLClientData struct {
 // encodeShape defines the structure used to re-serialization shape element.
 type encodeShape struct {
 	Fill       *vFill       `xml:"v:fill"`
+	ImageData  *vImageData  `xml:"v:imagedata"`
 	Shadow     *vShadow     `xml:"v:shadow"`
 	Path       *vPath       `xml:"v:path"`
 	TextBox    *vTextBox    `xml:"v:textbox"`
@@ -279,11 +292,41 @@ type vmlOptions struct {
 	rows     int
 	cols     int
 	formCtrl bool
+	note     bool
 	sheet    string
 	Comment
 	FormControl
 }
 
+// OLEObjectOptions directly maps the settings of the embedded OLE object.
+//
+// File specifies the content of the file to be embedded, it's required.
+//
+// FileName specifies the name of the embedded file with the extension, for
+// example "manual.pdf", it's required.
+//
+// Icon specifies the content of the image which displayed as the icon of the
+// embedded object, a placeholder icon will be used if it's empty.
+//
+// IconExtension specifies the extension of the icon image, for example
+// ".emf" or ".png", it's required when the Icon was specified.
+//
+// Width and Height specifies the size of the icon in pixels, the default
+// value is 64.
+//
+// OffsetX and OffsetY specifies the offset of the icon from the top-left
+// corner of the cell in pixels.
+type OLEObjectOptions struct {
+	File          []byte
+	FileName      string
+	Icon          []byte
+	IconExtension string
+	Width         int
+	Height        int
+	OffsetX       int
+	OffsetY       int
+}
+
 // FormControl directly maps the form controls information.
 type FormControl struct {
 	Cell         string
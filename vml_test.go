@@ -12,13 +12,16 @@
 package excelize
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/xuri/excelize/v2/xencoding/xml"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/richardlehane/mscfb"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -81,6 +84,44 @@ func TestAddComment(t *testing.T) {
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
 
+func TestCommentRichText(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Plain"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Author: "Reviewer", Paragraph: []RichTextRun{
+		{Text: "Reviewer:", Font: &Font{Bold: true, Color: "FF0000"}},
+		{Text: " large", Font: &Font{Italic: true, Size: 14, Family: "Arial"}},
+		{Text: " plain"},
+	}}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "C3", Author: "Reviewer", Text: "Again"}))
+	file := filepath.Join("test", "TestCommentRichText.xlsx")
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(file)
+	assert.NoError(t, err)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, "Reviewer", comments[2].Author)
+	assert.Equal(t, 1, comments[2].AuthorID)
+	assert.Equal(t, "Reviewer", comments[1].Author)
+	runs := comments[1].Paragraph
+	assert.Len(t, runs, 3)
+	assert.Equal(t, "Reviewer:", runs[0].Text)
+	assert.True(t, runs[0].Font.Bold)
+	assert.Equal(t, "FF0000", runs[0].Font.Color)
+	assert.Equal(t, 9.0, runs[0].Font.Size)
+	assert.Equal(t, "Calibri", runs[0].Font.Family)
+	assert.True(t, runs[1].Font.Italic)
+	assert.False(t, runs[1].Font.Bold)
+	assert.Equal(t, 14.0, runs[1].Font.Size)
+	assert.Equal(t, "Arial", runs[1].Font.Family)
+	assert.Equal(t, 81, runs[1].Font.ColorIndexed)
+	assert.Equal(t, " plain", runs[2].Text)
+	assert.Equal(t, 9.0, runs[2].Font.Size)
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteComment(t *testing.T) {
 	f, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -124,6 +165,70 @@ func TestDeleteComment(t *testing.T) {
 	assert.EqualError(t, f.DeleteComment("Sheet2", "A41"), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestNotes(t *testing.T) {
+	f := NewFile()
+	threadedAuthor := "tc={6C1B1E8D-9B62-4B1A-A1E4-3C8F0E6A4E21}"
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: threadedAuthor, Text: "[Threaded comment]"}))
+	assert.NoError(t, f.AddNote("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "This is a note."}))
+	assert.NoError(t, f.AddNote("Sheet1", Comment{Cell: "B2", Author: "Excelize", Text: "This is another note."}))
+	threadedCommentsXML := []byte(xml.Header + `<ThreadedComments xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"/>`)
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", threadedCommentsXML)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNotes.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestNotes.xlsx"))
+	assert.NoError(t, err)
+	notes, err := f.GetNotes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+	assert.Equal(t, "A1", notes[0].Cell)
+	assert.Equal(t, "This is a note.", notes[0].Text)
+	// Test the placeholder legacy comment of the threaded comment was kept, and
+	// the note was added with its own shape
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, "A1", comments[0].Cell)
+	assert.Equal(t, threadedAuthor, comments[0].Author)
+	assert.Equal(t, "A1", comments[1].Cell)
+	assert.Equal(t, "Excelize", comments[1].Author)
+	vml, err := f.decodeVMLDrawingReader("xl/drawings/vmlDrawing1.vml")
+	assert.NoError(t, err)
+	assert.Len(t, vml.Shape, 3)
+
+	// Test replace the existing note of the cell in place
+	assert.NoError(t, f.AddNote("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "This is an updated note."}))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, threadedAuthor, comments[0].Author)
+	assert.Equal(t, "This is an updated note.", comments[1].Text)
+	vml, err = f.decodeVMLDrawingReader("xl/drawings/vmlDrawing1.vml")
+	assert.NoError(t, err)
+	assert.Len(t, vml.Shape, 3)
+
+	// Test delete the note and keep the threaded comment in the same cell
+	assert.NoError(t, f.DeleteNote("Sheet1", "A1"))
+	notes, err = f.GetNotes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, notes, 1)
+	assert.Equal(t, "B2", notes[0].Cell)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "A1", comments[0].Cell)
+	assert.Equal(t, threadedAuthor, comments[0].Author)
+	content, ok := f.Pkg.Load("xl/threadedComments/threadedComment1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, threadedCommentsXML, content)
+
+	// Test get and delete notes with not exists worksheet
+	_, err = f.GetNotes("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.DeleteNote("SheetN", "A1"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestDecodeVMLDrawingReader(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/vmlDrawing1.xml"
@@ -156,6 +261,97 @@ func TestAddDrawingVML(t *testing.T) {
 	assert.EqualError(t, f.addDrawingVML(0, "xl/drawings/vmlDrawing1.vml", &vmlOptions{FormControl: FormControl{Cell: "A1"}}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestAddOLEObject(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	content := []byte("Excelize OLE object")
+	assert.NoError(t, f.AddOLEObject("Sheet1", "B2", &OLEObjectOptions{File: content, FileName: "data.txt"}))
+	icon, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddOLEObject("Sheet1", "D2", &OLEObjectOptions{
+		File: content, FileName: "data.txt", Icon: icon, IconExtension: ".png",
+		Width: 32, Height: 32, OffsetX: 10, OffsetY: 10,
+	}))
+	assert.EqualError(t, f.AddOLEObject("Sheet2", "B2", &OLEObjectOptions{File: content, FileName: "data.txt"}), "sheet Sheet2 does not exist")
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddOLEObject("Sheet2", "B2", &OLEObjectOptions{File: content, FileName: "data.txt"}))
+	assert.NoError(t, f.AddFormControl("Sheet2", FormControl{Cell: "D2", Type: FormControlButton, Macro: "Button1_Click"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddOLEObject.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddOLEObject.xlsx"))
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.NotNil(t, ws.LegacyDrawing)
+	assert.Equal(t, 2, strings.Count(ws.OleObjects.Content, "<mc:AlternateContent"))
+	assert.Contains(t, ws.OleObjects.Content, `progId="Package"`)
+	vml, err := f.decodeVMLDrawingReader("xl/drawings/vmlDrawing1.vml")
+	assert.NoError(t, err)
+	if assert.Len(t, vml.Shape, 3) {
+		assert.Equal(t, []string{"_x0000_s1025", "_x0000_s1026", "_x0000_s1027"}, []string{vml.Shape[0].ID, vml.Shape[1].ID, vml.Shape[2].ID})
+		assert.Contains(t, vml.Shape[1].Val, "<x:CF>Pict</x:CF>")
+		assert.Equal(t, []string{"#_x0000_t202", "#_x0000_t75", "#_x0000_t75"}, []string{vml.Shape[0].Type, vml.Shape[1].Type, vml.Shape[2].Type})
+	}
+	// Test each kind of the shapes in the VML drawing has the shape type
+	if assert.Len(t, vml.ShapeType, 2) {
+		assert.Equal(t, []string{"_x0000_t202", "_x0000_t75"}, []string{vml.ShapeType[0].ID, vml.ShapeType[1].ID})
+		assert.Equal(t, []int{202, 75}, []int{vml.ShapeType[0].Spt, vml.ShapeType[1].Spt})
+	}
+	vml, err = f.decodeVMLDrawingReader("xl/drawings/vmlDrawing2.vml")
+	assert.NoError(t, err)
+	if assert.Len(t, vml.ShapeType, 2) {
+		assert.Equal(t, []string{"_x0000_t75", "_x0000_t201"}, []string{vml.ShapeType[0].ID, vml.ShapeType[1].ID})
+	}
+	// Test add a comment into the VML drawing which contains the shape types
+	assert.NoError(t, f.AddComment("Sheet2", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.Equal(t, []string{"_x0000_t75", "_x0000_t201", "_x0000_t202"}, []string{
+		f.VMLDrawing["xl/drawings/vmlDrawing2.vml"].ShapeType[0].ID,
+		f.VMLDrawing["xl/drawings/vmlDrawing2.vml"].ShapeType[1].ID,
+		f.VMLDrawing["xl/drawings/vmlDrawing2.vml"].ShapeType[2].ID,
+	})
+	assert.Equal(t, 3, f.countOLEObjects())
+	for _, name := range []string{"xl/embeddings/oleObject1.bin", "xl/embeddings/oleObject3.bin"} {
+		data, ok := f.Pkg.Load(name)
+		assert.True(t, ok)
+		doc, err := mscfb.New(bytes.NewReader(data.([]byte)))
+		assert.NoError(t, err)
+		entry, err := doc.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, "Ole10Native", entry.Name)
+		native, err := io.ReadAll(entry)
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasSuffix(native, content))
+	}
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	var oleObjects int
+	for _, override := range contentTypes.Overrides {
+		if override.ContentType == ContentTypeOLEObject {
+			oleObjects++
+		}
+	}
+	assert.Equal(t, 3, oleObjects)
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add OLE object with invalid options
+	assert.Equal(t, ErrParameterRequired, f.AddOLEObject("Sheet1", "A1", nil))
+	assert.Equal(t, ErrParameterRequired, f.AddOLEObject("Sheet1", "A1", &OLEObjectOptions{FileName: "data.txt"}))
+	assert.Equal(t, ErrImgExt, f.AddOLEObject("Sheet1", "A1", &OLEObjectOptions{File: content, FileName: "data.txt", Icon: icon, IconExtension: ".txt"}))
+	// Test add OLE object with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddOLEObject("Sheet1", "A", &OLEObjectOptions{File: content, FileName: "data.txt"}))
+	// Test add OLE object on not exists worksheet
+	assert.EqualError(t, f.AddOLEObject("SheetN", "A1", &OLEObjectOptions{File: content, FileName: "data.txt"}), "sheet SheetN does not exist")
+	// Test add OLE object with unsupported charset VML drawing
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	delete(f.VMLDrawing, "xl/drawings/vmlDrawing1.vml")
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddOLEObject("Sheet1", "A1", &OLEObjectOptions{File: content, FileName: "data.txt"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestFormControl(t *testing.T) {
 	f := NewFile()
 	formControls := []FormControl{
@@ -15,17 +15,36 @@ import (
 	"bytes"
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"io"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
 )
 
-// SetWorkbookProps provides a function to sets workbook properties.
+// SetWorkbookProps provides a function to sets workbook properties. The
+// RefMode specifies the reference style which the spreadsheet application
+// displays the formulas in, the possible values are "A1" and "R1C1". For
+// example, display the formulas in the R1C1 reference style:
+//
+//	refMode := "R1C1"
+//	err := f.SetWorkbookProps(&excelize.WorkbookPropsOptions{RefMode: &refMode})
 func (f *File) SetWorkbookProps(opts *WorkbookPropsOptions) error {
+	if err := f.checkReadOnly("SetWorkbookProps"); err != nil {
+		return err
+	}
 	wb, err := f.workbookReader()
 	if err != nil {
 		return err
 	}
+	if opts != nil && opts.RefMode != nil {
+		if *opts.RefMode != "A1" && *opts.RefMode != "R1C1" {
+			return ErrRefMode
+		}
+		if wb.CalcPr == nil {
+			wb.CalcPr = new(xlsxCalcPr)
+		}
+		wb.CalcPr.RefMode = *opts.RefMode
+	}
 	if wb.WorkbookPr == nil {
 		wb.WorkbookPr = new(xlsxWorkbookPr)
 	}
@@ -56,6 +75,288 @@ func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 		opts.FilterPrivacy = boolPtr(wb.WorkbookPr.FilterPrivacy)
 		opts.CodeName = stringPtr(wb.WorkbookPr.CodeName)
 	}
+	opts.RefMode = stringPtr("A1")
+	if wb.CalcPr != nil && wb.CalcPr.RefMode != "" {
+		opts.RefMode = stringPtr(wb.CalcPr.RefMode)
+	}
+	return opts, err
+}
+
+// SetCalcProps provides a function to sets calculation properties. Optional
+// value of "CalcMode" property is: "manual", "auto" or "autoNoTable". Optional
+// value of "RefMode" property is: "A1" or "R1C1". The "IterateCount" and
+// "IterateDelta" properties specifies the maximum iterations and the maximum
+// change for the iterative calculation of the circular references, and the
+// "FullPrecision" property specifies if calculate with full precision instead
+// of the precision as displayed. For example, set the workbook in manual
+// calculation mode with iterative calculation enabled:
+//
+//	calcMode, iterate, iterateCount, iterateDelta := "manual", true, uint(50), 0.0001
+//	err := f.SetCalcProps(&excelize.CalcPropsOptions{
+//	    CalcMode:     &calcMode,
+//	    Iterate:      &iterate,
+//	    IterateCount: &iterateCount,
+//	    IterateDelta: &iterateDelta,
+//	})
+func (f *File) SetCalcProps(opts *CalcPropsOptions) error {
+	if err := f.checkReadOnly("SetCalcProps"); err != nil {
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		return err
+	}
+	calcModes := []string{"manual", "auto", "autoNoTable"}
+	if opts.CalcMode != nil && inStrSlice(calcModes, *opts.CalcMode, true) == -1 {
+		return newInvalidOptionalValue("CalcMode", *opts.CalcMode, calcModes)
+	}
+	if opts.RefMode != nil && *opts.RefMode != "A1" && *opts.RefMode != "R1C1" {
+		return ErrRefMode
+	}
+	if wb.CalcPr == nil {
+		wb.CalcPr = new(xlsxCalcPr)
+	}
+	if opts.CalcID != nil {
+		wb.CalcPr.CalcID = strconv.FormatUint(uint64(*opts.CalcID), 10)
+	}
+	if opts.CalcMode != nil {
+		wb.CalcPr.CalcMode = *opts.CalcMode
+	}
+	if opts.FullCalcOnLoad != nil {
+		wb.CalcPr.FullCalcOnLoad = *opts.FullCalcOnLoad
+	}
+	if opts.RefMode != nil {
+		wb.CalcPr.RefMode = *opts.RefMode
+	}
+	if opts.Iterate != nil {
+		wb.CalcPr.Iterate = *opts.Iterate
+	}
+	if opts.IterateCount != nil {
+		wb.CalcPr.IterateCount = int(*opts.IterateCount)
+	}
+	if opts.IterateDelta != nil {
+		wb.CalcPr.IterateDelta = *opts.IterateDelta
+	}
+	if opts.FullPrecision != nil {
+		wb.CalcPr.FullPrecision = opts.FullPrecision
+	}
+	if opts.CalcCompleted != nil {
+		wb.CalcPr.CalcCompleted = opts.CalcCompleted
+	}
+	if opts.CalcOnSave != nil {
+		wb.CalcPr.CalcOnSave = opts.CalcOnSave
+	}
+	if opts.ConcurrentCalc != nil {
+		wb.CalcPr.ConcurrentCalc = opts.ConcurrentCalc
+	}
+	if opts.ConcurrentManualCount != nil {
+		wb.CalcPr.ConcurrentManualCount = int(*opts.ConcurrentManualCount)
+	}
+	if opts.ForceFullCalc != nil {
+		wb.CalcPr.ForceFullCalc = *opts.ForceFullCalc
+	}
+	return err
+}
+
+// GetCalcProps provides a function to gets calculation properties, the
+// default values defined by the specification will be returned if the
+// properties were not set.
+func (f *File) GetCalcProps() (CalcPropsOptions, error) {
+	opts := CalcPropsOptions{
+		CalcMode:       stringPtr("auto"),
+		RefMode:        stringPtr("A1"),
+		Iterate:        boolPtr(false),
+		IterateCount:   uintPtr(100),
+		IterateDelta:   float64Ptr(0.001),
+		FullPrecision:  boolPtr(true),
+		CalcCompleted:  boolPtr(true),
+		CalcOnSave:     boolPtr(true),
+		ConcurrentCalc: boolPtr(true),
+	}
+	wb, err := f.workbookReader()
+	if err != nil || wb.CalcPr == nil {
+		return opts, err
+	}
+	if wb.CalcPr.CalcID != "" {
+		if calcID, err := strconv.ParseUint(wb.CalcPr.CalcID, 10, 32); err == nil {
+			opts.CalcID = uintPtr(uint(calcID))
+		}
+	}
+	if wb.CalcPr.CalcMode != "" {
+		opts.CalcMode = stringPtr(wb.CalcPr.CalcMode)
+	}
+	opts.FullCalcOnLoad = boolPtr(wb.CalcPr.FullCalcOnLoad)
+	if wb.CalcPr.RefMode != "" {
+		opts.RefMode = stringPtr(wb.CalcPr.RefMode)
+	}
+	opts.Iterate = boolPtr(wb.CalcPr.Iterate)
+	if wb.CalcPr.IterateCount > 0 {
+		opts.IterateCount = uintPtr(uint(wb.CalcPr.IterateCount))
+	}
+	if wb.CalcPr.IterateDelta > 0 {
+		opts.IterateDelta = float64Ptr(wb.CalcPr.IterateDelta)
+	}
+	if wb.CalcPr.FullPrecision != nil {
+		opts.FullPrecision = boolPtr(*wb.CalcPr.FullPrecision)
+	}
+	if wb.CalcPr.CalcCompleted != nil {
+		opts.CalcCompleted = boolPtr(*wb.CalcPr.CalcCompleted)
+	}
+	if wb.CalcPr.CalcOnSave != nil {
+		opts.CalcOnSave = boolPtr(*wb.CalcPr.CalcOnSave)
+	}
+	if wb.CalcPr.ConcurrentCalc != nil {
+		opts.ConcurrentCalc = boolPtr(*wb.CalcPr.ConcurrentCalc)
+	}
+	if wb.CalcPr.ConcurrentManualCount > 0 {
+		opts.ConcurrentManualCount = uintPtr(uint(wb.CalcPr.ConcurrentManualCount))
+	}
+	opts.ForceFullCalc = boolPtr(wb.CalcPr.ForceFullCalc)
+	return opts, err
+}
+
+// SetWorkbookView provides a function to sets the first workbook view, which
+// specifies the window settings of the workbook. The ActiveTab will set the
+// active sheet the same as the SetActiveSheet function. Note that the
+// maximized state of the spreadsheet application window is not stored in the
+// workbook. For example, set the workbook window size and position, widen the
+// sheet tabs bar, and scroll the third sheet to be the first sheet shown in
+// the sheet tabs bar:
+//
+//	x, y, width, height, tabRatio, firstSheet := 0, 0, 28800, 17280, 800, 2
+//	err := f.SetWorkbookView(&excelize.WorkbookViewOptions{
+//	    XWindow:      &x,
+//	    YWindow:      &y,
+//	    WindowWidth:  &width,
+//	    WindowHeight: &height,
+//	    TabRatio:     &tabRatio,
+//	    FirstSheet:   &firstSheet,
+//	})
+func (f *File) SetWorkbookView(opts *WorkbookViewOptions) error {
+	if err := f.checkReadOnly("SetWorkbookView"); err != nil {
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil || opts == nil {
+		return err
+	}
+	if opts.Visibility != nil && inStrSlice([]string{"visible", "hidden", "veryHidden"}, *opts.Visibility, true) == -1 {
+		return newInvalidOptionalValue("Visibility", *opts.Visibility, []string{"visible", "hidden", "veryHidden"})
+	}
+	if opts.TabRatio != nil && (*opts.TabRatio < 0 || *opts.TabRatio > 1000) {
+		return ErrTabRatio
+	}
+	for _, idx := range []*int{opts.FirstSheet, opts.ActiveTab} {
+		if idx != nil && (*idx < 0 || *idx >= len(wb.Sheets.Sheet)) {
+			return ErrSheetIdx
+		}
+	}
+	if wb.BookViews == nil {
+		wb.BookViews = &xlsxBookViews{}
+	}
+	if len(wb.BookViews.WorkBookView) == 0 {
+		wb.BookViews.WorkBookView = append(wb.BookViews.WorkBookView, xlsxWorkBookView{})
+	}
+	view := &wb.BookViews.WorkBookView[0]
+	if opts.Visibility != nil {
+		view.Visibility = *opts.Visibility
+	}
+	if opts.Minimized != nil {
+		view.Minimized = *opts.Minimized
+	}
+	if opts.XWindow != nil {
+		view.XWindow = strconv.Itoa(*opts.XWindow)
+	}
+	if opts.YWindow != nil {
+		view.YWindow = strconv.Itoa(*opts.YWindow)
+	}
+	if opts.WindowWidth != nil {
+		view.WindowWidth = *opts.WindowWidth
+	}
+	if opts.WindowHeight != nil {
+		view.WindowHeight = *opts.WindowHeight
+	}
+	if opts.TabRatio != nil {
+		view.TabRatio = intPtr(*opts.TabRatio)
+	}
+	if opts.FirstSheet != nil {
+		view.FirstSheet = *opts.FirstSheet
+	}
+	if opts.ShowHorizontalScroll != nil {
+		view.ShowHorizontalScroll = boolPtr(*opts.ShowHorizontalScroll)
+	}
+	if opts.ShowVerticalScroll != nil {
+		view.ShowVerticalScroll = boolPtr(*opts.ShowVerticalScroll)
+	}
+	if opts.ShowSheetTabs != nil {
+		view.ShowSheetTabs = boolPtr(*opts.ShowSheetTabs)
+	}
+	if opts.AutoFilterDateGrouping != nil {
+		view.AutoFilterDateGrouping = boolPtr(*opts.AutoFilterDateGrouping)
+	}
+	if opts.ActiveTab != nil {
+		f.SetActiveSheet(*opts.ActiveTab)
+	}
+	return err
+}
+
+// GetWorkbookView provides a function to gets the settings of the first
+// workbook view, the default values defined by the specification will be
+// returned if the settings were not set.
+func (f *File) GetWorkbookView() (WorkbookViewOptions, error) {
+	opts := WorkbookViewOptions{
+		Visibility:             stringPtr("visible"),
+		Minimized:              boolPtr(false),
+		TabRatio:               intPtr(600),
+		FirstSheet:             intPtr(0),
+		ActiveTab:              intPtr(0),
+		ShowHorizontalScroll:   boolPtr(true),
+		ShowVerticalScroll:     boolPtr(true),
+		ShowSheetTabs:          boolPtr(true),
+		AutoFilterDateGrouping: boolPtr(true),
+	}
+	wb, err := f.workbookReader()
+	if err != nil || wb.BookViews == nil || len(wb.BookViews.WorkBookView) == 0 {
+		return opts, err
+	}
+	view := wb.BookViews.WorkBookView[0]
+	if view.Visibility != "" {
+		opts.Visibility = stringPtr(view.Visibility)
+	}
+	opts.Minimized = boolPtr(view.Minimized)
+	if x, err := strconv.Atoi(view.XWindow); err == nil {
+		opts.XWindow = intPtr(x)
+	}
+	if y, err := strconv.Atoi(view.YWindow); err == nil {
+		opts.YWindow = intPtr(y)
+	}
+	if view.WindowWidth != 0 {
+		opts.WindowWidth = intPtr(view.WindowWidth)
+	}
+	if view.WindowHeight != 0 {
+		opts.WindowHeight = intPtr(view.WindowHeight)
+	}
+	if view.TabRatio != nil {
+		opts.TabRatio = intPtr(*view.TabRatio)
+	}
+	opts.FirstSheet = intPtr(view.FirstSheet)
+	opts.ActiveTab = intPtr(view.ActiveTab)
+	for _, field := range []struct {
+		value  *bool
+		target **bool
+	}{
+		{view.ShowHorizontalScroll, &opts.ShowHorizontalScroll},
+		{view.ShowVerticalScroll, &opts.ShowVerticalScroll},
+		{view.ShowSheetTabs, &opts.ShowSheetTabs},
+		{view.AutoFilterDateGrouping, &opts.AutoFilterDateGrouping},
+	} {
+		if field.value != nil {
+			*field.target = boolPtr(*field.value)
+		}
+	}
 	return opts, err
 }
 
@@ -72,6 +373,9 @@ func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 //	    LockStructure: true,
 //	})
 func (f *File) ProtectWorkbook(opts *WorkbookProtectionOptions) error {
+	if err := f.checkReadOnly("ProtectWorkbook"); err != nil {
+		return err
+	}
 	wb, err := f.workbookReader()
 	if err != nil {
 		return err
@@ -106,6 +410,9 @@ func (f *File) ProtectWorkbook(opts *WorkbookProtectionOptions) error {
 // specified the optional password parameter to remove workbook protection with
 // password verification.
 func (f *File) UnprotectWorkbook(password ...string) error {
+	if err := f.checkReadOnly("UnprotectWorkbook"); err != nil {
+		return err
+	}
 	wb, err := f.workbookReader()
 	if err != nil {
 		return err
@@ -170,6 +477,461 @@ func (f *File) getWorkbookRelsPath() (path string) {
 	return
 }
 
+// getExternalLinkPath provides a function to get the path of the external
+// workbook references part by given relationship ID in the workbook
+// relationships.
+func (f *File) getExternalLinkPath(rID string) string {
+	rels, _ := f.relsReader(f.getWorkbookRelsPath())
+	if rels == nil {
+		return ""
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.ID == rID && rel.Type == SourceRelationshipExternalLink {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/")
+			}
+			return path.Join(path.Dir(f.getWorkbookPath()), rel.Target)
+		}
+	}
+	return ""
+}
+
+// getExternalLinkRelsPath provides a function to get the path of the
+// relationships part of the external workbook references part by given path
+// of the external workbook references part.
+func getExternalLinkRelsPath(linkPath string) string {
+	return path.Dir(linkPath) + "/_rels/" + path.Base(linkPath) + ".rels"
+}
+
+// getExternalLinkTarget provides a function to get the path of the external
+// workbook by given path of the external workbook references part and the
+// relationship ID of the external workbook.
+func (f *File) getExternalLinkTarget(linkPath, rID string) string {
+	rels, _ := f.relsReader(getExternalLinkRelsPath(linkPath))
+	if rels == nil {
+		return ""
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.ID == rID && rel.Type == SourceRelationshipExternalLinkPath {
+			return rel.Target
+		}
+	}
+	return ""
+}
+
+// externalLinkReader provides a function to get the pointer to the structure
+// of the external workbook references part after deserialization by given
+// path of the part.
+func (f *File) externalLinkReader(linkPath string) (*xlsxExternalLink, error) {
+	link := new(xlsxExternalLink)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(linkPath)))).
+		Decode(link); err != nil && err != io.EOF {
+		return link, err
+	}
+	return link, nil
+}
+
+// addExternalLinkSheets provides a function to add the worksheet names which
+// not exist in the external workbook references part by given path of the
+// part, the external workbook references and worksheet names. The part will
+// be only updated if any worksheet name was added.
+func (f *File) addExternalLinkSheets(linkPath string, link *xlsxExternalLink, sheets []string) error {
+	var changed bool
+	if _, ok := f.Pkg.Load(linkPath); !ok {
+		changed = true
+	}
+	if link.ExternalBook.SheetNames == nil && len(sheets) > 0 {
+		link.ExternalBook.SheetNames = &xlsxExternalSheetNames{}
+	}
+	for _, sheet := range sheets {
+		exists := false
+		for _, sheetName := range link.ExternalBook.SheetNames.SheetName {
+			if sheetName.Val != nil && *sheetName.Val == sheet {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			link.ExternalBook.SheetNames.SheetName = append(link.ExternalBook.SheetNames.SheetName, attrValString{Val: stringPtr(sheet)})
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	link.XMLNSR = SourceRelationship.Value
+	output, err := xml.Marshal(link)
+	if err != nil {
+		return err
+	}
+	f.saveFileList(linkPath, replaceRelationshipsBytes(output))
+	return nil
+}
+
+// addExternalLink provides a function to add the external workbook references
+// part by given path of the external workbook and its worksheet names, and
+// returns the 1-based index of the external reference, which is used as the
+// workbook index in the formulas, such as [1]Sheet1!A1. The existing external
+// reference will be reused if it's linked to the same workbook.
+func (f *File) addExternalLink(target string, sheets []string) (int, error) {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return 0, err
+	}
+	if wb.ExternalReferences == nil {
+		wb.ExternalReferences = &xlsxExternalReferences{}
+	}
+	for idx, ref := range wb.ExternalReferences.ExternalReference {
+		linkPath := f.getExternalLinkPath(ref.RID)
+		link, err := f.externalLinkReader(linkPath)
+		if err != nil {
+			return 0, err
+		}
+		if link.ExternalBook != nil && f.getExternalLinkTarget(linkPath, link.ExternalBook.RID) == target {
+			return idx + 1, f.addExternalLinkSheets(linkPath, link, sheets)
+		}
+	}
+	linkID := 1
+	for ; ; linkID++ {
+		if _, ok := f.Pkg.Load("xl/externalLinks/externalLink" + strconv.Itoa(linkID) + ".xml"); !ok {
+			break
+		}
+	}
+	linkXML := "xl/externalLinks/externalLink" + strconv.Itoa(linkID) + ".xml"
+	rID := f.addRels(getExternalLinkRelsPath(linkXML), SourceRelationshipExternalLinkPath, target, "External")
+	link := xlsxExternalLink{ExternalBook: &xlsxExternalBook{RID: "rId" + strconv.Itoa(rID)}}
+	if err = f.addExternalLinkSheets(linkXML, &link, sheets); err != nil {
+		return 0, err
+	}
+	rID = f.addRels(f.getWorkbookRelsPath(), SourceRelationshipExternalLink, "/"+linkXML, "")
+	wb.ExternalReferences.ExternalReference = append(wb.ExternalReferences.ExternalReference,
+		xlsxExternalReference{RID: "rId" + strconv.Itoa(rID)})
+	return len(wb.ExternalReferences.ExternalReference), f.setContentTypes("/"+linkXML, ContentTypeSpreadSheetMLExternalLink)
+}
+
+// AddExternalLink provides a function to add the link to the external
+// workbook by given path of the external workbook and the names of worksheets
+// in the external workbook which will be referenced, and returns the 1-based
+// index of the external workbook link. The formulas should refer to the cells
+// in the external workbook by this index. The existing link will be reused if
+// it's linked to the same workbook. For example, add the link to the workbook
+// Budget.xlsx and refer to the cell A1 on Sheet1 of it:
+//
+//	idx, err := f.AddExternalLink("Budget.xlsx", "Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetCellFormula("Sheet1", "A1", fmt.Sprintf("=[%d]Sheet1!A1", idx))
+func (f *File) AddExternalLink(target string, sheets ...string) (int, error) {
+	if err := f.checkReadOnly("AddExternalLink"); err != nil {
+		return 0, err
+	}
+	if target == "" {
+		return 0, ErrParameterInvalid
+	}
+	return f.addExternalLink(target, sheets)
+}
+
+// GetExternalLinks provides a function to get all external workbook links of
+// the workbook. The index of each link is the 1-based index that the formulas
+// used to refer to the external workbook, such as [1]Sheet1!A1.
+func (f *File) GetExternalLinks() []ExternalLink {
+	var links []ExternalLink
+	wb, _ := f.workbookReader()
+	if wb == nil || wb.ExternalReferences == nil {
+		return links
+	}
+	for idx, ref := range wb.ExternalReferences.ExternalReference {
+		linkPath, link := f.getExternalLinkPath(ref.RID), ExternalLink{Index: idx + 1}
+		if externalLink, _ := f.externalLinkReader(linkPath); externalLink.ExternalBook != nil {
+			link.Target = f.getExternalLinkTarget(linkPath, externalLink.ExternalBook.RID)
+			if externalLink.ExternalBook.SheetNames != nil {
+				for _, sheetName := range externalLink.ExternalBook.SheetNames.SheetName {
+					if sheetName.Val != nil {
+						link.Sheets = append(link.Sheets, *sheetName.Val)
+					}
+				}
+			}
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// BreakExternalLinks provides a function to break all external workbook links
+// of the workbook. The formulas which refer to the external workbooks will be
+// replaced with their values, which are calculated with the cached cell
+// values of the external workbooks stored in the external workbook references
+// parts, the cells keep their last cached values if the external workbooks
+// cell values were not cached. The data validations and conditional formatting
+// rules which refer to the external workbooks will be deleted. The chart
+// series and titles which refer to the external workbooks will be converted to
+// the literal values with their cached data. The defined names which refer to
+// the external workbooks will be deleted, and the external workbook
+// references parts will be removed.
+func (f *File) BreakExternalLinks() error {
+	if err := f.checkReadOnly("BreakExternalLinks"); err != nil {
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	for _, sheet := range f.GetSheetList() {
+		if name, _ := f.getSheetXMLPath(sheet); !strings.HasPrefix(name, "xl/worksheets/") {
+			continue
+		}
+		if err = f.breakExternalFormulas(sheet); err != nil {
+			return err
+		}
+	}
+	f.breakExternalChartRefs()
+	if wb.DefinedNames != nil {
+		var definedNames []xlsxDefinedName
+		for _, dn := range wb.DefinedNames.DefinedName {
+			if !isExternalFormula(dn.Data) {
+				definedNames = append(definedNames, dn)
+			}
+		}
+		if wb.DefinedNames.DefinedName = definedNames; len(definedNames) == 0 {
+			wb.DefinedNames = nil
+		}
+	}
+	if wb.ExternalReferences == nil {
+		return err
+	}
+	for _, ref := range wb.ExternalReferences.ExternalReference {
+		linkPath := f.getExternalLinkPath(ref.RID)
+		f.deleteSheetFromWorkbookRels(ref.RID)
+		if linkPath == "" {
+			continue
+		}
+		relsPath := getExternalLinkRelsPath(linkPath)
+		f.Pkg.Delete(linkPath)
+		f.Pkg.Delete(relsPath)
+		f.Relationships.Delete(relsPath)
+		if err = f.deleteSheetFromContentTypes("/" + linkPath); err != nil {
+			return err
+		}
+	}
+	wb.ExternalReferences = nil
+	return err
+}
+
+// breakExternalFormulas provides a function to replace the formulas which
+// refer to the external workbooks with their values by given worksheet name.
+// The values will be refreshed by the cached cell values of the external
+// workbooks if all the referenced worksheets of the external workbooks have
+// cached values, otherwise the cells keep their cached values.
+func (f *File) breakExternalFormulas(sheet string) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	_, cachedSheets, err := f.getExternalCellValues()
+	if err != nil {
+		return err
+	}
+	type externalFormula struct {
+		cell       string
+		cached, ok bool
+		result     formulaArg
+	}
+	var formulas []externalFormula
+	ws.mu.Lock()
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			cell := &ws.SheetData.Row[r].C[c]
+			if cell.F == nil {
+				continue
+			}
+			formula := cell.F.Content
+			if cell.F.T == STCellFormulaTypeShared && cell.F.Si != nil {
+				formula = getSharedFormula(ws, *cell.F.Si, cell.R)
+			}
+			external, cached := false, true
+			replaceFormulaSheetRefs(formula, func(sheet string) (string, bool) {
+				if strings.ContainsRune(sheet, '[') {
+					external, cached = true, cached && cachedSheets[strings.ToUpper(sheet)]
+				}
+				return sheet, false
+			})
+			if external {
+				formulas = append(formulas, externalFormula{cell: cell.R, cached: cached})
+			}
+		}
+	}
+	ws.mu.Unlock()
+	for i := range formulas {
+		if formulas[i].cached {
+			formulas[i].result, formulas[i].ok = f.calcFormulaValue(sheet, formulas[i].cell)
+		}
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.breakExternalRules()
+	cells := make(map[string]*xlsxC, len(formulas))
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			cells[ws.SheetData.Row[r].C[c].R] = &ws.SheetData.Row[r].C[c]
+		}
+	}
+	for _, formula := range formulas {
+		cell, ok := cells[formula.cell]
+		if !ok {
+			continue
+		}
+		if formula.ok {
+			if err = f.setFormulaResult(cell, formula.result); err != nil {
+				return err
+			}
+		}
+		if err = f.removeFormula(cell, ws, sheet); err != nil {
+			return err
+		}
+		cell.F = nil
+		if cell.T == "str" {
+			idx, err := f.setSharedString(cell.V)
+			if err != nil {
+				return err
+			}
+			cell.T, cell.V = "s", strconv.Itoa(idx)
+		}
+	}
+	return err
+}
+
+// breakExternalRules provides a function to delete the data validations and
+// the conditional formatting rules which formulas refer to the external
+// workbooks, they can't be evaluated after the external links were broken.
+func (ws *xlsxWorksheet) breakExternalRules() {
+	if ws.DataValidations != nil {
+		var dvs []*DataValidation
+		for _, dv := range ws.DataValidations.DataValidation {
+			var external bool
+			for _, formula := range dataValidationFormulaRegexp.FindAllStringSubmatch(dv.Formula1+dv.Formula2, -1) {
+				external = external || isExternalFormula(xmlUnescaper.Replace(formula[2]))
+			}
+			if !external {
+				dvs = append(dvs, dv)
+			}
+		}
+		ws.DataValidations.DataValidation, ws.DataValidations.Count = dvs, len(dvs)
+		if len(dvs) == 0 {
+			ws.DataValidations = nil
+		}
+	}
+	var cfs []*xlsxConditionalFormatting
+	for _, cf := range ws.ConditionalFormatting {
+		var rules []*xlsxCfRule
+		for _, rule := range cf.CfRule {
+			if !rule.isExternal() {
+				rules = append(rules, rule)
+			}
+		}
+		if len(rules) > 0 || len(cf.CfRule) == 0 {
+			cf.CfRule = rules
+			cfs = append(cfs, cf)
+		}
+	}
+	ws.ConditionalFormatting = cfs
+}
+
+// isExternal returns if the formulas or the value objects of the conditional
+// formatting rule refer to the external workbooks.
+func (rule *xlsxCfRule) isExternal() bool {
+	for _, formula := range rule.Formula {
+		if isExternalFormula(formula) {
+			return true
+		}
+	}
+	var cfvos []*xlsxCfvo
+	if rule.ColorScale != nil {
+		cfvos = append(cfvos, rule.ColorScale.Cfvo...)
+	}
+	if rule.DataBar != nil {
+		cfvos = append(cfvos, rule.DataBar.Cfvo...)
+	}
+	if rule.IconSet != nil {
+		cfvos = append(cfvos, rule.IconSet.Cfvo...)
+	}
+	for _, cfvo := range cfvos {
+		if cfvo.Type == "formula" && isExternalFormula(cfvo.Val) {
+			return true
+		}
+	}
+	return false
+}
+
+// breakExternalChartRefs provides a function to convert the series names,
+// categories, values and titles of the charts which refer to the external
+// workbooks to the literal values with their cached data.
+func (f *File) breakExternalChartRefs() {
+	f.Pkg.Range(func(k, v interface{}) bool {
+		name := k.(string)
+		if !strings.HasPrefix(name, "xl/charts/chart") || !strings.HasSuffix(name, ".xml") {
+			return true
+		}
+		var changed bool
+		content := chartDataRefRegexp.ReplaceAllFunc(v.([]byte), func(match []byte) []byte {
+			sub := chartDataRefRegexp.FindSubmatch(match)
+			if !isExternalFormula(xmlUnescaper.Replace(string(sub[5]))) {
+				return match
+			}
+			changed = true
+			var data, text []byte
+			if cache := chartDataCacheRegexp.FindSubmatch(sub[6]); cache != nil {
+				data = cache[1]
+			}
+			if value := chartDataValueRegexp.FindSubmatch(data); value != nil {
+				text = value[1]
+			}
+			prefix, elem := string(sub[2]), string(sub[3])
+			var buf bytes.Buffer
+			buf.Write(sub[1])
+			buf.WriteString("<" + prefix + elem + ">")
+			switch {
+			case elem == "tx" && len(sub[1]) > 0:
+				buf.WriteString("<" + prefix + "rich><a:bodyPr/><a:p><a:r><a:t>")
+				buf.Write(text)
+				buf.WriteString("</a:t></a:r></a:p></" + prefix + "rich>")
+			case elem == "tx":
+				buf.WriteString("<" + prefix + "v>")
+				buf.Write(text)
+				buf.WriteString("</" + prefix + "v>")
+			default:
+				lit := prefix + string(sub[4]) + "Lit"
+				buf.WriteString("<" + lit + ">")
+				buf.Write(data)
+				buf.WriteString("</" + lit + ">")
+			}
+			buf.WriteString("</" + prefix + elem + ">")
+			return buf.Bytes()
+		})
+		if changed {
+			f.Pkg.Store(name, content)
+		}
+		return true
+	})
+}
+
+// isExternalFormula returns if the formula contains the references to the
+// external workbooks.
+func isExternalFormula(formula string) (external bool) {
+	replaceFormulaSheetRefs(formula, func(sheet string) (string, bool) {
+		external = external || strings.ContainsRune(sheet, '[')
+		return sheet, false
+	})
+	return
+}
+
 // workbookReader provides a function to get the pointer to the workbook.xml
 // structure after deserialization.
 func (f *File) workbookReader() (*xlsxWorkbook, error) {
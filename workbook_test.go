@@ -1,6 +1,9 @@
 package excelize
 
 import (
+	"github.com/xuri/excelize/v2/xencoding/xml"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,11 +19,23 @@ func TestWorkbookProps(t *testing.T) {
 		Date1904:      boolPtr(true),
 		FilterPrivacy: boolPtr(true),
 		CodeName:      stringPtr("code"),
+		RefMode:       stringPtr("R1C1"),
 	}
 	assert.NoError(t, f.SetWorkbookProps(&expected))
 	opts, err := f.GetWorkbookProps()
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	assert.Equal(t, "R1C1", wb.CalcPr.RefMode)
+	// Test keep the reference mode on updating linked value
+	assert.NoError(t, f.UpdateLinkedValue())
+	assert.Equal(t, &xlsxCalcPr{RefMode: "R1C1"}, wb.CalcPr)
+	// Test get the default reference mode
+	wb.CalcPr = nil
+	opts, err = f.GetWorkbookProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", *opts.RefMode)
+	// Test set workbook properties with invalid reference mode
+	assert.Equal(t, ErrRefMode, f.SetWorkbookProps(&WorkbookPropsOptions{RefMode: stringPtr("R1")}))
 	// Test set workbook properties with unsupported charset workbook
 	f.WorkBook = nil
 	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
@@ -31,3 +46,317 @@ func TestWorkbookProps(t *testing.T) {
 	_, err = f.GetWorkbookProps()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestCalcProps(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCalcProps(nil))
+	// Test get the default calculation properties
+	opts, err := f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, CalcPropsOptions{
+		CalcID:         uintPtr(122211),
+		CalcMode:       stringPtr("auto"),
+		FullCalcOnLoad: boolPtr(false),
+		RefMode:        stringPtr("A1"),
+		Iterate:        boolPtr(false),
+		IterateCount:   uintPtr(100),
+		IterateDelta:   float64Ptr(0.001),
+		FullPrecision:  boolPtr(true),
+		CalcCompleted:  boolPtr(true),
+		CalcOnSave:     boolPtr(true),
+		ConcurrentCalc: boolPtr(true),
+		ForceFullCalc:  boolPtr(false),
+	}, opts)
+	expected := CalcPropsOptions{
+		CalcID:                uintPtr(191029),
+		CalcMode:              stringPtr("manual"),
+		FullCalcOnLoad:        boolPtr(true),
+		RefMode:               stringPtr("R1C1"),
+		Iterate:               boolPtr(true),
+		IterateCount:          uintPtr(50),
+		IterateDelta:          float64Ptr(0.0001),
+		FullPrecision:         boolPtr(false),
+		CalcCompleted:         boolPtr(false),
+		CalcOnSave:            boolPtr(false),
+		ConcurrentCalc:        boolPtr(false),
+		ConcurrentManualCount: uintPtr(4),
+		ForceFullCalc:         boolPtr(true),
+	}
+	assert.NoError(t, f.SetCalcProps(&expected))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCalcProps.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestCalcProps.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	// Test set calculation properties with invalid calculation mode
+	assert.EqualError(t, f.SetCalcProps(&CalcPropsOptions{CalcMode: stringPtr("AUTO")}), "invalid CalcMode value \"AUTO\", acceptable value should be one of manual, auto, autoNoTable")
+	// Test set calculation properties with invalid reference mode
+	assert.Equal(t, ErrRefMode, f.SetCalcProps(&CalcPropsOptions{RefMode: stringPtr("R1")}))
+	assert.NoError(t, f.Close())
+	// Test set calculation properties with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCalcProps(&expected), "XML syntax error on line 1: invalid UTF-8")
+	// Test get calculation properties with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetCalcProps()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestWorkbookView(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetWorkbookView(nil))
+	f.WorkBook.BookViews = nil
+	opts, err := f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookViewOptions{
+		Visibility:             stringPtr("visible"),
+		Minimized:              boolPtr(false),
+		TabRatio:               intPtr(600),
+		FirstSheet:             intPtr(0),
+		ActiveTab:              intPtr(0),
+		ShowHorizontalScroll:   boolPtr(true),
+		ShowVerticalScroll:     boolPtr(true),
+		ShowSheetTabs:          boolPtr(true),
+		AutoFilterDateGrouping: boolPtr(true),
+	}, opts)
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err = f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	expected := WorkbookViewOptions{
+		Visibility:             stringPtr("visible"),
+		Minimized:              boolPtr(false),
+		XWindow:                intPtr(-120),
+		YWindow:                intPtr(-120),
+		WindowWidth:            intPtr(28800),
+		WindowHeight:           intPtr(17280),
+		TabRatio:               intPtr(0),
+		FirstSheet:             intPtr(1),
+		ActiveTab:              intPtr(2),
+		ShowHorizontalScroll:   boolPtr(false),
+		ShowVerticalScroll:     boolPtr(true),
+		ShowSheetTabs:          boolPtr(true),
+		AutoFilterDateGrouping: boolPtr(false),
+	}
+	assert.NoError(t, f.SetWorkbookView(&expected))
+	opts, err = f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	// Test the active tab was set as the active sheet
+	assert.Equal(t, 2, f.GetActiveSheetIndex())
+	for sheet, expected := range map[string]bool{"Sheet1": false, "Sheet2": false, "Sheet3": true} {
+		ws, err := f.workSheetReader(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, ws.SheetViews.SheetView[0].TabSelected, sheet)
+	}
+	// Test set the active sheet will be updated in the workbook view
+	f.SetActiveSheet(1)
+	opts, err = f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *opts.ActiveTab)
+	assert.Equal(t, 1, *opts.FirstSheet)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestWorkbookView.xlsx")))
+	// Test set workbook view with invalid settings
+	assert.EqualError(t, f.SetWorkbookView(&WorkbookViewOptions{Visibility: stringPtr("x")}),
+		newInvalidOptionalValue("Visibility", "x", []string{"visible", "hidden", "veryHidden"}).Error())
+	assert.Equal(t, ErrTabRatio, f.SetWorkbookView(&WorkbookViewOptions{TabRatio: intPtr(1001)}))
+	assert.Equal(t, ErrSheetIdx, f.SetWorkbookView(&WorkbookViewOptions{FirstSheet: intPtr(3)}))
+	assert.Equal(t, ErrSheetIdx, f.SetWorkbookView(&WorkbookViewOptions{ActiveTab: intPtr(-1)}))
+	// Test set and get workbook view with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookView(&WorkbookViewOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetWorkbookView()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestExternalLinks(t *testing.T) {
+	f := NewFile()
+	assert.Empty(t, f.GetExternalLinks())
+	idx, err := f.AddExternalLink("Budget.xlsx", "Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, idx)
+	// Test add link to the same workbook with new worksheet names
+	idx, err = f.AddExternalLink("Budget.xlsx", "Sheet1", "Sheet 2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, idx)
+	idx, err = f.AddExternalLink("Sales.xlsx")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, idx)
+	assert.Equal(t, []ExternalLink{
+		{Index: 1, Target: "Budget.xlsx", Sheets: []string{"Sheet1", "Sheet 2"}},
+		{Index: 2, Target: "Sales.xlsx"},
+	}, f.GetExternalLinks())
+
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=[1]Sheet1!A1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "=SUM('[1]Sheet 2'!A1:A2)+B1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "=B1"))
+	formulaType, ref := STCellFormulaTypeShared, "B2:B3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "=[2]Sheet1!A1", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].V = "100"
+	ws.(*xlsxWorksheet).SheetData.Row[1].C[0].T, ws.(*xlsxWorksheet).SheetData.Row[1].C[0].V = "str", "Total"
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "[1]Sheet1!$A$1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Local", RefersTo: "Sheet1!$A$1"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestExternalLinks.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test break external links keep the cached values without external cached
+	// cell values
+	f, err = OpenFile(filepath.Join("test", "TestExternalLinks.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.BreakExternalLinks())
+	for cell, expected := range map[string]string{"A1": "100", "A2": "Total"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestExternalLinks.xlsx"))
+	assert.NoError(t, err)
+	assert.Len(t, f.GetExternalLinks(), 2)
+	// Test break external links with the cached cell values of the external
+	// workbook
+	link, err := f.externalLinkReader("xl/externalLinks/externalLink1.xml")
+	assert.NoError(t, err)
+	link.ExternalBook.SheetDataSet = &xlsxExternalSheetDataSet{SheetData: []xlsxExternalSheetData{
+		{SheetID: 0, Row: []xlsxExternalRow{{R: 1, Cell: []xlsxExternalCell{{R: "A1", V: "200"}, {R: "B1", T: "b", V: "1"}, {R: "C1", T: "e", V: "#N/A"}}}}},
+		{SheetID: 1, Row: []xlsxExternalRow{{R: 1, Cell: []xlsxExternalCell{{R: "A1", V: "1"}}}, {R: 2, Cell: []xlsxExternalCell{{R: "A2", T: "str", V: "text"}}}}},
+		{SheetID: 2},
+	}}
+	output, err := xml.Marshal(link)
+	assert.NoError(t, err)
+	f.saveFileList("xl/externalLinks/externalLink1.xml", replaceRelationshipsBytes(output))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 1))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=[1]Sheet1!B1&\"\""))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "=IF([1]Sheet1!A1>100,\"Yes\",\"No\")"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C3", "=ISNA([1]Sheet1!C1)"))
+	result, err := f.CalcCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", result)
+	assert.NoError(t, f.BreakExternalLinks())
+	assert.Empty(t, f.GetExternalLinks())
+	for cell, expected := range map[string][]string{
+		"A1": {"", "200"}, "A2": {"", "2"}, "A3": {"=B1", ""}, "B2": {"", ""}, "B3": {"", ""}, "C1": {"", "TRUE"}, "C2": {"", "Yes"}, "C3": {"", "TRUE"},
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], formula, cell)
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], value, cell)
+	}
+	assert.Equal(t, []DefinedName{{Name: "Local", RefersTo: "Sheet1!$A$1", Scope: "Workbook"}}, f.GetDefinedName())
+	_, ok = f.Pkg.Load("xl/externalLinks/externalLink1.xml")
+	assert.False(t, ok)
+	_, ok = f.Pkg.Load("xl/externalLinks/_rels/externalLink1.xml.rels")
+	assert.False(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestBreakExternalLinks.xlsx")))
+	// Test break external links without links
+	assert.NoError(t, f.BreakExternalLinks())
+	assert.NoError(t, f.Close())
+
+	// Test add external link with invalid target
+	f = NewFile()
+	_, err = f.AddExternalLink("")
+	assert.Equal(t, ErrParameterInvalid, err)
+	// Test external links with unsupported charset external link part
+	_, err = f.AddExternalLink("Budget.xlsx")
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", MacintoshCyrillicCharset)
+	_, err = f.AddExternalLink("Budget.xlsx")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.Equal(t, []ExternalLink{{Index: 1}}, f.GetExternalLinks())
+	// Test external links with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.AddExternalLink("Budget.xlsx")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	assert.EqualError(t, f.BreakExternalLinks(), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestBreakExternalLinksRefs(t *testing.T) {
+	f := NewFile()
+	_, err := f.AddExternalLink("Budget.xlsx", "Sheet1")
+	assert.NoError(t, err)
+	// Test break external links in the data validations, the external
+	// references are not allowed to be added by the AddDataValidation
+	dv := NewDataValidation(true)
+	dv.SetSqref("D1:D3")
+	dv.SetSqrefDropList("Sheet1!$A$1:$A$3")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(true)
+	dv.SetSqref("D4:D6")
+	dv.SetSqrefDropList("[1]Sheet1!$A$1:$A$3")
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.DataValidations.DataValidation = append(ws.DataValidations.DataValidation, dv)
+	// Test break external links in the conditional formats
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "E1:E3", []ConditionalFormatOptions{
+		{Type: "formula", Criteria: "[1]Sheet1!$A$1>0", Format: format},
+		{Type: "formula", Criteria: "Sheet1!$A$1>0", Format: format},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "F1:F3", []ConditionalFormatOptions{
+		{Type: "formula", Criteria: "[1]Sheet1!$A$1>0", Format: format},
+	}))
+	// Test break external links in the charts
+	assert.NoError(t, f.AddChart("Sheet1", "H1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$1:$A$2", Values: "Sheet1!$B$1:$B$2"}},
+		Title:  []RichTextRun{{Text: "Title"}},
+	}))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(strings.NewReplacer(
+		"<title><tx><rich>", "<title><tx><strRef><f>[1]Sheet1!$C$1</f><strCache><ptCount val=\"1\"/><pt idx=\"0\"><v>Budget</v></pt></strCache></strRef></tx></title><title><tx><rich>",
+		"<f>Sheet1!$A$1</f>", "<f>[1]Sheet1!$A$1</f><strCache><ptCount val=\"1\"/><pt idx=\"0\"><v>Name</v></pt></strCache>",
+		"<f>Sheet1!$B$1:$B$2</f>", "<f>[1]Sheet1!$B$1:$B$2</f><numCache><ptCount val=\"2\"/><pt idx=\"0\"><v>1</v></pt><pt idx=\"1\"><v>2</v></pt></numCache>",
+	).Replace(string(content.([]byte)))))
+	assert.NoError(t, f.BreakExternalLinks())
+
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "<formula1>Sheet1!$A$1:$A$3</formula1>", dvs[0].Formula1)
+	cfs, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, cfs, 1)
+	assert.Len(t, cfs["E1:E3"], 1)
+	assert.Equal(t, "Sheet1!$A$1>0", cfs["E1:E3"][0].Criteria)
+	content, ok = f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	chart := string(content.([]byte))
+	assert.NotContains(t, chart, "[1]")
+	assert.Contains(t, chart, "<title><tx><rich><a:bodyPr/><a:p><a:r><a:t>Budget</a:t></a:r></a:p></rich></tx></title>")
+	assert.Contains(t, chart, "<tx><v>Name</v></tx>")
+	assert.Contains(t, chart, "<cat><strRef><f>Sheet1!$A$1:$A$2</f></strRef></cat>")
+	assert.Contains(t, chart, "<val><numLit><ptCount val=\"2\"/><pt idx=\"0\"><v>1</v></pt><pt idx=\"1\"><v>2</v></pt></numLit></val>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestBreakExternalLinksRefs.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestIsExternalFormula(t *testing.T) {
+	for formula, expected := range map[string]bool{
+		"[1]Sheet1!A1":                       true,
+		"SUM('[2]Sheet 1'!A1:A2)":            true,
+		"[1]!Rate":                           true,
+		"'C:\\Data\\[Budget.xlsx]Sheet1'!A1": true,
+		"Sheet1!A1+Table1[Column]":           false,
+		"\"[1]Sheet1!A1\"":                   false,
+	} {
+		assert.Equal(t, expected, isExternalFormula(formula), formula)
+	}
+}
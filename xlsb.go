@@ -0,0 +1,750 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"encoding/binary"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Record types of the XLSB (BIFF12) workbook, worksheet, shared strings table
+// and styles parts.
+const (
+	xlsbRowHdr       = 0x0000
+	xlsbCellBlank    = 0x0001
+	xlsbCellRk       = 0x0002
+	xlsbCellError    = 0x0003
+	xlsbCellBool     = 0x0004
+	xlsbCellReal     = 0x0005
+	xlsbCellSt       = 0x0006
+	xlsbCellIsst     = 0x0007
+	xlsbFmlaString   = 0x0008
+	xlsbFmlaNum      = 0x0009
+	xlsbFmlaBool     = 0x000A
+	xlsbFmlaError    = 0x000B
+	xlsbSSTItem      = 0x0013
+	xlsbName         = 0x0027
+	xlsbFmt          = 0x002C
+	xlsbXF           = 0x002F
+	xlsbBundleSh     = 0x009C
+	xlsbMergeCell    = 0x00B0
+	xlsbSupSelf      = 0x0163
+	xlsbSupSame      = 0x0164
+	xlsbBeginSupBook = 0x0168
+	xlsbExternSheet  = 0x016A
+	xlsbBeginCellXFs = 0x0269
+	xlsbEndCellXFs   = 0x026A
+	xlsbSupAddin     = 0x029B
+)
+
+// xlsbErrors defined the error values of the cells and formula tokens in the
+// XLSB workbook.
+var xlsbErrors = map[byte]string{
+	0x00: formulaErrorNULL,
+	0x07: formulaErrorDIV,
+	0x0F: formulaErrorVALUE,
+	0x17: formulaErrorREF,
+	0x1D: formulaErrorNAME,
+	0x24: formulaErrorNUM,
+	0x2A: formulaErrorNA,
+	0x2B: formulaErrorGETTINGDATA,
+}
+
+// xlsbOperators defined the binary operators of the formula tokens from
+// PtgAdd (0x03) to PtgRange (0x11) in the XLSB workbook.
+var xlsbOperators = []string{"+", "-", "*", "/", "^", "&", "<", "<=", "=", ">=", ">", "<>", " ", ",", ":"}
+
+// xlsbFunction directly maps the built-in function in the formula of the XLSB
+// workbook, the args is the number of the arguments for the functions with
+// fixed arguments, or -1 for the functions with variable arguments.
+type xlsbFunction struct {
+	name string
+	args int
+}
+
+// xlsbFunctions defined the built-in functions by the function index in the
+// formula of the XLSB workbook.
+var xlsbFunctions = map[uint16]xlsbFunction{
+	0: {"COUNT", -1}, 1: {"IF", -1}, 2: {"ISNA", 1}, 3: {"ISERROR", 1}, 4: {"SUM", -1},
+	5: {"AVERAGE", -1}, 6: {"MIN", -1}, 7: {"MAX", -1}, 8: {"ROW", -1}, 9: {"COLUMN", -1},
+	10: {"NA", 0}, 11: {"NPV", -1}, 12: {"STDEV", -1}, 13: {"DOLLAR", -1}, 14: {"FIXED", -1},
+	15: {"SIN", 1}, 16: {"COS", 1}, 17: {"TAN", 1}, 18: {"ATAN", 1}, 19: {"PI", 0},
+	20: {"SQRT", 1}, 21: {"EXP", 1}, 22: {"LN", 1}, 23: {"LOG10", 1}, 24: {"ABS", 1},
+	25: {"INT", 1}, 26: {"SIGN", 1}, 27: {"ROUND", 2}, 28: {"LOOKUP", -1}, 29: {"INDEX", -1},
+	30: {"REPT", 2}, 31: {"MID", 3}, 32: {"LEN", 1}, 33: {"VALUE", 1}, 34: {"TRUE", 0},
+	35: {"FALSE", 0}, 36: {"AND", -1}, 37: {"OR", -1}, 38: {"NOT", 1}, 39: {"MOD", 2},
+	40: {"DCOUNT", 3}, 41: {"DSUM", 3}, 42: {"DAVERAGE", 3}, 43: {"DMIN", 3}, 44: {"DMAX", 3},
+	45: {"DSTDEV", 3}, 46: {"VAR", -1}, 47: {"DVAR", 3}, 48: {"TEXT", 2}, 49: {"LINEST", -1},
+	50: {"TREND", -1}, 51: {"LOGEST", -1}, 52: {"GROWTH", -1}, 56: {"PV", -1}, 57: {"FV", -1},
+	58: {"NPER", -1}, 59: {"PMT", -1}, 60: {"RATE", -1}, 61: {"MIRR", 3}, 62: {"IRR", -1},
+	63: {"RAND", 0}, 64: {"MATCH", -1}, 65: {"DATE", 3}, 66: {"TIME", 3}, 67: {"DAY", 1},
+	68: {"MONTH", 1}, 69: {"YEAR", 1}, 70: {"WEEKDAY", -1}, 71: {"HOUR", 1}, 72: {"MINUTE", 1},
+	73: {"SECOND", 1}, 74: {"NOW", 0}, 75: {"AREAS", 1}, 76: {"ROWS", 1}, 77: {"COLUMNS", 1},
+	78: {"OFFSET", -1}, 82: {"SEARCH", -1}, 83: {"TRANSPOSE", 1}, 86: {"TYPE", 1}, 97: {"ATAN2", 2},
+	98: {"ASIN", 1}, 99: {"ACOS", 1}, 100: {"CHOOSE", -1}, 101: {"HLOOKUP", -1}, 102: {"VLOOKUP", -1},
+	105: {"ISREF", 1}, 109: {"LOG", -1}, 111: {"CHAR", 1}, 112: {"LOWER", 1}, 113: {"UPPER", 1},
+	114: {"PROPER", 1}, 115: {"LEFT", -1}, 116: {"RIGHT", -1}, 117: {"EXACT", 2}, 118: {"TRIM", 1},
+	119: {"REPLACE", 4}, 120: {"SUBSTITUTE", -1}, 121: {"CODE", 1}, 124: {"FIND", -1}, 125: {"CELL", -1},
+	126: {"ISERR", 1}, 127: {"ISTEXT", 1}, 128: {"ISNUMBER", 1}, 129: {"ISBLANK", 1}, 130: {"T", 1},
+	131: {"N", 1}, 140: {"DATEVALUE", 1}, 141: {"TIMEVALUE", 1}, 142: {"SLN", 3}, 143: {"SYD", 4},
+	144: {"DDB", -1}, 148: {"INDIRECT", -1}, 162: {"CLEAN", 1}, 163: {"MDETERM", 1}, 164: {"MINVERSE", 1},
+	165: {"MMULT", 2}, 167: {"IPMT", -1}, 168: {"PPMT", -1}, 169: {"COUNTA", -1}, 183: {"PRODUCT", -1},
+	184: {"FACT", 1}, 189: {"DPRODUCT", 3}, 190: {"ISNONTEXT", 1}, 193: {"STDEVP", -1}, 194: {"VARP", -1},
+	195: {"DSTDEVP", 3}, 196: {"DVARP", 3}, 197: {"TRUNC", -1}, 198: {"ISLOGICAL", 1}, 199: {"DCOUNTA", 3},
+	212: {"ROUNDUP", 2}, 213: {"ROUNDDOWN", 2}, 216: {"RANK", -1}, 219: {"ADDRESS", -1}, 220: {"DAYS360", -1},
+	221: {"TODAY", 0}, 222: {"VDB", -1}, 227: {"MEDIAN", -1}, 228: {"SUMPRODUCT", -1}, 229: {"SINH", 1},
+	230: {"COSH", 1}, 231: {"TANH", 1}, 232: {"ASINH", 1}, 233: {"ACOSH", 1}, 234: {"ATANH", 1},
+	235: {"DGET", 3}, 244: {"INFO", 1}, 247: {"DB", -1}, 252: {"FREQUENCY", 2}, 261: {"ERROR.TYPE", 1},
+	269: {"AVEDEV", -1}, 270: {"BETADIST", -1}, 271: {"GAMMALN", 1}, 272: {"BETAINV", -1}, 273: {"BINOMDIST", 4},
+	274: {"CHIDIST", 2}, 275: {"CHIINV", 2}, 276: {"COMBIN", 2}, 277: {"CONFIDENCE", 3}, 278: {"CRITBINOM", 3},
+	279: {"EVEN", 1}, 280: {"EXPONDIST", 3}, 281: {"FDIST", 3}, 282: {"FINV", 3}, 283: {"FISHER", 1},
+	284: {"FISHERINV", 1}, 285: {"FLOOR", 2}, 286: {"GAMMADIST", 4}, 287: {"GAMMAINV", 3}, 288: {"CEILING", 2},
+	289: {"HYPGEOMDIST", 4}, 290: {"LOGNORMDIST", 3}, 291: {"LOGINV", 3}, 292: {"NEGBINOMDIST", 3}, 293: {"NORMDIST", 4},
+	294: {"NORMSDIST", 1}, 295: {"NORMINV", 3}, 296: {"NORMSINV", 1}, 297: {"STANDARDIZE", 3}, 298: {"ODD", 1},
+	299: {"PERMUT", 2}, 300: {"POISSON", 3}, 301: {"TDIST", 3}, 302: {"WEIBULL", 4}, 303: {"SUMXMY2", 2},
+	304: {"SUMX2MY2", 2}, 305: {"SUMX2PY2", 2}, 306: {"CHITEST", 2}, 307: {"CORREL", 2}, 308: {"COVAR", 2},
+	309: {"FORECAST", 3}, 310: {"FTEST", 2}, 311: {"INTERCEPT", 2}, 312: {"PEARSON", 2}, 313: {"RSQ", 2},
+	314: {"STEYX", 2}, 315: {"SLOPE", 2}, 316: {"TTEST", 4}, 317: {"PROB", -1}, 318: {"DEVSQ", -1},
+	319: {"GEOMEAN", -1}, 320: {"HARMEAN", -1}, 321: {"SUMSQ", -1}, 322: {"KURT", -1}, 323: {"SKEW", -1},
+	324: {"ZTEST", -1}, 325: {"LARGE", 2}, 326: {"SMALL", 2}, 327: {"QUARTILE", 2}, 328: {"PERCENTILE", 2},
+	329: {"PERCENTRANK", -1}, 330: {"MODE", -1}, 331: {"TRIMMEAN", 2}, 332: {"TINV", 2}, 336: {"CONCATENATE", -1},
+	337: {"POWER", 2}, 342: {"RADIANS", 1}, 343: {"DEGREES", 1}, 344: {"SUBTOTAL", -1}, 345: {"SUMIF", -1},
+	346: {"COUNTIF", 2}, 347: {"COUNTBLANK", 1}, 350: {"ISPMT", 4}, 351: {"DATEDIF", 3}, 354: {"ROMAN", -1},
+	358: {"GETPIVOTDATA", -1}, 359: {"HYPERLINK", -1}, 360: {"PHONETIC", 1}, 361: {"AVERAGEA", -1}, 362: {"MAXA", -1},
+	363: {"MINA", -1}, 364: {"STDEVPA", -1}, 365: {"VARPA", -1}, 366: {"STDEVA", -1}, 367: {"VARA", -1},
+	480: {"IFERROR", 2}, 481: {"COUNTIFS", -1}, 482: {"SUMIFS", -1}, 483: {"AVERAGEIF", -1}, 484: {"AVERAGEIFS", -1},
+}
+
+// xlsbRecord directly maps the data of the record in the XLSB workbook, the
+// ErrWorkbookFileFormat error will be recorded on reading beyond the end of
+// the record.
+type xlsbRecord struct {
+	b   []byte
+	off int
+	err error
+}
+
+// xlsbXTI directly maps the XTI structure of the BrtExternSheet record, which
+// specifies a range of the sheets in the supporting link.
+type xlsbXTI struct {
+	supBook, first, last int
+}
+
+// xlsbReader directly maps the state of converting the XLSB workbook into the
+// workbook.
+type xlsbReader struct {
+	src, file     *File
+	wbPath        string
+	sheets        []string
+	names         []string
+	supBooks      []bool
+	externSheets  []xlsbXTI
+	sharedStrings []string
+	numFmts       map[int]string
+	xfs           []int
+	styleIDs      map[int]int
+}
+
+// next provides a function to read the next record of the part, the nil
+// record will be returned at the end of the part.
+func (r *xlsbRecord) next() (int, *xlsbRecord) {
+	var typ, size int
+	for i := 0; i < 2; i++ {
+		b := r.uint8()
+		typ |= int(b&0x7F) << (7 * i)
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	for i := 0; i < 4; i++ {
+		b := r.uint8()
+		size |= int(b&0x7F) << (7 * i)
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	if data := r.read(size); r.err == nil {
+		return typ, &xlsbRecord{b: data}
+	}
+	return typ, nil
+}
+
+// read provides a function to read the given number of bytes of the record.
+func (r *xlsbRecord) read(n int) []byte {
+	if r.err != nil || n < 0 || r.off+n > len(r.b) {
+		r.err = ErrWorkbookFileFormat
+		return nil
+	}
+	r.off += n
+	return r.b[r.off-n : r.off]
+}
+
+// uint8 provides a function to read an unsigned 8-bit integer of the record.
+func (r *xlsbRecord) uint8() byte {
+	if b := r.read(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+// uint16 provides a function to read an unsigned 16-bit integer of the
+// record.
+func (r *xlsbRecord) uint16() uint16 {
+	if b := r.read(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+// uint32 provides a function to read an unsigned 32-bit integer of the
+// record.
+func (r *xlsbRecord) uint32() uint32 {
+	if b := r.read(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+// float64 provides a function to read a 64-bit floating-point number of the
+// record.
+func (r *xlsbRecord) float64() float64 {
+	if b := r.read(8); b != nil {
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	}
+	return 0
+}
+
+// string provides a function to read the UTF-16 string with the given number
+// of characters of the record.
+func (r *xlsbRecord) string(cch int) string {
+	b := r.read(cch * 2)
+	chars := make([]uint16, len(b)/2)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	return string(utf16.Decode(chars))
+}
+
+// wideString provides a function to read the XLWideString or the
+// XLNullableWideString of the record, the empty string will be returned for
+// the null string, and the error will be set if the number of the characters
+// exceeds the rest of the record.
+func (r *xlsbRecord) wideString() string {
+	cch := r.uint32()
+	if r.err != nil || cch == math.MaxUint32 {
+		return ""
+	}
+	if uint64(cch)*2 > uint64(len(r.b)-r.off) {
+		r.err = ErrWorkbookFileFormat
+		return ""
+	}
+	return r.string(int(cch))
+}
+
+// isXLSB provides a function to check if the workbook part of the package is
+// a binary part of the XLSB workbook.
+func isXLSB(wbPath string) bool {
+	return strings.HasSuffix(strings.ToLower(wbPath), ".bin")
+}
+
+// openXLSB provides a function to convert the XLSB workbook into a read-only
+// workbook by given path of the workbook part, the cell values, formulas,
+// number formats and merged cells will be converted. The source package and
+// the temporary files of it will be closed after converting.
+func (f *File) openXLSB(wbPath string) (*File, error) {
+	defer func() { _ = f.Close() }()
+	x := &xlsbReader{
+		src:      f,
+		file:     NewFile(*f.options),
+		wbPath:   wbPath,
+		numFmts:  make(map[int]string),
+		styleIDs: make(map[int]int),
+	}
+	if err := x.read(); err != nil {
+		_ = x.file.Close()
+		return nil, err
+	}
+	x.file.readOnly = true
+	return x.file, nil
+}
+
+// read provides a function to read the parts of the XLSB workbook and write
+// the worksheets into the workbook.
+func (x *xlsbReader) read() error {
+	rels, err := x.src.relsReader(x.src.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return ErrWorkbookFileFormat
+	}
+	targets := make(map[string]string)
+	for _, rel := range rels.Relationships {
+		switch rel.Type {
+		case SourceRelationshipSharedStrings:
+			err = x.readSharedStrings(x.partPath(rel.Target))
+		case SourceRelationshipStyles:
+			err = x.readStyles(x.partPath(rel.Target))
+		case SourceRelationshipWorkSheet:
+			targets[rel.ID] = x.partPath(rel.Target)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	relIDs, err := x.readWorkbook()
+	if err != nil {
+		return err
+	}
+	var sheetCount int
+	for i, name := range x.sheets {
+		target, ok := targets[relIDs[i]]
+		if !ok {
+			continue
+		}
+		if sheetCount++; sheetCount == 1 {
+			err = x.file.SetSheetName(x.file.GetSheetName(0), name)
+		} else {
+			_, err = x.file.NewSheet(name)
+		}
+		if err != nil {
+			return err
+		}
+		if err = x.readWorksheet(name, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// partPath provides a function to get the path of the part by given target
+// of the relationship of the workbook part.
+func (x *xlsbReader) partPath(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(x.wbPath), target)
+}
+
+// readWorkbook provides a function to read the sheets, defined names and
+// external sheet references of the workbook part, and returns the
+// relationship IDs of the sheets.
+func (x *xlsbReader) readWorkbook() ([]string, error) {
+	var relIDs []string
+	r := &xlsbRecord{b: x.src.readBytes(x.wbPath)}
+	for r.off < len(r.b) {
+		typ, rec := r.next()
+		if rec == nil {
+			return relIDs, r.err
+		}
+		switch typ {
+		case xlsbBundleSh:
+			rec.read(8)
+			relIDs = append(relIDs, rec.wideString())
+			x.sheets = append(x.sheets, rec.wideString())
+		case xlsbName:
+			rec.read(9)
+			x.names = append(x.names, rec.wideString())
+		case xlsbSupSelf, xlsbSupSame:
+			x.supBooks = append(x.supBooks, true)
+		case xlsbBeginSupBook, xlsbSupAddin:
+			x.supBooks = append(x.supBooks, false)
+		case xlsbExternSheet:
+			for i, count := 0, rec.uint32(); i < int(count) && rec.err == nil; i++ {
+				x.externSheets = append(x.externSheets, xlsbXTI{
+					supBook: int(rec.uint32()), first: int(int32(rec.uint32())), last: int(int32(rec.uint32())),
+				})
+			}
+		}
+		if rec.err != nil {
+			return relIDs, rec.err
+		}
+	}
+	return relIDs, nil
+}
+
+// readSharedStrings provides a function to read the shared strings table part
+// by given path of the part.
+func (x *xlsbReader) readSharedStrings(name string) error {
+	r := &xlsbRecord{b: x.src.readBytes(name)}
+	for r.off < len(r.b) {
+		typ, rec := r.next()
+		if rec == nil {
+			return r.err
+		}
+		if typ == xlsbSSTItem {
+			rec.read(1)
+			x.sharedStrings = append(x.sharedStrings, rec.wideString())
+		}
+		if rec.err != nil {
+			return rec.err
+		}
+	}
+	return nil
+}
+
+// readStyles provides a function to read the number formats and the number
+// format IDs of the cell formats in the styles part by given path of the part.
+func (x *xlsbReader) readStyles(name string) error {
+	var cellXfs bool
+	r := &xlsbRecord{b: x.src.readBytes(name)}
+	for r.off < len(r.b) {
+		typ, rec := r.next()
+		if rec == nil {
+			return r.err
+		}
+		switch typ {
+		case xlsbFmt:
+			numFmtID := int(rec.uint16())
+			x.numFmts[numFmtID] = rec.wideString()
+		case xlsbBeginCellXFs, xlsbEndCellXFs:
+			cellXfs = typ == xlsbBeginCellXFs
+		case xlsbXF:
+			if rec.read(2); cellXfs {
+				x.xfs = append(x.xfs, int(rec.uint16()))
+			}
+		}
+		if rec.err != nil {
+			return rec.err
+		}
+	}
+	return nil
+}
+
+// readWorksheet provides a function to read the cells and merged cells of the
+// worksheet part, and write them into the worksheet by the stream writer.
+func (x *xlsbReader) readWorksheet(sheet, name string) error {
+	sw, err := x.file.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+	var (
+		row        = -1
+		values     []interface{}
+		mergeCells [][]int
+		flush      = func() error {
+			if row == -1 || len(values) == 0 {
+				return nil
+			}
+			cell, err := CoordinatesToCellName(1, row+1)
+			if err != nil {
+				return err
+			}
+			return sw.SetRow(cell, values)
+		}
+	)
+	r := &xlsbRecord{b: x.src.readBytes(name)}
+	for r.off < len(r.b) {
+		typ, rec := r.next()
+		if rec == nil {
+			return r.err
+		}
+		switch typ {
+		case xlsbRowHdr:
+			if err = flush(); err != nil {
+				return err
+			}
+			row, values = int(rec.uint32()), values[:0]
+		case xlsbCellBlank, xlsbCellRk, xlsbCellError, xlsbCellBool, xlsbCellReal,
+			xlsbCellSt, xlsbCellIsst, xlsbFmlaString, xlsbFmlaNum, xlsbFmlaBool, xlsbFmlaError:
+			col, value, err := x.readCell(typ, rec)
+			if err != nil {
+				return err
+			}
+			if row == -1 || col >= MaxColumns || value == nil {
+				continue
+			}
+			for len(values) <= col {
+				values = append(values, nil)
+			}
+			values[col] = value
+		case xlsbMergeCell:
+			mergeCells = append(mergeCells, []int{int(rec.uint32()), int(rec.uint32()), int(rec.uint32()), int(rec.uint32())})
+		}
+		if rec.err != nil {
+			return rec.err
+		}
+	}
+	if err = flush(); err != nil {
+		return err
+	}
+	for _, rng := range mergeCells {
+		topLeft, err := CoordinatesToCellName(rng[2]+1, rng[0]+1)
+		if err != nil {
+			return err
+		}
+		bottomRight, err := CoordinatesToCellName(rng[3]+1, rng[1]+1)
+		if err != nil {
+			return err
+		}
+		if err = sw.MergeCell(topLeft, bottomRight); err != nil {
+			return err
+		}
+	}
+	return sw.Flush()
+}
+
+// readCell provides a function to read the cell record by given record type
+// and record, returns the zero-based column number and the cell value.
+func (x *xlsbReader) readCell(typ int, rec *xlsbRecord) (int, interface{}, error) {
+	col, xf := int(rec.uint32()), int(rec.uint32()&0xFFFFFF)
+	styleID, err := x.getStyleID(xf)
+	if err != nil {
+		return col, nil, err
+	}
+	cell := Cell{StyleID: styleID}
+	switch typ {
+	case xlsbCellBlank:
+		if styleID == 0 {
+			return col, nil, rec.err
+		}
+	case xlsbCellRk:
+		cell.Value = xlsbRK(rec.uint32())
+	case xlsbCellError, xlsbFmlaError:
+		cell.Value = xlsbErrors[rec.uint8()]
+	case xlsbCellBool, xlsbFmlaBool:
+		cell.Value = rec.uint8() != 0
+	case xlsbCellReal, xlsbFmlaNum:
+		cell.Value = rec.float64()
+	case xlsbCellSt, xlsbFmlaString:
+		cell.Value = rec.wideString()
+	case xlsbCellIsst:
+		if idx := int(rec.uint32()); idx < len(x.sharedStrings) {
+			cell.Value = x.sharedStrings[idx]
+		}
+	}
+	if typ >= xlsbFmlaString && typ <= xlsbFmlaError {
+		rec.read(2)
+		cce := int(rec.uint32())
+		if rgce := rec.read(cce); rgce != nil {
+			cell.Formula, _ = x.decodeFormula(rgce)
+		}
+	}
+	return col, cell, rec.err
+}
+
+// getStyleID provides a function to get the style index of the workbook by
+// given cell format index of the XLSB workbook, the styles only contain the
+// number formats of the cell formats.
+func (x *xlsbReader) getStyleID(xf int) (int, error) {
+	if xf >= len(x.xfs) || x.xfs[xf] == 0 {
+		return 0, nil
+	}
+	numFmtID := x.xfs[xf]
+	if styleID, ok := x.styleIDs[numFmtID]; ok {
+		return styleID, nil
+	}
+	style := &Style{}
+	if _, ok := builtInNumFmt[numFmtID]; ok {
+		style.NumFmt = numFmtID
+	} else if numFmt, ok := x.numFmts[numFmtID]; ok {
+		style.CustomNumFmt = &numFmt
+	}
+	styleID, err := x.file.NewStyle(style)
+	x.styleIDs[numFmtID] = styleID
+	return styleID, err
+}
+
+// xlsbRK provides a function to convert the RK number of the XLSB workbook
+// into the floating-point number.
+func xlsbRK(rk uint32) float64 {
+	var value float64
+	if rk&0x02 != 0 {
+		value = float64(int32(rk) >> 2)
+	} else {
+		value = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		value /= 100
+	}
+	return value
+}
+
+// decodeFormula provides a function to convert the parsed formula tokens of
+// the XLSB workbook into the formula text, the false will be returned if the
+// formula contains unsupported tokens, such as shared formulas, array
+// constants and structured references.
+func (x *xlsbReader) decodeFormula(rgce []byte) (string, bool) {
+	var (
+		stack []string
+		r     = &xlsbRecord{b: rgce}
+		pop   = func(n int) []string {
+			if n > len(stack) {
+				r.err = ErrWorkbookFileFormat
+				return make([]string, n)
+			}
+			args := append([]string{}, stack[len(stack)-n:]...)
+			stack = stack[:len(stack)-n]
+			return args
+		}
+	)
+	for r.off < len(r.b) && r.err == nil {
+		ptg := r.uint8()
+		if ptg >= 0x20 {
+			ptg = ptg&0x1F | 0x20
+		}
+		switch {
+		case ptg >= 0x03 && ptg <= 0x11:
+			args := pop(2)
+			stack = append(stack, args[0]+xlsbOperators[ptg-0x03]+args[1])
+		case ptg == 0x12:
+			stack = append(stack, "+"+pop(1)[0])
+		case ptg == 0x13:
+			stack = append(stack, "-"+pop(1)[0])
+		case ptg == 0x14:
+			stack = append(stack, pop(1)[0]+"%")
+		case ptg == 0x15:
+			stack = append(stack, "("+pop(1)[0]+")")
+		case ptg == 0x16:
+			stack = append(stack, "")
+		case ptg == 0x17:
+			stack = append(stack, "\""+strings.ReplaceAll(r.string(int(r.uint16())), "\"", "\"\"")+"\"")
+		case ptg == 0x19:
+			attr, data := r.uint8(), r.uint16()
+			if attr&0x04 != 0 {
+				r.read((int(data) + 1) * 2)
+			}
+			if attr&0x10 != 0 {
+				stack = append(stack, "SUM("+pop(1)[0]+")")
+			}
+		case ptg == 0x1C:
+			stack = append(stack, xlsbErrors[r.uint8()])
+		case ptg == 0x1D:
+			stack = append(stack, strings.ToUpper(strconv.FormatBool(r.uint8() != 0)))
+		case ptg == 0x1E:
+			stack = append(stack, strconv.Itoa(int(r.uint16())))
+		case ptg == 0x1F:
+			stack = append(stack, strconv.FormatFloat(r.float64(), 'G', -1, 64))
+		case ptg == 0x21, ptg == 0x22:
+			args, iftab := -1, uint16(0)
+			if ptg == 0x22 {
+				args = int(r.uint8())
+			}
+			if iftab = r.uint16() & 0x7FFF; iftab == 0xFF && args > 0 {
+				params := pop(args)
+				stack = append(stack, params[0]+"("+strings.Join(params[1:], ",")+")")
+				continue
+			}
+			fn, ok := xlsbFunctions[iftab]
+			if !ok || (args == -1 && fn.args == -1) {
+				return "", false
+			}
+			if args == -1 {
+				args = fn.args
+			}
+			stack = append(stack, fn.name+"("+strings.Join(pop(args), ",")+")")
+		case ptg == 0x23:
+			idx := int(r.uint32())
+			if idx < 1 || idx > len(x.names) {
+				return "", false
+			}
+			stack = append(stack, x.names[idx-1])
+		case ptg == 0x24:
+			stack = append(stack, xlsbCellRef(r.uint32(), r.uint16()))
+		case ptg == 0x25:
+			stack = append(stack, xlsbAreaRef(r.uint32(), r.uint32(), r.uint16(), r.uint16()))
+		case ptg >= 0x26 && ptg <= 0x29:
+			r.read(6)
+		case ptg == 0x2A:
+			r.read(6)
+			stack = append(stack, formulaErrorREF)
+		case ptg == 0x2B:
+			r.read(12)
+			stack = append(stack, formulaErrorREF)
+		case ptg >= 0x3A && ptg <= 0x3D:
+			prefix, ok := x.sheetPrefix(int(r.uint16()))
+			if !ok {
+				return "", false
+			}
+			ref := formulaErrorREF
+			switch ptg {
+			case 0x3A:
+				ref = xlsbCellRef(r.uint32(), r.uint16())
+			case 0x3B:
+				ref = xlsbAreaRef(r.uint32(), r.uint32(), r.uint16(), r.uint16())
+			case 0x3C:
+				r.read(6)
+			default:
+				r.read(12)
+			}
+			stack = append(stack, prefix+ref)
+		default:
+			return "", false
+		}
+	}
+	if r.err != nil || len(stack) != 1 {
+		return "", false
+	}
+	return stack[0], true
+}
+
+// sheetPrefix provides a function to get the sheet name prefix of the 3D
+// reference in the formula by given index of the external sheet references,
+// only the references to the sheets of the workbook are supported.
+func (x *xlsbReader) sheetPrefix(ixti int) (string, bool) {
+	if ixti >= len(x.externSheets) {
+		return "", false
+	}
+	xti := x.externSheets[ixti]
+	if xti.supBook >= len(x.supBooks) || !x.supBooks[xti.supBook] ||
+		xti.first < 0 || xti.last < xti.first || xti.last >= len(x.sheets) {
+		return "", false
+	}
+	first, last := x.sheets[xti.first], x.sheets[xti.last]
+	if xti.last == xti.first {
+		return quoteSheetName(first) + "!", true
+	}
+	if quoteSheetName(first) != first || quoteSheetName(last) != last {
+		return "'" + strings.ReplaceAll(first+":"+last, "'", "''") + "'!", true
+	}
+	return first + ":" + last + "!", true
+}
+
+// xlsbCellRef provides a function to convert the row and the column with the
+// relative flags of the formula token into the cell reference.
+func xlsbCellRef(row uint32, col uint16) string {
+	return xlsbColRef(col) + xlsbRowRef(row, col)
+}
+
+// xlsbAreaRef provides a function to convert the rows and the columns with
+// the relative flags of the formula token into the range reference, the
+// references of the entire columns or rows will be returned without the rows
+// or columns.
+func xlsbAreaRef(rowFirst, rowLast uint32, colFirst, colLast uint16) string {
+	if rowFirst == 0 && rowLast == TotalRows-1 {
+		return xlsbColRef(colFirst) + ":" + xlsbColRef(colLast)
+	}
+	if colFirst&0x3FFF == 0 && colLast&0x3FFF == MaxColumns-1 {
+		return xlsbRowRef(rowFirst, colFirst) + ":" + xlsbRowRef(rowLast, colLast)
+	}
+	return xlsbCellRef(rowFirst, colFirst) + ":" + xlsbCellRef(rowLast, colLast)
+}
+
+// xlsbColRef provides a function to convert the column with the relative
+// flags of the formula token into the column reference.
+func xlsbColRef(col uint16) string {
+	name, _ := ColumnNumberToName(int(col&0x3FFF) + 1)
+	if col&0x4000 == 0 {
+		return "$" + name
+	}
+	return name
+}
+
+// xlsbRowRef provides a function to convert the row with the relative flags
+// of the formula token into the row reference.
+func xlsbRowRef(row uint32, col uint16) string {
+	if col&0x8000 == 0 {
+		return "$" + strconv.Itoa(int(row)+1)
+	}
+	return strconv.Itoa(int(row) + 1)
+}
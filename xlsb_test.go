@@ -0,0 +1,264 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// xlsbTestRecord provides a function to encode the XLSB record by given
+// record type and data.
+func xlsbTestRecord(typ int, data ...[]byte) []byte {
+	var b []byte
+	for typ >= 0x80 {
+		b, typ = append(b, byte(typ&0x7F|0x80)), typ>>7
+	}
+	b = append(b, byte(typ))
+	body := bytes.Join(data, nil)
+	size := len(body)
+	for size >= 0x80 {
+		b, size = append(b, byte(size&0x7F|0x80)), size>>7
+	}
+	return append(append(b, byte(size)), body...)
+}
+
+func xlsbTestUint16(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.LittleEndian.PutUint16(b, v)
+	return b
+}
+
+func xlsbTestUint32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	return b
+}
+
+func xlsbTestFloat64(v float64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+	return b
+}
+
+func xlsbTestString(s string) []byte {
+	b := xlsbTestUint32(uint32(len(utf16.Encode([]rune(s)))))
+	for _, c := range utf16.Encode([]rune(s)) {
+		b = append(b, xlsbTestUint16(c)...)
+	}
+	return b
+}
+
+func xlsbTestCell(col, xf uint32) []byte {
+	return append(xlsbTestUint32(col), xlsbTestUint32(xf)...)
+}
+
+func xlsbTestFormula(rgce ...[]byte) []byte {
+	b := bytes.Join(rgce, nil)
+	return bytes.Join([][]byte{xlsbTestUint16(0), xlsbTestUint32(uint32(len(b))), b, xlsbTestUint32(0)}, nil)
+}
+
+// prepareXLSB provides a function to create the XLSB package by given parts.
+func prepareXLSB(t *testing.T, parts map[string][]byte) *bytes.Buffer {
+	files := map[string][]byte{
+		"_rels/.rels":                []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.bin"/></Relationships>`),
+		"xl/_rels/workbook.bin.rels": []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.bin"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.bin"/><Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.bin"/><Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.bin"/></Relationships>`),
+	}
+	for name, content := range parts {
+		files[name] = content
+	}
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range files {
+		fw, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = fw.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf
+}
+
+func TestOpenXLSB(t *testing.T) {
+	workbook := bytes.Join([][]byte{
+		xlsbTestRecord(xlsbBundleSh, xlsbTestUint32(0), xlsbTestUint32(1), xlsbTestString("rId1"), xlsbTestString("Data")),
+		xlsbTestRecord(xlsbBundleSh, xlsbTestUint32(0), xlsbTestUint32(2), xlsbTestString("rId2"), xlsbTestString("Other Sheet")),
+		xlsbTestRecord(xlsbName, xlsbTestUint32(0), []byte{0}, xlsbTestUint32(math.MaxUint32), xlsbTestString("Rate")),
+		xlsbTestRecord(xlsbSupSelf),
+		xlsbTestRecord(xlsbExternSheet, xlsbTestUint32(1), xlsbTestUint32(0), xlsbTestUint32(1), xlsbTestUint32(1)),
+	}, nil)
+	sharedStrings := xlsbTestRecord(xlsbSSTItem, []byte{0}, xlsbTestString("Hello"))
+	styles := bytes.Join([][]byte{
+		xlsbTestRecord(xlsbFmt, xlsbTestUint16(164), xlsbTestString("0.0%")),
+		xlsbTestRecord(xlsbXF, xlsbTestUint16(0), xlsbTestUint16(10)),
+		xlsbTestRecord(xlsbBeginCellXFs, xlsbTestUint32(3)),
+		xlsbTestRecord(xlsbXF, xlsbTestUint16(0), xlsbTestUint16(0)),
+		xlsbTestRecord(xlsbXF, xlsbTestUint16(0), xlsbTestUint16(14)),
+		xlsbTestRecord(xlsbXF, xlsbTestUint16(0), xlsbTestUint16(164)),
+		xlsbTestRecord(xlsbEndCellXFs),
+	}, nil)
+	// SUM($B$1:C1)*2+'Other Sheet'!A1
+	sumFormula := xlsbTestFormula(
+		[]byte{0x25}, xlsbTestUint32(0), xlsbTestUint32(0), xlsbTestUint16(1), xlsbTestUint16(2|0xC000),
+		[]byte{0x22, 1}, xlsbTestUint16(4), []byte{0x1E}, xlsbTestUint16(2), []byte{0x05},
+		[]byte{0x3A}, xlsbTestUint16(0), xlsbTestUint32(0), xlsbTestUint16(0xC000), []byte{0x03},
+	)
+	// "a"&"b"""
+	strFormula := xlsbTestFormula([]byte{0x17}, xlsbTestUint16(1), xlsbTestUint16('a'), []byte{0x17}, xlsbTestUint16(2), xlsbTestUint16('b'), xlsbTestUint16('"'), []byte{0x08})
+	// Rate>0.5
+	boolFormula := xlsbTestFormula([]byte{0x43}, xlsbTestUint32(1), []byte{0x1F}, xlsbTestFloat64(0.5), []byte{0x0D})
+	sheet1 := bytes.Join([][]byte{
+		xlsbTestRecord(xlsbRowHdr, xlsbTestUint32(0), make([]byte, 13)),
+		xlsbTestRecord(xlsbCellIsst, xlsbTestCell(0, 0), xlsbTestUint32(0)),
+		xlsbTestRecord(xlsbCellReal, xlsbTestCell(1, 0), xlsbTestFloat64(1.5)),
+		xlsbTestRecord(xlsbCellRk, xlsbTestCell(2, 0), xlsbTestUint32(100<<2|0x02)),
+		xlsbTestRecord(xlsbCellBool, xlsbTestCell(3, 0), []byte{1}),
+		xlsbTestRecord(xlsbCellError, xlsbTestCell(4, 0), []byte{0x07}),
+		xlsbTestRecord(xlsbCellSt, xlsbTestCell(5, 0), xlsbTestString("inline")),
+		xlsbTestRecord(xlsbCellBlank, xlsbTestCell(6, 0)),
+		xlsbTestRecord(xlsbRowHdr, xlsbTestUint32(1), make([]byte, 13)),
+		xlsbTestRecord(xlsbCellReal, xlsbTestCell(0, 1), xlsbTestFloat64(45047)),
+		xlsbTestRecord(xlsbCellRk, xlsbTestCell(1, 0), xlsbTestUint32(1234<<2|0x03)),
+		xlsbTestRecord(xlsbCellReal, xlsbTestCell(2, 2), xlsbTestFloat64(0.125)),
+		xlsbTestRecord(xlsbFmlaNum, xlsbTestCell(3, 0), xlsbTestFloat64(3), sumFormula),
+		xlsbTestRecord(xlsbFmlaString, xlsbTestCell(4, 0), xlsbTestString(`ab"`), strFormula),
+		xlsbTestRecord(xlsbFmlaBool, xlsbTestCell(5, 0), []byte{1}, boolFormula),
+		xlsbTestRecord(xlsbFmlaError, xlsbTestCell(6, 0), []byte{0x2A}, xlsbTestFormula([]byte{0x41}, xlsbTestUint16(10))),
+		xlsbTestRecord(xlsbFmlaNum, xlsbTestCell(7, 0), xlsbTestFloat64(7), xlsbTestFormula([]byte{0x01}, xlsbTestUint32(0))),
+		xlsbTestRecord(xlsbCellRk, xlsbTestCell(8, 1), xlsbTestUint32(uint32(math.Float64bits(0.5)>>32))),
+		xlsbTestRecord(xlsbRowHdr, xlsbTestUint32(2), make([]byte, 13)),
+		xlsbTestRecord(xlsbCellSt, xlsbTestCell(0, 0), xlsbTestString("merged")),
+		xlsbTestRecord(xlsbMergeCell, xlsbTestUint32(2), xlsbTestUint32(3), xlsbTestUint32(0), xlsbTestUint32(1)),
+	}, nil)
+	sheet2 := bytes.Join([][]byte{
+		xlsbTestRecord(xlsbRowHdr, xlsbTestUint32(0), make([]byte, 13)),
+		xlsbTestRecord(xlsbCellRk, xlsbTestCell(0, 0), xlsbTestUint32(1<<2|0x02)),
+	}, nil)
+	parts := map[string][]byte{
+		"xl/workbook.bin":          workbook,
+		"xl/sharedStrings.bin":     sharedStrings,
+		"xl/styles.bin":            styles,
+		"xl/worksheets/sheet1.bin": sheet1,
+		"xl/worksheets/sheet2.bin": sheet2,
+	}
+	f, err := OpenReader(prepareXLSB(t, parts))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Data", "Other Sheet"}, f.GetSheetList())
+	rows, err := f.GetRows("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Hello", "1.5", "100", "TRUE", "#DIV/0!", "inline"},
+		{"05-01-23", "12.34", "12.5%", "3", `ab"`, "TRUE", "#N/A", "7", "12-30-99"},
+		{"merged"},
+	}, rows)
+	for cell, expected := range map[string]string{
+		"D2": "SUM($B$1:C1)*2+'Other Sheet'!A1",
+		"E2": `"a"&"b"""`,
+		"F2": "Rate>0.5",
+		"G2": "NA()",
+		"H2": "",
+	} {
+		formula, err := f.GetCellFormula("Data", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	raw, err := f.GetCellValue("Data", "I2", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.5", raw)
+	mergeCells, err := f.GetMergeCells("Data")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A3", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "B4", mergeCells[0].GetEndAxis())
+	cellValue, err := f.GetCellValue("Other Sheet", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", cellValue)
+	// Test save the workbook opened from the XLSB file
+	assert.Equal(t, ErrWorkbookReadOnly{Func: "WriteTo"}, f.Write(new(bytes.Buffer)))
+	assert.Equal(t, ErrWorkbookReadOnly{Func: "SaveAs"}, f.SaveAs("Book1.xlsx"))
+	_, err = f.WriteToBuffer()
+	assert.Equal(t, ErrWorkbookReadOnly{Func: "WriteToBuffer"}, err)
+	// Test modify the workbook opened from the XLSB file
+	assert.EqualError(t, f.SetCellValue("Data", "A1", 1), "unsupported SetCellValue on the read-only workbook")
+	assert.True(t, errors.As(f.MergeCell("Data", "A1", "B1"), &ErrWorkbookReadOnly{}))
+	assert.True(t, errors.As(f.SetSheetName("Data", "Sheet1"), &ErrWorkbookReadOnly{}))
+	assert.True(t, errors.As(f.DeleteSheet("Data"), &ErrWorkbookReadOnly{}))
+	idx, err := f.NewSheet("Sheet2")
+	assert.Equal(t, -1, idx)
+	assert.True(t, errors.As(err, &ErrWorkbookReadOnly{}))
+	_, err = f.NewStyle(&Style{})
+	assert.True(t, errors.As(err, &ErrWorkbookReadOnly{}))
+	_, err = f.NewStreamWriter("Data")
+	assert.True(t, errors.As(err, &ErrWorkbookReadOnly{}))
+	active := f.GetActiveSheetIndex()
+	f.SetActiveSheet(active + 1)
+	assert.Equal(t, active, f.GetActiveSheetIndex())
+	cellValue, err = f.GetCellValue("Data", "A1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "1", cellValue)
+	assert.NoError(t, f.Close())
+
+	// Test open the XLSB file with truncated records
+	for _, part := range []struct {
+		name    string
+		content []byte
+	}{
+		{"xl/workbook.bin", xlsbTestRecord(xlsbBundleSh, xlsbTestUint32(0))},
+		{"xl/sharedStrings.bin", xlsbTestRecord(xlsbSSTItem)},
+		{"xl/sharedStrings.bin", xlsbTestRecord(xlsbSSTItem, []byte{0}, xlsbTestUint32(100), xlsbTestUint16('a'))},
+		{"xl/styles.bin", xlsbTestRecord(xlsbFmt, []byte{0})},
+		{"xl/worksheets/sheet1.bin", xlsbTestRecord(xlsbCellReal, xlsbTestCell(0, 0))},
+		{"xl/worksheets/sheet2.bin", []byte{0x85}},
+	} {
+		invalid := make(map[string][]byte, len(parts))
+		for k, v := range parts {
+			invalid[k] = v
+		}
+		invalid[part.name] = part.content
+		_, err = OpenReader(prepareXLSB(t, invalid))
+		assert.Equal(t, ErrWorkbookFileFormat, err, part.name)
+	}
+}
+
+func TestDecodeXLSBFormula(t *testing.T) {
+	x := &xlsbReader{sheets: []string{"Sheet1", "Sheet2", "Sheet 3"}, supBooks: []bool{false, true}, externSheets: []xlsbXTI{{0, 0, 0}, {1, 0, 1}, {1, -1, -1}, {1, 1, 2}}}
+	for expected, rgce := range map[string][]byte{
+		"$A:B":                    bytes.Join([][]byte{{0x25}, xlsbTestUint32(0), xlsbTestUint32(TotalRows - 1), xlsbTestUint16(0), xlsbTestUint16(1 | 0x4000)}, nil),
+		"1:$2":                    bytes.Join([][]byte{{0x25}, xlsbTestUint32(0), xlsbTestUint32(1), xlsbTestUint16(0x8000), xlsbTestUint16(MaxColumns - 1)}, nil),
+		"SUM(A1)":                 bytes.Join([][]byte{{0x24}, xlsbTestUint32(0), xlsbTestUint16(0xC000), {0x19, 0x10}, xlsbTestUint16(0)}, nil),
+		"-(1%)":                   bytes.Join([][]byte{{0x1E}, xlsbTestUint16(1), {0x14, 0x15, 0x13}}, nil),
+		"+#REF!":                  bytes.Join([][]byte{{0x2A}, make([]byte, 6), {0x12}}, nil),
+		"Sheet1:Sheet2!$A$1:$A$2": bytes.Join([][]byte{{0x3B}, xlsbTestUint16(1), xlsbTestUint32(0), xlsbTestUint32(1), xlsbTestUint16(0), xlsbTestUint16(0)}, nil),
+		"Sheet1:Sheet2!#REF!":     bytes.Join([][]byte{{0x3C}, xlsbTestUint16(1), make([]byte, 6)}, nil),
+		"'Sheet2:Sheet 3'!#REF!":  bytes.Join([][]byte{{0x3D}, xlsbTestUint16(3), make([]byte, 12)}, nil),
+		"MYFUNC(1,)":              bytes.Join([][]byte{{0x23}, xlsbTestUint32(1), {0x1E}, xlsbTestUint16(1), {0x16, 0x22, 3}, xlsbTestUint16(0xFF)}, nil),
+		"CHOOSE(1,TRUE)":          bytes.Join([][]byte{{0x1E}, xlsbTestUint16(1), {0x19, 0x04}, xlsbTestUint16(0), make([]byte, 2), {0x1D, 1, 0x42, 2}, xlsbTestUint16(100)}, nil),
+		"1E+20":                   bytes.Join([][]byte{{0x1F}, xlsbTestFloat64(1e20)}, nil),
+	} {
+		x.names = []string{"MYFUNC"}
+		formula, ok := x.decodeFormula(rgce)
+		assert.True(t, ok, expected)
+		assert.Equal(t, expected, formula)
+	}
+	for _, rgce := range [][]byte{
+		{0x01, 0, 0, 0, 0},
+		{0x18},
+		{0x03},
+		{0x1E},
+		{0x21, 0xFF, 0x0F},
+		{0x21, 0x04, 0x00},
+		{0x23, 0x02, 0x00, 0x00, 0x00},
+		bytes.Join([][]byte{{0x3A}, xlsbTestUint16(0), xlsbTestUint32(0), xlsbTestUint16(0)}, nil),
+		bytes.Join([][]byte{{0x3A}, xlsbTestUint16(2), xlsbTestUint32(0), xlsbTestUint16(0)}, nil),
+		bytes.Join([][]byte{{0x3A}, xlsbTestUint16(4), xlsbTestUint32(0), xlsbTestUint16(0)}, nil),
+		bytes.Join([][]byte{{0x1E}, xlsbTestUint16(1), {0x1E}, xlsbTestUint16(1)}, nil),
+	} {
+		_, ok := x.decodeFormula(rgce)
+		assert.False(t, ok, rgce)
+	}
+}
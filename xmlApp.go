@@ -19,6 +19,8 @@ type AppProperties struct {
 	ScaleCrop         bool
 	DocSecurity       int
 	Company           string
+	Manager           string
+	HyperlinkBase     string
 	LinksUpToDate     bool
 	HyperlinksChanged bool
 	AppVersion        string
@@ -73,3 +75,29 @@ type xlsxVectorLpstr struct {
 type xlsxDigSig struct {
 	Content string `xml:",innerxml"`
 }
+
+// xlsxCustomProperties directly maps the root element for a part of this
+// content type shall custom properties, which contains the custom properties
+// of the document.
+type xlsxCustomProperties struct {
+	XMLName  xml.Name             `xml:"http://schemas.openxmlformats.org/officeDocument/2006/custom-properties Properties"`
+	Vt       string               `xml:"xmlns:vt,attr"`
+	Property []xlsxCustomProperty `xml:"property"`
+}
+
+// xlsxCustomProperty directly maps the property element of the custom
+// properties, which specifies a single custom property with an unique
+// property ID and name.
+type xlsxCustomProperty struct {
+	FmtID string                  `xml:"fmtid,attr"`
+	PID   int                     `xml:"pid,attr"`
+	Name  string                  `xml:"name,attr,omitempty"`
+	Value xlsxCustomPropertyValue `xml:",any"`
+}
+
+// xlsxCustomPropertyValue directly maps the variant type value element of the
+// custom property, such as vt:lpwstr, vt:i4, vt:r8, vt:bool and vt:filetime.
+type xlsxCustomPropertyValue struct {
+	XMLName xml.Name
+	Content string `xml:",chardata"`
+}
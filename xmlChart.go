@@ -56,12 +56,27 @@ type cChart struct {
 // title.
 type cTitle struct {
 	Tx      cTx          `xml:"tx,omitempty"`
-	Layout  string       `xml:"layout,omitempty"`
+	Layout  *cLayout     `xml:"layout"`
 	Overlay *attrValBool `xml:"overlay"`
 	SpPr    cSpPr        `xml:"spPr,omitempty"`
 	TxPr    cTxPr        `xml:"txPr,omitempty"`
 }
 
+// cLayout (Layout) directly maps the layout element. This element specifies
+// how the chart element is placed on the chart.
+type cLayout struct {
+	ManualLayout *cManualLayout `xml:"manualLayout"`
+}
+
+// cManualLayout (Manual Layout) directly maps the manualLayout element. This
+// element specifies the exact position of a chart element.
+type cManualLayout struct {
+	XMode *attrValString `xml:"xMode"`
+	YMode *attrValString `xml:"yMode"`
+	X     *attrValFloat  `xml:"x"`
+	Y     *attrValFloat  `xml:"y"`
+}
+
 // cTx (Chart Text) directly maps the tx element. This element specifies text
 // to use on a chart, including rich text formatting.
 type cTx struct {
@@ -360,6 +375,7 @@ type cAxs struct {
 	TxPr           *cTxPr         `xml:"txPr"`
 	CrossAx        *attrValInt    `xml:"crossAx"`
 	Crosses        *attrValString `xml:"crosses"`
+	CrossesAt      *attrValFloat  `xml:"crossesAt"`
 	CrossBetween   *attrValString `xml:"crossBetween"`
 	MajorUnit      *attrValFloat  `xml:"majorUnit"`
 	MinorUnit      *attrValFloat  `xml:"minorUnit"`
@@ -482,14 +498,19 @@ type cNumCache struct {
 // entire series or the entire chart. It contains child elements that specify
 // the specific formatting and positioning settings.
 type cDLbls struct {
-	NumFmt          *cNumFmt     `xml:"numFmt"`
-	ShowLegendKey   *attrValBool `xml:"showLegendKey"`
-	ShowVal         *attrValBool `xml:"showVal"`
-	ShowCatName     *attrValBool `xml:"showCatName"`
-	ShowSerName     *attrValBool `xml:"showSerName"`
-	ShowPercent     *attrValBool `xml:"showPercent"`
-	ShowBubbleSize  *attrValBool `xml:"showBubbleSize"`
-	ShowLeaderLines *attrValBool `xml:"showLeaderLines"`
+	NumFmt          *cNumFmt       `xml:"numFmt"`
+	TxPr            *cTxPr         `xml:"txPr"`
+	DLblPos         *attrValString `xml:"dLblPos"`
+	ShowLegendKey   *attrValBool   `xml:"showLegendKey"`
+	ShowVal         *attrValBool   `xml:"showVal"`
+	ShowCatName     *attrValBool   `xml:"showCatName"`
+	ShowSerName     *attrValBool   `xml:"showSerName"`
+	ShowPercent     *attrValBool   `xml:"showPercent"`
+	ShowBubbleSize  *attrValBool   `xml:"showBubbleSize"`
+	Separator       *string        `xml:"separator"`
+	ShowLeaderLines *attrValBool   `xml:"showLeaderLines"`
+	LeaderLines     *cChartLines   `xml:"leaderLines"`
+	ExtLst          *xlsxExtLst    `xml:"extLst"`
 }
 
 // cLegend (Legend) directly maps the legend element. This element specifies
@@ -523,88 +544,132 @@ type cPageMargins struct {
 
 // ChartNumFmt directly maps the number format settings of the chart.
 type ChartNumFmt struct {
-	CustomNumFmt string
-	SourceLinked bool
+	CustomNumFmt string `json:"customNumFmt,omitempty"`
+	SourceLinked bool   `json:"sourceLinked,omitempty"`
 }
 
 // ChartAxis directly maps the format settings of the chart axis.
 type ChartAxis struct {
-	None           bool
-	MajorGridLines bool
-	MinorGridLines bool
-	MajorUnit      float64
-	TickLabelSkip  int
-	ReverseOrder   bool
-	Secondary      bool
-	Maximum        *float64
-	Minimum        *float64
-	Font           Font
-	LogBase        float64
-	NumFmt         ChartNumFmt
-	Title          []RichTextRun
+	None           bool          `json:"none,omitempty"`
+	MajorGridLines bool          `json:"majorGridLines,omitempty"`
+	MinorGridLines bool          `json:"minorGridLines,omitempty"`
+	MajorUnit      float64       `json:"majorUnit,omitempty"`
+	TickLabelSkip  int           `json:"tickLabelSkip,omitempty"`
+	ReverseOrder   bool          `json:"reverseOrder,omitempty"`
+	Secondary      bool          `json:"secondary,omitempty"`
+	Maximum        *float64      `json:"maximum,omitempty"`
+	Minimum        *float64      `json:"minimum,omitempty"`
+	Font           Font          `json:"font,omitempty"`
+	LogBase        float64       `json:"logBase,omitempty"`
+	NumFmt         ChartNumFmt   `json:"numFmt,omitempty"`
+	Title          []RichTextRun `json:"title,omitempty"`
+	Crosses        string        `json:"crosses,omitempty"`
+	CrossesAt      float64       `json:"crossesAt,omitempty"`
+	AxisPosition   string        `json:"axisPosition,omitempty"`
+	MajorTickMark  string        `json:"majorTickMark,omitempty"`
+	MinorTickMark  string        `json:"minorTickMark,omitempty"`
 	axID           int
 }
 
 // ChartDimension directly maps the dimension of the chart.
 type ChartDimension struct {
-	Width  uint
-	Height uint
+	Width  uint `json:"width,omitempty"`
+	Height uint `json:"height,omitempty"`
 }
 
 // ChartPlotArea directly maps the format settings of the plot area.
 type ChartPlotArea struct {
-	SecondPlotValues int
-	ShowBubbleSize   bool
-	ShowCatName      bool
-	ShowLeaderLines  bool
-	ShowPercent      bool
-	ShowSerName      bool
-	ShowVal          bool
-	NumFmt           ChartNumFmt
+	SecondPlotValues int         `json:"secondPlotValues,omitempty"`
+	Separator        string      `json:"separator,omitempty"`
+	ShowBubbleSize   bool        `json:"showBubbleSize,omitempty"`
+	ShowCatName      bool        `json:"showCatName,omitempty"`
+	ShowLeaderLines  bool        `json:"showLeaderLines,omitempty"`
+	ShowPercent      bool        `json:"showPercent,omitempty"`
+	ShowSerName      bool        `json:"showSerName,omitempty"`
+	ShowVal          bool        `json:"showVal,omitempty"`
+	NumFmt           ChartNumFmt `json:"numFmt,omitempty"`
 }
 
 // Chart directly maps the format settings of the chart.
 type Chart struct {
-	Type         ChartType
-	Series       []ChartSeries
-	Format       GraphicOptions
-	Dimension    ChartDimension
-	Legend       ChartLegend
-	Title        []RichTextRun
-	VaryColors   *bool
-	XAxis        ChartAxis
-	YAxis        ChartAxis
-	PlotArea     ChartPlotArea
-	ShowBlanksAs string
-	HoleSize     int
+	Type         ChartType      `json:"type"`
+	Series       []ChartSeries  `json:"series,omitempty"`
+	Format       GraphicOptions `json:"format,omitempty"`
+	Dimension    ChartDimension `json:"dimension,omitempty"`
+	Legend       ChartLegend    `json:"legend,omitempty"`
+	Title        []RichTextRun  `json:"title,omitempty"`
+	TitleLayout  ChartLayout    `json:"titleLayout,omitempty"`
+	VaryColors   *bool          `json:"varyColors,omitempty"`
+	XAxis        ChartAxis      `json:"xAxis,omitempty"`
+	YAxis        ChartAxis      `json:"yAxis,omitempty"`
+	PlotArea     ChartPlotArea  `json:"plotArea,omitempty"`
+	ShowBlanksAs string         `json:"showBlanksAs,omitempty"`
+	HoleSize     int            `json:"holeSize,omitempty"`
 	order        int
+	seriesOrder  []int
 }
 
 // ChartLegend directly maps the format settings of the chart legend.
 type ChartLegend struct {
-	Position      string
-	ShowLegendKey bool
+	Position      string      `json:"position,omitempty"`
+	ShowLegendKey bool        `json:"showLegendKey,omitempty"`
+	Overlay       bool        `json:"overlay,omitempty"`
+	Font          Font        `json:"font,omitempty"`
+	Fill          Fill        `json:"fill,omitempty"`
+	Border        ChartBorder `json:"border,omitempty"`
+}
+
+// ChartLayout directly maps the layout settings of the chart elements. The X
+// and Y specifies the position of the element as the fractions of the chart
+// width and height from the top-left corner of the chart.
+type ChartLayout struct {
+	Overlay bool     `json:"overlay,omitempty"`
+	X       *float64 `json:"x,omitempty"`
+	Y       *float64 `json:"y,omitempty"`
+}
+
+// ChartBorder directly maps the border format settings of the chart elements.
+type ChartBorder struct {
+	Color string  `json:"color,omitempty"`
+	Width float64 `json:"width,omitempty"`
 }
 
 // ChartMarker directly maps the format settings of the chart marker.
 type ChartMarker struct {
-	Symbol string
-	Size   int
+	Symbol string      `json:"symbol,omitempty"`
+	Size   int         `json:"size,omitempty"`
+	Fill   Fill        `json:"fill,omitempty"`
+	Border ChartBorder `json:"border,omitempty"`
 }
 
 // ChartLine directly maps the format settings of the chart line.
 type ChartLine struct {
-	Smooth bool
-	Width  float64
+	Smooth bool    `json:"smooth,omitempty"`
+	Width  float64 `json:"width,omitempty"`
 }
 
 // ChartSeries directly maps the format settings of the chart series.
 type ChartSeries struct {
-	Name       string
-	Categories string
-	Sizes      string
-	Values     string
-	Fill       Fill
-	Line       ChartLine
-	Marker     ChartMarker
+	Name       string         `json:"name,omitempty"`
+	Categories string         `json:"categories,omitempty"`
+	Sizes      string         `json:"sizes,omitempty"`
+	Values     string         `json:"values,omitempty"`
+	Fill       Fill           `json:"fill,omitempty"`
+	Line       ChartLine      `json:"line,omitempty"`
+	Marker     ChartMarker    `json:"marker,omitempty"`
+	Order      *int           `json:"order,omitempty"`
+	DataLabel  ChartDataLabel `json:"dataLabel,omitempty"`
+}
+
+// ChartDataLabel directly maps the format settings of the data labels of the
+// chart series.
+type ChartDataLabel struct {
+	NumFmt         ChartNumFmt `json:"numFmt,omitempty"`
+	Position       string      `json:"position,omitempty"`
+	Font           Font        `json:"font,omitempty"`
+	ShowBubbleSize *bool       `json:"showBubbleSize,omitempty"`
+	ShowCatName    *bool       `json:"showCatName,omitempty"`
+	ShowPercent    *bool       `json:"showPercent,omitempty"`
+	ShowSerName    *bool       `json:"showSerName,omitempty"`
+	ShowVal        *bool       `json:"showVal,omitempty"`
 }
@@ -26,6 +26,7 @@ type xlsxComments struct {
 	XMLName     xml.Name        `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main comments"`
 	Authors     xlsxAuthor      `xml:"authors"`
 	CommentList xlsxCommentList `xml:"commentList"`
+	ExtLst      *xlsxExtLst     `xml:"extLst"`
 }
 
 // xlsxAuthor directly maps the author element. This element holds a string
@@ -72,11 +73,13 @@ type xlsxPhoneticRun struct {
 	T  string `xml:"t"`
 }
 
-// Comment directly maps the comment information.
+// Comment directly maps the comment information. Set ShowComment to true to
+// make the comment always visible in the worksheet.
 type Comment struct {
-	Author    string
-	AuthorID  int
-	Cell      string
-	Text      string
-	Paragraph []RichTextRun
+	Author      string
+	AuthorID    int
+	Cell        string
+	Text        string
+	Paragraph   []RichTextRun
+	ShowComment bool
 }
@@ -23,6 +23,8 @@ var (
 	NameSpaceDrawing2016SVG                 = xml.Attr{Name: xml.Name{Local: "asvg", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2016/SVG/main"}
 	NameSpaceDrawingML                      = xml.Attr{Name: xml.Name{Local: "a", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/main"}
 	NameSpaceDrawingMLChart                 = xml.Attr{Name: xml.Name{Local: "c", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/chart"}
+	NameSpaceDrawingMLChart2012             = xml.Attr{Name: xml.Name{Local: "c15", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2012/chart"}
+	NameSpaceDynamicArray                   = xml.Attr{Name: xml.Name{Local: "xda", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray"}
 	NameSpaceDrawingMLSpreadSheet           = xml.Attr{Name: xml.Name{Local: "xdr", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"}
 	NameSpaceMacExcel2008Main               = xml.Attr{Name: xml.Name{Local: "mx", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/mac/excel/2008/main"}
 	NameSpaceSpreadSheet                    = xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: "http://schemas.openxmlformats.org/spreadsheetml/2006/main"}
@@ -39,12 +41,16 @@ var (
 // Source relationship and namespace.
 const (
 	ContentTypeAddinMacro                         = "application/vnd.ms-excel.addin.macroEnabled.main+xml"
+	ContentTypeCustomProperties                   = "application/vnd.openxmlformats-officedocument.custom-properties+xml"
 	ContentTypeDrawing                            = "application/vnd.openxmlformats-officedocument.drawing+xml"
 	ContentTypeDrawingML                          = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
 	ContentTypeMacro                              = "application/vnd.ms-excel.sheet.macroEnabled.main+xml"
+	ContentTypeOLEObject                          = "application/vnd.openxmlformats-officedocument.oleObject"
 	ContentTypeSheetML                            = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
+	ContentTypeSheetMetadata                      = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheetMetadata+xml"
 	ContentTypeSpreadSheetMLChartsheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.chartsheet+xml"
 	ContentTypeSpreadSheetMLComments              = "application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"
+	ContentTypeSpreadSheetMLExternalLink          = "application/vnd.openxmlformats-officedocument.spreadsheetml.externalLink+xml"
 	ContentTypeSpreadSheetMLPivotCacheDefinition  = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheDefinition+xml"
 	ContentTypeSpreadSheetMLPivotTable            = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotTable+xml"
 	ContentTypeSpreadSheetMLSharedStrings         = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
@@ -52,8 +58,10 @@ const (
 	ContentTypeSpreadSheetMLWorksheet             = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
 	ContentTypeTemplate                           = "application/vnd.openxmlformats-officedocument.spreadsheetml.template.main+xml"
 	ContentTypeTemplateMacro                      = "application/vnd.ms-excel.template.macroEnabled.main+xml"
+	ContentTypeTheme                              = "application/vnd.openxmlformats-officedocument.theme+xml"
 	ContentTypeVBA                                = "application/vnd.ms-office.vbaProject"
 	ContentTypeVML                                = "application/vnd.openxmlformats-officedocument.vmlDrawing"
+	NameSpaceCustomProperties                     = "http://schemas.openxmlformats.org/officeDocument/2006/custom-properties"
 	NameSpaceDrawingMLMain                        = "http://schemas.openxmlformats.org/drawingml/2006/main"
 	NameSpaceDublinCore                           = "http://purl.org/dc/elements/1.1/"
 	NameSpaceDublinCoreMetadataInitiative         = "http://purl.org/dc/dcmitype/"
@@ -64,17 +72,24 @@ const (
 	SourceRelationshipChart                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
 	SourceRelationshipChartsheet                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet"
 	SourceRelationshipComments                    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
+	SourceRelationshipCustomProperties            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/custom-properties"
 	SourceRelationshipDialogsheet                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet"
 	SourceRelationshipDrawingML                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing"
 	SourceRelationshipDrawingVML                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing"
 	SourceRelationshipExtendProperties            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties"
+	SourceRelationshipExternalLink                = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/externalLink"
+	SourceRelationshipExternalLinkPath            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/externalLinkPath"
 	SourceRelationshipHyperLink                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
 	SourceRelationshipImage                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
 	SourceRelationshipOfficeDocument              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	SourceRelationshipOLEObject                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/oleObject"
 	SourceRelationshipPivotCache                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
 	SourceRelationshipPivotTable                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
 	SourceRelationshipSharedStrings               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
+	SourceRelationshipSheetMetadata               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sheetMetadata"
+	SourceRelationshipStyles                      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
 	SourceRelationshipTable                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
+	SourceRelationshipTheme                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme"
 	SourceRelationshipVBAProject                  = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
 	SourceRelationshipWorkSheet                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
 	StrictNameSpaceDocumentPropertiesVariantTypes = "http://purl.oclc.org/ooxml/officeDocument/docPropsVTypes"
@@ -91,10 +106,12 @@ const (
 	// ([ISO/IEC29500-1:2016] section 18.2.10) of the worksheet element
 	// ([ISO/IEC29500-1:2016] section 18.3.1.99) is extended by the addition of
 	// new child ext elements ([ISO/IEC29500-1:2016] section 18.2.7)
+	ExtURIChartDataLabels             = "{CE6537A1-D6FC-4f65-9D91-7224C49458BB}"
 	ExtURIConditionalFormattingRuleID = "{B025F937-C7B1-47D3-B67F-A62EFF666E3E}"
 	ExtURIConditionalFormattings      = "{78C0D931-6437-407d-A8EE-F0AAD7539E65}"
 	ExtURIDataValidations             = "{CCE6A557-97BC-4B89-ADB6-D9C93CAAB3DF}"
 	ExtURIDrawingBlip                 = "{28A0092B-C50C-407E-A947-70E740481C1C}"
+	ExtURIDynamicArrayProperties      = "{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"
 	ExtURIIgnoredErrors               = "{01252117-D84E-4E92-8308-4BE1C098FCBB}"
 	ExtURIMacExcelMX                  = "{64002731-A6B0-56B0-2670-7721B7C09600}"
 	ExtURIProtectedRanges             = "{FC87AEE6-9EDD-4A0A-B7FB-166176984837}"
@@ -222,6 +239,14 @@ var supportedDrawingUnderlineTypes = []string{
 // supportedPositioning defined supported positioning types.
 var supportedPositioning = []string{"absolute", "oneCell", "twoCell"}
 
+// supportedPhoneticTypes defined supported character types of the phonetic
+// hints.
+var supportedPhoneticTypes = []string{"halfwidthKatakana", "fullwidthKatakana", "Hiragana", "noConversion"}
+
+// supportedPhoneticAlignments defined supported alignment types of the
+// phonetic hints.
+var supportedPhoneticAlignments = []string{"noControl", "left", "center", "distributed"}
+
 // xlsxCNvPr directly maps the cNvPr (Non-Visual Drawing Properties). This
 // element specifies non-visual canvas properties. This allows for additional
 // information that does not affect the appearance of the picture to be stored.
@@ -475,8 +500,28 @@ type xlsxGraphicFrame struct {
 // information that does not affect the appearance of the graphic frame to be
 // stored.
 type xlsxNvGraphicFramePr struct {
-	CNvPr                *xlsxCNvPr `xml:"xdr:cNvPr"`
-	ChicNvGraphicFramePr string     `xml:"xdr:cNvGraphicFramePr"`
+	CNvPr             *xlsxCNvPr            `xml:"xdr:cNvPr"`
+	CNvGraphicFramePr xlsxCNvGraphicFramePr `xml:"xdr:cNvGraphicFramePr"`
+}
+
+// xlsxCNvGraphicFramePr (Non-Visual Graphic Frame Drawing Properties)
+// directly maps the cNvGraphicFramePr element. This element specifies the
+// non-visual properties for a graphic frame.
+type xlsxCNvGraphicFramePr struct {
+	GraphicFrameLocks *xlsxGraphicFrameLocks `xml:"a:graphicFrameLocks"`
+}
+
+// xlsxGraphicFrameLocks directly maps the graphicFrameLocks (Graphic Frame
+// Locks). This element specifies all locking properties for a graphic frame.
+// These properties inform the generating application about specific
+// properties that have been previously locked and thus should not be changed.
+type xlsxGraphicFrameLocks struct {
+	NoChangeAspect bool `xml:"noChangeAspect,attr,omitempty"`
+	NoDrilldown    bool `xml:"noDrilldown,attr,omitempty"`
+	NoGrp          bool `xml:"noGrp,attr,omitempty"`
+	NoMove         bool `xml:"noMove,attr,omitempty"`
+	NoResize       bool `xml:"noResize,attr,omitempty"`
+	NoSelect       bool `xml:"noSelect,attr,omitempty"`
 }
 
 // xlsxGraphic (Graphic Object) directly maps the a:graphic element. This
@@ -592,33 +637,51 @@ type Picture struct {
 	Format    *GraphicOptions
 }
 
+// MediaFile maps the media or embedded object stored in the spreadsheet. The
+// Name is the part name of the item in the package, such as
+// xl/media/image1.png or xl/embeddings/oleObject1.bin. The References are the
+// part names which reference the item directly, such as the drawing or the
+// worksheet, and the Sheets are the names of the worksheets or chartsheets
+// which reference the item directly or through the drawings and charts. The
+// Unreferenced will be true if the item isn't referenced by any sheet.
+type MediaFile struct {
+	Name         string
+	ContentType  string
+	File         []byte
+	References   []string
+	Sheets       []string
+	Unreferenced bool
+}
+
 // GraphicOptions directly maps the format settings of the picture.
 type GraphicOptions struct {
-	AltText         string
-	PrintObject     *bool
-	Locked          *bool
-	LockAspectRatio bool
-	AutoFit         bool
-	OffsetX         int
-	OffsetY         int
-	ScaleX          float64
-	ScaleY          float64
-	Hyperlink       string
-	HyperlinkType   string
-	Positioning     string
+	AltText         string  `json:"altText,omitempty"`
+	PrintObject     *bool   `json:"printObject,omitempty"`
+	Locked          *bool   `json:"locked,omitempty"`
+	LockAspectRatio bool    `json:"lockAspectRatio,omitempty"`
+	AutoFit         bool    `json:"autoFit,omitempty"`
+	OffsetX         int     `json:"offsetX,omitempty"`
+	OffsetY         int     `json:"offsetY,omitempty"`
+	OffsetXEMU      int     `json:"offsetXEMU,omitempty"`
+	OffsetYEMU      int     `json:"offsetYEMU,omitempty"`
+	ScaleX          float64 `json:"scaleX,omitempty"`
+	ScaleY          float64 `json:"scaleY,omitempty"`
+	Hyperlink       string  `json:"hyperlink,omitempty"`
+	HyperlinkType   string  `json:"hyperlinkType,omitempty"`
+	Positioning     string  `json:"positioning,omitempty"`
 }
 
 // Shape directly maps the format settings of the shape.
 type Shape struct {
-	Cell      string
-	Type      string
-	Macro     string
-	Width     uint
-	Height    uint
-	Format    GraphicOptions
-	Fill      Fill
-	Line      ShapeLine
-	Paragraph []RichTextRun
+	Cell      string         `json:"cell,omitempty"`
+	Type      string         `json:"type,omitempty"`
+	Macro     string         `json:"macro,omitempty"`
+	Width     uint           `json:"width,omitempty"`
+	Height    uint           `json:"height,omitempty"`
+	Format    GraphicOptions `json:"format,omitempty"`
+	Fill      Fill           `json:"fill,omitempty"`
+	Line      ShapeLine      `json:"line,omitempty"`
+	Paragraph []RichTextRun  `json:"paragraph,omitempty"`
 }
 
 // ShapeColor directly maps the color settings of the shape.
@@ -630,6 +693,6 @@ type ShapeColor struct {
 
 // ShapeLine directly maps the line settings of the shape.
 type ShapeLine struct {
-	Color string
-	Width *float64
+	Color string   `json:"color,omitempty"`
+	Width *float64 `json:"width,omitempty"`
 }
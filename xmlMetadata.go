@@ -0,0 +1,127 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import "github.com/xuri/excelize/v2/xencoding/xml"
+
+// xlsxMetadata directly maps the metadata element. A cell in a spreadsheet
+// application can have metadata associated with it. Metadata is just a set of
+// additional properties about the particular cell, and this metadata is
+// stored in the metadata part. The cellMetadata element contains the metadata
+// referenced by the cm attribute of the cells, and the valueMetadata element
+// contains the metadata referenced by the vm attribute of the cells.
+type xlsxMetadata struct {
+	XMLName         xml.Name             `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main metadata"`
+	MetadataTypes   *xlsxMetadataTypes   `xml:"metadataTypes"`
+	MetadataStrings *xlsxInnerXML        `xml:"metadataStrings"`
+	MdxMetadata     *xlsxInnerXML        `xml:"mdxMetadata"`
+	FutureMetadata  []xlsxFutureMetadata `xml:"futureMetadata"`
+	CellMetadata    *xlsxMetadataBlocks  `xml:"cellMetadata"`
+	ValueMetadata   *xlsxMetadataBlocks  `xml:"valueMetadata"`
+	ExtLst          *xlsxInnerXML        `xml:"extLst"`
+}
+
+// xlsxMetadataTypes directly maps the metadataTypes element. This element
+// represents the set of metadata types used in this workbook.
+type xlsxMetadataTypes struct {
+	Count        int                `xml:"count,attr"`
+	MetadataType []xlsxMetadataType `xml:"metadataType"`
+}
+
+// xlsxMetadataType directly maps the metadataType element. This element
+// represents a single metadata type and the behaviors of the metadata type on
+// the cell operations.
+type xlsxMetadataType struct {
+	Name                string `xml:"name,attr"`
+	MinSupportedVersion int    `xml:"minSupportedVersion,attr"`
+	GhostRow            bool   `xml:"ghostRow,attr,omitempty"`
+	GhostCol            bool   `xml:"ghostCol,attr,omitempty"`
+	Edit                bool   `xml:"edit,attr,omitempty"`
+	Delete              bool   `xml:"delete,attr,omitempty"`
+	Copy                bool   `xml:"copy,attr,omitempty"`
+	PasteAll            bool   `xml:"pasteAll,attr,omitempty"`
+	PasteFormulas       bool   `xml:"pasteFormulas,attr,omitempty"`
+	PasteValues         bool   `xml:"pasteValues,attr,omitempty"`
+	PasteFormats        bool   `xml:"pasteFormats,attr,omitempty"`
+	PasteComments       bool   `xml:"pasteComments,attr,omitempty"`
+	PasteDataValidation bool   `xml:"pasteDataValidation,attr,omitempty"`
+	PasteBorders        bool   `xml:"pasteBorders,attr,omitempty"`
+	PasteColWidths      bool   `xml:"pasteColWidths,attr,omitempty"`
+	PasteNumberFormats  bool   `xml:"pasteNumberFormats,attr,omitempty"`
+	Merge               bool   `xml:"merge,attr,omitempty"`
+	SplitFirst          bool   `xml:"splitFirst,attr,omitempty"`
+	SplitAll            bool   `xml:"splitAll,attr,omitempty"`
+	RowColShift         bool   `xml:"rowColShift,attr,omitempty"`
+	ClearAll            bool   `xml:"clearAll,attr,omitempty"`
+	ClearFormats        bool   `xml:"clearFormats,attr,omitempty"`
+	ClearContents       bool   `xml:"clearContents,attr,omitempty"`
+	ClearComments       bool   `xml:"clearComments,attr,omitempty"`
+	Assign              bool   `xml:"assign,attr,omitempty"`
+	Coerce              bool   `xml:"coerce,attr,omitempty"`
+	Adjust              bool   `xml:"adjust,attr,omitempty"`
+	CellMeta            bool   `xml:"cellMeta,attr,omitempty"`
+}
+
+// xlsxFutureMetadata directly maps the futureMetadata element. This element
+// represents future metadata information, each of the bk element contains the
+// metadata stored in the extension list, such as the dynamic array properties.
+type xlsxFutureMetadata struct {
+	Name   string         `xml:"name,attr"`
+	Count  int            `xml:"count,attr,omitempty"`
+	Bk     []xlsxInnerXML `xml:"bk"`
+	ExtLst *xlsxInnerXML  `xml:"extLst"`
+}
+
+// decodeFutureMetadataBlock directly maps the bk element of the future
+// metadata. This element contains the extension list of the future metadata
+// record, such as the dynamic array properties.
+type decodeFutureMetadataBlock struct {
+	Ext []decodeFutureMetadataExt `xml:"extLst>ext"`
+}
+
+// decodeFutureMetadataExt directly maps the ext element of the future
+// metadata record.
+type decodeFutureMetadataExt struct {
+	URI                    string                        `xml:"uri,attr"`
+	DynamicArrayProperties *decodeDynamicArrayProperties `xml:"dynamicArrayProperties"`
+}
+
+// decodeDynamicArrayProperties directly maps the dynamicArrayProperties
+// element. This element specifies if the array formula is a dynamic array
+// formula, and if the dynamic array formula is collapsed to a single cell.
+type decodeDynamicArrayProperties struct {
+	FDynamic   bool `xml:"fDynamic,attr"`
+	FCollapsed bool `xml:"fCollapsed,attr"`
+}
+
+// xlsxMetadataBlocks directly maps the cellMetadata and valueMetadata
+// elements. This element represents the metadata blocks which referenced by
+// the cells.
+type xlsxMetadataBlocks struct {
+	Count int                 `xml:"count,attr,omitempty"`
+	Bk    []xlsxMetadataBlock `xml:"bk"`
+}
+
+// xlsxMetadataBlock directly maps the bk element of the metadata blocks. This
+// element represents a block of metadata records.
+type xlsxMetadataBlock struct {
+	Rc []xlsxMetadataRecord `xml:"rc"`
+}
+
+// xlsxMetadataRecord directly maps the rc element. This element represents a
+// reference to a metadata record, the t attribute is the 1-based index of the
+// metadata type, and the v attribute is the 0-based index of the metadata
+// record of the type.
+type xlsxMetadataRecord struct {
+	T int `xml:"t,attr"`
+	V int `xml:"v,attr"`
+}
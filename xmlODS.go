@@ -0,0 +1,115 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"github.com/xuri/excelize/v2/xencoding/xml"
+	"strconv"
+	"strings"
+)
+
+// Source relationship and namespace list of the OpenDocument spreadsheet.
+const (
+	mimeTypeODS        = "application/vnd.oasis.opendocument.spreadsheet"
+	nameSpaceODSTable  = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	nameSpaceODSText   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	nameSpaceODSStyle  = "urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+	nameSpaceODSNumber = "urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0"
+)
+
+// odsTableCell directly maps the table-cell and covered-table-cell elements in
+// the OpenDocument spreadsheet, which specifies a cell of the table.
+type odsTableCell struct {
+	XMLName         xml.Name
+	StyleName       string         `xml:"urn:oasis:names:tc:opendocument:xmlns:table:1.0 style-name,attr"`
+	ColumnsRepeated string         `xml:"urn:oasis:names:tc:opendocument:xmlns:table:1.0 number-columns-repeated,attr"`
+	ColumnsSpanned  string         `xml:"urn:oasis:names:tc:opendocument:xmlns:table:1.0 number-columns-spanned,attr"`
+	RowsSpanned     string         `xml:"urn:oasis:names:tc:opendocument:xmlns:table:1.0 number-rows-spanned,attr"`
+	ValueType       string         `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 value-type,attr"`
+	Value           string         `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 value,attr"`
+	DateValue       string         `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 date-value,attr"`
+	TimeValue       string         `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 time-value,attr"`
+	BooleanValue    string         `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 boolean-value,attr"`
+	StringValue     string         `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 string-value,attr"`
+	Paragraphs      []odsParagraph `xml:"urn:oasis:names:tc:opendocument:xmlns:text:1.0 p"`
+}
+
+// odsParagraph directly maps the p element in the OpenDocument spreadsheet,
+// which specifies a paragraph of the text in the cell.
+type odsParagraph struct {
+	Text string
+}
+
+// UnmarshalXML convert the paragraph element into the plain text, the
+// elements for the spaces, tabs and line breaks will be converted into the
+// corresponding characters.
+func (p *odsParagraph) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var text strings.Builder
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch element := token.(type) {
+		case xml.CharData:
+			text.Write(element)
+		case xml.StartElement:
+			if element.Name.Space != nameSpaceODSText {
+				if err = d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			switch element.Name.Local {
+			case "s":
+				count := 1
+				for _, attr := range element.Attr {
+					if attr.Name.Local == "c" {
+						if c, err := strconv.Atoi(attr.Value); err == nil {
+							count = c
+						}
+					}
+				}
+				text.WriteString(strings.Repeat(" ", count))
+			case "tab":
+				text.WriteString("\t")
+			case "line-break":
+				text.WriteString("\n")
+			}
+		case xml.EndElement:
+			if element.Name == start.Name {
+				p.Text = text.String()
+				return nil
+			}
+		}
+	}
+}
+
+// odsStyle directly maps the style element in the OpenDocument spreadsheet,
+// which specifies the data style of the cells.
+type odsStyle struct {
+	Name          string `xml:"urn:oasis:names:tc:opendocument:xmlns:style:1.0 name,attr"`
+	Family        string `xml:"urn:oasis:names:tc:opendocument:xmlns:style:1.0 family,attr"`
+	DataStyleName string `xml:"urn:oasis:names:tc:opendocument:xmlns:style:1.0 data-style-name,attr"`
+}
+
+// odsNumberStyle directly maps the number-style, percentage-style and
+// currency-style elements in the OpenDocument spreadsheet, which specifies
+// the number format.
+type odsNumberStyle struct {
+	XMLName xml.Name
+	Name    string `xml:"urn:oasis:names:tc:opendocument:xmlns:style:1.0 name,attr"`
+	Number  *struct {
+		DecimalPlaces string `xml:"urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0 decimal-places,attr"`
+		Grouping      bool   `xml:"urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0 grouping,attr"`
+	} `xml:"urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0 number"`
+}
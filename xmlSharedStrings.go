@@ -25,10 +25,11 @@ import (
 // implementations to store values only once.
 type xlsxSST struct {
 	mu          sync.Mutex
-	XMLName     xml.Name `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main sst"`
-	Count       int      `xml:"count,attr"`
-	UniqueCount int      `xml:"uniqueCount,attr"`
-	SI          []xlsxSI `xml:"si"`
+	XMLName     xml.Name    `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main sst"`
+	Count       int         `xml:"count,attr"`
+	UniqueCount int         `xml:"uniqueCount,attr"`
+	SI          []xlsxSI    `xml:"si"`
+	ExtLst      *xlsxExtLst `xml:"extLst"`
 }
 
 // xlsxSI (String Item) is the representation of an individual string in the
@@ -87,6 +88,37 @@ type xlsxRPr struct {
 
 // RichTextRun directly maps the settings of the rich text run.
 type RichTextRun struct {
-	Font *Font
-	Text string
+	Font *Font  `json:"font,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+// PhoneticRun directly maps the settings of the phonetic hint run (furigana)
+// of the cell. StartIndex and EndIndex specifies the zero-based character
+// index range [StartIndex, EndIndex) of the cell value which the phonetic text
+// applies to.
+type PhoneticRun struct {
+	StartIndex int
+	EndIndex   int
+	Text       string
+}
+
+// PhoneticProperties directly maps the display settings of the phonetic hint
+// runs of the cell.
+//
+// FontID specifies the index of the font in the style sheet for the phonetic
+// text, the default value is 0.
+//
+// Type specifies the character type of the phonetic text, acceptable values:
+// "halfwidthKatakana", "fullwidthKatakana" (default), "Hiragana" and
+// "noConversion".
+//
+// Alignment specifies the alignment of the phonetic text, acceptable values:
+// "noControl", "left" (default), "center" and "distributed".
+//
+// Visible specifies if show the phonetic text above the cell value.
+type PhoneticProperties struct {
+	FontID    int
+	Type      string
+	Alignment string
+	Visible   bool
 }
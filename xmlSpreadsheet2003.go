@@ -0,0 +1,168 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import "github.com/xuri/excelize/v2/xencoding/xml"
+
+// Namespace list of the SpreadsheetML 2003 XML format.
+const (
+	nameSpaceSpreadsheet2003       = "urn:schemas-microsoft-com:office:spreadsheet"
+	nameSpaceSpreadsheet2003Office = "urn:schemas-microsoft-com:office:office"
+	nameSpaceSpreadsheet2003Excel  = "urn:schemas-microsoft-com:office:excel"
+	nameSpaceSpreadsheet2003HTML   = "http://www.w3.org/TR/REC-html40"
+)
+
+// XMLSpreadsheet2003Options directly maps the settings of exporting the
+// workbook into the SpreadsheetML 2003 XML format.
+//
+// CachedFormulaValues specifies if export the cached values of the formula
+// cells only instead of the formulas in the R1C1 reference style. The cached
+// values will always be exported for the formulas which couldn't be converted,
+// such as the formulas with structured or external references.
+type XMLSpreadsheet2003Options struct {
+	CachedFormulaValues bool
+}
+
+// ss2003Workbook directly maps the Workbook element of the SpreadsheetML 2003
+// XML format, which is the root element of the document.
+type ss2003Workbook struct {
+	XMLName   xml.Name          `xml:"Workbook"`
+	XMLNS     string            `xml:"xmlns,attr"`
+	XMLNSo    string            `xml:"xmlns:o,attr"`
+	XMLNSx    string            `xml:"xmlns:x,attr"`
+	XMLNSss   string            `xml:"xmlns:ss,attr"`
+	XMLNShtml string            `xml:"xmlns:html,attr"`
+	Styles    *ss2003Styles     `xml:"Styles"`
+	Worksheet []ss2003Worksheet `xml:"Worksheet"`
+}
+
+// ss2003Styles directly maps the Styles element of the SpreadsheetML 2003 XML
+// format, which contains the styles used by the cells.
+type ss2003Styles struct {
+	Style []ss2003Style `xml:"Style"`
+}
+
+// ss2003Style directly maps the Style element of the SpreadsheetML 2003 XML
+// format.
+type ss2003Style struct {
+	ID           string              `xml:"ss:ID,attr"`
+	Name         string              `xml:"ss:Name,attr,omitempty"`
+	Alignment    *ss2003Alignment    `xml:"Alignment"`
+	Borders      *ss2003Borders      `xml:"Borders"`
+	Font         *ss2003Font         `xml:"Font"`
+	Interior     *ss2003Interior     `xml:"Interior"`
+	NumberFormat *ss2003NumberFormat `xml:"NumberFormat"`
+}
+
+// ss2003Alignment directly maps the Alignment element of the SpreadsheetML
+// 2003 XML format.
+type ss2003Alignment struct {
+	Horizontal   string `xml:"ss:Horizontal,attr,omitempty"`
+	Vertical     string `xml:"ss:Vertical,attr,omitempty"`
+	Indent       int    `xml:"ss:Indent,attr,omitempty"`
+	Rotate       int    `xml:"ss:Rotate,attr,omitempty"`
+	ShrinkToFit  int    `xml:"ss:ShrinkToFit,attr,omitempty"`
+	VerticalText int    `xml:"ss:VerticalText,attr,omitempty"`
+	WrapText     int    `xml:"ss:WrapText,attr,omitempty"`
+}
+
+// ss2003Borders directly maps the Borders element of the SpreadsheetML 2003
+// XML format.
+type ss2003Borders struct {
+	Border []ss2003Border `xml:"Border"`
+}
+
+// ss2003Border directly maps the Border element of the SpreadsheetML 2003 XML
+// format.
+type ss2003Border struct {
+	Position  string `xml:"ss:Position,attr"`
+	LineStyle string `xml:"ss:LineStyle,attr,omitempty"`
+	Weight    int    `xml:"ss:Weight,attr,omitempty"`
+	Color     string `xml:"ss:Color,attr,omitempty"`
+}
+
+// ss2003Font directly maps the Font element of the SpreadsheetML 2003 XML
+// format.
+type ss2003Font struct {
+	FontName      string  `xml:"ss:FontName,attr,omitempty"`
+	Size          float64 `xml:"ss:Size,attr,omitempty"`
+	Color         string  `xml:"ss:Color,attr,omitempty"`
+	Bold          int     `xml:"ss:Bold,attr,omitempty"`
+	Italic        int     `xml:"ss:Italic,attr,omitempty"`
+	Underline     string  `xml:"ss:Underline,attr,omitempty"`
+	StrikeThrough int     `xml:"ss:StrikeThrough,attr,omitempty"`
+}
+
+// ss2003Interior directly maps the Interior element of the SpreadsheetML 2003
+// XML format.
+type ss2003Interior struct {
+	Color        string `xml:"ss:Color,attr,omitempty"`
+	Pattern      string `xml:"ss:Pattern,attr,omitempty"`
+	PatternColor string `xml:"ss:PatternColor,attr,omitempty"`
+}
+
+// ss2003NumberFormat directly maps the NumberFormat element of the
+// SpreadsheetML 2003 XML format.
+type ss2003NumberFormat struct {
+	Format string `xml:"ss:Format,attr,omitempty"`
+}
+
+// ss2003Worksheet directly maps the Worksheet element of the SpreadsheetML
+// 2003 XML format.
+type ss2003Worksheet struct {
+	Name  string      `xml:"ss:Name,attr"`
+	Table ss2003Table `xml:"Table"`
+}
+
+// ss2003Table directly maps the Table element of the SpreadsheetML 2003 XML
+// format, which contains the columns and rows of the worksheet.
+type ss2003Table struct {
+	Column []ss2003Column `xml:"Column"`
+	Row    []ss2003Row    `xml:"Row"`
+}
+
+// ss2003Column directly maps the Column element of the SpreadsheetML 2003 XML
+// format.
+type ss2003Column struct {
+	Index   int     `xml:"ss:Index,attr"`
+	Span    int     `xml:"ss:Span,attr,omitempty"`
+	Width   float64 `xml:"ss:Width,attr,omitempty"`
+	Hidden  int     `xml:"ss:Hidden,attr,omitempty"`
+	StyleID string  `xml:"ss:StyleID,attr,omitempty"`
+}
+
+// ss2003Row directly maps the Row element of the SpreadsheetML 2003 XML
+// format.
+type ss2003Row struct {
+	Index  int          `xml:"ss:Index,attr"`
+	Height float64      `xml:"ss:Height,attr,omitempty"`
+	Hidden int          `xml:"ss:Hidden,attr,omitempty"`
+	Cell   []ss2003Cell `xml:"Cell"`
+}
+
+// ss2003Cell directly maps the Cell element of the SpreadsheetML 2003 XML
+// format.
+type ss2003Cell struct {
+	Index       int         `xml:"ss:Index,attr"`
+	StyleID     string      `xml:"ss:StyleID,attr,omitempty"`
+	MergeAcross int         `xml:"ss:MergeAcross,attr,omitempty"`
+	MergeDown   int         `xml:"ss:MergeDown,attr,omitempty"`
+	Formula     string      `xml:"ss:Formula,attr,omitempty"`
+	Data        *ss2003Data `xml:"Data"`
+}
+
+// ss2003Data directly maps the Data element of the SpreadsheetML 2003 XML
+// format, which specifies the value and value type of the cell.
+type ss2003Data struct {
+	Type  string `xml:"ss:Type,attr"`
+	Value string `xml:",chardata"`
+}
@@ -18,19 +18,20 @@ import (
 
 // xlsxStyleSheet is the root element of the Styles part.
 type xlsxStyleSheet struct {
-	mu           sync.Mutex
-	XMLName      xml.Name          `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main styleSheet"`
-	NumFmts      *xlsxNumFmts      `xml:"numFmts"`
-	Fonts        *xlsxFonts        `xml:"fonts"`
-	Fills        *xlsxFills        `xml:"fills"`
-	Borders      *xlsxBorders      `xml:"borders"`
-	CellStyleXfs *xlsxCellStyleXfs `xml:"cellStyleXfs"`
-	CellXfs      *xlsxCellXfs      `xml:"cellXfs"`
-	CellStyles   *xlsxCellStyles   `xml:"cellStyles"`
-	Dxfs         *xlsxDxfs         `xml:"dxfs"`
-	TableStyles  *xlsxTableStyles  `xml:"tableStyles"`
-	Colors       *xlsxStyleColors  `xml:"colors"`
-	ExtLst       *xlsxExtLst       `xml:"extLst"`
+	mu             sync.Mutex
+	dateTimeStyles map[int]bool
+	XMLName        xml.Name          `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main styleSheet"`
+	NumFmts        *xlsxNumFmts      `xml:"numFmts"`
+	Fonts          *xlsxFonts        `xml:"fonts"`
+	Fills          *xlsxFills        `xml:"fills"`
+	Borders        *xlsxBorders      `xml:"borders"`
+	CellStyleXfs   *xlsxCellStyleXfs `xml:"cellStyleXfs"`
+	CellXfs        *xlsxCellXfs      `xml:"cellXfs"`
+	CellStyles     *xlsxCellStyles   `xml:"cellStyles"`
+	Dxfs           *xlsxDxfs         `xml:"dxfs"`
+	TableStyles    *xlsxTableStyles  `xml:"tableStyles"`
+	Colors         *xlsxStyleColors  `xml:"colors"`
+	ExtLst         *xlsxExtLst       `xml:"extLst"`
 }
 
 // xlsxAlignment formatting information pertaining to text alignment in cells.
@@ -184,13 +185,14 @@ type xlsxCellStyles struct {
 // the name and related formatting records for a named cell style in this
 // workbook.
 type xlsxCellStyle struct {
-	XMLName       xml.Name `xml:"cellStyle"`
-	Name          string   `xml:"name,attr"`
-	XfID          int      `xml:"xfId,attr"`
-	BuiltInID     *int     `xml:"builtinId,attr"`
-	ILevel        *int     `xml:"iLevel,attr"`
-	Hidden        *bool    `xml:"hidden,attr"`
-	CustomBuiltIn *bool    `xml:"customBuiltin,attr"`
+	XMLName       xml.Name    `xml:"cellStyle"`
+	Name          string      `xml:"name,attr"`
+	XfID          int         `xml:"xfId,attr"`
+	BuiltInID     *int        `xml:"builtinId,attr"`
+	ILevel        *int        `xml:"iLevel,attr"`
+	Hidden        *bool       `xml:"hidden,attr"`
+	CustomBuiltIn *bool       `xml:"customBuiltin,attr"`
+	ExtLst        *xlsxExtLst `xml:"extLst"`
 }
 
 // xlsxCellStyleXfs directly maps the cellStyleXfs element. This element
@@ -223,6 +225,7 @@ type xlsxXf struct {
 	ApplyProtection   *bool           `xml:"applyProtection,attr"`
 	Alignment         *xlsxAlignment  `xml:"alignment"`
 	Protection        *xlsxProtection `xml:"protection"`
+	ExtLst            *xlsxExtLst     `xml:"extLst"`
 }
 
 // xlsxCellXfs directly maps the cellXfs element. This element contains the
@@ -257,7 +260,7 @@ type xlsxDxf struct {
 	Alignment  *xlsxAlignment  `xml:"alignment"`
 	Border     *xlsxBorder     `xml:"border"`
 	Protection *xlsxProtection `xml:"protection"`
-	ExtLst     *xlsxExt        `xml:"extLst"`
+	ExtLst     *xlsxExtLst     `xml:"extLst"`
 }
 
 // xlsxTableStyles directly maps the tableStyles element. This element
@@ -305,67 +308,82 @@ type xlsxNumFmt struct {
 // legacy color palette has been modified (backwards compatibility settings) or
 // a custom color has been selected while using this workbook.
 type xlsxStyleColors struct {
-	Color string `xml:",innerxml"`
+	IndexedColors *xlsxIndexedColors `xml:"indexedColors"`
+	MruColors     *xlsxInnerXML      `xml:"mruColors"`
+}
+
+// xlsxIndexedColors directly maps the indexedColors element. A legacy indexing
+// scheme for colors that is still required for some records. When the color
+// palette has been modified from default, then the entire color palette is
+// written out.
+type xlsxIndexedColors struct {
+	RgbColor []xlsxRgbColor `xml:"rgbColor"`
+}
+
+// xlsxRgbColor directly maps the rgbColor element, which specifies an ARGB
+// color value of the indexed color palette.
+type xlsxRgbColor struct {
+	RGB string `xml:"rgb,attr"`
 }
 
 // Alignment directly maps the alignment settings of the cells.
 type Alignment struct {
-	Horizontal      string
-	Indent          int
-	JustifyLastLine bool
-	ReadingOrder    uint64
-	RelativeIndent  int
-	ShrinkToFit     bool
-	TextRotation    int
-	Vertical        string
-	WrapText        bool
+	Horizontal      string `json:"horizontal,omitempty"`
+	Indent          int    `json:"indent,omitempty"`
+	JustifyLastLine bool   `json:"justifyLastLine,omitempty"`
+	ReadingOrder    uint64 `json:"readingOrder,omitempty"`
+	RelativeIndent  int    `json:"relativeIndent,omitempty"`
+	ShrinkToFit     bool   `json:"shrinkToFit,omitempty"`
+	TextRotation    int    `json:"textRotation,omitempty"`
+	Vertical        string `json:"vertical,omitempty"`
+	WrapText        bool   `json:"wrapText,omitempty"`
 }
 
 // Border directly maps the border settings of the cells.
 type Border struct {
-	Type  string
-	Color string
-	Style int
+	Type  string `json:"type,omitempty"`
+	Color string `json:"color,omitempty"`
+	Style int    `json:"style,omitempty"`
 }
 
 // Font directly maps the font settings of the fonts.
 type Font struct {
-	Bold         bool
-	Italic       bool
-	Underline    string
-	Family       string
-	Size         float64
-	Strike       bool
-	Color        string
-	ColorIndexed int
-	ColorTheme   *int
-	ColorTint    float64
-	VertAlign    string
+	Bold         bool    `json:"bold,omitempty"`
+	Italic       bool    `json:"italic,omitempty"`
+	Underline    string  `json:"underline,omitempty"`
+	Family       string  `json:"family,omitempty"`
+	Size         float64 `json:"size,omitempty"`
+	Strike       bool    `json:"strike,omitempty"`
+	Color        string  `json:"color,omitempty"`
+	ColorIndexed int     `json:"colorIndexed,omitempty"`
+	ColorTheme   *int    `json:"colorTheme,omitempty"`
+	ColorTint    float64 `json:"colorTint,omitempty"`
+	VertAlign    string  `json:"vertAlign,omitempty"`
 }
 
 // Fill directly maps the fill settings of the cells.
 type Fill struct {
-	Type    string
-	Pattern int
-	Color   []string
-	Shading int
+	Type    string   `json:"type,omitempty"`
+	Pattern int      `json:"pattern,omitempty"`
+	Color   []string `json:"color,omitempty"`
+	Shading int      `json:"shading,omitempty"`
 }
 
 // Protection directly maps the protection settings of the cells.
 type Protection struct {
-	Hidden bool
-	Locked bool
+	Hidden bool `json:"hidden,omitempty"`
+	Locked bool `json:"locked,omitempty"`
 }
 
 // Style directly maps the style settings of the cells.
 type Style struct {
-	Border        []Border
-	Fill          Fill
-	Font          *Font
-	Alignment     *Alignment
-	Protection    *Protection
-	NumFmt        int
-	DecimalPlaces *int
-	CustomNumFmt  *string
-	NegRed        bool
+	Border        []Border    `json:"border,omitempty"`
+	Fill          Fill        `json:"fill,omitempty"`
+	Font          *Font       `json:"font,omitempty"`
+	Alignment     *Alignment  `json:"alignment,omitempty"`
+	Protection    *Protection `json:"protection,omitempty"`
+	NumFmt        int         `json:"numFmt,omitempty"`
+	DecimalPlaces *int        `json:"decimalPlaces,omitempty"`
+	CustomNumFmt  *string     `json:"customNumFmt,omitempty"`
+	NegRed        bool        `json:"negRed,omitempty"`
 }
@@ -40,6 +40,7 @@ type xlsxTable struct {
 	AutoFilter           *xlsxAutoFilter     `xml:"autoFilter"`
 	TableColumns         *xlsxTableColumns   `xml:"tableColumns"`
 	TableStyleInfo       *xlsxTableStyleInfo `xml:"tableStyleInfo"`
+	ExtLst               *xlsxExtLst         `xml:"extLst"`
 }
 
 // xlsxAutoFilter temporarily hides rows based on a filter criteria, which is
@@ -49,6 +50,7 @@ type xlsxAutoFilter struct {
 	XMLName      xml.Name            `xml:"autoFilter"`
 	Ref          string              `xml:"ref,attr"`
 	FilterColumn []*xlsxFilterColumn `xml:"filterColumn"`
+	ExtLst       *xlsxExtLst         `xml:"extLst"`
 }
 
 // xlsxFilterColumn directly maps the filterColumn element. The filterColumn
@@ -171,18 +173,29 @@ type xlsxTableColumns struct {
 // xlsxTableColumn directly maps the element representing a single column for
 // this table.
 type xlsxTableColumn struct {
-	DataCellStyle      string `xml:"dataCellStyle,attr,omitempty"`
-	DataDxfID          int    `xml:"dataDxfId,attr,omitempty"`
-	HeaderRowCellStyle string `xml:"headerRowCellStyle,attr,omitempty"`
-	HeaderRowDxfID     int    `xml:"headerRowDxfId,attr,omitempty"`
-	ID                 int    `xml:"id,attr"`
-	Name               string `xml:"name,attr"`
-	QueryTableFieldID  int    `xml:"queryTableFieldId,attr,omitempty"`
-	TotalsRowCellStyle string `xml:"totalsRowCellStyle,attr,omitempty"`
-	TotalsRowDxfID     int    `xml:"totalsRowDxfId,attr,omitempty"`
-	TotalsRowFunction  string `xml:"totalsRowFunction,attr,omitempty"`
-	TotalsRowLabel     string `xml:"totalsRowLabel,attr,omitempty"`
-	UniqueName         string `xml:"uniqueName,attr,omitempty"`
+	DataCellStyle           string            `xml:"dataCellStyle,attr,omitempty"`
+	DataDxfID               int               `xml:"dataDxfId,attr,omitempty"`
+	HeaderRowCellStyle      string            `xml:"headerRowCellStyle,attr,omitempty"`
+	HeaderRowDxfID          int               `xml:"headerRowDxfId,attr,omitempty"`
+	ID                      int               `xml:"id,attr"`
+	Name                    string            `xml:"name,attr"`
+	QueryTableFieldID       int               `xml:"queryTableFieldId,attr,omitempty"`
+	TotalsRowCellStyle      string            `xml:"totalsRowCellStyle,attr,omitempty"`
+	TotalsRowDxfID          int               `xml:"totalsRowDxfId,attr,omitempty"`
+	TotalsRowFunction       string            `xml:"totalsRowFunction,attr,omitempty"`
+	TotalsRowLabel          string            `xml:"totalsRowLabel,attr,omitempty"`
+	UniqueName              string            `xml:"uniqueName,attr,omitempty"`
+	CalculatedColumnFormula *xlsxTableFormula `xml:"calculatedColumnFormula"`
+	TotalsRowFormula        *xlsxTableFormula `xml:"totalsRowFormula"`
+	ExtLst                  *xlsxExtLst       `xml:"extLst"`
+}
+
+// xlsxTableFormula directly maps the calculatedColumnFormula and
+// totalsRowFormula element, which specifies the formula of the calculated
+// column or the totals row of the table column.
+type xlsxTableFormula struct {
+	Array   bool   `xml:"array,attr,omitempty"`
+	Content string `xml:",chardata"`
 }
 
 // xlsxTableStyleInfo directly maps the tableStyleInfo element. This element
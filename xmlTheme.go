@@ -161,3 +161,39 @@ type xlsxSysClr struct {
 	Val     string `xml:"val,attr"`
 	LastClr string `xml:"lastClr,attr"`
 }
+
+// Theme directly maps the settings of the workbook theme, includes the color
+// scheme and the major and minor fonts of the font scheme. The colors are
+// represented in hex RGB format, for example "4472C4".
+type Theme struct {
+	Name        string
+	ColorScheme ThemeColorScheme
+	MajorFont   ThemeFont
+	MinorFont   ThemeFont
+}
+
+// ThemeColorScheme directly maps the twelve color slots of the theme color
+// scheme.
+type ThemeColorScheme struct {
+	Name              string
+	Dark1             string
+	Light1            string
+	Dark2             string
+	Light2            string
+	Accent1           string
+	Accent2           string
+	Accent3           string
+	Accent4           string
+	Accent5           string
+	Accent6           string
+	Hyperlink         string
+	FollowedHyperlink string
+}
+
+// ThemeFont directly maps the typefaces of the major or minor font of the
+// theme font scheme.
+type ThemeFont struct {
+	Latin         string
+	EastAsian     string
+	ComplexScript string
+}
@@ -14,6 +14,7 @@ package excelize
 import (
 	"github.com/xuri/excelize/v2/xencoding/xml"
 	"sync"
+	"time"
 )
 
 // xlsxRelationships describe references from parts to other internal resources in the package or to external resources.
@@ -141,19 +142,20 @@ type xlsxBookViews struct {
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main This element
 // specifies a single Workbook view.
 type xlsxWorkBookView struct {
-	Visibility             string `xml:"visibility,attr,omitempty"`
-	Minimized              bool   `xml:"minimized,attr,omitempty"`
-	ShowHorizontalScroll   *bool  `xml:"showHorizontalScroll,attr"`
-	ShowVerticalScroll     *bool  `xml:"showVerticalScroll,attr"`
-	ShowSheetTabs          *bool  `xml:"showSheetTabs,attr"`
-	XWindow                string `xml:"xWindow,attr,omitempty"`
-	YWindow                string `xml:"yWindow,attr,omitempty"`
-	WindowWidth            int    `xml:"windowWidth,attr,omitempty"`
-	WindowHeight           int    `xml:"windowHeight,attr,omitempty"`
-	TabRatio               int    `xml:"tabRatio,attr,omitempty"`
-	FirstSheet             int    `xml:"firstSheet,attr,omitempty"`
-	ActiveTab              int    `xml:"activeTab,attr,omitempty"`
-	AutoFilterDateGrouping *bool  `xml:"autoFilterDateGrouping,attr"`
+	Visibility             string      `xml:"visibility,attr,omitempty"`
+	Minimized              bool        `xml:"minimized,attr,omitempty"`
+	ShowHorizontalScroll   *bool       `xml:"showHorizontalScroll,attr"`
+	ShowVerticalScroll     *bool       `xml:"showVerticalScroll,attr"`
+	ShowSheetTabs          *bool       `xml:"showSheetTabs,attr"`
+	XWindow                string      `xml:"xWindow,attr,omitempty"`
+	YWindow                string      `xml:"yWindow,attr,omitempty"`
+	WindowWidth            int         `xml:"windowWidth,attr,omitempty"`
+	WindowHeight           int         `xml:"windowHeight,attr,omitempty"`
+	TabRatio               *int        `xml:"tabRatio,attr"`
+	FirstSheet             int         `xml:"firstSheet,attr,omitempty"`
+	ActiveTab              int         `xml:"activeTab,attr,omitempty"`
+	AutoFilterDateGrouping *bool       `xml:"autoFilterDateGrouping,attr"`
+	ExtLst                 *xlsxExtLst `xml:"extLst"`
 }
 
 // xlsxSheets directly maps the sheets element from the namespace
@@ -194,6 +196,64 @@ type xlsxExternalReference struct {
 	RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
 }
 
+// xlsxExternalLink directly maps the externalLink element. This element is
+// the root element of the external workbook references part, it contains the
+// information about the external workbook referenced by the formulas and the
+// defined names in this workbook.
+type xlsxExternalLink struct {
+	XMLName      xml.Name          `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main externalLink"`
+	XMLNSR       string            `xml:"xmlns:r,attr,omitempty"`
+	ExternalBook *xlsxExternalBook `xml:"externalBook"`
+}
+
+// xlsxExternalBook directly maps the externalBook element. This element
+// specifies the relationship ID of the external workbook and the cached
+// worksheet names, defined names and cell values of the external workbook.
+type xlsxExternalBook struct {
+	RID          string                    `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	SheetNames   *xlsxExternalSheetNames   `xml:"sheetNames"`
+	DefinedNames *xlsxInnerXML             `xml:"definedNames"`
+	SheetDataSet *xlsxExternalSheetDataSet `xml:"sheetDataSet"`
+}
+
+// xlsxExternalSheetDataSet directly maps the sheetDataSet element of the
+// external workbook references part. This element specifies the cached cell
+// values of the worksheets in the external workbook.
+type xlsxExternalSheetDataSet struct {
+	SheetData []xlsxExternalSheetData `xml:"sheetData"`
+}
+
+// xlsxExternalSheetData directly maps the sheetData element of the external
+// workbook references part, the sheetId attribute is the zero-based index of
+// the worksheet in the sheetNames element.
+type xlsxExternalSheetData struct {
+	SheetID      int               `xml:"sheetId,attr"`
+	RefreshError bool              `xml:"refreshError,attr,omitempty"`
+	Row          []xlsxExternalRow `xml:"row"`
+}
+
+// xlsxExternalRow directly maps the row element of the external workbook
+// references part.
+type xlsxExternalRow struct {
+	R    int                `xml:"r,attr"`
+	Cell []xlsxExternalCell `xml:"cell"`
+}
+
+// xlsxExternalCell directly maps the cell element of the external workbook
+// references part, which contains the cached value of the cell.
+type xlsxExternalCell struct {
+	R  string `xml:"r,attr,omitempty"`
+	T  string `xml:"t,attr,omitempty"`
+	Vm *uint  `xml:"vm,attr"`
+	V  string `xml:"v,omitempty"`
+}
+
+// xlsxExternalSheetNames directly maps the sheetNames element of the external
+// workbook references part.
+type xlsxExternalSheetNames struct {
+	SheetName []attrValString `xml:"sheetName"`
+}
+
 // xlsxPivotCaches element enumerates pivot cache definition parts used by pivot
 // tables and formulas in this workbook.
 type xlsxPivotCaches struct {
@@ -255,15 +315,15 @@ type xlsxDefinedName struct {
 // and details. Calculation is the process of computing formulas and then
 // displaying the results as values in the cells that contain the formulas.
 type xlsxCalcPr struct {
-	CalcCompleted         bool    `xml:"calcCompleted,attr,omitempty"`
+	CalcCompleted         *bool   `xml:"calcCompleted,attr"`
 	CalcID                string  `xml:"calcId,attr,omitempty"`
 	CalcMode              string  `xml:"calcMode,attr,omitempty"`
-	CalcOnSave            bool    `xml:"calcOnSave,attr,omitempty"`
+	CalcOnSave            *bool   `xml:"calcOnSave,attr"`
 	ConcurrentCalc        *bool   `xml:"concurrentCalc,attr"`
 	ConcurrentManualCount int     `xml:"concurrentManualCount,attr,omitempty"`
 	ForceFullCalc         bool    `xml:"forceFullCalc,attr,omitempty"`
 	FullCalcOnLoad        bool    `xml:"fullCalcOnLoad,attr,omitempty"`
-	FullPrecision         bool    `xml:"fullPrecision,attr,omitempty"`
+	FullPrecision         *bool   `xml:"fullPrecision,attr"`
 	Iterate               bool    `xml:"iterate,attr,omitempty"`
 	IterateCount          int     `xml:"iterateCount,attr,omitempty"`
 	IterateDelta          float64 `xml:"iterateDelta,attr,omitempty"`
@@ -325,11 +385,79 @@ type DefinedName struct {
 	Scope    string
 }
 
+// CalcContextOptions directly maps the settings of the formula calculation
+// context. The Now specifies the fixed current time for the NOW and TODAY
+// formula functions, and the RandSeed specifies the seed of the random number
+// generator for the RAND and RANDBETWEEN formula functions.
+type CalcContextOptions struct {
+	Now      *time.Time
+	RandSeed *int64
+}
+
+// ExternalLink directly maps the link to the external workbook. The Index is
+// the 1-based index of the external workbook link that the formulas used to
+// refer to the external workbook, the Target is the path of the external
+// workbook, and the Sheets are the cached worksheet names of the external
+// workbook.
+type ExternalLink struct {
+	Index  int
+	Target string
+	Sheets []string
+}
+
 // WorkbookPropsOptions directly maps the settings of workbook proprieties.
 type WorkbookPropsOptions struct {
 	Date1904      *bool
 	FilterPrivacy *bool
 	CodeName      *string
+	RefMode       *string
+}
+
+// CalcPropsOptions directly maps the settings of the workbook calculation
+// properties.
+type CalcPropsOptions struct {
+	CalcID                *uint
+	CalcMode              *string
+	FullCalcOnLoad        *bool
+	RefMode               *string
+	Iterate               *bool
+	IterateCount          *uint
+	IterateDelta          *float64
+	FullPrecision         *bool
+	CalcCompleted         *bool
+	CalcOnSave            *bool
+	ConcurrentCalc        *bool
+	ConcurrentManualCount *uint
+	ForceFullCalc         *bool
+}
+
+// WorkbookViewOptions directly maps the settings of the workbook view.
+type WorkbookViewOptions struct {
+	// Visibility specifies visible state of the workbook window, the possible
+	// values are "visible", "hidden" and "veryHidden".
+	Visibility *string
+	// Minimized specifies the workbook window is minimized.
+	Minimized *bool
+	// XWindow and YWindow specifies the position of the upper-left corner of
+	// the workbook window in twips.
+	XWindow *int
+	YWindow *int
+	// WindowWidth and WindowHeight specifies the width and height of the
+	// workbook window in twips.
+	WindowWidth  *int
+	WindowHeight *int
+	// TabRatio specifies ratio between the sheet tabs bar and the horizontal
+	// scroll bar in per mille, the value should be between 0 and 1000.
+	TabRatio *int
+	// FirstSheet specifies the index of the first sheet shown in the sheet
+	// tabs bar.
+	FirstSheet *int
+	// ActiveTab specifies the index of the active sheet.
+	ActiveTab              *int
+	ShowHorizontalScroll   *bool
+	ShowVerticalScroll     *bool
+	ShowSheetTabs          *bool
+	AutoFilterDateGrouping *bool
 }
 
 // WorkbookProtectionOptions directly maps the settings of workbook protection.
@@ -20,6 +20,7 @@ import (
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main.
 type xlsxWorksheet struct {
 	mu                     sync.Mutex
+	mergeCellsIdx          *mergeCellsIndex
 	XMLName                xml.Name                     `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main worksheet"`
 	SheetPr                *xlsxSheetPr                 `xml:"sheetPr"`
 	Dimension              *xlsxDimension               `xml:"dimension"`
@@ -48,7 +49,7 @@ type xlsxWorksheet struct {
 	ColBreaks              *xlsxColBreaks               `xml:"colBreaks"`
 	CustomProperties       *xlsxInnerXML                `xml:"customProperties"`
 	CellWatches            *xlsxInnerXML                `xml:"cellWatches"`
-	IgnoredErrors          *xlsxInnerXML                `xml:"ignoredErrors"`
+	IgnoredErrors          *xlsxIgnoredErrors           `xml:"ignoredErrors"`
 	SmartTags              *xlsxInnerXML                `xml:"smartTags"`
 	Drawing                *xlsxDrawing                 `xml:"drawing"`
 	LegacyDrawing          *xlsxLegacyDrawing           `xml:"legacyDrawing"`
@@ -198,7 +199,7 @@ type xlsxSheetView struct {
 	DefaultGridColor         *bool            `xml:"defaultGridColor,attr"`
 	View                     string           `xml:"view,attr,omitempty"`
 	TopLeftCell              string           `xml:"topLeftCell,attr,omitempty"`
-	ColorID                  int              `xml:"colorId,attr,omitempty"`
+	ColorID                  *int             `xml:"colorId,attr"`
 	ZoomScale                float64          `xml:"zoomScale,attr,omitempty"`
 	ZoomScaleNormal          float64          `xml:"zoomScaleNormal,attr,omitempty"`
 	ZoomScalePageLayoutView  float64          `xml:"zoomScalePageLayoutView,attr,omitempty"`
@@ -206,6 +207,7 @@ type xlsxSheetView struct {
 	WorkbookViewID           int              `xml:"workbookViewId,attr"`
 	Pane                     *xlsxPane        `xml:"pane,omitempty"`
 	Selection                []*xlsxSelection `xml:"selection"`
+	ExtLst                   *xlsxExtLst      `xml:"extLst"`
 }
 
 // xlsxSelection directly maps the selection element in the namespace
@@ -307,19 +309,20 @@ type xlsxSheetData struct {
 // about an entire row of a worksheet, and contains all cell definitions for a
 // particular row in the worksheet.
 type xlsxRow struct {
-	C            []xlsxC  `xml:"c"`
-	R            int      `xml:"r,attr,omitempty"`
-	Spans        string   `xml:"spans,attr,omitempty"`
-	S            int      `xml:"s,attr,omitempty"`
-	CustomFormat bool     `xml:"customFormat,attr,omitempty"`
-	Ht           *float64 `xml:"ht,attr"`
-	Hidden       bool     `xml:"hidden,attr,omitempty"`
-	CustomHeight bool     `xml:"customHeight,attr,omitempty"`
-	OutlineLevel uint8    `xml:"outlineLevel,attr,omitempty"`
-	Collapsed    bool     `xml:"collapsed,attr,omitempty"`
-	ThickTop     bool     `xml:"thickTop,attr,omitempty"`
-	ThickBot     bool     `xml:"thickBot,attr,omitempty"`
-	Ph           bool     `xml:"ph,attr,omitempty"`
+	C            []xlsxC     `xml:"c"`
+	R            int         `xml:"r,attr,omitempty"`
+	Spans        string      `xml:"spans,attr,omitempty"`
+	S            int         `xml:"s,attr,omitempty"`
+	CustomFormat bool        `xml:"customFormat,attr,omitempty"`
+	Ht           *float64    `xml:"ht,attr"`
+	Hidden       bool        `xml:"hidden,attr,omitempty"`
+	CustomHeight bool        `xml:"customHeight,attr,omitempty"`
+	OutlineLevel uint8       `xml:"outlineLevel,attr,omitempty"`
+	Collapsed    bool        `xml:"collapsed,attr,omitempty"`
+	ThickTop     bool        `xml:"thickTop,attr,omitempty"`
+	ThickBot     bool        `xml:"thickBot,attr,omitempty"`
+	Ph           bool        `xml:"ph,attr,omitempty"`
+	ExtLst       *xlsxExtLst `xml:"extLst"`
 }
 
 // xlsxSortState directly maps the sortState element. This collection
@@ -368,6 +371,29 @@ type xlsxBreaks struct {
 	ManualBreakCount int        `xml:"manualBreakCount,attr,omitempty"`
 }
 
+// xlsxIgnoredErrors directly maps the ignoredErrors element, which specifies
+// the cell ranges that the spreadsheet application should ignore the errors
+// of the particular types.
+type xlsxIgnoredErrors struct {
+	XMLName      xml.Name           `xml:"ignoredErrors"`
+	IgnoredError []xlsxIgnoredError `xml:"ignoredError"`
+	ExtLst       *xlsxExtLst        `xml:"extLst"`
+}
+
+// xlsxIgnoredError directly maps the ignoredError element.
+type xlsxIgnoredError struct {
+	Sqref              string `xml:"sqref,attr"`
+	EvalError          bool   `xml:"evalError,attr,omitempty"`
+	TwoDigitTextYear   bool   `xml:"twoDigitTextYear,attr,omitempty"`
+	NumberStoredAsText bool   `xml:"numberStoredAsText,attr,omitempty"`
+	Formula            bool   `xml:"formula,attr,omitempty"`
+	FormulaRange       bool   `xml:"formulaRange,attr,omitempty"`
+	UnlockedFormula    bool   `xml:"unlockedFormula,attr,omitempty"`
+	EmptyCellReference bool   `xml:"emptyCellReference,attr,omitempty"`
+	ListDataValidation bool   `xml:"listDataValidation,attr,omitempty"`
+	CalculatedColumn   bool   `xml:"calculatedColumn,attr,omitempty"`
+}
+
 // xlsxCustomSheetView directly maps the customSheetView element.
 type xlsxCustomSheetView struct {
 	Pane           *xlsxPane         `xml:"pane"`
@@ -466,17 +492,18 @@ type DataValidation struct {
 //	 s (Shared String)         | Cell containing a shared string.
 //	 str (String)              | Cell containing a formula string.
 type xlsxC struct {
-	XMLName  xml.Name `xml:"c"`
-	XMLSpace xml.Attr `xml:"space,attr,omitempty"`
-	R        string   `xml:"r,attr,omitempty"` // Cell ID, e.g. A1
-	S        int      `xml:"s,attr,omitempty"` // Style reference
-	T        string   `xml:"t,attr,omitempty"` // Type
-	Cm       *uint    `xml:"cm,attr"`
-	Vm       *uint    `xml:"vm,attr"`
-	Ph       *bool    `xml:"ph,attr"`
-	F        *xlsxF   `xml:"f"`           // Formula
-	V        string   `xml:"v,omitempty"` // Value
-	IS       *xlsxSI  `xml:"is"`
+	XMLName  xml.Name    `xml:"c"`
+	XMLSpace xml.Attr    `xml:"space,attr,omitempty"`
+	R        string      `xml:"r,attr,omitempty"` // Cell ID, e.g. A1
+	S        int         `xml:"s,attr,omitempty"` // Style reference
+	T        string      `xml:"t,attr,omitempty"` // Type
+	Cm       *uint       `xml:"cm,attr"`
+	Vm       *uint       `xml:"vm,attr"`
+	Ph       *bool       `xml:"ph,attr"`
+	F        *xlsxF      `xml:"f"`           // Formula
+	V        string      `xml:"v,omitempty"` // Value
+	IS       *xlsxSI     `xml:"is"`
+	ExtLst   *xlsxExtLst `xml:"extLst"`
 }
 
 // xlsxF represents a formula for the cell. The formula expression is
@@ -547,6 +574,7 @@ type xlsxConditionalFormatting struct {
 	Pivot   bool          `xml:"pivot,attr,omitempty"`
 	SQRef   string        `xml:"sqref,attr,omitempty"`
 	CfRule  []*xlsxCfRule `xml:"cfRule"`
+	ExtLst  *xlsxExtLst   `xml:"extLst"`
 }
 
 // xlsxCfRule (Conditional Formatting Rule) represents a description of a
@@ -708,6 +736,37 @@ type xlsxAlternateContent struct {
 	Content string `xml:",innerxml"`
 }
 
+// xlsxOleObject directly maps the oleObject element. This element embeds an OLE
+// object in the worksheet, the shapeId attribute references to the VML shape
+// in the legacy drawing part which displays the object.
+type xlsxOleObject struct {
+	XMLName  xml.Name      `xml:"oleObject"`
+	ProgID   string        `xml:"progId,attr,omitempty"`
+	DvAspect string        `xml:"dvAspect,attr,omitempty"`
+	ShapeID  int           `xml:"shapeId,attr"`
+	RID      string        `xml:"r:id,attr,omitempty"`
+	ObjectPr *xlsxObjectPr `xml:"objectPr"`
+}
+
+// xlsxObjectPr directly maps the objectPr element. This element specifies the
+// properties and the anchor of the embedded OLE object, the r:id attribute
+// references to the image which displays the object.
+type xlsxObjectPr struct {
+	DefaultSize *bool             `xml:"defaultSize,attr"`
+	AutoPict    *bool             `xml:"autoPict,attr"`
+	RID         string            `xml:"r:id,attr,omitempty"`
+	Anchor      *xlsxObjectAnchor `xml:"anchor"`
+}
+
+// xlsxObjectAnchor directly maps the anchor element of the OLE object
+// properties.
+type xlsxObjectAnchor struct {
+	MoveWithCells bool     `xml:"moveWithCells,attr,omitempty"`
+	SizeWithCells bool     `xml:"sizeWithCells,attr,omitempty"`
+	From          xlsxFrom `xml:"from"`
+	To            xlsxTo   `xml:"to"`
+}
+
 // xlsxInnerXML holds parts of XML content currently not unmarshal.
 type xlsxInnerXML struct {
 	Content string `xml:",innerxml"`
@@ -914,30 +973,33 @@ type Panes struct {
 
 // ConditionalFormatOptions directly maps the conditional format settings of the cells.
 type ConditionalFormatOptions struct {
-	Type           string
-	AboveAverage   bool
-	Percent        bool
-	Format         int
-	Criteria       string
-	Value          string
-	MinType        string
-	MidType        string
-	MaxType        string
-	MinValue       string
-	MidValue       string
-	MaxValue       string
-	MinColor       string
-	MidColor       string
-	MaxColor       string
-	BarColor       string
-	BarBorderColor string
-	BarDirection   string
-	BarOnly        bool
-	BarSolid       bool
-	IconStyle      string
-	ReverseIcons   bool
-	IconsOnly      bool
-	StopIfTrue     bool
+	Type           string `json:"type,omitempty"`
+	AboveAverage   bool   `json:"aboveAverage,omitempty"`
+	Percent        bool   `json:"percent,omitempty"`
+	StdDev         int    `json:"stdDev,omitempty"`
+	EqualAverage   bool   `json:"equalAverage,omitempty"`
+	Format         int    `json:"format,omitempty"`
+	Criteria       string `json:"criteria,omitempty"`
+	Value          string `json:"value,omitempty"`
+	MinType        string `json:"minType,omitempty"`
+	MidType        string `json:"midType,omitempty"`
+	MaxType        string `json:"maxType,omitempty"`
+	MinValue       string `json:"minValue,omitempty"`
+	MidValue       string `json:"midValue,omitempty"`
+	MaxValue       string `json:"maxValue,omitempty"`
+	MinColor       string `json:"minColor,omitempty"`
+	MidColor       string `json:"midColor,omitempty"`
+	MaxColor       string `json:"maxColor,omitempty"`
+	BarColor       string `json:"barColor,omitempty"`
+	BarBorderColor string `json:"barBorderColor,omitempty"`
+	BarDirection   string `json:"barDirection,omitempty"`
+	BarOnly        bool   `json:"barOnly,omitempty"`
+	BarSolid       bool   `json:"barSolid,omitempty"`
+	IconStyle      string `json:"iconStyle,omitempty"`
+	ReverseIcons   bool   `json:"reverseIcons,omitempty"`
+	IconsOnly      bool   `json:"iconsOnly,omitempty"`
+	StopIfTrue     bool   `json:"stopIfTrue,omitempty"`
+	Priority       int    `json:"priority,omitempty"`
 }
 
 // SheetProtectionOptions directly maps the settings of worksheet protection.
@@ -963,16 +1025,42 @@ type SheetProtectionOptions struct {
 
 // HeaderFooterOptions directly maps the settings of header and footer.
 type HeaderFooterOptions struct {
-	AlignWithMargins bool
-	DifferentFirst   bool
-	DifferentOddEven bool
-	ScaleWithDoc     bool
-	OddHeader        string
-	OddFooter        string
-	EvenHeader       string
-	EvenFooter       string
-	FirstHeader      string
-	FirstFooter      string
+	AlignWithMargins    bool
+	DifferentFirst      bool
+	DifferentOddEven    bool
+	ScaleWithDoc        bool
+	OddHeader           string
+	OddFooter           string
+	EvenHeader          string
+	EvenFooter          string
+	FirstHeader         string
+	FirstFooter         string
+	OddHeaderSegments   *HeaderFooterSegments
+	OddFooterSegments   *HeaderFooterSegments
+	EvenHeaderSegments  *HeaderFooterSegments
+	EvenFooterSegments  *HeaderFooterSegments
+	FirstHeaderSegments *HeaderFooterSegments
+	FirstFooterSegments *HeaderFooterSegments
+}
+
+// HeaderFooterSegments directly maps the left, center and right sections of
+// a header or footer.
+type HeaderFooterSegments struct {
+	Left   []HeaderFooterRun
+	Center []HeaderFooterRun
+	Right  []HeaderFooterRun
+}
+
+// HeaderFooterRun directly maps a text run or a field in the section of a
+// header or footer. The Field specifies the field to be inserted instead of
+// the text, and the optional value is one of "page", "pages", "date", "time",
+// "file_name", "file_path", "sheet_name" and "picture". The Bold, Italic,
+// Underline, Family, Size, Strike, Color and VertAlign settings of the Font
+// are supported.
+type HeaderFooterRun struct {
+	Text  string
+	Field string
+	Font  *Font
 }
 
 // PageLayoutMarginsOptions directly maps the settings of page layout margins.
@@ -987,9 +1075,19 @@ type PageLayoutMarginsOptions struct {
 	Vertically   *bool
 }
 
+// PrintOptions directly maps the print settings of the worksheet, which are
+// controlled independently from the display settings of the sheet view.
+type PrintOptions struct {
+	// GridLines specifies if print the gridlines.
+	GridLines *bool
+	// Headings specifies if print the row and column headings.
+	Headings *bool
+}
+
 // PageLayoutOptions directly maps the settings of page layout.
 type PageLayoutOptions struct {
-	// Size defines the paper size of the worksheet.
+	// Size defines the paper size of the worksheet, the default value is 1
+	// (Letter).
 	Size *int
 	// Orientation defines the orientation of page layout for a worksheet.
 	Orientation *string
@@ -998,14 +1096,21 @@ type PageLayoutOptions struct {
 	FirstPageNumber *uint
 	// AdjustTo defines the print scaling. This attribute is restricted to
 	// value ranging from 10 (10%) to 400 (400%). This setting is overridden
-	// when fitToWidth and/or fitToHeight are in use.
+	// when fitToWidth and/or fitToHeight are in use, set this without the
+	// FitToHeight and FitToWidth will disable the fit to page print option.
 	AdjustTo *uint
-	// FitToHeight specified the number of vertical pages to fit on.
+	// FitToHeight specified the number of vertical pages to fit on, the
+	// value 0 means as many pages as needed. Set this or FitToWidth will
+	// enable the fit to page print option.
 	FitToHeight *int
-	// FitToWidth specified the number of horizontal pages to fit on.
+	// FitToWidth specified the number of horizontal pages to fit on, the
+	// value 0 means as many pages as needed.
 	FitToWidth *int
 	// BlackAndWhite specified print black and white.
 	BlackAndWhite *bool
+	// Margins specified the page margins, including the header and footer
+	// margins.
+	Margins *PageLayoutMarginsOptions
 }
 
 // ViewOptions directly maps the settings of sheet view.
@@ -1046,6 +1151,142 @@ type ViewOptions struct {
 	ZoomScale *float64
 }
 
+// AutoFitColumnOptions directly maps the settings of auto fit column width.
+type AutoFitColumnOptions struct {
+	// MaxWidth specifies the maximum width of the column measured as the
+	// number of characters, the width will not be capped by default except
+	// the maximum column width 255.
+	MaxWidth float64
+	// IgnoreMergeCells specifies if ignore the cells in the merged range
+	// which spanning multiple columns on measuring the width of the column.
+	IgnoreMergeCells bool
+}
+
+// IgnoredErrorType is the type of the errors which should be ignored by the
+// spreadsheet application.
+type IgnoredErrorType byte
+
+// Ignored error types enumeration.
+const (
+	IgnoredErrorEvalError IgnoredErrorType = iota
+	IgnoredErrorTwoDigitTextYear
+	IgnoredErrorNumberStoredAsText
+	IgnoredErrorFormula
+	IgnoredErrorFormulaRange
+	IgnoredErrorUnlockedFormula
+	IgnoredErrorEmptyCellReference
+	IgnoredErrorListDataValidation
+	IgnoredErrorCalculatedColumn
+)
+
+// IgnoredErrorsOptions directly maps the settings of the ignored errors, the
+// Ref is the space separated cell or range references sequence.
+type IgnoredErrorsOptions struct {
+	Ref   string
+	Types []IgnoredErrorType
+}
+
+// PageBreak directly maps the settings of a row or column page break. The
+// Number is the row or column number which the next printed page begins with,
+// and the Manual indicating the page break was inserted manually.
+type PageBreak struct {
+	Number int
+	Manual bool
+}
+
+// PageBreaks directly maps the row and column page breaks of a worksheet.
+type PageBreaks struct {
+	Rows    []PageBreak
+	Columns []PageBreak
+}
+
+// ColStyleOptions directly maps the settings of setting column style.
+type ColStyleOptions struct {
+	// Cascade specifies if apply the style to the existing cells in the
+	// columns, the default value is true. If set to false, only the cells
+	// created in the columns afterwards and the existing cells without style
+	// will inherit the column style, the existing cells with their own style
+	// will be kept.
+	Cascade *bool
+}
+
+// ColOpts directly maps the settings of a column definition of the worksheet,
+// which applies to the columns from StartCol to EndCol. The Width is measured
+// as the number of characters, and it will be 0 if the width of the columns
+// was not specified.
+type ColOpts struct {
+	StartCol     string
+	EndCol       string
+	Width        float64
+	CustomWidth  bool
+	Hidden       bool
+	StyleID      int
+	OutlineLevel uint8
+	Collapsed    bool
+}
+
+// CellValueOptions directly maps the settings of getting the cell value by the
+// GetCellValueWithMeta function.
+type CellValueOptions struct {
+	// RawCellValue specifies if get the raw value of the cell without
+	// applying the number format.
+	RawCellValue bool
+	// Recalculate specifies if calculate the formula cell by the formula
+	// engine instead of returning the cached result, the cached result will
+	// be returned if the formula engine can't evaluate the formula.
+	Recalculate bool
+	// ErrorValue specifies the text to represent the error values, such as
+	// "#DIV/0!" and "#N/A", the error values will be returned as is if it's
+	// empty.
+	ErrorValue string
+}
+
+// CellValueMeta directly maps the metadata of the cell value returned by the
+// GetCellValueWithMeta function.
+type CellValueMeta struct {
+	// Type specifies the data type of the cell value stored in the
+	// worksheet.
+	Type CellType
+	// Formula specifies the formula of the cell, it's empty if the cell
+	// doesn't have a formula.
+	Formula string
+	// Error specifies the error value of the cell, such as "#DIV/0!", it's
+	// empty if the cell value isn't an error value.
+	Error string
+	// HyperlinkType specifies the type of the hyperlink of the cell, it's
+	// "External" or "Location", and empty if the cell doesn't have a
+	// hyperlink.
+	HyperlinkType string
+	// HyperlinkTarget specifies the link address of the hyperlink of the
+	// cell.
+	HyperlinkTarget string
+}
+
+// CopyOptions directly maps the settings of copying a range of cells.
+type CopyOptions struct {
+	// ValuesOnly specifies if only copy the values of the cells, the formula
+	// cells will be copied as their cached values, and the styles and merged
+	// cells will not be copied. The values, formulas, styles and merged cells
+	// will be copied by default.
+	ValuesOnly bool
+	// Transpose specifies if write the range rotated, the rows of the source
+	// range become the columns of the destination range. The formula cells
+	// will be copied as their cached values on transposing, unless the
+	// TransposeFormulas was set.
+	Transpose bool
+	// TransposeFormulas specifies if copy the formulas on transposing, the
+	// references to the cells inside the source range will be transposed,
+	// and the other relative references will be translated by the offset of
+	// each cell.
+	TransposeFormulas bool
+}
+
+// CopySheetOptions directly maps the settings of copying worksheet across
+// workbooks.
+type CopySheetOptions struct {
+	FormulasToValues bool
+}
+
 // SheetPropsOptions directly maps the settings of sheet view.
 type SheetPropsOptions struct {
 	// Specifies a stable name of the sheet, which should not change over time,